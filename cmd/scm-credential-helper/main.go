@@ -0,0 +1,74 @@
+// Command scm-credential-helper is a git credential helper returning
+// the credentials configured for the factory package, so the git
+// operations and the API operations share the same credentials:
+//
+//	git config --global credential.helper /path/to/scm-credential-helper
+//
+// The credentials are configured from the environment, see
+// factory.GitCredentialFromEnvironment. Only the get action is
+// implemented: the credentials are not stored by git.
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm/factory"
+)
+
+// lookupFunc returns the credential of the host, or nil.
+type lookupFunc func(ctx context.Context, host string) (*factory.GitCredential, error)
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdin, os.Stdout, factory.GitCredentialFromEnvironment); err != nil {
+		fmt.Fprintf(os.Stderr, "scm-credential-helper: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// run implements the action of the git credential protocol, see
+// https://git-scm.com/docs/git-credential.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, lookup lookupFunc) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: scm-credential-helper get|store|erase")
+	}
+	attrs, err := readAttributes(stdin)
+	if err != nil {
+		return err
+	}
+	// the store and erase actions, and the future
+	// actions, are ignored.
+	if args[0] != "get" {
+		return nil
+	}
+	if protocol := attrs["protocol"]; protocol != "https" && protocol != "http" {
+		return nil
+	}
+	cred, err := lookup(ctx, attrs["host"])
+	if err != nil || cred == nil {
+		return err
+	}
+	_, err = fmt.Fprintf(stdout, "username=%s\npassword=%s\n", cred.Username, cred.Password)
+	return err
+}
+
+// readAttributes reads the key=value lines sent by git, up
+// to a blank line or the end of the input.
+func readAttributes(r io.Reader) (map[string]string, error) {
+	attrs := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		if i := strings.Index(line, "="); i > 0 {
+			attrs[line[:i]] = line[i+1:]
+		}
+	}
+	return attrs, scanner.Err()
+}
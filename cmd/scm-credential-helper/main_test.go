@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/slimm609/go-scm/scm/factory"
+)
+
+func TestRun(t *testing.T) {
+	lookup := func(ctx context.Context, host string) (*factory.GitCredential, error) {
+		if host != "github.com" {
+			return nil, nil
+		}
+		return &factory.GitCredential{Username: "x-access-token", Password: "ghs_token"}, nil
+	}
+	tests := []struct {
+		action, input, want string
+	}{
+		{"get", "protocol=https\nhost=github.com\npath=octocat/hello-world.git\n\n", "username=x-access-token\npassword=ghs_token\n"},
+		{"get", "protocol=https\nhost=gitlab.com\n", ""},
+		{"get", "protocol=ssh\nhost=github.com\n", ""},
+		{"store", "protocol=https\nhost=github.com\nusername=octocat\npassword=secret\n", ""},
+		{"erase", "protocol=https\nhost=github.com\n", ""},
+	}
+	for _, test := range tests {
+		stdout := new(bytes.Buffer)
+		if err := run(context.Background(), []string{test.action}, strings.NewReader(test.input), stdout, lookup); err != nil {
+			t.Errorf("%s: %s", test.action, err)
+		}
+		if got := stdout.String(); got != test.want {
+			t.Errorf("%s: want output %q, got %q", test.action, test.want, got)
+		}
+	}
+}
+
+func TestRun_Error(t *testing.T) {
+	failure := errors.New("No Git OAuth token specified")
+	lookup := func(ctx context.Context, host string) (*factory.GitCredential, error) {
+		return nil, failure
+	}
+	err := run(context.Background(), []string{"get"}, strings.NewReader("protocol=https\nhost=github.com\n"), new(bytes.Buffer), lookup)
+	if err != failure {
+		t.Errorf("Want the lookup error, got %v", err)
+	}
+}
@@ -0,0 +1,314 @@
+// Command scm exposes common source code management operations
+// for any provider supported by the factory package, so they can
+// be scripted uniformly.
+//
+// The client is configured from the environment, see
+// factory.NewClientFromEnvironment:
+//
+//	GIT_KIND=github GIT_TOKEN=... scm pr get octocat/hello-world 1
+//
+// Results are written to stdout as JSON.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/factory"
+)
+
+// command is a CLI sub command.
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, env *environment, args []string) error
+}
+
+// environment holds the state shared by the commands.
+type environment struct {
+	stdin  io.Reader
+	stdout io.Writer
+
+	// newClient returns the client for the commands that
+	// talk to the provider API.
+	newClient func() (*scm.Client, error)
+}
+
+var commands []*command
+
+func init() {
+	commands = []*command{
+		{"pr get", "pr get <repo> <number>", runPullRequestGet},
+		{"pr create", "pr create [-body text] <repo> <title> <head> <base>", runPullRequestCreate},
+		{"comment", "comment <repo> <number> <body>", runComment},
+		{"status", "status [-label name] [-desc text] [-target url] <repo> <ref> <state>", runStatus},
+		{"repos list", "repos list [-page n] [-size n]", runReposList},
+		{"file", "file [-ref ref] <repo> <path>", runFile},
+		{"parse-webhook", "parse-webhook [-kind driver] [-secret secret] [-H 'Name: value']... < request", runParseWebhook},
+	}
+}
+
+func main() {
+	env := &environment{
+		stdin:     os.Stdin,
+		stdout:    os.Stdout,
+		newClient: factory.NewClientFromEnvironment,
+	}
+	if err := run(context.Background(), env, os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "scm: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// run dispatches the arguments to the matching command.
+func run(ctx context.Context, env *environment, args []string) error {
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) >= len(words) && strings.Join(args[:len(words)], " ") == cmd.name {
+			return cmd.run(ctx, env, args[len(words):])
+		}
+	}
+	var usage bytes.Buffer
+	usage.WriteString("usage:")
+	for _, cmd := range commands {
+		usage.WriteString("\n  scm " + cmd.usage)
+	}
+	return errors.New(usage.String())
+}
+
+func runPullRequestGet(ctx context.Context, env *environment, args []string) error {
+	if len(args) != 2 {
+		return errUsage("pr get")
+	}
+	number, err := strconv.Atoi(args[1])
+	if err != nil {
+		return err
+	}
+	client, err := env.newClient()
+	if err != nil {
+		return err
+	}
+	pr, _, err := client.PullRequests.Find(ctx, args[0], number)
+	if err != nil {
+		return err
+	}
+	return env.print(pr)
+}
+
+func runPullRequestCreate(ctx context.Context, env *environment, args []string) error {
+	flags := flag.NewFlagSet("pr create", flag.ContinueOnError)
+	body := flags.String("body", "", "the pull request description")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 4 {
+		return errUsage("pr create")
+	}
+	client, err := env.newClient()
+	if err != nil {
+		return err
+	}
+	pr, _, err := client.PullRequests.Create(ctx, flags.Arg(0), &scm.PullRequestInput{
+		Title: flags.Arg(1),
+		Head:  flags.Arg(2),
+		Base:  flags.Arg(3),
+		Body:  *body,
+	})
+	if err != nil {
+		return err
+	}
+	return env.print(pr)
+}
+
+func runComment(ctx context.Context, env *environment, args []string) error {
+	if len(args) != 3 {
+		return errUsage("comment")
+	}
+	number, err := strconv.Atoi(args[1])
+	if err != nil {
+		return err
+	}
+	client, err := env.newClient()
+	if err != nil {
+		return err
+	}
+	comment, _, err := client.Issues.CreateComment(ctx, args[0], number, &scm.CommentInput{Body: args[2]})
+	if err != nil {
+		return err
+	}
+	return env.print(comment)
+}
+
+func runStatus(ctx context.Context, env *environment, args []string) error {
+	flags := flag.NewFlagSet("status", flag.ContinueOnError)
+	label := flags.String("label", "", "the status context")
+	desc := flags.String("desc", "", "the status description")
+	target := flags.String("target", "", "the status target URL")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 3 {
+		return errUsage("status")
+	}
+	state := scm.ToState(flags.Arg(2))
+	if state == scm.StateUnknown {
+		return fmt.Errorf("unknown state %q", flags.Arg(2))
+	}
+	client, err := env.newClient()
+	if err != nil {
+		return err
+	}
+	status, _, err := client.Repositories.CreateStatus(ctx, flags.Arg(0), flags.Arg(1), &scm.StatusInput{
+		State:  state,
+		Label:  *label,
+		Desc:   *desc,
+		Target: *target,
+	})
+	if err != nil {
+		return err
+	}
+	return env.print(status)
+}
+
+func runReposList(ctx context.Context, env *environment, args []string) error {
+	flags := flag.NewFlagSet("repos list", flag.ContinueOnError)
+	page := flags.Int("page", 1, "the page to list")
+	size := flags.Int("size", 100, "the page size")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return errUsage("repos list")
+	}
+	client, err := env.newClient()
+	if err != nil {
+		return err
+	}
+	repos, _, err := client.Repositories.List(ctx, scm.ListOptions{Page: *page, Size: *size})
+	if err != nil {
+		return err
+	}
+	return env.print(repos)
+}
+
+func runFile(ctx context.Context, env *environment, args []string) error {
+	flags := flag.NewFlagSet("file", flag.ContinueOnError)
+	ref := flags.String("ref", "master", "the branch, tag or commit to read the file at")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return errUsage("file")
+	}
+	client, err := env.newClient()
+	if err != nil {
+		return err
+	}
+	content, _, err := client.Contents.Find(ctx, flags.Arg(0), flags.Arg(1), *ref)
+	if err != nil {
+		return err
+	}
+	// the file is downloaded as is rather than as JSON so
+	// it can be redirected to a file.
+	_, err = env.stdout.Write(content.Data)
+	return err
+}
+
+// headerFlags collects repeated -H flags.
+type headerFlags http.Header
+
+func (h headerFlags) String() string { return "" }
+
+func (h headerFlags) Set(value string) error {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid header %q", value)
+	}
+	http.Header(h).Add(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+	return nil
+}
+
+func runParseWebhook(ctx context.Context, env *environment, args []string) error {
+	header := headerFlags{}
+	flags := flag.NewFlagSet("parse-webhook", flag.ContinueOnError)
+	kind := flags.String("kind", os.Getenv("GIT_KIND"), "the driver that sent the webhook")
+	secret := flags.String("secret", "", "the webhook secret used to validate the signature")
+	flags.Var(header, "H", "a request header, may be repeated")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return errUsage("parse-webhook")
+	}
+	service, err := factory.NewWebHookService(*kind)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return fmt.Errorf("driver %q does not support webhooks", *kind)
+	}
+	req, err := readRequest(env.stdin, http.Header(header))
+	if err != nil {
+		return err
+	}
+	hook, err := service.Parse(req, func(scm.Webhook) (string, error) {
+		return *secret, nil
+	})
+	if err != nil {
+		return err
+	}
+	return env.print(struct {
+		Kind scm.WebhookKind
+		Hook scm.Webhook
+	}{hook.Kind(), hook})
+}
+
+// readRequest reads the webhook request from r. The input is
+// either a raw HTTP request, as captured by a proxy, or the
+// request body, in which case the headers are taken from the
+// command line.
+func readRequest(r io.Reader, header http.Header) (*http.Request, error) {
+	buf := bufio.NewReader(r)
+	line, _ := buf.Peek(5)
+	if string(line) == "POST " {
+		return http.ReadRequest(buf)
+	}
+	body, err := ioutil.ReadAll(buf)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", "/", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = header
+	return req, nil
+}
+
+// print writes v to stdout as indented JSON.
+func (env *environment) print(v interface{}) error {
+	enc := json.NewEncoder(env.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func errUsage(name string) error {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return fmt.Errorf("usage: scm %s", cmd.usage)
+		}
+	}
+	return fmt.Errorf("unknown command %q", name)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/fake"
+)
+
+func newTestEnvironment(stdin string) (*environment, *fake.Data, *bytes.Buffer) {
+	client, data := fake.NewDefault()
+	stdout := new(bytes.Buffer)
+	return &environment{
+		stdin:     strings.NewReader(stdin),
+		stdout:    stdout,
+		newClient: func() (*scm.Client, error) { return client, nil },
+	}, data, stdout
+}
+
+func TestPullRequestGet(t *testing.T) {
+	env, data, stdout := newTestEnvironment("")
+	data.PullRequests[1] = &scm.PullRequest{Number: 1, Title: "Update README"}
+
+	if err := run(context.Background(), env, []string{"pr", "get", "foo/repo", "1"}); err != nil {
+		t.Fatal(err)
+	}
+	out := new(scm.PullRequest)
+	if err := json.Unmarshal(stdout.Bytes(), out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Title != "Update README" {
+		t.Errorf("Want pull request title %q, got %q", "Update README", out.Title)
+	}
+}
+
+func TestComment(t *testing.T) {
+	env, data, _ := newTestEnvironment("")
+	if err := run(context.Background(), env, []string{"comment", "foo/repo", "1", "LGTM"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := data.IssueCommentsAdded, []string{"foo/repo#1:LGTM"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("Want comments %v, got %v", want, got)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	env, data, _ := newTestEnvironment("")
+	args := []string{"status", "-label", "ci", "foo/repo", "abcde", "success"}
+	if err := run(context.Background(), env, args); err != nil {
+		t.Fatal(err)
+	}
+	statuses := data.Statuses["abcde"]
+	if len(statuses) != 1 || statuses[0].Label != "ci" || statuses[0].State != scm.StateSuccess {
+		t.Errorf("Unexpected statuses %+v", statuses)
+	}
+
+	args = []string{"status", "foo/repo", "abcde", "sideways"}
+	if err := run(context.Background(), env, args); err == nil {
+		t.Errorf("Expect error for an unknown state")
+	}
+}
+
+func TestParseWebhook(t *testing.T) {
+	body, err := ioutil.ReadFile("../../scm/driver/github/testdata/webhooks/push.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, _, stdout := newTestEnvironment(string(body))
+	args := []string{"parse-webhook", "-kind", "github", "-H", "X-GitHub-Event: push", "-H", "X-GitHub-Delivery: 1"}
+	if err := run(context.Background(), env, args); err != nil {
+		t.Fatal(err)
+	}
+	out := struct {
+		Kind scm.WebhookKind
+		Hook *scm.PushHook
+	}{}
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Kind != scm.WebhookKindPush || out.Hook.Ref == "" {
+		t.Errorf("Unexpected webhook %+v", out)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	env, _, _ := newTestEnvironment("")
+	err := run(context.Background(), env, []string{"unknown"})
+	if err == nil || !strings.Contains(err.Error(), "scm pr get") {
+		t.Errorf("Expect usage error, got %v", err)
+	}
+}
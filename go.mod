@@ -4,6 +4,7 @@ require (
 	code.gitea.io/sdk/gitea v0.13.0
 	github.com/bluekeyes/go-gitdiff v0.4.0
 	github.com/ghodss/yaml v1.0.0
+	github.com/go-git/go-git/v5 v5.2.0
 	github.com/google/go-cmp v0.3.0
 	github.com/h2non/gock v1.0.9
 	github.com/mitchellh/copystructure v1.0.0
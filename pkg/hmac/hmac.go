@@ -5,18 +5,35 @@
 package hmac
 
 import (
+	"crypto/ed25519"
 	"crypto/hmac"
 	"crypto/sha1" // #nosec
 	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"hash"
 	"strings"
 )
 
+// ErrUnsupportedAlgorithm is returned when signing with an
+// unknown algorithm prefix.
+var ErrUnsupportedAlgorithm = errors.New("hmac: unsupported algorithm")
+
+// algorithms maps the signature prefixes to the hash
+// functions used to compute them.
+var algorithms = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
 // Validate checks the hmac signature of the mssasge
 // using a hex encoded signature.
 func Validate(h func() hash.Hash, message, key []byte, signature string) bool {
-	decoded, err := hex.DecodeString(signature)
+	decoded, err := hex.DecodeString(strings.TrimSpace(signature))
 	if err != nil {
 		return false
 	}
@@ -25,24 +42,64 @@ func Validate(h func() hash.Hash, message, key []byte, signature string) bool {
 
 // ValidatePrefix checks the hmac signature of the message
 // using the message prefix to determine the signing algorithm.
+// The prefix is case insensitive, and sha1, sha256 and sha512
+// are supported.
 func ValidatePrefix(message, key []byte, signature string) bool {
-	parts := strings.Split(signature, "=")
+	parts := strings.SplitN(strings.TrimSpace(signature), "=", 2)
 	if len(parts) != 2 {
 		return false
 	}
-	switch parts[0] {
-	case "sha1":
-		return Validate(sha1.New, message, key, parts[1])
-	case "sha256":
-		return Validate(sha256.New, message, key, parts[1])
-	default:
+	h, ok := algorithms[strings.ToLower(parts[0])]
+	if !ok {
+		return false
+	}
+	return Validate(h, message, key, parts[1])
+}
+
+// Sign returns the hex encoded hmac signature of the message.
+func Sign(h func() hash.Hash, message, key []byte) string {
+	return hex.EncodeToString(sum(h, message, key))
+}
+
+// SignPrefix returns the hex encoded hmac signature of the
+// message prefixed with the algorithm (e.g. sha256=...), in
+// the form accepted by ValidatePrefix.
+func SignPrefix(algorithm string, message, key []byte) (string, error) {
+	algorithm = strings.ToLower(algorithm)
+	h, ok := algorithms[algorithm]
+	if !ok {
+		return "", ErrUnsupportedAlgorithm
+	}
+	return algorithm + "=" + Sign(h, message, key), nil
+}
+
+// ValidateEd25519 checks the base64 encoded Ed25519 signature
+// of the message using the public key.
+func ValidateEd25519(message []byte, key ed25519.PublicKey, signature string) bool {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(signature))
+	if err != nil || len(key) != ed25519.PublicKeySize {
 		return false
 	}
+	return ed25519.Verify(key, message, decoded)
+}
+
+// SignEd25519 returns the base64 encoded Ed25519 signature of
+// the message, in the form accepted by ValidateEd25519.
+func SignEd25519(message []byte, key ed25519.PrivateKey) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(key, message))
+}
+
+// Equal compares the shared secret tokens in constant time.
+func Equal(token, secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
 }
 
 func validate(h func() hash.Hash, message, key, signature []byte) bool {
+	return hmac.Equal(signature, sum(h, message, key))
+}
+
+func sum(h func() hash.Hash, message, key []byte) []byte {
 	mac := hmac.New(h, key)
 	mac.Write(message) // #nosec
-	sum := mac.Sum(nil)
-	return hmac.Equal(signature, sum)
+	return mac.Sum(nil)
 }
@@ -5,6 +5,7 @@
 package hmac
 
 import (
+	"crypto/ed25519"
 	"crypto/md5"
 	"crypto/sha1"
 	"crypto/sha256"
@@ -50,6 +51,24 @@ func TestValidatePrefix(t *testing.T) {
 			res: true,
 		},
 		//
+		// sha512
+		//
+		{
+			msg: "hello world",
+			key: "topsecret",
+			sig: "sha512=30040f4a2cc7b94e97311452471f0ab03a626784158e4f90cbb607f72f616f2e2c9e48dfc2f368284d382c32d15b49f64db3050c902501231f24c10a42a45b40",
+			res: true,
+		},
+		//
+		// prefix is case insensitive
+		//
+		{
+			msg: "hello world",
+			key: "topsecret",
+			sig: "SHA1=f25bad540601ff3131736e24a48dd928fa9ccc93",
+			res: true,
+		},
+		//
 		// algorithm not supported
 		//
 		{
@@ -166,3 +185,52 @@ func TestValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestSignPrefix(t *testing.T) {
+	for _, alg := range []string{"sha1", "sha256", "sha512"} {
+		sig, err := SignPrefix(alg, []byte("hello world"), []byte("topsecret"))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !ValidatePrefix([]byte("hello world"), []byte("topsecret"), sig) {
+			t.Errorf("Want signature %q valid", sig)
+		}
+	}
+	if _, err := SignPrefix("md5", []byte("hello world"), []byte("topsecret")); err != ErrUnsupportedAlgorithm {
+		t.Errorf("Want unsupported algorithm error, got %v", err)
+	}
+}
+
+func TestSign(t *testing.T) {
+	got := Sign(sha1.New, []byte("hello world"), []byte("topsecret"))
+	if want := "f25bad540601ff3131736e24a48dd928fa9ccc93"; got != want {
+		t.Errorf("Want signature %q, got %q", want, got)
+	}
+}
+
+func TestValidateEd25519(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := SignEd25519([]byte("hello world"), private)
+	if !ValidateEd25519([]byte("hello world"), public, sig) {
+		t.Errorf("Want valid signature")
+	}
+	if ValidateEd25519([]byte("bonjour monde"), public, sig) {
+		t.Errorf("Want invalid signature for a different message")
+	}
+	if ValidateEd25519([]byte("hello world"), public, "not base64!") {
+		t.Errorf("Want invalid signature for malformed input")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	if !Equal("topsecret", "topsecret") {
+		t.Errorf("Want equal tokens")
+	}
+	if Equal("topsecret", "void") || Equal("", "topsecret") {
+		t.Errorf("Want unequal tokens")
+	}
+}
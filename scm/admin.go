@@ -0,0 +1,53 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// SiteStats represents the statistics of a self-hosted
+	// server. Counts the provider does not report are zero.
+	SiteStats struct {
+		Users         int
+		Organizations int
+		Repositories  int
+		Issues        int
+		PullRequests  int
+	}
+
+	// License represents the license of a self-hosted
+	// server.
+	License struct {
+		Plan      string
+		Licensee  string
+		Seats     int
+		SeatsUsed int
+
+		// Expires is the expiry time of the license, or the
+		// zero time if the license does not expire.
+		Expires time.Time
+	}
+
+	// AdminService provides access to the administration of
+	// self-hosted servers, GitHub Enterprise Server, GitLab
+	// and Gitea, and requires an administrator token.
+	//
+	// The service is optional: the Admin field of the client
+	// is nil for drivers that do not support it.
+	AdminService interface {
+		// Stats returns the statistics of the server.
+		Stats(context.Context) (*SiteStats, *Response, error)
+
+		// License returns the license of the server.
+		License(context.Context) (*License, *Response, error)
+
+		// ListHooks returns the global webhooks of the
+		// server.
+		ListHooks(context.Context, ListOptions) ([]*Hook, *Response, error)
+	}
+)
@@ -0,0 +1,103 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"strings"
+)
+
+// NormalizeAuthorAssociation returns the author association
+// in the upper case form of the AuthorAssociation values.
+func NormalizeAuthorAssociation(association string) string {
+	return strings.ToUpper(strings.TrimSpace(association))
+}
+
+// IsTrustedAuthorAssociation returns true if the author
+// association grants write access to the repository, i.e. the
+// author is the owner, a member or a collaborator.
+func IsTrustedAuthorAssociation(association string) bool {
+	switch NormalizeAuthorAssociation(association) {
+	case AuthorAssociationOwner, AuthorAssociationMember, AuthorAssociationCollaborator:
+		return true
+	}
+	return false
+}
+
+// AuthorAssociationFromPermission returns the author
+// association of a user with the permission on a repository.
+// Users with read access are reported as NONE, since anyone
+// can read a public repository.
+func AuthorAssociationFromPermission(permission string, owner bool) string {
+	switch {
+	case owner:
+		return AuthorAssociationOwner
+	case permission == AdminPermission || permission == WritePermission:
+		return AuthorAssociationCollaborator
+	}
+	return AuthorAssociationNone
+}
+
+// FindAuthorAssociation returns the author association of the
+// user with the repository, derived from the permission of the
+// user for providers which do not report it.
+func FindAuthorAssociation(ctx context.Context, client *Client, repo, login string) (string, *Response, error) {
+	if r, err := ParseRepo(repo); err == nil && strings.EqualFold(r.Namespace, login) {
+		return AuthorAssociationOwner, nil, nil
+	}
+	permission, res, err := client.Repositories.FindUserPermission(ctx, repo, login)
+	if err != nil {
+		return "", res, err
+	}
+	return AuthorAssociationFromPermission(permission, false), res, nil
+}
+
+// PopulateAuthorAssociation sets the author association of
+// the comment and pull request of the webhook when the
+// provider omitted it from the payload, with one API call per
+// author. Command bots can then authorize the author from the
+// hook alone.
+func PopulateAuthorAssociation(ctx context.Context, client *Client, hook Webhook) (*Response, error) {
+	repo := hook.Repository()
+	fullname := repo.FullName
+	if fullname == "" {
+		fullname = Join(repo.Namespace, repo.Name)
+	}
+	cache := map[string]string{}
+	var last *Response
+	populate := func(association *string, author User) error {
+		if *association != "" || author.Login == "" {
+			*association = NormalizeAuthorAssociation(*association)
+			return nil
+		}
+		if v, ok := cache[author.Login]; ok {
+			*association = v
+			return nil
+		}
+		v, res, err := FindAuthorAssociation(ctx, client, fullname, author.Login)
+		last = res
+		if err != nil {
+			return err
+		}
+		cache[author.Login] = v
+		*association = v
+		return nil
+	}
+
+	var err error
+	switch v := hook.(type) {
+	case *IssueCommentHook:
+		err = populate(&v.Comment.AuthorAssociation, v.Comment.Author)
+	case *PullRequestCommentHook:
+		if err = populate(&v.PullRequest.AuthorAssociation, v.PullRequest.Author); err == nil {
+			err = populate(&v.Comment.AuthorAssociation, v.Comment.Author)
+		}
+	case *PullRequestHook:
+		err = populate(&v.PullRequest.AuthorAssociation, v.PullRequest.Author)
+	case *ReviewCommentHook:
+		err = populate(&v.PullRequest.AuthorAssociation, v.PullRequest.Author)
+	}
+	return last, err
+}
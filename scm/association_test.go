@@ -0,0 +1,79 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"testing"
+)
+
+// permissions serves the repository permission of the users.
+type permissions struct {
+	RepositoryService
+	perms map[string]string
+	calls int
+}
+
+func (s *permissions) FindUserPermission(ctx context.Context, repo, user string) (string, *Response, error) {
+	s.calls++
+	return s.perms[user], &Response{}, nil
+}
+
+func TestPopulateAuthorAssociation(t *testing.T) {
+	service := &permissions{perms: map[string]string{"jcitizen": WritePermission, "octocat": ReadPermission}}
+	client := &Client{Repositories: service}
+	hook := &PullRequestCommentHook{
+		Repo:        Repository{Namespace: "octo-org", Name: "hello-world"},
+		PullRequest: PullRequest{Author: User{Login: "jcitizen"}},
+		Comment:     Comment{Author: User{Login: "jcitizen"}},
+	}
+	if _, err := PopulateAuthorAssociation(context.Background(), client, hook); err != nil {
+		t.Fatal(err)
+	}
+	if got := hook.Comment.AuthorAssociation; got != AuthorAssociationCollaborator {
+		t.Errorf("Want comment author association %s, got %s", AuthorAssociationCollaborator, got)
+	}
+	if got := hook.PullRequest.AuthorAssociation; got != AuthorAssociationCollaborator {
+		t.Errorf("Want pull request author association %s, got %s", AuthorAssociationCollaborator, got)
+	}
+	if service.calls != 1 {
+		t.Errorf("Want the permission of the author looked up once, got %d calls", service.calls)
+	}
+
+	comment := &IssueCommentHook{
+		Repo:    Repository{FullName: "octo-org/hello-world"},
+		Comment: Comment{Author: User{Login: "octocat"}, AuthorAssociation: "member"},
+	}
+	if _, err := PopulateAuthorAssociation(context.Background(), client, comment); err != nil {
+		t.Fatal(err)
+	}
+	if got := comment.Comment.AuthorAssociation; got != AuthorAssociationMember {
+		t.Errorf("Want the reported author association normalized, got %s", got)
+	}
+}
+
+func TestFindAuthorAssociation(t *testing.T) {
+	client := &Client{Repositories: &permissions{perms: map[string]string{"octocat": ReadPermission}}}
+	tests := []struct {
+		login string
+		want  string
+	}{
+		{"octo-org", AuthorAssociationOwner},
+		{"octocat", AuthorAssociationNone},
+	}
+	for _, test := range tests {
+		got, _, err := FindAuthorAssociation(context.Background(), client, "octo-org/hello-world", test.login)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("Want author association %s for %s, got %s", test.want, test.login, got)
+		}
+		if IsTrustedAuthorAssociation(got) != (test.want == AuthorAssociationOwner) {
+			t.Errorf("Unexpected trust of author association %s", got)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package bulk provides helpers to run an operation across
+// many repositories or pull requests with bounded concurrency,
+// e.g. adding a file to hundreds of repositories:
+//
+//	err := bulk.AddFile(ctx, client, repos, ".github/CODEOWNERS", &scm.ContentParams{
+//		Message: "add CODEOWNERS",
+//		Data:    data,
+//	}, bulk.Options{Concurrency: 4})
+//
+// The operations wait for the client rate limit to reset
+// rather than failing once it is exhausted, and the errors of
+// the individual operations are aggregated in the returned
+// Errors.
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// Options configures a bulk operation.
+type Options struct {
+	// Concurrency is the maximum number of operations in
+	// flight. It defaults to scm.DefaultConcurrency.
+	Concurrency int
+
+	// MinRemaining is the number of requests kept in reserve
+	// in the client rate limit. Once the remaining requests
+	// reported by the provider drop to this value, new
+	// operations wait until the rate limit resets.
+	MinRemaining int
+
+	// Progress is optionally called after each operation
+	// completes. Calls are serialized.
+	Progress func(Progress)
+}
+
+// Progress reports the state of a bulk operation after an
+// operation completes.
+type Progress struct {
+	// Key is the item the completed operation ran for, and
+	// Err the error it returned.
+	Key string
+	Err error
+
+	Done   int
+	Failed int
+	Total  int
+}
+
+// Errors aggregates the errors of a bulk operation, keyed by
+// the item the failed operation ran for.
+type Errors map[string]error
+
+func (e Errors) Error() string {
+	keys := make([]string, 0, len(e))
+	for key := range e {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	messages := make([]string, len(keys))
+	for i, key := range keys {
+		messages[i] = fmt.Sprintf("%s: %s", key, e[key])
+	}
+	return fmt.Sprintf("%d operation(s) failed: %s", len(e), strings.Join(messages, "; "))
+}
+
+// Run calls fn for each distinct key, e.g. a repository full
+// name, with at most opts.Concurrency calls in flight. It
+// returns nil if every call succeeded, and Errors otherwise.
+func Run(ctx context.Context, client *scm.Client, keys []string, opts Options, fn func(ctx context.Context, key string) error) error {
+	limit := opts.Concurrency
+	if limit <= 0 {
+		limit = scm.DefaultConcurrency
+	}
+
+	var distinct []string
+	seen := map[string]bool{}
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			distinct = append(distinct, key)
+		}
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, limit)
+		errs     = Errors{}
+		progress = Progress{Total: len(distinct)}
+	)
+	for _, key := range distinct {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			var err error
+			select {
+			case sem <- struct{}{}:
+				err = waitRate(ctx, client, opts.MinRemaining)
+				if err == nil {
+					err = fn(ctx, key)
+				}
+				<-sem
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			progress.Done++
+			if err != nil {
+				progress.Failed++
+				errs[key] = err
+			}
+			if opts.Progress != nil {
+				progress.Key, progress.Err = key, err
+				opts.Progress(progress)
+			}
+		}(key)
+	}
+	wg.Wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// AddFile creates the file at path in each repository, or
+// updates it if it exists with a different content. The
+// params are copied for each repository, and the file is
+// left untouched if it already has the expected content.
+func AddFile(ctx context.Context, client *scm.Client, repos []string, path string, params *scm.ContentParams, opts Options) error {
+	return Run(ctx, client, repos, opts, func(ctx context.Context, repo string) error {
+		in := *params
+		ref := in.Branch
+		if ref == "" {
+			ref = in.Ref
+		}
+		content, res, err := client.Contents.Find(ctx, repo, path, ref)
+		switch {
+		case err == nil:
+			if bytes.Equal(content.Data, in.Data) {
+				return nil
+			}
+			in.Sha = content.Sha
+			_, err = client.Contents.Update(ctx, repo, path, &in)
+		case err == scm.ErrNotFound || (res != nil && res.Status == 404):
+			_, err = client.Contents.Create(ctx, repo, path, &in)
+		}
+		return err
+	})
+}
+
+// waitRate blocks until the client rate limit has more than
+// min requests remaining, or its reset time has passed.
+func waitRate(ctx context.Context, client *scm.Client, min int) error {
+	if client == nil {
+		return nil
+	}
+	rate := client.Rate()
+	if rate.Limit == 0 || rate.Remaining > min || rate.Reset == 0 {
+		return nil
+	}
+	wait := time.Until(time.Unix(rate.Reset, 0))
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
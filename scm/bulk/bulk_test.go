@@ -0,0 +1,127 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bulk
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/fake"
+)
+
+func TestRun(t *testing.T) {
+	client, _ := fake.NewDefault()
+
+	var inFlight, maxInFlight int32
+	var reports []Progress
+	keys := []string{"octocat/a", "octocat/b", "octocat/c", "octocat/d", "octocat/a"}
+	err := Run(context.Background(), client, keys, Options{
+		Concurrency: 2,
+		Progress: func(p Progress) {
+			reports = append(reports, p)
+		},
+	}, func(ctx context.Context, key string) error {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		if key == "octocat/c" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	errs, ok := err.(Errors)
+	if !ok {
+		t.Fatalf("Want Errors, got %v", err)
+	}
+	if len(errs) != 1 || errs["octocat/c"] == nil {
+		t.Errorf("Unexpected errors %v", errs)
+	}
+	if got, want := err.Error(), "1 operation(s) failed: octocat/c: boom"; got != want {
+		t.Errorf("Want error %q, got %q", want, got)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("Want at most 2 operations in flight, got %d", maxInFlight)
+	}
+	if len(reports) != 4 {
+		t.Fatalf("Want 4 progress reports, got %d", len(reports))
+	}
+	last := reports[3]
+	if last.Done != 4 || last.Failed != 1 || last.Total != 4 {
+		t.Errorf("Unexpected progress %+v", last)
+	}
+}
+
+func TestRunRateLimited(t *testing.T) {
+	client, _ := fake.NewDefault()
+	client.SetRate(scm.Rate{Limit: 5000, Remaining: 10, Reset: time.Now().Add(time.Hour).Unix()})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	called := false
+	err := Run(ctx, client, []string{"octocat/hello-world"}, Options{MinRemaining: 10}, func(context.Context, string) error {
+		called = true
+		return nil
+	})
+	if called {
+		t.Errorf("Expect operation to wait for the rate limit to reset")
+	}
+	if errs, ok := err.(Errors); !ok || errs["octocat/hello-world"] != context.DeadlineExceeded {
+		t.Errorf("Want deadline exceeded error, got %v", err)
+	}
+}
+
+func TestAddFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bulk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	repos := []string{"octocat/hello-world", "octocat/spoon-knife"}
+	for _, repo := range repos {
+		if err := os.MkdirAll(filepath.Join(dir, repo), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	existing := filepath.Join(dir, "octocat/spoon-knife/CODEOWNERS")
+	if err := ioutil.WriteFile(existing, []byte("* @octocat\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	client, data := fake.NewDefault()
+	data.ContentDir = dir
+
+	err = AddFile(context.Background(), client, repos, "CODEOWNERS", &scm.ContentParams{
+		Message: "add CODEOWNERS",
+		Data:    []byte("* @octocat/admins\n"),
+	}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, repo := range repos {
+		raw, err := ioutil.ReadFile(filepath.Join(dir, repo, "CODEOWNERS"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(raw), "* @octocat/admins\n"; got != want {
+			t.Errorf("Want %s content %q, got %q", repo, want, got)
+		}
+	}
+}
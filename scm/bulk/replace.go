@@ -0,0 +1,329 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// TransformFunc returns the new content of the file at path
+// in the repository. Returning the data unchanged leaves the
+// file untouched.
+type TransformFunc func(repo, path string, data []byte) ([]byte, error)
+
+// ReplaceOptions configures SearchReplace.
+type ReplaceOptions struct {
+	Options
+
+	// Org is the organization whose repositories are
+	// searched, unless Repos is set.
+	Org string
+
+	// Repos optionally lists the repositories to search.
+	Repos []string
+
+	// Patterns are the path.Match patterns of the files to
+	// transform, e.g. ".github/workflows/*.yml". A pattern
+	// component never matches across directories.
+	Patterns []string
+
+	// Transform computes the new content of the files.
+	Transform TransformFunc
+
+	// Branch is the branch the changes are pushed to, and
+	// the head of the pull request. A repository with an open
+	// pull request from the branch is skipped, and a branch
+	// left behind by a failed run is reused, so the operation
+	// can safely be run again. Closed and merged pull requests
+	// are not considered, so a repository whose files still
+	// change gets a new pull request from the branch.
+	//
+	// The ContentService commits a single file at a time, so
+	// every changed file is its own commit on the branch.
+	// Squash the pull requests to land a single commit.
+	Branch string
+
+	// Base is the branch the pull request targets. It
+	// defaults to the default branch of the repository.
+	Base string
+
+	// Title and Body are text/template templates for the
+	// pull request, executed with a ReplaceData. The Title
+	// is also used as the commit message, unless Message is
+	// set.
+	Title   string
+	Body    string
+	Message string
+}
+
+// ReplaceData is the data the pull request templates are
+// executed with.
+type ReplaceData struct {
+	Repo  string
+	Files []string
+}
+
+// SearchReplace finds the files matching the patterns in the
+// repositories, applies the transform, and opens a pull request
+// with the changes in every repository where a file changed.
+// The pull requests, including those already open from a
+// previous run, are returned keyed by repository.
+func SearchReplace(ctx context.Context, client *scm.Client, opts ReplaceOptions) (map[string]*scm.PullRequest, error) {
+	if opts.Branch == "" || opts.Title == "" || opts.Transform == nil {
+		return nil, errors.New("bulk: branch, title and transform are required")
+	}
+	title, err := template.New("title").Parse(opts.Title)
+	if err != nil {
+		return nil, err
+	}
+	body, err := template.New("body").Parse(opts.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	repos := opts.Repos
+	defaults := map[string]string{}
+	if len(repos) == 0 {
+		list, err := listOrganisation(ctx, client, opts.Org)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range list {
+			repos = append(repos, repo.FullName)
+			defaults[repo.FullName] = repo.Branch
+		}
+	}
+
+	var mu sync.Mutex
+	prs := map[string]*scm.PullRequest{}
+	err = Run(ctx, client, repos, opts.Options, func(ctx context.Context, repo string) error {
+		r := &replacer{client: client, opts: &opts, repo: repo, title: title, body: body}
+		pr, err := r.run(ctx, defaults[repo])
+		if pr != nil {
+			mu.Lock()
+			prs[repo] = pr
+			mu.Unlock()
+		}
+		return err
+	})
+	return prs, err
+}
+
+// replacer runs the search and replace in a repository.
+type replacer struct {
+	client *scm.Client
+	opts   *ReplaceOptions
+	repo   string
+	title  *template.Template
+	body   *template.Template
+}
+
+func (r *replacer) run(ctx context.Context, base string) (*scm.PullRequest, error) {
+	if pr, err := r.existing(ctx); pr != nil || err != nil {
+		return pr, err
+	}
+
+	if r.opts.Base != "" {
+		base = r.opts.Base
+	}
+	if base == "" {
+		repo, _, err := r.client.Repositories.Find(ctx, r.repo)
+		if err != nil {
+			return nil, err
+		}
+		base = repo.Branch
+	}
+
+	var files []string
+	for _, pattern := range r.opts.Patterns {
+		matches, err := r.match(ctx, "", strings.Split(strings.Trim(pattern, "/"), "/"), base)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+
+	changed := map[string]*scm.Content{}
+	var paths []string
+	for _, file := range files {
+		if _, ok := changed[file]; ok {
+			continue
+		}
+		content, _, err := r.client.Contents.Find(ctx, r.repo, file, base)
+		if err != nil {
+			return nil, err
+		}
+		data, err := r.opts.Transform(r.repo, file, content.Data)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(data, content.Data) {
+			continue
+		}
+		changed[file] = &scm.Content{Path: file, Sha: content.Sha, Data: data}
+		paths = append(paths, file)
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	data := &ReplaceData{Repo: r.repo, Files: paths}
+	title, err := execute(r.title, data)
+	if err != nil {
+		return nil, err
+	}
+	body, err := execute(r.body, data)
+	if err != nil {
+		return nil, err
+	}
+	message := r.opts.Message
+	if message == "" {
+		message = title
+	}
+
+	reuse, err := r.branch(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range paths {
+		content := changed[file]
+		sha := content.Sha
+		if reuse {
+			// a previous run may have committed the file to
+			// the branch before failing.
+			current, _, err := r.client.Contents.Find(ctx, r.repo, file, r.opts.Branch)
+			if err != nil && !errors.Is(err, scm.ErrNotFound) {
+				return nil, err
+			}
+			if current != nil {
+				if bytes.Equal(current.Data, content.Data) {
+					continue
+				}
+				sha = current.Sha
+			}
+		}
+		_, err := r.client.Contents.Update(ctx, r.repo, file, &scm.ContentParams{
+			Branch:  r.opts.Branch,
+			Message: message,
+			Data:    content.Data,
+			Sha:     sha,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	pr, _, err := r.client.PullRequests.Create(ctx, r.repo, &scm.PullRequestInput{
+		Title: title,
+		Body:  body,
+		Head:  r.opts.Branch,
+		Base:  base,
+	})
+	return pr, err
+}
+
+// existing returns the open pull request from the branch, if
+// any. Closed and merged pull requests are ignored.
+func (r *replacer) existing(ctx context.Context) (*scm.PullRequest, error) {
+	opts := scm.PullRequestListOptions{Open: true, Page: 1, Size: 100}
+	for {
+		prs, res, err := r.client.PullRequests.List(ctx, r.repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, pr := range prs {
+			if pr.Head.Ref == r.opts.Branch || pr.Source == r.opts.Branch {
+				return pr, nil
+			}
+		}
+		if res == nil || res.Page.Next == 0 {
+			return nil, nil
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
+// branch creates the branch from the base branch, and
+// reports whether it already existed.
+func (r *replacer) branch(ctx context.Context, base string) (bool, error) {
+	_, _, err := r.client.Git.FindBranch(ctx, r.repo, r.opts.Branch)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, scm.ErrNotFound) {
+		return false, err
+	}
+	ref, _, err := r.client.Git.FindBranch(ctx, r.repo, base)
+	if err != nil {
+		return false, err
+	}
+	_, _, err = r.client.Git.CreateRef(ctx, r.repo, scm.ExpandRef(r.opts.Branch, "refs/heads"), ref.Sha)
+	return false, err
+}
+
+// match returns the files below dir matching the pattern
+// components. Only the directories matching the leading
+// components are listed.
+func (r *replacer) match(ctx context.Context, dir string, parts []string, ref string) ([]string, error) {
+	entries, _, err := r.client.Contents.List(ctx, r.repo, dir, ref)
+	if errors.Is(err, scm.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		ok, err := path.Match(parts[0], entry.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		name := path.Join(dir, entry.Name)
+		switch {
+		case len(parts) == 1 && entry.Type == "file":
+			files = append(files, name)
+		case len(parts) > 1 && entry.Type == "dir":
+			matches, err := r.match(ctx, name, parts[1:], ref)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, matches...)
+		}
+	}
+	return files, nil
+}
+
+// listOrganisation returns all the repositories of the
+// organization.
+func listOrganisation(ctx context.Context, client *scm.Client, org string) ([]*scm.Repository, error) {
+	var out []*scm.Repository
+	opts := scm.ListOptions{Page: 1, Size: 100}
+	for {
+		repos, res, err := client.Repositories.ListOrganisation(ctx, org, opts)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, repos...)
+		if res == nil || res.Page.Next == 0 {
+			return out, nil
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
+func execute(t *template.Template, data *ReplaceData) (string, error) {
+	var buf bytes.Buffer
+	err := t.Execute(&buf, data)
+	return buf.String(), err
+}
@@ -0,0 +1,179 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/fake"
+)
+
+func TestSearchReplace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "replace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"octocat/hello-world/.github/workflows/ci.yml":   "uses: actions/checkout@v1\n",
+		"octocat/hello-world/.github/workflows/lint.yml": "uses: golangci/golangci-lint-action@v2\n",
+		"octocat/hello-world/README.md":                  "uses: actions/checkout@v1\n",
+		"octocat/spoon-knife/.github/workflows/ci.yml":   "uses: actions/checkout@v2\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client, data := fake.NewDefault()
+	data.ContentDir = dir
+	data.Repositories = []*scm.Repository{
+		{Namespace: "octocat", Name: "hello-world", FullName: "octocat/hello-world", Branch: "master"},
+		{Namespace: "octocat", Name: "spoon-knife", FullName: "octocat/spoon-knife", Branch: "master"},
+		{Namespace: "github", Name: "linguist", FullName: "github/linguist", Branch: "master"},
+	}
+	data.CommitOnBranch("octocat/hello-world", "master", "initial commit")
+	data.CommitOnBranch("octocat/spoon-knife", "master", "initial commit")
+
+	opts := ReplaceOptions{
+		Org:      "octocat",
+		Patterns: []string{".github/workflows/*.yml"},
+		Transform: func(repo, path string, data []byte) ([]byte, error) {
+			return bytes.Replace(data, []byte("actions/checkout@v1"), []byte("actions/checkout@v2"), -1), nil
+		},
+		Branch: "update-checkout",
+		Title:  "Update actions/checkout in {{ .Repo }}",
+		Body:   "{{ range .Files }}* {{ . }}\n{{ end }}",
+	}
+	prs, err := SearchReplace(context.Background(), client, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prs) != 1 || prs["octocat/hello-world"] == nil {
+		t.Fatalf("Want a pull request for octocat/hello-world only, got %v", prs)
+	}
+	pr := prs["octocat/hello-world"]
+	if got, want := pr.Title, "Update actions/checkout in octocat/hello-world"; got != want {
+		t.Errorf("Want title %q, got %q", want, got)
+	}
+	if got, want := pr.Body, "* .github/workflows/ci.yml\n"; got != want {
+		t.Errorf("Want body %q, got %q", want, got)
+	}
+	if pr.Head.Ref != "update-checkout" || pr.Base.Ref != "master" {
+		t.Errorf("Unexpected branches %s <- %s", pr.Base.Ref, pr.Head.Ref)
+	}
+	if _, ok := data.Refs["octocat/hello-world"]["refs/heads/update-checkout"]; !ok {
+		t.Errorf("Expect branch update-checkout created")
+	}
+	raw, _ := ioutil.ReadFile(filepath.Join(dir, "octocat/hello-world/README.md"))
+	if string(raw) != "uses: actions/checkout@v1\n" {
+		t.Errorf("Expect files not matching the pattern untouched")
+	}
+
+	// running again finds the open pull request rather than
+	// opening another one.
+	prs, err = SearchReplace(context.Background(), client, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := prs["octocat/hello-world"]; got == nil || got.Number != pr.Number {
+		t.Errorf("Want existing pull request returned, got %v", got)
+	}
+	if got, want := len(data.PullRequests), 1; got != want {
+		t.Errorf("Want %d pull request, got %d", want, got)
+	}
+}
+
+func TestSearchReplace_ExistingBranch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "replace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "octocat", "hello-world", "README.md")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte("Hello World\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	client, data := fake.NewDefault()
+	data.ContentDir = dir
+	data.CommitOnBranch("octocat/hello-world", "master", "initial commit")
+
+	opts := ReplaceOptions{
+		Repos:    []string{"octocat/hello-world"},
+		Patterns: []string{"README.md"},
+		Transform: func(repo, path string, data []byte) ([]byte, error) {
+			return bytes.Replace(data, []byte("World"), []byte("Octocat"), -1), nil
+		},
+		Branch: "update-readme",
+		Base:   "master",
+		Title:  "Update README",
+	}
+
+	// the first run creates the branch, then fails before
+	// opening the pull request.
+	data.MethodErrors["Contents.Update"] = []error{errors.New("boom")}
+	if _, err := SearchReplace(context.Background(), client, opts); err == nil {
+		t.Fatal("Want error from the first run")
+	}
+	if _, ok := data.Refs["octocat/hello-world"]["refs/heads/update-readme"]; !ok {
+		t.Fatal("Expect branch update-readme created")
+	}
+
+	// running again reuses the branch.
+	prs, err := SearchReplace(context.Background(), client, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prs["octocat/hello-world"] == nil {
+		t.Fatalf("Want a pull request for octocat/hello-world, got %v", prs)
+	}
+	raw, _ := ioutil.ReadFile(path)
+	if got, want := string(raw), "Hello Octocat\n"; got != want {
+		t.Errorf("Want content %q, got %q", want, got)
+	}
+}
+
+func TestSearchReplace_MissingDirectory(t *testing.T) {
+	client, data := fake.NewDefault()
+	data.CommitOnBranch("octocat/hello-world", "master", "initial commit")
+	data.MethodErrors["Contents.List"] = []error{fmt.Errorf("directory .github: %w", scm.ErrNotFound)}
+
+	opts := ReplaceOptions{
+		Repos:    []string{"octocat/hello-world"},
+		Patterns: []string{".github/workflows/*.yml"},
+		Transform: func(repo, path string, data []byte) ([]byte, error) {
+			return data, nil
+		},
+		Branch: "update-checkout",
+		Base:   "master",
+		Title:  "Update actions/checkout",
+	}
+	prs, err := SearchReplace(context.Background(), client, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prs) != 0 {
+		t.Errorf("Want no pull requests, got %v", prs)
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cache provides an in-process read-through cache for
+// the objects a client fetches repeatedly, such as a repository
+// looked up by every webhook of a burst.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/internal/flight"
+)
+
+// DefaultTTL is the time an object is cached for, unless
+// Options.TTL is set.
+const DefaultTTL = 30 * time.Second
+
+// Options configures the cache.
+type Options struct {
+	// TTL is the time an object is cached for. It defaults
+	// to DefaultTTL.
+	TTL time.Duration
+
+	// Now optionally returns the current time, for tests.
+	Now func() time.Time
+}
+
+// Cache caches the results of Repositories.Find, Contents.Find
+// and Users.FindLogin. Errors are not cached, and concurrent
+// lookups of the same object are collapsed into a single request,
+// sent with the values of the context of the first caller. A
+// caller whose context is canceled returns without waiting for
+// the request, which is only canceled once all the callers
+// returned. The objects are
+// cached for the credentials of the context, the token set with
+// scm.WithContext and the user impersonated with scm.WithSudo,
+// so an object is only returned to the callers that may read it.
+// A file found with scm.WithContentData, which has no Sha, and a
+// response kept with scm.WithRaw are cached apart from the others.
+// The objects and the responses are copied, so callers may
+// modify them. The body of the responses is empty.
+//
+// A file looked up by branch is cached for the TTL even if the
+// branch moves, so look it up by commit SHA when it must be
+// current.
+type Cache struct {
+	ttl   time.Duration
+	now   func() time.Time
+	group flight.Group
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+type entry struct {
+	val     interface{}
+	res     *scm.Response
+	expires time.Time
+}
+
+type result struct {
+	val interface{}
+	res *scm.Response
+}
+
+// Wrap replaces the Repositories, Contents and Users services of
+// the client with services reading through the returned cache.
+func Wrap(client *scm.Client, opts Options) *Cache {
+	c := &Cache{
+		ttl:     opts.TTL,
+		now:     opts.Now,
+		entries: map[string]*entry{},
+	}
+	if c.ttl <= 0 {
+		c.ttl = DefaultTTL
+	}
+	if c.now == nil {
+		c.now = time.Now
+	}
+	client.Repositories = &repositoryService{RepositoryService: client.Repositories, cache: c}
+	client.Contents = &contentService{ContentService: client.Contents, cache: c}
+	client.Users = &userService{UserService: client.Users, cache: c}
+	return c
+}
+
+// Purge removes all the objects from the cache.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	c.entries = map[string]*entry{}
+	c.mu.Unlock()
+}
+
+// get returns the cached object of the key, or calls fn to fetch
+// it. The response is the one of the request that fetched the
+// object.
+func (c *Cache) get(ctx context.Context, key string, fn func(ctx context.Context) (interface{}, *scm.Response, error)) (interface{}, *scm.Response, error) {
+	now := c.now()
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok && now.Before(e.expires) {
+		c.mu.Unlock()
+		return e.val, copyResponse(e.res), nil
+	}
+	c.mu.Unlock()
+
+	v, err, _ := c.group.Do(ctx, key, func(ctx context.Context) (interface{}, error) {
+		val, res, err := fn(ctx)
+		if err != nil {
+			return &result{res: res}, err
+		}
+		c.mu.Lock()
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		c.entries[key] = &entry{val: val, res: res, expires: now.Add(c.ttl)}
+		c.mu.Unlock()
+		return &result{val: val, res: res}, nil
+	})
+	r, _ := v.(*result)
+	if r == nil {
+		return nil, nil, err
+	}
+	return r.val, copyResponse(r.res), err
+}
+
+// copyResponse returns a copy of the response with an empty
+// body, the body of the response having been read by the
+// request that fetched the object.
+func copyResponse(res *scm.Response) *scm.Response {
+	if res == nil {
+		return nil
+	}
+	out := *res
+	out.Header = res.Header.Clone()
+	out.Body = http.NoBody
+	out.Raw = append(json.RawMessage(nil), res.Raw...)
+	return &out
+}
+
+func key(parts ...string) string {
+	return strings.Join(parts, "\x00")
+}
+
+// identity returns the key of the credentials of the context,
+// which are the token set with scm.WithContext, e.g. read by
+// oauth2.ContextTokenSource, and the impersonated user. The
+// token is hashed so it is not kept in memory.
+func identity(ctx context.Context) string {
+	var token string
+	if t, ok := ctx.Value(scm.TokenKey{}).(*scm.Token); ok && t != nil {
+		sum := sha256.Sum256([]byte(t.Token))
+		token = hex.EncodeToString(sum[:])
+	}
+	sudo, _ := ctx.Value(scm.SudoKey{}).(string)
+	return key(token, sudo)
+}
+
+// variant returns the key of the context values changing the
+// result of a lookup: the file data only requested with
+// scm.WithContentData, and the raw payloads kept with
+// scm.WithRaw.
+func variant(ctx context.Context) string {
+	return key(strconv.FormatBool(scm.ContentDataOnly(ctx)), strconv.FormatBool(scm.RawPayload(ctx)))
+}
+
+type repositoryService struct {
+	scm.RepositoryService
+	cache *Cache
+}
+
+func (s *repositoryService) Find(ctx context.Context, repo string) (*scm.Repository, *scm.Response, error) {
+	v, res, err := s.cache.get(ctx, key("Repositories.Find", identity(ctx), variant(ctx), repo), func(ctx context.Context) (interface{}, *scm.Response, error) {
+		return s.RepositoryService.Find(ctx, repo)
+	})
+	found, _ := v.(*scm.Repository)
+	if err != nil || found == nil {
+		return nil, res, err
+	}
+	out := *found
+	if out.Perm != nil {
+		perm := *out.Perm
+		out.Perm = &perm
+	}
+	return &out, res, nil
+}
+
+type contentService struct {
+	scm.ContentService
+	cache *Cache
+}
+
+func (s *contentService) Find(ctx context.Context, repo, path, ref string) (*scm.Content, *scm.Response, error) {
+	v, res, err := s.cache.get(ctx, key("Contents.Find", identity(ctx), variant(ctx), repo, path, ref), func(ctx context.Context) (interface{}, *scm.Response, error) {
+		return s.ContentService.Find(ctx, repo, path, ref)
+	})
+	found, _ := v.(*scm.Content)
+	if err != nil || found == nil {
+		return nil, res, err
+	}
+	out := *found
+	out.Data = append([]byte(nil), out.Data...)
+	return &out, res, nil
+}
+
+type userService struct {
+	scm.UserService
+	cache *Cache
+}
+
+func (s *userService) FindLogin(ctx context.Context, login string) (*scm.User, *scm.Response, error) {
+	v, res, err := s.cache.get(ctx, key("Users.FindLogin", identity(ctx), variant(ctx), login), func(ctx context.Context) (interface{}, *scm.Response, error) {
+		return s.UserService.FindLogin(ctx, login)
+	})
+	found, _ := v.(*scm.User)
+	if err != nil || found == nil {
+		return nil, res, err
+	}
+	out := *found
+	return &out, res, nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/fake"
+)
+
+func TestRepositoryFind(t *testing.T) {
+	client, data := fake.NewDefault()
+	data.Repositories = []*scm.Repository{{FullName: "octocat/hello-world"}}
+	data.MethodLatency = map[string]time.Duration{"Repositories.Find": 20 * time.Millisecond}
+	boom := errors.New("boom")
+	// only the first request succeeds
+	data.MethodErrors["Repositories.Find"] = []error{nil, boom}
+
+	now := time.Now()
+	Wrap(client, Options{TTL: time.Minute, Now: func() time.Time { return now }})
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _, errs[i] = client.Repositories.Find(ctx, "octocat/hello-world")
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("Want concurrent lookups collapsed into one request, got %v", err)
+		}
+	}
+
+	repo, _, err := client.Repositories.Find(ctx, "octocat/hello-world")
+	if err != nil {
+		t.Fatalf("Want the cached repository, got %v", err)
+	}
+	repo.FullName = "modified"
+
+	now = now.Add(time.Minute)
+	if _, _, err := client.Repositories.Find(ctx, "octocat/hello-world"); err != boom {
+		t.Errorf("Want the expired repository fetched again, got %v", err)
+	}
+	if got := data.Repositories[0].FullName; got != "octocat/hello-world" {
+		t.Errorf("Want the cached repository copied, got %s", got)
+	}
+}
+
+func TestRepositoryFind_Credentials(t *testing.T) {
+	client, data := fake.NewDefault()
+	data.Repositories = []*scm.Repository{{FullName: "octocat/hello-world", Private: true}}
+	boom := errors.New("boom")
+	// only the first request succeeds
+	data.MethodErrors["Repositories.Find"] = []error{nil, boom}
+
+	Wrap(client, Options{TTL: time.Minute})
+	octocat := scm.WithContext(context.Background(), &scm.Token{Token: "octocat"})
+	if _, _, err := client.Repositories.Find(octocat, "octocat/hello-world"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Repositories.Find(octocat, "octocat/hello-world"); err != nil {
+		t.Errorf("Want the repository cached for the token, got %v", err)
+	}
+	janedoe := scm.WithContext(context.Background(), &scm.Token{Token: "janedoe"})
+	if _, _, err := client.Repositories.Find(janedoe, "octocat/hello-world"); err != boom {
+		t.Errorf("Want the repository fetched again with another token, got %v", err)
+	}
+}
+
+func TestContentFind_Variant(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.MkdirAll(filepath.Join(dir, "octocat", "hello-world"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "octocat", "hello-world", "README"), []byte("Hello World\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	client, data := fake.NewDefault()
+	data.ContentDir = dir
+	boom := errors.New("boom")
+	// only the first request succeeds
+	data.MethodErrors["Contents.Find"] = []error{nil, boom, boom}
+
+	Wrap(client, Options{TTL: time.Minute})
+	dataOnly := scm.WithContentData(context.Background())
+	if _, _, err := client.Contents.Find(dataOnly, "octocat/hello-world", "README", "master"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Contents.Find(context.Background(), "octocat/hello-world", "README", "master"); err != boom {
+		t.Errorf("Want the file fetched again with its Sha, got %v", err)
+	}
+	if _, _, err := client.Contents.Find(scm.WithRaw(dataOnly), "octocat/hello-world", "README", "master"); err != boom {
+		t.Errorf("Want the file fetched again with its raw payload, got %v", err)
+	}
+}
+
+func TestGet_Response(t *testing.T) {
+	c := Wrap(&scm.Client{}, Options{})
+	fetch := func(context.Context) (interface{}, *scm.Response, error) {
+		return "value", &scm.Response{
+			Status: 200,
+			Header: http.Header{"Etag": {"abc"}},
+			Body:   ioutil.NopCloser(strings.NewReader("read by the driver")),
+		}, nil
+	}
+	for i := 0; i < 2; i++ {
+		_, res, err := c.get(context.Background(), "key", fetch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if body, _ := ioutil.ReadAll(res.Body); len(body) != 0 {
+			t.Errorf("Want an empty response body, got %q", body)
+		}
+		if got := res.Header.Get("Etag"); got != "abc" {
+			t.Errorf("Want the response header copied, got Etag %q", got)
+		}
+		res.Header.Set("Etag", "modified")
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import "context"
+
+// CallFunc sends a request of the API of the provider, like
+// Client.Call.
+type CallFunc func(ctx context.Context, method, path string, in, out interface{}) (*Response, error)
+
+// SetCaller sets the function sending the API requests of
+// Call. Drivers set it to the function sending the requests
+// of their services.
+func (c *Client) SetCaller(fn CallFunc) {
+	c.caller = fn
+}
+
+// Call sends a request to an endpoint of the API of the
+// provider the services do not wrap, with in encoded as the
+// JSON body and the JSON response decoded into out, which may
+// both be nil. The path is relative to the base URL, e.g.
+// repos/octocat/hello-world/topics on GitHub. The request is
+// sent like the requests of the services, with the same
+// authentication, error responses and rate limit tracking.
+// The drivers without a REST API, such as the fake, local,
+// githttp and gitiles drivers, return a NotSupportedError.
+func (c *Client) Call(ctx context.Context, method, path string, in, out interface{}) (*Response, error) {
+	if c.caller == nil {
+		return nil, &NotSupportedError{Driver: c.Driver, Service: "Client", Method: "Call"}
+	}
+	return c.caller(ctx, method, path, in, out)
+}
@@ -0,0 +1,203 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package changelog generates release notes from the commits
+// between two refs, grouped by their conventional commit type.
+// Commits merging a pull request are described by the pull
+// request title, so squashed and merged pull requests are
+// listed once.
+package changelog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/parse"
+)
+
+// Entry is a change listed in the release notes.
+type Entry struct {
+	Type        string
+	Scope       string
+	Description string
+
+	// Breaking is true if the change is marked as breaking,
+	// with an optional note describing the migration.
+	Breaking     bool
+	BreakingNote string
+
+	// Sha is the commit introducing the change, and
+	// PullRequest the number of its pull request, or zero.
+	Sha         string
+	PullRequest int
+	Author      string
+	Issues      []*parse.IssueRef
+}
+
+// Notes are the release notes of the changes between two
+// refs.
+type Notes struct {
+	From string
+	To   string
+
+	Breaking []*Entry
+	Features []*Entry
+	Fixes    []*Entry
+	Other    []*Entry
+}
+
+// Options configures the release notes.
+type Options struct {
+	// PullRequests enables looking up the pull request
+	// merged by each commit, so it is described by the pull
+	// request title rather than the commit message.
+	PullRequests bool
+
+	// SkipOther omits the changes that are neither features,
+	// fixes nor breaking changes.
+	SkipOther bool
+}
+
+// pullRequestRe matches the pull request number in the
+// messages of the commits created by merging or squashing a
+// pull request.
+var pullRequestRe = regexp.MustCompile(`(?m)^Merge pull request #(\d+)|\(#(\d+)\)[ \t]*$|See merge request [\w./-]*!(\d+)|\(pull request #(\d+)\)`)
+
+// Generate returns the release notes of the commits reachable
+// from the to ref but not from the from ref.
+func Generate(ctx context.Context, client *scm.Client, repo, from, to string, opts Options) (*Notes, error) {
+	if _, err := scm.ParseRepo(repo); err != nil {
+		return nil, err
+	}
+	comparison, _, err := client.Git.CompareCommits(ctx, repo, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	notes := &Notes{From: from, To: to}
+	seen := map[int]bool{}
+	for _, commit := range comparison.Commits {
+		entry := newEntry(client.Driver, commit.Sha, commit.Message)
+		entry.Author = commit.Author.Login
+
+		if number := pullRequestNumber(commit.Message); number != 0 {
+			if seen[number] {
+				continue
+			}
+			seen[number] = true
+			if opts.PullRequests {
+				pr, _, err := client.PullRequests.Find(ctx, repo, number)
+				if err != nil {
+					return nil, err
+				}
+				entry = newEntry(client.Driver, commit.Sha, pr.Title+"\n\n"+pr.Body)
+				entry.Author = pr.Author.Login
+			}
+			entry.PullRequest = number
+			entry.Description = strings.TrimSpace(strings.TrimSuffix(entry.Description, fmt.Sprintf("(#%d)", number)))
+		}
+		notes.add(entry, opts)
+	}
+	return notes, nil
+}
+
+// newEntry returns the entry describing the message.
+func newEntry(driver scm.Driver, sha, message string) *Entry {
+	entry := &Entry{
+		Sha:    sha,
+		Issues: parse.ClosingIssues(driver, message),
+	}
+	if c, ok := parse.ParseConventional(message); ok {
+		entry.Type = c.Type
+		entry.Scope = c.Scope
+		entry.Description = c.Description
+		entry.Breaking = c.Breaking
+		entry.BreakingNote = c.BreakingNote
+	} else {
+		entry.Description = strings.TrimSpace(strings.SplitN(message, "\n", 2)[0])
+		entry.Breaking = parse.Parse(driver, message).Breaking
+	}
+	return entry
+}
+
+// pullRequestNumber returns the number of the pull request
+// merged by the commit, or zero.
+func pullRequestNumber(message string) int {
+	m := pullRequestRe.FindStringSubmatch(message)
+	if m == nil {
+		return 0
+	}
+	for _, s := range m[1:] {
+		if s != "" {
+			number, _ := strconv.Atoi(s)
+			return number
+		}
+	}
+	return 0
+}
+
+func (n *Notes) add(entry *Entry, opts Options) {
+	switch {
+	case entry.Breaking:
+		n.Breaking = append(n.Breaking, entry)
+	case entry.Type == "feat":
+		n.Features = append(n.Features, entry)
+	case entry.Type == "fix":
+		n.Fixes = append(n.Fixes, entry)
+	case !opts.SkipOther:
+		n.Other = append(n.Other, entry)
+	}
+}
+
+// Markdown returns the release notes formatted as markdown,
+// with a section per group of changes.
+func (n *Notes) Markdown() string {
+	var buf bytes.Buffer
+	for _, section := range []struct {
+		title   string
+		entries []*Entry
+	}{
+		{"Breaking Changes", n.Breaking},
+		{"Features", n.Features},
+		{"Bug Fixes", n.Fixes},
+		{"Other Changes", n.Other},
+	} {
+		if len(section.entries) == 0 {
+			continue
+		}
+		if buf.Len() != 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "## %s\n\n", section.title)
+		for _, entry := range section.entries {
+			buf.WriteString("* " + entry.String() + "\n")
+			if entry.BreakingNote != "" {
+				fmt.Fprintf(&buf, "  %s\n", strings.Replace(entry.BreakingNote, "\n", "\n  ", -1))
+			}
+		}
+	}
+	return buf.String()
+}
+
+// String returns the entry formatted as a release notes line.
+func (e *Entry) String() string {
+	s := e.Description
+	if e.Scope != "" {
+		s = "**" + e.Scope + ":** " + s
+	}
+	if e.PullRequest != 0 {
+		s += fmt.Sprintf(" (#%d)", e.PullRequest)
+	} else if len(e.Sha) > 7 {
+		s += " (" + e.Sha[:7] + ")"
+	}
+	if e.Author != "" {
+		s += " @" + e.Author
+	}
+	return s
+}
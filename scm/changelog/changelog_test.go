@@ -0,0 +1,81 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package changelog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/fake"
+)
+
+func TestGenerate(t *testing.T) {
+	client, data := fake.NewDefault()
+	client.Driver = scm.DriverGithub
+
+	repo := "octocat/hello-world"
+	data.CommitOnBranch(repo, "master", "chore: initial commit")
+	data.Refs[repo]["refs/tags/v1.0.0"] = data.Refs[repo]["refs/heads/master"]
+
+	fix := data.CommitOnBranch(repo, "master", "fix(git): resolve short refs\n\nFixes #3")
+	data.CommitOnBranch(repo, "master", "feat: add the changelog package (#7)")
+	data.CommitOnBranch(repo, "master", "Merge pull request #8 from octocat/rename\n\nRename the client")
+	docs := data.CommitOnBranch(repo, "master", "Update README.md")
+	data.PullRequests[7] = &scm.PullRequest{
+		Number: 7,
+		Title:  "feat: add the changelog package",
+	}
+	data.PullRequests[8] = &scm.PullRequest{
+		Number: 8,
+		Title:  "refactor!: rename the client",
+		Body:   "BREAKING CHANGE: Client is renamed to Session",
+		Author: scm.User{Login: "octocat"},
+	}
+
+	notes, err := Generate(context.Background(), client, repo, "v1.0.0", "master", Options{PullRequests: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the entries are listed from the most recent commit.
+	if got, want := len(notes.Breaking), 1; got != want {
+		t.Fatalf("Want %d breaking change, got %d", want, got)
+	}
+	if got, want := notes.Breaking[0].PullRequest, 8; got != want {
+		t.Errorf("Want breaking change from pull request %d, got %d", want, got)
+	}
+	if got, want := len(notes.Fixes), 1; got != want {
+		t.Fatalf("Want %d fix, got %d", want, got)
+	}
+	if got, want := notes.Fixes[0].Sha, fix.Sha; got != want {
+		t.Errorf("Want fix %s, got %s", want, got)
+	}
+	if got, want := len(notes.Fixes[0].Issues), 1; got != want {
+		t.Errorf("Want %d closed issue, got %d", want, got)
+	}
+
+	want := "## Breaking Changes\n\n" +
+		"* rename the client (#8) @octocat\n" +
+		"  Client is renamed to Session\n" +
+		"\n## Features\n\n" +
+		"* add the changelog package (#7)\n" +
+		"\n## Bug Fixes\n\n" +
+		"* **git:** resolve short refs (" + fix.Sha[:7] + ")\n" +
+		"\n## Other Changes\n\n" +
+		"* Update README.md (" + docs.Sha[:7] + ")\n"
+	if diff := cmp.Diff(want, notes.Markdown()); diff != "" {
+		t.Errorf("Unexpected release notes\n%s", diff)
+	}
+
+	notes, err = Generate(context.Background(), client, repo, "v1.0.0", "master", Options{SkipOther: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notes.Other) != 0 || len(notes.Breaking) != 0 {
+		t.Errorf("Want other changes skipped, and the merge commit not breaking without its pull request")
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package chatops parses slash commands from issue and pull
+// request comments, checks that their author may run them, and
+// acknowledges them with a reaction.
+package chatops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// Reaction values.
+const (
+	ReactionEyes     = "eyes"
+	ReactionRocket   = "rocket"
+	ReactionConfused = "confused"
+)
+
+// ErrPermission is returned when the author of a command does
+// not have the permission required to run it.
+var ErrPermission = errors.New("chatops: insufficient permission")
+
+// Command is a slash command of a comment, e.g. "/retest unit".
+type Command struct {
+	// Name is the command without the slash, in lower case.
+	Name string
+
+	// Args are the whitespace separated arguments.
+	Args []string
+
+	// Line is the line of the comment the command was
+	// parsed from.
+	Line string
+}
+
+// Parse returns the slash commands of the comment body, one per
+// line starting with a slash. Lines in fenced code blocks and
+// quoted lines are ignored, so replies quoting a command do not
+// run it again.
+func Parse(body string) []Command {
+	var commands []Command
+	fenced := false
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			fenced = !fenced
+			continue
+		}
+		if fenced || !strings.HasPrefix(line, "/") {
+			continue
+		}
+		fields := strings.Fields(line[1:])
+		if len(fields) == 0 {
+			continue
+		}
+		commands = append(commands, Command{
+			Name: strings.ToLower(fields[0]),
+			Args: fields[1:],
+			Line: line,
+		})
+	}
+	return commands
+}
+
+// Reactor adds a reaction to the comment of an issue or pull
+// request.
+type Reactor interface {
+	CreateCommentReaction(ctx context.Context, repo string, number, id int, reaction string) (*scm.Response, error)
+}
+
+// NewReactor returns a Reactor adding the reactions with the
+// reactions API of the provider of the client, which is
+// supported by GitHub and Gitea. The reactions of the other
+// drivers fail with a scm.NotSupportedError.
+func NewReactor(client *scm.Client) Reactor {
+	return &clientReactor{client: client}
+}
+
+type clientReactor struct {
+	client *scm.Client
+}
+
+func (r *clientReactor) CreateCommentReaction(ctx context.Context, repo string, number, id int, reaction string) (*scm.Response, error) {
+	in := map[string]string{"content": reaction}
+	switch r.client.Driver {
+	case scm.DriverGithub:
+		path := fmt.Sprintf("repos/%s/issues/comments/%d/reactions", repo, id)
+		return r.client.Call(ctx, "POST", path, in, nil)
+	case scm.DriverGitea:
+		path := fmt.Sprintf("api/v1/repos/%s/issues/comments/%d/reactions", repo, id)
+		return r.client.Call(ctx, "POST", path, in, nil)
+	}
+	return nil, &scm.NotSupportedError{Driver: r.client.Driver, Service: "Reactor", Method: "CreateCommentReaction"}
+}
+
+// Handler acknowledges the commands of comments.
+type Handler struct {
+	Client *scm.Client
+
+	// Reactor reacts to the acknowledged comments. It
+	// defaults to the NewReactor of the Client, so the
+	// reactions of the drivers without a reactions API fail
+	// unless a Reactor is set.
+	Reactor Reactor
+
+	// Permission is the permission the author of a command
+	// needs on the repository. It defaults to
+	// scm.WritePermission.
+	Permission string
+}
+
+// Acknowledge parses the commands of the comment, checks that its
+// author has the required permission and reacts to the comment
+// with eyes. It returns ErrPermission, after reacting with
+// confused, if the author may not run commands. A comment without
+// commands is ignored.
+func (h *Handler) Acknowledge(ctx context.Context, repo string, number int, comment *scm.Comment) ([]Command, error) {
+	commands := Parse(comment.Body)
+	if len(commands) == 0 {
+		return nil, nil
+	}
+	perm, _, err := h.Client.Repositories.FindUserPermission(ctx, repo, comment.Author.Login)
+	if err != nil {
+		return nil, err
+	}
+	required := h.Permission
+	if required == "" {
+		required = scm.WritePermission
+	}
+	if rank(perm) < rank(required) {
+		if err := h.react(ctx, repo, number, comment, ReactionConfused); err != nil {
+			return nil, err
+		}
+		return nil, ErrPermission
+	}
+	if err := h.react(ctx, repo, number, comment, ReactionEyes); err != nil {
+		return nil, err
+	}
+	return commands, nil
+}
+
+// Done reacts to the comment with a rocket once its commands
+// have run.
+func (h *Handler) Done(ctx context.Context, repo string, number int, comment *scm.Comment) error {
+	return h.react(ctx, repo, number, comment, ReactionRocket)
+}
+
+func (h *Handler) react(ctx context.Context, repo string, number int, comment *scm.Comment, reaction string) error {
+	reactor := h.Reactor
+	if reactor == nil {
+		reactor = NewReactor(h.Client)
+	}
+	_, err := reactor.CreateCommentReaction(ctx, repo, number, comment.ID, reaction)
+	return err
+}
+
+// rank orders the permission levels. GitHub reports the maintain
+// and triage roles, which rank with write and read.
+func rank(perm string) int {
+	switch perm {
+	case scm.AdminPermission:
+		return 3
+	case scm.WritePermission, "maintain":
+		return 2
+	case scm.ReadPermission, "triage":
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package chatops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/fake"
+	"github.com/slimm609/go-scm/scm/driver/github"
+)
+
+func TestParse(t *testing.T) {
+	body := "Thanks!\n/retest unit e2e\n> /approve\n```\n/hold\n```\n  /LGTM\n/"
+	want := []Command{
+		{Name: "retest", Args: []string{"unit", "e2e"}, Line: "/retest unit e2e"},
+		{Name: "lgtm", Args: []string{}, Line: "/LGTM"},
+	}
+	if diff := cmp.Diff(want, Parse(body)); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+// reactions records the reactions added to comments.
+type reactions []string
+
+func (r *reactions) CreateCommentReaction(ctx context.Context, repo string, number, id int, reaction string) (*scm.Response, error) {
+	*r = append(*r, fmt.Sprintf("%s#%d:%s", repo, id, reaction))
+	return nil, nil
+}
+
+func TestAcknowledge(t *testing.T) {
+	client, data := fake.NewDefault()
+	data.UserPermissions["octocat/hello-world"] = map[string]string{
+		"octocat": scm.AdminPermission,
+		"hubot":   scm.ReadPermission,
+	}
+	r := &reactions{}
+	h := &Handler{Client: client, Reactor: r}
+	ctx := context.Background()
+
+	comment := &scm.Comment{ID: 1, Body: "/retest", Author: scm.User{Login: "octocat"}}
+	commands, err := h.Acknowledge(ctx, "octocat/hello-world", 1, comment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(commands), 1; got != want {
+		t.Errorf("Want %d commands, got %d", want, got)
+	}
+	if err := h.Done(ctx, "octocat/hello-world", 1, comment); err != nil {
+		t.Fatal(err)
+	}
+
+	comment = &scm.Comment{ID: 2, Body: "/retest", Author: scm.User{Login: "hubot"}}
+	if _, err := h.Acknowledge(ctx, "octocat/hello-world", 1, comment); err != ErrPermission {
+		t.Errorf("Want ErrPermission, got %v", err)
+	}
+
+	want := reactions{
+		"octocat/hello-world#1:eyes",
+		"octocat/hello-world#1:rocket",
+		"octocat/hello-world#2:confused",
+	}
+	if diff := cmp.Diff(want, *r); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestNewReactor(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/repos/octocat/hello-world/issues/comments/1/reactions").
+		JSON(map[string]string{"content": ReactionEyes}).
+		Reply(201).
+		Type("application/json").
+		BodyString(`{"id": 1, "content": "eyes"}`)
+
+	client := github.NewDefault()
+	if _, err := NewReactor(client).CreateCommentReaction(context.Background(), "octocat/hello-world", 1, 1, ReactionEyes); err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Want the reaction created with the reactions API")
+	}
+}
+
+func TestAcknowledge_NotSupported(t *testing.T) {
+	client, data := fake.NewDefault()
+	data.UserPermissions["octocat/hello-world"] = map[string]string{
+		"octocat": scm.AdminPermission,
+	}
+	h := &Handler{Client: client}
+
+	comment := &scm.Comment{ID: 1, Body: "/retest", Author: scm.User{Login: "octocat"}}
+	if _, err := h.Acknowledge(context.Background(), "octocat/hello-world", 1, comment); !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Want ErrNotSupported without a Reactor, got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import "context"
+
+type (
+	// CILintResult is the result of validating a CI
+	// configuration with the provider.
+	CILintResult struct {
+		Valid    bool
+		Errors   []string
+		Warnings []string
+
+		// Merged is the configuration with its includes
+		// expanded, if returned by the provider.
+		Merged string
+	}
+
+	// CIService provides access to the CI configuration
+	// validation of the provider, such as the GitLab CI
+	// lint API.
+	//
+	// The service is optional: the CI field of the client
+	// is nil for drivers that do not support it.
+	CIService interface {
+		// Lint validates the CI configuration in the
+		// context of the repository.
+		Lint(ctx context.Context, repo string, config []byte) (*CILintResult, *Response, error)
+	}
+)
@@ -0,0 +1,173 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ciconfig fetches the CI configuration files of a
+// repository, GitHub Actions workflows, GitLab CI and Jenkins
+// pipelines, and validates them with the lint API of the
+// provider where there is one.
+package ciconfig
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// Kind identifies the CI system of a configuration file.
+type Kind string
+
+// Kind values.
+const (
+	KindGitHubActions Kind = "github-actions"
+	KindGitLabCI      Kind = "gitlab-ci"
+	KindJenkins       Kind = "jenkins"
+)
+
+// Well known configuration paths.
+const (
+	WorkflowDir  = ".github/workflows"
+	GitLabCIFile = ".gitlab-ci.yml"
+	Jenkinsfile  = "Jenkinsfile"
+)
+
+// Result is the validation result of a configuration file.
+type Result struct {
+	Path    string
+	Kind    Kind
+	Content []byte
+
+	// Linted is true if the file was validated with the
+	// lint API of the provider. Valid, Errors and Warnings
+	// otherwise only reflect the local checks.
+	Linted   bool
+	Valid    bool
+	Errors   []string
+	Warnings []string
+}
+
+// Options configures the validation.
+type Options struct {
+	// Lint enables validating the files with the lint API
+	// of the provider, if the driver supports it.
+	Lint bool
+}
+
+// Find returns the CI configuration files of the repository
+// at the given ref. The GitHub Actions workflows are looked
+// up for GitHub, the GitLab CI file for GitLab and the
+// Jenkinsfile for every driver. Missing files are skipped.
+func Find(ctx context.Context, client *scm.Client, repo, ref string) ([]*Result, error) {
+	var results []*Result
+	switch client.Driver {
+	case scm.DriverGithub:
+		paths, err := listWorkflows(ctx, client, repo, ref)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range paths {
+			result, err := find(ctx, client, repo, p, ref, KindGitHubActions)
+			if err != nil {
+				return nil, err
+			}
+			if result != nil {
+				results = append(results, result)
+			}
+		}
+	case scm.DriverGitlab:
+		result, err := find(ctx, client, repo, GitLabCIFile, ref, KindGitLabCI)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			results = append(results, result)
+		}
+	}
+	result, err := find(ctx, client, repo, Jenkinsfile, ref, KindJenkins)
+	if err != nil {
+		return nil, err
+	}
+	if result != nil {
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// Validate finds the CI configuration files of the repository
+// at the given ref and validates them. Files are checked
+// locally for being empty, and linted by the provider if
+// enabled and supported, as GitLab CI files are.
+func Validate(ctx context.Context, client *scm.Client, repo, ref string, opts Options) ([]*Result, error) {
+	results, err := Find(ctx, client, repo, ref)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		if len(bytes.TrimSpace(result.Content)) == 0 {
+			result.Errors = append(result.Errors, "configuration is empty")
+			continue
+		}
+		result.Valid = true
+		if !opts.Lint || client.CI == nil || !lintable(client.Driver, result.Kind) {
+			continue
+		}
+		lint, _, err := client.CI.Lint(ctx, repo, result.Content)
+		if errors.Is(err, scm.ErrNotSupported) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Linted = true
+		result.Valid = lint.Valid
+		result.Errors = lint.Errors
+		result.Warnings = lint.Warnings
+	}
+	return results, nil
+}
+
+// lintable returns true if the lint API of the driver
+// validates the configuration kind.
+func lintable(driver scm.Driver, kind Kind) bool {
+	return driver == scm.DriverGitlab && kind == KindGitLabCI
+}
+
+func listWorkflows(ctx context.Context, client *scm.Client, repo, ref string) ([]string, error) {
+	exists, _, err := client.Contents.Exists(ctx, repo, WorkflowDir, ref)
+	if err != nil || !exists {
+		return nil, err
+	}
+	entries, _, err := client.Contents.List(ctx, repo, WorkflowDir, ref)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, entry := range entries {
+		if entry.Type != "file" {
+			continue
+		}
+		if strings.HasSuffix(entry.Name, ".yml") || strings.HasSuffix(entry.Name, ".yaml") {
+			paths = append(paths, path.Join(WorkflowDir, entry.Name))
+		}
+	}
+	return paths, nil
+}
+
+func find(ctx context.Context, client *scm.Client, repo, file, ref string, kind Kind) (*Result, error) {
+	content, res, err := client.Contents.Find(ctx, repo, file, ref)
+	if err == scm.ErrNotFound || (err != nil && res != nil && res.Status == 404) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Result{
+		Path:    file,
+		Kind:    kind,
+		Content: content.Data,
+	}, nil
+}
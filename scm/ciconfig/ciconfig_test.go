@@ -0,0 +1,135 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ciconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/fake"
+)
+
+// ciLinter lints the configurations with a fixed result.
+type ciLinter struct {
+	result  *scm.CILintResult
+	configs []string
+}
+
+func (l *ciLinter) Lint(ctx context.Context, repo string, config []byte) (*scm.CILintResult, *scm.Response, error) {
+	l.configs = append(l.configs, string(config))
+	return l.result, nil, nil
+}
+
+func TestValidate_GitHub(t *testing.T) {
+	client, data := fake.NewDefault()
+	client.Driver = scm.DriverGithub
+	data.ContentDir = "testdata"
+
+	results, err := Validate(context.Background(), client, "octocat/hello-world", "master", Options{Lint: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type summary struct {
+		Path   string
+		Kind   Kind
+		Linted bool
+		Valid  bool
+		Errors []string
+	}
+	var got []summary
+	for _, result := range results {
+		got = append(got, summary{result.Path, result.Kind, result.Linted, result.Valid, result.Errors})
+	}
+	want := []summary{
+		{".github/workflows/build.yml", KindGitHubActions, false, true, nil},
+		{".github/workflows/release.yaml", KindGitHubActions, false, false, []string{"configuration is empty"}},
+		{"Jenkinsfile", KindJenkins, false, true, nil},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestValidate_GitLab(t *testing.T) {
+	client, data := fake.NewDefault()
+	client.Driver = scm.DriverGitlab
+	data.ContentDir = "testdata"
+	linter := &ciLinter{
+		result: &scm.CILintResult{
+			Valid:    false,
+			Errors:   []string{"jobs:build config contains unknown keys: scripts"},
+			Warnings: []string{},
+		},
+	}
+	client.CI = linter
+
+	results, err := Validate(context.Background(), client, "octocat/hello-world", "master", Options{Lint: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(results), 2; got != want {
+		t.Fatalf("Want %d results, got %d", want, got)
+	}
+
+	gitlab := results[0]
+	if got, want := gitlab.Path, GitLabCIFile; got != want {
+		t.Errorf("Want path %q, got %q", want, got)
+	}
+	if !gitlab.Linted || gitlab.Valid {
+		t.Errorf("Want the GitLab CI file linted and invalid, got linted %v valid %v", gitlab.Linted, gitlab.Valid)
+	}
+	if diff := cmp.Diff(gitlab.Errors, linter.result.Errors); diff != "" {
+		t.Errorf("Unexpected lint errors")
+		t.Log(diff)
+	}
+	if diff := cmp.Diff(linter.configs, []string{"build:\n  script:\n  - make\n"}); diff != "" {
+		t.Errorf("Want only the GitLab CI file linted")
+		t.Log(diff)
+	}
+
+	jenkins := results[1]
+	if jenkins.Kind != KindJenkins || jenkins.Linted || !jenkins.Valid {
+		t.Errorf("Want the Jenkinsfile valid and not linted, got %+v", jenkins)
+	}
+}
+
+func TestValidate_NoLint(t *testing.T) {
+	client, data := fake.NewDefault()
+	client.Driver = scm.DriverGitlab
+	data.ContentDir = "testdata"
+	linter := &ciLinter{result: &scm.CILintResult{Valid: true}}
+	client.CI = linter
+
+	results, err := Validate(context.Background(), client, "octocat/hello-world", "master", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, result := range results {
+		if result.Linted {
+			t.Errorf("Want %s not linted", result.Path)
+		}
+	}
+	if len(linter.configs) != 0 {
+		t.Errorf("Want the lint API not called")
+	}
+}
+
+func TestFind_Missing(t *testing.T) {
+	client, data := fake.NewDefault()
+	client.Driver = scm.DriverGithub
+	data.ContentDir = "testdata"
+
+	results, err := Find(context.Background(), client, "octocat/missing", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Want no configuration, got %d", len(results))
+	}
+}
@@ -5,9 +5,14 @@
 package scm
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"mime"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -40,13 +45,26 @@ type (
 
 	// Response represents an HTTP response.
 	Response struct {
+		// ID is the request ID.
+		//
+		// Deprecated: use RequestID.
 		ID     string
 		Status int
 		Header http.Header
 		Body   io.ReadCloser
 
+		// RequestID is the ID the provider assigned to the
+		// request, taken from the first of the RequestIDHeaders
+		// in the response. It is useful to correlate errors
+		// with provider support tickets and logs.
+		RequestID string
+
 		Page Page // Page values
 		Rate Rate // Rate limit snapshot
+
+		// Raw is the body of a JSON response, which is kept
+		// if the request is made with a context of WithRaw.
+		Raw json.RawMessage `json:"-"`
 	}
 
 	// Page represents parsed link rel values for
@@ -75,6 +93,10 @@ type (
 		Size int
 	}
 
+	// DryRunFunc receives the mutating requests intercepted
+	// in dry-run mode together with their would-be payload.
+	DryRunFunc func(req *Request, payload []byte)
+
 	// GraphQLService the API to performing GraphQL queries
 	GraphQLService interface {
 		Query(ctx context.Context, q interface{}, vars map[string]interface{}) error
@@ -94,7 +116,9 @@ type (
 
 		// Services used for communicating with the API.
 		Driver        Driver
+		Admin         AdminService
 		Apps          AppService
+		CI            CIService
 		Contents      ContentService
 		Deployments   DeploymentService
 		Git           GitService
@@ -102,10 +126,14 @@ type (
 		Organizations OrganizationService
 		Issues        IssueService
 		Milestones    MilestoneService
+		Provisioning  ProvisioningService
 		PullRequests  PullRequestService
 		Repositories  RepositoryService
 		Reviews       ReviewService
+		Runners       RunnerService
+		Security      SecurityService
 		Users         UserService
+		Variables     VariableService
 		Webhooks      WebhookService
 
 		// DumpResponse optionally specifies a function to
@@ -113,8 +141,38 @@ type (
 		// This can be set to httputil.DumpResponse.
 		DumpResponse func(*http.Response, bool) ([]byte, error)
 
+		// DryRun optionally enables the dry-run mode. Mutating
+		// requests (POST, PUT, PATCH and DELETE, except GraphQL
+		// queries) are not sent, they are passed to the
+		// function instead and a synthesized successful
+		// response with an empty JSON object is returned.
+		DryRun DryRunFunc
+
+		// Sudo optionally specifies the user the requests are
+		// made on behalf of, see WithSudo to impersonate a
+		// user for a single call.
+		Sudo string
+
+		// Limits optionally specifies the list and search
+		// options accepted by the provider. Requests with a
+		// larger page size fail with an OptionError before
+		// they are sent.
+		Limits *QueryLimits
+
+		// Retry optionally specifies how the failed requests
+		// are retried, see WithRetry to override it for a
+		// single call. The requests are not retried if nil.
+		Retry *RetryPolicy
+
 		// snapshot of the request rate limit.
 		rate Rate
+
+		// memoized identity of the authenticated user.
+		identityMu sync.Mutex
+		identity   *Identity
+
+		// caller sends the API requests of Call.
+		caller CallFunc
 	}
 )
 
@@ -145,9 +203,82 @@ func (c *Client) Do(ctx context.Context, in *Request) (*Response, error) {
 	if err != nil {
 		return nil, err
 	}
+	if c.Limits != nil {
+		if err := c.Limits.validateQuery(uri.Query()); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.DryRun != nil && isMutating(in.Method) {
+		mutation, err := c.isMutation(uri, in)
+		if err != nil {
+			return nil, err
+		}
+		if mutation {
+			return c.dryRun(in)
+		}
+	}
+
+	// buffers the body of the retried requests, so it can be
+	// sent again.
+	policy := c.RetryPolicy(ctx)
+	retries := policy.retries(in.Method)
+	var payload []byte
+	if retries && in.Body != nil {
+		payload, err = ioutil.ReadAll(in.Body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// use the default client if none provided.
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
 
+	for attempt := 1; ; attempt++ {
+		body := in.Body
+		if payload != nil {
+			body = bytes.NewReader(payload)
+		}
+		req, err := c.newRequest(ctx, uri, in, body)
+		if err != nil {
+			return nil, err
+		}
+		res, err := client.Do(req)
+		if retries && attempt < policy.MaxAttempts && ctx.Err() == nil &&
+			(err != nil || policy.retryable(res.StatusCode)) {
+			if res != nil {
+				discard(res)
+			}
+			if err := sleep(ctx, policy.delay(attempt, res)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// dumps the response for debugging purposes.
+		if c.DumpResponse != nil {
+			_, err = c.DumpResponse(res, true)
+		}
+		// fails the reads of the body once the context
+		// is done.
+		res.Body = newContextBody(ctx, res.Body)
+		if err == nil && RawPayload(ctx) && isJSON(res.Header) {
+			return newRawResponse(res)
+		}
+		return newResponse(res), err
+	}
+}
+
+// newRequest creates the http request of the API request.
+func (c *Client) newRequest(ctx context.Context, uri *url.URL, in *Request, body io.Reader) (*http.Request, error) {
 	// creates a new http request with context.
-	req, err := http.NewRequest(in.Method, uri.String(), in.Body)
+	req, err := http.NewRequest(in.Method, uri.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -159,24 +290,147 @@ func (c *Client) Do(ctx context.Context, in *Request) (*Response, error) {
 
 	req = req.WithContext(ctx)
 	if in.Header != nil {
-		req.Header = in.Header
+		req.Header = in.Header.Clone()
+	}
+	if user := c.SudoUser(ctx); user != "" {
+		if err := setSudo(c.Driver, req, user); err != nil {
+			return nil, err
+		}
 	}
+	return req, nil
+}
 
-	// use the default client if none provided.
-	client := c.Client
-	if client == nil {
-		client = http.DefaultClient
+// dryRun passes the request to the dry-run function and
+// returns a synthesized successful response.
+func (c *Client) dryRun(in *Request) (*Response, error) {
+	var payload []byte
+	if in.Body != nil {
+		var err error
+		payload, err = ioutil.ReadAll(in.Body)
+		if err != nil {
+			return nil, err
+		}
+	}
+	c.DryRun(in, payload)
+
+	// the payload is not echoed, since the input types of the
+	// drivers do not decode into their output types.
+	return &Response{
+		Status: http.StatusOK,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+			"X-Dry-Run":    {"true"},
+		},
+		Body: ioutil.NopCloser(strings.NewReader("{}")),
+	}, nil
+}
+
+// isMutation returns true if the request modifies the
+// resource. GraphQL queries are sent as POST requests, so
+// the requests to the GraphQL endpoint are classified by
+// their operation instead of their method.
+func (c *Client) isMutation(uri *url.URL, in *Request) (bool, error) {
+	if c.GraphQLURL == nil || uri.String() != c.GraphQLURL.String() || in.Body == nil {
+		return true, nil
+	}
+	payload, err := ioutil.ReadAll(in.Body)
+	if err != nil {
+		return false, err
+	}
+	in.Body = bytes.NewReader(payload)
+	return isGraphQLMutation(payload), nil
+}
+
+// isGraphQLMutation returns true if the GraphQL request
+// payload holds a mutation. Payloads that cannot be parsed
+// are considered mutations.
+func isGraphQLMutation(payload []byte) bool {
+	in := struct {
+		Query string `json:"query"`
+	}{}
+	if err := json.Unmarshal(payload, &in); err != nil {
+		return true
+	}
+	return strings.HasPrefix(strings.TrimSpace(in.Query), "mutation")
+}
+
+// DryRunTransport returns a http.RoundTripper sending the
+// requests with base, except for the mutating requests in
+// dry-run mode, which are passed to the DryRun function of
+// the client. Drivers use it for the http clients of the
+// provider SDKs, whose requests do not go through Do.
+func (c *Client) DryRunTransport(base http.RoundTripper) http.RoundTripper {
+	return &dryRunTransport{client: c, base: base}
+}
+
+type dryRunTransport struct {
+	client *Client
+	base   http.RoundTripper
+}
+
+func (t *dryRunTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if t.client.DryRun == nil || !isMutating(r.Method) {
+		return t.base.RoundTrip(r)
+	}
+	path := r.URL.RequestURI()
+	if t.client.BaseURL != nil {
+		path = strings.TrimPrefix(path, t.client.BaseURL.Path)
 	}
-	res, err := client.Do(req)
+	in := &Request{
+		Method: r.Method,
+		Path:   path,
+		Header: r.Header,
+	}
+	if r.Body != nil {
+		defer r.Body.Close()
+		in.Body = r.Body
+	}
+	res, err := t.client.dryRun(in)
 	if err != nil {
 		return nil, err
 	}
+	status := res.Status
+	if r.Method == http.MethodDelete {
+		status = http.StatusNoContent
+	}
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode: status,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     res.Header,
+		Body:       res.Body,
+		Request:    r,
+	}, nil
+}
 
-	// dumps the response for debugging purposes.
-	if c.DumpResponse != nil {
-		_, err = c.DumpResponse(res, true)
+// DryRunLog returns a DryRunFunc writing the intercepted
+// requests and their payload to w.
+func DryRunLog(w io.Writer) DryRunFunc {
+	return func(req *Request, payload []byte) {
+		fmt.Fprintf(w, "dry-run: %s %s %s\n", req.Method, req.Path, bytes.TrimSpace(payload))
 	}
-	return newResponse(res), err
+}
+
+// isMutating returns true if requests with the method
+// modify the resource.
+func isMutating(method string) bool {
+	switch strings.ToUpper(method) {
+	case "POST", "PUT", "PATCH", "DELETE":
+		return true
+	}
+	return false
+}
+
+// RequestIDHeaders are the response headers holding the
+// request ID, in order of precedence.
+var RequestIDHeaders = []string{
+	"X-GitHub-Request-Id",
+	"X-Request-Id",
+	"X-Amzn-RequestId",
+	"X-Arequestid",
+	"X-Correlation-Id",
 }
 
 // newResponse creates a new Response for the provided
@@ -187,10 +441,46 @@ func newResponse(r *http.Response) *Response {
 		Header: r.Header,
 		Body:   r.Body,
 	}
+	res.RequestID = RequestIDOf(r.Header)
+	res.ID = res.RequestID
 	res.PopulatePageValues()
 	return res
 }
 
+// RequestIDOf returns the request ID of the response header,
+// taken from the first of the RequestIDHeaders it holds.
+// Drivers use it for the responses of the provider SDKs, which
+// are not created by Do.
+func RequestIDOf(header http.Header) string {
+	for _, key := range RequestIDHeaders {
+		if id := header.Get(key); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// isJSON returns true if the content type of the header is
+// JSON, e.g. application/json or application/vnd.api+json.
+func isJSON(header http.Header) bool {
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// newRawResponse reads the body of the response, which is
+// kept in Raw and can still be read from Body.
+func newRawResponse(r *http.Response) (*Response, error) {
+	raw, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(raw))
+	res := newResponse(r)
+	res.Raw = raw
+	return res, nil
+}
+
 // PopulatePageValues parses the HTTP Link response headers
 // and populates the various pagination link values in the
 // Response.
@@ -5,7 +5,14 @@
 package scm
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 )
 
@@ -39,3 +46,288 @@ func TestResponse(t *testing.T) {
 		t.Errorf("Want rel next %d, got %d", want, got)
 	}
 }
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestClientDryRun(t *testing.T) {
+	var sent []string
+	var logged bytes.Buffer
+	client := &Client{
+		BaseURL: &url.URL{Scheme: "https", Host: "api.github.com", Path: "/"},
+		Client: &http.Client{
+			Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				sent = append(sent, r.Method)
+				return nil, errors.New("unexpected request")
+			}),
+		},
+		DryRun: DryRunLog(&logged),
+	}
+
+	res, err := client.Do(context.Background(), &Request{
+		Method: "POST",
+		Path:   "repos/octocat/hello-world/statuses/6dcb09b",
+		Body:   strings.NewReader(`{"state":"success"}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := res.Status, 200; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+	body, _ := ioutil.ReadAll(res.Body)
+	if got, want := string(body), "{}"; got != want {
+		t.Errorf("Want empty object body, got %s", got)
+	}
+	if got, want := logged.String(), "dry-run: POST repos/octocat/hello-world/statuses/6dcb09b {\"state\":\"success\"}\n"; got != want {
+		t.Errorf("Want log %q, got %q", want, got)
+	}
+
+	res, err = client.Do(context.Background(), &Request{
+		Method: "DELETE",
+		Path:   "repos/octocat/hello-world/hooks/1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ = ioutil.ReadAll(res.Body)
+	if got, want := string(body), "{}"; got != want {
+		t.Errorf("Want empty object body, got %s", got)
+	}
+	if len(sent) != 0 {
+		t.Errorf("Want mutating requests not sent, got %v", sent)
+	}
+
+	client.Do(context.Background(), &Request{Method: "GET", Path: "user"})
+	if len(sent) != 1 {
+		t.Errorf("Want read requests sent, got %v", sent)
+	}
+}
+
+func TestClientDryRunGraphQL(t *testing.T) {
+	var sent []string
+	var logged bytes.Buffer
+	client := &Client{
+		BaseURL:    &url.URL{Scheme: "https", Host: "api.github.com", Path: "/"},
+		GraphQLURL: &url.URL{Scheme: "https", Host: "api.github.com", Path: "/graphql"},
+		Client: &http.Client{
+			Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				body, _ := ioutil.ReadAll(r.Body)
+				sent = append(sent, string(body))
+				return &http.Response{
+					StatusCode: 200,
+					Header:     http.Header{},
+					Body:       ioutil.NopCloser(strings.NewReader(`{"data":{}}`)),
+				}, nil
+			}),
+		},
+		DryRun: DryRunLog(&logged),
+	}
+
+	query := `{"query":"query { viewer { login } }"}`
+	if _, err := client.Do(context.Background(), &Request{
+		Method: "POST",
+		Path:   "https://api.github.com/graphql",
+		Body:   strings.NewReader(query),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != 1 || sent[0] != query {
+		t.Errorf("Want GraphQL query sent with its payload, got %v", sent)
+	}
+
+	mutation := `{"query":"mutation { addStar(input: {starrableId: \"1\"}) { clientMutationId } }"}`
+	if _, err := client.Do(context.Background(), &Request{
+		Method: "POST",
+		Path:   "https://api.github.com/graphql",
+		Body:   strings.NewReader(mutation),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != 1 {
+		t.Errorf("Want GraphQL mutation not sent, got %v", sent)
+	}
+	if logged.Len() == 0 {
+		t.Errorf("Want GraphQL mutation passed to the dry-run function")
+	}
+}
+
+func TestClientDryRunTransport(t *testing.T) {
+	var sent []string
+	var logged bytes.Buffer
+	client := &Client{
+		BaseURL: &url.URL{Scheme: "https", Host: "try.gitea.io", Path: "/"},
+		DryRun:  DryRunLog(&logged),
+	}
+	httpClient := &http.Client{
+		Transport: client.DryRunTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			sent = append(sent, r.Method)
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader("{}")),
+			}, nil
+		})),
+	}
+
+	res, err := httpClient.Post("https://try.gitea.io/api/v1/repos/go-gitea/gitea/issues", "application/json", strings.NewReader(`{"title":"bug"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(res.Body)
+	if got, want := string(body), "{}"; got != want {
+		t.Errorf("Want empty object body, got %s", got)
+	}
+	if got, want := logged.String(), "dry-run: POST api/v1/repos/go-gitea/gitea/issues {\"title\":\"bug\"}\n"; got != want {
+		t.Errorf("Want log %q, got %q", want, got)
+	}
+
+	req, _ := http.NewRequest("DELETE", "https://try.gitea.io/api/v1/repos/go-gitea/gitea/branches/feature", nil)
+	res, err = httpClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := res.StatusCode, 204; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+	if len(sent) != 0 {
+		t.Errorf("Want mutating requests not sent, got %v", sent)
+	}
+
+	httpClient.Get("https://try.gitea.io/api/v1/version")
+	if len(sent) != 1 {
+		t.Errorf("Want read requests sent, got %v", sent)
+	}
+}
+
+func TestResponseRequestID(t *testing.T) {
+	tests := []struct {
+		header http.Header
+		want   string
+	}{
+		{http.Header{"X-Github-Request-Id": {"DD0E:6011"}, "X-Request-Id": {"other"}}, "DD0E:6011"},
+		{http.Header{"X-Request-Id": {"0d511a76"}}, "0d511a76"},
+		{http.Header{"X-Arequestid": {"@1F2KZ3x"}}, "@1F2KZ3x"},
+		{http.Header{}, ""},
+	}
+	for _, test := range tests {
+		res := newResponse(&http.Response{Header: test.header})
+		if got := res.RequestID; got != test.want {
+			t.Errorf("Want request id %q, got %q", test.want, got)
+		}
+		if got := res.ID; got != test.want {
+			t.Errorf("Want id %q, got %q", test.want, got)
+		}
+	}
+}
+
+func TestClientSudo(t *testing.T) {
+	var sudo []string
+	client := &Client{
+		Driver:  DriverGitlab,
+		BaseURL: &url.URL{Scheme: "https", Host: "gitlab.com", Path: "/"},
+		Sudo:    "root",
+		Client: &http.Client{
+			Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				sudo = append(sudo, r.Header.Get("Sudo"))
+				return &http.Response{StatusCode: 200, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader("{}"))}, nil
+			}),
+		},
+	}
+	ctx := context.Background()
+	for _, c := range []context.Context{ctx, WithSudo(ctx, "jcitizen")} {
+		res, err := client.Do(c, &Request{Method: "GET", Path: "api/v4/user"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+	if sudo[0] != "root" || sudo[1] != "jcitizen" {
+		t.Errorf("Want Sudo headers root and jcitizen, got %q", sudo)
+	}
+
+	header := http.Header{"Accept": {"application/json"}}
+	res, err := client.Do(ctx, &Request{Method: "GET", Path: "api/v4/user", Header: header})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if got := header.Get("Sudo"); got != "" {
+		t.Errorf("Want the header of the request unchanged, got Sudo %q", got)
+	}
+
+	client.Driver = DriverGithub
+	if _, err := client.Do(ctx, &Request{Method: "GET", Path: "user"}); err != ErrNotSupported {
+		t.Errorf("Want ErrNotSupported impersonating a user on GitHub, got %v", err)
+	}
+}
+
+func TestClientRaw(t *testing.T) {
+	client := &Client{
+		BaseURL: &url.URL{Scheme: "https", Host: "api.github.com", Path: "/"},
+		Client: &http.Client{
+			Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				if strings.HasSuffix(r.URL.Path, ".tar.gz") {
+					return &http.Response{
+						StatusCode: 200,
+						Header:     http.Header{"Content-Type": {"application/x-gzip"}},
+						Body:       ioutil.NopCloser(strings.NewReader("archive")),
+					}, nil
+				}
+				return &http.Response{
+					StatusCode: 200,
+					Header:     http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+					Body:       ioutil.NopCloser(strings.NewReader(`{"id":1,"node_id":"MDEw"}`)),
+				}, nil
+			}),
+		},
+	}
+
+	res, err := client.Do(context.Background(), &Request{Method: "GET", Path: "repos/octocat/hello-world"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Raw != nil {
+		t.Errorf("Want no raw payload without WithRaw, got %s", res.Raw)
+	}
+
+	res, err = client.Do(WithRaw(context.Background()), &Request{Method: "GET", Path: "repos/octocat/hello-world"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(res.Body)
+	if want := `{"id":1,"node_id":"MDEw"}`; string(res.Raw) != want || string(body) != want {
+		t.Errorf("Want the raw payload and body %s, got %s and %s", want, res.Raw, body)
+	}
+	if out, err := json.Marshal(&Response{Raw: res.Raw}); err != nil || bytes.Contains(out, []byte("MDEw")) {
+		t.Errorf("Want the raw payload not encoded to JSON, got %s", out)
+	}
+
+	res, err = client.Do(WithRaw(context.Background()), &Request{Method: "GET", Path: "repos/octocat/hello-world/tarball/master.tar.gz"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Raw != nil {
+		t.Errorf("Want no raw payload of an archive, got %s", res.Raw)
+	}
+}
+
+func TestClientCall(t *testing.T) {
+	client := &Client{Driver: DriverGitiles}
+	if _, err := client.Call(context.Background(), "GET", "projects/", nil, nil); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Want not supported error without caller, got %v", err)
+	}
+
+	client.SetCaller(func(ctx context.Context, method, path string, in, out interface{}) (*Response, error) {
+		*out.(*string) = method + " " + path
+		return &Response{Status: 200}, nil
+	})
+	var got string
+	if _, err := client.Call(context.Background(), "GET", "projects/", nil, &got); err != nil {
+		t.Fatal(err)
+	}
+	if want := "GET projects/"; got != want {
+		t.Errorf("Want the request sent by the caller %q, got %q", want, got)
+	}
+}
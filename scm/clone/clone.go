@@ -0,0 +1,187 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package clone provides helpers to build authenticated
+// clone URLs and git credentials for a repository, using the
+// username each provider expects alongside an access token.
+package clone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/transport"
+	"github.com/slimm609/go-scm/scm/transport/oauth2"
+	xoauth2 "golang.org/x/oauth2"
+)
+
+// ErrNoCredentials is returned when the credentials cannot
+// be found in the client transport.
+var ErrNoCredentials = errors.New("clone: no credentials found in the client transport")
+
+// Credentials are the username and password used to clone
+// a repository over https.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// TokenCredentials returns the credentials authenticating
+// git over https with the access token:
+//
+//	GitHub            x-access-token:<token>
+//	GitLab            oauth2:<token>
+//	Bitbucket         x-token-auth:<token>
+//	Gitea and Gogs    <token>:x-oauth-basic
+//
+// Other drivers use the token as the password of an oauth2
+// user.
+func TokenCredentials(driver scm.Driver, token string) Credentials {
+	switch driver {
+	case scm.DriverGithub:
+		return Credentials{Username: "x-access-token", Password: token}
+	case scm.DriverBitbucket, scm.DriverStash:
+		return Credentials{Username: "x-token-auth", Password: token}
+	case scm.DriverGitea, scm.DriverGogs:
+		return Credentials{Username: token, Password: "x-oauth-basic"}
+	}
+	return Credentials{Username: "oauth2", Password: token}
+}
+
+// FromClient returns the credentials of the client, read
+// from the authorization transport of its http client.
+// ErrNoCredentials is returned if the transport is not one of
+// the transports of this module or golang.org/x/oauth2.
+func FromClient(ctx context.Context, client *scm.Client) (Credentials, error) {
+	if client.Client == nil {
+		return Credentials{}, ErrNoCredentials
+	}
+	rt := client.Client.Transport
+	for rt != nil {
+		switch t := rt.(type) {
+		case *transport.BasicAuth:
+			return Credentials{Username: t.Username, Password: t.Password}, nil
+		case *transport.BearerToken:
+			return TokenCredentials(client.Driver, t.Token), nil
+		case *transport.PrivateToken:
+			return TokenCredentials(client.Driver, t.Token), nil
+		case *transport.Authorization:
+			return TokenCredentials(client.Driver, t.Credentials), nil
+		case *oauth2.Transport:
+			token, err := t.Source.Token(ctx)
+			if err != nil {
+				return Credentials{}, err
+			}
+			if token == nil {
+				return Credentials{}, ErrNoCredentials
+			}
+			return TokenCredentials(client.Driver, token.Token), nil
+		case *xoauth2.Transport:
+			token, err := t.Source.Token()
+			if err != nil {
+				return Credentials{}, err
+			}
+			return TokenCredentials(client.Driver, token.AccessToken), nil
+		case *transport.Audit:
+			rt = t.Base
+		default:
+			rt = nil
+		}
+	}
+	return Credentials{}, ErrNoCredentials
+}
+
+// HTTPS returns the https clone URL of the repository with
+// the credentials embedded.
+func HTTPS(repo *scm.Repository, creds Credentials) (string, error) {
+	if repo.Clone == "" {
+		return "", fmt.Errorf("clone: repository %s has no https clone url", repo.FullName)
+	}
+	u, err := url.Parse(repo.Clone)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "", fmt.Errorf("clone: %s is not an http clone url", repo.Clone)
+	}
+	u.User = nil
+	if creds.Username != "" || creds.Password != "" {
+		u.User = url.UserPassword(creds.Username, creds.Password)
+	}
+	return u.String(), nil
+}
+
+var scpRe = regexp.MustCompile(`^(?:([^@/]+)@)?([^:/]+):(.*)$`)
+
+// SSH returns the ssh clone URL of the repository in the
+// ssh://user@host/path form. The scp-like syntax reported by
+// most providers, e.g. git@github.com:octocat/hello-world.git,
+// is converted.
+func SSH(repo *scm.Repository) (string, error) {
+	if repo.CloneSSH == "" {
+		return "", fmt.Errorf("clone: repository %s has no ssh clone url", repo.FullName)
+	}
+	return NormalizeSSH(repo.CloneSSH)
+}
+
+// NormalizeSSH converts the ssh clone URL to the
+// ssh://user@host/path form.
+func NormalizeSSH(s string) (string, error) {
+	if strings.Contains(s, "://") {
+		u, err := url.Parse(s)
+		if err != nil {
+			return "", err
+		}
+		if u.Scheme != "ssh" {
+			return "", fmt.Errorf("clone: %s is not an ssh clone url", s)
+		}
+		return u.String(), nil
+	}
+	m := scpRe.FindStringSubmatch(s)
+	if m == nil {
+		return "", fmt.Errorf("clone: %s is not an ssh clone url", s)
+	}
+	u := &url.URL{Scheme: "ssh", Host: m[2], Path: "/" + strings.TrimPrefix(m[3], "/")}
+	if m[1] != "" {
+		u.User = url.User(m[1])
+	}
+	return u.String(), nil
+}
+
+// Netrc returns the .netrc entry authenticating requests to
+// the host of the clone URL.
+func Netrc(cloneURL string, creds Credentials) (string, error) {
+	u, err := url.Parse(cloneURL)
+	if err != nil {
+		return "", err
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("clone: %s has no host", cloneURL)
+	}
+	return fmt.Sprintf("machine %s\nlogin %s\npassword %s\n", u.Hostname(), creds.Username, creds.Password), nil
+}
+
+// GitCredential returns the credential description of the
+// clone URL in the format read and written by the git
+// credential helpers, see git-credential(1).
+func GitCredential(cloneURL string, creds Credentials) (string, error) {
+	u, err := url.Parse(cloneURL)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("clone: %s is not an http clone url", cloneURL)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "protocol=%s\n", u.Scheme)
+	fmt.Fprintf(&b, "host=%s\n", u.Host)
+	fmt.Fprintf(&b, "username=%s\n", creds.Username)
+	fmt.Fprintf(&b, "password=%s\n", creds.Password)
+	return b.String(), nil
+}
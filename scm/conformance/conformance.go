@@ -0,0 +1,301 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package conformance provides a reusable test harness that
+// asserts the normalization rules every driver is expected to
+// follow, so behavioral drift between drivers is caught by the
+// driver tests rather than by downstream users.
+//
+// A driver runs the suite from its own tests, either against
+// recorded fixtures:
+//
+//	conformance.Run(t, conformance.Config{
+//		Client: client,
+//		Repo:   "octocat/hello-world",
+//		Branch: "master",
+//	})
+//
+// or against a live server configured with FromEnvironment.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// Environment variables used to configure a live run.
+const (
+	EnvRepo   = "SCM_CONFORMANCE_REPO"
+	EnvBranch = "SCM_CONFORMANCE_BRANCH"
+	EnvToken  = "SCM_CONFORMANCE_TOKEN"
+	EnvURL    = "SCM_CONFORMANCE_URL"
+)
+
+// Config configures a conformance run.
+type Config struct {
+	// Client is the driver client under test.
+	Client *scm.Client
+
+	// Repo is the full name of a repository with at least
+	// one branch and one commit.
+	Repo string
+
+	// Branch is the name of an existing branch in Repo.
+	Branch string
+
+	// Skip lists the names of the checks to skip, e.g.
+	// because the fixtures for them were not recorded.
+	Skip []string
+}
+
+// FromEnvironment returns the repository and branch of a live
+// conformance run, and false if no live run is configured.
+func FromEnvironment() (repo, branch string, ok bool) {
+	repo = os.Getenv(EnvRepo)
+	branch = os.Getenv(EnvBranch)
+	if branch == "" {
+		branch = "master"
+	}
+	return repo, branch, repo != ""
+}
+
+// check is a named conformance check.
+type check struct {
+	name string
+	fn   func(t *testing.T, config Config)
+}
+
+// checks is the table of conformance checks run by Run.
+var checks = []check{
+	{"FindRepository", checkFindRepository},
+	{"FindBranch", checkFindBranch},
+	{"FindCommit", checkFindCommit},
+	{"FindRef", checkFindRef},
+	{"ListBranches", checkListBranches},
+	{"ListBranchesPagination", checkListBranchesPagination},
+	{"ListTags", checkListTags},
+	{"NotFound", checkNotFound},
+}
+
+// Run runs the conformance checks as subtests. Checks that hit
+// an operation the driver does not support are skipped.
+func Run(t *testing.T, config Config) {
+	for _, c := range checks {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			for _, name := range config.Skip {
+				if name == c.name {
+					t.Skip("skipped by configuration")
+				}
+			}
+			c.fn(t, config)
+		})
+	}
+}
+
+var shaPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+func checkFindRepository(t *testing.T, config Config) {
+	repo, _, err := config.Client.Repositories.Find(context.Background(), config.Repo)
+	requireSupported(t, err)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.EqualFold(repo.FullName, config.Repo) {
+		t.Errorf("Want repository full name %q, got %q", config.Repo, repo.FullName)
+	}
+	if got := scm.Join(repo.Namespace, repo.Name); got != repo.FullName {
+		t.Errorf("Want namespace and name to join to the full name %q, got %q", repo.FullName, got)
+	}
+}
+
+func checkFindBranch(t *testing.T, config Config) {
+	ref, _, err := config.Client.Git.FindBranch(context.Background(), config.Repo, config.Branch)
+	requireSupported(t, err)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkBranch(t, ref)
+	if ref.Name != config.Branch {
+		t.Errorf("Want branch name %q, got %q", config.Branch, ref.Name)
+	}
+}
+
+func checkFindCommit(t *testing.T, config Config) {
+	commit, _, err := config.Client.Git.FindCommit(context.Background(), config.Repo, config.Branch)
+	requireSupported(t, err)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shaPattern.MatchString(commit.Sha) {
+		t.Errorf("Want a full commit sha, got %q", commit.Sha)
+	}
+}
+
+func checkFindRef(t *testing.T, config Config) {
+	forms := []string{
+		config.Branch,
+		"heads/" + config.Branch,
+		"refs/heads/" + config.Branch,
+	}
+	var want string
+	for _, ref := range forms {
+		sha, _, err := config.Client.Git.FindRef(context.Background(), config.Repo, ref)
+		requireSupported(t, err)
+		if err != nil {
+			t.Fatalf("Want ref %q resolved, got %s", ref, err)
+		}
+		if !shaPattern.MatchString(sha) {
+			t.Errorf("Want a full commit sha for ref %q, got %q", ref, sha)
+		}
+		if want == "" {
+			want = sha
+		} else if sha != want {
+			t.Errorf("Want ref %q to resolve to %q, got %q", ref, want, sha)
+		}
+	}
+}
+
+func checkListBranches(t *testing.T, config Config) {
+	refs, _, err := config.Client.Git.ListBranches(context.Background(), config.Repo, scm.ListOptions{Size: 100})
+	requireSupported(t, err)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(refs) == 0 {
+		t.Fatalf("Want at least one branch")
+	}
+	for _, ref := range refs {
+		checkBranch(t, ref)
+	}
+}
+
+func checkListBranchesPagination(t *testing.T, config Config) {
+	refs, _, err := config.Client.Git.ListBranches(context.Background(), config.Repo, scm.ListOptions{Page: 1, Size: 1})
+	requireSupported(t, err)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(refs) > 1 {
+		t.Errorf("Want at most one branch for a page size of one, got %d", len(refs))
+	}
+}
+
+func checkListTags(t *testing.T, config Config) {
+	refs, _, err := config.Client.Git.ListTags(context.Background(), config.Repo, scm.ListOptions{Size: 100})
+	requireSupported(t, err)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ref := range refs {
+		if strings.HasPrefix(ref.Name, "refs/") {
+			t.Errorf("Want tag name without refs/ prefix, got %q", ref.Name)
+		}
+		if ref.Path != "" && !strings.HasPrefix(ref.Path, "refs/tags/") {
+			t.Errorf("Want tag path with refs/tags/ prefix, got %q", ref.Path)
+		}
+	}
+}
+
+func checkNotFound(t *testing.T, config Config) {
+	_, res, err := config.Client.Repositories.Find(context.Background(), config.Repo+"-does-not-exist")
+	requireSupported(t, err)
+	if !errors.Is(err, scm.ErrNotFound) {
+		t.Fatalf("Want ErrNotFound finding a missing repository, got %v", err)
+	}
+	if res != nil && res.Status != http.StatusNotFound {
+		t.Errorf("Want status %d for a missing repository, got %d", http.StatusNotFound, res.Status)
+	}
+}
+
+func checkBranch(t *testing.T, ref *scm.Reference) {
+	t.Helper()
+	if ref == nil {
+		t.Fatalf("Want a branch, got nil")
+	}
+	if strings.HasPrefix(ref.Name, "refs/") {
+		t.Errorf("Want branch name without refs/ prefix, got %q", ref.Name)
+	}
+	if ref.Path != "" && ref.Path != "refs/heads/"+ref.Name {
+		t.Errorf("Want branch path %q, got %q", "refs/heads/"+ref.Name, ref.Path)
+	}
+	if !shaPattern.MatchString(ref.Sha) {
+		t.Errorf("Want a full commit sha for branch %q, got %q", ref.Name, ref.Sha)
+	}
+}
+
+// requireSupported skips the check when the driver does not
+// support the operation.
+func requireSupported(t *testing.T, err error) {
+	t.Helper()
+	if errors.Is(err, scm.ErrNotSupported) {
+		t.Skip("not supported by the driver")
+	}
+}
+
+// WebhookFixture describes a recorded webhook request and the
+// normalized hook the driver must parse it into.
+type WebhookFixture struct {
+	// Header holds the request headers that identify the
+	// event, e.g. X-GitHub-Event.
+	Header http.Header
+
+	// Body is the recorded payload.
+	Body []byte
+
+	// Kind is the expected kind of the parsed hook.
+	Kind scm.WebhookKind
+
+	// Action is the expected action of the parsed hook, if
+	// the hook has one.
+	Action scm.Action
+}
+
+// RunWebhooks asserts that the webhook service maps each fixture
+// to the expected hook kind and action.
+func RunWebhooks(t *testing.T, service scm.WebhookService, fixtures map[string]WebhookFixture) {
+	for name, fixture := range fixtures {
+		fixture := fixture
+		t.Run(name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "/", strings.NewReader(string(fixture.Body)))
+			for key, values := range fixture.Header {
+				req.Header[key] = values
+			}
+			hook, err := service.Parse(req, func(scm.Webhook) (string, error) { return "", nil })
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hook == nil {
+				t.Fatalf("Want a hook, got nil")
+			}
+			if got := hook.Kind(); got != fixture.Kind {
+				t.Errorf("Want hook kind %q, got %q", fixture.Kind, got)
+			}
+			if got, ok := hookAction(hook); ok && got != fixture.Action {
+				t.Errorf("Want hook action %s, got %s", fixture.Action, got)
+			}
+		})
+	}
+}
+
+// hookAction returns the Action field of the hook, if any.
+func hookAction(hook scm.Webhook) (scm.Action, bool) {
+	v := reflect.Indirect(reflect.ValueOf(hook))
+	if v.Kind() != reflect.Struct {
+		return 0, false
+	}
+	f := v.FieldByName("Action")
+	if !f.IsValid() || f.Type() != reflect.TypeOf(scm.Action(0)) {
+		return 0, false
+	}
+	return f.Interface().(scm.Action), true
+}
@@ -23,6 +23,9 @@ const (
 	StatePending
 	StateRunning
 	StateSuccess
+	StateNeutral
+	StateActionRequired
+	StateSkipped
 )
 
 // String returns a string representation of the State
@@ -44,6 +47,12 @@ func (s State) String() string {
 		return "expected"
 	case StateError:
 		return "error"
+	case StateNeutral:
+		return "neutral"
+	case StateActionRequired:
+		return "action_required"
+	case StateSkipped:
+		return "skipped"
 	default:
 		return "unknown"
 	}
@@ -66,6 +75,12 @@ func ToState(s string) State {
 		return StateExpected
 	case "error":
 		return StateError
+	case "neutral":
+		return StateNeutral
+	case "action_required":
+		return StateActionRequired
+	case "skipped":
+		return StateSkipped
 	default:
 		return StateUnknown
 	}
@@ -220,6 +235,11 @@ const (
 	DriverStash
 	DriverCoding
 	DriverFake
+	DriverLocal
+	DriverGitee
+	DriverSourcehut
+	DriverGitiles
+	DriverGitHTTP
 )
 
 // String returns the string representation of Driver.
@@ -241,6 +261,16 @@ func (d Driver) String() (s string) {
 		return "coding"
 	case DriverFake:
 		return "fake"
+	case DriverLocal:
+		return "local"
+	case DriverGitee:
+		return "gitee"
+	case DriverSourcehut:
+		return "sourcehut"
+	case DriverGitiles:
+		return "gitiles"
+	case DriverGitHTTP:
+		return "githttp"
 	default:
 		return "unknown"
 	}
@@ -6,7 +6,7 @@ import (
 )
 
 func TestStateJSON(t *testing.T) {
-	for i := StateUnknown; i < StateExpected; i++ {
+	for i := StateUnknown; i <= StateSkipped; i++ {
 		in := State(i)
 		t.Run(in.String(), func(t *testing.T) {
 			b, err := json.Marshal(in)
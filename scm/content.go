@@ -4,7 +4,15 @@
 
 package scm
 
-import "context"
+import (
+	"context"
+	"sync"
+)
+
+// DefaultConcurrency is the default number of requests
+// sent in parallel by the batch helpers, such as
+// FindManyContents and ResolveRefs.
+const DefaultConcurrency = 8
 
 type (
 	// Content stores the contents of a repository file.
@@ -39,9 +47,22 @@ type (
 		// Find returns the repository file content by path.
 		Find(ctx context.Context, repo, path, ref string) (*Content, *Response, error)
 
+		// FindMany returns the content of multiple repository
+		// files at the given ref, keyed by path. Files that
+		// could not be fetched are reported in the error map.
+		FindMany(ctx context.Context, repo, ref string, paths []string) (map[string]*Content, map[string]error)
+
 		// Lists the files or directories at the given path
 		List(ctx context.Context, repo, path, ref string) ([]*FileEntry, *Response, error)
 
+		// Exists returns true if the repository file exists at
+		// the given ref, without downloading the file content.
+		Exists(ctx context.Context, repo, path, ref string) (bool, *Response, error)
+
+		// Stat returns the repository file metadata by path,
+		// without downloading the file content.
+		Stat(ctx context.Context, repo, path, ref string) (*FileEntry, *Response, error)
+
 		// Create creates a new repository file.
 		Create(ctx context.Context, repo, path string, params *ContentParams) (*Response, error)
 
@@ -52,3 +73,85 @@ type (
 		Delete(ctx context.Context, repo, path, ref string) (*Response, error)
 	}
 )
+
+// ContentDataKey is the key to use with the context.WithValue
+// function to request only the data of the files found with
+// the context.
+type ContentDataKey struct{}
+
+// WithContentData returns a copy of parent in which
+// ContentService.Find only needs the data of the file. The
+// GitHub driver then downloads the raw file rather than its
+// base64 encoding, and returns the file without its Sha.
+func WithContentData(parent context.Context) context.Context {
+	return context.WithValue(parent, ContentDataKey{}, true)
+}
+
+// ContentDataOnly reports whether only the data of the files
+// found with the context is needed.
+func ContentDataOnly(ctx context.Context) bool {
+	only, _ := ctx.Value(ContentDataKey{}).(bool)
+	return only
+}
+
+// FindManyContents fetches the repository files at the given
+// ref concurrently using the Find method of the content
+// service, with at most limit requests in flight. Drivers
+// without a native batch endpoint use this to implement
+// ContentService.FindMany.
+func FindManyContents(ctx context.Context, service ContentService, repo, ref string, paths []string, limit int) (map[string]*Content, map[string]error) {
+	var mu sync.Mutex
+	contents := map[string]*Content{}
+	errs := forEachLimit(ctx, paths, limit, func(path string) error {
+		content, _, err := service.Find(ctx, repo, path, ref)
+		if err == nil {
+			mu.Lock()
+			contents[path] = content
+			mu.Unlock()
+		}
+		return err
+	})
+	return contents, errs
+}
+
+// forEachLimit invokes fn for each distinct key concurrently,
+// with at most limit invocations in flight, and returns the
+// errors keyed by the failed key.
+func forEachLimit(ctx context.Context, keys []string, limit int, fn func(key string) error) map[string]error {
+	if limit <= 0 {
+		limit = DefaultConcurrency
+	}
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, limit)
+		seen = map[string]bool{}
+		errs = map[string]error{}
+	)
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			var err error
+			select {
+			case sem <- struct{}{}:
+				err = fn(key)
+				<-sem
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+			if err != nil {
+				mu.Lock()
+				errs[key] = err
+				mu.Unlock()
+			}
+		}(key)
+	}
+	wg.Wait()
+	return errs
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+type findContentService struct {
+	ContentService
+
+	mu       sync.Mutex
+	active   int
+	maxCount int
+}
+
+func (s *findContentService) Find(ctx context.Context, repo, path, ref string) (*Content, *Response, error) {
+	s.mu.Lock()
+	s.active++
+	if s.active > s.maxCount {
+		s.maxCount = s.active
+	}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.active--
+		s.mu.Unlock()
+	}()
+
+	if path == "missing" {
+		return nil, nil, ErrNotFound
+	}
+	return &Content{Path: path, Data: []byte(repo + "@" + ref + ":" + path)}, nil, nil
+}
+
+func TestFindManyContents(t *testing.T) {
+	service := new(findContentService)
+	paths := []string{"a", "b", "c", "missing", "d", "e", "a"}
+
+	contents, errs := FindManyContents(context.Background(), service, "octocat/hello-world", "master", paths, 2)
+	if got, want := len(contents), 5; got != want {
+		t.Errorf("Want %d contents, got %d", want, got)
+	}
+	if got, want := string(contents["c"].Data), "octocat/hello-world@master:c"; got != want {
+		t.Errorf("Want content %q, got %q", want, got)
+	}
+	if got, want := errs["missing"], ErrNotFound; got != want {
+		t.Errorf("Want error %v, got %v", want, got)
+	}
+	if len(errs) != 1 {
+		t.Errorf("Want a single error, got %v", errs)
+	}
+	if service.maxCount > 2 {
+		t.Errorf("Want at most 2 concurrent requests, got %d", service.maxCount)
+	}
+}
+
+func TestFindManyContents_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	service := new(findContentService)
+	contents, errs := FindManyContents(ctx, service, "octocat/hello-world", "master", []string{"a"}, 0)
+	if len(contents)+len(errs) != 1 {
+		t.Errorf("Want a single result, got %v and %v", contents, errs)
+	}
+}
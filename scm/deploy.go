@@ -67,6 +67,34 @@ type (
 		AutoInactive    bool
 	}
 
+	// DeploymentApproval represents a deployment waiting for
+	// the approval of a reviewer, such as a GitHub workflow
+	// run waiting on a protected environment or a GitLab
+	// manual job.
+	DeploymentApproval struct {
+		// ID is the workflow run or job waiting for the
+		// approval.
+		ID   string
+		Name string
+
+		Environment   string
+		EnvironmentID int
+
+		Ref  string
+		Sha  string
+		Link string
+
+		// CanApprove is true if the current user is allowed
+		// to approve the deployment.
+		CanApprove bool
+
+		// Reviewers are the users and teams allowed to
+		// approve the deployment, if known.
+		Reviewers []string
+
+		Created time.Time
+	}
+
 	// DeploymentService a service for working with deployments and deployment services
 	DeploymentService interface {
 		// Find find a deployment by id.
@@ -89,5 +117,15 @@ type (
 
 		// Create creates a new deployment.
 		CreateStatus(ctx context.Context, repoFullName string, deploymentID string, deployment *DeploymentStatusInput) (*DeploymentStatus, *Response, error)
+
+		// ListPendingApprovals returns the deployments waiting
+		// for an approval.
+		ListPendingApprovals(ctx context.Context, repoFullName string, opts ListOptions) ([]*DeploymentApproval, *Response, error)
+
+		// ApproveDeployment approves a pending deployment.
+		ApproveDeployment(ctx context.Context, repoFullName string, approval *DeploymentApproval, comment string) (*Response, error)
+
+		// RejectDeployment rejects a pending deployment.
+		RejectDeployment(ctx context.Context, repoFullName string, approval *DeploymentApproval, comment string) (*Response, error)
 	}
 )
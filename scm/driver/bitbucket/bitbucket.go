@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -17,8 +18,12 @@ import (
 )
 
 // NewWebHookService creates a new instance of the webhook service without the rest of the client
-func NewWebHookService() scm.WebhookService {
-	return &webhookService{nil}
+func NewWebHookService(opts ...scm.WebhookServiceOptions) scm.WebhookService {
+	s := &webhookService{}
+	if len(opts) > 0 {
+		s.opts = opts[0]
+	}
+	return s
 }
 
 // New returns a new Bitbucket API client.
@@ -34,6 +39,7 @@ func New(uri string) (*scm.Client, error) {
 	client.BaseURL = base
 	// initialize services
 	client.Driver = scm.DriverBitbucket
+	client.SetCaller(client.do)
 	client.Contents = &contentService{client}
 	client.Git = &gitService{client}
 	client.Issues = &issueService{client}
@@ -43,7 +49,7 @@ func New(uri string) (*scm.Client, error) {
 	client.Repositories = &repositoryService{client}
 	client.Reviews = &reviewService{client}
 	client.Users = &userService{client}
-	client.Webhooks = &webhookService{client}
+	client.Webhooks = &webhookService{client: client}
 	return client.Client, nil
 }
 
@@ -90,7 +96,7 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	if res.Status == 401 {
 		return res, scm.ErrNotAuthorized
 	} else if res.Status > 300 {
-		err := new(Error)
+		err := &Error{status: res.Status}
 		json.NewDecoder(res.Body).Decode(err) // #nosec
 		return res, err
 	}
@@ -126,8 +132,22 @@ type Error struct {
 	Data struct {
 		Message string `json:"message"`
 	} `json:"error"`
+
+	status int
 }
 
 func (e *Error) Error() string {
 	return e.Data.Message
 }
+
+// Is returns true if target is scm.ErrNotFound and the
+// error was returned for a missing resource.
+func (e *Error) Is(target error) bool {
+	return target == scm.ErrNotFound && e.status == http.StatusNotFound
+}
+
+// notSupported returns the error of a method of a service
+// the driver does not support.
+func notSupported(service, method string) error {
+	return &scm.NotSupportedError{Driver: scm.DriverBitbucket, Service: service, Method: method}
+}
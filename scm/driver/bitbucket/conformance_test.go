@@ -0,0 +1,65 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bitbucket
+
+import (
+	"testing"
+
+	"github.com/slimm609/go-scm/scm/conformance"
+
+	"github.com/h2non/gock"
+)
+
+func TestConformance(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin-does-not-exist$").
+		Persist().
+		Reply(404).
+		Type("application/json").
+		File("testdata/error.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/repo.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/refs/branches/master$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/branch.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/refs/branches$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/branches.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/refs/tags$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/tags.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/commit/master$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/commit.json")
+
+	conformance.Run(t, conformance.Config{
+		Client: NewDefault(),
+		Repo:   "atlassian/stash-example-plugin",
+		Branch: "master",
+	})
+}
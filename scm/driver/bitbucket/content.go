@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/slimm609/go-scm/scm"
 )
@@ -26,18 +27,67 @@ func (s *contentService) Find(ctx context.Context, repo, path, ref string) (*scm
 	}, res, err
 }
 
+func (s *contentService) FindMany(ctx context.Context, repo, ref string, paths []string) (map[string]*scm.Content, map[string]error) {
+	return scm.FindManyContents(ctx, s, repo, ref, paths, scm.DefaultConcurrency)
+}
+
 func (s *contentService) List(ctx context.Context, repo, path, ref string) ([]*scm.FileEntry, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Contents", "List")
+}
+
+func (s *contentService) Exists(ctx context.Context, repo, path, ref string) (bool, *scm.Response, error) {
+	_, res, err := s.Stat(ctx, repo, path, ref)
+	if res != nil && res.Status == 404 {
+		return false, res, nil
+	}
+	return err == nil, res, err
+}
+
+func (s *contentService) Stat(ctx context.Context, repo, path, ref string) (*scm.FileEntry, *scm.Response, error) {
+	endpoint := fmt.Sprintf("/2.0/repositories/%s/src/%s/%s?format=meta", repo, ref, path)
+	out := new(contentMeta)
+	res, err := s.client.do(ctx, "GET", endpoint, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertContentMeta(out), res, nil
 }
 
 func (s *contentService) Create(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Contents", "Create")
 }
 
 func (s *contentService) Update(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Contents", "Update")
 }
 
 func (s *contentService) Delete(ctx context.Context, repo, path, ref string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Contents", "Delete")
+}
+
+type contentMeta struct {
+	Path  string `json:"path"`
+	Type  string `json:"type"`
+	Size  int    `json:"size"`
+	Links struct {
+		Self link `json:"self"`
+	} `json:"links"`
+}
+
+func convertContentMeta(from *contentMeta) *scm.FileEntry {
+	t := "file"
+	if from.Type == "commit_directory" {
+		t = "dir"
+	}
+	name := from.Path
+	if i := strings.LastIndex(name, "/"); i != -1 {
+		name = name[i+1:]
+	}
+	return &scm.FileEntry{
+		Name: name,
+		Path: from.Path,
+		Type: t,
+		Size: from.Size,
+		Link: from.Links.Self.Href,
+	}
 }
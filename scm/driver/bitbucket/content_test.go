@@ -7,6 +7,7 @@ package bitbucket
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"testing"
 
@@ -41,10 +42,57 @@ func TestContentFind(t *testing.T) {
 	}
 }
 
+func TestContentStat(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/src/425863f9dbe56d70c8dcdbf2e4e0805e85591fcc/README").
+		MatchParam("format", "meta").
+		Reply(200).
+		Type("application/json").
+		File("testdata/content_meta.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Contents.Stat(context.Background(), "atlassian/atlaskit", "README", "425863f9dbe56d70c8dcdbf2e4e0805e85591fcc")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.FileEntry)
+	raw, _ := ioutil.ReadFile("testdata/content_meta.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestContentExists(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/src/master/MISSING").
+		MatchParam("format", "meta").
+		Reply(404).
+		Type("application/json").
+		BodyString(`{"type":"error","error":{"message":"No such file or directory: MISSING"}}`)
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Contents.Exists(context.Background(), "atlassian/atlaskit", "MISSING", "master")
+	if err != nil {
+		t.Error(err)
+	}
+	if got {
+		t.Errorf("Expect file does not exist")
+	}
+}
+
 func TestContentCreate(t *testing.T) {
 	content := new(contentService)
 	_, err := content.Create(context.Background(), "atlassian/atlaskit", "README", nil)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -52,7 +100,7 @@ func TestContentCreate(t *testing.T) {
 func TestContentUpdate(t *testing.T) {
 	content := new(contentService)
 	_, err := content.Update(context.Background(), "atlassian/atlaskit", "README", nil)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -60,7 +108,7 @@ func TestContentUpdate(t *testing.T) {
 func TestContentDelete(t *testing.T) {
 	content := new(contentService)
 	_, err := content.Delete(context.Background(), "atlassian/atlaskit", "README", "master")
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -18,20 +18,15 @@ type gitService struct {
 }
 
 func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *scm.Response, error) {
+	ref = scm.TrimRef(scm.QualifyRef(ref))
 	commit, res, err := s.FindCommit(ctx, repo, ref)
-	if err != nil && res.Status != 404 {
+	if err != nil {
 		return "", res, err
 	}
-	if commit != nil {
-		if commit.Sha != "" {
-			return commit.Sha, res, nil
-		}
-	}
-	idx := strings.LastIndex(ref, "/")
-	if idx >= 0 {
-		ref = ref[idx+1:]
+	if commit == nil || commit.Sha == "" {
+		return "", res, scm.ErrNotFound
 	}
-	return ref, nil, nil
+	return commit.Sha, res, nil
 
 	/*
 		path := fmt.Sprintf("2.0/repositories/%s/refs?%s", repo, encodeRefQueryOptions(ref))
@@ -51,12 +46,53 @@ func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *sc
 	*/
 }
 
+func (s *gitService) ResolveRefs(ctx context.Context, repo string, refs []string) (map[string]string, map[string]error) {
+	return scm.ResolveRefs(ctx, s, repo, refs, scm.DefaultConcurrency)
+}
+
 func (s *gitService) CreateRef(ctx context.Context, repo, ref, sha string) (*scm.Reference, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	kind, name, ok := splitRef(ref)
+	if !ok {
+		return nil, nil, notSupported("Git", "CreateRef")
+	}
+	path := fmt.Sprintf("2.0/repositories/%s/refs/%s", repo, kind)
+	in := new(refInput)
+	in.Name = name
+	in.Target.Hash = sha
+	out := new(branch)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	if kind == "tags" {
+		return convertTag(out), res, err
+	}
+	return convertBranch(out), res, err
+}
+
+// UpdateRef is not supported: Bitbucket Cloud cannot move an
+// existing branch or tag in place.
+func (s *gitService) UpdateRef(ctx context.Context, repo, ref, sha string, force bool) (*scm.Reference, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "UpdateRef")
 }
 
 func (s *gitService) DeleteRef(ctx context.Context, repo, ref string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	kind, name, ok := splitRef(ref)
+	if !ok {
+		return nil, notSupported("Git", "DeleteRef")
+	}
+	path := fmt.Sprintf("2.0/repositories/%s/refs/%s/%s", repo, kind, name)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+// splitRef returns the Bitbucket ref collection (branches or
+// tags) and short name of the given ref.
+func splitRef(ref string) (kind, name string, ok bool) {
+	ref = scm.QualifyRef(ref)
+	switch {
+	case strings.HasPrefix(ref, "refs/heads/"):
+		return "branches", scm.TrimRef(ref), true
+	case strings.HasPrefix(ref, "refs/tags/"):
+		return "tags", scm.TrimRef(ref), true
+	}
+	return "", "", false
 }
 
 func (s *gitService) FindBranch(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
@@ -124,6 +160,21 @@ func (s *gitService) ListChanges(ctx context.Context, repo, ref string, opts scm
 	return convertDiffstats(out), res, err
 }
 
+func (s *gitService) CompareCommits(ctx context.Context, repo, base, head string) (*scm.Comparison, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "CompareCommits")
+}
+
+func (s *gitService) CompareAcrossForks(ctx context.Context, baseRepo, baseRef, headOwner, headRef string) (*scm.Comparison, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "CompareAcrossForks")
+}
+
+type refInput struct {
+	Name   string `json:"name"`
+	Target struct {
+		Hash string `json:"hash"`
+	} `json:"target"`
+}
+
 type branch struct {
 	Type   string `json:"type"`
 	Name   string `json:"name"`
@@ -7,6 +7,7 @@ package bitbucket
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"testing"
 
@@ -204,3 +205,49 @@ func TestGitListChanges(t *testing.T) {
 		t.Log(diff)
 	}
 }
+
+func TestGitCreateRef(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Post("/2.0/repositories/atlassian/stash-example-plugin/refs/branches").
+		Reply(201).
+		Type("application/json").
+		File("testdata/branch.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Git.CreateRef(context.Background(), "atlassian/stash-example-plugin", "master", "a6e5e7d797edf751cbd839d6bd4aef86c941eec9")
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(scm.Reference)
+	raw, _ := ioutil.ReadFile("testdata/branch.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitDeleteRef(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Delete("/2.0/repositories/atlassian/stash-example-plugin/refs/tags/v1.0.0").
+		Reply(204)
+
+	client, _ := New("https://api.bitbucket.org")
+	_, err := client.Git.DeleteRef(context.Background(), "atlassian/stash-example-plugin", "refs/tags/v1.0.0")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGitUpdateRef(t *testing.T) {
+	_, _, err := NewDefault().Git.UpdateRef(context.Background(), "atlassian/stash-example-plugin", "master", "a6e5e7d797edf751cbd839d6bd4aef86c941eec9", true)
+	if !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error")
+	}
+}
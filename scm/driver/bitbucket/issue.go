@@ -15,85 +15,109 @@ type issueService struct {
 }
 
 func (s *issueService) Search(context.Context, scm.SearchOptions) ([]*scm.SearchIssue, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Issues", "Search")
 }
 
 func (s *issueService) AssignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "AssignIssue")
 }
 
 func (s *issueService) UnassignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "UnassignIssue")
 }
 
 func (s *issueService) ListEvents(context.Context, string, int, scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Issues", "ListEvents")
 }
 
 func (s *issueService) ListLabels(context.Context, string, int, scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Issues", "ListLabels")
 }
 
 func (s *issueService) AddLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "AddLabel")
 }
 
 func (s *issueService) DeleteLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "DeleteLabel")
 }
 
 func (s *issueService) Find(ctx context.Context, repo string, number int) (*scm.Issue, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Issues", "Find")
 }
 
 func (s *issueService) FindComment(ctx context.Context, repo string, index, id int) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Issues", "FindComment")
 }
 
 func (s *issueService) List(ctx context.Context, repo string, opts scm.IssueListOptions) ([]*scm.Issue, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Issues", "List")
 }
 
-func (s *issueService) ListComments(ctx context.Context, repo string, index int, opts scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+func (s *issueService) ListComments(ctx context.Context, repo string, index int, opts scm.CommentListOptions) ([]*scm.Comment, *scm.Response, error) {
+	return nil, nil, notSupported("Issues", "ListComments")
 }
 
 func (s *issueService) Create(ctx context.Context, repo string, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Issues", "Create")
+}
+
+func (s *issueService) Update(ctx context.Context, repo string, number int, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	return nil, nil, notSupported("Issues", "Update")
 }
 
 func (s *issueService) CreateComment(ctx context.Context, repo string, number int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Issues", "CreateComment")
 }
 
 func (s *issueService) DeleteComment(ctx context.Context, repo string, number, id int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "DeleteComment")
 }
 
 func (s *issueService) EditComment(ctx context.Context, repo string, number int, id int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Issues", "EditComment")
+}
+
+func (s *issueService) MinimizeComment(ctx context.Context, repo string, number int, id int, reason string) (*scm.Response, error) {
+	return nil, notSupported("Issues", "MinimizeComment")
+}
+
+func (s *issueService) UnminimizeComment(ctx context.Context, repo string, number int, id int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "UnminimizeComment")
 }
 
 func (s *issueService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "Close")
 }
 
 func (s *issueService) Reopen(ctx context.Context, repo string, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "Reopen")
 }
 
 func (s *issueService) Lock(ctx context.Context, repo string, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "Lock")
 }
 
 func (s *issueService) Unlock(ctx context.Context, repo string, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "Unlock")
 }
 
 func (s *issueService) SetMilestone(ctx context.Context, repo string, issueID int, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "SetMilestone")
 }
 
 func (s *issueService) ClearMilestone(ctx context.Context, repo string, id int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "ClearMilestone")
+}
+
+func (s *issueService) Pin(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "Pin")
+}
+
+func (s *issueService) Unpin(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "Unpin")
+}
+
+func (s *issueService) ListPinned(ctx context.Context, repo string) ([]*scm.Issue, *scm.Response, error) {
+	return nil, nil, notSupported("Issues", "ListPinned")
 }
@@ -16,31 +16,31 @@ type organizationService struct {
 }
 
 func (s *organizationService) Create(context.Context, *scm.OrganizationInput) (*scm.Organization, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Organizations", "Create")
 }
 
 func (s *organizationService) Delete(context.Context, string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Organizations", "Delete")
 }
 
 func (s *organizationService) IsMember(ctx context.Context, org string, user string) (bool, *scm.Response, error) {
-	return false, nil, scm.ErrNotSupported
+	return false, nil, notSupported("Organizations", "IsMember")
 }
 
 func (s *organizationService) IsAdmin(ctx context.Context, org string, user string) (bool, *scm.Response, error) {
-	return false, nil, scm.ErrNotSupported
+	return false, nil, notSupported("Organizations", "IsAdmin")
 }
 
 func (s *organizationService) ListTeams(ctx context.Context, org string, ops scm.ListOptions) ([]*scm.Team, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Organizations", "ListTeams")
 }
 
 func (s *organizationService) ListTeamMembers(ctx context.Context, id int, role string, ops scm.ListOptions) ([]*scm.TeamMember, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Organizations", "ListTeamMembers")
 }
 
 func (s *organizationService) ListOrgMembers(ctx context.Context, org string, ops scm.ListOptions) ([]*scm.TeamMember, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Organizations", "ListOrgMembers")
 }
 
 func (s *organizationService) Find(ctx context.Context, name string) (*scm.Organization, *scm.Response, error) {
@@ -62,15 +62,15 @@ func (s *organizationService) List(ctx context.Context, opts scm.ListOptions) ([
 }
 
 func (s *organizationService) ListPendingInvitations(ctx context.Context, org string, opts scm.ListOptions) ([]*scm.OrganizationPendingInvite, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Organizations", "ListPendingInvitations")
 }
 
 func (s *organizationService) AcceptOrganizationInvitation(ctx context.Context, org string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Organizations", "AcceptOrganizationInvitation")
 }
 
 func (s *organizationService) ListMemberships(ctx context.Context, opts scm.ListOptions) ([]*scm.Membership, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Organizations", "ListMemberships")
 }
 
 func convertOrganizationList(from *organizationList) []*scm.Organization {
@@ -41,7 +41,7 @@ func (s *pullService) List(ctx context.Context, repo string, opts scm.PullReques
 		return nil, res, err
 	}
 	err = copyPagination(out.pagination, res)
-	return convertPullRequests(out), res, err
+	return scm.FilterPullRequests(convertPullRequests(out), opts), res, err
 }
 
 func (s *pullService) ListChanges(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
@@ -56,15 +56,15 @@ func (s *pullService) ListChanges(ctx context.Context, repo string, number int,
 }
 
 func (s *pullService) ListLabels(context.Context, string, int, scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("PullRequests", "ListLabels")
 }
 
 func (s *pullService) ListEvents(context.Context, string, int, scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("PullRequests", "ListEvents")
 }
 
 func (s *pullService) DeleteLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "DeleteLabel")
 }
 
 func (s *pullService) Merge(ctx context.Context, repo string, number int, options *scm.PullRequestMergeOptions) (*scm.Response, error) {
@@ -74,35 +74,35 @@ func (s *pullService) Merge(ctx context.Context, repo string, number int, option
 }
 
 func (s *pullService) Update(ctx context.Context, repo string, number int, prInput *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("PullRequests", "Update")
 }
 
 func (s *pullService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "Close")
 }
 
 func (s *pullService) Reopen(ctx context.Context, repo string, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "Reopen")
 }
 
 func (s *pullService) AssignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "AssignIssue")
 }
 
 func (s *pullService) UnassignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "UnassignIssue")
 }
 
 func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("PullRequests", "Create")
 }
 
 func (s *pullService) RequestReview(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "RequestReview")
 }
 
 func (s *pullService) UnrequestReview(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "UnrequestReview")
 }
 
 type prCommit struct {
@@ -163,7 +163,7 @@ func convertPullRequest(from *pullRequest) *scm.PullRequest {
 	// TODO
 	fork := "false"
 	closed := strings.ToLower(from.State) != "open"
-	return &scm.PullRequest{
+	dst := &scm.PullRequest{
 		Number:   from.ID,
 		Title:    from.Title,
 		Body:     from.Description,
@@ -172,15 +172,15 @@ func convertPullRequest(from *pullRequest) *scm.PullRequest {
 		Source:   from.Source.Commit.Commit,
 		Target:   from.Destination.Commit.Commit,
 		Fork:     fork,
-		Base:     convertPullRequestBranch(from.Destination.Commit.Ref, from.Destination.Commit.Commit, from.Destination.Repository),
-		Head:     convertPullRequestBranch(from.Source.Commit.Ref, from.Source.Commit.Commit, from.Source.Repository),
+		Base:     convertPullRequestBranch(from.Destination.Branch.Name, from.Destination.Commit.Commit, from.Destination.Repository),
+		Head:     convertPullRequestBranch(from.Source.Branch.Name, from.Source.Commit.Commit, from.Source.Repository),
 		Link:     from.Links.HTML.Href,
 		DiffLink: from.Links.Diff.Href,
 		State:    strings.ToLower(from.State),
 		Closed:   closed,
 		Merged:   from.State == "MERGED",
-		Created:  from.CreatedDate,
-		Updated:  from.UpdatedDate,
+		Created:  from.CreatedDate.UTC(),
+		Updated:  from.UpdatedDate.UTC(),
 		Author: scm.User{
 			Login:  from.Author.GetLogin(),
 			Name:   from.Author.DisplayName,
@@ -189,6 +189,21 @@ func convertPullRequest(from *pullRequest) *scm.PullRequest {
 			Avatar: from.Author.Links.Avatar.Href,
 		},
 	}
+	setClosedTimes(dst)
+	return dst
+}
+
+// setClosedTimes sets the closed and merged times of the pull
+// request. Bitbucket Cloud does not report them, so the last
+// update time is used, which is the time of the state change
+// unless the pull request was commented on afterwards.
+func setClosedTimes(pr *scm.PullRequest) {
+	if pr.Closed {
+		pr.ClosedAt = pr.Updated
+	}
+	if pr.Merged {
+		pr.MergedAt = pr.Updated
+	}
 }
 
 func convertPullRequestBranch(ref string, sha string, repo repository) scm.PullRequestBranch {
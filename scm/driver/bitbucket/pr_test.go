@@ -7,6 +7,7 @@ package bitbucket
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"testing"
 
@@ -88,7 +89,7 @@ func TestPullMerge(t *testing.T) {
 func TestPullClose(t *testing.T) {
 	client, _ := New("https://api.bitbucket.org")
 	_, err := client.PullRequests.Close(context.Background(), "atlassian/atlaskit", 1)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -103,7 +104,7 @@ func TestPullCreate(t *testing.T) {
 	}
 
 	_, _, err := client.PullRequests.Create(context.Background(), "atlassian/atlaskit", input)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
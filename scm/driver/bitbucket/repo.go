@@ -7,6 +7,7 @@ package bitbucket
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/url"
 	"time"
 
@@ -60,39 +61,87 @@ type repositoryService struct {
 }
 
 func (s *repositoryService) Create(context.Context, *scm.RepositoryInput) (*scm.Repository, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Repositories", "Create")
 }
 
 func (s *repositoryService) Fork(context.Context, *scm.RepositoryInput, string) (*scm.Repository, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Repositories", "Fork")
 }
 
 func (s *repositoryService) FindCombinedStatus(ctx context.Context, repo, ref string) (*scm.CombinedStatus, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Repositories", "FindCombinedStatus")
 }
 
 func (s *repositoryService) FindUserPermission(ctx context.Context, repo string, user string) (string, *scm.Response, error) {
-	return "", nil, scm.ErrNotSupported
+	return "", nil, notSupported("Repositories", "FindUserPermission")
 }
 
 func (s *repositoryService) AddCollaborator(ctx context.Context, repo, user, permission string) (bool, bool, *scm.Response, error) {
-	return false, false, nil, scm.ErrNotSupported
+	return false, false, nil, notSupported("Repositories", "AddCollaborator")
 }
 
 func (s *repositoryService) IsCollaborator(ctx context.Context, repo, user string) (bool, *scm.Response, error) {
-	return false, nil, scm.ErrNotSupported
+	return false, nil, notSupported("Repositories", "IsCollaborator")
 }
 
 func (s *repositoryService) ListCollaborators(ctx context.Context, repo string, ops scm.ListOptions) ([]scm.User, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Repositories", "ListCollaborators")
 }
 
 func (s *repositoryService) ListLabels(context.Context, string, scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Repositories", "ListLabels")
 }
 
 func (s *repositoryService) Delete(context.Context, string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Repositories", "Delete")
+}
+
+func (s *repositoryService) GetDependencyGraph(context.Context, string) (*scm.DependencyGraph, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "GetDependencyGraph")
+}
+
+func (s *repositoryService) ExportSBOM(context.Context, string) (io.ReadCloser, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ExportSBOM")
+}
+
+func (s *repositoryService) ListRulesets(context.Context, string, scm.ListOptions) ([]*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListRulesets")
+}
+
+func (s *repositoryService) FindRuleset(context.Context, string, int) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "FindRuleset")
+}
+
+func (s *repositoryService) CreateRuleset(context.Context, string, *scm.RulesetInput) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "CreateRuleset")
+}
+
+func (s *repositoryService) UpdateRuleset(context.Context, string, int, *scm.RulesetInput) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "UpdateRuleset")
+}
+
+func (s *repositoryService) GetPages(context.Context, string) (*scm.Pages, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "GetPages")
+}
+
+func (s *repositoryService) EnablePages(context.Context, string, *scm.PagesInput) (*scm.Pages, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "EnablePages")
+}
+
+func (s *repositoryService) DisablePages(context.Context, string) (*scm.Response, error) {
+	return nil, notSupported("Repositories", "DisablePages")
+}
+
+func (s *repositoryService) GetLatestPagesBuild(context.Context, string) (*scm.PagesBuild, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "GetLatestPagesBuild")
+}
+
+func (s *repositoryService) ListHookDeliveries(context.Context, string, string, scm.ListOptions) ([]*scm.HookDelivery, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListHookDeliveries")
+}
+
+func (s *repositoryService) RedeliverHookDelivery(context.Context, string, string, int64) (*scm.Response, error) {
+	return nil, notSupported("Repositories", "RedeliverHookDelivery")
 }
 
 // Find returns the repository by name.
@@ -135,11 +184,11 @@ func (s *repositoryService) List(ctx context.Context, opts scm.ListOptions) ([]*
 }
 
 func (s *repositoryService) ListOrganisation(context.Context, string, scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Repositories", "ListOrganisation")
 }
 
 func (s *repositoryService) ListUser(context.Context, string, scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Repositories", "ListUser")
 }
 
 // ListHooks returns a list or repository hooks.
@@ -235,8 +284,8 @@ func convertRepository(from *repository) *scm.Repository {
 		Private:   from.IsPrivate,
 		Clone:     fmt.Sprintf("https://bitbucket.org/%s.git", from.FullName),
 		CloneSSH:  fmt.Sprintf("git@bitbucket.org:%s.git", from.FullName),
-		Created:   from.CreatedOn,
-		Updated:   from.UpdatedOn,
+		Created:   from.CreatedOn.UTC(),
+		Updated:   from.UpdatedOn.UTC(),
 	}
 }
 
@@ -367,9 +416,9 @@ func convertState(from string) scm.State {
 
 func convertFromState(from scm.State) string {
 	switch from {
-	case scm.StatePending, scm.StateRunning:
+	case scm.StatePending, scm.StateRunning, scm.StateExpected:
 		return "INPROGRESS"
-	case scm.StateSuccess:
+	case scm.StateSuccess, scm.StateNeutral, scm.StateSkipped:
 		return "SUCCESSFUL"
 	default:
 		return "FAILED"
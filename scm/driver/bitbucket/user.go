@@ -16,11 +16,25 @@ type userService struct {
 }
 
 func (s *userService) CreateToken(context.Context, string, string) (*scm.UserToken, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Users", "CreateToken")
 }
 
 func (s *userService) DeleteToken(context.Context, int64) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Users", "DeleteToken")
+}
+
+// TokenInfo returns the scopes of the OAuth token, read from
+// the response headers.
+func (s *userService) TokenInfo(ctx context.Context) (*scm.TokenInfo, *scm.Response, error) {
+	res, err := s.client.do(ctx, "GET", "2.0/user", nil, nil)
+	if err != nil {
+		return nil, res, err
+	}
+	return &scm.TokenInfo{Scopes: scm.ParseScopes(res.Header.Get("X-OAuth-Scopes"))}, res, nil
+}
+
+func (s *userService) ListTokens(context.Context, scm.ListOptions) ([]*scm.UserToken, *scm.Response, error) {
+	return nil, nil, notSupported("Users", "ListTokens")
 }
 
 func (s *userService) Find(ctx context.Context) (*scm.User, *scm.Response, error) {
@@ -37,15 +51,15 @@ func (s *userService) FindLogin(ctx context.Context, login string) (*scm.User, *
 }
 
 func (s *userService) FindEmail(ctx context.Context) (string, *scm.Response, error) {
-	return "", nil, scm.ErrNotSupported
+	return "", nil, notSupported("Users", "FindEmail")
 }
 
 func (s *userService) ListInvitations(context.Context) ([]*scm.Invitation, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Users", "ListInvitations")
 }
 
 func (s *userService) AcceptInvitation(context.Context, int64) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Users", "AcceptInvitation")
 }
 
 type user struct {
@@ -7,6 +7,7 @@ package bitbucket
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"testing"
 
@@ -69,7 +70,7 @@ func TestUserLoginFind(t *testing.T) {
 func TestUserFindEmail(t *testing.T) {
 	client, _ := New("https://api.bitbucket.org")
 	_, _, err := client.Users.FindEmail(context.Background())
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -8,11 +8,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"net/http"
 	"time"
 
+	"github.com/slimm609/go-scm/pkg/hmac"
 	"github.com/slimm609/go-scm/scm"
 )
 
@@ -22,12 +21,15 @@ import (
 
 type webhookService struct {
 	client *wrapper
+	opts   scm.WebhookServiceOptions
 }
 
 func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
-	data, err := ioutil.ReadAll(
-		io.LimitReader(req.Body, 10000000),
-	)
+	return s.ParseMulti(req, scm.Secrets(fn))
+}
+
+func (s *webhookService) ParseMulti(req *http.Request, fn scm.MultiSecretFunc) (scm.Webhook, error) {
+	_, data, err := s.opts.ReadBody(req)
 	if err != nil {
 		return nil, err
 	}
@@ -40,19 +42,33 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 		hook, err = s.parsePullRequestHook(data)
 	case "pullrequest:updated":
 		hook, err = s.parsePullRequestHook(data)
-		if hook != nil {
+		if err == nil {
 			hook.(*scm.PullRequestHook).Action = scm.ActionSync
 		}
 	case "pullrequest:fulfilled":
 		hook, err = s.parsePullRequestHook(data)
-		if hook != nil {
+		if err == nil {
 			hook.(*scm.PullRequestHook).Action = scm.ActionMerge
 		}
 	case "pullrequest:rejected":
 		hook, err = s.parsePullRequestHook(data)
-		if hook != nil {
+		if err == nil {
 			hook.(*scm.PullRequestHook).Action = scm.ActionClose
 		}
+	case "pullrequest:comment_created":
+		hook, err = s.parsePullRequestCommentHook(data, scm.ActionCreate)
+	case "pullrequest:comment_updated":
+		hook, err = s.parsePullRequestCommentHook(data, scm.ActionEdited)
+	case "pullrequest:comment_deleted":
+		hook, err = s.parsePullRequestCommentHook(data, scm.ActionDelete)
+	case "pullrequest:approved":
+		hook, err = s.parseReviewHook(data, scm.ActionSubmitted, scm.ReviewStateApproved)
+	case "pullrequest:unapproved":
+		hook, err = s.parseReviewHook(data, scm.ActionDismissed, scm.ReviewStateDismissed)
+	case "pullrequest:changes_request_created":
+		hook, err = s.parseReviewHook(data, scm.ActionSubmitted, scm.ReviewStateChangesRequested)
+	case "pullrequest:changes_request_removed":
+		hook, err = s.parseReviewHook(data, scm.ActionDismissed, scm.ReviewStateDismissed)
 	}
 	if err != nil {
 		return nil, err
@@ -60,18 +76,23 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	if hook == nil {
 		return nil, nil
 	}
+	scm.SetGUID(hook, req.Header.Get("X-Request-UUID"))
+	if s.opts.RawPayload {
+		scm.SetRaw(hook, data)
+	}
 
 	// get the gogs signature key to verify the payload
 	// signature. If no key is provided, no validation
 	// is performed.
-	key, err := fn(hook)
+	keys, err := fn(hook)
 	if err != nil {
 		return hook, err
-	} else if key == "" {
+	} else if len(keys) == 0 {
 		return hook, nil
 	}
 
-	if req.FormValue("secret") != key {
+	secret := req.FormValue("secret")
+	if !scm.ValidateAny(keys, func(key string) bool { return hmac.Equal(secret, key) }) {
 		return hook, scm.ErrSignatureInvalid
 	}
 
@@ -115,6 +136,24 @@ func (s *webhookService) parsePullRequestHook(data []byte) (*scm.PullRequestHook
 	}
 }
 
+func (s *webhookService) parsePullRequestCommentHook(data []byte, action scm.Action) (scm.Webhook, error) {
+	dst := new(commentHook)
+	err := json.Unmarshal(data, dst)
+	if err != nil {
+		return nil, err
+	}
+	return convertPullRequestCommentHook(dst, action), nil
+}
+
+func (s *webhookService) parseReviewHook(data []byte, action scm.Action, state string) (scm.Webhook, error) {
+	dst := new(reviewHook)
+	err := json.Unmarshal(data, dst)
+	if err != nil {
+		return nil, err
+	}
+	return convertReviewHook(dst, action, state), nil
+}
+
 //
 // native data structures
 //
@@ -122,222 +161,143 @@ func (s *webhookService) parsePullRequestHook(data []byte) (*scm.PullRequestHook
 type (
 	pushHook struct {
 		Push struct {
-			Changes []struct {
-				Forced bool `json:"forced"`
-				Old    struct {
-					Type  string `json:"type"`
-					Name  string `json:"name"`
-					Links struct {
-						Commits struct {
-							Href string `json:"href"`
-						} `json:"commits"`
-						Self struct {
-							Href string `json:"href"`
-						} `json:"self"`
-						HTML struct {
-							Href string `json:"href"`
-						} `json:"html"`
-					} `json:"links"`
-					Target struct {
-						Hash  string `json:"hash"`
-						Links struct {
-							Self struct {
-								Href string `json:"href"`
-							} `json:"self"`
-							HTML struct {
-								Href string `json:"href"`
-							} `json:"html"`
-						} `json:"links"`
-						Author struct {
-							Raw  string `json:"raw"`
-							Type string `json:"type"`
-							User struct {
-								Username    string `json:"username"`
-								DisplayName string `json:"display_name"`
-								AccountID   string `json:"account_id"`
-								Links       struct {
-									Self struct {
-										Href string `json:"href"`
-									} `json:"self"`
-									HTML struct {
-										Href string `json:"href"`
-									} `json:"html"`
-									Avatar struct {
-										Href string `json:"href"`
-									} `json:"avatar"`
-								} `json:"links"`
-								Type string `json:"type"`
-								UUID string `json:"uuid"`
-							} `json:"user"`
-						} `json:"author"`
-						Summary struct {
-							Raw    string `json:"raw"`
-							Markup string `json:"markup"`
-							HTML   string `json:"html"`
-							Type   string `json:"type"`
-						} `json:"summary"`
-						Parents []interface{} `json:"parents"`
-						Date    time.Time     `json:"date"`
-						Message string        `json:"message"`
-						Type    string        `json:"type"`
-					} `json:"target"`
-				} `json:"old"`
+			Changes pushChanges `json:"changes"`
+		} `json:"push"`
+		Repository webhookRepository `json:"repository"`
+		Actor      webhookActor      `json:"actor"`
+	}
+
+	// pushChange is a reference updated by a push. The commits
+	// of the change are not decoded, since they are not used.
+	pushChange struct {
+		Forced bool `json:"forced"`
+		Old    struct {
+			Type  string `json:"type"`
+			Name  string `json:"name"`
+			Links struct {
+				Commits struct {
+					Href string `json:"href"`
+				} `json:"commits"`
+				Self struct {
+					Href string `json:"href"`
+				} `json:"self"`
+				HTML struct {
+					Href string `json:"href"`
+				} `json:"html"`
+			} `json:"links"`
+			Target struct {
+				Hash  string `json:"hash"`
 				Links struct {
-					Commits struct {
+					Self struct {
 						Href string `json:"href"`
-					} `json:"commits"`
+					} `json:"self"`
 					HTML struct {
 						Href string `json:"href"`
 					} `json:"html"`
-					Diff struct {
-						Href string `json:"href"`
-					} `json:"diff"`
 				} `json:"links"`
-				Truncated bool `json:"truncated"`
-				Commits   []struct {
-					Hash  string `json:"hash"`
-					Links struct {
-						Self struct {
-							Href string `json:"href"`
-						} `json:"self"`
-						Comments struct {
-							Href string `json:"href"`
-						} `json:"comments"`
-						Patch struct {
-							Href string `json:"href"`
-						} `json:"patch"`
-						HTML struct {
-							Href string `json:"href"`
-						} `json:"html"`
-						Diff struct {
-							Href string `json:"href"`
-						} `json:"diff"`
-						Approve struct {
-							Href string `json:"href"`
-						} `json:"approve"`
-						Statuses struct {
-							Href string `json:"href"`
-						} `json:"statuses"`
-					} `json:"links"`
-					Author struct {
-						Raw  string `json:"raw"`
-						Type string `json:"type"`
-						User struct {
-							Username    string `json:"username"`
-							DisplayName string `json:"display_name"`
-							AccountID   string `json:"account_id"`
-							Links       struct {
-								Self struct {
-									Href string `json:"href"`
-								} `json:"self"`
-								HTML struct {
-									Href string `json:"href"`
-								} `json:"html"`
-								Avatar struct {
-									Href string `json:"href"`
-								} `json:"avatar"`
-							} `json:"links"`
-							Type string `json:"type"`
-							UUID string `json:"uuid"`
-						} `json:"user"`
-					} `json:"author"`
-					Summary struct {
-						Raw    string `json:"raw"`
-						Markup string `json:"markup"`
-						HTML   string `json:"html"`
-						Type   string `json:"type"`
-					} `json:"summary"`
-					Parents []struct {
-						Type  string `json:"type"`
-						Hash  string `json:"hash"`
-						Links struct {
+				Author struct {
+					Raw  string `json:"raw"`
+					Type string `json:"type"`
+					User struct {
+						Username    string `json:"username"`
+						DisplayName string `json:"display_name"`
+						AccountID   string `json:"account_id"`
+						Links       struct {
 							Self struct {
 								Href string `json:"href"`
 							} `json:"self"`
 							HTML struct {
 								Href string `json:"href"`
 							} `json:"html"`
+							Avatar struct {
+								Href string `json:"href"`
+							} `json:"avatar"`
 						} `json:"links"`
-					} `json:"parents"`
-					Date    time.Time `json:"date"`
-					Message string    `json:"message"`
-					Type    string    `json:"type"`
+						Type string `json:"type"`
+						UUID string `json:"uuid"`
+					} `json:"user"`
+				} `json:"author"`
+				Summary struct {
+					Raw    string `json:"raw"`
+					Markup string `json:"markup"`
+					HTML   string `json:"html"`
+					Type   string `json:"type"`
+				} `json:"summary"`
+				Date    time.Time `json:"date"`
+				Message string    `json:"message"`
+				Type    string    `json:"type"`
+			} `json:"target"`
+		} `json:"old"`
+		Links struct {
+			Commits struct {
+				Href string `json:"href"`
+			} `json:"commits"`
+			HTML struct {
+				Href string `json:"href"`
+			} `json:"html"`
+			Diff struct {
+				Href string `json:"href"`
+			} `json:"diff"`
+		} `json:"links"`
+		Created bool `json:"created"`
+		Closed  bool `json:"closed"`
+		New     struct {
+			Type  string `json:"type"`
+			Name  string `json:"name"`
+			Links struct {
+				Commits struct {
+					Href string `json:"href"`
 				} `json:"commits"`
-				Created bool `json:"created"`
-				Closed  bool `json:"closed"`
-				New     struct {
-					Type  string `json:"type"`
-					Name  string `json:"name"`
-					Links struct {
-						Commits struct {
-							Href string `json:"href"`
-						} `json:"commits"`
-						Self struct {
-							Href string `json:"href"`
-						} `json:"self"`
-						HTML struct {
-							Href string `json:"href"`
-						} `json:"html"`
-					} `json:"links"`
-					Target struct {
-						Hash  string `json:"hash"`
-						Links struct {
+				Self struct {
+					Href string `json:"href"`
+				} `json:"self"`
+				HTML struct {
+					Href string `json:"href"`
+				} `json:"html"`
+			} `json:"links"`
+			Target struct {
+				Hash  string `json:"hash"`
+				Links struct {
+					Self struct {
+						Href string `json:"href"`
+					} `json:"self"`
+					HTML struct {
+						Href string `json:"href"`
+					} `json:"html"`
+				} `json:"links"`
+				Author struct {
+					Raw  string `json:"raw"`
+					Type string `json:"type"`
+					User struct {
+						Username    string `json:"username"`
+						DisplayName string `json:"display_name"`
+						AccountID   string `json:"account_id"`
+						Links       struct {
 							Self struct {
 								Href string `json:"href"`
 							} `json:"self"`
 							HTML struct {
 								Href string `json:"href"`
 							} `json:"html"`
+							Avatar struct {
+								Href string `json:"href"`
+							} `json:"avatar"`
 						} `json:"links"`
-						Author struct {
-							Raw  string `json:"raw"`
-							Type string `json:"type"`
-							User struct {
-								Username    string `json:"username"`
-								DisplayName string `json:"display_name"`
-								AccountID   string `json:"account_id"`
-								Links       struct {
-									Self struct {
-										Href string `json:"href"`
-									} `json:"self"`
-									HTML struct {
-										Href string `json:"href"`
-									} `json:"html"`
-									Avatar struct {
-										Href string `json:"href"`
-									} `json:"avatar"`
-								} `json:"links"`
-								Type string `json:"type"`
-								UUID string `json:"uuid"`
-							} `json:"user"`
-						} `json:"author"`
-						Summary struct {
-							Raw    string `json:"raw"`
-							Markup string `json:"markup"`
-							HTML   string `json:"html"`
-							Type   string `json:"type"`
-						} `json:"summary"`
-						Parents []struct {
-							Type  string `json:"type"`
-							Hash  string `json:"hash"`
-							Links struct {
-								Self struct {
-									Href string `json:"href"`
-								} `json:"self"`
-								HTML struct {
-									Href string `json:"href"`
-								} `json:"html"`
-							} `json:"links"`
-						} `json:"parents"`
-						Date    time.Time `json:"date"`
-						Message string    `json:"message"`
-						Type    string    `json:"type"`
-					} `json:"target"`
-				} `json:"new"`
-			} `json:"changes"`
-		} `json:"push"`
-		Repository webhookRepository `json:"repository"`
-		Actor      webhookActor      `json:"actor"`
+						Type string `json:"type"`
+						UUID string `json:"uuid"`
+					} `json:"user"`
+				} `json:"author"`
+				Summary struct {
+					Raw    string `json:"raw"`
+					Markup string `json:"markup"`
+					HTML   string `json:"html"`
+					Type   string `json:"type"`
+				} `json:"summary"`
+				Date    time.Time `json:"date"`
+				Message string    `json:"message"`
+				Type    string    `json:"type"`
+			} `json:"target"`
+		} `json:"new"`
 	}
 
 	webhook struct {
@@ -346,6 +306,37 @@ type (
 		Actor       webhookActor       `json:"actor"`
 	}
 
+	commentHook struct {
+		webhook
+		Comment struct {
+			ID      int `json:"id"`
+			Content struct {
+				Raw string `json:"raw"`
+			} `json:"content"`
+			User  webhookActor `json:"user"`
+			Links struct {
+				HTML struct {
+					Href string `json:"href"`
+				} `json:"html"`
+			} `json:"links"`
+			CreatedOn time.Time `json:"created_on"`
+			UpdatedOn time.Time `json:"updated_on"`
+		} `json:"comment"`
+	}
+
+	// reviewHook is the payload of the approval and changes
+	// request events, only one of which is set.
+	reviewHook struct {
+		webhook
+		Approval       *webhookReview `json:"approval"`
+		ChangesRequest *webhookReview `json:"changes_request"`
+	}
+
+	webhookReview struct {
+		Date time.Time    `json:"date"`
+		User webhookActor `json:"user"`
+	}
+
 	webhookPullRequest struct {
 		Description string `json:"description"`
 		Links       struct {
@@ -356,76 +347,18 @@ type (
 				Href string `json:"href"`
 			} `json:"diff"`
 		} `json:"links"`
-		Title       string `json:"title"`
-		ID          int    `json:"id"`
-		Destination struct {
-			Commit struct {
-				Hash  string `json:"hash"`
-				Links struct {
-					Self struct {
-						Href string `json:"href"`
-					} `json:"self"`
-				} `json:"links"`
-			} `json:"commit"`
-			Branch struct {
-				Name string `json:"name"`
-			} `json:"branch"`
-			Repository struct {
-				FullName string `json:"full_name"`
-				Type     string `json:"type"`
-				Name     string `json:"name"`
-				Links    struct {
-					Self struct {
-						Href string `json:"href"`
-					} `json:"self"`
-					HTML struct {
-						Href string `json:"href"`
-					} `json:"html"`
-					Avatar struct {
-						Href string `json:"href"`
-					} `json:"avatar"`
-				} `json:"links"`
-				UUID string `json:"uuid"`
-			} `json:"repository"`
-		} `json:"destination"`
-		CommentCount int `json:"comment_count"`
+		Title        string                   `json:"title"`
+		ID           int                      `json:"id"`
+		Destination  webhookPullRequestBranch `json:"destination"`
+		CommentCount int                      `json:"comment_count"`
 		Summary      struct {
 			Raw    string `json:"raw"`
 			Markup string `json:"markup"`
 			HTML   string `json:"html"`
 			Type   string `json:"type"`
 		} `json:"summary"`
-		Source struct {
-			Commit struct {
-				Hash  string `json:"hash"`
-				Links struct {
-					Self struct {
-						Href string `json:"href"`
-					} `json:"self"`
-				} `json:"links"`
-			} `json:"commit"`
-			Branch struct {
-				Name string `json:"name"`
-			} `json:"branch"`
-			Repository struct {
-				FullName string `json:"full_name"`
-				Type     string `json:"type"`
-				Name     string `json:"name"`
-				Links    struct {
-					Self struct {
-						Href string `json:"href"`
-					} `json:"self"`
-					HTML struct {
-						Href string `json:"href"`
-					} `json:"html"`
-					Avatar struct {
-						Href string `json:"href"`
-					} `json:"avatar"`
-				} `json:"links"`
-				UUID string `json:"uuid"`
-			} `json:"repository"`
-		} `json:"source"`
-		State  string `json:"state"`
+		Source webhookPullRequestBranch `json:"source"`
+		State  string                   `json:"state"`
 		Author struct {
 			Username    string `json:"username"`
 			DisplayName string `json:"display_name"`
@@ -448,6 +381,37 @@ type (
 		UpdatedOn time.Time `json:"updated_on"`
 	}
 
+	webhookPullRequestBranch struct {
+		Commit struct {
+			Hash  string `json:"hash"`
+			Links struct {
+				Self struct {
+					Href string `json:"href"`
+				} `json:"self"`
+			} `json:"links"`
+		} `json:"commit"`
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			Type     string `json:"type"`
+			Name     string `json:"name"`
+			Links    struct {
+				Self struct {
+					Href string `json:"href"`
+				} `json:"self"`
+				HTML struct {
+					Href string `json:"href"`
+				} `json:"html"`
+				Avatar struct {
+					Href string `json:"href"`
+				} `json:"avatar"`
+			} `json:"links"`
+			UUID string `json:"uuid"`
+		} `json:"repository"`
+	}
+
 	webhookRepository struct {
 		Scm   string `json:"scm"`
 		Name  string `json:"name"`
@@ -485,6 +449,24 @@ type (
 	}
 )
 
+// pushChanges holds the first of the changes of a push, the
+// only one the hooks are converted from. The other changes,
+// with their commits, are skipped while decoding, which keeps
+// the allocations of a large push independent of its size.
+type pushChanges []pushChange
+
+func (c *pushChanges) UnmarshalJSON(data []byte) error {
+	var first [1]*pushChange
+	if err := json.Unmarshal(data, &first); err != nil {
+		return err
+	}
+	*c = nil
+	if first[0] != nil {
+		*c = pushChanges{*first[0]}
+	}
+	return nil
+}
+
 //
 // push hooks
 //
@@ -493,7 +475,11 @@ func convertPushHook(src *pushHook) *scm.PushHook {
 	change := src.Push.Changes[0]
 	namespace, name := scm.Split(src.Repository.FullName)
 	dst := &scm.PushHook{
-		Ref: scm.ExpandRef(change.New.Name, "refs/heads/"),
+		Ref:     scm.ExpandRef(change.New.Name, "refs/heads/"),
+		Before:  change.Old.Target.Hash,
+		After:   change.New.Target.Hash,
+		Created: change.Created,
+		Forced:  change.Forced,
 		Commit: scm.Commit{
 			Sha:     change.New.Target.Hash,
 			Message: change.New.Target.Message,
@@ -532,6 +518,10 @@ func convertPushHook(src *pushHook) *scm.PushHook {
 	if change.New.Type == "tag" {
 		dst.Ref = scm.ExpandRef(change.New.Name, "refs/tags/")
 	}
+	// bitbucket omits the previous commit of a new ref.
+	if dst.Before == "" {
+		dst.Before = scm.EmptyCommit
+	}
 	return dst
 }
 
@@ -653,7 +643,7 @@ func convertTagDeleteHook(src *pushHook) *scm.TagHook {
 
 func convertPullRequestHook(src *webhook) *scm.PullRequestHook {
 	namespace, name := scm.Split(src.Repository.FullName)
-	return &scm.PullRequestHook{
+	dst := &scm.PullRequestHook{
 		Action: scm.ActionOpen,
 		PullRequest: scm.PullRequest{
 			Number: src.PullRequest.ID,
@@ -664,6 +654,8 @@ func convertPullRequestHook(src *webhook) *scm.PullRequestHook {
 			Source: src.PullRequest.Source.Branch.Name,
 			Target: src.PullRequest.Destination.Branch.Name,
 			Fork:   src.PullRequest.Source.Repository.FullName,
+			Base:   convertPullRequestBranchHook(&src.PullRequest.Destination, &src.Repository),
+			Head:   convertPullRequestBranchHook(&src.PullRequest.Source, &src.Repository),
 			Link:   src.PullRequest.Links.HTML.Href,
 			Closed: src.PullRequest.State != "OPEN",
 			Merged: src.PullRequest.State == "MERGED",
@@ -672,8 +664,8 @@ func convertPullRequestHook(src *webhook) *scm.PullRequestHook {
 				Name:   src.PullRequest.Author.DisplayName,
 				Avatar: src.PullRequest.Author.Links.Avatar.Href,
 			},
-			Created: src.PullRequest.CreatedOn,
-			Updated: src.PullRequest.UpdatedOn,
+			Created: src.PullRequest.CreatedOn.UTC(),
+			Updated: src.PullRequest.UpdatedOn.UTC(),
 		},
 		Repo: scm.Repository{
 			ID:        src.Repository.UUID,
@@ -691,4 +683,85 @@ func convertPullRequestHook(src *webhook) *scm.PullRequestHook {
 			Avatar: src.Actor.Links.Avatar.Href,
 		},
 	}
+	setClosedTimes(&dst.PullRequest)
+	return dst
+}
+
+// convertPullRequestBranchHook converts the source or
+// destination of the pull request. The payload only includes the
+// visibility of the hook repository, so a fork is reported as
+// public.
+func convertPullRequestBranchHook(src *webhookPullRequestBranch, repo *webhookRepository) scm.PullRequestBranch {
+	namespace, name := scm.Split(src.Repository.FullName)
+	return scm.PullRequestBranch{
+		Ref: src.Branch.Name,
+		Sha: src.Commit.Hash,
+		Repo: scm.Repository{
+			ID:        src.Repository.UUID,
+			Namespace: namespace,
+			Name:      name,
+			FullName:  src.Repository.FullName,
+			Private:   repo.IsPrivate && src.Repository.FullName == repo.FullName,
+			Clone:     fmt.Sprintf("https://bitbucket.org/%s.git", src.Repository.FullName),
+			CloneSSH:  fmt.Sprintf("git@bitbucket.org:%s.git", src.Repository.FullName),
+			Link:      src.Repository.Links.HTML.Href,
+		},
+	}
+}
+
+//
+// pull request comment hooks
+//
+
+func convertPullRequestCommentHook(src *commentHook, action scm.Action) *scm.PullRequestCommentHook {
+	hook := convertPullRequestHook(&src.webhook)
+	return &scm.PullRequestCommentHook{
+		Action:      action,
+		Repo:        hook.Repo,
+		PullRequest: hook.PullRequest,
+		Comment: scm.Comment{
+			ID:   src.Comment.ID,
+			Body: src.Comment.Content.Raw,
+			Link: src.Comment.Links.HTML.Href,
+			Author: scm.User{
+				Login:  src.Comment.User.Username,
+				Name:   src.Comment.User.DisplayName,
+				Avatar: src.Comment.User.Links.Avatar.Href,
+			},
+			Created: src.Comment.CreatedOn.UTC(),
+			Updated: src.Comment.UpdatedOn.UTC(),
+		},
+		Sender: hook.Sender,
+	}
+}
+
+//
+// review hooks
+//
+
+func convertReviewHook(src *reviewHook, action scm.Action, state string) *scm.ReviewHook {
+	hook := convertPullRequestHook(&src.webhook)
+	review := src.Approval
+	if review == nil {
+		review = src.ChangesRequest
+	}
+	dst := &scm.ReviewHook{
+		Action:      action,
+		Repo:        hook.Repo,
+		PullRequest: hook.PullRequest,
+		Review: scm.Review{
+			Sha:   hook.PullRequest.Sha,
+			State: state,
+		},
+	}
+	if review != nil {
+		dst.Review.Author = scm.User{
+			Login:  review.User.Username,
+			Name:   review.User.DisplayName,
+			Avatar: review.User.Links.Avatar.Href,
+		}
+		dst.Review.Created = review.Date.UTC()
+		dst.Review.Updated = review.Date.UTC()
+	}
+	return dst
 }
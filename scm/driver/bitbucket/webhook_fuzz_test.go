@@ -0,0 +1,48 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package bitbucket
+
+import (
+	"testing"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/internal/hooktest"
+)
+
+// fuzzEvents are the events parsed by the webhook service.
+var fuzzEvents = []string{
+	"repo:push", "pullrequest:created", "pullrequest:updated",
+	"pullrequest:fulfilled", "pullrequest:rejected",
+	"pullrequest:comment_created", "pullrequest:comment_updated",
+	"pullrequest:comment_deleted", "pullrequest:approved",
+	"pullrequest:unapproved", "pullrequest:changes_request_created",
+	"pullrequest:changes_request_removed",
+}
+
+// FuzzWebhook parses the corpus of hooktest.Corpus built from
+// the Bitbucket Cloud test payloads.
+func FuzzWebhook(f *testing.F) {
+	payloads, err := hooktest.Payloads("testdata/webhooks")
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, seed := range hooktest.Corpus(payloads, fuzzEvents) {
+		f.Add(seed.Event, seed.ContentType, seed.Mixed, seed.Data)
+	}
+	s := NewWebHookService(scm.WebhookServiceOptions{FormPayload: true})
+	f.Fuzz(func(t *testing.T, event, contentType string, mixed bool, data []byte) {
+		r := hooktest.Request("x-event-key", event, contentType, mixed, data)
+		hook, err := s.Parse(r, func(scm.Webhook) (string, error) {
+			return "", nil
+		})
+		if err == nil && hook != nil {
+			hook.Kind()
+			hook.Repository()
+		}
+	})
+}
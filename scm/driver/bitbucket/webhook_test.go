@@ -116,6 +116,32 @@ func TestWebhooks(t *testing.T) {
 			after:  "testdata/webhooks/pr_declined.json.golden",
 			obj:    new(scm.PullRequestHook),
 		},
+
+		//
+		// pull request comment events
+		//
+
+		// pull request comment created
+		{
+			sig:    "71295b197fa25f4356d2fb9965df3f2379d903d7",
+			event:  "pullrequest:comment_created",
+			before: "testdata/webhooks/pr_comment_created.json",
+			after:  "testdata/webhooks/pr_comment_created.json.golden",
+			obj:    new(scm.PullRequestCommentHook),
+		},
+
+		//
+		// review events
+		//
+
+		// pull request approved
+		{
+			sig:    "71295b197fa25f4356d2fb9965df3f2379d903d7",
+			event:  "pullrequest:approved",
+			before: "testdata/webhooks/pr_approved.json",
+			after:  "testdata/webhooks/pr_approved.json.golden",
+			obj:    new(scm.ReviewHook),
+		},
 		// 		// pull request labeled
 		// 		{
 		// 			sig:    "71295b197fa25f4356d2fb9965df3f2379d903d7",
@@ -213,3 +239,50 @@ func TestWebhookValidated(t *testing.T) {
 func secretFunc(scm.Webhook) (string, error) {
 	return "71295b197fa25f4356d2fb9965df3f2379d903d7", nil
 }
+
+func BenchmarkWebhookPush(b *testing.B) {
+	data := largePush(b, 2, 1000)
+	s := new(webhookService)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r, _ := http.NewRequest("POST", "/", bytes.NewReader(data))
+		r.Header.Set("x-event-key", "repo:push")
+		if _, err := s.Parse(r, func(scm.Webhook) (string, error) { return "", nil }); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// largePush returns the push payload of the testdata with the
+// change and its commit repeated, as sent for the pushes of
+// many branches and commits of a monorepo.
+func largePush(b *testing.B, changes, commits int) []byte {
+	f, err := ioutil.ReadFile("testdata/webhooks/push.json")
+	if err != nil {
+		b.Fatal(err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(f, &doc); err != nil {
+		b.Fatal(err)
+	}
+	push := doc["push"].(map[string]interface{})
+	change := push["changes"].([]interface{})[0].(map[string]interface{})
+	commit := change["commits"].([]interface{})[0]
+	list := make([]interface{}, commits)
+	for i := range list {
+		list[i] = commit
+	}
+	change["commits"] = list
+	all := make([]interface{}, changes)
+	for i := range all {
+		all[i] = change
+	}
+	push["changes"] = all
+	data, err := json.Marshal(doc)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return data
+}
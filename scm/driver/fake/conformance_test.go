@@ -0,0 +1,33 @@
+package fake_test
+
+import (
+	"testing"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/conformance"
+	"github.com/slimm609/go-scm/scm/driver/fake"
+)
+
+func TestConformance(t *testing.T) {
+	const sha = "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"
+
+	client, data := fake.NewDefault()
+	data.Repositories = []*scm.Repository{
+		{Namespace: "octocat", Name: "hello-world", FullName: "octocat/hello-world", Branch: "master"},
+	}
+	data.Commits = map[string]*scm.Commit{
+		sha: {Sha: sha, Message: "Merge pull request #6 from Spaceghost/patch-1"},
+	}
+	data.Refs = map[string]map[string]string{
+		"octocat/hello-world": {
+			"refs/heads/master": sha,
+			"refs/tags/v1.0.0":  sha,
+		},
+	}
+
+	conformance.Run(t, conformance.Config{
+		Client: client,
+		Repo:   "octocat/hello-world",
+		Branch: "master",
+	})
+}
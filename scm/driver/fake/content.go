@@ -2,6 +2,9 @@ package fake
 
 import (
 	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -21,7 +24,10 @@ type contentService struct {
 	data   *Data
 }
 
-func (c contentService) Find(_ context.Context, repo, path, ref string) (*scm.Content, *scm.Response, error) {
+func (c contentService) Find(ctx context.Context, repo, path, ref string) (*scm.Content, *scm.Response, error) {
+	if res, err := c.data.inject(ctx, c.client, "Contents.Find"); err != nil {
+		return nil, res, err
+	}
 	f, err := c.path(repo, path, ref)
 	if err != nil {
 		return nil, nil, err
@@ -39,11 +45,18 @@ func (c contentService) Find(_ context.Context, repo, path, ref string) (*scm.Co
 	return &scm.Content{
 		Path: path,
 		Data: data,
-		Sha:  ref,
+		Sha:  blobSha(data),
 	}, nil, nil
 }
 
-func (c contentService) List(_ context.Context, repo, path, ref string) ([]*scm.FileEntry, *scm.Response, error) {
+func (c contentService) FindMany(ctx context.Context, repo, ref string, paths []string) (map[string]*scm.Content, map[string]error) {
+	return scm.FindManyContents(ctx, c, repo, ref, paths, scm.DefaultConcurrency)
+}
+
+func (c contentService) List(ctx context.Context, repo, path, ref string) ([]*scm.FileEntry, *scm.Response, error) {
+	if res, err := c.data.inject(ctx, c.client, "Contents.List"); err != nil {
+		return nil, res, err
+	}
 	dir, err := c.path(repo, path, ref)
 	if err != nil {
 		return nil, nil, err
@@ -55,24 +68,100 @@ func (c contentService) List(_ context.Context, repo, path, ref string) ([]*scm.
 	var answer []*scm.FileEntry
 	for _, f := range fileNames {
 		name := f.Name()
-		t := "file"
-		if f.IsDir() {
-			t = "dir"
-		}
 		path := filepath.Join(dir, name)
+		t, sha := "dir", ""
+		if !f.IsDir() {
+			t = "file"
+			if sha, err = fileSha(path); err != nil {
+				return nil, nil, err
+			}
+		}
 		answer = append(answer, &scm.FileEntry{
 			Name: name,
 			Path: path,
 			Type: t,
 			Size: int(f.Size()),
-			Sha:  ref,
+			Sha:  sha,
 			Link: "file://" + path,
 		})
 	}
 	return answer, nil, nil
 }
 
-func (c contentService) Create(_ context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+func (c contentService) Exists(ctx context.Context, repo, path, ref string) (bool, *scm.Response, error) {
+	if res, err := c.data.inject(ctx, c.client, "Contents.Exists"); err != nil {
+		return false, res, err
+	}
+	f, err := c.path(repo, path, ref)
+	if err != nil {
+		return false, nil, err
+	}
+	_, err = os.Stat(f)
+	if os.IsNotExist(err) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, errors.Wrapf(err, "failed to stat file %s", f)
+	}
+	return true, nil, nil
+}
+
+func (c contentService) Stat(ctx context.Context, repo, path, ref string) (*scm.FileEntry, *scm.Response, error) {
+	if res, err := c.data.inject(ctx, c.client, "Contents.Stat"); err != nil {
+		return nil, res, err
+	}
+	f, err := c.path(repo, path, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := os.Stat(f)
+	if os.IsNotExist(err) {
+		return nil, &scm.Response{
+			Status: 404,
+		}, scm.ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to stat file %s", f)
+	}
+	t, sha := "dir", ""
+	if !info.IsDir() {
+		t = "file"
+		if sha, err = fileSha(f); err != nil {
+			return nil, nil, err
+		}
+	}
+	return &scm.FileEntry{
+		Name: info.Name(),
+		Path: path,
+		Type: t,
+		Size: int(info.Size()),
+		Sha:  sha,
+		Link: "file://" + f,
+	}, nil, nil
+}
+
+// fileSha returns the git blob SHA of the file.
+func fileSha(path string) (string, error) {
+	data, err := ioutil.ReadFile(path) // #nosec
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read file %s", path)
+	}
+	return blobSha(data), nil
+}
+
+// blobSha returns the SHA git computes for a blob with the
+// data, so the fake reports the same SHA as a git server.
+func blobSha(data []byte) string {
+	h := sha1.New() // #nosec
+	fmt.Fprintf(h, "blob %d\x00", len(data))
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (c contentService) Create(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	if res, err := c.data.inject(ctx, c.client, "Contents.Create"); err != nil {
+		return res, err
+	}
 	f, err := c.path(repo, path, "")
 	if err != nil {
 		return nil, err
@@ -84,7 +173,10 @@ func (c contentService) Create(_ context.Context, repo, path string, params *scm
 	return nil, nil
 }
 
-func (c contentService) Update(_ context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+func (c contentService) Update(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	if res, err := c.data.inject(ctx, c.client, "Contents.Update"); err != nil {
+		return res, err
+	}
 	f, err := c.path(repo, path, "")
 	if err != nil {
 		return nil, err
@@ -96,7 +188,10 @@ func (c contentService) Update(_ context.Context, repo, path string, params *scm
 	return nil, nil
 }
 
-func (c contentService) Delete(_ context.Context, repo, path, ref string) (*scm.Response, error) {
+func (c contentService) Delete(ctx context.Context, repo, path, ref string) (*scm.Response, error) {
+	if res, err := c.data.inject(ctx, c.client, "Contents.Delete"); err != nil {
+		return res, err
+	}
 	f, err := c.path(repo, path, ref)
 	if err != nil {
 		return nil, err
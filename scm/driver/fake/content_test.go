@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/slimm609/go-scm/scm"
 	"github.com/slimm609/go-scm/scm/driver/fake"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -45,6 +46,34 @@ func TestContent(t *testing.T) {
 	assert.Contains(t, text, "root dir of a repo", "for repo %s path %s", repo, path)
 }
 
+func TestContentStat(t *testing.T) {
+	client, _ := fake.NewDefault()
+
+	ctx := context.Background()
+	repo := "myorg/myrepo"
+
+	exists, _, err := client.Contents.Exists(ctx, repo, "README.md", "master")
+	require.NoError(t, err, "could not check file exists in repo %s", repo)
+	assert.True(t, exists, "README.md should exist")
+
+	exists, _, err = client.Contents.Exists(ctx, repo, "MISSING.md", "master")
+	require.NoError(t, err, "could not check file exists in repo %s", repo)
+	assert.False(t, exists, "MISSING.md should not exist")
+
+	f, _, err := client.Contents.Stat(ctx, repo, "README.md", "master")
+	require.NoError(t, err, "could not stat file in repo %s", repo)
+	assert.Equal(t, "file", f.Type)
+	assert.Equal(t, "a25ceb60c5efdb683dfe09acb414c8a970cda06e", f.Sha, "want the git blob SHA of the file")
+
+	f, _, err = client.Contents.Stat(ctx, repo, "somedir", "master")
+	require.NoError(t, err, "could not stat file in repo %s", repo)
+	assert.Equal(t, "dir", f.Type)
+	assert.Equal(t, "somedir", f.Name)
+
+	_, _, err = client.Contents.Stat(ctx, repo, "MISSING.md", "master")
+	assert.Equal(t, scm.ErrNotFound, err)
+}
+
 func TestContentWithRefs(t *testing.T) {
 	client, fakeData := fake.NewDefault()
 	fakeData.ContentDir = filepath.Join("test_data", "test_refs")
@@ -1,6 +1,11 @@
 package fake
 
-import "github.com/slimm609/go-scm/scm"
+import (
+	"sync"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
 
 // Data is used to store/represent test data for the fake client
 type Data struct {
@@ -29,8 +34,12 @@ type Data struct {
 	Organizations              []*scm.Organization
 	Repositories               []*scm.Repository
 	CurrentUser                scm.User
+	TokenInfo                  *scm.TokenInfo
 	Users                      []*scm.User
 	Hooks                      map[string][]*scm.Hook
+	HookID                     int
+	// HookSecrets maps the ID of the hooks created via CreateHook to their secret
+	HookSecrets map[string]string
 
 	//All Labels That Exist In The Repo
 	RepoLabelsExisting []string
@@ -44,6 +53,9 @@ type Data struct {
 	// org/repo#issuecommentid
 	IssueCommentsDeleted []string
 
+	// MinimizedComments maps org/repo#issuecommentid to the reason the comment is hidden for
+	MinimizedComments map[string]string
+
 	// org/repo#issuecommentid:reaction
 	IssueReactionsAdded   []string
 	CommentReactionsAdded []string
@@ -55,10 +67,17 @@ type Data struct {
 	Milestone    int
 	MilestoneMap map[string]int
 
+	// PinnedIssues maps org/repo to the numbers of its pinned issues
+	PinnedIssues map[string][]int
+
 	// list of commits for each PR
 	// org/repo#number:[]commit
 	CommitMap map[string][]scm.Commit
 
+	// CommitParents maps a commit SHA to the SHAs of its parents, forming together with
+	// Commits and Refs the commit graph of the fake git service
+	CommitParents map[string][]string
+
 	// Fake remote git storage. File name are keys
 	// and values map SHA to content
 	RemoteFiles map[string]map[string]string
@@ -66,6 +85,10 @@ type Data struct {
 	// A list of refs that got deleted via DeleteRef
 	RefsDeleted []DeletedRef
 
+	// Refs maps org/repo to the fully qualified refs created or
+	// updated via CreateRef and UpdateRef and the SHA they point at
+	Refs map[string]map[string]string
+
 	UserPermissions map[string]map[string]string
 
 	// Invitations the current pending invitations
@@ -73,6 +96,28 @@ type Data struct {
 
 	// ContentDir the directory used to implement the Content service to access files and directories
 	ContentDir string
+
+	// MethodErrors maps a method name such as "Git.FindRef" to the errors returned by its
+	// successive calls. The faults apply to every service method the fake implements,
+	// methods that panic or return ErrNotSupported are not covered. A nil entry lets the call through and once the errors are used up
+	// calls succeed again, so "fail twice then succeed" is expressed as {err, err}
+	MethodErrors map[string][]error `json:"-"`
+
+	// Latency is an artificial delay added to every call, MethodLatency overrides it per method.
+	// Calls return early with the context error if the context is done first
+	Latency       time.Duration
+	MethodLatency map[string]time.Duration
+
+	// RateLimit simulates the provider rate limit when not nil. Each call consumes one request
+	// and once Remaining reaches zero calls fail with ErrRateLimited and a 429 response until
+	// the Reset time has passed. The client Rate is updated after every call
+	RateLimit *scm.Rate
+
+	// RateLimitWindow is the duration of the rate limit windows following the first
+	// one, which ends at RateLimit.Reset. It defaults to an hour
+	RateLimitWindow time.Duration
+
+	faultLock sync.Mutex
 }
 
 // DeletedRef represents a ref that has been deleted
@@ -98,19 +143,26 @@ func NewData() *Data {
 		Statuses:                  map[string][]*scm.Status{},
 		IssueEvents:               map[int][]*scm.ListedIssueEvent{},
 		Commits:                   map[string]*scm.Commit{},
+		CommitParents:             map[string][]string{},
 		MilestoneMap:              map[string]int{},
+		PinnedIssues:              map[string][]int{},
 		CommitMap:                 map[string][]scm.Commit{},
 		RemoteFiles:               map[string]map[string]string{},
 		TestRef:                   "abcde",
+		Refs:                      map[string]map[string]string{},
 		IssueLabelsAdded:          []string{},
 		IssueLabelsExisting:       []string{},
 		IssueLabelsRemoved:        []string{},
 		IssueCommentsAdded:        []string{},
 		IssueCommentsDeleted:      []string{},
+		MinimizedComments:         map[string]string{},
 		IssueReactionsAdded:       []string{},
 		CommentReactionsAdded:     []string{},
 		AssigneesAdded:            []string{},
 		UserPermissions:           map[string]map[string]string{},
 		Hooks:                     map[string][]*scm.Hook{},
+		HookSecrets:               map[string]string{},
+		MethodErrors:              map[string][]error{},
+		MethodLatency:             map[string]time.Duration{},
 	}
 }
@@ -31,14 +31,16 @@ func NewDefault() (*scm.Client, *Data) {
 	client.Reviews = &reviewService{client: client, data: data}
 	client.Users = &userService{client: client, data: data}
 	client.Contents = &contentService{client: client, data: data}
-
-	// TODO
-	/*
-		client.Webhooks = &webhookService{client}
-	*/
+	client.Webhooks = &webhookService{client: client, data: data}
 	return client.Client, data
 }
 
 type wrapper struct {
 	*scm.Client
 }
+
+// notSupported returns the error of a method of a service
+// the driver does not support.
+func notSupported(service, method string) error {
+	return &scm.NotSupportedError{Driver: scm.DriverFake, Service: service, Method: method}
+}
@@ -0,0 +1,96 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// ErrRateLimited is returned by the fake client once the
+// simulated rate limit configured in Data.RateLimit is exhausted
+var ErrRateLimited = errors.New("API rate limit exceeded")
+
+// inject applies the latency, errors and rate limit configured in the Data
+// to a call of the given method, e.g. "Git.FindRef". A non nil error
+// means the call must fail with the returned response and error
+func (d *Data) inject(ctx context.Context, client *wrapper, method string) (*scm.Response, error) {
+	d.faultLock.Lock()
+	latency := d.Latency
+	if l, ok := d.MethodLatency[method]; ok {
+		latency = l
+	}
+	var err error
+	if errs := d.MethodErrors[method]; len(errs) > 0 {
+		err = errs[0]
+		d.MethodErrors[method] = errs[1:]
+	}
+	var rate *scm.Rate
+	if d.RateLimit != nil {
+		if d.RateLimit.Remaining > 0 {
+			d.RateLimit.Remaining--
+		} else if now := time.Now(); d.RateLimit.Reset != 0 && now.Unix() >= d.RateLimit.Reset {
+			window := d.RateLimitWindow
+			if window <= 0 {
+				window = time.Hour
+			}
+			d.RateLimit.Remaining = d.RateLimit.Limit - 1
+			d.RateLimit.Reset = now.Add(window).Unix()
+		} else if err == nil {
+			err = ErrRateLimited
+		}
+		snapshot := *d.RateLimit
+		rate = &snapshot
+	}
+	d.faultLock.Unlock()
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if rate != nil {
+		client.SetRate(*rate)
+	}
+	if err == nil {
+		return nil, nil
+	}
+	res := &scm.Response{Status: statusForError(err), Header: http.Header{}}
+	if rate != nil {
+		res.Rate = *rate
+		res.Header.Set("X-RateLimit-Limit", strconv.Itoa(rate.Limit))
+		res.Header.Set("X-RateLimit-Remaining", strconv.Itoa(rate.Remaining))
+		res.Header.Set("X-RateLimit-Reset", strconv.FormatInt(rate.Reset, 10))
+		if err == ErrRateLimited && rate.Reset != 0 {
+			if wait := rate.Reset - time.Now().Unix(); wait > 0 {
+				res.Header.Set("Retry-After", strconv.FormatInt(wait, 10))
+			}
+		}
+	}
+	return res, err
+}
+
+// statusForError returns the HTTP status code a real provider would
+// reply with for the error
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, ErrRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, scm.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, scm.ErrNotAuthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, scm.ErrNotSupported):
+		return http.StatusNotImplemented
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,109 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestMethodErrors(t *testing.T) {
+	ctx := context.Background()
+	client, data := NewDefault()
+
+	boom := errors.New("boom")
+	data.MethodErrors["Git.FindRef"] = []error{boom, scm.ErrNotFound}
+
+	if _, _, err := client.Git.FindRef(ctx, "foo/repo", "master"); err != boom {
+		t.Errorf("want error %v, got %v", boom, err)
+	}
+	_, res, err := client.Git.FindRef(ctx, "foo/repo", "master")
+	if err != scm.ErrNotFound {
+		t.Errorf("want error %v, got %v", scm.ErrNotFound, err)
+	}
+	if res == nil || res.Status != http.StatusNotFound {
+		t.Errorf("want a 404 response, got %+v", res)
+	}
+	if _, _, err := client.Git.FindRef(ctx, "foo/repo", "master"); err != nil {
+		t.Errorf("want the call to succeed once the errors are used up, got %v", err)
+	}
+	if _, _, err := client.Users.Find(ctx); err != nil {
+		t.Errorf("want other methods unaffected, got %v", err)
+	}
+}
+
+func TestLatency(t *testing.T) {
+	client, data := NewDefault()
+	data.Latency = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err := client.Git.FindRef(ctx, "foo/repo", "master"); err != context.DeadlineExceeded {
+		t.Errorf("want error %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	data.MethodLatency["Git.FindRef"] = time.Millisecond
+	if _, _, err := client.Git.FindRef(context.Background(), "foo/repo", "master"); err != nil {
+		t.Errorf("want the method latency to override the default, got %v", err)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	ctx := context.Background()
+	client, data := NewDefault()
+	data.RateLimit = &scm.Rate{Limit: 2, Remaining: 2, Reset: time.Now().Add(time.Hour).Unix()}
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Git.FindRef(ctx, "foo/repo", "master"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := client.Rate().Remaining, 0; got != want {
+		t.Errorf("want remaining %d, got %d", want, got)
+	}
+
+	_, res, err := client.Git.FindRef(ctx, "foo/repo", "master")
+	if err != ErrRateLimited {
+		t.Fatalf("want error %v, got %v", ErrRateLimited, err)
+	}
+	if res.Status != http.StatusTooManyRequests {
+		t.Errorf("want status %d, got %d", http.StatusTooManyRequests, res.Status)
+	}
+	if got, want := res.Header.Get("X-RateLimit-Remaining"), "0"; got != want {
+		t.Errorf("want header X-RateLimit-Remaining %s, got %s", want, got)
+	}
+	if res.Header.Get("Retry-After") == "" {
+		t.Errorf("want a Retry-After header")
+	}
+
+	data.RateLimit.Reset = time.Now().Add(-time.Second).Unix()
+	if _, _, err := client.Git.FindRef(ctx, "foo/repo", "master"); err != nil {
+		t.Errorf("want the limit to reset, got %v", err)
+	}
+	if got, want := client.Rate().Remaining, 1; got != want {
+		t.Errorf("want remaining %d, got %d", want, got)
+	}
+}
+
+func TestRateLimitWindows(t *testing.T) {
+	ctx := context.Background()
+	client, data := NewDefault()
+	data.RateLimit = &scm.Rate{Limit: 1, Remaining: 0, Reset: time.Now().Add(-time.Second).Unix()}
+	data.RateLimitWindow = time.Minute
+
+	for window := 0; window < 2; window++ {
+		if _, _, err := client.Git.FindRef(ctx, "foo/repo", "master"); err != nil {
+			t.Fatalf("window %d: want the limit to reset, got %v", window, err)
+		}
+		if data.RateLimit.Reset <= time.Now().Unix() {
+			t.Fatalf("window %d: want the reset time moved to the next window, got %d", window, data.RateLimit.Reset)
+		}
+		if _, _, err := client.Git.FindRef(ctx, "foo/repo", "master"); err != ErrRateLimited {
+			t.Fatalf("window %d: want error %v, got %v", window, ErrRateLimited, err)
+		}
+		data.RateLimit.Reset = time.Now().Add(-time.Second).Unix()
+	}
+}
@@ -3,9 +3,9 @@ package fake
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/internal/paging"
 )
 
 type gitService struct {
@@ -14,51 +14,178 @@ type gitService struct {
 }
 
 func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Git.FindRef"); err != nil {
+		return "", res, err
+	}
 	f := s.data
+	for _, path := range scm.QualifyRefs(ref) {
+		if sha, ok := f.Refs[repo][path]; ok {
+			return sha, nil, nil
+		}
+	}
 	return f.TestRef, nil, nil
 }
 
+func (s *gitService) ResolveRefs(ctx context.Context, repo string, refs []string) (map[string]string, map[string]error) {
+	return scm.ResolveRefs(ctx, s, repo, refs, scm.DefaultConcurrency)
+}
+
 func (s *gitService) CreateRef(ctx context.Context, repo, ref, sha string) (*scm.Reference, *scm.Response, error) {
-	panic("implement me")
+	if res, err := s.data.inject(ctx, s.client, "Git.CreateRef"); err != nil {
+		return nil, res, err
+	}
+	f := s.data
+	ref = scm.QualifyRef(ref)
+	if _, ok := f.Refs[repo][ref]; ok {
+		return nil, nil, fmt.Errorf("reference '%s' already exists", ref)
+	}
+	if f.Refs[repo] == nil {
+		f.Refs[repo] = map[string]string{}
+	}
+	f.Refs[repo][ref] = sha
+	return &scm.Reference{Name: scm.TrimRef(ref), Path: ref, Sha: sha}, nil, nil
+}
+
+func (s *gitService) UpdateRef(ctx context.Context, repo, ref, sha string, force bool) (*scm.Reference, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Git.UpdateRef"); err != nil {
+		return nil, res, err
+	}
+	f := s.data
+	ref = scm.QualifyRef(ref)
+	old, ok := f.Refs[repo][ref]
+	if !ok {
+		return nil, nil, scm.ErrNotFound
+	}
+	if _, known := f.Commits[old]; known && !force && !f.isAncestor(old, sha) {
+		return nil, nil, fmt.Errorf("update of reference '%s' to %s is not a fast-forward", ref, sha)
+	}
+	f.Refs[repo][ref] = sha
+	return &scm.Reference{Name: scm.TrimRef(ref), Path: ref, Sha: sha}, nil, nil
 }
 
 func (s *gitService) DeleteRef(ctx context.Context, repo, ref string) (*scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Git.DeleteRef"); err != nil {
+		return res, err
+	}
 	f := s.data
-	paths := strings.SplitN(repo, "/", 2)
-	if len(paths) < 2 {
-		return nil, fmt.Errorf("repository string '%s' should contain two words separated by '/'", repo)
+	r, err := scm.ParseRepo(repo)
+	if err != nil {
+		return nil, err
 	}
-	org := paths[0]
-	name := paths[1]
-	f.RefsDeleted = append(f.RefsDeleted, DeletedRef{Org: org, Repo: name, Ref: ref})
+	f.RefsDeleted = append(f.RefsDeleted, DeletedRef{Org: r.Namespace, Repo: r.Name, Ref: ref})
+	delete(f.Refs[repo], scm.QualifyRef(ref))
 	return nil, nil
 }
 
 func (s *gitService) FindBranch(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
-	panic("implement me")
+	if res, err := s.data.inject(ctx, s.client, "Git.FindBranch"); err != nil {
+		return nil, res, err
+	}
+	path := scm.ExpandRef(name, "refs/heads")
+	sha, ok := s.data.Refs[repo][path]
+	if !ok {
+		return nil, &scm.Response{Status: 404}, scm.ErrNotFound
+	}
+	return &scm.Reference{Name: scm.TrimRef(path), Path: path, Sha: sha}, nil, nil
 }
 
 func (s *gitService) FindCommit(ctx context.Context, repo, SHA string) (*scm.Commit, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Git.FindCommit"); err != nil {
+		return nil, res, err
+	}
 	f := s.data
-	return f.Commits[SHA], nil, nil
+	sha, ok := f.resolve(repo, SHA)
+	if !ok || f.Commits[sha] == nil {
+		return nil, &scm.Response{Status: 404}, scm.ErrNotFound
+	}
+	return f.Commits[sha], nil, nil
 }
 
 func (s *gitService) FindTag(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
-	panic("implement me")
+	if res, err := s.data.inject(ctx, s.client, "Git.FindTag"); err != nil {
+		return nil, res, err
+	}
+	path := scm.ExpandRef(name, "refs/tags")
+	sha, ok := s.data.Refs[repo][path]
+	if !ok {
+		return nil, &scm.Response{Status: 404}, scm.ErrNotFound
+	}
+	return &scm.Reference{Name: scm.TrimRef(path), Path: path, Sha: sha}, nil, nil
 }
 
 func (s *gitService) ListBranches(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
-	panic("implement me")
+	if res, err := s.data.inject(ctx, s.client, "Git.ListBranches"); err != nil {
+		return nil, res, err
+	}
+	return s.data.listRefs(repo, "refs/heads/", opts), nil, nil
 }
 
 func (s *gitService) ListCommits(ctx context.Context, repo string, opts scm.CommitListOptions) ([]*scm.Commit, *scm.Response, error) {
-	panic("implement me")
+	if res, err := s.data.inject(ctx, s.client, "Git.ListCommits"); err != nil {
+		return nil, res, err
+	}
+	f := s.data
+	ref := opts.Sha
+	if ref == "" {
+		ref = opts.Ref
+	}
+	if ref == "" {
+		ref = "master"
+	}
+	head, ok := f.resolve(repo, ref)
+	if !ok {
+		return nil, &scm.Response{Status: 404}, scm.ErrNotFound
+	}
+	var answer []*scm.Commit
+	for _, sha := range f.ancestors(head) {
+		if commit := f.Commits[sha]; commit != nil {
+			answer = append(answer, commit)
+		}
+	}
+	start, end := paging.Bounds(opts.Page, opts.Size, len(answer))
+	return answer[start:end], nil, nil
 }
 
 func (s *gitService) ListChanges(ctx context.Context, repo, ref string, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
 	panic("implement me")
 }
 
+func (s *gitService) CompareCommits(ctx context.Context, repo, base, head string) (*scm.Comparison, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Git.CompareCommits"); err != nil {
+		return nil, res, err
+	}
+	f := s.data
+	baseSha, ok := f.resolve(repo, base)
+	if !ok {
+		return nil, &scm.Response{Status: 404}, scm.ErrNotFound
+	}
+	headSha, ok := f.resolve(repo, head)
+	if !ok {
+		return nil, &scm.Response{Status: 404}, scm.ErrNotFound
+	}
+	return f.compare(baseSha, headSha), nil, nil
+}
+
+func (s *gitService) CompareAcrossForks(ctx context.Context, baseRepo, baseRef, headOwner, headRef string) (*scm.Comparison, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Git.CompareAcrossForks"); err != nil {
+		return nil, res, err
+	}
+	f := s.data
+	_, name := scm.Split(baseRepo)
+	base, ok := f.resolve(baseRepo, baseRef)
+	if !ok {
+		return nil, &scm.Response{Status: 404}, scm.ErrNotFound
+	}
+	head, ok := f.resolve(scm.Join(headOwner, name), headRef)
+	if !ok {
+		return nil, &scm.Response{Status: 404}, scm.ErrNotFound
+	}
+	return f.compare(base, head), nil, nil
+}
+
 func (s *gitService) ListTags(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
-	panic("implement me")
+	if res, err := s.data.inject(ctx, s.client, "Git.ListTags"); err != nil {
+		return nil, res, err
+	}
+	return s.data.listRefs(repo, "refs/tags/", opts), nil, nil
 }
@@ -0,0 +1,69 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestRefCreateUpdateDelete(t *testing.T) {
+	ctx := context.Background()
+	client, data := NewDefault()
+
+	ref, _, err := client.Git.CreateRef(ctx, "foo/repo", "refs/releases/v1", "a1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref.Path != "refs/releases/v1" || ref.Sha != "a1" {
+		t.Errorf("unexpected reference %+v", ref)
+	}
+
+	if _, _, err := client.Git.CreateRef(ctx, "foo/repo", "refs/releases/v1", "a1"); err == nil {
+		t.Error("expected error creating an existing ref")
+	}
+
+	if _, _, err := client.Git.UpdateRef(ctx, "foo/repo", "refs/releases/v1", "b2", false); err != nil {
+		t.Fatal(err)
+	}
+	sha, _, err := client.Git.FindRef(ctx, "foo/repo", "refs/releases/v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sha != "b2" {
+		t.Errorf("want sha b2, got %s", sha)
+	}
+
+	if _, err := client.Git.DeleteRef(ctx, "foo/repo", "refs/releases/v1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(data.RefsDeleted) != 1 {
+		t.Errorf("expected one deleted ref, got %d", len(data.RefsDeleted))
+	}
+	if _, _, err := client.Git.UpdateRef(ctx, "foo/repo", "refs/releases/v1", "c3", true); err != scm.ErrNotFound {
+		t.Errorf("want ErrNotFound updating a deleted ref, got %v", err)
+	}
+}
+
+func TestResolveTagNames(t *testing.T) {
+	ctx := context.Background()
+	client, data := NewDefault()
+	commit := data.CommitOnBranch("foo/repo", "master", "initial commit")
+	data.Refs["foo/repo"]["refs/tags/v1.0.0"] = commit.Sha
+
+	sha, _, err := client.Git.FindRef(ctx, "foo/repo", "v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sha != commit.Sha {
+		t.Errorf("want sha %s, got %s", commit.Sha, sha)
+	}
+
+	shas, errs := client.Git.ResolveRefs(ctx, "foo/repo", []string{"master", "v1.0.0"})
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+	if shas["master"] != commit.Sha || shas["v1.0.0"] != commit.Sha {
+		t.Errorf("unexpected shas %v", shas)
+	}
+}
@@ -0,0 +1,144 @@
+package fake
+
+import (
+	"crypto/sha1" // #nosec
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/internal/paging"
+)
+
+// AddCommit adds the commit to the commit graph with the given parents
+func (d *Data) AddCommit(commit *scm.Commit, parents ...string) {
+	d.Commits[commit.Sha] = commit
+	d.CommitParents[commit.Sha] = parents
+}
+
+// CommitOnBranch simulates pushing a new commit with the given message to the branch of the repository,
+// creating the branch if it does not exist yet. The new commit becomes the head of the branch
+func (d *Data) CommitOnBranch(repo, branch, message string) *scm.Commit {
+	ref := scm.ExpandRef(branch, "refs/heads")
+	parent := d.Refs[repo][ref]
+
+	/* #nosec */
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s\x00%s\x00%s\x00%d", repo, parent, message, len(d.Commits))))
+	commit := &scm.Commit{
+		Sha:     hex.EncodeToString(sum[:]),
+		Message: message,
+	}
+	if parent == "" {
+		d.AddCommit(commit)
+	} else {
+		d.AddCommit(commit, parent)
+	}
+	if d.Refs[repo] == nil {
+		d.Refs[repo] = map[string]string{}
+	}
+	d.Refs[repo][ref] = commit.Sha
+	return commit
+}
+
+// resolve returns the SHA the ref points at in the repository, accepting the
+// same forms as FindRef as well as commit SHAs
+func (d *Data) resolve(repo, ref string) (string, bool) {
+	if _, ok := d.Commits[ref]; ok {
+		return ref, true
+	}
+	for _, path := range scm.QualifyRefs(ref) {
+		if sha, ok := d.Refs[repo][path]; ok {
+			return sha, true
+		}
+	}
+	return "", false
+}
+
+// ancestors returns the commits reachable from the given SHA, including itself, in
+// breadth first order so the most recent commits come first
+func (d *Data) ancestors(sha string) []string {
+	var answer []string
+	seen := map[string]bool{sha: true}
+	queue := []string{sha}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		answer = append(answer, next)
+		for _, parent := range d.CommitParents[next] {
+			if !seen[parent] {
+				seen[parent] = true
+				queue = append(queue, parent)
+			}
+		}
+	}
+	return answer
+}
+
+// isAncestor returns true if the ancestor commit is reachable from the commit
+func (d *Data) isAncestor(ancestor, commit string) bool {
+	for _, sha := range d.ancestors(commit) {
+		if sha == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// compare returns the comparison of the head commit against the base commit
+func (d *Data) compare(base, head string) *scm.Comparison {
+	inBase := map[string]bool{}
+	for _, sha := range d.ancestors(base) {
+		inBase[sha] = true
+	}
+	inHead := map[string]bool{}
+	answer := &scm.Comparison{}
+	for _, sha := range d.ancestors(head) {
+		inHead[sha] = true
+		if inBase[sha] {
+			if answer.MergeBase == "" {
+				answer.MergeBase = sha
+			}
+			continue
+		}
+		answer.AheadBy++
+		if commit := d.Commits[sha]; commit != nil {
+			answer.Commits = append(answer.Commits, commit)
+		}
+	}
+	for sha := range inBase {
+		if !inHead[sha] {
+			answer.BehindBy++
+		}
+	}
+	switch {
+	case answer.AheadBy == 0 && answer.BehindBy == 0:
+		answer.Status = "identical"
+	case answer.BehindBy == 0:
+		answer.Status = "ahead"
+	case answer.AheadBy == 0:
+		answer.Status = "behind"
+	default:
+		answer.Status = "diverged"
+	}
+	return answer
+}
+
+// listRefs returns the refs of the repository in the given namespace, e.g. refs/heads/, sorted by name
+func (d *Data) listRefs(repo, prefix string, opts scm.ListOptions) []*scm.Reference {
+	var answer []*scm.Reference
+	for path, sha := range d.Refs[repo] {
+		if strings.HasPrefix(path, prefix) {
+			answer = append(answer, &scm.Reference{
+				Name: strings.TrimPrefix(path, prefix),
+				Path: path,
+				Sha:  sha,
+			})
+		}
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Name < answer[j].Name
+	})
+	start, end := paging.Bounds(opts.Page, opts.Size, len(answer))
+	return answer[start:end]
+}
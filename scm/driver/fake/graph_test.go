@@ -0,0 +1,87 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestCommitGraph(t *testing.T) {
+	ctx := context.Background()
+	client, data := NewDefault()
+
+	first := data.CommitOnBranch("foo/repo", "master", "initial commit")
+	second := data.CommitOnBranch("foo/repo", "master", "add README")
+
+	if _, _, err := client.Git.CreateRef(ctx, "foo/repo", "feature", second.Sha); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Git.CreateRef(ctx, "foo/repo", "refs/tags/v1.0.0", first.Sha); err != nil {
+		t.Fatal(err)
+	}
+	feature := data.CommitOnBranch("foo/repo", "feature", "add feature")
+
+	commits, _, err := client.Git.ListCommits(ctx, "foo/repo", scm.CommitListOptions{Ref: "feature"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]*scm.Commit{feature, second, first}, commits); diff != "" {
+		t.Errorf("unexpected commits\n%s", diff)
+	}
+
+	commit, _, err := client.Git.FindCommit(ctx, "foo/repo", "v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit != first {
+		t.Errorf("want the tag to point at the first commit, got %+v", commit)
+	}
+	if _, _, err := client.Git.FindCommit(ctx, "foo/repo", "0000000000000000000000000000000000000000"); err != scm.ErrNotFound {
+		t.Errorf("want error %v for an unknown commit, got %v", scm.ErrNotFound, err)
+	}
+
+	branches, _, err := client.Git.ListBranches(ctx, "foo/repo", scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*scm.Reference{
+		{Name: "feature", Path: "refs/heads/feature", Sha: feature.Sha},
+		{Name: "master", Path: "refs/heads/master", Sha: second.Sha},
+	}
+	if diff := cmp.Diff(want, branches); diff != "" {
+		t.Errorf("unexpected branches\n%s", diff)
+	}
+
+	comparison, _, err := client.Git.CompareAcrossForks(ctx, "foo/repo", "master", "foo", "feature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comparison.Status != "ahead" || comparison.AheadBy != 1 || comparison.BehindBy != 0 || comparison.MergeBase != second.Sha {
+		t.Errorf("unexpected comparison %+v", comparison)
+	}
+
+	comparison, _, err = client.Git.CompareCommits(ctx, "foo/repo", "feature", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comparison.Status != "behind" || comparison.AheadBy != 0 || comparison.BehindBy != 1 {
+		t.Errorf("unexpected comparison %+v", comparison)
+	}
+
+	hotfix := data.CommitOnBranch("foo/repo", "master", "hotfix")
+	if _, _, err := client.Git.UpdateRef(ctx, "foo/repo", "feature", hotfix.Sha, false); err == nil {
+		t.Errorf("want a non fast-forward update to be rejected")
+	}
+	if _, _, err := client.Git.UpdateRef(ctx, "foo/repo", "feature", hotfix.Sha, true); err != nil {
+		t.Errorf("want a forced update to succeed, got %v", err)
+	}
+
+	if _, err := client.Git.DeleteRef(ctx, "foo/repo", "feature"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Git.FindBranch(ctx, "foo/repo", "feature"); err != scm.ErrNotFound {
+		t.Errorf("want error %v, got %v", scm.ErrNotFound, err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/slimm609/go-scm/scm"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -16,17 +17,26 @@ type issueService struct {
 
 const botName = "k8s-ci-robot"
 
-func (s *issueService) Search(context.Context, scm.SearchOptions) ([]*scm.SearchIssue, *scm.Response, error) {
+func (s *issueService) Search(ctx context.Context, _ scm.SearchOptions) ([]*scm.SearchIssue, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Issues.Search"); err != nil {
+		return nil, res, err
+	}
 	// TODO implemment
 	return nil, nil, nil
 }
 
 func (s *issueService) ListEvents(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Issues.ListEvents"); err != nil {
+		return nil, res, err
+	}
 	f := s.data
 	return append([]*scm.ListedIssueEvent{}, f.IssueEvents[number]...), nil, nil
 }
 
 func (s *issueService) Find(ctx context.Context, repo string, number int) (*scm.Issue, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Issues.Find"); err != nil {
+		return nil, res, err
+	}
 	f := s.data
 	for _, slice := range f.Issues {
 		for _, issue := range slice {
@@ -39,6 +49,9 @@ func (s *issueService) Find(ctx context.Context, repo string, number int) (*scm.
 }
 
 func (s *issueService) ListLabels(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Issues.ListLabels"); err != nil {
+		return nil, res, err
+	}
 	f := s.data
 	re := regexp.MustCompile(fmt.Sprintf(`^%s#%d:(.*)$`, repo, number))
 	la := []*scm.Label{}
@@ -55,6 +68,9 @@ func (s *issueService) ListLabels(ctx context.Context, repo string, number int,
 }
 
 func (s *issueService) AddLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Issues.AddLabel"); err != nil {
+		return res, err
+	}
 	f := s.data
 	labelString := fmt.Sprintf("%s#%d:%s", repo, number, label)
 	if sets.NewString(f.IssueLabelsAdded...).Has(labelString) {
@@ -75,6 +91,9 @@ func (s *issueService) AddLabel(ctx context.Context, repo string, number int, la
 
 // DeleteLabel removes a label
 func (s *issueService) DeleteLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Issues.DeleteLabel"); err != nil {
+		return res, err
+	}
 	f := s.data
 	labelString := fmt.Sprintf("%s#%d:%s", repo, number, label)
 	if !sets.NewString(f.IssueLabelsRemoved...).Has(labelString) {
@@ -98,6 +117,9 @@ func (s *issueService) FindIssues(query, sort string, asc bool) ([]scm.Issue, er
 
 // AssignIssue adds assignees.
 func (s *issueService) AssignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Issues.AssignIssue"); err != nil {
+		return res, err
+	}
 	f := s.data
 	var m scm.MissingUsers
 	for _, a := range logins {
@@ -125,16 +147,64 @@ func (s *issueService) List(context.Context, string, scm.IssueListOptions) ([]*s
 	panic("implement me")
 }
 
-func (s *issueService) ListComments(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
+func (s *issueService) ListComments(ctx context.Context, repo string, number int, opts scm.CommentListOptions) ([]*scm.Comment, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Issues.ListComments"); err != nil {
+		return nil, res, err
+	}
 	f := s.data
-	return append([]*scm.Comment{}, f.IssueComments[number]...), nil, nil
+	return scm.FilterComments(f.IssueComments[number], opts), nil, nil
 }
 
 func (s *issueService) Create(context.Context, string, *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
 	panic("implement me")
 }
 
+func (s *issueService) Update(ctx context.Context, repo string, number int, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Issues.Update"); err != nil {
+		return nil, res, err
+	}
+	for _, slice := range s.data.Issues {
+		for _, issue := range slice {
+			if issue.Number != number {
+				continue
+			}
+			if input.Title != "" {
+				issue.Title = input.Title
+			}
+			if input.Body != "" {
+				issue.Body = input.Body
+			}
+			if state := input.TargetState(); state != "" {
+				issue.State = state
+				issue.Closed = state == scm.IssueStateClosed
+				issue.StateReason = input.StateReason
+				issue.ClosedAt = time.Time{}
+				if issue.Closed {
+					issue.ClosedAt = time.Now().UTC()
+				}
+			}
+			if len(input.Labels) != 0 {
+				issue.Labels = nil
+				for _, l := range input.Labels {
+					issue.Labels = append(issue.Labels, &scm.Label{Name: l})
+				}
+			}
+			if len(input.Assignees) != 0 {
+				issue.Assignees = nil
+				for _, login := range input.Assignees {
+					issue.Assignees = append(issue.Assignees, scm.User{Login: login})
+				}
+			}
+			return issue, nil, nil
+		}
+	}
+	return nil, nil, scm.ErrNotFound
+}
+
 func (s *issueService) CreateComment(ctx context.Context, repo string, number int, comment *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Issues.CreateComment"); err != nil {
+		return nil, res, err
+	}
 	f := s.data
 	f.IssueCommentsAdded = append(f.IssueCommentsAdded, fmt.Sprintf("%s#%d:%s", repo, number, comment.Body))
 	answer := &scm.Comment{
@@ -148,6 +218,9 @@ func (s *issueService) CreateComment(ctx context.Context, repo string, number in
 }
 
 func (s *issueService) DeleteComment(ctx context.Context, repo string, number int, id int) (*scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Issues.DeleteComment"); err != nil {
+		return res, err
+	}
 	f := s.data
 	f.IssueCommentsDeleted = append(f.IssueCommentsDeleted, fmt.Sprintf("%s#%d", repo, id))
 	for num, ics := range f.IssueComments {
@@ -162,7 +235,33 @@ func (s *issueService) DeleteComment(ctx context.Context, repo string, number in
 }
 
 func (s *issueService) EditComment(ctx context.Context, repo string, number int, id int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	if res, err := s.data.inject(ctx, s.client, "Issues.EditComment"); err != nil {
+		return nil, res, err
+	}
+	for _, ic := range s.data.IssueComments[number] {
+		if ic.ID == id {
+			ic.Body = input.Body
+			ic.Updated = time.Now().UTC()
+			return ic, nil, nil
+		}
+	}
+	return nil, nil, scm.ErrNotFound
+}
+
+func (s *issueService) MinimizeComment(ctx context.Context, repo string, number int, id int, reason string) (*scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Issues.MinimizeComment"); err != nil {
+		return res, err
+	}
+	s.data.MinimizedComments[fmt.Sprintf("%s#%d", repo, id)] = reason
+	return nil, nil
+}
+
+func (s *issueService) UnminimizeComment(ctx context.Context, repo string, number int, id int) (*scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Issues.UnminimizeComment"); err != nil {
+		return res, err
+	}
+	delete(s.data.MinimizedComments, fmt.Sprintf("%s#%d", repo, id))
+	return nil, nil
 }
 
 func (s *issueService) Close(context.Context, string, int) (*scm.Response, error) {
@@ -182,9 +281,58 @@ func (s *issueService) Unlock(context.Context, string, int) (*scm.Response, erro
 }
 
 func (s *issueService) SetMilestone(ctx context.Context, repo string, issueID int, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "SetMilestone")
 }
 
 func (s *issueService) ClearMilestone(ctx context.Context, repo string, id int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "ClearMilestone")
+}
+
+func (s *issueService) Pin(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Issues.Pin"); err != nil {
+		return res, err
+	}
+	f := s.data
+	for _, n := range f.PinnedIssues[repo] {
+		if n == number {
+			return nil, nil
+		}
+	}
+	f.PinnedIssues[repo] = append(f.PinnedIssues[repo], number)
+	return nil, nil
+}
+
+func (s *issueService) Unpin(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Issues.Unpin"); err != nil {
+		return res, err
+	}
+	f := s.data
+	pinned := f.PinnedIssues[repo]
+	for i, n := range pinned {
+		if n == number {
+			f.PinnedIssues[repo] = append(pinned[:i], pinned[i+1:]...)
+			return nil, nil
+		}
+	}
+	return nil, scm.ErrNotFound
+}
+
+func (s *issueService) ListPinned(ctx context.Context, repo string) ([]*scm.Issue, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Issues.ListPinned"); err != nil {
+		return nil, res, err
+	}
+	f := s.data
+	answer := []*scm.Issue{}
+	for _, number := range f.PinnedIssues[repo] {
+		pinned := &scm.Issue{Number: number}
+		for _, slice := range f.Issues {
+			for _, issue := range slice {
+				if issue.Number == number {
+					pinned = issue
+				}
+			}
+		}
+		answer = append(answer, pinned)
+	}
+	return answer, nil, nil
 }
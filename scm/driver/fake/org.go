@@ -32,7 +32,7 @@ func (s *organizationService) Create(context.Context, *scm.OrganizationInput) (*
 }
 
 func (s *organizationService) Delete(context.Context, string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Organizations", "Delete")
 }
 
 func (s *organizationService) IsMember(ctx context.Context, org string, user string) (bool, *scm.Response, error) {
@@ -40,10 +40,16 @@ func (s *organizationService) IsMember(ctx context.Context, org string, user str
 }
 
 func (s *organizationService) IsAdmin(ctx context.Context, org string, user string) (bool, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Organizations.IsAdmin"); err != nil {
+		return false, res, err
+	}
 	return user == "adminUser", &scm.Response{}, nil
 }
 
 func (s *organizationService) Find(ctx context.Context, name string) (*scm.Organization, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Organizations.Find"); err != nil {
+		return nil, res, err
+	}
 	for _, org := range s.data.Organizations {
 		if org.Name == name {
 			return org, nil, nil
@@ -52,7 +58,10 @@ func (s *organizationService) Find(ctx context.Context, name string) (*scm.Organ
 	return nil, nil, scm.ErrNotFound
 }
 
-func (s *organizationService) List(context.Context, scm.ListOptions) ([]*scm.Organization, *scm.Response, error) {
+func (s *organizationService) List(ctx context.Context, _ scm.ListOptions) ([]*scm.Organization, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Organizations.List"); err != nil {
+		return nil, res, err
+	}
 	orgs := s.data.Organizations
 	if orgs == nil {
 		// Return hardcoded organizations if none specified explicitly
@@ -74,6 +83,9 @@ func (s *organizationService) List(context.Context, scm.ListOptions) ([]*scm.Org
 }
 
 func (s *organizationService) ListTeams(ctx context.Context, org string, ops scm.ListOptions) ([]*scm.Team, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Organizations.ListTeams"); err != nil {
+		return nil, res, err
+	}
 	return []*scm.Team{
 		{
 			ID:   0,
@@ -87,6 +99,9 @@ func (s *organizationService) ListTeams(ctx context.Context, org string, ops scm
 }
 
 func (s *organizationService) ListTeamMembers(ctx context.Context, teamID int, role string, ops scm.ListOptions) ([]*scm.TeamMember, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Organizations.ListTeamMembers"); err != nil {
+		return nil, res, err
+	}
 	if role != RoleAll {
 		return nil, nil, fmt.Errorf("unsupported role %v (only all supported)", role)
 	}
@@ -102,9 +117,12 @@ func (s *organizationService) ListTeamMembers(ctx context.Context, teamID int, r
 }
 
 func (s *organizationService) ListOrgMembers(ctx context.Context, org string, ops scm.ListOptions) ([]*scm.TeamMember, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Organizations", "ListOrgMembers")
 }
-func (s *organizationService) ListPendingInvitations(_ context.Context, org string, opts scm.ListOptions) ([]*scm.OrganizationPendingInvite, *scm.Response, error) {
+func (s *organizationService) ListPendingInvitations(ctx context.Context, org string, opts scm.ListOptions) ([]*scm.OrganizationPendingInvite, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Organizations.ListPendingInvitations"); err != nil {
+		return nil, res, err
+	}
 	for _, o := range s.data.Organizations {
 		if o.Name == org {
 			return []*scm.OrganizationPendingInvite{{
@@ -118,6 +136,9 @@ func (s *organizationService) ListPendingInvitations(_ context.Context, org stri
 }
 
 func (s *organizationService) ListMemberships(ctx context.Context, opts scm.ListOptions) ([]*scm.Membership, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Organizations.ListMemberships"); err != nil {
+		return nil, res, err
+	}
 
 	return []*scm.Membership{
 		{
@@ -134,7 +155,10 @@ func (s *organizationService) ListMemberships(ctx context.Context, opts scm.List
 
 }
 
-func (s *organizationService) AcceptOrganizationInvitation(_ context.Context, org string) (*scm.Response, error) {
+func (s *organizationService) AcceptOrganizationInvitation(ctx context.Context, org string) (*scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Organizations.AcceptOrganizationInvitation"); err != nil {
+		return res, err
+	}
 	for _, o := range s.data.Organizations {
 		if o.Name == org {
 			return nil, nil
@@ -0,0 +1,90 @@
+package fake
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// Save writes the data to the given file as JSON so it can be loaded again via Load.
+// Errors registered in MethodErrors are not saved
+func (d *Data) Save(path string) error {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal fake data")
+	}
+	err = ioutil.WriteFile(path, data, DefaultFileWritePermissions)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write fake data to %s", path)
+	}
+	return nil
+}
+
+// Load replaces the data with the contents of the given file written by Save
+func (d *Data) Load(path string) error {
+	data, err := ioutil.ReadFile(path) // #nosec
+	if err != nil {
+		return errors.Wrapf(err, "failed to read fake data from %s", path)
+	}
+	loaded := NewData()
+	err = json.Unmarshal(data, loaded)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal fake data from %s", path)
+	}
+	loaded.MethodErrors = d.MethodErrors
+	d.replace(loaded)
+	return nil
+}
+
+// Snapshot returns a deep copy of the data which can later be passed to Restore
+// to roll the fake back to the current state
+func (d *Data) Snapshot() (*Data, error) {
+	out, err := d.clone()
+	if err != nil {
+		return nil, err
+	}
+	out.MethodErrors = map[string][]error{}
+	for k, v := range d.MethodErrors {
+		out.MethodErrors[k] = append([]error(nil), v...)
+	}
+	return out, nil
+}
+
+// Restore replaces the data with a deep copy of the snapshot
+func (d *Data) Restore(snapshot *Data) error {
+	restored, err := snapshot.Snapshot()
+	if err != nil {
+		return err
+	}
+	d.replace(restored)
+	return nil
+}
+
+// clone deep copies the data by round tripping it through JSON
+func (d *Data) clone() (*Data, error) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal fake data")
+	}
+	out := NewData()
+	err = json.Unmarshal(data, out)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal fake data")
+	}
+	return out, nil
+}
+
+// replace sets all the exported fields of the data to the ones of from. The fields
+// are copied one by one so the data keeps its own lock and the clients created via
+// NewDefault keep pointing at it
+func (d *Data) replace(from *Data) {
+	dst := reflect.ValueOf(d).Elem()
+	src := reflect.ValueOf(from).Elem()
+	for i := 0; i < dst.NumField(); i++ {
+		if dst.Type().Field(i).PkgPath == "" {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+}
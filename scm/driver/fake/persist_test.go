@@ -0,0 +1,76 @@
+package fake
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+	client, data := NewDefault()
+
+	if _, _, err := client.Repositories.CreateHook(ctx, "foo/repo", &scm.HookInput{Name: "before"}); err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := data.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := client.Repositories.CreateHook(ctx, "foo/repo", &scm.HookInput{Name: "after"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(snapshot.Hooks["foo/repo"]); got != 1 {
+		t.Errorf("want the snapshot unaffected by later changes, got %d hooks", got)
+	}
+
+	if err := data.Restore(snapshot); err != nil {
+		t.Fatal(err)
+	}
+	hooks, _, err := client.Repositories.ListHooks(ctx, "foo/repo", scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hooks) != 1 || hooks[0].Name != "before" {
+		t.Errorf("want the client to see the restored hooks, got %+v", hooks)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fake")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "data.json")
+
+	ctx := context.Background()
+	client, data := NewDefault()
+	data.PullRequests[1] = &scm.PullRequest{Number: 1, Title: "Update README"}
+	data.Refs["foo/repo"] = map[string]string{"refs/heads/master": "a1"}
+	if err := data.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	other, loaded := NewDefault()
+	if err := loaded.Load(path); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(data.PullRequests, loaded.PullRequests); diff != "" {
+		t.Errorf("unexpected pull requests\n%s", diff)
+	}
+	want, _, _ := client.Git.FindRef(ctx, "foo/repo", "master")
+	got, _, err := other.Git.FindRef(ctx, "foo/repo", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("want ref %s, got %s", want, got)
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sort"
+	"time"
 
 	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/internal/paging"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -15,6 +18,9 @@ type pullService struct {
 }
 
 func (s *pullService) Find(ctx context.Context, repo string, number int) (*scm.PullRequest, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "PullRequests.Find"); err != nil {
+		return nil, res, err
+	}
 	f := s.data
 	val, exists := f.PullRequests[number]
 	if !exists {
@@ -30,22 +36,53 @@ func (s *pullService) FindComment(context.Context, string, int, int) (*scm.Comme
 	panic("implement me")
 }
 
-func (s *pullService) List(context.Context, string, scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
-	panic("implement me")
+func (s *pullService) List(ctx context.Context, repo string, opts scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "PullRequests.List"); err != nil {
+		return nil, res, err
+	}
+	var numbers []int
+	for number := range s.data.PullRequests {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+	var prs []*scm.PullRequest
+	for _, number := range numbers {
+		pr := s.data.PullRequests[number]
+		// pull requests seeded without a repository are
+		// listed for every repository
+		if pr.Base.Repo.FullName != "" && pr.Base.Repo.FullName != repo {
+			continue
+		}
+		if (opts.Open || opts.Closed) && !(opts.Open && !pr.Closed || opts.Closed && pr.Closed) {
+			continue
+		}
+		prs = append(prs, pr)
+	}
+	start, end := paging.Bounds(opts.Page, opts.Size, len(prs))
+	return prs[start:end], nil, nil
 }
 
 func (s *pullService) ListChanges(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "PullRequests.ListChanges"); err != nil {
+		return nil, res, err
+	}
 	f := s.data
-	returnStart, returnEnd := paginated(opts.Page, opts.Size, len(f.PullRequestChanges[number]))
+	returnStart, returnEnd := paging.Bounds(opts.Page, opts.Size, len(f.PullRequestChanges[number]))
 	return f.PullRequestChanges[number][returnStart:returnEnd], nil, nil
 }
 
-func (s *pullService) ListComments(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
+func (s *pullService) ListComments(ctx context.Context, repo string, number int, opts scm.CommentListOptions) ([]*scm.Comment, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "PullRequests.ListComments"); err != nil {
+		return nil, res, err
+	}
 	f := s.data
-	return append([]*scm.Comment{}, f.PullRequestComments[number]...), nil, nil
+	return scm.FilterComments(f.PullRequestComments[number], opts), nil, nil
 }
 
 func (s *pullService) ListLabels(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "PullRequests.ListLabels"); err != nil {
+		return nil, res, err
+	}
 	f := s.data
 	re := regexp.MustCompile(fmt.Sprintf(`^%s#%d:(.*)$`, repo, number))
 	la := []*scm.Label{}
@@ -62,10 +99,13 @@ func (s *pullService) ListLabels(ctx context.Context, repo string, number int, o
 }
 
 func (s *pullService) ListEvents(context.Context, string, int, scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("PullRequests", "ListEvents")
 }
 
 func (s *pullService) AddLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "PullRequests.AddLabel"); err != nil {
+		return res, err
+	}
 	f := s.data
 	labelString := fmt.Sprintf("%s#%d:%s", repo, number, label)
 	if sets.NewString(f.PullRequestLabelsAdded...).Has(labelString) {
@@ -86,6 +126,9 @@ func (s *pullService) AddLabel(ctx context.Context, repo string, number int, lab
 
 // DeleteLabel removes a label
 func (s *pullService) DeleteLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "PullRequests.DeleteLabel"); err != nil {
+		return res, err
+	}
 	f := s.data
 	labelString := fmt.Sprintf("%s#%d:%s", repo, number, label)
 	if !sets.NewString(f.PullRequestLabelsRemoved...).Has(labelString) {
@@ -96,13 +139,19 @@ func (s *pullService) DeleteLabel(ctx context.Context, repo string, number int,
 }
 
 func (s *pullService) Merge(ctx context.Context, repo string, number int, mergeOpts *scm.PullRequestMergeOptions) (*scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "PullRequests.Merge"); err != nil {
+		return res, err
+	}
 	pr, ok := s.data.PullRequests[number]
 	if !ok || pr == nil {
 		return nil, fmt.Errorf("pull request %d not found", number)
 	}
+	now := time.Now().UTC()
 	pr.Merged = true
+	pr.MergedAt = now
 	pr.State = "closed"
 	pr.Closed = true
+	pr.ClosedAt = now
 	pr.Mergeable = false
 	return nil, nil
 }
@@ -120,6 +169,9 @@ func (s *pullService) Reopen(context.Context, string, int) (*scm.Response, error
 }
 
 func (s *pullService) CreateComment(ctx context.Context, repo string, number int, comment *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "PullRequests.CreateComment"); err != nil {
+		return nil, res, err
+	}
 	f := s.data
 	f.PullRequestCommentsAdded = append(f.PullRequestCommentsAdded, fmt.Sprintf("%s#%d:%s", repo, number, comment.Body))
 	answer := &scm.Comment{
@@ -133,6 +185,9 @@ func (s *pullService) CreateComment(ctx context.Context, repo string, number int
 }
 
 func (s *pullService) DeleteComment(ctx context.Context, repo string, number int, id int) (*scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "PullRequests.DeleteComment"); err != nil {
+		return res, err
+	}
 	f := s.data
 	f.PullRequestCommentsDeleted = append(f.PullRequestCommentsDeleted, fmt.Sprintf("%s#%d", repo, id))
 	for num, ics := range f.PullRequestComments {
@@ -147,10 +202,23 @@ func (s *pullService) DeleteComment(ctx context.Context, repo string, number int
 }
 
 func (s *pullService) EditComment(ctx context.Context, repo string, number int, id int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	if res, err := s.data.inject(ctx, s.client, "PullRequests.EditComment"); err != nil {
+		return nil, res, err
+	}
+	for _, ic := range s.data.PullRequestComments[number] {
+		if ic.ID == id {
+			ic.Body = input.Body
+			ic.Updated = time.Now().UTC()
+			return ic, nil, nil
+		}
+	}
+	return nil, nil, scm.ErrNotFound
 }
 
 func (s *pullService) AssignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "PullRequests.AssignIssue"); err != nil {
+		return res, err
+	}
 	f := s.data
 	var m scm.MissingUsers
 	for _, a := range logins {
@@ -171,26 +239,38 @@ func (s *pullService) UnassignIssue(ctx context.Context, repo string, number int
 }
 
 func (s *pullService) RequestReview(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "RequestReview")
 }
 
 func (s *pullService) UnrequestReview(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "UnrequestReview")
 }
 
 func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "PullRequests.Create"); err != nil {
+		return nil, res, err
+	}
 	f := s.data
 	f.PullRequestID++
+	namespace, name := scm.Split(repo)
 	answer := &scm.PullRequest{
 		Number: f.PullRequestID,
 		Title:  input.Title,
 		Body:   input.Body,
 		Base: scm.PullRequestBranch{
-			Ref: input.Base,
+			Ref:  input.Base,
+			Repo: scm.Repository{Namespace: namespace, Name: name, FullName: repo},
 		},
 		Head: scm.PullRequestBranch{
 			Ref: input.Head,
 		},
+		Milestone: scm.Milestone{Number: input.Milestone},
+	}
+	for _, l := range input.Labels {
+		answer.Labels = append(answer.Labels, &scm.Label{Name: l})
+	}
+	for _, a := range input.Assignees {
+		answer.Assignees = append(answer.Assignees, scm.User{Login: a})
 	}
 	f.PullRequestsCreated[f.PullRequestID] = input
 	f.PullRequests[f.PullRequestID] = answer
@@ -198,9 +278,9 @@ func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRe
 }
 
 func (s *pullService) SetMilestone(ctx context.Context, repo string, prID int, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "SetMilestone")
 }
 
 func (s *pullService) ClearMilestone(ctx context.Context, repo string, prID int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "ClearMilestone")
 }
@@ -42,29 +42,6 @@ func TestListChangesPagination(t *testing.T) {
 	}
 }
 
-func TestPaginated(t *testing.T) {
-	tests := []struct {
-		page      int
-		size      int
-		items     int
-		wantStart int
-		wantEnd   int
-	}{
-		{1, 5, 10, 0, 5},
-		{2, 5, 10, 5, 10},
-		{2, 5, 9, 5, 9},
-		{4, 5, 10, 10, 10}, // this results in an empty slice
-		{0, 0, 10, 0, 10},  // this is the default 0 value for ListOption
-	}
-
-	for _, tt := range tests {
-		start, end := paginated(tt.page, tt.size, tt.items)
-		if tt.wantStart != start || tt.wantEnd != end {
-			t.Fatalf("paginaged(%d, %d, %d) got items[%d:%d], want items[%d:%d]", tt.page, tt.size, tt.items, start, end, tt.wantStart, tt.wantEnd)
-		}
-	}
-}
-
 func makeChanges(n int) []*scm.Change {
 	c := []*scm.Change{}
 	for i := 1; i <= n; i++ {
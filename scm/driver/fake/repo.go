@@ -3,11 +3,12 @@ package fake
 import (
 	"context"
 	"fmt"
-	"math/rand"
+	"io"
 	"strings"
 	"time"
 
 	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/internal/paging"
 )
 
 type repositoryService struct {
@@ -16,6 +17,9 @@ type repositoryService struct {
 }
 
 func (s *repositoryService) FindCombinedStatus(ctx context.Context, repo, ref string) (*scm.CombinedStatus, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Repositories.FindCombinedStatus"); err != nil {
+		return nil, res, err
+	}
 	statuses, _, err := s.ListStatus(ctx, repo, ref, scm.ListOptions{})
 	if err != nil {
 		return nil, nil, err
@@ -27,6 +31,9 @@ func (s *repositoryService) FindCombinedStatus(ctx context.Context, repo, ref st
 }
 
 func (s *repositoryService) FindUserPermission(ctx context.Context, repo string, user string) (string, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Repositories.FindUserPermission"); err != nil {
+		return "", res, err
+	}
 	f := s.data
 	m := f.UserPermissions[repo]
 	perm := ""
@@ -39,16 +46,34 @@ func (s *repositoryService) FindUserPermission(ctx context.Context, repo string,
 // NormLogin normalizes login strings
 var NormLogin = strings.ToLower
 
-func (s *repositoryService) FindHook(context.Context, string, string) (*scm.Hook, *scm.Response, error) {
-	panic("implement me")
+func (s *repositoryService) FindHook(ctx context.Context, fullName string, hookID string) (*scm.Hook, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Repositories.FindHook"); err != nil {
+		return nil, res, err
+	}
+	for _, h := range s.data.Hooks[fullName] {
+		if h.ID == hookID {
+			return h, nil, nil
+		}
+	}
+	return nil, &scm.Response{Status: 404}, scm.ErrNotFound
 }
 
 func (s *repositoryService) FindPerms(context.Context, string) (*scm.Perm, *scm.Response, error) {
 	panic("implement me")
 }
 
-func (s *repositoryService) ListOrganisation(context.Context, string, scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
-	panic("implement me")
+func (s *repositoryService) ListOrganisation(ctx context.Context, org string, opts scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Repositories.ListOrganisation"); err != nil {
+		return nil, res, err
+	}
+	var repos []*scm.Repository
+	for _, r := range s.data.Repositories {
+		if r.Namespace == org {
+			repos = append(repos, r)
+		}
+	}
+	start, end := paging.Bounds(opts.Page, opts.Size, len(repos))
+	return repos[start:end], nil, nil
 }
 
 func (s *repositoryService) ListUser(context.Context, string, scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
@@ -56,6 +81,9 @@ func (s *repositoryService) ListUser(context.Context, string, scm.ListOptions) (
 }
 
 func (s *repositoryService) AddCollaborator(ctx context.Context, repo, user, permission string) (bool, bool, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Repositories.AddCollaborator"); err != nil {
+		return false, false, res, err
+	}
 	s.data.Collaborators = append(s.data.Collaborators, user)
 	if len(s.data.UserPermissions) == 0 {
 		s.data.UserPermissions = make(map[string]map[string]string)
@@ -76,24 +104,18 @@ func (s *repositoryService) AddCollaborator(ctx context.Context, repo, user, per
 	// lets add an invitation if the user isn't already in the repo...
 	if !alreadyExists {
 		id := int64(len(s.data.Invitations) + 1)
-		names := strings.SplitN(repo, "/", 2)
-		owner := ""
-		repoName := ""
-		if len(names) == 2 {
-			owner = names[0]
-			repoName = names[1]
-		}
+		r, _ := scm.ParseRepo(repo)
 		s.data.Invitations = append(s.data.Invitations, &scm.Invitation{
 			ID: id,
 			Repo: &scm.Repository{
-				Namespace: owner,
-				Name:      repoName,
+				Namespace: r.Namespace,
+				Name:      r.Name,
 				FullName:  repo,
 			},
 			Invitee:     &scm.User{},
 			Inviter:     &scm.User{},
 			Permissions: permission,
-			Created:     time.Now(),
+			Created:     time.Now().UTC(),
 		})
 	}
 
@@ -101,6 +123,9 @@ func (s *repositoryService) AddCollaborator(ctx context.Context, repo, user, per
 }
 
 func (s *repositoryService) IsCollaborator(ctx context.Context, repo, login string) (bool, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Repositories.IsCollaborator"); err != nil {
+		return false, res, err
+	}
 	f := s.data
 	normed := NormLogin(login)
 	for _, collab := range f.Collaborators {
@@ -112,6 +137,9 @@ func (s *repositoryService) IsCollaborator(ctx context.Context, repo, login stri
 }
 
 func (s *repositoryService) ListCollaborators(ctx context.Context, repo string, ops scm.ListOptions) ([]scm.User, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Repositories.ListCollaborators"); err != nil {
+		return nil, res, err
+	}
 	f := s.data
 	result := make([]scm.User, 0, len(f.Collaborators))
 	for _, login := range f.Collaborators {
@@ -121,6 +149,9 @@ func (s *repositoryService) ListCollaborators(ctx context.Context, repo string,
 }
 
 func (s *repositoryService) Find(ctx context.Context, fullName string) (*scm.Repository, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Repositories.Find"); err != nil {
+		return nil, res, err
+	}
 	for _, repo := range s.data.Repositories {
 		if repo.FullName == fullName {
 			return repo, nil, nil
@@ -130,10 +161,16 @@ func (s *repositoryService) Find(ctx context.Context, fullName string) (*scm.Rep
 }
 
 func (s *repositoryService) List(ctx context.Context, opts scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Repositories.List"); err != nil {
+		return nil, res, err
+	}
 	return s.data.Repositories, nil, nil
 }
 
-func (s *repositoryService) ListLabels(context.Context, string, scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
+func (s *repositoryService) ListLabels(ctx context.Context, _ string, _ scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Repositories.ListLabels"); err != nil {
+		return nil, res, err
+	}
 	f := s.data
 	la := []*scm.Label{}
 	for _, l := range f.RepoLabelsExisting {
@@ -143,6 +180,9 @@ func (s *repositoryService) ListLabels(context.Context, string, scm.ListOptions)
 }
 
 func (s *repositoryService) ListStatus(ctx context.Context, repo string, ref string, opt scm.ListOptions) ([]*scm.Status, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Repositories.ListStatus"); err != nil {
+		return nil, res, err
+	}
 	f := s.data
 	result := make([]*scm.Status, 0, len(f.Statuses))
 	for _, status := range f.Statuses[ref] {
@@ -152,6 +192,9 @@ func (s *repositoryService) ListStatus(ctx context.Context, repo string, ref str
 }
 
 func (s *repositoryService) Create(ctx context.Context, input *scm.RepositoryInput) (*scm.Repository, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Repositories.Create"); err != nil {
+		return nil, res, err
+	}
 	s.data.CreateRepositories = append(s.data.CreateRepositories, input)
 	fullName := scm.Join(input.Namespace, input.Name)
 	repo := &scm.Repository{
@@ -159,46 +202,66 @@ func (s *repositoryService) Create(ctx context.Context, input *scm.RepositoryInp
 		Name:      input.Name,
 		FullName:  fullName,
 		Link:      fmt.Sprintf("https://fake.com/%s.git", fullName),
-		Created:   time.Now(),
+		Created:   time.Now().UTC(),
 	}
 	s.data.Repositories = append(s.data.Repositories, repo)
 	return repo, nil, nil
 }
 
 func (s *repositoryService) Fork(ctx context.Context, input *scm.RepositoryInput, origRepo string) (*scm.Repository, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Repositories.Fork"); err != nil {
+		return nil, res, err
+	}
 	// TODO: Actually make this fork rather than just duplicate Create.
 	return s.Create(ctx, input)
 }
 
 func (s *repositoryService) ListHooks(ctx context.Context, fullName string, opts scm.ListOptions) ([]*scm.Hook, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Repositories.ListHooks"); err != nil {
+		return nil, res, err
+	}
 	return s.data.Hooks[fullName], nil, nil
 }
 
 func (s *repositoryService) CreateHook(ctx context.Context, fullName string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
-	/* #nosec */
+	if res, err := s.data.inject(ctx, s.client, "Repositories.CreateHook"); err != nil {
+		return nil, res, err
+	}
+	s.data.HookID++
 	hook := &scm.Hook{
-		ID:     fmt.Sprintf("%d", rand.Int()),
-		Name:   input.Name,
-		Target: input.Target,
-		Active: true,
+		ID:         fmt.Sprintf("%d", s.data.HookID),
+		Name:       input.Name,
+		Target:     input.Target,
+		Events:     append(convertHookEvents(input.Events), input.NativeEvents...),
+		Active:     true,
+		SkipVerify: input.SkipVerify,
 	}
 	s.data.Hooks[fullName] = append(s.data.Hooks[fullName], hook)
+	if input.Secret != "" {
+		s.data.HookSecrets[hook.ID] = input.Secret
+	}
 	return hook, nil, nil
 }
 
 func (s *repositoryService) DeleteHook(ctx context.Context, fullName string, hookID string) (*scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Repositories.DeleteHook"); err != nil {
+		return res, err
+	}
 	hooks := s.data.Hooks[fullName]
 	for i, h := range hooks {
 		if h.ID == hookID {
-			hooks = append(hooks[0:i], hooks[i+1:]...)
-			s.data.Hooks[fullName] = hooks
-			break
+			s.data.Hooks[fullName] = append(hooks[0:i], hooks[i+1:]...)
+			delete(s.data.HookSecrets, hookID)
+			return nil, nil
 		}
 	}
-	return nil, nil
+	return &scm.Response{Status: 404}, scm.ErrNotFound
 }
 
 func (s *repositoryService) CreateStatus(ctx context.Context, repo string, ref string, in *scm.StatusInput) (*scm.Status, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Repositories.CreateStatus"); err != nil {
+		return nil, res, err
+	}
 	statuses := s.data.Statuses[ref]
 	if statuses == nil {
 		statuses = []*scm.Status{}
@@ -218,3 +281,84 @@ func (s *repositoryService) CreateStatus(ctx context.Context, repo string, ref s
 func (s *repositoryService) Delete(context.Context, string) (*scm.Response, error) {
 	panic("implement me")
 }
+
+func (s *repositoryService) GetDependencyGraph(context.Context, string) (*scm.DependencyGraph, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "GetDependencyGraph")
+}
+
+func (s *repositoryService) ExportSBOM(context.Context, string) (io.ReadCloser, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ExportSBOM")
+}
+
+func (s *repositoryService) ListRulesets(context.Context, string, scm.ListOptions) ([]*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListRulesets")
+}
+
+func (s *repositoryService) FindRuleset(context.Context, string, int) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "FindRuleset")
+}
+
+func (s *repositoryService) CreateRuleset(context.Context, string, *scm.RulesetInput) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "CreateRuleset")
+}
+
+func (s *repositoryService) UpdateRuleset(context.Context, string, int, *scm.RulesetInput) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "UpdateRuleset")
+}
+
+func (s *repositoryService) GetPages(context.Context, string) (*scm.Pages, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "GetPages")
+}
+
+func (s *repositoryService) EnablePages(context.Context, string, *scm.PagesInput) (*scm.Pages, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "EnablePages")
+}
+
+func (s *repositoryService) DisablePages(context.Context, string) (*scm.Response, error) {
+	return nil, notSupported("Repositories", "DisablePages")
+}
+
+func (s *repositoryService) GetLatestPagesBuild(context.Context, string) (*scm.PagesBuild, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "GetLatestPagesBuild")
+}
+
+func (s *repositoryService) ListHookDeliveries(context.Context, string, string, scm.ListOptions) ([]*scm.HookDelivery, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListHookDeliveries")
+}
+
+func (s *repositoryService) RedeliverHookDelivery(context.Context, string, string, int64) (*scm.Response, error) {
+	return nil, notSupported("Repositories", "RedeliverHookDelivery")
+}
+
+// convertHookEvents returns the webhook kinds the fake webhook service delivers for the events
+func convertHookEvents(from scm.HookEvents) []string {
+	var events []string
+	if from.Branch {
+		events = append(events, string(scm.WebhookKindBranch))
+	}
+	if from.Issue {
+		events = append(events, string(scm.WebhookKindIssue))
+	}
+	if from.IssueComment {
+		events = append(events, string(scm.WebhookKindIssueComment))
+	}
+	if from.PullRequest {
+		events = append(events, string(scm.WebhookKindPullRequest))
+	}
+	if from.PullRequestComment {
+		events = append(events, string(scm.WebhookKindPullRequestComment))
+	}
+	if from.Push {
+		events = append(events, string(scm.WebhookKindPush))
+	}
+	if from.Review {
+		events = append(events, string(scm.WebhookKindReview))
+	}
+	if from.ReviewComment {
+		events = append(events, string(scm.WebhookKindReviewCommentHook))
+	}
+	if from.Tag {
+		events = append(events, string(scm.WebhookKindTag))
+	}
+	return events
+}
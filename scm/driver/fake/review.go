@@ -12,6 +12,9 @@ type reviewService struct {
 }
 
 func (s *reviewService) Find(ctx context.Context, repo string, number int, reviewID int) (*scm.Review, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Reviews.Find"); err != nil {
+		return nil, res, err
+	}
 	reviews, r, err := s.List(ctx, repo, number, scm.ListOptions{})
 	if err != nil {
 		return nil, r, err
@@ -25,11 +28,17 @@ func (s *reviewService) Find(ctx context.Context, repo string, number int, revie
 }
 
 func (s *reviewService) List(ctx context.Context, repo string, number int, opt scm.ListOptions) ([]*scm.Review, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Reviews.List"); err != nil {
+		return nil, res, err
+	}
 	f := s.data
 	return append([]*scm.Review{}, f.Reviews[number]...), nil, nil
 }
 
 func (s *reviewService) Create(ctx context.Context, repo string, number int, input *scm.ReviewInput) (*scm.Review, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Reviews.Create"); err != nil {
+		return nil, res, err
+	}
 	f := s.data
 	review := &scm.Review{
 		ID:     f.ReviewID,
@@ -46,17 +55,17 @@ func (s *reviewService) Delete(context.Context, string, int, int) (*scm.Response
 }
 
 func (s *reviewService) ListComments(ctx context.Context, repo string, prID int, reviewID int, options scm.ListOptions) ([]*scm.ReviewComment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Reviews", "ListComments")
 }
 
 func (s *reviewService) Update(ctx context.Context, repo string, prID int, reviewID int, body string) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Reviews", "Update")
 }
 
 func (s *reviewService) Submit(ctx context.Context, repo string, prID int, reviewID int, input *scm.ReviewSubmitInput) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Reviews", "Submit")
 }
 
 func (s *reviewService) Dismiss(ctx context.Context, repo string, prID int, reviewID int, msg string) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Reviews", "Dismiss")
 }
@@ -12,22 +12,45 @@ type userService struct {
 }
 
 func (s *userService) CreateToken(context.Context, string, string) (*scm.UserToken, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Users", "CreateToken")
 }
 
 func (s *userService) DeleteToken(context.Context, int64) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Users", "DeleteToken")
+}
+
+func (s *userService) TokenInfo(ctx context.Context) (*scm.TokenInfo, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Users.TokenInfo"); err != nil {
+		return nil, res, err
+	}
+	if s.data.TokenInfo == nil {
+		return nil, nil, scm.ErrNotFound
+	}
+	return s.data.TokenInfo, nil, nil
+}
+
+func (s *userService) ListTokens(context.Context, scm.ListOptions) ([]*scm.UserToken, *scm.Response, error) {
+	return nil, nil, notSupported("Users", "ListTokens")
 }
 
 func (s *userService) Find(ctx context.Context) (*scm.User, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Users.Find"); err != nil {
+		return nil, res, err
+	}
 	return &s.data.CurrentUser, nil, nil
 }
 
 func (s *userService) FindEmail(ctx context.Context) (string, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Users.FindEmail"); err != nil {
+		return "", res, err
+	}
 	return s.data.CurrentUser.Email, nil, nil
 }
 
 func (s *userService) FindLogin(ctx context.Context, login string) (*scm.User, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Users.FindLogin"); err != nil {
+		return nil, res, err
+	}
 	for _, user := range s.data.Users {
 		if user.Login == login {
 			return user, nil, nil
@@ -36,11 +59,17 @@ func (s *userService) FindLogin(ctx context.Context, login string) (*scm.User, *
 	return nil, nil, nil
 }
 
-func (s *userService) ListInvitations(context.Context) ([]*scm.Invitation, *scm.Response, error) {
+func (s *userService) ListInvitations(ctx context.Context) ([]*scm.Invitation, *scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Users.ListInvitations"); err != nil {
+		return nil, res, err
+	}
 	return s.data.Invitations, nil, nil
 }
 
-func (s *userService) AcceptInvitation(_ context.Context, id int64) (*scm.Response, error) {
+func (s *userService) AcceptInvitation(ctx context.Context, id int64) (*scm.Response, error) {
+	if res, err := s.data.inject(ctx, s.client, "Users.AcceptInvitation"); err != nil {
+		return res, err
+	}
 	invitations := s.data.Invitations
 	for i, invite := range invitations {
 		if invite.ID == id {
@@ -52,5 +81,5 @@ func (s *userService) AcceptInvitation(_ context.Context, id int64) (*scm.Respon
 			return nil, nil
 		}
 	}
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Users", "AcceptInvitation")
 }
@@ -0,0 +1,115 @@
+package fake
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/slimm609/go-scm/pkg/hmac"
+	"github.com/slimm609/go-scm/scm"
+)
+
+const (
+	// EventHeader the header holding the scm.WebhookKind of a fake webhook request
+	EventHeader = "X-Fake-Event"
+
+	// SignatureHeader the header holding the sha256 signature of a fake webhook request
+	SignatureHeader = "X-Fake-Signature"
+)
+
+// webhookKinds maps the supported webhook kinds to a constructor of the hook they decode into
+var webhookKinds = map[scm.WebhookKind]func() scm.Webhook{
+	scm.WebhookKindBranch:               func() scm.Webhook { return new(scm.BranchHook) },
+	scm.WebhookKindBranchProtectionRule: func() scm.Webhook { return new(scm.BranchProtectionRuleHook) },
+	scm.WebhookKindCheckRun:             func() scm.Webhook { return new(scm.CheckRunHook) },
+	scm.WebhookKindCheckSuite:           func() scm.Webhook { return new(scm.CheckSuiteHook) },
+	scm.WebhookKindDeploy:               func() scm.Webhook { return new(scm.DeployHook) },
+	scm.WebhookKindDeploymentStatus:     func() scm.Webhook { return new(scm.DeploymentStatusHook) },
+	scm.WebhookKindFork:                 func() scm.Webhook { return new(scm.ForkHook) },
+	scm.WebhookKindIssue:                func() scm.Webhook { return new(scm.IssueHook) },
+	scm.WebhookKindIssueComment:         func() scm.Webhook { return new(scm.IssueCommentHook) },
+	scm.WebhookKindLabel:                func() scm.Webhook { return new(scm.LabelHook) },
+	scm.WebhookKindMilestone:            func() scm.Webhook { return new(scm.MilestoneHook) },
+	scm.WebhookKindPing:                 func() scm.Webhook { return new(scm.PingHook) },
+	scm.WebhookKindPullRequest:          func() scm.Webhook { return new(scm.PullRequestHook) },
+	scm.WebhookKindPullRequestComment:   func() scm.Webhook { return new(scm.PullRequestCommentHook) },
+	scm.WebhookKindPush:                 func() scm.Webhook { return new(scm.PushHook) },
+	scm.WebhookKindRelease:              func() scm.Webhook { return new(scm.ReleaseHook) },
+	scm.WebhookKindRepository:           func() scm.Webhook { return new(scm.RepositoryHook) },
+	scm.WebhookKindReview:               func() scm.Webhook { return new(scm.ReviewHook) },
+	scm.WebhookKindReviewCommentHook:    func() scm.Webhook { return new(scm.ReviewCommentHook) },
+	scm.WebhookKindSecurityAlert:        func() scm.Webhook { return new(scm.SecurityAlertHook) },
+	scm.WebhookKindStar:                 func() scm.Webhook { return new(scm.StarHook) },
+	scm.WebhookKindStatus:               func() scm.Webhook { return new(scm.StatusHook) },
+	scm.WebhookKindTag:                  func() scm.Webhook { return new(scm.TagHook) },
+	scm.WebhookKindWatch:                func() scm.Webhook { return new(scm.WatchHook) },
+}
+
+type webhookService struct {
+	client *wrapper
+	data   *Data
+}
+
+// NewWebhookRequest creates a webhook delivery for the given hook which the fake webhook service
+// parses back into an equal hook. If the secret is not empty the payload is signed with it
+func NewWebhookRequest(hook scm.Webhook, secret string) (*http.Request, error) {
+	data, err := json.Marshal(hook)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal webhook")
+	}
+	req, err := http.NewRequest("POST", "https://fake.com/hook", bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(hook.Kind()))
+	if secret != "" {
+		signature, err := hmac.SignPrefix("sha256", data, []byte(secret))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(SignatureHeader, signature)
+	}
+	return req, nil
+}
+
+func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
+	return s.ParseMulti(req, scm.Secrets(fn))
+}
+
+func (s *webhookService) ParseMulti(req *http.Request, fn scm.MultiSecretFunc) (scm.Webhook, error) {
+	data, err := ioutil.ReadAll(
+		io.LimitReader(req.Body, 10000000),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	event := req.Header.Get(EventHeader)
+	newHook, ok := webhookKinds[scm.WebhookKind(event)]
+	if !ok {
+		return nil, scm.UnknownWebhook{Event: event}
+	}
+	hook := newHook()
+	if err := json.Unmarshal(data, hook); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s webhook", event)
+	}
+
+	// get the signature keys to verify the payload. If no
+	// key is provided, no validation is performed.
+	keys, err := fn(hook)
+	if err != nil {
+		return hook, err
+	} else if len(keys) == 0 {
+		return hook, nil
+	}
+
+	signature := req.Header.Get(SignatureHeader)
+	if !scm.ValidateAny(keys, func(key string) bool { return hmac.ValidatePrefix(data, []byte(key), signature) }) {
+		return hook, scm.ErrSignatureInvalid
+	}
+	return hook, nil
+}
@@ -0,0 +1,45 @@
+//go:build go1.18
+// +build go1.18
+
+package fake
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/internal/hooktest"
+)
+
+// FuzzWebhook parses the corpus of hooktest.Corpus built from
+// the empty hooks of each kind.
+func FuzzWebhook(f *testing.F) {
+	var events []string
+	for kind := range webhookKinds {
+		events = append(events, string(kind))
+	}
+	sort.Strings(events)
+	var payloads [][]byte
+	for _, event := range events {
+		payload, err := json.Marshal(webhookKinds[scm.WebhookKind(event)]())
+		if err != nil {
+			f.Fatal(err)
+		}
+		payloads = append(payloads, payload)
+	}
+	for _, seed := range hooktest.Corpus(payloads, events) {
+		f.Add(seed.Event, seed.ContentType, seed.Mixed, seed.Data)
+	}
+	client, _ := NewDefault()
+	f.Fuzz(func(t *testing.T, event, contentType string, mixed bool, data []byte) {
+		r := hooktest.Request(EventHeader, event, contentType, mixed, data)
+		hook, err := client.Webhooks.Parse(r, func(scm.Webhook) (string, error) {
+			return "", nil
+		})
+		if err == nil && hook != nil {
+			hook.Kind()
+			hook.Repository()
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestHookLifecycle(t *testing.T) {
+	ctx := context.Background()
+	client, data := NewDefault()
+
+	hook, _, err := client.Repositories.CreateHook(ctx, "foo/repo", &scm.HookInput{
+		Name:   "test",
+		Target: "https://example.com",
+		Secret: "topsecret",
+		Events: scm.HookEvents{Push: true, PullRequest: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"pull_request", "push"}, hook.Events); diff != "" {
+		t.Errorf("unexpected hook events\n%s", diff)
+	}
+	if got := data.HookSecrets[hook.ID]; got != "topsecret" {
+		t.Errorf("want hook secret recorded, got %q", got)
+	}
+
+	found, _, err := client.Repositories.FindHook(ctx, "foo/repo", hook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found != hook {
+		t.Errorf("want the created hook, got %+v", found)
+	}
+
+	if _, err := client.Repositories.DeleteHook(ctx, "foo/repo", hook.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Repositories.FindHook(ctx, "foo/repo", hook.ID); err != scm.ErrNotFound {
+		t.Errorf("want error %v, got %v", scm.ErrNotFound, err)
+	}
+	if _, err := client.Repositories.DeleteHook(ctx, "foo/repo", hook.ID); err != scm.ErrNotFound {
+		t.Errorf("want error %v, got %v", scm.ErrNotFound, err)
+	}
+	if _, ok := data.HookSecrets[hook.ID]; ok {
+		t.Errorf("want hook secret removed")
+	}
+}
+
+func TestWebhookParse(t *testing.T) {
+	client, _ := NewDefault()
+
+	want := &scm.PullRequestHook{
+		Action: scm.ActionOpen,
+		Repo: scm.Repository{
+			Namespace: "foo",
+			Name:      "repo",
+			FullName:  "foo/repo",
+		},
+		PullRequest: scm.PullRequest{
+			Number: 1,
+			Title:  "Update README",
+		},
+	}
+
+	req, err := NewWebhookRequest(want, "topsecret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := client.Webhooks.Parse(req, func(scm.Webhook) (string, error) { return "topsecret", nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected webhook\n%s", diff)
+	}
+
+	req, _ = NewWebhookRequest(want, "topsecret")
+	if _, err := client.Webhooks.Parse(req, func(scm.Webhook) (string, error) { return "wrong", nil }); err != scm.ErrSignatureInvalid {
+		t.Errorf("want error %v, got %v", scm.ErrSignatureInvalid, err)
+	}
+
+	req, _ = NewWebhookRequest(want, "")
+	req.Header.Set(EventHeader, "unknown")
+	if _, err := client.Webhooks.Parse(req, func(scm.Webhook) (string, error) { return "", nil }); !scm.IsUnknownWebhook(err) {
+		t.Errorf("want unknown webhook error, got %v", err)
+	}
+}
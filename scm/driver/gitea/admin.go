@@ -0,0 +1,54 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/slimm609/go-scm/scm"
+)
+
+type adminService struct {
+	client *wrapper
+}
+
+// Stats returns the statistics of the server. Gitea has no
+// statistics endpoint, so the counts are read from the total
+// count header of the admin list endpoints.
+func (s *adminService) Stats(ctx context.Context) (*scm.SiteStats, *scm.Response, error) {
+	out := new(scm.SiteStats)
+	counts := []struct {
+		path  string
+		count *int
+	}{
+		{"api/v1/admin/users?limit=1", &out.Users},
+		{"api/v1/admin/orgs?limit=1", &out.Organizations},
+		{"api/v1/repos/search?limit=1", &out.Repositories},
+	}
+	var res *scm.Response
+	var err error
+	for _, c := range counts {
+		res, err = s.client.do(ctx, "GET", c.path, nil, nil)
+		if err != nil {
+			return nil, res, err
+		}
+		*c.count, _ = strconv.Atoi(res.Header.Get("X-Total-Count"))
+	}
+	return out, res, nil
+}
+
+func (s *adminService) License(ctx context.Context) (*scm.License, *scm.Response, error) {
+	return nil, nil, notSupported("Admin", "License")
+}
+
+func (s *adminService) ListHooks(ctx context.Context, opts scm.ListOptions) ([]*scm.Hook, *scm.Response, error) {
+	path := fmt.Sprintf("api/v1/admin/hooks?%s", encodeListOptions(opts))
+	out := []*gitea.Hook{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertHookList(out), res, err
+}
@@ -0,0 +1,52 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitea
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestAdminStats(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	for path, count := range map[string]string{
+		"/api/v1/admin/users":  "1050",
+		"/api/v1/admin/orgs":   "32",
+		"/api/v1/repos/search": "23",
+	} {
+		gock.New("https://try.gitea.io").
+			Get(path).
+			MatchParam("limit", "1").
+			MatchHeader("Authorization", "token secret").
+			Reply(200).
+			Type("application/json").
+			SetHeader("X-Total-Count", count).
+			BodyString("[]")
+	}
+
+	client, _ := NewWithToken("https://try.gitea.io", "secret")
+	got, _, err := client.Admin.Stats(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := &scm.SiteStats{
+		Users:         1050,
+		Organizations: 32,
+		Repositories:  23,
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
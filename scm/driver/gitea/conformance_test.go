@@ -0,0 +1,89 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitea
+
+import (
+	"os"
+	"testing"
+
+	"github.com/slimm609/go-scm/scm/conformance"
+
+	"github.com/h2non/gock"
+)
+
+func TestConformance(t *testing.T) {
+	if repo, branch, ok := conformance.FromEnvironment(); ok {
+		client, err := NewWithToken(os.Getenv(conformance.EnvURL), os.Getenv(conformance.EnvToken))
+		if err != nil {
+			t.Fatal(err)
+		}
+		conformance.Run(t, conformance.Config{
+			Client: client,
+			Repo:   repo,
+			Branch: branch,
+		})
+		return
+	}
+
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea-does-not-exist$").
+		Persist().
+		Reply(404).
+		Type("application/json").
+		BodyString(`{"message":"The target couldn't be found."}`)
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/repo.json")
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/branches/master$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/branch.json")
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/branches$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/branches.json")
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/tags$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/tags.json")
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/git/commits/master$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/commit.json")
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/git/refs/heads/master$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/refs.json")
+
+	client, _ := New("https://try.gitea.io")
+	conformance.Run(t, conformance.Config{
+		Client: client,
+		Repo:   "go-gitea/gitea",
+		Branch: "master",
+	})
+}
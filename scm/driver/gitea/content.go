@@ -6,6 +6,7 @@ package gitea
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/slimm609/go-scm/scm"
@@ -21,25 +22,90 @@ func (s *contentService) Find(ctx context.Context, repo, path, ref string) (*scm
 	ref = strings.TrimPrefix(ref, "refs/heads/")
 	ref = strings.TrimPrefix(ref, "refs/tags/")
 
-	out, resp, err := s.client.GiteaClient.GetFile(namespace, name, ref, path)
+	out, resp, err := s.client.sdk(ctx).GetFile(namespace, name, ref, path)
 	return &scm.Content{
 		Path: path,
 		Data: out,
 	}, toSCMResponse(resp), err
 }
 
+func (s *contentService) FindMany(ctx context.Context, repo, ref string, paths []string) (map[string]*scm.Content, map[string]error) {
+	return scm.FindManyContents(ctx, s, repo, ref, paths, scm.DefaultConcurrency)
+}
+
 func (s *contentService) List(ctx context.Context, repo, path, ref string) ([]*scm.FileEntry, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	endpoint := fmt.Sprintf("api/v1/repos/%s/contents/%s?ref=%s", repo, path, ref)
+	out := []*entry{}
+	res, err := s.client.do(ctx, "GET", endpoint, nil, &out)
+	return convertEntryList(out), res, err
+}
+
+func (s *contentService) Exists(ctx context.Context, repo, path, ref string) (bool, *scm.Response, error) {
+	endpoint := fmt.Sprintf("api/v1/repos/%s/contents/%s?ref=%s", repo, path, ref)
+	res, err := s.client.do(ctx, "HEAD", endpoint, nil, nil)
+	if res != nil && res.Status == 404 {
+		return false, res, nil
+	}
+	return err == nil, res, err
+}
+
+// Stat returns the file metadata from the listing of the
+// parent directory, since the contents endpoint embeds the
+// encoded content of a file.
+func (s *contentService) Stat(ctx context.Context, repo, path, ref string) (*scm.FileEntry, *scm.Response, error) {
+	path = strings.Trim(path, "/")
+	dir := ""
+	if i := strings.LastIndex(path, "/"); i != -1 {
+		dir = path[:i]
+	}
+	entries, res, err := s.List(ctx, repo, dir, ref)
+	if err != nil {
+		return nil, res, err
+	}
+	for _, entry := range entries {
+		if entry.Path == path {
+			return entry, res, nil
+		}
+	}
+	return nil, res, scm.ErrNotFound
 }
 
 func (s *contentService) Create(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Contents", "Create")
 }
 
 func (s *contentService) Update(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Contents", "Update")
 }
 
 func (s *contentService) Delete(ctx context.Context, repo, path, ref string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Contents", "Delete")
+}
+
+type entry struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Path string `json:"path"`
+	Size int    `json:"size"`
+	Sha  string `json:"sha"`
+	URL  string `json:"url"`
+}
+
+func convertEntryList(out []*entry) []*scm.FileEntry {
+	answer := make([]*scm.FileEntry, 0, len(out))
+	for _, o := range out {
+		answer = append(answer, convertEntry(o))
+	}
+	return answer
+}
+
+func convertEntry(from *entry) *scm.FileEntry {
+	return &scm.FileEntry{
+		Name: from.Name,
+		Path: from.Path,
+		Type: from.Type,
+		Size: from.Size,
+		Sha:  from.Sha,
+		Link: from.URL,
+	}
 }
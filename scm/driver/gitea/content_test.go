@@ -6,8 +6,12 @@ package gitea
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/h2non/gock"
 	"github.com/slimm609/go-scm/scm"
 )
@@ -41,26 +45,136 @@ func TestContentFind(t *testing.T) {
 	}
 }
 
+func TestContentList(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/contents/").
+		MatchParam("ref", "master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/content_list.json")
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.Contents.List(
+		context.Background(),
+		"go-gitea/gitea",
+		"",
+		"master",
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.FileEntry{}
+	raw, _ := ioutil.ReadFile("testdata/content_list.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestContentStat(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+	file := gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/contents/README.md")
+	file.Reply(500)
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/contents/").
+		MatchParam("ref", "master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/content_list.json")
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.Contents.Stat(
+		context.Background(),
+		"go-gitea/gitea",
+		"README.md",
+		"master",
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := got.Type, "file"; got != want {
+		t.Errorf("Want file Type %q, got %q", want, got)
+	}
+	if got, want := got.Size, 12; got != want {
+		t.Errorf("Want file Size %d, got %d", want, got)
+	}
+	if got, want := got.Sha, "4a1a5f1d5fb1a1a8c8b4c62e4a1f0a5f4c2e6f3a"; got != want {
+		t.Errorf("Want file Sha %q, got %q", want, got)
+	}
+	if file.Mock.Done() {
+		t.Errorf("Want the file metadata without requesting its contents")
+	}
+}
+
+func TestContentExists(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+	gock.New("https://try.gitea.io").
+		Head("/api/v1/repos/go-gitea/gitea/contents/MISSING.md").
+		MatchParam("ref", "master").
+		Reply(404)
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.Contents.Exists(
+		context.Background(),
+		"go-gitea/gitea",
+		"MISSING.md",
+		"master",
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	if got {
+		t.Errorf("Expect file does not exist")
+	}
+}
+
 func TestContentCreate(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
 	client, _ := New("https://try.gitea.io")
 	_, err := client.Contents.Create(context.Background(), "go-gitea/gitea", "README.md", nil)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
 
 func TestContentUpdate(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
 	client, _ := New("https://try.gitea.io")
 	_, err := client.Contents.Update(context.Background(), "go-gitea/gitea", "README.md", nil)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
 
 func TestContentDelete(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
 	client, _ := New("https://try.gitea.io")
 	_, err := client.Contents.Delete(context.Background(), "go-gitea/gitea", "README.md", "master")
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
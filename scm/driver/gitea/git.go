@@ -23,29 +23,70 @@ type gitService struct {
 func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 
-	out, giteaResp, err := s.client.GiteaClient.GetRepoRefs(namespace, name, ref)
-	resp := toSCMResponse(giteaResp)
-	if err != nil {
-		return "", resp, err
-	}
-	for _, r := range out {
-		if r.Object != nil {
-			return r.Object.SHA, resp, nil
+	var resp *scm.Response
+	for _, qualified := range scm.QualifyRefs(ref) {
+		// the refs matching the path as a prefix are returned.
+		out, giteaResp, err := s.client.sdk(ctx).GetRepoRefs(namespace, name, strings.TrimPrefix(qualified, "refs/"))
+		resp = toSCMResponse(giteaResp)
+		if err = toSCMError(giteaResp, err); err == scm.ErrNotFound {
+			continue
+		} else if err != nil {
+			return "", resp, err
+		}
+		for _, r := range out {
+			if r.Ref == qualified && r.Object != nil {
+				return r.Object.SHA, resp, nil
+			}
 		}
 	}
-	return "", resp, fmt.Errorf("no match found for ref %s", ref)
+	return "", resp, scm.ErrNotFound
+}
+
+func (s *gitService) ResolveRefs(ctx context.Context, repo string, refs []string) (map[string]string, map[string]error) {
+	return scm.ResolveRefs(ctx, s, repo, refs, scm.DefaultConcurrency)
 }
 
+// CreateRef creates a branch or a tag pointing at the sha. A
+// branch is created from a sha since Gitea 1.21, older versions
+// ignore the sha and branch from the default branch.
 func (s *gitService) CreateRef(ctx context.Context, repo, ref, sha string) (*scm.Reference, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	switch ref = scm.QualifyRef(ref); {
+	case strings.HasPrefix(ref, "refs/heads/"):
+		path := fmt.Sprintf("api/v1/repos/%s/branches", repo)
+		in := &branchInput{
+			Name:       scm.TrimRef(ref),
+			OldRefName: sha,
+		}
+		out := new(gitea.Branch)
+		res, err := s.client.do(ctx, "POST", path, in, out)
+		return convertBranch(out), res, err
+	case strings.HasPrefix(ref, "refs/tags/"):
+		path := fmt.Sprintf("api/v1/repos/%s/tags", repo)
+		in := &tagInput{
+			Name:   scm.TrimRef(ref),
+			Target: sha,
+		}
+		out := new(gitea.Tag)
+		res, err := s.client.do(ctx, "POST", path, in, out)
+		return convertTag(out), res, err
+	}
+	return nil, nil, notSupported("Git", "CreateRef")
+}
+
+// UpdateRef is not supported: the Gitea API can rename a
+// branch but not move it to another commit.
+func (s *gitService) UpdateRef(ctx context.Context, repo, ref, sha string, force bool) (*scm.Reference, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "UpdateRef")
 }
 
 func (s *gitService) DeleteRef(ctx context.Context, repo, ref string) (*scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	if strings.HasPrefix(ref, "heads/") {
-		ref = strings.TrimPrefix(ref, "heads/")
+	ref = scm.QualifyRef(ref)
+	if !strings.HasPrefix(ref, "refs/heads/") {
+		return nil, notSupported("Git", "DeleteRef")
 	}
-	out, giteaResp, err := s.client.GiteaClient.DeleteRepoBranch(namespace, name, ref)
+	ref = scm.TrimRef(ref)
+	out, giteaResp, err := s.client.sdk(ctx).DeleteRepoBranch(namespace, name, ref)
 	resp := toSCMResponse(giteaResp)
 	if !out {
 		return resp, errors.New("Failed to delete branch")
@@ -55,23 +96,23 @@ func (s *gitService) DeleteRef(ctx context.Context, repo, ref string) (*scm.Resp
 
 func (s *gitService) FindBranch(ctx context.Context, repo, branchName string) (*scm.Reference, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	out, resp, err := s.client.GiteaClient.GetRepoBranch(namespace, name, branchName)
-	return convertBranch(out), toSCMResponse(resp), err
+	out, resp, err := s.client.sdk(ctx).GetRepoBranch(namespace, name, branchName)
+	return convertBranch(out), toSCMResponse(resp), toSCMError(resp, err)
 }
 
 func (s *gitService) FindCommit(ctx context.Context, repo, ref string) (*scm.Commit, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	out, resp, err := s.client.GiteaClient.GetSingleCommit(namespace, name, ref)
-	return convertCommit(out), toSCMResponse(resp), err
+	out, resp, err := s.client.sdk(ctx).GetSingleCommit(namespace, name, ref)
+	return convertCommit(out), toSCMResponse(resp), toSCMError(resp, err)
 }
 
 func (s *gitService) FindTag(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Git", "FindTag")
 }
 
 func (s *gitService) ListBranches(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	out, resp, err := s.client.GiteaClient.ListRepoBranches(namespace, name, gitea.ListRepoBranchesOptions{ListOptions: toGiteaListOptions(opts)})
+	out, resp, err := s.client.sdk(ctx).ListRepoBranches(namespace, name, gitea.ListRepoBranchesOptions{ListOptions: toGiteaListOptions(opts)})
 	return convertBranchList(out), toSCMResponse(resp), err
 }
 
@@ -85,25 +126,120 @@ func (s *gitService) ListCommits(ctx context.Context, repo string, opts scm.Comm
 		},
 		SHA: opts.Sha,
 	}
-	out, resp, err := s.client.GiteaClient.ListRepoCommits(namespace, name, listOpts)
+	out, resp, err := s.client.sdk(ctx).ListRepoCommits(namespace, name, listOpts)
 	return convertCommitList(out), toSCMResponse(resp), err
 }
 
 func (s *gitService) ListTags(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 
-	out, resp, err := s.client.GiteaClient.ListRepoTags(namespace, name, gitea.ListRepoTagsOptions{ListOptions: toGiteaListOptions(opts)})
+	out, resp, err := s.client.sdk(ctx).ListRepoTags(namespace, name, gitea.ListRepoTagsOptions{ListOptions: toGiteaListOptions(opts)})
 	return convertTagList(out), toSCMResponse(resp), err
 }
 
 func (s *gitService) ListChanges(ctx context.Context, repo, ref string, _ scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Git", "ListChanges")
+}
+
+// CompareCommits compares the base ref with the head ref of the
+// repository. Gitea does not report the changed files or the
+// merge base.
+func (s *gitService) CompareCommits(ctx context.Context, repo, base, head string) (*scm.Comparison, *scm.Response, error) {
+	return s.compare(ctx, repo, base, repo, head)
+}
+
+// CompareAcrossForks compares the base ref with the head ref of
+// the fork owned by headOwner. Gitea does not report the changed
+// files or the merge base.
+func (s *gitService) CompareAcrossForks(ctx context.Context, baseRepo, baseRef, headOwner, headRef string) (*scm.Comparison, *scm.Response, error) {
+	r, err := scm.ParseRepo(baseRepo)
+	if err != nil {
+		return nil, nil, err
+	}
+	headRepo := baseRepo
+	if !strings.EqualFold(r.Namespace, headOwner) {
+		fork, res, err := s.findFork(ctx, baseRepo, headOwner)
+		if err != nil {
+			return nil, res, err
+		}
+		headRepo = fork.FullName
+	}
+	return s.compare(ctx, baseRepo, baseRef, headRepo, headRef)
+}
+
+// compare compares the base ref of the base repository with the
+// head ref of the head repository. Gitea only counts the commits
+// of the head missing from the base, so the refs are also
+// compared the other way round to find how far the head is
+// behind.
+func (s *gitService) compare(ctx context.Context, baseRepo, baseRef, headRepo, headRef string) (*scm.Comparison, *scm.Response, error) {
+	ahead := new(comparison)
+	res, err := s.client.do(ctx, "GET", comparePath(baseRepo, baseRef, headRepo, headRef), nil, ahead)
+	if err != nil {
+		return nil, res, err
+	}
+	behind := new(comparison)
+	res, err = s.client.do(ctx, "GET", comparePath(headRepo, headRef, baseRepo, baseRef), nil, behind)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertComparison(ahead, behind.TotalCommits), res, nil
+}
+
+// findFork returns the fork of the repository owned by the
+// owner. The name of the fork may differ from the name of the
+// repository.
+func (s *gitService) findFork(ctx context.Context, repo, owner string) (*gitea.Repository, *scm.Response, error) {
+	opts := scm.ListOptions{Page: 1, Size: 50}
+	for {
+		path := fmt.Sprintf("api/v1/repos/%s/forks?%s", repo, encodeListOptions(opts))
+		out := []*gitea.Repository{}
+		res, err := s.client.do(ctx, "GET", path, nil, &out)
+		if err != nil {
+			return nil, res, err
+		}
+		for _, fork := range out {
+			if fork.Owner != nil && strings.EqualFold(fork.Owner.UserName, owner) {
+				return fork, res, nil
+			}
+		}
+		if res.Page.Next == 0 {
+			return nil, res, scm.ErrNotFound
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
+// comparePath returns the path of the request listing the
+// commits of the head ref of the head repository missing from
+// the base ref of the base repository.
+func comparePath(baseRepo, baseRef, headRepo, headRef string) string {
+	if headRepo != baseRepo {
+		owner, _ := scm.Split(headRepo)
+		headRef = owner + ":" + headRef
+	}
+	return fmt.Sprintf("api/v1/repos/%s/compare/%s...%s", baseRepo, baseRef, headRef)
 }
 
 //
 // native data structures
 //
 
+type branchInput struct {
+	Name       string `json:"new_branch_name"`
+	OldRefName string `json:"old_ref_name"`
+}
+
+type tagInput struct {
+	Name   string `json:"tag_name"`
+	Target string `json:"target"`
+}
+
+type comparison struct {
+	TotalCommits int             `json:"total_commits"`
+	Commits      []*gitea.Commit `json:"commits"`
+}
+
 type (
 	// gitea commit object.
 	commit struct {
@@ -174,6 +310,25 @@ func convertCommitList(src []*gitea.Commit) []*scm.Commit {
 	return dst
 }
 
+func convertComparison(src *comparison, behind int) *scm.Comparison {
+	dst := &scm.Comparison{
+		AheadBy:  src.TotalCommits,
+		BehindBy: behind,
+		Commits:  convertCommitList(src.Commits),
+	}
+	switch {
+	case dst.AheadBy == 0 && dst.BehindBy == 0:
+		dst.Status = "identical"
+	case dst.BehindBy == 0:
+		dst.Status = "ahead"
+	case dst.AheadBy == 0:
+		dst.Status = "behind"
+	default:
+		dst.Status = "diverged"
+	}
+	return dst
+}
+
 func convertCommit(src *gitea.Commit) *scm.Commit {
 	if src == nil || src.RepoCommit == nil {
 		return nil
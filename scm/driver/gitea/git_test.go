@@ -7,6 +7,7 @@ package gitea
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"testing"
 
@@ -80,10 +81,89 @@ func TestCommitList(t *testing.T) {
 	}
 }
 
+func TestCompareCommits(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/compare/master...topic").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare.json")
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/compare/topic...master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare_empty.json")
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.Git.CompareCommits(context.Background(), "go-gitea/gitea", "master", "topic")
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(scm.Comparison)
+	raw, _ := ioutil.ReadFile("testdata/compare.json.golden")
+	err = json.Unmarshal(raw, want)
+	assert.NoError(t, err)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestCompareAcrossForks(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/forks").
+		MatchParam("page", "1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/forks.json")
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/compare/master...fork-owner:topic").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare.json")
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/fork-owner/gitea-fork/compare/topic...go-gitea:master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare.json")
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.Git.CompareAcrossForks(context.Background(), "go-gitea/gitea", "master", "fork-owner", "topic")
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(scm.Comparison)
+	raw, _ := ioutil.ReadFile("testdata/compare_forks.json.golden")
+	err = json.Unmarshal(raw, want)
+	assert.NoError(t, err)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
 func TestChangeList(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
 	client, _ := New("https://try.gitea.io")
 	_, _, err := client.Git.ListChanges(context.Background(), "go-gitea/gitea", "f05f642b892d59a0a9ef6a31f6c905a24b5db13a", scm.ListOptions{})
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -119,6 +199,68 @@ func TestBranchFind(t *testing.T) {
 	}
 }
 
+func TestBranchCreate(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Post("/api/v1/repos/go-gitea/gitea/branches").
+		JSON(map[string]string{
+			"new_branch_name": "master",
+			"old_ref_name":    "f05f642b892d59a0a9ef6a31f6c905a24b5db13a",
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/branch.json")
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.Git.CreateRef(context.Background(), "go-gitea/gitea", "master", "f05f642b892d59a0a9ef6a31f6c905a24b5db13a")
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(scm.Reference)
+	raw, _ := ioutil.ReadFile("testdata/branch.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestTagCreate(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Post("/api/v1/repos/go-gitea/gitea/tags").
+		JSON(map[string]string{
+			"tag_name": "some-tag",
+			"target":   "c43399cad8766ee521b873a32c1652407c5a4630",
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/tag.json")
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.Git.CreateRef(context.Background(), "go-gitea/gitea", "refs/tags/some-tag", "c43399cad8766ee521b873a32c1652407c5a4630")
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(scm.Reference)
+	raw, _ := ioutil.ReadFile("testdata/tag.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
 func TestBranchList(t *testing.T) {
 	defer gock.Off()
 
@@ -154,9 +296,13 @@ func TestBranchList(t *testing.T) {
 //
 
 func TestTagFind(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
 	client, _ := New("https://try.gitea.io")
 	_, _, err := client.Git.FindTag(context.Background(), "go-gitea/gitea", "v1.0.0")
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
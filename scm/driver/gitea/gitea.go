@@ -11,17 +11,26 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/transport"
+	"github.com/slimm609/go-scm/scm/transport/oauth2"
 )
 
 // NewWebHookService creates a new instance of the webhook service without the rest of the client
-func NewWebHookService() scm.WebhookService {
-	return &webhookService{nil}
+func NewWebHookService(opts ...scm.WebhookServiceOptions) scm.WebhookService {
+	s := &webhookService{}
+	if len(opts) > 0 {
+		s.opts = opts[0]
+	}
+	return s
 }
 
 // New returns a new Gitea API client without a token set
@@ -39,14 +48,20 @@ func NewWithToken(uri string, token string) (*scm.Client, error) {
 		base.Path = base.Path + "/"
 	}
 	client := &wrapper{Client: new(scm.Client)}
-	client.GiteaClient, err = gitea.NewClient(base.String(), gitea.SetToken(token))
-
-	if err != nil {
-		return nil, err
+	// the requests not sent through the sdk are authorized
+	// by the http client.
+	if token != "" {
+		client.Client.Client = &http.Client{
+			Transport: &transport.Authorization{Scheme: "token", Credentials: token},
+		}
 	}
 	client.BaseURL = base
+	if err := client.connect(gitea.SetToken(token)); err != nil {
+		return nil, err
+	}
 	// initialize services
 	client.Driver = scm.DriverGitea
+	client.SetCaller(client.do)
 	client.Contents = &contentService{client}
 	client.Git = &gitService{client}
 	client.Issues = &issueService{client}
@@ -55,8 +70,11 @@ func NewWithToken(uri string, token string) (*scm.Client, error) {
 	client.PullRequests = &pullService{&issueService{client}}
 	client.Repositories = &repositoryService{client}
 	client.Reviews = &reviewService{client}
+	client.Runners = &runnerService{client}
 	client.Users = &userService{client}
-	client.Webhooks = &webhookService{client}
+	client.Variables = &variableService{client}
+	client.Webhooks = &webhookService{client: client}
+	client.Admin = &adminService{client}
 	return client.Client, nil
 }
 
@@ -70,14 +88,55 @@ func NewWithBasicAuth(uri string, user, password string) (*scm.Client, error) {
 		base.Path = base.Path + "/"
 	}
 	client := &wrapper{Client: new(scm.Client)}
-	client.GiteaClient, err = gitea.NewClient(base.String(), gitea.SetBasicAuth(user, password))
+	// the requests not sent through the sdk are authorized
+	// by the http client.
+	client.Client.Client = &http.Client{
+		Transport: &transport.BasicAuth{Username: user, Password: password},
+	}
+	client.BaseURL = base
+	if err := client.connect(gitea.SetBasicAuth(user, password)); err != nil {
+		return nil, err
+	}
+	// initialize services
+	client.Driver = scm.DriverGitea
+	client.SetCaller(client.do)
+	client.Contents = &contentService{client}
+	client.Git = &gitService{client}
+	client.Issues = &issueService{client}
+	client.Milestones = &milestoneService{client}
+	client.Organizations = &organizationService{client}
+	client.PullRequests = &pullService{&issueService{client}}
+	client.Repositories = &repositoryService{client}
+	client.Reviews = &reviewService{client}
+	client.Runners = &runnerService{client}
+	client.Users = &userService{client}
+	client.Variables = &variableService{client}
+	client.Webhooks = &webhookService{client: client}
+	client.Admin = &adminService{client}
+	return client.Client, nil
+}
 
+// NewWithTokenSource returns a new Gitea API client reading the
+// token of every request from the source, so a rotated token is
+// used without recreating the client.
+func NewWithTokenSource(uri string, source scm.TokenSource) (*scm.Client, error) {
+	base, err := url.Parse(uri)
 	if err != nil {
 		return nil, err
 	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path = base.Path + "/"
+	}
+	auth := &oauth2.Transport{Scheme: oauth2.SchemeToken, Source: source}
+	client := &wrapper{Client: new(scm.Client)}
+	client.Client.Client = &http.Client{Transport: auth}
 	client.BaseURL = base
+	if err := client.connect(); err != nil {
+		return nil, err
+	}
 	// initialize services
 	client.Driver = scm.DriverGitea
+	client.SetCaller(client.do)
 	client.Contents = &contentService{client}
 	client.Git = &gitService{client}
 	client.Issues = &issueService{client}
@@ -86,8 +145,11 @@ func NewWithBasicAuth(uri string, user, password string) (*scm.Client, error) {
 	client.PullRequests = &pullService{&issueService{client}}
 	client.Repositories = &repositoryService{client}
 	client.Reviews = &reviewService{client}
+	client.Runners = &runnerService{client}
 	client.Users = &userService{client}
-	client.Webhooks = &webhookService{client}
+	client.Variables = &variableService{client}
+	client.Webhooks = &webhookService{client: client}
+	client.Admin = &adminService{client}
 	return client.Client, nil
 }
 
@@ -96,6 +158,102 @@ func NewWithBasicAuth(uri string, user, password string) (*scm.Client, error) {
 type wrapper struct {
 	*scm.Client
 	GiteaClient *gitea.Client
+
+	// options are the options the SDK clients are created with.
+	options []func(*gitea.Client)
+	// version is the body of the response to the version check
+	// of the SDK, replayed to the SDK clients created later.
+	version []byte
+}
+
+// connect creates the SDK client with the options.
+func (c *wrapper) connect(options ...func(*gitea.Client)) error {
+	c.options = options
+	client, err := c.newSDK(context.Background())
+	if err != nil {
+		return err
+	}
+	c.GiteaClient = client
+	return nil
+}
+
+func (c *wrapper) newSDK(ctx context.Context) (*gitea.Client, error) {
+	options := append([]func(*gitea.Client){}, c.options...)
+	options = append(options,
+		gitea.SetHTTPClient(&http.Client{Transport: c.DryRunTransport(&sdkTransport{client: c})}),
+		gitea.SetContext(ctx),
+	)
+	return gitea.NewClient(c.BaseURL.String(), options...)
+}
+
+// sdk returns the SDK client of the requests made with the
+// context. The SDK client sends its requests with the context
+// it is bound to, so an SDK client bound to the call context is
+// created for every call.
+func (c *wrapper) sdk(ctx context.Context) *gitea.Client {
+	// the version check is answered with the recorded version,
+	// so creating the client does not fail.
+	client, err := c.newSDK(ctx)
+	if err != nil {
+		return c.GiteaClient
+	}
+	return client
+}
+
+// sdkTransport sends the SDK requests with Client.Do and the
+// context of the request, which is the call context, so the
+// cancellation, retry policy, limits and Sudo user of the call
+// and the http client of the client apply to the SDK requests
+// too.
+type sdkTransport struct {
+	client *wrapper
+}
+
+func (t *sdkTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+	version := strings.HasSuffix(r.URL.Path, "/api/v1/version")
+	if version && t.client.version != nil {
+		return &http.Response{
+			Status:     http.StatusText(http.StatusOK),
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       ioutil.NopCloser(bytes.NewReader(t.client.version)),
+			Request:    r,
+		}, nil
+	}
+	in := &scm.Request{
+		Method: r.Method,
+		Path:   strings.TrimPrefix(r.URL.RequestURI(), t.client.BaseURL.Path),
+		Header: r.Header,
+	}
+	if r.Body != nil {
+		in.Body = r.Body
+	}
+	res, err := t.client.Client.Do(r.Context(), in)
+	if err != nil {
+		return nil, err
+	}
+	out := &http.Response{
+		Status:     http.StatusText(res.Status),
+		StatusCode: res.Status,
+		Header:     res.Header,
+		Body:       res.Body,
+		Request:    r,
+	}
+	if !version || res.Status != http.StatusOK {
+		return out, nil
+	}
+	// record the version checked when the client is created.
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	t.client.version = body
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return out, nil
 }
 
 // do wraps the Client.Do function by creating the Request and
@@ -125,7 +283,9 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 
 	// if an error is encountered, unmarshal and return the
 	// error response.
-	if res.Status > 300 {
+	if res.Status == http.StatusNotFound {
+		return res, scm.ErrNotFound
+	} else if res.Status > 300 {
 		return res, errors.New(
 			http.StatusText(res.Status),
 		)
@@ -138,8 +298,8 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	// if raw output is expected, copy to the provided
 	// buffer and exit.
 	if w, ok := out.(io.Writer); ok {
-		io.Copy(w, res.Body)
-		return res, nil
+		_, err := io.Copy(w, res.Body)
+		return res, err
 	}
 
 	// if a json response is expected, parse and return
@@ -154,17 +314,77 @@ func toSCMResponse(r *gitea.Response) *scm.Response {
 		return nil
 	}
 	res := &scm.Response{
-		Status: r.StatusCode,
-		Header: r.Header,
-		Body:   r.Body,
+		Status:    r.StatusCode,
+		Header:    r.Header,
+		Body:      r.Body,
+		RequestID: scm.RequestIDOf(r.Header),
 	}
+	res.ID = res.RequestID
+	// parse the rate limit details, sent by the proxies
+	// limiting the requests.
+	res.Rate.Limit, _ = strconv.Atoi(
+		r.Header.Get("X-RateLimit-Limit"),
+	)
+	res.Rate.Remaining, _ = strconv.Atoi(
+		r.Header.Get("X-RateLimit-Remaining"),
+	)
+	res.Rate.Reset, _ = strconv.ParseInt(
+		r.Header.Get("X-RateLimit-Reset"), 10, 64,
+	)
 	res.PopulatePageValues()
 	return res
 }
 
+// toSCMError returns scm.ErrNotFound for the SDK errors of
+// the requests for a missing resource, and err otherwise. The
+// SDK returns no response with its errors, so the 404 error it
+// creates is matched by its message.
+func toSCMError(resp *gitea.Response, err error) error {
+	if err == nil {
+		return nil
+	}
+	if resp != nil && resp.StatusCode == http.StatusNotFound || err.Error() == errNotFound {
+		return scm.ErrNotFound
+	}
+	return err
+}
+
+// errNotFound is the message of the error the SDK returns for
+// the requests for a missing resource.
+const errNotFound = "404 Not Found"
+
+// toTime returns the optional time in UTC, or the zero time
+// if it is not set.
+func toTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.UTC()
+}
+
 func toGiteaListOptions(in scm.ListOptions) gitea.ListOptions {
 	return gitea.ListOptions{
 		Page:     in.Page,
 		PageSize: in.Size,
 	}
 }
+
+// encodeListOptions encodes the list options as the query
+// parameters of the API, for the requests not sent through
+// the SDK.
+func encodeListOptions(opts scm.ListOptions) string {
+	params := url.Values{}
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Size != 0 {
+		params.Set("limit", strconv.Itoa(opts.Size))
+	}
+	return params.Encode()
+}
+
+// notSupported returns the error of a method of a service
+// the driver does not support.
+func notSupported(service, method string) error {
+	return &scm.NotSupportedError{Driver: scm.DriverGitea, Service: service, Method: method}
+}
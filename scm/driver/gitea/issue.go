@@ -20,7 +20,7 @@ type issueService struct {
 
 func (s *issueService) Search(context.Context, scm.SearchOptions) ([]*scm.SearchIssue, *scm.Response, error) {
 	// TODO implemment
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Issues", "Search")
 }
 
 func (s *issueService) AssignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
@@ -41,7 +41,7 @@ func (s *issueService) AssignIssue(ctx context.Context, repo string, number int,
 		Title:     issue.Title,
 		Assignees: assignees.List(),
 	}
-	_, giteaResp, err := s.client.GiteaClient.EditIssue(namespace, name, int64(number), in)
+	_, giteaResp, err := s.client.sdk(ctx).EditIssue(namespace, name, int64(number), in)
 	return toSCMResponse(giteaResp), err
 }
 
@@ -64,17 +64,17 @@ func (s *issueService) UnassignIssue(ctx context.Context, repo string, number in
 		Title:     issue.Title,
 		Assignees: assignees.List(),
 	}
-	_, giteaResp, err := s.client.GiteaClient.EditIssue(namespace, name, int64(number), in)
+	_, giteaResp, err := s.client.sdk(ctx).EditIssue(namespace, name, int64(number), in)
 	return toSCMResponse(giteaResp), err
 }
 
 func (s *issueService) ListEvents(context.Context, string, int, scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Issues", "ListEvents")
 }
 
 func (s *issueService) ListLabels(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	out, resp, err := s.client.GiteaClient.GetIssueLabels(namespace, name, int64(number), gitea.ListLabelsOptions{ListOptions: toGiteaListOptions(opts)})
+	out, resp, err := s.client.sdk(ctx).GetIssueLabels(namespace, name, int64(number), gitea.ListLabelsOptions{ListOptions: toGiteaListOptions(opts)})
 	return convertLabels(out), toSCMResponse(resp), err
 }
 
@@ -107,28 +107,52 @@ func (s *issueService) lookupLabel(ctx context.Context, repo string, lbl string)
 	return labelID, res, nil
 }
 
-func (s *issueService) AddLabel(ctx context.Context, repo string, number int, lbl string) (*scm.Response, error) {
+// ensureLabel returns the id of the label, creating the label
+// if it does not exist in the repository.
+func (s *issueService) ensureLabel(ctx context.Context, repo string, lbl string) (int64, *scm.Response, error) {
 	labelID, res, err := s.lookupLabel(ctx, repo, lbl)
 	if err != nil {
-		return res, err
+		return labelID, res, err
+	}
+	if labelID != -1 {
+		return labelID, res, nil
 	}
 	namespace, name := scm.Split(repo)
+	lblInput := gitea.CreateLabelOption{
+		Color:       "#00aabb",
+		Description: "",
+		Name:        lbl,
+	}
+	newLabel, giteaResp, err := s.client.sdk(ctx).CreateLabel(namespace, name, lblInput)
+	if err != nil {
+		return labelID, toSCMResponse(giteaResp), errors.Wrapf(err, "failed to create label %s in repository %s", lbl, repo)
+	}
+	return newLabel.ID, toSCMResponse(giteaResp), nil
+}
 
-	if labelID == -1 {
-		lblInput := gitea.CreateLabelOption{
-			Color:       "#00aabb",
-			Description: "",
-			Name:        lbl,
-		}
-		newLabel, giteaResp, err := s.client.GiteaClient.CreateLabel(namespace, name, lblInput)
+// ensureLabels returns the ids of the labels, which gitea
+// expects instead of the names.
+func (s *issueService) ensureLabels(ctx context.Context, repo string, labels []string) ([]int64, *scm.Response, error) {
+	var ids []int64
+	for _, lbl := range labels {
+		id, res, err := s.ensureLabel(ctx, repo, lbl)
 		if err != nil {
-			return toSCMResponse(giteaResp), errors.Wrapf(err, "failed to create label %s in repository %s", lbl, repo)
+			return nil, res, err
 		}
-		labelID = newLabel.ID
+		ids = append(ids, id)
+	}
+	return ids, nil, nil
+}
+
+func (s *issueService) AddLabel(ctx context.Context, repo string, number int, lbl string) (*scm.Response, error) {
+	labelID, res, err := s.ensureLabel(ctx, repo, lbl)
+	if err != nil {
+		return res, err
 	}
+	namespace, name := scm.Split(repo)
 
 	in := gitea.IssueLabelsOption{Labels: []int64{labelID}}
-	_, giteaResp, err := s.client.GiteaClient.AddIssueLabels(namespace, name, int64(number), in)
+	_, giteaResp, err := s.client.sdk(ctx).AddIssueLabels(namespace, name, int64(number), in)
 	return toSCMResponse(giteaResp), err
 }
 
@@ -142,13 +166,13 @@ func (s *issueService) DeleteLabel(ctx context.Context, repo string, number int,
 	}
 
 	namespace, name := scm.Split(repo)
-	giteaResp, err := s.client.GiteaClient.DeleteIssueLabel(namespace, name, int64(number), labelID)
+	giteaResp, err := s.client.sdk(ctx).DeleteIssueLabel(namespace, name, int64(number), labelID)
 	return toSCMResponse(giteaResp), err
 }
 
 func (s *issueService) Find(ctx context.Context, repo string, number int) (*scm.Issue, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	out, resp, err := s.client.GiteaClient.GetIssue(namespace, name, int64(number))
+	out, resp, err := s.client.sdk(ctx).GetIssue(namespace, name, int64(number))
 	return convertIssue(out), toSCMResponse(resp), err
 }
 
@@ -158,7 +182,7 @@ func (s *issueService) FindComment(ctx context.Context, repo string, index, id i
 	var commentsPage []*scm.Comment
 	var err error
 	firstRun := false
-	opts := scm.ListOptions{
+	opts := scm.CommentListOptions{
 		Page: 1,
 	}
 	for !firstRun || (res != nil && opts.Page <= res.Page.Last) {
@@ -192,54 +216,113 @@ func (s *issueService) List(ctx context.Context, repo string, opts scm.IssueList
 	} else if opts.Closed && !opts.Open {
 		in.State = gitea.StateClosed
 	}
-	out, resp, err := s.client.GiteaClient.ListRepoIssues(namespace, name, in)
-	return convertIssueList(out), toSCMResponse(resp), err
+	out, resp, err := s.client.sdk(ctx).ListRepoIssues(namespace, name, in)
+	return scm.FilterIssues(convertIssueList(out), opts.UpdatedSince), toSCMResponse(resp), err
 }
 
-func (s *issueService) ListComments(ctx context.Context, repo string, index int, opts scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
+func (s *issueService) ListComments(ctx context.Context, repo string, index int, opts scm.CommentListOptions) ([]*scm.Comment, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	out, resp, err := s.client.GiteaClient.ListIssueComments(namespace, name, int64(index), gitea.ListIssueCommentOptions{ListOptions: toGiteaListOptions(opts)})
-	return convertIssueCommentList(out), toSCMResponse(resp), err
+	in := gitea.ListIssueCommentOptions{
+		ListOptions: toGiteaListOptions(scm.ListOptions{Page: opts.Page, Size: opts.Size}),
+		Since:       opts.Since,
+	}
+	out, resp, err := s.client.sdk(ctx).ListIssueComments(namespace, name, int64(index), in)
+	if err != nil {
+		return nil, toSCMResponse(resp), err
+	}
+	return scm.FilterComments(convertIssueCommentList(out), opts), toSCMResponse(resp), nil
 }
 
 func (s *issueService) Create(ctx context.Context, repo string, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	labels, res, err := s.ensureLabels(ctx, repo, input.Labels)
+	if err != nil {
+		return nil, res, err
+	}
 	namespace, name := scm.Split(repo)
 
 	in := gitea.CreateIssueOption{
-		Title: input.Title,
-		Body:  input.Body,
+		Title:     input.Title,
+		Body:      input.Body,
+		Milestone: int64(input.Milestone),
+		Labels:    labels,
+		Assignees: input.Assignees,
 	}
-	out, resp, err := s.client.GiteaClient.CreateIssue(namespace, name, in)
+	out, resp, err := s.client.sdk(ctx).CreateIssue(namespace, name, in)
 	return convertIssue(out), toSCMResponse(resp), err
 }
 
+func (s *issueService) Update(ctx context.Context, repo string, number int, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	in := gitea.EditIssueOption{
+		Title:     input.Title,
+		Assignees: input.Assignees,
+	}
+	if input.Body != "" {
+		in.Body = &input.Body
+	}
+	if input.Milestone != 0 {
+		milestone := int64(input.Milestone)
+		in.Milestone = &milestone
+	}
+	// gitea has no state reason, only the state is applied.
+	if state := input.TargetState(); state != "" {
+		st := gitea.StateType(state)
+		in.State = &st
+	}
+	out, resp, err := s.client.sdk(ctx).EditIssue(namespace, name, int64(number), in)
+	if err != nil {
+		return nil, toSCMResponse(resp), err
+	}
+	if len(input.Labels) == 0 {
+		return convertIssue(out), toSCMResponse(resp), nil
+	}
+
+	labels, res, err := s.ensureLabels(ctx, repo, input.Labels)
+	if err != nil {
+		return nil, res, err
+	}
+	_, resp, err = s.client.sdk(ctx).ReplaceIssueLabels(namespace, name, int64(number), gitea.IssueLabelsOption{Labels: labels})
+	if err != nil {
+		return nil, toSCMResponse(resp), err
+	}
+	return s.Find(ctx, repo, number)
+}
+
 func (s *issueService) CreateComment(ctx context.Context, repo string, index int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	in := gitea.CreateIssueCommentOption{Body: input.Body}
-	out, resp, err := s.client.GiteaClient.CreateIssueComment(namespace, name, int64(index), in)
+	out, resp, err := s.client.sdk(ctx).CreateIssueComment(namespace, name, int64(index), in)
 	return convertIssueComment(out), toSCMResponse(resp), err
 }
 
 func (s *issueService) DeleteComment(ctx context.Context, repo string, index, id int) (*scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	resp, err := s.client.GiteaClient.DeleteIssueComment(namespace, name, int64(id))
+	resp, err := s.client.sdk(ctx).DeleteIssueComment(namespace, name, int64(id))
 	return toSCMResponse(resp), err
 }
 
 func (s *issueService) EditComment(ctx context.Context, repo string, number int, id int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	in := gitea.EditIssueCommentOption{Body: input.Body}
-	out, resp, err := s.client.GiteaClient.EditIssueComment(namespace, name, int64(id), in)
+	out, resp, err := s.client.sdk(ctx).EditIssueComment(namespace, name, int64(id), in)
 	return convertIssueComment(out), toSCMResponse(resp), err
 }
 
+func (s *issueService) MinimizeComment(ctx context.Context, repo string, number int, id int, reason string) (*scm.Response, error) {
+	return nil, notSupported("Issues", "MinimizeComment")
+}
+
+func (s *issueService) UnminimizeComment(ctx context.Context, repo string, number int, id int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "UnminimizeComment")
+}
+
 func (s *issueService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	closed := gitea.StateClosed
 	in := gitea.EditIssueOption{
 		State: &closed,
 	}
-	_, resp, err := s.client.GiteaClient.EditIssue(namespace, name, int64(number), in)
+	_, resp, err := s.client.sdk(ctx).EditIssue(namespace, name, int64(number), in)
 	return toSCMResponse(resp), err
 }
 
@@ -249,16 +332,16 @@ func (s *issueService) Reopen(ctx context.Context, repo string, number int) (*sc
 	in := gitea.EditIssueOption{
 		State: &reopen,
 	}
-	_, resp, err := s.client.GiteaClient.EditIssue(namespace, name, int64(number), in)
+	_, resp, err := s.client.sdk(ctx).EditIssue(namespace, name, int64(number), in)
 	return toSCMResponse(resp), err
 }
 
 func (s *issueService) Lock(ctx context.Context, repo string, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "Lock")
 }
 
 func (s *issueService) Unlock(ctx context.Context, repo string, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "Unlock")
 }
 
 func (s *issueService) SetMilestone(ctx context.Context, repo string, issueID int, number int) (*scm.Response, error) {
@@ -267,17 +350,34 @@ func (s *issueService) SetMilestone(ctx context.Context, repo string, issueID in
 	in := gitea.EditIssueOption{
 		Milestone: &num64,
 	}
-	_, resp, err := s.client.GiteaClient.EditIssue(namespace, name, int64(issueID), in)
+	_, resp, err := s.client.sdk(ctx).EditIssue(namespace, name, int64(issueID), in)
 	return toSCMResponse(resp), err
 }
 
 func (s *issueService) ClearMilestone(ctx context.Context, repo string, id int) (*scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	in := gitea.EditIssueOption{}
-	_, resp, err := s.client.GiteaClient.EditIssue(namespace, name, int64(id), in)
+	_, resp, err := s.client.sdk(ctx).EditIssue(namespace, name, int64(id), in)
 	return toSCMResponse(resp), err
 }
 
+func (s *issueService) Pin(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/pin", repo, number)
+	return s.client.do(ctx, "POST", path, nil, nil)
+}
+
+func (s *issueService) Unpin(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/pin", repo, number)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *issueService) ListPinned(ctx context.Context, repo string) ([]*scm.Issue, *scm.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/pinned", repo)
+	out := []*gitea.Issue{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertIssueList(out), res, err
+}
+
 //
 // native data structure conversion
 //
@@ -291,17 +391,21 @@ func convertIssueList(from []*gitea.Issue) []*scm.Issue {
 }
 
 func convertIssue(from *gitea.Issue) *scm.Issue {
+	if from == nil {
+		return nil
+	}
 	return &scm.Issue{
 		Number:    int(from.Index),
 		Title:     from.Title,
 		Body:      from.Body,
 		Link:      from.URL,
 		Closed:    from.State == gitea.StateClosed,
-		Labels:    convertIssueLabels(from),
-		Author:    *convertUser(from.Poster),
+		Labels:    convertLabels(from.Labels),
+		Author:    convertUserValue(from.Poster),
 		Assignees: convertUsers(from.Assignees),
-		Created:   from.Created,
-		Updated:   from.Updated,
+		Created:   from.Created.UTC(),
+		Updated:   from.Updated.UTC(),
+		ClosedAt:  toTime(from.Closed),
 	}
 }
 
@@ -314,24 +418,16 @@ func convertIssueCommentList(from []*gitea.Comment) []*scm.Comment {
 }
 
 func convertIssueComment(from *gitea.Comment) *scm.Comment {
-	if from == nil || from.Poster == nil {
+	if from == nil {
 		return nil
 	}
 	return &scm.Comment{
 		ID:      int(from.ID),
 		Body:    from.Body,
-		Author:  *convertUser(from.Poster),
-		Created: from.Created,
-		Updated: from.Updated,
-	}
-}
-
-func convertIssueLabels(from *gitea.Issue) []string {
-	var labels []string
-	for _, label := range from.Labels {
-		labels = append(labels, label.Name)
+		Author:  convertUserValue(from.Poster),
+		Created: from.Created.UTC(),
+		Updated: from.Updated.UTC(),
 	}
-	return labels
 }
 
 func convertLabels(from []*gitea.Label) []*scm.Label {
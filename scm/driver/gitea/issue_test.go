@@ -7,6 +7,7 @@ package gitea
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"testing"
 
@@ -148,17 +149,25 @@ func TestIssueReopen(t *testing.T) {
 }
 
 func TestIssueLock(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
 	client, _ := New("https://try.gitea.io")
 	_, err := client.Issues.Lock(context.Background(), "gogits/go-gogs-client", 1)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
 
 func TestIssueUnlock(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
 	client, _ := New("https://try.gitea.io")
 	_, err := client.Issues.Unlock(context.Background(), "gogits/go-gogs-client", 1)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -207,7 +216,7 @@ func TestIssueCommentList(t *testing.T) {
 		File("testdata/comments.json")
 
 	client, _ := New("https://try.gitea.io")
-	got, res, err := client.Issues.ListComments(context.Background(), "go-gitea/gitea", 1, scm.ListOptions{})
+	got, res, err := client.Issues.ListComments(context.Background(), "go-gitea/gitea", 1, scm.CommentListOptions{})
 	if err != nil {
 		t.Error(err)
 	}
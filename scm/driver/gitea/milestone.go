@@ -13,7 +13,7 @@ type milestoneService struct {
 
 func (s *milestoneService) Find(ctx context.Context, repo string, id int) (*scm.Milestone, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	out, resp, err := s.client.GiteaClient.GetMilestone(namespace, name, int64(id))
+	out, resp, err := s.client.sdk(ctx).GetMilestone(namespace, name, int64(id))
 	return convertMilestone(out), toSCMResponse(resp), err
 }
 
@@ -32,7 +32,7 @@ func (s *milestoneService) List(ctx context.Context, repo string, opts scm.Miles
 	} else if opts.Open {
 		in.State = gitea.StateOpen
 	}
-	out, resp, err := s.client.GiteaClient.ListRepoMilestones(namespace, name, in)
+	out, resp, err := s.client.sdk(ctx).ListRepoMilestones(namespace, name, in)
 	return convertMilestoneList(out), toSCMResponse(resp), err
 }
 
@@ -47,13 +47,13 @@ func (s *milestoneService) Create(ctx context.Context, repo string, input *scm.M
 	if input.State == "closed" {
 		in.State = gitea.StateClosed
 	}
-	out, resp, err := s.client.GiteaClient.CreateMilestone(namespace, name, in)
+	out, resp, err := s.client.sdk(ctx).CreateMilestone(namespace, name, in)
 	return convertMilestone(out), toSCMResponse(resp), err
 }
 
 func (s *milestoneService) Delete(ctx context.Context, repo string, id int) (*scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	resp, err := s.client.GiteaClient.DeleteMilestone(namespace, name, int64(id))
+	resp, err := s.client.sdk(ctx).DeleteMilestone(namespace, name, int64(id))
 	return toSCMResponse(resp), err
 }
 
@@ -77,7 +77,7 @@ func (s *milestoneService) Update(ctx context.Context, repo string, id int, inpu
 	if input.DueDate != nil {
 		in.Deadline = input.DueDate
 	}
-	out, resp, err := s.client.GiteaClient.EditMilestone(namespace, name, int64(id), in)
+	out, resp, err := s.client.sdk(ctx).EditMilestone(namespace, name, int64(id), in)
 	return convertMilestone(out), toSCMResponse(resp), err
 }
 
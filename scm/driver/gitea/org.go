@@ -15,12 +15,12 @@ type organizationService struct {
 	client *wrapper
 }
 
-func (s *organizationService) Create(_ context.Context, org *scm.OrganizationInput) (*scm.Organization, *scm.Response, error) {
+func (s *organizationService) Create(ctx context.Context, org *scm.OrganizationInput) (*scm.Organization, *scm.Response, error) {
 	visibility := gitea.VisibleTypePublic
 	if org.Private {
 		visibility = gitea.VisibleTypePrivate
 	}
-	out, resp, err := s.client.GiteaClient.CreateOrg(gitea.CreateOrgOption{
+	out, resp, err := s.client.sdk(ctx).CreateOrg(gitea.CreateOrgOption{
 		Name:        org.Name,
 		FullName:    org.Name,
 		Description: org.Description,
@@ -30,13 +30,13 @@ func (s *organizationService) Create(_ context.Context, org *scm.OrganizationInp
 	return convertOrg(out), toSCMResponse(resp), err
 }
 
-func (s *organizationService) Delete(_ context.Context, org string) (*scm.Response, error) {
-	resp, err := s.client.GiteaClient.DeleteOrg(org)
+func (s *organizationService) Delete(ctx context.Context, org string) (*scm.Response, error) {
+	resp, err := s.client.sdk(ctx).DeleteOrg(org)
 	return toSCMResponse(resp), err
 }
 
 func (s *organizationService) IsMember(ctx context.Context, org string, user string) (bool, *scm.Response, error) {
-	isMember, resp, err := s.client.GiteaClient.CheckOrgMembership(org, user)
+	isMember, resp, err := s.client.sdk(ctx).CheckOrgMembership(org, user)
 	return isMember, toSCMResponse(resp), err
 }
 
@@ -67,42 +67,42 @@ func (s *organizationService) IsAdmin(ctx context.Context, org string, user stri
 }
 
 func (s *organizationService) ListTeams(ctx context.Context, org string, ops scm.ListOptions) ([]*scm.Team, *scm.Response, error) {
-	out, resp, err := s.client.GiteaClient.ListOrgTeams(org, gitea.ListTeamsOptions{ListOptions: toGiteaListOptions(ops)})
+	out, resp, err := s.client.sdk(ctx).ListOrgTeams(org, gitea.ListTeamsOptions{ListOptions: toGiteaListOptions(ops)})
 	return convertTeamList(out), toSCMResponse(resp), err
 }
 
 func (s *organizationService) ListTeamMembers(ctx context.Context, id int, role string, ops scm.ListOptions) ([]*scm.TeamMember, *scm.Response, error) {
-	out, resp, err := s.client.GiteaClient.ListTeamMembers(int64(id), gitea.ListTeamMembersOptions{
+	out, resp, err := s.client.sdk(ctx).ListTeamMembers(int64(id), gitea.ListTeamMembersOptions{
 		ListOptions: toGiteaListOptions(ops),
 	})
 	return convertMemberList(out), toSCMResponse(resp), err
 }
 
 func (s *organizationService) ListOrgMembers(ctx context.Context, org string, ops scm.ListOptions) ([]*scm.TeamMember, *scm.Response, error) {
-	out, resp, err := s.client.GiteaClient.ListOrgMembership(org, gitea.ListOrgMembershipOption{ListOptions: toGiteaListOptions(ops)})
+	out, resp, err := s.client.sdk(ctx).ListOrgMembership(org, gitea.ListOrgMembershipOption{ListOptions: toGiteaListOptions(ops)})
 	return convertMemberList(out), toSCMResponse(resp), err
 }
 
 func (s *organizationService) Find(ctx context.Context, name string) (*scm.Organization, *scm.Response, error) {
-	out, resp, err := s.client.GiteaClient.GetOrg(name)
+	out, resp, err := s.client.sdk(ctx).GetOrg(name)
 	return convertOrg(out), toSCMResponse(resp), err
 }
 
 func (s *organizationService) List(ctx context.Context, opts scm.ListOptions) ([]*scm.Organization, *scm.Response, error) {
-	out, resp, err := s.client.GiteaClient.ListMyOrgs(gitea.ListOrgsOptions{ListOptions: toGiteaListOptions(opts)})
+	out, resp, err := s.client.sdk(ctx).ListMyOrgs(gitea.ListOrgsOptions{ListOptions: toGiteaListOptions(opts)})
 	return convertOrgList(out), toSCMResponse(resp), err
 }
 
 func (s *organizationService) ListPendingInvitations(ctx context.Context, org string, opts scm.ListOptions) ([]*scm.OrganizationPendingInvite, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Organizations", "ListPendingInvitations")
 }
 
 func (s *organizationService) AcceptOrganizationInvitation(ctx context.Context, org string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Organizations", "AcceptOrganizationInvitation")
 }
 
 func (s *organizationService) ListMemberships(ctx context.Context, opts scm.ListOptions) ([]*scm.Membership, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Organizations", "ListMemberships")
 }
 
 //
@@ -20,8 +20,35 @@ type pullService struct {
 
 func (s *pullService) Find(ctx context.Context, repo string, index int) (*scm.PullRequest, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	out, resp, err := s.client.GiteaClient.GetPullRequest(namespace, name, int64(index))
-	return convertPullRequest(out), toSCMResponse(resp), err
+	out, resp, err := s.client.sdk(ctx).GetPullRequest(namespace, name, int64(index))
+	if err != nil {
+		return nil, toSCMResponse(resp), err
+	}
+	pr := convertPullRequest(out)
+	// the sdk does not expose diff stats, so they are
+	// computed from the diff. The stats are best effort, and
+	// are left unset if the diff cannot be fetched or parsed.
+	// The errors of a canceled context are returned.
+	path := fmt.Sprintf("api/v1/repos/%s/pulls/%d.diff", repo, index)
+	buf := new(bytes.Buffer)
+	if _, err := s.client.do(ctx, "GET", path, nil, buf); err != nil {
+		if ctx.Err() != nil {
+			return nil, toSCMResponse(resp), err
+		}
+		return pr, toSCMResponse(resp), nil
+	}
+	files, _, err := gitdiff.Parse(buf)
+	if err != nil {
+		return pr, toSCMResponse(resp), nil
+	}
+	for _, f := range files {
+		for _, tf := range f.TextFragments {
+			pr.Additions += int(tf.LinesAdded)
+			pr.Deletions += int(tf.LinesDeleted)
+		}
+	}
+	pr.ChangedFiles = len(files)
+	return pr, toSCMResponse(resp), nil
 }
 
 func (s *pullService) List(ctx context.Context, repo string, opts scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
@@ -37,8 +64,8 @@ func (s *pullService) List(ctx context.Context, repo string, opts scm.PullReques
 	} else if opts.Closed && !opts.Open {
 		in.State = gitea.StateClosed
 	}
-	out, resp, err := s.client.GiteaClient.ListRepoPullRequests(namespace, name, in)
-	return convertPullRequests(out), toSCMResponse(resp), err
+	out, resp, err := s.client.sdk(ctx).ListRepoPullRequests(namespace, name, in)
+	return scm.FilterPullRequests(convertPullRequests(out), opts), toSCMResponse(resp), err
 }
 
 // TODO: Maybe contribute to gitea/go-sdk with .patch function?
@@ -85,18 +112,25 @@ func (s *pullService) Merge(ctx context.Context, repo string, index int, options
 		in.Title = options.CommitTitle
 	}
 
-	_, resp, err := s.client.GiteaClient.MergePullRequest(namespace, name, int64(index), in)
+	_, resp, err := s.client.sdk(ctx).MergePullRequest(namespace, name, int64(index), in)
 	return toSCMResponse(resp), err
 }
 
 func (s *pullService) Update(ctx context.Context, repo string, number int, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
+	labels, res, err := s.ensureLabels(ctx, repo, input.Labels)
+	if err != nil {
+		return nil, res, err
+	}
 	namespace, name := scm.Split(repo)
 	in := gitea.EditPullRequestOption{
-		Title: input.Title,
-		Body:  input.Body,
-		Base:  input.Base,
+		Title:     input.Title,
+		Body:      input.Body,
+		Base:      input.Base,
+		Milestone: int64(input.Milestone),
+		Labels:    labels,
+		Assignees: input.Assignees,
 	}
-	out, resp, err := s.client.GiteaClient.EditPullRequest(namespace, name, int64(number), in)
+	out, resp, err := s.client.sdk(ctx).EditPullRequest(namespace, name, int64(number), in)
 	return convertPullRequest(out), toSCMResponse(resp), err
 }
 
@@ -106,7 +140,7 @@ func (s *pullService) Close(ctx context.Context, repo string, number int) (*scm.
 	in := gitea.EditPullRequestOption{
 		State: &closed,
 	}
-	_, resp, err := s.client.GiteaClient.EditPullRequest(namespace, name, int64(number), in)
+	_, resp, err := s.client.sdk(ctx).EditPullRequest(namespace, name, int64(number), in)
 	return toSCMResponse(resp), err
 }
 
@@ -116,19 +150,26 @@ func (s *pullService) Reopen(ctx context.Context, repo string, number int) (*scm
 	in := gitea.EditPullRequestOption{
 		State: &reopen,
 	}
-	_, resp, err := s.client.GiteaClient.EditPullRequest(namespace, name, int64(number), in)
+	_, resp, err := s.client.sdk(ctx).EditPullRequest(namespace, name, int64(number), in)
 	return toSCMResponse(resp), err
 }
 
 func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
+	labels, res, err := s.ensureLabels(ctx, repo, input.Labels)
+	if err != nil {
+		return nil, res, err
+	}
 	namespace, name := scm.Split(repo)
 	in := gitea.CreatePullRequestOption{
-		Head:  input.Head,
-		Base:  input.Base,
-		Title: input.Title,
-		Body:  input.Body,
+		Head:      input.Head,
+		Base:      input.Base,
+		Title:     input.Title,
+		Body:      input.Body,
+		Milestone: int64(input.Milestone),
+		Labels:    labels,
+		Assignees: input.Assignees,
 	}
-	out, resp, err := s.client.GiteaClient.CreatePullRequest(namespace, name, in)
+	out, resp, err := s.client.sdk(ctx).CreatePullRequest(namespace, name, in)
 	return convertPullRequest(out), toSCMResponse(resp), err
 }
 
@@ -156,57 +197,87 @@ func convertPullRequest(src *gitea.PullRequest) *scm.PullRequest {
 	if src == nil || src.Title == "" {
 		return nil
 	}
+	base := convertPullRequestBranch(src.Base)
+	head := convertPullRequestBranch(src.Head)
 	pr := &scm.PullRequest{
 		Number:    int(src.Index),
 		Title:     src.Title,
 		Body:      src.Body,
 		Labels:    convertLabels(src.Labels),
-		Sha:       src.Head.Sha,
+		Sha:       head.Sha,
 		Ref:       fmt.Sprintf("refs/pull/%d/head", src.Index),
 		State:     string(src.State),
-		Source:    src.Head.Name,
-		Target:    src.Base.Name,
-		Fork:      src.Base.Repository.FullName,
-		Base:      *convertPullRequestBranch(src.Base),
-		Head:      *convertPullRequestBranch(src.Head),
+		Base:      *base,
+		Head:      *head,
 		DiffLink:  src.DiffURL,
 		Link:      src.HTMLURL,
 		Closed:    src.State == gitea.StateClosed,
-		Author:    *convertUser(src.Poster),
+		Author:    convertUserValue(src.Poster),
 		Assignees: convertUsers(src.Assignees),
 		Merged:    src.HasMerged,
 		Mergeable: src.Mergeable,
-		Created:   *src.Created,
-		Updated:   *src.Updated,
+		Created:   toTime(src.Created),
+		Updated:   toTime(src.Updated),
+		ClosedAt:  toTime(src.Closed),
+		MergedAt:  toTime(src.Merged),
+	}
+	if src.Head != nil {
+		pr.Source = src.Head.Name
 	}
+	if src.Base != nil {
+		pr.Target = src.Base.Name
+	}
+	pr.Fork = pr.Head.Repo.FullName
 	if src.MergedCommitID != nil {
 		pr.MergeSha = *src.MergedCommitID
 	}
 	return pr
 }
 
+// convertPullRequestValue converts the pull request of a payload,
+// which is empty if the payload omits it.
+func convertPullRequestValue(src *gitea.PullRequest) scm.PullRequest {
+	if dst := convertPullRequest(src); dst != nil {
+		return *dst
+	}
+	return scm.PullRequest{}
+}
+
 func convertPullRequestFromIssue(src *gitea.Issue) *scm.PullRequest {
-	return &scm.PullRequest{
-		Number:  int(src.Index),
-		Title:   src.Title,
-		Body:    src.Body,
-		Labels:  convertLabels(src.Labels),
-		Closed:  src.State == gitea.StateClosed,
-		State:   string(src.State),
-		Link:    src.URL,
-		Author:  *convertUser(src.Poster),
-		Merged:  src.PullRequest.HasMerged,
-		Created: src.Created,
-		Updated: src.Updated,
+	pr := &scm.PullRequest{
+		Number:   int(src.Index),
+		Title:    src.Title,
+		Body:     src.Body,
+		Labels:   convertLabels(src.Labels),
+		Closed:   src.State == gitea.StateClosed,
+		State:    string(src.State),
+		Link:     src.URL,
+		Author:   convertUserValue(src.Poster),
+		Created:  src.Created.UTC(),
+		Updated:  src.Updated.UTC(),
+		ClosedAt: toTime(src.Closed),
+	}
+	if src.PullRequest != nil {
+		pr.Merged = src.PullRequest.HasMerged
+		pr.MergedAt = toTime(src.PullRequest.Merged)
 	}
+	return pr
 }
 
+// convertPullRequestBranch converts the head or base of the pull
+// request. The repository is omitted by gitea once a fork is
+// deleted, leaving the repository of the branch empty.
 func convertPullRequestBranch(src *gitea.PRBranchInfo) *scm.PullRequestBranch {
-	return &scm.PullRequestBranch{
-		Ref:  src.Ref,
-		Sha:  src.Sha,
-		Repo: *convertRepository(src.Repository),
+	dst := &scm.PullRequestBranch{}
+	if src == nil {
+		return dst
+	}
+	dst.Ref = src.Ref
+	dst.Sha = src.Sha
+	if repo := convertRepository(src.Repository); repo != nil {
+		dst.Repo = *repo
 	}
+	return dst
 }
 
 func convertMergeMethodToMergeStyle(mm string) gitea.MergeStyle {
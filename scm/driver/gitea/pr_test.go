@@ -7,7 +7,9 @@ package gitea
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -32,6 +34,12 @@ func TestPullRequestFind(t *testing.T) {
 		Type("application/json").
 		File("testdata/pr.json")
 
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls/1.diff").
+		Reply(200).
+		Type("text/plain").
+		File("testdata/pr_changes.patch")
+
 	client, _ := New("https://try.gitea.io")
 	got, _, err := client.PullRequests.Find(context.Background(), "jcitizen/my-repo", 1)
 	if err != nil {
@@ -48,6 +56,61 @@ func TestPullRequestFind(t *testing.T) {
 	}
 }
 
+func TestPullRequestFindDiffError(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls/1.diff").
+		Reply(500)
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.PullRequests.Find(context.Background(), "jcitizen/my-repo", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Additions != 0 || got.Deletions != 0 || got.ChangedFiles != 0 {
+		t.Errorf("Want the diff stats unset without the diff")
+	}
+}
+
+func TestPullRequestFindDiffCanceled(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls/1.diff").
+		Reply(200).
+		Type("text/plain").
+		File("testdata/pr_changes.patch").
+		Map(func(res *http.Response) *http.Response {
+			cancel()
+			return res
+		})
+
+	client, _ := New("https://try.gitea.io")
+	if _, _, err := client.PullRequests.Find(ctx, "jcitizen/my-repo", 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("Want the context error, got %v", err)
+	}
+}
+
 func TestPullRequestList(t *testing.T) {
 	defer gock.Off()
 
@@ -193,6 +256,8 @@ func TestPullCreate(t *testing.T) {
 	want := new(scm.PullRequest)
 	raw, _ := ioutil.ReadFile("testdata/pr.json.golden")
 	json.Unmarshal(raw, want)
+	// the diff stats are only computed by Find.
+	want.Additions, want.Deletions, want.ChangedFiles = 0, 0, 0
 
 	if diff := cmp.Diff(got, want); diff != "" {
 		t.Errorf("Unexpected Results")
@@ -6,6 +6,7 @@ package gitea
 
 import (
 	"context"
+	"io"
 	"net/url"
 	"strconv"
 
@@ -17,7 +18,7 @@ type repositoryService struct {
 	client *wrapper
 }
 
-func (s *repositoryService) Create(_ context.Context, input *scm.RepositoryInput) (*scm.Repository, *scm.Response, error) {
+func (s *repositoryService) Create(ctx context.Context, input *scm.RepositoryInput) (*scm.Repository, *scm.Response, error) {
 	var out *gitea.Repository
 	var err error
 	var resp *gitea.Response
@@ -28,9 +29,9 @@ func (s *repositoryService) Create(_ context.Context, input *scm.RepositoryInput
 	}
 
 	if input.Namespace == "" {
-		out, resp, err = s.client.GiteaClient.CreateRepo(in)
+		out, resp, err = s.client.sdk(ctx).CreateRepo(in)
 	} else {
-		out, resp, err = s.client.GiteaClient.CreateOrgRepo(input.Namespace, in)
+		out, resp, err = s.client.sdk(ctx).CreateOrgRepo(input.Namespace, in)
 	}
 	return convertRepository(out), toSCMResponse(resp), err
 }
@@ -38,13 +39,13 @@ func (s *repositoryService) Create(_ context.Context, input *scm.RepositoryInput
 func (s *repositoryService) Fork(ctx context.Context, input *scm.RepositoryInput, origRepo string) (*scm.Repository, *scm.Response, error) {
 	namespace, name := scm.Split(origRepo)
 	opts := gitea.CreateForkOption{Organization: &input.Namespace}
-	out, resp, err := s.client.GiteaClient.CreateFork(namespace, name, opts)
+	out, resp, err := s.client.sdk(ctx).CreateFork(namespace, name, opts)
 	return convertRepository(out), toSCMResponse(resp), err
 }
 
-func (s *repositoryService) FindCombinedStatus(_ context.Context, repo, ref string) (*scm.CombinedStatus, *scm.Response, error) {
+func (s *repositoryService) FindCombinedStatus(ctx context.Context, repo, ref string) (*scm.CombinedStatus, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	out, resp, err := s.client.GiteaClient.GetCombinedStatus(namespace, name, ref)
+	out, resp, err := s.client.sdk(ctx).GetCombinedStatus(namespace, name, ref)
 	if err != nil {
 		return nil, toSCMResponse(resp), err
 	}
@@ -89,48 +90,48 @@ func (s *repositoryService) FindUserPermission(ctx context.Context, repo string,
 	return scm.NoPermission, res, nil
 }
 
-func (s *repositoryService) AddCollaborator(_ context.Context, repo, user, permission string) (bool, bool, *scm.Response, error) {
+func (s *repositoryService) AddCollaborator(ctx context.Context, repo, user, permission string) (bool, bool, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	giteaPerm := gitea.AccessMode(permission)
 	opt := gitea.AddCollaboratorOption{Permission: &giteaPerm}
-	resp, err := s.client.GiteaClient.AddCollaborator(namespace, name, user, opt)
+	resp, err := s.client.sdk(ctx).AddCollaborator(namespace, name, user, opt)
 	if err != nil {
 		return false, false, toSCMResponse(resp), err
 	}
 	return true, false, toSCMResponse(resp), nil
 }
 
-func (s *repositoryService) IsCollaborator(_ context.Context, repo, user string) (bool, *scm.Response, error) {
+func (s *repositoryService) IsCollaborator(ctx context.Context, repo, user string) (bool, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	isCollab, resp, err := s.client.GiteaClient.IsCollaborator(namespace, name, user)
+	isCollab, resp, err := s.client.sdk(ctx).IsCollaborator(namespace, name, user)
 	return isCollab, toSCMResponse(resp), err
 }
 
-func (s *repositoryService) ListCollaborators(_ context.Context, repo string, ops scm.ListOptions) ([]scm.User, *scm.Response, error) {
+func (s *repositoryService) ListCollaborators(ctx context.Context, repo string, ops scm.ListOptions) ([]scm.User, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	out, resp, err := s.client.GiteaClient.ListCollaborators(namespace, name, gitea.ListCollaboratorsOptions{ListOptions: toGiteaListOptions(ops)})
+	out, resp, err := s.client.sdk(ctx).ListCollaborators(namespace, name, gitea.ListCollaboratorsOptions{ListOptions: toGiteaListOptions(ops)})
 	return convertUsers(out), toSCMResponse(resp), err
 }
 
-func (s *repositoryService) ListLabels(_ context.Context, repo string, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
+func (s *repositoryService) ListLabels(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	out, resp, err := s.client.GiteaClient.ListRepoLabels(namespace, name, gitea.ListLabelsOptions{ListOptions: toGiteaListOptions(opts)})
+	out, resp, err := s.client.sdk(ctx).ListRepoLabels(namespace, name, gitea.ListLabelsOptions{ListOptions: toGiteaListOptions(opts)})
 	return convertLabels(out), toSCMResponse(resp), err
 }
 
-func (s *repositoryService) Find(_ context.Context, repo string) (*scm.Repository, *scm.Response, error) {
+func (s *repositoryService) Find(ctx context.Context, repo string) (*scm.Repository, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	out, resp, err := s.client.GiteaClient.GetRepo(namespace, name)
-	return convertRepository(out), toSCMResponse(resp), err
+	out, resp, err := s.client.sdk(ctx).GetRepo(namespace, name)
+	return convertRepository(out), toSCMResponse(resp), toSCMError(resp, err)
 }
 
-func (s *repositoryService) FindHook(_ context.Context, repo string, id string) (*scm.Hook, *scm.Response, error) {
+func (s *repositoryService) FindHook(ctx context.Context, repo string, id string) (*scm.Hook, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	idInt, err := strconv.ParseInt(id, 10, 64)
 	if err != nil {
 		return nil, nil, err
 	}
-	out, resp, err := s.client.GiteaClient.GetRepoHook(namespace, name, idInt)
+	out, resp, err := s.client.sdk(ctx).GetRepoHook(namespace, name, idInt)
 	return convertHook(out), toSCMResponse(resp), err
 }
 
@@ -142,34 +143,34 @@ func (s *repositoryService) FindPerms(ctx context.Context, repo string) (*scm.Pe
 	return r.Perm, resp, err
 }
 
-func (s *repositoryService) List(_ context.Context, opts scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
-	out, resp, err := s.client.GiteaClient.ListMyRepos(gitea.ListReposOptions{ListOptions: toGiteaListOptions(opts)})
+func (s *repositoryService) List(ctx context.Context, opts scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	out, resp, err := s.client.sdk(ctx).ListMyRepos(gitea.ListReposOptions{ListOptions: toGiteaListOptions(opts)})
 	return convertRepositoryList(out), toSCMResponse(resp), err
 }
 
-func (s *repositoryService) ListOrganisation(_ context.Context, org string, opts scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
-	out, resp, err := s.client.GiteaClient.ListOrgRepos(org, gitea.ListOrgReposOptions{ListOptions: toGiteaListOptions(opts)})
+func (s *repositoryService) ListOrganisation(ctx context.Context, org string, opts scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	out, resp, err := s.client.sdk(ctx).ListOrgRepos(org, gitea.ListOrgReposOptions{ListOptions: toGiteaListOptions(opts)})
 	return convertRepositoryList(out), toSCMResponse(resp), err
 }
 
-func (s *repositoryService) ListUser(_ context.Context, username string, opts scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
-	out, resp, err := s.client.GiteaClient.ListUserRepos(username, gitea.ListReposOptions{ListOptions: toGiteaListOptions(opts)})
+func (s *repositoryService) ListUser(ctx context.Context, username string, opts scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	out, resp, err := s.client.sdk(ctx).ListUserRepos(username, gitea.ListReposOptions{ListOptions: toGiteaListOptions(opts)})
 	return convertRepositoryList(out), toSCMResponse(resp), err
 }
 
-func (s *repositoryService) ListHooks(_ context.Context, repo string, opts scm.ListOptions) ([]*scm.Hook, *scm.Response, error) {
+func (s *repositoryService) ListHooks(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Hook, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	out, resp, err := s.client.GiteaClient.ListRepoHooks(namespace, name, gitea.ListHooksOptions{ListOptions: toGiteaListOptions(opts)})
+	out, resp, err := s.client.sdk(ctx).ListRepoHooks(namespace, name, gitea.ListHooksOptions{ListOptions: toGiteaListOptions(opts)})
 	return convertHookList(out), toSCMResponse(resp), err
 }
 
-func (s *repositoryService) ListStatus(_ context.Context, repo string, ref string, opts scm.ListOptions) ([]*scm.Status, *scm.Response, error) {
+func (s *repositoryService) ListStatus(ctx context.Context, repo string, ref string, opts scm.ListOptions) ([]*scm.Status, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	out, resp, err := s.client.GiteaClient.ListStatuses(namespace, name, ref, gitea.ListStatusesOption{ListOptions: toGiteaListOptions(opts)})
+	out, resp, err := s.client.sdk(ctx).ListStatuses(namespace, name, ref, gitea.ListStatusesOption{ListOptions: toGiteaListOptions(opts)})
 	return convertStatusList(out), toSCMResponse(resp), err
 }
 
-func (s *repositoryService) CreateHook(_ context.Context, repo string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+func (s *repositoryService) CreateHook(ctx context.Context, repo string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
 	target, err := url.Parse(input.Target)
 	if err != nil {
 		return nil, nil, err
@@ -192,11 +193,11 @@ func (s *repositoryService) CreateHook(_ context.Context, repo string, input *sc
 		),
 		Active: true,
 	}
-	out, resp, err := s.client.GiteaClient.CreateRepoHook(namespace, name, in)
+	out, resp, err := s.client.sdk(ctx).CreateRepoHook(namespace, name, in)
 	return convertHook(out), toSCMResponse(resp), err
 }
 
-func (s *repositoryService) CreateStatus(_ context.Context, repo string, ref string, input *scm.StatusInput) (*scm.Status, *scm.Response, error) {
+func (s *repositoryService) CreateStatus(ctx context.Context, repo string, ref string, input *scm.StatusInput) (*scm.Status, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	in := gitea.CreateStatusOption{
 		State:       convertFromState(input.State),
@@ -204,26 +205,74 @@ func (s *repositoryService) CreateStatus(_ context.Context, repo string, ref str
 		Description: input.Desc,
 		Context:     input.Label,
 	}
-	out, resp, err := s.client.GiteaClient.CreateStatus(namespace, name, ref, in)
+	out, resp, err := s.client.sdk(ctx).CreateStatus(namespace, name, ref, in)
 	return convertStatus(out), toSCMResponse(resp), err
 }
 
-func (s *repositoryService) DeleteHook(_ context.Context, repo string, id string) (*scm.Response, error) {
+func (s *repositoryService) DeleteHook(ctx context.Context, repo string, id string) (*scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	idInt, err := strconv.ParseInt(id, 10, 64)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := s.client.GiteaClient.DeleteRepoHook(namespace, name, idInt)
+	resp, err := s.client.sdk(ctx).DeleteRepoHook(namespace, name, idInt)
 	return toSCMResponse(resp), err
 }
 
-func (s *repositoryService) Delete(_ context.Context, repo string) (*scm.Response, error) {
+func (s *repositoryService) Delete(ctx context.Context, repo string) (*scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	resp, err := s.client.GiteaClient.DeleteRepo(namespace, name)
+	resp, err := s.client.sdk(ctx).DeleteRepo(namespace, name)
 	return toSCMResponse(resp), err
 }
 
+func (s *repositoryService) GetDependencyGraph(context.Context, string) (*scm.DependencyGraph, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "GetDependencyGraph")
+}
+
+func (s *repositoryService) ExportSBOM(context.Context, string) (io.ReadCloser, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ExportSBOM")
+}
+
+func (s *repositoryService) ListRulesets(context.Context, string, scm.ListOptions) ([]*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListRulesets")
+}
+
+func (s *repositoryService) FindRuleset(context.Context, string, int) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "FindRuleset")
+}
+
+func (s *repositoryService) CreateRuleset(context.Context, string, *scm.RulesetInput) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "CreateRuleset")
+}
+
+func (s *repositoryService) UpdateRuleset(context.Context, string, int, *scm.RulesetInput) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "UpdateRuleset")
+}
+
+func (s *repositoryService) GetPages(context.Context, string) (*scm.Pages, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "GetPages")
+}
+
+func (s *repositoryService) EnablePages(context.Context, string, *scm.PagesInput) (*scm.Pages, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "EnablePages")
+}
+
+func (s *repositoryService) DisablePages(context.Context, string) (*scm.Response, error) {
+	return nil, notSupported("Repositories", "DisablePages")
+}
+
+func (s *repositoryService) GetLatestPagesBuild(context.Context, string) (*scm.PagesBuild, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "GetLatestPagesBuild")
+}
+
+func (s *repositoryService) ListHookDeliveries(context.Context, string, string, scm.ListOptions) ([]*scm.HookDelivery, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListHookDeliveries")
+}
+
+func (s *repositoryService) RedeliverHookDelivery(context.Context, string, string, int64) (*scm.Response, error) {
+	return nil, notSupported("Repositories", "RedeliverHookDelivery")
+}
+
 //
 // native data structure conversion
 //
@@ -251,9 +300,18 @@ func convertRepository(src *gitea.Repository) *scm.Repository {
 		Clone:     src.CloneURL,
 		CloneSSH:  src.SSHURL,
 		Link:      src.HTMLURL,
-		Created:   src.Created,
-		Updated:   src.Updated,
+		Created:   src.Created.UTC(),
+		Updated:   src.Updated.UTC(),
+	}
+}
+
+// convertRepositoryValue converts the repository of a payload,
+// which is empty if the payload omits it.
+func convertRepositoryValue(src *gitea.Repository) scm.Repository {
+	if dst := convertRepository(src); dst != nil {
+		return *dst
 	}
+	return scm.Repository{}
 }
 
 func convertPerm(src *gitea.Permission) *scm.Perm {
@@ -338,6 +396,8 @@ func convertState(from gitea.StatusState) scm.State {
 		return scm.StatePending
 	case gitea.StatusSuccess:
 		return scm.StateSuccess
+	case gitea.StatusWarning:
+		return scm.StateNeutral
 	default:
 		return scm.StateUnknown
 	}
@@ -345,10 +405,12 @@ func convertState(from gitea.StatusState) scm.State {
 
 func convertFromState(from scm.State) gitea.StatusState {
 	switch from {
-	case scm.StatePending, scm.StateRunning:
+	case scm.StatePending, scm.StateRunning, scm.StateExpected:
 		return gitea.StatusPending
-	case scm.StateSuccess:
+	case scm.StateSuccess, scm.StateSkipped:
 		return gitea.StatusSuccess
+	case scm.StateNeutral:
+		return gitea.StatusWarning
 	case scm.StateFailure:
 		return gitea.StatusFailure
 	default:
@@ -115,10 +115,8 @@ func TestRepoNotFound(t *testing.T) {
 
 	client, _ := New("https://try.gitea.io")
 	_, _, err := client.Repositories.FindPerms(context.Background(), "gogits/go-gogs-client")
-	if err == nil {
-		t.Errorf("Expect Not Found error")
-	} else if got, want := err.Error(), "404 Not Found"; got != want {
-		t.Errorf("Want error %q, got %q", want, got)
+	if err != scm.ErrNotFound {
+		t.Errorf("Want ErrNotFound, got %v", err)
 	}
 }
 
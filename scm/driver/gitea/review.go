@@ -17,13 +17,13 @@ type reviewService struct {
 
 func (s *reviewService) Find(ctx context.Context, repo string, number, id int) (*scm.Review, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	review, resp, err := s.client.GiteaClient.GetPullReview(namespace, name, int64(number), int64(id))
+	review, resp, err := s.client.sdk(ctx).GetPullReview(namespace, name, int64(number), int64(id))
 	return convertReview(review), toSCMResponse(resp), err
 }
 
 func (s *reviewService) List(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Review, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	reviews, resp, err := s.client.GiteaClient.ListPullReviews(namespace, name, int64(number), gitea.ListPullReviewsOptions{ListOptions: toGiteaListOptions(opts)})
+	reviews, resp, err := s.client.sdk(ctx).ListPullReviews(namespace, name, int64(number), gitea.ListPullReviewsOptions{ListOptions: toGiteaListOptions(opts)})
 
 	return convertReviewList(reviews), toSCMResponse(resp), err
 }
@@ -37,19 +37,19 @@ func (s *reviewService) Create(ctx context.Context, repo string, number int, inp
 		CommitID: input.Sha,
 		Comments: toCreatePullRequestComments(input.Comments),
 	}
-	review, resp, err := s.client.GiteaClient.CreatePullReview(namespace, name, int64(number), in)
+	review, resp, err := s.client.sdk(ctx).CreatePullReview(namespace, name, int64(number), in)
 	return convertReview(review), toSCMResponse(resp), err
 }
 
 func (s *reviewService) Delete(ctx context.Context, repo string, number, id int) (*scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	resp, err := s.client.GiteaClient.DeletePullReview(namespace, name, int64(number), int64(id))
+	resp, err := s.client.sdk(ctx).DeletePullReview(namespace, name, int64(number), int64(id))
 	return toSCMResponse(resp), err
 }
 
 func (s *reviewService) ListComments(ctx context.Context, repo string, prID int, reviewID int, options scm.ListOptions) ([]*scm.ReviewComment, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	comments, resp, err := s.client.GiteaClient.ListPullReviewComments(namespace, name, int64(prID), int64(reviewID))
+	comments, resp, err := s.client.sdk(ctx).ListPullReviewComments(namespace, name, int64(prID), int64(reviewID))
 	return convertReviewCommentList(comments), toSCMResponse(resp), err
 }
 
@@ -58,7 +58,7 @@ func (s *reviewService) Update(ctx context.Context, repo string, prID int, revie
 	in := gitea.SubmitPullReviewOptions{
 		Body: body,
 	}
-	review, resp, err := s.client.GiteaClient.SubmitPullReview(namespace, name, int64(prID), int64(reviewID), in)
+	review, resp, err := s.client.sdk(ctx).SubmitPullReview(namespace, name, int64(prID), int64(reviewID), in)
 	return convertReview(review), toSCMResponse(resp), err
 }
 
@@ -68,13 +68,13 @@ func (s *reviewService) Submit(ctx context.Context, repo string, prID int, revie
 		State: toGiteaState(input.Event),
 		Body:  input.Body,
 	}
-	review, resp, err := s.client.GiteaClient.SubmitPullReview(namespace, name, int64(prID), int64(reviewID), in)
+	review, resp, err := s.client.sdk(ctx).SubmitPullReview(namespace, name, int64(prID), int64(reviewID), in)
 	return convertReview(review), toSCMResponse(resp), err
 }
 
 // TODO: Figure out whether this actually is a _thing_ exactly in Gitea. I don't think it is.
 func (s *reviewService) Dismiss(ctx context.Context, repo string, prID int, reviewID int, msg string) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Reviews", "Dismiss")
 }
 
 func convertReviewList(from []*gitea.PullReview) []*scm.Review {
@@ -95,8 +95,8 @@ func convertReview(src *gitea.PullReview) *scm.Review {
 		Sha:     src.CommitID,
 		Link:    src.HTMLURL,
 		State:   string(src.State),
-		Author:  *convertUser(src.Reviewer),
-		Created: src.Submitted,
+		Author:  convertUserValue(src.Reviewer),
+		Created: src.Submitted.UTC(),
 	}
 }
 
@@ -116,9 +116,9 @@ func convertReviewComment(src *gitea.PullReviewComment) *scm.ReviewComment {
 		Sha:     src.CommitID,
 		Line:    int(src.LineNum),
 		Link:    src.HTMLURL,
-		Author:  *convertUser(src.Reviewer),
-		Created: src.Created,
-		Updated: src.Updated,
+		Author:  convertUserValue(src.Reviewer),
+		Created: src.Created.UTC(),
+		Updated: src.Updated.UTC(),
 	}
 }
 func toCreatePullRequestComments(src []*scm.ReviewCommentInput) []gitea.CreatePullReviewComment {
@@ -0,0 +1,84 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type runnerService struct {
+	client *wrapper
+}
+
+type runner struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Busy   bool   `json:"busy"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+type runnerList struct {
+	TotalCount int       `json:"total_count"`
+	Runners    []*runner `json:"runners"`
+}
+
+type runnerToken struct {
+	Token string `json:"token"`
+}
+
+func (s *runnerService) List(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Runner, *scm.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/actions/runners?%s", repo, encodeListOptions(opts))
+	out := new(runnerList)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertRunnerList(out.Runners), res, err
+}
+
+// CreateToken returns the registration token of the act
+// runners. Gitea tokens do not expire.
+func (s *runnerService) CreateToken(ctx context.Context, repo string) (*scm.RunnerToken, *scm.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/actions/runners/registration-token", repo)
+	out := new(runnerToken)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return &scm.RunnerToken{Token: out.Token}, res, err
+}
+
+func (s *runnerService) Delete(ctx context.Context, repo string, id int) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/actions/runners/%d", repo, id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func convertRunnerList(from []*runner) []*scm.Runner {
+	to := []*scm.Runner{}
+	for _, v := range from {
+		to = append(to, convertRunner(v))
+	}
+	return to
+}
+
+func convertRunner(from *runner) *scm.Runner {
+	to := &scm.Runner{
+		ID:     from.ID,
+		Name:   from.Name,
+		Status: convertRunnerStatus(from.Status),
+		Busy:   from.Busy,
+	}
+	for _, label := range from.Labels {
+		to.Labels = append(to.Labels, label.Name)
+	}
+	return to
+}
+
+// convertRunnerStatus folds the idle and active states of
+// the online runners.
+func convertRunnerStatus(from string) string {
+	switch from {
+	case "idle", "active":
+		return scm.RunnerStatusOnline
+	default:
+		return from
+	}
+}
@@ -0,0 +1,83 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestRunnerList(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/actions/runners").
+		MatchParam("page", "1").
+		MatchParam("limit", "30").
+		Reply(200).
+		Type("application/json").
+		File("testdata/runners.json")
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.Runners.List(context.Background(), "go-gitea/gitea", scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Runner{}
+	raw, _ := ioutil.ReadFile("testdata/runners.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestRunnerCreateToken(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/actions/runners/registration-token").
+		MatchHeader("Authorization", "token secret").
+		Reply(200).
+		Type("application/json").
+		File("testdata/runner_token.json")
+
+	client, _ := NewWithToken("https://try.gitea.io", "secret")
+	got, _, err := client.Runners.CreateToken(context.Background(), "go-gitea/gitea")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := got.Token, "ZT7hSnA6pRmqbPWuXeZkvhEWjzAa0zBnCWBRYSQs"; got != want {
+		t.Errorf("Want token %q, got %q", want, got)
+	}
+}
+
+func TestRunnerDelete(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Delete("/api/v1/repos/go-gitea/gitea/actions/runners/1").
+		Reply(204).
+		Type("application/json")
+
+	client, _ := New("https://try.gitea.io")
+	_, err := client.Runners.Delete(context.Background(), "go-gitea/gitea", 1)
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -15,32 +15,45 @@ type userService struct {
 	client *wrapper
 }
 
-func (s *userService) CreateToken(_ context.Context, user string, name string) (*scm.UserToken, *scm.Response, error) {
-	out, resp, err := s.client.GiteaClient.CreateAccessToken(gitea.CreateAccessTokenOption{
+func (s *userService) CreateToken(ctx context.Context, user string, name string) (*scm.UserToken, *scm.Response, error) {
+	out, resp, err := s.client.sdk(ctx).CreateAccessToken(gitea.CreateAccessTokenOption{
 		Name: name,
 	})
 	if out == nil {
 		return nil, toSCMResponse(resp), err
 	}
-	token := &scm.UserToken{
-		ID:    out.ID,
-		Token: out.Token,
-	}
-	return token, toSCMResponse(resp), err
+	return convertToken(out), toSCMResponse(resp), err
 }
 
-func (s *userService) DeleteToken(_ context.Context, id int64) (*scm.Response, error) {
-	resp, err := s.client.GiteaClient.DeleteAccessToken(id)
+func (s *userService) DeleteToken(ctx context.Context, id int64) (*scm.Response, error) {
+	resp, err := s.client.sdk(ctx).DeleteAccessToken(id)
 	return toSCMResponse(resp), err
 }
 
+// TokenInfo is not supported. The tokens API of Gitea only
+// accepts basic auth, so a client authenticated with a token
+// cannot list the tokens, and the listing only has the last
+// eight characters of each token. Gitea does not report the
+// scopes of the token in a response header either.
+func (s *userService) TokenInfo(context.Context) (*scm.TokenInfo, *scm.Response, error) {
+	return nil, nil, notSupported("Users", "TokenInfo")
+}
+
+// ListTokens returns the tokens of the user. Like the other
+// token methods it requires a client authenticated with basic
+// auth, see NewWithBasicAuth.
+func (s *userService) ListTokens(ctx context.Context, opts scm.ListOptions) ([]*scm.UserToken, *scm.Response, error) {
+	out, resp, err := s.client.sdk(ctx).ListAccessTokens(gitea.ListAccessTokensOptions{ListOptions: toGiteaListOptions(opts)})
+	return convertTokenList(out), toSCMResponse(resp), err
+}
+
 func (s *userService) Find(ctx context.Context) (*scm.User, *scm.Response, error) {
-	out, resp, err := s.client.GiteaClient.GetMyUserInfo()
+	out, resp, err := s.client.sdk(ctx).GetMyUserInfo()
 	return convertUser(out), toSCMResponse(resp), err
 }
 
 func (s *userService) FindLogin(ctx context.Context, login string) (*scm.User, *scm.Response, error) {
-	out, resp, err := s.client.GiteaClient.GetUserInfo(login)
+	out, resp, err := s.client.sdk(ctx).GetUserInfo(login)
 	return convertUser(out), toSCMResponse(resp), err
 }
 
@@ -53,11 +66,11 @@ func (s *userService) FindEmail(ctx context.Context) (string, *scm.Response, err
 }
 
 func (s *userService) ListInvitations(context.Context) ([]*scm.Invitation, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Users", "ListInvitations")
 }
 
 func (s *userService) AcceptInvitation(context.Context, int64) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Users", "AcceptInvitation")
 }
 
 //
@@ -67,8 +80,7 @@ func (s *userService) AcceptInvitation(context.Context, int64) (*scm.Response, e
 func convertUsers(src []*gitea.User) []scm.User {
 	answer := []scm.User{}
 	for _, u := range src {
-		user := convertUser(u)
-		if user.Login != "" {
+		if user := convertUser(u); user != nil {
 			answer = append(answer, *user)
 		}
 	}
@@ -83,10 +95,36 @@ func convertUser(src *gitea.User) *scm.User {
 		return nil
 	}
 	return &scm.User{
-		ID:     int(src.ID),
-		Login:  src.UserName,
-		Name:   src.FullName,
-		Email:  src.Email,
-		Avatar: src.AvatarURL,
+		ID:      int(src.ID),
+		Login:   src.UserName,
+		Name:    src.FullName,
+		Email:   src.Email,
+		Avatar:  src.AvatarURL,
+		Created: src.Created.UTC(),
+	}
+}
+
+// convertUserValue converts the user of a payload, which is
+// empty if the payload omits it.
+func convertUserValue(src *gitea.User) scm.User {
+	if dst := convertUser(src); dst != nil {
+		return *dst
+	}
+	return scm.User{}
+}
+
+func convertTokenList(src []*gitea.AccessToken) []*scm.UserToken {
+	var dst []*scm.UserToken
+	for _, v := range src {
+		dst = append(dst, convertToken(v))
+	}
+	return dst
+}
+
+func convertToken(src *gitea.AccessToken) *scm.UserToken {
+	return &scm.UserToken{
+		ID:    src.ID,
+		Name:  src.Name,
+		Token: src.Token,
 	}
 }
@@ -91,3 +91,30 @@ func TestUserFindEmail(t *testing.T) {
 		t.Errorf("Want email %s, got %s", want, got)
 	}
 }
+
+func TestUserTokenList(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/users/jcitizen/tokens").
+		Reply(200).
+		Type("application/json").
+		File("testdata/tokens.json")
+
+	client, _ := NewWithBasicAuth("https://try.gitea.io", "jcitizen", "pa55word")
+	got, _, err := client.Users.ListTokens(context.Background(), scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*scm.UserToken{}
+	raw, _ := ioutil.ReadFile("testdata/tokens.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
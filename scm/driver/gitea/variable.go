@@ -0,0 +1,108 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type variableService struct {
+	client *wrapper
+}
+
+type variable struct {
+	Name string `json:"name"`
+	Data string `json:"data"`
+}
+
+type variableInput struct {
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value"`
+}
+
+func (s *variableService) List(ctx context.Context, scope scm.VariableScope, opts scm.ListOptions) ([]*scm.Variable, *scm.Response, error) {
+	base, err := variablePath(scope, "List")
+	if err != nil {
+		return nil, nil, err
+	}
+	path := fmt.Sprintf("%s?%s", base, encodeListOptions(opts))
+	out := []*variable{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertVariableList(out), res, err
+}
+
+func (s *variableService) Find(ctx context.Context, scope scm.VariableScope, name string) (*scm.Variable, *scm.Response, error) {
+	base, err := variablePath(scope, "Find")
+	if err != nil {
+		return nil, nil, err
+	}
+	path := fmt.Sprintf("%s/%s", base, name)
+	out := new(variable)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertVariable(out), res, err
+}
+
+func (s *variableService) Create(ctx context.Context, scope scm.VariableScope, input *scm.VariableInput) (*scm.Response, error) {
+	base, err := variablePath(scope, "Create")
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s", base, input.Name)
+	in := &variableInput{Value: input.Value}
+	return s.client.do(ctx, "POST", path, in, nil)
+}
+
+func (s *variableService) Update(ctx context.Context, scope scm.VariableScope, input *scm.VariableInput) (*scm.Response, error) {
+	base, err := variablePath(scope, "Update")
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s", base, input.Name)
+	in := &variableInput{
+		Name:  input.Name,
+		Value: input.Value,
+	}
+	return s.client.do(ctx, "PUT", path, in, nil)
+}
+
+func (s *variableService) Delete(ctx context.Context, scope scm.VariableScope, name string) (*scm.Response, error) {
+	base, err := variablePath(scope, "Delete")
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s", base, name)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+// variablePath returns the path of the variables of the
+// scope. Gitea variables cannot be scoped to an environment.
+func variablePath(scope scm.VariableScope, method string) (string, error) {
+	switch {
+	case scope.Repo != "" && scope.Org != "":
+		return "", scm.ErrInvalidVariableScope
+	case scope.Environment != "":
+		return "", notSupported("Variables", method)
+	case scope.Repo != "":
+		return fmt.Sprintf("api/v1/repos/%s/actions/variables", scope.Repo), nil
+	case scope.Org != "":
+		return fmt.Sprintf("api/v1/orgs/%s/actions/variables", scope.Org), nil
+	default:
+		return "", scm.ErrInvalidVariableScope
+	}
+}
+
+func convertVariableList(from []*variable) []*scm.Variable {
+	to := []*scm.Variable{}
+	for _, v := range from {
+		to = append(to, convertVariable(v))
+	}
+	return to
+}
+
+func convertVariable(from *variable) *scm.Variable {
+	return &scm.Variable{
+		Name:  from.Name,
+		Value: from.Data,
+	}
+}
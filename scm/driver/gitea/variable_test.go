@@ -0,0 +1,77 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestVariableList(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/actions/variables").
+		Reply(200).
+		Type("application/json").
+		File("testdata/variables.json")
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.Variables.List(context.Background(), scm.VariableScope{Repo: "go-gitea/gitea"}, scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Variable{}
+	raw, _ := ioutil.ReadFile("testdata/variables.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestVariableCreate(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Post("/api/v1/orgs/go-gitea/actions/variables/USERNAME").
+		MatchHeader("Authorization", "token secret").
+		Reply(201)
+
+	client, _ := NewWithToken("https://try.gitea.io", "secret")
+	input := &scm.VariableInput{Name: "USERNAME", Value: "gitea"}
+	_, err := client.Variables.Create(context.Background(), scm.VariableScope{Org: "go-gitea"}, input)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVariableEnvironment(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	client, _ := New("https://try.gitea.io")
+	scope := scm.VariableScope{Repo: "go-gitea/gitea", Environment: "production"}
+	_, _, err := client.Variables.List(context.Background(), scope, scm.ListOptions{})
+	if !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error, got %v", err)
+	}
+	want := &scm.NotSupportedError{Driver: scm.DriverGitea, Service: "Variables", Method: "List"}
+	if diff := cmp.Diff(err, error(want)); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
@@ -7,8 +7,6 @@ package gitea
 import (
 	"crypto/sha256"
 	"encoding/json"
-	"io"
-	"io/ioutil"
 	"net/http"
 
 	"code.gitea.io/sdk/gitea"
@@ -19,12 +17,15 @@ import (
 
 type webhookService struct {
 	client *wrapper
+	opts   scm.WebhookServiceOptions
 }
 
 func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
-	data, err := ioutil.ReadAll(
-		io.LimitReader(req.Body, 10000000),
-	)
+	return s.ParseMulti(req, scm.Secrets(fn))
+}
+
+func (s *webhookService) ParseMulti(req *http.Request, fn scm.MultiSecretFunc) (scm.Webhook, error) {
+	body, data, err := s.opts.ReadBody(req)
 	if err != nil {
 		return nil, err
 	}
@@ -41,6 +42,8 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 		hook, err = s.parseCreateHook(data)
 	case "delete":
 		hook, err = s.parseDeleteHook(data)
+	case "fork":
+		hook, err = s.parseForkHook(data)
 	case "issues":
 		hook, err = s.parseIssueHook(data)
 	case "issue_comment":
@@ -55,6 +58,10 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	if err != nil {
 		return nil, err
 	}
+	scm.SetGUID(hook, req.Header.Get("X-Gitea-Delivery"))
+	if s.opts.RawPayload {
+		scm.SetRaw(hook, data)
+	}
 
 	if secret == "" {
 		secret = req.FormValue("secret")
@@ -63,10 +70,10 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	// get the gitea signature key to verify the payload
 	// signature. If no key is provided, no validation
 	// is performed.
-	key, err := fn(hook)
+	keys, err := fn(hook)
 	if err != nil {
 		return hook, err
-	} else if key == "" {
+	} else if len(keys) == 0 {
 		return hook, nil
 	}
 
@@ -78,12 +85,12 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	}
 
 	// test signature if header not set and secret is in payload
-	if signature == "" && secret != "" && secret != key {
+	if signature == "" && secret != "" && !scm.ValidateAny(keys, func(key string) bool { return hmac.Equal(secret, key) }) {
 		return hook, scm.ErrSignatureInvalid
 	}
 
 	// test signature using header
-	if signature != "" && !hmac.Validate(sha256.New, data, []byte(key), signature) {
+	if signature != "" && !scm.ValidateAny(keys, func(key string) bool { return hmac.Validate(sha256.New, body, []byte(key), signature) }) {
 		return hook, scm.ErrSignatureInvalid
 	}
 
@@ -122,6 +129,12 @@ func (s *webhookService) parseDeleteHook(data []byte) (scm.Webhook, error) {
 	}
 }
 
+func (s *webhookService) parseForkHook(data []byte) (scm.Webhook, error) {
+	dst := new(forkHook)
+	err := json.Unmarshal(data, dst)
+	return convertForkHook(dst), err
+}
+
 func (s *webhookService) parseIssueHook(data []byte) (scm.Webhook, error) {
 	dst := new(issueHook)
 	err := json.Unmarshal(data, dst)
@@ -177,6 +190,14 @@ type (
 		Sender        gitea.User       `json:"sender"`
 	}
 
+	// gitea fork webhook payload. Unlike github, the forkee
+	// is the repository that was forked.
+	forkHook struct {
+		Forkee     gitea.Repository `json:"forkee"`
+		Repository gitea.Repository `json:"repository"`
+		Sender     gitea.User       `json:"sender"`
+	}
+
 	// gitea issue webhook payload
 	issueHook struct {
 		Action     string           `json:"action"`
@@ -223,8 +244,16 @@ func convertTagHook(dst *createHook, action scm.Action) *scm.TagHook {
 			Name: dst.Ref,
 			Sha:  dst.Sha,
 		},
-		Repo:   *convertRepository(&dst.Repository),
-		Sender: *convertUser(&dst.Sender),
+		Repo:   convertRepositoryValue(&dst.Repository),
+		Sender: convertUserValue(&dst.Sender),
+	}
+}
+
+func convertForkHook(dst *forkHook) *scm.ForkHook {
+	return &scm.ForkHook{
+		Repo:   convertRepositoryValue(&dst.Forkee),
+		Fork:   convertRepositoryValue(&dst.Repository),
+		Sender: convertUserValue(&dst.Sender),
 	}
 }
 
@@ -234,15 +263,19 @@ func convertBranchHook(dst *createHook, action scm.Action) *scm.BranchHook {
 		Ref: scm.Reference{
 			Name: dst.Ref,
 		},
-		Repo:   *convertRepository(&dst.Repository),
-		Sender: *convertUser(&dst.Sender),
+		Repo:   convertRepositoryValue(&dst.Repository),
+		Sender: convertUserValue(&dst.Sender),
 	}
 }
 
 func convertPushHook(dst *pushHook) *scm.PushHook {
 	if len(dst.Commits) > 0 {
 		return &scm.PushHook{
-			Ref: dst.Ref,
+			Ref:     dst.Ref,
+			Before:  dst.Before,
+			After:   dst.After,
+			Created: dst.Before == scm.EmptyCommit,
+			Deleted: dst.After == scm.EmptyCommit,
 			Commit: scm.Commit{
 				Sha:     dst.After,
 				Message: dst.Commits[0].Message,
@@ -260,12 +293,16 @@ func convertPushHook(dst *pushHook) *scm.PushHook {
 					Date:  dst.Commits[0].Timestamp,
 				},
 			},
-			Repo:   *convertRepository(&dst.Repository),
-			Sender: *convertUser(&dst.Sender),
+			Repo:   convertRepositoryValue(&dst.Repository),
+			Sender: convertUserValue(&dst.Sender),
 		}
 	}
 	return &scm.PushHook{
-		Ref: dst.Ref,
+		Ref:     dst.Ref,
+		Before:  dst.Before,
+		After:   dst.After,
+		Created: dst.Before == scm.EmptyCommit,
+		Deleted: dst.After == scm.EmptyCommit,
 		Commit: scm.Commit{
 			Sha:  dst.After,
 			Link: dst.Compare,
@@ -280,32 +317,32 @@ func convertPushHook(dst *pushHook) *scm.PushHook {
 				Name:  dst.Pusher.FullName,
 			},
 		},
-		Repo:   *convertRepository(&dst.Repository),
-		Sender: *convertUser(&dst.Sender),
+		Repo:   convertRepositoryValue(&dst.Repository),
+		Sender: convertUserValue(&dst.Sender),
 	}
 }
 
 func convertPullRequestHook(dst *pullRequestHook) *scm.PullRequestHook {
 	return &scm.PullRequestHook{
 		Action:      convertAction(dst.Action),
-		PullRequest: *convertPullRequest(&dst.PullRequest),
-		Repo:        *convertRepository(&dst.Repository),
-		Sender:      *convertUser(&dst.Sender),
+		PullRequest: convertPullRequestValue(&dst.PullRequest),
+		Repo:        convertRepositoryValue(&dst.Repository),
+		Sender:      convertUserValue(&dst.Sender),
 	}
 }
 
 func convertPullRequestReviewPayload(dst *pullRequestReviewHook) *scm.Review {
 	return &scm.Review{
 		Body:   dst.Review.Content,
-		Author: *convertUser(&dst.Sender),
+		Author: convertUserValue(&dst.Sender),
 	}
 }
 
 func convertPullRequestReviewHook(dst *pullRequestReviewHook) *scm.ReviewHook {
 	return &scm.ReviewHook{
 		Action:      convertReviewAction(dst.Review.Type),
-		PullRequest: *convertPullRequest(&dst.PullRequest),
-		Repo:        *convertRepository(&dst.Repository),
+		PullRequest: convertPullRequestValue(&dst.PullRequest),
+		Repo:        convertRepositoryValue(&dst.Repository),
 		Review:      *convertPullRequestReviewPayload(dst),
 	}
 }
@@ -315,8 +352,8 @@ func convertPullRequestCommentHook(dst *issueHook) *scm.PullRequestCommentHook {
 		Action:      convertAction(dst.Action),
 		PullRequest: *convertPullRequestFromIssue(&dst.Issue),
 		Comment:     *convertIssueComment(&dst.Comment),
-		Repo:        *convertRepository(&dst.Repository),
-		Sender:      *convertUser(&dst.Sender),
+		Repo:        convertRepositoryValue(&dst.Repository),
+		Sender:      convertUserValue(&dst.Sender),
 	}
 }
 
@@ -324,8 +361,8 @@ func convertIssueHook(dst *issueHook) *scm.IssueHook {
 	return &scm.IssueHook{
 		Action: convertAction(dst.Action),
 		Issue:  *convertIssue(&dst.Issue),
-		Repo:   *convertRepository(&dst.Repository),
-		Sender: *convertUser(&dst.Sender),
+		Repo:   convertRepositoryValue(&dst.Repository),
+		Sender: convertUserValue(&dst.Sender),
 	}
 }
 
@@ -334,8 +371,8 @@ func convertIssueCommentHook(dst *issueHook) *scm.IssueCommentHook {
 		Action:  convertAction(dst.Action),
 		Issue:   *convertIssue(&dst.Issue),
 		Comment: *convertIssueComment(&dst.Comment),
-		Repo:    *convertRepository(&dst.Repository),
-		Sender:  *convertUser(&dst.Sender),
+		Repo:    convertRepositoryValue(&dst.Repository),
+		Sender:  convertUserValue(&dst.Sender),
 	}
 }
 
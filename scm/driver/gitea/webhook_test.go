@@ -51,6 +51,13 @@ func TestWebhooks(t *testing.T) {
 			after:  "testdata/webhooks/tag_delete.json.golden",
 			obj:    new(scm.TagHook),
 		},
+		// fork hooks
+		{
+			event:  "fork",
+			before: "testdata/webhooks/fork.json",
+			after:  "testdata/webhooks/fork.json.golden",
+			obj:    new(scm.ForkHook),
+		},
 		// push hooks
 		{
 			event:  "push",
@@ -0,0 +1,166 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitee
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type contentService struct {
+	client *wrapper
+}
+
+func (s *contentService) Find(ctx context.Context, repo, path, ref string) (*scm.Content, *scm.Response, error) {
+	endpoint := fmt.Sprintf("repos/%s/contents/%s?ref=%s", repo, path, ref)
+	out := new(content)
+	res, err := s.client.do(ctx, "GET", endpoint, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	raw, err := base64.StdEncoding.DecodeString(out.Content)
+	return &scm.Content{
+		Path: out.Path,
+		Data: raw,
+		Sha:  out.Sha,
+	}, res, err
+}
+
+func (s *contentService) FindMany(ctx context.Context, repo, ref string, paths []string) (map[string]*scm.Content, map[string]error) {
+	return scm.FindManyContents(ctx, s, repo, ref, paths, scm.DefaultConcurrency)
+}
+
+func (s *contentService) List(ctx context.Context, repo, path, ref string) ([]*scm.FileEntry, *scm.Response, error) {
+	endpoint := fmt.Sprintf("repos/%s/contents/%s?ref=%s", repo, path, ref)
+	out := []*content{}
+	res, err := s.client.do(ctx, "GET", endpoint, nil, &out)
+	return convertEntryList(out), res, err
+}
+
+func (s *contentService) Exists(ctx context.Context, repo, path, ref string) (bool, *scm.Response, error) {
+	_, res, err := s.Stat(ctx, repo, path, ref)
+	if err == scm.ErrNotFound {
+		return false, res, nil
+	}
+	return err == nil, res, err
+}
+
+// Stat returns the file metadata from the contents endpoint,
+// which lists the entries of a directory rather than
+// describing it, so a directory is looked up in the listing
+// of its parent.
+func (s *contentService) Stat(ctx context.Context, repo, path, ref string) (*scm.FileEntry, *scm.Response, error) {
+	path = strings.Trim(path, "/")
+	endpoint := fmt.Sprintf("repos/%s/contents/%s?ref=%s", repo, path, ref)
+	out := json.RawMessage{}
+	res, err := s.client.do(ctx, "GET", endpoint, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(out), []byte("[")) {
+		file := new(content)
+		if err := json.Unmarshal(out, file); err != nil {
+			return nil, res, err
+		}
+		return convertEntry(file), res, nil
+	}
+	dir := ""
+	if i := strings.LastIndex(path, "/"); i != -1 {
+		dir = path[:i]
+	}
+	entries, res, err := s.List(ctx, repo, dir, ref)
+	if err != nil {
+		return nil, res, err
+	}
+	for _, entry := range entries {
+		if entry.Path == path {
+			return entry, res, nil
+		}
+	}
+	return nil, res, scm.ErrNotFound
+}
+
+func (s *contentService) Create(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	endpoint := fmt.Sprintf("repos/%s/contents/%s", repo, path)
+	in := &contentInput{
+		Message: params.Message,
+		Content: params.Data,
+		Branch:  params.Branch,
+	}
+	return s.client.do(ctx, "POST", endpoint, in, nil)
+}
+
+func (s *contentService) Update(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	endpoint := fmt.Sprintf("repos/%s/contents/%s", repo, path)
+	in := &contentInput{
+		Message: params.Message,
+		Content: params.Data,
+		Branch:  params.Branch,
+		Sha:     params.Sha,
+	}
+	return s.client.do(ctx, "PUT", endpoint, in, nil)
+}
+
+// Delete is not supported: Gitee requires the sha and the
+// commit message of the deleted file.
+func (s *contentService) Delete(ctx context.Context, repo, path, ref string) (*scm.Response, error) {
+	return nil, notSupported("Contents", "Delete")
+}
+
+//
+// native data structures
+//
+
+type (
+	// gitee file or directory entry. The content is only
+	// returned for a file.
+	content struct {
+		Type    string `json:"type"`
+		Name    string `json:"name"`
+		Path    string `json:"path"`
+		Size    int    `json:"size"`
+		Sha     string `json:"sha"`
+		Content string `json:"content"`
+		HTMLURL string `json:"html_url"`
+	}
+
+	// gitee content request object. The content is encoded
+	// in base64 by encoding/json.
+	contentInput struct {
+		Message string `json:"message"`
+		Content []byte `json:"content"`
+		Branch  string `json:"branch,omitempty"`
+		Sha     string `json:"sha,omitempty"`
+	}
+)
+
+//
+// native data structure conversion
+//
+
+func convertEntryList(from []*content) []*scm.FileEntry {
+	to := make([]*scm.FileEntry, 0, len(from))
+	for _, v := range from {
+		to = append(to, convertEntry(v))
+	}
+	return to
+}
+
+func convertEntry(from *content) *scm.FileEntry {
+	return &scm.FileEntry{
+		Name: from.Name,
+		Path: from.Path,
+		Type: from.Type,
+		Size: from.Size,
+		Sha:  from.Sha,
+		Link: from.HTMLURL,
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitee
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestContentFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/contents/README.md").
+		MatchParam("ref", "master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/content.json")
+
+	client := NewDefault()
+	got, _, err := client.Contents.Find(context.Background(), "octocat/hello-world", "README.md", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &scm.Content{
+		Path: "README.md",
+		Data: []byte("Hello World\n"),
+		Sha:  "980a0d5f19a64b4b30a87d4206aade58726b60e3",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestContentList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/contents/").
+		MatchParam("ref", "master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/content_list.json")
+
+	client := NewDefault()
+	got, _, err := client.Contents.List(context.Background(), "octocat/hello-world", "", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.FileEntry{}
+	raw, _ := ioutil.ReadFile("testdata/content_list.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestContentStatDir(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/contents/docs").
+		MatchParam("ref", "master").
+		Reply(200).
+		Type("application/json").
+		BodyString("[]")
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/contents/").
+		MatchParam("ref", "master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/content_list.json")
+
+	client := NewDefault()
+	got, _, err := client.Contents.Stat(context.Background(), "octocat/hello-world", "docs", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Type != "dir" || got.Path != "docs" {
+		t.Errorf("Want the docs directory, got %+v", got)
+	}
+}
+
+func TestContentExists(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/contents/missing.md").
+		Reply(404).
+		Type("application/json").
+		BodyString(`{"message":"Not Found"}`)
+
+	client := NewDefault()
+	got, _, err := client.Contents.Exists(context.Background(), "octocat/hello-world", "missing.md", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got {
+		t.Errorf("Want the file not to exist")
+	}
+}
+
+func TestContentCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Post("/api/v5/repos/octocat/hello-world/contents/README.md").
+		JSON(map[string]interface{}{
+			"message": "my commit message",
+			"content": "SGVsbG8gV29ybGQK",
+			"branch":  "master",
+		}).
+		Reply(201).
+		Type("application/json").
+		BodyString("{}")
+
+	client := NewDefault()
+	params := &scm.ContentParams{
+		Message: "my commit message",
+		Data:    []byte("Hello World\n"),
+		Branch:  "master",
+	}
+	_, err := client.Contents.Create(context.Background(), "octocat/hello-world", "README.md", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitee
+
+import (
+	"context"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// gitService is a stub: the driver does not implement the
+// Gitee branch, commit and tag APIs yet.
+type gitService struct {
+	client *wrapper
+}
+
+func (s *gitService) FindBranch(context.Context, string, string) (*scm.Reference, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "FindBranch")
+}
+
+func (s *gitService) FindCommit(context.Context, string, string) (*scm.Commit, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "FindCommit")
+}
+
+func (s *gitService) FindTag(context.Context, string, string) (*scm.Reference, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "FindTag")
+}
+
+func (s *gitService) ListBranches(context.Context, string, scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "ListBranches")
+}
+
+func (s *gitService) ListCommits(context.Context, string, scm.CommitListOptions) ([]*scm.Commit, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "ListCommits")
+}
+
+func (s *gitService) ListChanges(context.Context, string, string, scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "ListChanges")
+}
+
+func (s *gitService) CompareCommits(context.Context, string, string, string) (*scm.Comparison, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "CompareCommits")
+}
+
+func (s *gitService) CompareAcrossForks(context.Context, string, string, string, string) (*scm.Comparison, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "CompareAcrossForks")
+}
+
+func (s *gitService) ListTags(context.Context, string, scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "ListTags")
+}
+
+func (s *gitService) FindRef(context.Context, string, string) (string, *scm.Response, error) {
+	return "", nil, notSupported("Git", "FindRef")
+}
+
+func (s *gitService) ResolveRefs(ctx context.Context, repo string, refs []string) (map[string]string, map[string]error) {
+	return scm.ResolveRefs(ctx, s, repo, refs, scm.DefaultConcurrency)
+}
+
+func (s *gitService) DeleteRef(context.Context, string, string) (*scm.Response, error) {
+	return nil, notSupported("Git", "DeleteRef")
+}
+
+func (s *gitService) CreateRef(context.Context, string, string, string) (*scm.Reference, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "CreateRef")
+}
+
+func (s *gitService) UpdateRef(context.Context, string, string, string, bool) (*scm.Reference, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "UpdateRef")
+}
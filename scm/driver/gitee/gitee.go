@@ -0,0 +1,167 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gitee implements a Gitee client.
+package gitee
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// Reference API Documentation:
+//   https://gitee.com/api/v5/swagger
+
+// NewWebHookService creates a new instance of the webhook service without the rest of the client
+func NewWebHookService(opts ...scm.WebhookServiceOptions) scm.WebhookService {
+	s := &webhookService{}
+	if len(opts) > 0 {
+		s.opts = opts[0]
+	}
+	return s
+}
+
+// New returns a new Gitee API client.
+func New(uri string) (*scm.Client, error) {
+	base, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path = base.Path + "/"
+	}
+	client := &wrapper{new(scm.Client)}
+	client.BaseURL = base
+	// initialize services
+	client.Driver = scm.DriverGitee
+	client.SetCaller(client.do)
+	client.Contents = &contentService{client}
+	client.Git = &gitService{client}
+	client.Issues = &issueService{client}
+	client.Milestones = &milestoneService{client}
+	client.Organizations = &organizationService{client}
+	client.PullRequests = &pullService{client}
+	client.Repositories = &repositoryService{client}
+	client.Reviews = &reviewService{client}
+	client.Users = &userService{client}
+	client.Webhooks = &webhookService{client: client}
+	return client.Client, nil
+}
+
+// NewDefault returns a new Gitee API client using the
+// default gitee.com/api/v5 address.
+func NewDefault() *scm.Client {
+	client, _ := New("https://gitee.com/api/v5")
+	return client
+}
+
+// wraper wraps the Client to provide high level helper functions
+// for making http requests and unmarshaling the response.
+type wrapper struct {
+	*scm.Client
+}
+
+// do wraps the Client.Do function by creating the Request and
+// unmarshalling the response.
+func (c *wrapper) do(ctx context.Context, method, path string, in, out interface{}) (*scm.Response, error) {
+	req := &scm.Request{
+		Method: method,
+		Path:   path,
+	}
+	// if we are posting or putting data, we need to
+	// write it to the body of the request.
+	if in != nil {
+		buf := new(bytes.Buffer)
+		json.NewEncoder(buf).Encode(in) // #nosec
+		req.Header = map[string][]string{
+			"Content-Type": {"application/json"},
+		}
+		req.Body = buf
+	}
+
+	// execute the http request
+	res, err := c.Client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// if an error is encountered, unmarshal and return the
+	// error response.
+	if res.Status == http.StatusNotFound {
+		return res, scm.ErrNotFound
+	} else if res.Status == http.StatusUnauthorized {
+		return res, scm.ErrNotAuthorized
+	} else if res.Status > 300 {
+		err := &Error{status: res.Status}
+		json.NewDecoder(res.Body).Decode(err) // #nosec
+		return res, err
+	}
+
+	if out == nil {
+		return res, nil
+	}
+
+	// if raw output is expected, copy to the provided
+	// buffer and exit.
+	if w, ok := out.(io.Writer); ok {
+		_, err := io.Copy(w, res.Body)
+		return res, err
+	}
+
+	// if a json response is expected, parse and return
+	// the json response.
+	return res, json.NewDecoder(res.Body).Decode(out)
+}
+
+// populatePageValues sets the pages of the response from the
+// total_page header, since Gitee sends no Link header.
+func populatePageValues(res *scm.Response, page int) {
+	if res == nil {
+		return
+	}
+	total, err := strconv.Atoi(res.Header.Get("total_page"))
+	if err != nil || total == 0 {
+		return
+	}
+	if page < 1 {
+		page = 1
+	}
+	res.Page.First = 1
+	res.Page.Last = total
+	if page > 1 {
+		res.Page.Prev = page - 1
+	}
+	if page < total {
+		res.Page.Next = page + 1
+	}
+}
+
+// Error represents a Gitee error.
+type Error struct {
+	Message string `json:"message"`
+
+	status int
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return http.StatusText(e.status)
+	}
+	return e.Message
+}
+
+// notSupported returns the error of a method of a service
+// the driver does not support.
+func notSupported(service, method string) error {
+	return &scm.NotSupportedError{Driver: scm.DriverGitee, Service: service, Method: method}
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitee
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestClient(t *testing.T) {
+	client, err := New("https://gitee.com/api/v5")
+	if err != nil {
+		t.Error(err)
+	}
+	if got, want := client.BaseURL.String(), "https://gitee.com/api/v5/"; got != want {
+		t.Errorf("Want Client URL %q, got %q", want, got)
+	}
+}
+
+func TestClient_Default(t *testing.T) {
+	client := NewDefault()
+	if got, want := client.BaseURL.String(), "https://gitee.com/api/v5/"; got != want {
+		t.Errorf("Want Client URL %q, got %q", want, got)
+	}
+	if got, want := client.Driver, scm.DriverGitee; got != want {
+		t.Errorf("Want Driver %v, got %v", want, got)
+	}
+}
+
+func TestClient_Error(t *testing.T) {
+	_, err := New("http://a b.com/")
+	if err == nil {
+		t.Errorf("Expect error when invalid URL")
+	}
+}
+
+func TestClient_NotSupported(t *testing.T) {
+	client := NewDefault()
+	if _, _, err := client.Git.FindBranch(context.Background(), "octocat/hello-world", "master"); !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error from the git service")
+	}
+	if _, _, err := client.Reviews.List(context.Background(), "octocat/hello-world", 1, scm.ListOptions{}); !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error from the review service")
+	}
+	if _, _, err := client.Milestones.Find(context.Background(), "octocat/hello-world", 1); !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error from the milestone service")
+	}
+	if _, _, err := client.Organizations.Find(context.Background(), "octocat"); !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error from the organization service")
+	}
+}
+
+func TestClient_ErrorMessage(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world").
+		Reply(403).
+		Type("application/json").
+		BodyString(`{"message":"Forbidden by the repository"}`)
+
+	client := NewDefault()
+	_, _, err := client.Repositories.Find(context.Background(), "octocat/hello-world")
+	if err == nil || err.Error() != "Forbidden by the repository" {
+		t.Errorf("Want the error message of the response, got %v", err)
+	}
+}
+
+func TestClient_NotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world").
+		Reply(404).
+		Type("application/json").
+		BodyString(`{"message":"Not Found Project"}`)
+
+	client := NewDefault()
+	_, _, err := client.Repositories.Find(context.Background(), "octocat/hello-world")
+	if err != scm.ErrNotFound {
+		t.Errorf("Want scm.ErrNotFound, got %v", err)
+	}
+}
+
+func TestIssueNumber(t *testing.T) {
+	tests := []struct {
+		id     string
+		number int
+	}{
+		{"I1DACG", 2299552},
+		{"I1", 1},
+		{"IZZ", 1295},
+	}
+	for _, test := range tests {
+		if got := issueNumber(test.id); got != test.number {
+			t.Errorf("Want issue number %d of %s, got %d", test.number, test.id, got)
+		}
+		if got := issueID(test.number); got != test.id {
+			t.Errorf("Want issue id %s of %d, got %s", test.id, test.number, got)
+		}
+	}
+	if got := issueNumber("1DACG"); got != 0 {
+		t.Errorf("Want zero issue number of an invalid id, got %d", got)
+	}
+}
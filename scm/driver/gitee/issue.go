@@ -0,0 +1,340 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitee
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type issueService struct {
+	client *wrapper
+}
+
+func (s *issueService) Find(ctx context.Context, repo string, number int) (*scm.Issue, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/issues/%s", repo, issueID(number))
+	out := new(issue)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertIssue(out), res, err
+}
+
+func (s *issueService) FindComment(ctx context.Context, repo string, index, id int) (*scm.Comment, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/issues/comments/%d", repo, id)
+	out := new(comment)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertComment(out), res, err
+}
+
+func (s *issueService) List(ctx context.Context, repo string, opts scm.IssueListOptions) ([]*scm.Issue, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/issues?%s", repo, encodeIssueListOptions(opts))
+	out := []*issue{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	populatePageValues(res, opts.Page)
+	return scm.FilterIssues(convertIssueList(out), opts.UpdatedSince), res, err
+}
+
+func (s *issueService) Search(context.Context, scm.SearchOptions) ([]*scm.SearchIssue, *scm.Response, error) {
+	return nil, nil, notSupported("Issues", "Search")
+}
+
+func (s *issueService) ListComments(ctx context.Context, repo string, index int, opts scm.CommentListOptions) ([]*scm.Comment, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/issues/%s/comments?%s", repo, issueID(index), encodeCommentListOptions(opts))
+	out := []*comment{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
+	populatePageValues(res, opts.Page)
+	return scm.FilterComments(convertCommentList(out), opts), res, nil
+}
+
+func (s *issueService) ListLabels(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/issues/%s/labels", repo, issueID(number))
+	out := []*label{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertLabelList(out), res, err
+}
+
+func (s *issueService) ListEvents(context.Context, string, int, scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
+	return nil, nil, notSupported("Issues", "ListEvents")
+}
+
+// Create creates the issue. The issues are created in the
+// namespace of the repository, which is named in the body.
+func (s *issueService) Create(ctx context.Context, repo string, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	owner, name := scm.Split(repo)
+	path := fmt.Sprintf("repos/%s/issues", owner)
+	in := &issueInput{
+		Repo:      name,
+		Title:     input.Title,
+		Body:      input.Body,
+		Labels:    strings.Join(input.Labels, ","),
+		Milestone: input.Milestone,
+	}
+	if len(input.Assignees) != 0 {
+		in.Assignee = input.Assignees[0]
+	}
+	out := new(issue)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertIssue(out), res, err
+}
+
+func (s *issueService) Update(ctx context.Context, repo string, number int, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	// gitee issues have a single assignee.
+	if len(input.Assignees) > 1 {
+		return nil, nil, notSupported("Issues", "Update")
+	}
+	owner, name := scm.Split(repo)
+	path := fmt.Sprintf("repos/%s/issues/%s", owner, issueID(number))
+	in := &issueInput{
+		Repo:      name,
+		Title:     input.Title,
+		Body:      input.Body,
+		Labels:    strings.Join(input.Labels, ","),
+		Milestone: input.Milestone,
+		State:     input.TargetState(),
+	}
+	if len(input.Assignees) == 1 {
+		in.Assignee = input.Assignees[0]
+	}
+	out := new(issue)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertIssue(out), res, err
+}
+
+func (s *issueService) CreateComment(ctx context.Context, repo string, number int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/issues/%s/comments", repo, issueID(number))
+	in := &commentInput{
+		Body: input.Body,
+	}
+	out := new(comment)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertComment(out), res, err
+}
+
+func (s *issueService) DeleteComment(ctx context.Context, repo string, number, id int) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/issues/comments/%d", repo, id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *issueService) EditComment(ctx context.Context, repo string, number int, id int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/issues/comments/%d", repo, id)
+	in := &commentInput{
+		Body: input.Body,
+	}
+	out := new(comment)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertComment(out), res, err
+}
+
+func (s *issueService) MinimizeComment(ctx context.Context, repo string, number int, id int, reason string) (*scm.Response, error) {
+	return nil, notSupported("Issues", "MinimizeComment")
+}
+
+func (s *issueService) UnminimizeComment(ctx context.Context, repo string, number int, id int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "UnminimizeComment")
+}
+
+func (s *issueService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setState(ctx, repo, number, "closed")
+}
+
+func (s *issueService) Reopen(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setState(ctx, repo, number, "open")
+}
+
+func (s *issueService) setState(ctx context.Context, repo string, number int, state string) (*scm.Response, error) {
+	owner, name := scm.Split(repo)
+	path := fmt.Sprintf("repos/%s/issues/%s", owner, issueID(number))
+	in := &issueInput{Repo: name, State: state}
+	return s.client.do(ctx, "PATCH", path, in, nil)
+}
+
+func (s *issueService) Lock(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "Lock")
+}
+
+func (s *issueService) Unlock(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "Unlock")
+}
+
+func (s *issueService) AddLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/issues/%s/labels", repo, issueID(number))
+	in := []string{label}
+	return s.client.do(ctx, "POST", path, in, nil)
+}
+
+func (s *issueService) DeleteLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/issues/%s/labels/%s", repo, issueID(number), url.PathEscape(label))
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *issueService) AssignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
+	return nil, notSupported("Issues", "AssignIssue")
+}
+
+func (s *issueService) UnassignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
+	return nil, notSupported("Issues", "UnassignIssue")
+}
+
+func (s *issueService) SetMilestone(ctx context.Context, repo string, issueID int, number int) (*scm.Response, error) {
+	_, res, err := s.Update(ctx, repo, issueID, &scm.IssueInput{Milestone: number})
+	return res, err
+}
+
+func (s *issueService) ClearMilestone(ctx context.Context, repo string, id int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "ClearMilestone")
+}
+
+func (s *issueService) Pin(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "Pin")
+}
+
+func (s *issueService) Unpin(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "Unpin")
+}
+
+func (s *issueService) ListPinned(ctx context.Context, repo string) ([]*scm.Issue, *scm.Response, error) {
+	return nil, nil, notSupported("Issues", "ListPinned")
+}
+
+//
+// native data structures
+//
+
+type (
+	// gitee issue response object. The number is the
+	// identifier of the issue, such as I1DACG.
+	issue struct {
+		ID        int       `json:"id"`
+		Number    string    `json:"number"`
+		HTMLURL   string    `json:"html_url"`
+		User      user      `json:"user"`
+		Assignee  *user     `json:"assignee"`
+		Title     string    `json:"title"`
+		Body      string    `json:"body"`
+		State     string    `json:"state"`
+		Labels    []*label  `json:"labels"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+		// FinishedAt is the time the issue was closed.
+		FinishedAt *time.Time `json:"finished_at"`
+	}
+
+	// gitee issue request object.
+	issueInput struct {
+		Repo      string `json:"repo"`
+		Title     string `json:"title,omitempty"`
+		Body      string `json:"body,omitempty"`
+		Labels    string `json:"labels,omitempty"`
+		Assignee  string `json:"assignee,omitempty"`
+		Milestone int    `json:"milestone,omitempty"`
+		State     string `json:"state,omitempty"`
+	}
+
+	// gitee label response object.
+	label struct {
+		ID    int64  `json:"id"`
+		Name  string `json:"name"`
+		Color string `json:"color"`
+		URL   string `json:"url"`
+	}
+
+	// gitee comment response object, of an issue or a
+	// pull request.
+	comment struct {
+		ID        int       `json:"id"`
+		HTMLURL   string    `json:"html_url"`
+		User      user      `json:"user"`
+		Body      string    `json:"body"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+
+	// gitee comment request object.
+	commentInput struct {
+		Body string `json:"body"`
+	}
+)
+
+//
+// native data structure conversion
+//
+
+func convertIssueList(from []*issue) []*scm.Issue {
+	to := []*scm.Issue{}
+	for _, v := range from {
+		to = append(to, convertIssue(v))
+	}
+	return to
+}
+
+// convertIssue converts the issue. Gitee issues are open,
+// progressing, closed or rejected.
+func convertIssue(from *issue) *scm.Issue {
+	closed := from.State == "closed" || from.State == "rejected"
+	to := &scm.Issue{
+		Number:  issueNumber(from.Number),
+		Title:   from.Title,
+		Body:    from.Body,
+		Link:    from.HTMLURL,
+		State:   scm.IssueStateOpen,
+		Labels:  convertLabelList(from.Labels),
+		Closed:  closed,
+		Author:  *convertUser(&from.User),
+		Created: from.CreatedAt.UTC(),
+		Updated: from.UpdatedAt.UTC(),
+	}
+	if closed {
+		to.State = scm.IssueStateClosed
+		if from.FinishedAt != nil {
+			to.ClosedAt = from.FinishedAt.UTC()
+		}
+	}
+	if from.Assignee != nil && userLogin(from.Assignee) != "" {
+		to.Assignees = []scm.User{*convertUser(from.Assignee)}
+	}
+	return to
+}
+
+func convertLabelList(from []*label) []*scm.Label {
+	var labels []*scm.Label
+	for _, label := range from {
+		if label == nil {
+			continue
+		}
+		labels = append(labels, &scm.Label{
+			ID:    label.ID,
+			Name:  label.Name,
+			Color: label.Color,
+			URL:   label.URL,
+		})
+	}
+	return labels
+}
+
+func convertCommentList(from []*comment) []*scm.Comment {
+	to := []*scm.Comment{}
+	for _, v := range from {
+		to = append(to, convertComment(v))
+	}
+	return to
+}
+
+func convertComment(from *comment) *scm.Comment {
+	return &scm.Comment{
+		ID:      from.ID,
+		Body:    from.Body,
+		Author:  *convertUser(&from.User),
+		Link:    from.HTMLURL,
+		Created: from.CreatedAt.UTC(),
+		Updated: from.UpdatedAt.UTC(),
+	}
+}
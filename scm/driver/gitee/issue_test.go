@@ -0,0 +1,197 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitee
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestIssueFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/issues/I1DACG").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	client := NewDefault()
+	got, _, err := client.Issues.Find(context.Background(), "octocat/hello-world", issueNumber("I1DACG"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Issue)
+	raw, _ := ioutil.ReadFile("testdata/issue.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/issues").
+		MatchParam("state", "all").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issues.json")
+
+	client := NewDefault()
+	got, _, err := client.Issues.List(context.Background(), "octocat/hello-world", scm.IssueListOptions{Open: true, Closed: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Issue{}
+	raw, _ := ioutil.ReadFile("testdata/issues.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Post("/api/v5/repos/octocat/issues").
+		JSON(map[string]interface{}{
+			"repo":   "hello-world",
+			"title":  "Found a bug",
+			"body":   "I'm having a problem with this.",
+			"labels": "bug",
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	client := NewDefault()
+	input := &scm.IssueInput{
+		Title:  "Found a bug",
+		Body:   "I'm having a problem with this.",
+		Labels: []string{"bug"},
+	}
+	got, _, err := client.Issues.Create(context.Background(), "octocat/hello-world", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Issue)
+	raw, _ := ioutil.ReadFile("testdata/issue.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueClose(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Patch("/api/v5/repos/octocat/issues/I1DACG").
+		JSON(map[string]interface{}{
+			"repo":  "hello-world",
+			"state": "closed",
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	client := NewDefault()
+	_, err := client.Issues.Close(context.Background(), "octocat/hello-world", issueNumber("I1DACG"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestIssueCommentList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/issues/I1DACG/comments").
+		Reply(200).
+		Type("application/json").
+		File("testdata/comments.json")
+
+	client := NewDefault()
+	got, _, err := client.Issues.ListComments(context.Background(), "octocat/hello-world", issueNumber("I1DACG"), scm.CommentListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Comment{}
+	raw, _ := ioutil.ReadFile("testdata/comments.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueCommentCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Post("/api/v5/repos/octocat/hello-world/issues/I1DACG/comments").
+		JSON(map[string]interface{}{"body": "Me too"}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/comment.json")
+
+	client := NewDefault()
+	got, _, err := client.Issues.CreateComment(context.Background(), "octocat/hello-world", issueNumber("I1DACG"), &scm.CommentInput{Body: "Me too"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Comment)
+	raw, _ := ioutil.ReadFile("testdata/comment.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueAddLabel(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Post("/api/v5/repos/octocat/hello-world/issues/I1DACG/labels").
+		JSON([]string{"bug"}).
+		Reply(201).
+		Type("application/json").
+		BodyString("[]")
+
+	client := NewDefault()
+	_, err := client.Issues.AddLabel(context.Background(), "octocat/hello-world", issueNumber("I1DACG"), "bug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
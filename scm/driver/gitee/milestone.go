@@ -0,0 +1,37 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitee
+
+import (
+	"context"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// milestoneService is a stub: the driver does not implement
+// the Gitee milestone API yet.
+type milestoneService struct {
+	client *wrapper
+}
+
+func (s *milestoneService) Find(context.Context, string, int) (*scm.Milestone, *scm.Response, error) {
+	return nil, nil, notSupported("Milestones", "Find")
+}
+
+func (s *milestoneService) List(context.Context, string, scm.MilestoneListOptions) ([]*scm.Milestone, *scm.Response, error) {
+	return nil, nil, notSupported("Milestones", "List")
+}
+
+func (s *milestoneService) Create(context.Context, string, *scm.MilestoneInput) (*scm.Milestone, *scm.Response, error) {
+	return nil, nil, notSupported("Milestones", "Create")
+}
+
+func (s *milestoneService) Update(context.Context, string, int, *scm.MilestoneInput) (*scm.Milestone, *scm.Response, error) {
+	return nil, nil, notSupported("Milestones", "Update")
+}
+
+func (s *milestoneService) Delete(context.Context, string, int) (*scm.Response, error) {
+	return nil, notSupported("Milestones", "Delete")
+}
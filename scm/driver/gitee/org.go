@@ -0,0 +1,65 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitee
+
+import (
+	"context"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// organizationService is a stub: the driver does not
+// implement the Gitee organization API yet.
+type organizationService struct {
+	client *wrapper
+}
+
+func (s *organizationService) Find(context.Context, string) (*scm.Organization, *scm.Response, error) {
+	return nil, nil, notSupported("Organizations", "Find")
+}
+
+func (s *organizationService) Create(context.Context, *scm.OrganizationInput) (*scm.Organization, *scm.Response, error) {
+	return nil, nil, notSupported("Organizations", "Create")
+}
+
+func (s *organizationService) Delete(context.Context, string) (*scm.Response, error) {
+	return nil, notSupported("Organizations", "Delete")
+}
+
+func (s *organizationService) List(context.Context, scm.ListOptions) ([]*scm.Organization, *scm.Response, error) {
+	return nil, nil, notSupported("Organizations", "List")
+}
+
+func (s *organizationService) ListTeams(context.Context, string, scm.ListOptions) ([]*scm.Team, *scm.Response, error) {
+	return nil, nil, notSupported("Organizations", "ListTeams")
+}
+
+func (s *organizationService) IsMember(context.Context, string, string) (bool, *scm.Response, error) {
+	return false, nil, notSupported("Organizations", "IsMember")
+}
+
+func (s *organizationService) IsAdmin(context.Context, string, string) (bool, *scm.Response, error) {
+	return false, nil, notSupported("Organizations", "IsAdmin")
+}
+
+func (s *organizationService) ListTeamMembers(context.Context, int, string, scm.ListOptions) ([]*scm.TeamMember, *scm.Response, error) {
+	return nil, nil, notSupported("Organizations", "ListTeamMembers")
+}
+
+func (s *organizationService) ListOrgMembers(context.Context, string, scm.ListOptions) ([]*scm.TeamMember, *scm.Response, error) {
+	return nil, nil, notSupported("Organizations", "ListOrgMembers")
+}
+
+func (s *organizationService) ListPendingInvitations(context.Context, string, scm.ListOptions) ([]*scm.OrganizationPendingInvite, *scm.Response, error) {
+	return nil, nil, notSupported("Organizations", "ListPendingInvitations")
+}
+
+func (s *organizationService) AcceptOrganizationInvitation(context.Context, string) (*scm.Response, error) {
+	return nil, notSupported("Organizations", "AcceptOrganizationInvitation")
+}
+
+func (s *organizationService) ListMemberships(context.Context, scm.ListOptions) ([]*scm.Membership, *scm.Response, error) {
+	return nil, nil, notSupported("Organizations", "ListMemberships")
+}
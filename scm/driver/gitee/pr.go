@@ -0,0 +1,392 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitee
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type pullService struct {
+	client *wrapper
+}
+
+func (s *pullService) Find(ctx context.Context, repo string, number int) (*scm.PullRequest, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d", repo, number)
+	out := new(pr)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertPullRequest(out), res, err
+}
+
+// List lists the pull requests. Gitee lists the merged pull
+// requests separately from the closed ones, so all the pull
+// requests are requested and the open ones are filtered out
+// when listing only the closed pull requests.
+func (s *pullService) List(ctx context.Context, repo string, opts scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls?%s", repo, encodePullRequestListOptions(opts))
+	out := []*pr{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
+	populatePageValues(res, opts.Page)
+	prs := convertPullRequestList(out)
+	if opts.Closed && !opts.Open {
+		closed := prs[:0]
+		for _, p := range prs {
+			if p.Closed {
+				closed = append(closed, p)
+			}
+		}
+		prs = closed
+	}
+	return scm.FilterPullRequests(prs, opts), res, nil
+}
+
+func (s *pullService) ListChanges(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d/files?%s", repo, number, encodeListOptions(opts))
+	out := []*file{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertChangeList(out), res, err
+}
+
+func (s *pullService) ListLabels(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d/labels?%s", repo, number, encodeListOptions(opts))
+	out := []*label{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	populatePageValues(res, opts.Page)
+	return convertLabelList(out), res, err
+}
+
+func (s *pullService) ListEvents(context.Context, string, int, scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
+	return nil, nil, notSupported("PullRequests", "ListEvents")
+}
+
+func (s *pullService) AddLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d/labels", repo, number)
+	in := []string{label}
+	return s.client.do(ctx, "POST", path, in, nil)
+}
+
+func (s *pullService) DeleteLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d/labels/%s", repo, number, url.PathEscape(label))
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *pullService) Merge(ctx context.Context, repo string, number int, options *scm.PullRequestMergeOptions) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d/merge", repo, number)
+	in := &prMergeInput{}
+	if options != nil {
+		in.MergeMethod = options.MergeMethod
+		in.Title = options.CommitTitle
+		in.PruneSourceBranch = options.DeleteSourceBranch
+	}
+	return s.client.do(ctx, "PUT", path, in, nil)
+}
+
+func (s *pullService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d", repo, number)
+	in := &prInput{State: "closed"}
+	return s.client.do(ctx, "PATCH", path, in, nil)
+}
+
+func (s *pullService) Reopen(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d", repo, number)
+	in := &prInput{State: "open"}
+	return s.client.do(ctx, "PATCH", path, in, nil)
+}
+
+func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls", repo)
+	in := &prInput{
+		Title:           input.Title,
+		Head:            input.Head,
+		Base:            input.Base,
+		Body:            input.Body,
+		MilestoneNumber: input.Milestone,
+		Labels:          strings.Join(input.Labels, ","),
+		Assignees:       strings.Join(input.Assignees, ","),
+	}
+	out := new(pr)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertPullRequest(out), res, err
+}
+
+func (s *pullService) Update(ctx context.Context, repo string, number int, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d", repo, number)
+	in := &prInput{
+		Title:           input.Title,
+		Body:            input.Body,
+		MilestoneNumber: input.Milestone,
+		Labels:          strings.Join(input.Labels, ","),
+	}
+	out := new(pr)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertPullRequest(out), res, err
+}
+
+func (s *pullService) FindComment(ctx context.Context, repo string, number, id int) (*scm.Comment, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/comments/%d", repo, id)
+	out := new(comment)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertComment(out), res, err
+}
+
+func (s *pullService) ListComments(ctx context.Context, repo string, number int, opts scm.CommentListOptions) ([]*scm.Comment, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d/comments?%s", repo, number, encodeCommentListOptions(opts))
+	out := []*comment{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
+	populatePageValues(res, opts.Page)
+	return scm.FilterComments(convertCommentList(out), opts), res, nil
+}
+
+func (s *pullService) CreateComment(ctx context.Context, repo string, number int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d/comments", repo, number)
+	in := &commentInput{
+		Body: input.Body,
+	}
+	out := new(comment)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertComment(out), res, err
+}
+
+func (s *pullService) DeleteComment(ctx context.Context, repo string, number, id int) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/comments/%d", repo, id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *pullService) EditComment(ctx context.Context, repo string, number int, id int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/comments/%d", repo, id)
+	in := &commentInput{
+		Body: input.Body,
+	}
+	out := new(comment)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertComment(out), res, err
+}
+
+// AssignIssue is not supported: the assignees of a Gitee pull
+// request are its reviewers, see RequestReview.
+func (s *pullService) AssignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
+	return nil, notSupported("PullRequests", "AssignIssue")
+}
+
+func (s *pullService) UnassignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
+	return nil, notSupported("PullRequests", "UnassignIssue")
+}
+
+func (s *pullService) RequestReview(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d/assignees", repo, number)
+	in := &prAssigneesInput{Assignees: strings.Join(logins, ",")}
+	return s.client.do(ctx, "POST", path, in, nil)
+}
+
+func (s *pullService) UnrequestReview(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
+	params := url.Values{}
+	params.Set("assignees", strings.Join(logins, ","))
+	path := fmt.Sprintf("repos/%s/pulls/%d/assignees?%s", repo, number, params.Encode())
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *pullService) SetMilestone(ctx context.Context, repo string, prID int, number int) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d", repo, prID)
+	in := &prInput{MilestoneNumber: number}
+	return s.client.do(ctx, "PATCH", path, in, nil)
+}
+
+func (s *pullService) ClearMilestone(ctx context.Context, repo string, prID int) (*scm.Response, error) {
+	return nil, notSupported("PullRequests", "ClearMilestone")
+}
+
+//
+// native data structures
+//
+
+type (
+	// gitee pull request resource. The state is open,
+	// closed or merged.
+	pr struct {
+		ID        int        `json:"id"`
+		Number    int        `json:"number"`
+		State     string     `json:"state"`
+		Title     string     `json:"title"`
+		Body      string     `json:"body"`
+		HTMLURL   string     `json:"html_url"`
+		User      user       `json:"user"`
+		Assignees []*user    `json:"assignees"`
+		Labels    []*label   `json:"labels"`
+		Head      prBranch   `json:"head"`
+		Base      prBranch   `json:"base"`
+		Mergeable bool       `json:"mergeable"`
+		Draft     bool       `json:"draft"`
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedAt time.Time  `json:"updated_at"`
+		ClosedAt  *time.Time `json:"closed_at"`
+		MergedAt  *time.Time `json:"merged_at"`
+	}
+
+	// gitee pull request branch.
+	prBranch struct {
+		Label string      `json:"label"`
+		Ref   string      `json:"ref"`
+		Sha   string      `json:"sha"`
+		User  user        `json:"user"`
+		Repo  *repository `json:"repo"`
+	}
+
+	// gitee pull request request object.
+	prInput struct {
+		Title           string `json:"title,omitempty"`
+		Head            string `json:"head,omitempty"`
+		Base            string `json:"base,omitempty"`
+		Body            string `json:"body,omitempty"`
+		State           string `json:"state,omitempty"`
+		MilestoneNumber int    `json:"milestone_number,omitempty"`
+		Labels          string `json:"labels,omitempty"`
+		Assignees       string `json:"assignees,omitempty"`
+	}
+
+	// gitee pull request merge request object. The merge
+	// method is merge, squash or rebase.
+	prMergeInput struct {
+		MergeMethod       string `json:"merge_method,omitempty"`
+		Title             string `json:"title,omitempty"`
+		PruneSourceBranch bool   `json:"prune_source_branch,omitempty"`
+	}
+
+	// gitee pull request reviewers request object.
+	prAssigneesInput struct {
+		Assignees string `json:"assignees"`
+	}
+
+	// gitee pull request file.
+	file struct {
+		Sha       string `json:"sha"`
+		Filename  string `json:"filename"`
+		Status    string `json:"status"`
+		Additions count  `json:"additions"`
+		Deletions count  `json:"deletions"`
+		BlobURL   string `json:"blob_url"`
+		Patch     struct {
+			Diff        string `json:"diff"`
+			OldPath     string `json:"old_path"`
+			NewPath     string `json:"new_path"`
+			NewFile     bool   `json:"new_file"`
+			RenamedFile bool   `json:"renamed_file"`
+			DeletedFile bool   `json:"deleted_file"`
+		} `json:"patch"`
+	}
+)
+
+// count is a number that Gitee encodes as a string in some
+// responses.
+type count int
+
+func (c *count) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*c = 0
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	*c = count(n)
+	return err
+}
+
+//
+// native data structure conversion
+//
+
+func convertPullRequestList(from []*pr) []*scm.PullRequest {
+	to := []*scm.PullRequest{}
+	for _, v := range from {
+		to = append(to, convertPullRequest(v))
+	}
+	return to
+}
+
+func convertPullRequest(from *pr) *scm.PullRequest {
+	to := &scm.PullRequest{
+		Number:    from.Number,
+		Title:     from.Title,
+		Body:      from.Body,
+		Labels:    convertLabelList(from.Labels),
+		Sha:       from.Head.Sha,
+		Ref:       fmt.Sprintf("refs/pull/%d/head", from.Number),
+		Source:    from.Head.Ref,
+		Target:    from.Base.Ref,
+		Base:      convertPullRequestBranch(&from.Base),
+		Head:      convertPullRequestBranch(&from.Head),
+		State:     from.State,
+		Closed:    from.State != "open",
+		Draft:     from.Draft,
+		Merged:    from.State == "merged",
+		Mergeable: from.Mergeable,
+		Author:    *convertUser(&from.User),
+		Link:      from.HTMLURL,
+		Created:   from.CreatedAt.UTC(),
+		Updated:   from.UpdatedAt.UTC(),
+	}
+	if from.Head.Repo != nil && from.Base.Repo != nil && from.Head.Repo.FullName != from.Base.Repo.FullName {
+		to.Fork = from.Head.Repo.FullName
+	}
+	for _, assignee := range from.Assignees {
+		to.Reviewers = append(to.Reviewers, *convertUser(assignee))
+	}
+	if from.ClosedAt != nil && to.Closed {
+		to.ClosedAt = from.ClosedAt.UTC()
+	}
+	if from.MergedAt != nil && to.Merged {
+		to.MergedAt = from.MergedAt.UTC()
+	}
+	return to
+}
+
+func convertPullRequestBranch(from *prBranch) scm.PullRequestBranch {
+	to := scm.PullRequestBranch{
+		Ref: from.Ref,
+		Sha: from.Sha,
+	}
+	if from.Repo != nil {
+		to.Repo = *convertRepository(from.Repo)
+	}
+	return to
+}
+
+func convertChangeList(from []*file) []*scm.Change {
+	to := []*scm.Change{}
+	for _, v := range from {
+		to = append(to, convertChange(v))
+	}
+	return to
+}
+
+func convertChange(from *file) *scm.Change {
+	to := &scm.Change{
+		Path:      from.Filename,
+		Added:     from.Patch.NewFile || from.Status == "added",
+		Renamed:   from.Patch.RenamedFile || from.Status == "renamed",
+		Deleted:   from.Patch.DeletedFile || from.Status == "removed",
+		Patch:     from.Patch.Diff,
+		Additions: int(from.Additions),
+		Deletions: int(from.Deletions),
+		Changes:   int(from.Additions + from.Deletions),
+		BlobURL:   from.BlobURL,
+		Sha:       from.Sha,
+	}
+	if to.Renamed {
+		to.PreviousPath = from.Patch.OldPath
+	}
+	return to
+}
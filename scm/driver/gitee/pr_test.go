@@ -0,0 +1,214 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitee
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestPullRequestFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/pulls/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.Find(context.Background(), "octocat/hello-world", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.PullRequest)
+	raw, _ := ioutil.ReadFile("testdata/pr.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullRequestList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/pulls").
+		MatchParam("state", "all").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pulls.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.List(context.Background(), "octocat/hello-world", scm.PullRequestListOptions{Open: true, Closed: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.PullRequest{}
+	raw, _ := ioutil.ReadFile("testdata/pulls.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullRequestListClosed(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/pulls").
+		MatchParam("state", "all").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pulls.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.List(context.Background(), "octocat/hello-world", scm.PullRequestListOptions{Closed: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Number != 1 || !got[0].Merged {
+		t.Errorf("Want the merged pull request only, got %v", got)
+	}
+}
+
+func TestPullRequestChanges(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/pulls/1/files").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_files.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.ListChanges(context.Background(), "octocat/hello-world", 1, scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Change{}
+	raw, _ := ioutil.ReadFile("testdata/pr_files.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullRequestCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Post("/api/v5/repos/octocat/hello-world/pulls").
+		JSON(map[string]interface{}{
+			"title": "Update README",
+			"head":  "feature",
+			"base":  "master",
+			"body":  "Fix the typos",
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	client := NewDefault()
+	input := &scm.PullRequestInput{
+		Title: "Update README",
+		Head:  "feature",
+		Base:  "master",
+		Body:  "Fix the typos",
+	}
+	got, _, err := client.PullRequests.Create(context.Background(), "octocat/hello-world", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.PullRequest)
+	raw, _ := ioutil.ReadFile("testdata/pr.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullRequestMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Put("/api/v5/repos/octocat/hello-world/pulls/1/merge").
+		JSON(map[string]interface{}{"merge_method": "squash"}).
+		Reply(200).
+		Type("application/json").
+		BodyString("{}")
+
+	client := NewDefault()
+	_, err := client.PullRequests.Merge(context.Background(), "octocat/hello-world", 1, &scm.PullRequestMergeOptions{MergeMethod: "squash"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestPullRequestClose(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Patch("/api/v5/repos/octocat/hello-world/pulls/1").
+		JSON(map[string]interface{}{"state": "closed"}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	client := NewDefault()
+	_, err := client.PullRequests.Close(context.Background(), "octocat/hello-world", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestPullRequestCommentList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/pulls/1/comments").
+		Reply(200).
+		Type("application/json").
+		File("testdata/comments.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.ListComments(context.Background(), "octocat/hello-world", 1, scm.CommentListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Comment{}
+	raw, _ := ioutil.ReadFile("testdata/comments.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
@@ -0,0 +1,590 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitee
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type repositoryService struct {
+	client *wrapper
+}
+
+func (s *repositoryService) Find(ctx context.Context, repo string) (*scm.Repository, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s", repo)
+	out := new(repository)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertRepository(out), res, err
+}
+
+func (s *repositoryService) FindHook(ctx context.Context, repo string, id string) (*scm.Hook, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/hooks/%s", repo, id)
+	out := new(hook)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertHook(out), res, err
+}
+
+func (s *repositoryService) FindPerms(ctx context.Context, repo string) (*scm.Perm, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s", repo)
+	out := new(repository)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertRepository(out).Perm, res, err
+}
+
+func (s *repositoryService) FindUserPermission(ctx context.Context, repo string, user string) (string, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/collaborators/%s/permission", repo, user)
+	out := new(collaboratorPermission)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return out.Permission, res, err
+}
+
+func (s *repositoryService) List(ctx context.Context, opts scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	path := fmt.Sprintf("user/repos?%s", encodeListOptions(opts))
+	out := []*repository{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	populatePageValues(res, opts.Page)
+	return convertRepositoryList(out), res, err
+}
+
+func (s *repositoryService) ListOrganisation(ctx context.Context, org string, opts scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	path := fmt.Sprintf("orgs/%s/repos?%s", org, encodeListOptions(opts))
+	out := []*repository{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	populatePageValues(res, opts.Page)
+	return convertRepositoryList(out), res, err
+}
+
+func (s *repositoryService) ListUser(ctx context.Context, username string, opts scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	path := fmt.Sprintf("users/%s/repos?%s", username, encodeListOptions(opts))
+	out := []*repository{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	populatePageValues(res, opts.Page)
+	return convertRepositoryList(out), res, err
+}
+
+func (s *repositoryService) ListLabels(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/labels", repo)
+	out := []*label{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertLabelList(out), res, err
+}
+
+func (s *repositoryService) ListHooks(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Hook, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/hooks?%s", repo, encodeListOptions(opts))
+	out := []*hook{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	populatePageValues(res, opts.Page)
+	return convertHookList(out), res, err
+}
+
+// ListStatus lists the check runs of the ref, since Gitee has
+// no commit status API.
+func (s *repositoryService) ListStatus(ctx context.Context, repo, ref string, opts scm.ListOptions) ([]*scm.Status, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/commits/%s/check-runs?%s", repo, ref, encodeListOptions(opts))
+	out := new(checkRuns)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	populatePageValues(res, opts.Page)
+	return convertCheckRunList(out.CheckRuns), res, err
+}
+
+// FindCombinedStatus combines the latest check run of each
+// name, since Gitee reports no combined state.
+func (s *repositoryService) FindCombinedStatus(ctx context.Context, repo, ref string) (*scm.CombinedStatus, *scm.Response, error) {
+	statuses, res, err := scm.ListLatestStatuses(ctx, s, repo, ref)
+	if err != nil {
+		return nil, res, err
+	}
+	state := scm.StateUnknown
+	for _, status := range statuses {
+		if state == scm.StateUnknown || status.State < state {
+			state = status.State
+		}
+	}
+	return &scm.CombinedStatus{
+		State:    state,
+		Sha:      ref,
+		Statuses: statuses,
+	}, res, nil
+}
+
+func (s *repositoryService) Create(ctx context.Context, input *scm.RepositoryInput) (*scm.Repository, *scm.Response, error) {
+	path := "user/repos"
+	if input.Namespace != "" {
+		path = fmt.Sprintf("orgs/%s/repos", input.Namespace)
+	}
+	in := &repositoryInput{
+		Name:        input.Name,
+		Description: input.Description,
+		Homepage:    input.Homepage,
+		Private:     input.Private,
+	}
+	out := new(repository)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertRepository(out), res, err
+}
+
+func (s *repositoryService) Fork(ctx context.Context, input *scm.RepositoryInput, origRepo string) (*scm.Repository, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/forks", origRepo)
+	in := &forkInput{
+		Organization: input.Namespace,
+		Name:         input.Name,
+	}
+	out := new(repository)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertRepository(out), res, err
+}
+
+func (s *repositoryService) CreateHook(ctx context.Context, repo string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/hooks", repo)
+	in := &hookInput{
+		URL:      input.Target,
+		Password: input.Secret,
+	}
+	for _, event := range append(input.NativeEvents, convertHookEvent(input.Events)...) {
+		switch event {
+		case "push_events":
+			in.PushEvents = true
+		case "tag_push_events":
+			in.TagPushEvents = true
+		case "issues_events":
+			in.IssuesEvents = true
+		case "note_events":
+			in.NoteEvents = true
+		case "merge_requests_events":
+			in.MergeRequestsEvents = true
+		}
+	}
+	out := new(hook)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertHook(out), res, err
+}
+
+// CreateStatus creates a check run on the ref, since Gitee has
+// no commit status API.
+func (s *repositoryService) CreateStatus(ctx context.Context, repo, ref string, input *scm.StatusInput) (*scm.Status, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/check-runs", repo)
+	status, conclusion := convertCheckRunState(input.State)
+	in := &checkRunInput{
+		Name:       input.Label,
+		HeadSha:    ref,
+		Status:     status,
+		Conclusion: conclusion,
+		DetailsURL: input.Target,
+		Output: &checkRunOutput{
+			Title:   input.Label,
+			Summary: input.Desc,
+		},
+	}
+	out := new(checkRun)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertCheckRun(out), res, err
+}
+
+func (s *repositoryService) DeleteHook(ctx context.Context, repo string, id string) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/hooks/%s", repo, id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *repositoryService) Delete(ctx context.Context, repo string) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s", repo)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *repositoryService) IsCollaborator(ctx context.Context, repo, user string) (bool, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/collaborators/%s", repo, user)
+	res, err := s.client.do(ctx, "GET", path, nil, nil)
+	if res != nil && res.Status == 404 {
+		return false, res, nil
+	}
+	return err == nil, res, err
+}
+
+func (s *repositoryService) AddCollaborator(ctx context.Context, repo, user, permission string) (bool, bool, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/collaborators/%s", repo, user)
+	in := &collaboratorPermission{Permission: convertPermissionInput(permission)}
+	res, err := s.client.do(ctx, "PUT", path, in, nil)
+	return err == nil, false, res, err
+}
+
+func (s *repositoryService) ListCollaborators(ctx context.Context, repo string, opts scm.ListOptions) ([]scm.User, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/collaborators?%s", repo, encodeListOptions(opts))
+	out := []*user{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	populatePageValues(res, opts.Page)
+	var users []scm.User
+	for _, u := range out {
+		users = append(users, *convertUser(u))
+	}
+	return users, res, err
+}
+
+func (s *repositoryService) GetDependencyGraph(context.Context, string) (*scm.DependencyGraph, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "GetDependencyGraph")
+}
+
+func (s *repositoryService) ExportSBOM(context.Context, string) (io.ReadCloser, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ExportSBOM")
+}
+
+func (s *repositoryService) ListRulesets(context.Context, string, scm.ListOptions) ([]*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListRulesets")
+}
+
+func (s *repositoryService) FindRuleset(context.Context, string, int) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "FindRuleset")
+}
+
+func (s *repositoryService) CreateRuleset(context.Context, string, *scm.RulesetInput) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "CreateRuleset")
+}
+
+func (s *repositoryService) UpdateRuleset(context.Context, string, int, *scm.RulesetInput) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "UpdateRuleset")
+}
+
+func (s *repositoryService) GetPages(context.Context, string) (*scm.Pages, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "GetPages")
+}
+
+func (s *repositoryService) EnablePages(context.Context, string, *scm.PagesInput) (*scm.Pages, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "EnablePages")
+}
+
+func (s *repositoryService) DisablePages(context.Context, string) (*scm.Response, error) {
+	return nil, notSupported("Repositories", "DisablePages")
+}
+
+func (s *repositoryService) GetLatestPagesBuild(context.Context, string) (*scm.PagesBuild, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "GetLatestPagesBuild")
+}
+
+func (s *repositoryService) ListHookDeliveries(context.Context, string, string, scm.ListOptions) ([]*scm.HookDelivery, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListHookDeliveries")
+}
+
+func (s *repositoryService) RedeliverHookDelivery(context.Context, string, string, int64) (*scm.Response, error) {
+	return nil, notSupported("Repositories", "RedeliverHookDelivery")
+}
+
+//
+// native data structures
+//
+
+type (
+	// gitee repository resource.
+	repository struct {
+		ID            int       `json:"id"`
+		Owner         user      `json:"owner"`
+		Namespace     namespace `json:"namespace"`
+		Path          string    `json:"path"`
+		Name          string    `json:"name"`
+		FullName      string    `json:"full_name"`
+		Private       bool      `json:"private"`
+		Fork          bool      `json:"fork"`
+		HTMLURL       string    `json:"html_url"`
+		SSHURL        string    `json:"ssh_url"`
+		DefaultBranch string    `json:"default_branch"`
+		CreatedAt     time.Time `json:"created_at"`
+		UpdatedAt     time.Time `json:"updated_at"`
+		Permission    perm      `json:"permission"`
+	}
+
+	// gitee namespace of a repository.
+	namespace struct {
+		ID   int    `json:"id"`
+		Type string `json:"type"`
+		Name string `json:"name"`
+		Path string `json:"path"`
+	}
+
+	// gitee permissions details.
+	perm struct {
+		Admin bool `json:"admin"`
+		Push  bool `json:"push"`
+		Pull  bool `json:"pull"`
+	}
+
+	// gitee collaborator permission, which is admin, write
+	// or read, and admin, push or pull when adding a
+	// collaborator.
+	collaboratorPermission struct {
+		Permission string `json:"permission"`
+	}
+
+	// gitee repository request object.
+	repositoryInput struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		Homepage    string `json:"homepage,omitempty"`
+		Private     bool   `json:"private"`
+	}
+
+	// gitee fork request object.
+	forkInput struct {
+		Organization string `json:"organization,omitempty"`
+		Name         string `json:"name,omitempty"`
+	}
+
+	// gitee hook resource.
+	hook struct {
+		ID                  int       `json:"id"`
+		URL                 string    `json:"url"`
+		Password            string    `json:"password"`
+		PushEvents          bool      `json:"push_events"`
+		TagPushEvents       bool      `json:"tag_push_events"`
+		IssuesEvents        bool      `json:"issues_events"`
+		NoteEvents          bool      `json:"note_events"`
+		MergeRequestsEvents bool      `json:"merge_requests_events"`
+		CreatedAt           time.Time `json:"created_at"`
+	}
+
+	// gitee hook request object.
+	hookInput struct {
+		URL                 string `json:"url"`
+		Password            string `json:"password,omitempty"`
+		PushEvents          bool   `json:"push_events"`
+		TagPushEvents       bool   `json:"tag_push_events"`
+		IssuesEvents        bool   `json:"issues_events"`
+		NoteEvents          bool   `json:"note_events"`
+		MergeRequestsEvents bool   `json:"merge_requests_events"`
+	}
+
+	// gitee check run resource.
+	checkRun struct {
+		ID         int            `json:"id"`
+		Name       string         `json:"name"`
+		HeadSha    string         `json:"head_sha"`
+		Status     string         `json:"status"`
+		Conclusion string         `json:"conclusion"`
+		DetailsURL string         `json:"details_url"`
+		HTMLURL    string         `json:"html_url"`
+		Output     checkRunOutput `json:"output"`
+	}
+
+	// gitee check run list.
+	checkRuns struct {
+		TotalCount int         `json:"total_count"`
+		CheckRuns  []*checkRun `json:"check_runs"`
+	}
+
+	// gitee check run output.
+	checkRunOutput struct {
+		Title   string `json:"title"`
+		Summary string `json:"summary"`
+	}
+
+	// gitee check run request object.
+	checkRunInput struct {
+		Name       string          `json:"name"`
+		HeadSha    string          `json:"head_sha"`
+		Status     string          `json:"status"`
+		Conclusion string          `json:"conclusion,omitempty"`
+		DetailsURL string          `json:"details_url,omitempty"`
+		Output     *checkRunOutput `json:"output,omitempty"`
+	}
+)
+
+// UnmarshalJSON decodes the namespace, which is the path of
+// the namespace in the webhook payloads.
+func (n *namespace) UnmarshalJSON(data []byte) error {
+	if len(data) != 0 && data[0] == '"' {
+		*n = namespace{}
+		return json.Unmarshal(data, &n.Path)
+	}
+	type plain namespace
+	return json.Unmarshal(data, (*plain)(n))
+}
+
+//
+// native data structure conversion
+//
+
+func convertRepositoryList(src []*repository) []*scm.Repository {
+	var dst []*scm.Repository
+	for _, v := range src {
+		dst = append(dst, convertRepository(v))
+	}
+	return dst
+}
+
+// convertRepository converts the repository. The html_url of
+// a Gitee repository is its clone url, ending with .git.
+func convertRepository(src *repository) *scm.Repository {
+	namespace := src.Namespace.Path
+	if namespace == "" {
+		namespace, _ = scm.Split(src.FullName)
+	}
+	return &scm.Repository{
+		ID:        strconv.Itoa(src.ID),
+		Namespace: namespace,
+		Name:      src.Path,
+		FullName:  src.FullName,
+		Perm:      convertPerm(src.Permission),
+		Branch:    src.DefaultBranch,
+		Private:   src.Private,
+		Clone:     src.HTMLURL,
+		CloneSSH:  src.SSHURL,
+		Link:      strings.TrimSuffix(src.HTMLURL, ".git"),
+		Created:   src.CreatedAt.UTC(),
+		Updated:   src.UpdatedAt.UTC(),
+	}
+}
+
+func convertPerm(src perm) *scm.Perm {
+	return &scm.Perm{
+		Push:  src.Push,
+		Pull:  src.Pull,
+		Admin: src.Admin,
+	}
+}
+
+// convertPermissionInput converts the permission accepted by
+// AddCollaborator, which may use the read and write names of
+// the other providers.
+func convertPermissionInput(src string) string {
+	switch src {
+	case "read":
+		return "pull"
+	case "write":
+		return "push"
+	default:
+		return src
+	}
+}
+
+func convertHookList(src []*hook) []*scm.Hook {
+	var dst []*scm.Hook
+	for _, v := range src {
+		dst = append(dst, convertHook(v))
+	}
+	return dst
+}
+
+func convertHook(from *hook) *scm.Hook {
+	var events []string
+	if from.PushEvents {
+		events = append(events, "push_events")
+	}
+	if from.TagPushEvents {
+		events = append(events, "tag_push_events")
+	}
+	if from.IssuesEvents {
+		events = append(events, "issues_events")
+	}
+	if from.NoteEvents {
+		events = append(events, "note_events")
+	}
+	if from.MergeRequestsEvents {
+		events = append(events, "merge_requests_events")
+	}
+	return &scm.Hook{
+		ID:     strconv.Itoa(from.ID),
+		Active: true,
+		Target: from.URL,
+		Events: events,
+	}
+}
+
+// convertHookEvent converts the events to the Gitee events.
+// Branches are created and deleted by pushes.
+func convertHookEvent(from scm.HookEvents) []string {
+	var events []string
+	if from.Push || from.Branch {
+		events = append(events, "push_events")
+	}
+	if from.Tag {
+		events = append(events, "tag_push_events")
+	}
+	if from.Issue {
+		events = append(events, "issues_events")
+	}
+	if from.IssueComment || from.PullRequestComment || from.ReviewComment {
+		events = append(events, "note_events")
+	}
+	if from.PullRequest {
+		events = append(events, "merge_requests_events")
+	}
+	return events
+}
+
+func convertCheckRunList(from []*checkRun) []*scm.Status {
+	to := []*scm.Status{}
+	for _, v := range from {
+		to = append(to, convertCheckRun(v))
+	}
+	return to
+}
+
+func convertCheckRun(from *checkRun) *scm.Status {
+	return &scm.Status{
+		State:  convertCheckRunStatus(from.Status, from.Conclusion),
+		Label:  from.Name,
+		Desc:   from.Output.Summary,
+		Target: from.DetailsURL,
+		Link:   from.HTMLURL,
+	}
+}
+
+// convertCheckRunStatus converts the status and conclusion of
+// a check run to the state.
+func convertCheckRunStatus(status, conclusion string) scm.State {
+	switch status {
+	case "queued":
+		return scm.StatePending
+	case "in_progress":
+		return scm.StateRunning
+	}
+	switch conclusion {
+	case "success":
+		return scm.StateSuccess
+	case "failure":
+		return scm.StateFailure
+	case "cancelled":
+		return scm.StateCanceled
+	case "timed_out":
+		return scm.StateError
+	case "neutral":
+		return scm.StateNeutral
+	case "skipped":
+		return scm.StateSkipped
+	case "action_required":
+		return scm.StateActionRequired
+	default:
+		return scm.StateUnknown
+	}
+}
+
+// convertCheckRunState converts the state to the status and
+// conclusion of a check run.
+func convertCheckRunState(from scm.State) (status, conclusion string) {
+	switch from {
+	case scm.StatePending, scm.StateExpected:
+		return "queued", ""
+	case scm.StateRunning:
+		return "in_progress", ""
+	case scm.StateSuccess:
+		return "completed", "success"
+	case scm.StateCanceled:
+		return "completed", "cancelled"
+	case scm.StateError:
+		return "completed", "timed_out"
+	case scm.StateNeutral:
+		return "completed", "neutral"
+	case scm.StateSkipped:
+		return "completed", "skipped"
+	case scm.StateActionRequired:
+		return "completed", "action_required"
+	default:
+		return "completed", "failure"
+	}
+}
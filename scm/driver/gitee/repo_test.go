@@ -0,0 +1,309 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitee
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestRepositoryFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world").
+		Reply(200).
+		Type("application/json").
+		File("testdata/repo.json")
+
+	client := NewDefault()
+	got, _, err := client.Repositories.Find(context.Background(), "octocat/hello-world")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Repository)
+	raw, _ := ioutil.ReadFile("testdata/repo.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestRepositoryPerms(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world").
+		Reply(200).
+		Type("application/json").
+		File("testdata/repo.json")
+
+	client := NewDefault()
+	got, _, err := client.Repositories.FindPerms(context.Background(), "octocat/hello-world")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &scm.Perm{Pull: true, Push: true, Admin: false}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestRepositoryList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/user/repos").
+		MatchParam("page", "1").
+		MatchParam("per_page", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeader("total_count", "2").
+		SetHeader("total_page", "3").
+		File("testdata/repos.json")
+
+	client := NewDefault()
+	got, res, err := client.Repositories.List(context.Background(), scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Repository{}
+	raw, _ := ioutil.ReadFile("testdata/repos.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Page", func(t *testing.T) {
+		if got, want := res.Page.Next, 2; got != want {
+			t.Errorf("Want next page %d, got %d", want, got)
+		}
+		if got, want := res.Page.Last, 3; got != want {
+			t.Errorf("Want last page %d, got %d", want, got)
+		}
+	})
+}
+
+func TestRepositoryHookFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/hooks/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/hook.json")
+
+	client := NewDefault()
+	got, _, err := client.Repositories.FindHook(context.Background(), "octocat/hello-world", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Hook)
+	raw, _ := ioutil.ReadFile("testdata/hook.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestRepositoryHookList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/hooks").
+		Reply(200).
+		Type("application/json").
+		File("testdata/hooks.json")
+
+	client := NewDefault()
+	got, _, err := client.Repositories.ListHooks(context.Background(), "octocat/hello-world", scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Hook{}
+	raw, _ := ioutil.ReadFile("testdata/hooks.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestRepositoryHookCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Post("/api/v5/repos/octocat/hello-world/hooks").
+		JSON(map[string]interface{}{
+			"url":                   "https://example.com/hook",
+			"password":              "topsecret",
+			"push_events":           true,
+			"tag_push_events":       true,
+			"issues_events":         false,
+			"note_events":           false,
+			"merge_requests_events": true,
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/hook.json")
+
+	client := NewDefault()
+	input := &scm.HookInput{
+		Target: "https://example.com/hook",
+		Secret: "topsecret",
+		Events: scm.HookEvents{Push: true, Tag: true, PullRequest: true},
+	}
+	got, _, err := client.Repositories.CreateHook(context.Background(), "octocat/hello-world", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Hook)
+	raw, _ := ioutil.ReadFile("testdata/hook.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestRepositoryIsCollaborator(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/collaborators/hubot").
+		Reply(204)
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/collaborators/ghost").
+		Reply(404)
+
+	client := NewDefault()
+	got, _, err := client.Repositories.IsCollaborator(context.Background(), "octocat/hello-world", "hubot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got {
+		t.Errorf("Want hubot to be a collaborator")
+	}
+	got, _, err = client.Repositories.IsCollaborator(context.Background(), "octocat/hello-world", "ghost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got {
+		t.Errorf("Want ghost not to be a collaborator")
+	}
+}
+
+func TestStatusList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e/check-runs").
+		MatchParam("page", "1").
+		MatchParam("per_page", "30").
+		Reply(200).
+		Type("application/json").
+		File("testdata/statuses.json")
+
+	client := NewDefault()
+	got, _, err := client.Repositories.ListStatus(context.Background(), "octocat/hello-world", "6dcb09b5b57875f334f61aebed695e2e4193db5e", scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Status{}
+	raw, _ := ioutil.ReadFile("testdata/statuses.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestStatusCombined(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/repos/octocat/hello-world/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e/check-runs").
+		Reply(200).
+		Type("application/json").
+		File("testdata/statuses.json")
+
+	client := NewDefault()
+	got, _, err := client.Repositories.FindCombinedStatus(context.Background(), "octocat/hello-world", "6dcb09b5b57875f334f61aebed695e2e4193db5e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := got.State, scm.StateRunning; got != want {
+		t.Errorf("Want combined state %s, got %s", want, got)
+	}
+	if got, want := len(got.Statuses), 2; got != want {
+		t.Errorf("Want %d statuses, got %d", want, got)
+	}
+}
+
+func TestStatusCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Post("/api/v5/repos/octocat/hello-world/check-runs").
+		JSON(map[string]interface{}{
+			"name":        "ci/build",
+			"head_sha":    "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			"status":      "completed",
+			"conclusion":  "success",
+			"details_url": "https://ci.example.com/1000/output",
+			"output": map[string]string{
+				"title":   "ci/build",
+				"summary": "Build has completed successfully",
+			},
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/status.json")
+
+	in := &scm.StatusInput{
+		Desc:   "Build has completed successfully",
+		Label:  "ci/build",
+		State:  scm.StateSuccess,
+		Target: "https://ci.example.com/1000/output",
+	}
+
+	client := NewDefault()
+	got, _, err := client.Repositories.CreateStatus(context.Background(), "octocat/hello-world", "6dcb09b5b57875f334f61aebed695e2e4193db5e", in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Status{}
+	raw, _ := ioutil.ReadFile("testdata/statuses.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want[0]); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
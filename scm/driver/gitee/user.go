@@ -0,0 +1,96 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitee
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type userService struct {
+	client *wrapper
+}
+
+func (s *userService) CreateToken(context.Context, string, string) (*scm.UserToken, *scm.Response, error) {
+	return nil, nil, notSupported("Users", "CreateToken")
+}
+
+func (s *userService) DeleteToken(context.Context, int64) (*scm.Response, error) {
+	return nil, notSupported("Users", "DeleteToken")
+}
+
+func (s *userService) TokenInfo(context.Context) (*scm.TokenInfo, *scm.Response, error) {
+	return nil, nil, notSupported("Users", "TokenInfo")
+}
+
+func (s *userService) ListTokens(context.Context, scm.ListOptions) ([]*scm.UserToken, *scm.Response, error) {
+	return nil, nil, notSupported("Users", "ListTokens")
+}
+
+func (s *userService) Find(ctx context.Context) (*scm.User, *scm.Response, error) {
+	out := new(user)
+	res, err := s.client.do(ctx, "GET", "user", nil, out)
+	return convertUser(out), res, err
+}
+
+func (s *userService) FindLogin(ctx context.Context, login string) (*scm.User, *scm.Response, error) {
+	path := fmt.Sprintf("users/%s", login)
+	out := new(user)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertUser(out), res, err
+}
+
+func (s *userService) FindEmail(ctx context.Context) (string, *scm.Response, error) {
+	user, res, err := s.Find(ctx)
+	return user.Email, res, err
+}
+
+func (s *userService) ListInvitations(context.Context) ([]*scm.Invitation, *scm.Response, error) {
+	return nil, nil, notSupported("Users", "ListInvitations")
+}
+
+func (s *userService) AcceptInvitation(context.Context, int64) (*scm.Response, error) {
+	return nil, notSupported("Users", "AcceptInvitation")
+}
+
+//
+// native data structures
+//
+
+type user struct {
+	ID        int    `json:"id"`
+	Login     string `json:"login"`
+	Username  string `json:"username"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatar_url"`
+	HTMLURL   string `json:"html_url"`
+}
+
+//
+// native data structure conversion
+//
+
+func convertUser(src *user) *scm.User {
+	return &scm.User{
+		ID:     src.ID,
+		Login:  userLogin(src),
+		Name:   src.Name,
+		Email:  src.Email,
+		Avatar: src.AvatarURL,
+		Link:   src.HTMLURL,
+	}
+}
+
+// userLogin returns the login of the user, which is the
+// username in the webhook payloads.
+func userLogin(src *user) string {
+	if src.Login != "" {
+		return src.Login
+	}
+	return src.Username
+}
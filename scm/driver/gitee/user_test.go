@@ -0,0 +1,66 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitee
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestUserFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/user").
+		Reply(200).
+		Type("application/json").
+		File("testdata/user.json")
+
+	client := NewDefault()
+	got, _, err := client.Users.Find(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.User)
+	raw, _ := ioutil.ReadFile("testdata/user.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestUserFindLogin(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitee.com").
+		Get("/api/v5/users/octocat").
+		Reply(200).
+		Type("application/json").
+		File("testdata/user.json")
+
+	client := NewDefault()
+	got, _, err := client.Users.FindLogin(context.Background(), "octocat")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.User)
+	raw, _ := ioutil.ReadFile("testdata/user.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitee
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+func encodeListOptions(opts scm.ListOptions) string {
+	params := url.Values{}
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Size != 0 {
+		params.Set("per_page", strconv.Itoa(opts.Size))
+	}
+	return params.Encode()
+}
+
+func encodeCommentListOptions(opts scm.CommentListOptions) string {
+	params := url.Values{}
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Size != 0 {
+		params.Set("per_page", strconv.Itoa(opts.Size))
+	}
+	return params.Encode()
+}
+
+func encodeIssueListOptions(opts scm.IssueListOptions) string {
+	params := url.Values{}
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Size != 0 {
+		params.Set("per_page", strconv.Itoa(opts.Size))
+	}
+	if opts.Open && opts.Closed {
+		params.Set("state", "all")
+	} else if opts.Closed {
+		params.Set("state", "closed")
+	}
+	return params.Encode()
+}
+
+func encodePullRequestListOptions(opts scm.PullRequestListOptions) string {
+	params := url.Values{}
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Size != 0 {
+		params.Set("per_page", strconv.Itoa(opts.Size))
+	}
+	// the merged pull requests are not listed with the
+	// closed state, see pullService.List.
+	if opts.Closed {
+		params.Set("state", "all")
+	}
+	if len(opts.Labels) != 0 {
+		params.Set("labels", strings.Join(opts.Labels, ","))
+	}
+	return params.Encode()
+}
+
+// Gitee identifies the issues by a string such as I1DACG
+// instead of a number. The string is an I followed by a
+// base 36 number, which is the issue number of this package.
+
+// issueNumber returns the issue number of the Gitee issue
+// identifier, or zero if it is not a valid identifier.
+func issueNumber(id string) int {
+	if !strings.HasPrefix(id, "I") {
+		return 0
+	}
+	n, err := strconv.ParseInt(id[1:], 36, 0)
+	if err != nil {
+		return 0
+	}
+	return int(n)
+}
+
+// issueID returns the Gitee issue identifier of the issue
+// number.
+func issueID(number int) string {
+	return "I" + strings.ToUpper(strconv.FormatInt(int64(number), 36))
+}
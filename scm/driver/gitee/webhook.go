@@ -0,0 +1,322 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitee
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type webhookService struct {
+	client *wrapper
+	opts   scm.WebhookServiceOptions
+}
+
+func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
+	return s.ParseMulti(req, scm.Secrets(fn))
+}
+
+func (s *webhookService) ParseMulti(req *http.Request, fn scm.MultiSecretFunc) (scm.Webhook, error) {
+	_, data, err := s.opts.ReadBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var hook scm.Webhook
+	event := req.Header.Get("X-Gitee-Event")
+	switch event {
+	case "Push Hook", "Tag Push Hook":
+		hook, err = parsePushHook(data)
+	case "Issue Hook":
+		hook, err = parseIssueHook(data)
+	case "Merge Request Hook":
+		hook, err = parsePullRequestHook(data)
+	case "Note Hook":
+		hook, err = parseNoteHook(data)
+	default:
+		return nil, scm.UnknownWebhook{Event: event}
+	}
+	if err != nil {
+		return nil, err
+	}
+	scm.SetGUID(hook, req.Header.Get("X-Gitee-Delivery"))
+	if s.opts.RawPayload {
+		scm.SetRaw(hook, data)
+	}
+
+	// get the gitee password or signature key to verify the
+	// payload. If no key is provided, no validation is
+	// performed.
+	keys, err := fn(hook)
+	if err != nil {
+		return hook, err
+	} else if len(keys) == 0 {
+		return hook, nil
+	}
+
+	token := req.Header.Get("X-Gitee-Token")
+	if token == "" {
+		return hook, scm.ErrSignatureInvalid
+	}
+	timestamp := req.Header.Get("X-Gitee-Timestamp")
+	if !scm.ValidateAny(keys, func(key string) bool { return validateToken(token, timestamp, key) }) {
+		return hook, scm.ErrSignatureInvalid
+	}
+
+	return hook, nil
+}
+
+// validateToken reports whether the X-Gitee-Token header is
+// the webhook password, or the signature of the timestamp
+// made with the webhook signing key: the base64 encoded
+// HMAC-SHA256 of the timestamp and the key separated by a
+// newline.
+func validateToken(token, timestamp, key string) bool {
+	if hmac.Equal([]byte(token), []byte(key)) {
+		return true
+	}
+	if timestamp == "" {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(timestamp + "\n" + key))
+	sig := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(token), []byte(sig)) ||
+		hmac.Equal([]byte(token), []byte(url.QueryEscape(sig)))
+}
+
+func parsePushHook(data []byte) (scm.Webhook, error) {
+	dst := new(pushHook)
+	err := json.Unmarshal(data, dst)
+	return convertPushHook(dst), err
+}
+
+func parseIssueHook(data []byte) (scm.Webhook, error) {
+	dst := new(issueHook)
+	err := json.Unmarshal(data, dst)
+	return convertIssueHook(dst), err
+}
+
+func parsePullRequestHook(data []byte) (scm.Webhook, error) {
+	dst := new(pullRequestHook)
+	err := json.Unmarshal(data, dst)
+	return convertPullRequestHook(dst), err
+}
+
+func parseNoteHook(data []byte) (scm.Webhook, error) {
+	dst := new(noteHook)
+	err := json.Unmarshal(data, dst)
+	if err != nil {
+		return nil, err
+	}
+	switch dst.NoteableType {
+	case "Issue":
+		return convertIssueCommentHook(dst), nil
+	case "PullRequest":
+		return convertPullRequestCommentHook(dst), nil
+	default:
+		return nil, scm.UnknownWebhook{Event: "Note Hook " + dst.NoteableType}
+	}
+}
+
+//
+// native data structures
+//
+
+type (
+	// gitee push webhook payload, of a branch or a tag.
+	pushHook struct {
+		Ref        string       `json:"ref"`
+		Before     string       `json:"before"`
+		After      string       `json:"after"`
+		Created    bool         `json:"created"`
+		Deleted    bool         `json:"deleted"`
+		Compare    string       `json:"compare"`
+		Commits    []hookCommit `json:"commits"`
+		HeadCommit *hookCommit  `json:"head_commit"`
+		Repository repository   `json:"repository"`
+		Sender     user         `json:"sender"`
+	}
+
+	// gitee commit of a push webhook payload.
+	hookCommit struct {
+		ID        string     `json:"id"`
+		Message   string     `json:"message"`
+		Timestamp time.Time  `json:"timestamp"`
+		URL       string     `json:"url"`
+		Author    hookAuthor `json:"author"`
+		Committer hookAuthor `json:"committer"`
+		Added     []string   `json:"added"`
+		Removed   []string   `json:"removed"`
+		Modified  []string   `json:"modified"`
+	}
+
+	// gitee commit author of a push webhook payload.
+	hookAuthor struct {
+		Name     string `json:"name"`
+		Email    string `json:"email"`
+		Username string `json:"username"`
+	}
+
+	// gitee issue webhook payload. The action is open,
+	// delete, state_change or assign.
+	issueHook struct {
+		Action     string     `json:"action"`
+		Issue      issue      `json:"issue"`
+		Repository repository `json:"repository"`
+		Sender     user       `json:"sender"`
+	}
+
+	// gitee merge request webhook payload. The action is
+	// open, update, close, merge, test, tested, assign,
+	// unassign or approved, and action_desc details the
+	// update action.
+	pullRequestHook struct {
+		Action      string     `json:"action"`
+		ActionDesc  string     `json:"action_desc"`
+		PullRequest pr         `json:"pull_request"`
+		Repository  repository `json:"repository"`
+		Sender      user       `json:"sender"`
+	}
+
+	// gitee note webhook payload, of a comment on an issue,
+	// a pull request or a commit.
+	noteHook struct {
+		Action       string     `json:"action"`
+		NoteableType string     `json:"noteable_type"`
+		Comment      comment    `json:"comment"`
+		Issue        issue      `json:"issue"`
+		PullRequest  pr         `json:"pull_request"`
+		Repository   repository `json:"repository"`
+		Sender       user       `json:"sender"`
+	}
+)
+
+//
+// native data structure conversion
+//
+
+func convertPushHook(src *pushHook) *scm.PushHook {
+	dst := &scm.PushHook{
+		Ref:     src.Ref,
+		Before:  src.Before,
+		After:   src.After,
+		Created: src.Created || src.Before == scm.EmptyCommit,
+		Deleted: src.Deleted || src.After == scm.EmptyCommit,
+		Compare: src.Compare,
+		Commit:  scm.Commit{Sha: src.After},
+		Repo:    *convertRepository(&src.Repository),
+		Sender:  *convertUser(&src.Sender),
+	}
+	for _, c := range src.Commits {
+		dst.Commits = append(dst.Commits, scm.PushCommit{
+			ID:       c.ID,
+			Message:  c.Message,
+			Added:    c.Added,
+			Removed:  c.Removed,
+			Modified: c.Modified,
+		})
+	}
+	if head := src.HeadCommit; head != nil {
+		dst.Commit = scm.Commit{
+			Sha:     head.ID,
+			Message: head.Message,
+			Link:    head.URL,
+			Author: scm.Signature{
+				Login: head.Author.Username,
+				Name:  head.Author.Name,
+				Email: head.Author.Email,
+				Date:  head.Timestamp.UTC(),
+			},
+			Committer: scm.Signature{
+				Login: head.Committer.Username,
+				Name:  head.Committer.Name,
+				Email: head.Committer.Email,
+				Date:  head.Timestamp.UTC(),
+			},
+		}
+	}
+	return dst
+}
+
+func convertIssueHook(src *issueHook) *scm.IssueHook {
+	dst := &scm.IssueHook{
+		Action: convertAction(src.Action),
+		Issue:  *convertIssue(&src.Issue),
+		Repo:   *convertRepository(&src.Repository),
+		Sender: *convertUser(&src.Sender),
+	}
+	if src.Action == "state_change" {
+		if dst.Issue.Closed {
+			dst.Action = scm.ActionClose
+		} else {
+			dst.Action = scm.ActionReopen
+		}
+	}
+	return dst
+}
+
+func convertPullRequestHook(src *pullRequestHook) *scm.PullRequestHook {
+	dst := &scm.PullRequestHook{
+		Action:      convertAction(src.Action),
+		PullRequest: *convertPullRequest(&src.PullRequest),
+		Repo:        *convertRepository(&src.Repository),
+		Sender:      *convertUser(&src.Sender),
+	}
+	if src.Action == "update" && src.ActionDesc == "source_branch_changed" {
+		dst.Action = scm.ActionSync
+	}
+	return dst
+}
+
+func convertIssueCommentHook(src *noteHook) *scm.IssueCommentHook {
+	return &scm.IssueCommentHook{
+		Action:  scm.ActionCreate,
+		Issue:   *convertIssue(&src.Issue),
+		Comment: *convertComment(&src.Comment),
+		Repo:    *convertRepository(&src.Repository),
+		Sender:  *convertUser(&src.Sender),
+	}
+}
+
+func convertPullRequestCommentHook(src *noteHook) *scm.PullRequestCommentHook {
+	return &scm.PullRequestCommentHook{
+		Action:      scm.ActionCreate,
+		PullRequest: *convertPullRequest(&src.PullRequest),
+		Comment:     *convertComment(&src.Comment),
+		Repo:        *convertRepository(&src.Repository),
+		Sender:      *convertUser(&src.Sender),
+	}
+}
+
+func convertAction(src string) (action scm.Action) {
+	switch src {
+	case "open":
+		return scm.ActionOpen
+	case "update":
+		return scm.ActionUpdate
+	case "close":
+		return scm.ActionClose
+	case "reopen":
+		return scm.ActionReopen
+	case "merge":
+		return scm.ActionMerge
+	case "delete":
+		return scm.ActionDelete
+	case "assign":
+		return scm.ActionAssigned
+	case "unassign":
+		return scm.ActionUnassigned
+	default:
+		return
+	}
+}
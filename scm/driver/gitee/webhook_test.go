@@ -0,0 +1,186 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitee
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/slimm609/go-scm/scm"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWebhooks(t *testing.T) {
+	tests := []struct {
+		event  string
+		before string
+		after  string
+		obj    interface{}
+	}{
+		// push hooks
+		{
+			event:  "Push Hook",
+			before: "testdata/webhooks/push.json",
+			after:  "testdata/webhooks/push.json.golden",
+			obj:    new(scm.PushHook),
+		},
+		{
+			event:  "Tag Push Hook",
+			before: "testdata/webhooks/tag_push.json",
+			after:  "testdata/webhooks/tag_push.json.golden",
+			obj:    new(scm.PushHook),
+		},
+		// issue hooks
+		{
+			event:  "Issue Hook",
+			before: "testdata/webhooks/issue_open.json",
+			after:  "testdata/webhooks/issue_open.json.golden",
+			obj:    new(scm.IssueHook),
+		},
+		{
+			event:  "Issue Hook",
+			before: "testdata/webhooks/issue_state_change.json",
+			after:  "testdata/webhooks/issue_state_change.json.golden",
+			obj:    new(scm.IssueHook),
+		},
+		// pull request hooks
+		{
+			event:  "Merge Request Hook",
+			before: "testdata/webhooks/merge_request_open.json",
+			after:  "testdata/webhooks/merge_request_open.json.golden",
+			obj:    new(scm.PullRequestHook),
+		},
+		{
+			event:  "Merge Request Hook",
+			before: "testdata/webhooks/merge_request_sync.json",
+			after:  "testdata/webhooks/merge_request_sync.json.golden",
+			obj:    new(scm.PullRequestHook),
+		},
+		// comment hooks
+		{
+			event:  "Note Hook",
+			before: "testdata/webhooks/note_issue.json",
+			after:  "testdata/webhooks/note_issue.json.golden",
+			obj:    new(scm.IssueCommentHook),
+		},
+		{
+			event:  "Note Hook",
+			before: "testdata/webhooks/note_pull_request.json",
+			after:  "testdata/webhooks/note_pull_request.json.golden",
+			obj:    new(scm.PullRequestCommentHook),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.before, func(t *testing.T) {
+			before, err := ioutil.ReadFile(test.before)
+			if err != nil {
+				t.Fatal(err)
+			}
+			after, err := ioutil.ReadFile(test.after)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r, _ := http.NewRequest("POST", "/", bytes.NewBuffer(before))
+			r.Header.Set("X-Gitee-Event", test.event)
+			r.Header.Set("X-Gitee-Token", "topsecret")
+
+			s := new(webhookService)
+			o, err := s.Parse(r, secretFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = json.Unmarshal(after, test.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(test.obj, o); diff != "" {
+				t.Errorf("Error unmarshaling %s", test.before)
+				t.Log(diff)
+			}
+		})
+	}
+}
+
+func TestWebhook_ErrUnknownEvent(t *testing.T) {
+	f, _ := ioutil.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("POST", "/", bytes.NewBuffer(f))
+
+	s := new(webhookService)
+	_, err := s.Parse(r, secretFunc)
+	if !scm.IsUnknownWebhook(err) {
+		t.Errorf("Expect unknown event error, got %v", err)
+	}
+}
+
+func TestWebhook_GUID(t *testing.T) {
+	f, _ := ioutil.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("POST", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-Gitee-Event", "Push Hook")
+	r.Header.Set("X-Gitee-Delivery", "6f1a2b3c-4d5e-6f70-8192-a3b4c5d6e7f8")
+
+	s := new(webhookService)
+	hook, err := s.Parse(r, func(scm.Webhook) (string, error) { return "", nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := scm.GUID(hook), "6f1a2b3c-4d5e-6f70-8192-a3b4c5d6e7f8"; got != want {
+		t.Errorf("Want guid %q, got %q", want, got)
+	}
+}
+
+func TestWebhookSigned(t *testing.T) {
+	// the token can be recalculated with the below command
+	// printf '<timestamp>\n<secret>' | openssl dgst -sha256 -hmac <secret> -binary | base64
+
+	f, _ := ioutil.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("POST", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-Gitee-Event", "Push Hook")
+	r.Header.Set("X-Gitee-Timestamp", "1576754827988")
+	r.Header.Set("X-Gitee-Token", "RJtKiDICbKu94vqinvz8i4USQ5PMROxH4L5uS8I6DYY=")
+
+	s := new(webhookService)
+	_, err := s.Parse(r, secretFunc)
+	if err != nil {
+		t.Errorf("Expect valid signature, got %v", err)
+	}
+}
+
+func TestWebhookInvalid(t *testing.T) {
+	f, _ := ioutil.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("POST", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-Gitee-Event", "Push Hook")
+	r.Header.Set("X-Gitee-Timestamp", "1576754827989")
+	r.Header.Set("X-Gitee-Token", "RJtKiDICbKu94vqinvz8i4USQ5PMROxH4L5uS8I6DYY=")
+
+	s := new(webhookService)
+	_, err := s.Parse(r, secretFunc)
+	if err != scm.ErrSignatureInvalid {
+		t.Errorf("Expect invalid signature error, got %v", err)
+	}
+}
+
+func TestWebhookMissingToken(t *testing.T) {
+	f, _ := ioutil.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("POST", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-Gitee-Event", "Push Hook")
+
+	s := new(webhookService)
+	_, err := s.Parse(r, secretFunc)
+	if err != scm.ErrSignatureInvalid {
+		t.Errorf("Expect invalid signature error, got %v", err)
+	}
+}
+
+func secretFunc(scm.Webhook) (string, error) {
+	return "topsecret", nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package githttp
+
+import (
+	"context"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/capability"
+	"github.com/slimm609/go-scm/scm"
+)
+
+type contentService struct {
+	client *wrapper
+}
+
+// Find returns the file. The trees of the commit are fetched
+// first, then the blob of the file by its sha, if the server
+// filtered it out.
+func (s *contentService) Find(ctx context.Context, repo, path, ref string) (*scm.Content, *scm.Response, error) {
+	commit, refs, res, err := s.client.commit(ctx, repo, ref, 1)
+	if err != nil {
+		return nil, res, err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, res, err
+	}
+	entry, err := tree.FindEntry(cleanPath(path))
+	if err == object.ErrEntryNotFound || err == object.ErrDirectoryNotFound {
+		return nil, res, scm.ErrNotFound
+	} else if err != nil {
+		return nil, res, err
+	}
+	if entry.Mode == filemode.Dir || entry.Mode == filemode.Submodule {
+		return nil, res, scm.ErrNotFound
+	}
+
+	var blob *object.Blob
+	file, err := tree.TreeEntryFile(entry)
+	if err == nil {
+		blob = &file.Blob
+	} else if err == plumbing.ErrObjectNotFound {
+		blob, res, err = s.blob(ctx, repo, refs.Capabilities, entry.Hash)
+	}
+	if err != nil {
+		return nil, res, err
+	}
+	r, err := blob.Reader()
+	if err != nil {
+		return nil, res, err
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, res, err
+	}
+	return &scm.Content{
+		Path: path,
+		Data: data,
+		Sha:  entry.Hash.String(),
+	}, res, nil
+}
+
+func (s *contentService) FindMany(ctx context.Context, repo, ref string, paths []string) (map[string]*scm.Content, map[string]error) {
+	return scm.FindManyContents(ctx, s, repo, ref, paths, scm.DefaultConcurrency)
+}
+
+// List returns the entries of the directory. The size of the
+// files is not reported, as their blobs are not fetched.
+func (s *contentService) List(ctx context.Context, repo, path, ref string) ([]*scm.FileEntry, *scm.Response, error) {
+	tree, res, err := s.tree(ctx, repo, ref)
+	if err != nil {
+		return nil, res, err
+	}
+	dir := cleanPath(path)
+	if dir != "" {
+		tree, err = tree.Tree(dir)
+		if err == object.ErrDirectoryNotFound {
+			return nil, res, scm.ErrNotFound
+		} else if err != nil {
+			return nil, res, err
+		}
+	}
+	out := []*scm.FileEntry{}
+	for _, entry := range tree.Entries {
+		out = append(out, convertEntry(dir, entry))
+	}
+	return out, res, nil
+}
+
+func (s *contentService) Exists(ctx context.Context, repo, path, ref string) (bool, *scm.Response, error) {
+	_, res, err := s.Stat(ctx, repo, path, ref)
+	if err == scm.ErrNotFound {
+		return false, res, nil
+	} else if err != nil {
+		return false, res, err
+	}
+	return true, res, nil
+}
+
+func (s *contentService) Stat(ctx context.Context, repo, path, ref string) (*scm.FileEntry, *scm.Response, error) {
+	tree, res, err := s.tree(ctx, repo, ref)
+	if err != nil {
+		return nil, res, err
+	}
+	entry, err := tree.FindEntry(cleanPath(path))
+	if err == object.ErrEntryNotFound || err == object.ErrDirectoryNotFound {
+		return nil, res, scm.ErrNotFound
+	} else if err != nil {
+		return nil, res, err
+	}
+	return convertEntry(parentPath(cleanPath(path)), *entry), res, nil
+}
+
+func (s *contentService) Create(context.Context, string, string, *scm.ContentParams) (*scm.Response, error) {
+	return nil, notSupported("Contents", "Create")
+}
+
+func (s *contentService) Update(context.Context, string, string, *scm.ContentParams) (*scm.Response, error) {
+	return nil, notSupported("Contents", "Update")
+}
+
+func (s *contentService) Delete(context.Context, string, string, string) (*scm.Response, error) {
+	return nil, notSupported("Contents", "Delete")
+}
+
+// tree returns the tree of the commit the ref points at.
+func (s *contentService) tree(ctx context.Context, repo, ref string) (*object.Tree, *scm.Response, error) {
+	commit, _, res, err := s.client.commit(ctx, repo, ref, 1)
+	if err != nil {
+		return nil, res, err
+	}
+	tree, err := commit.Tree()
+	return tree, res, err
+}
+
+// blob fetches the blob filtered out of the fetch of the
+// trees by its sha.
+func (s *contentService) blob(ctx context.Context, repo string, caps *capability.List, hash plumbing.Hash) (*object.Blob, *scm.Response, error) {
+	objects, res, err := s.client.fetch(ctx, repo, caps, []plumbing.Hash{hash}, 0, true)
+	if err != nil {
+		return nil, res, err
+	}
+	blob, err := object.GetBlob(objects, hash)
+	return blob, res, err
+}
+
+func convertEntry(dir string, from object.TreeEntry) *scm.FileEntry {
+	out := &scm.FileEntry{
+		Name: from.Name,
+		Path: path.Join(dir, from.Name),
+		Sha:  from.Hash.String(),
+	}
+	switch from.Mode {
+	case filemode.Dir:
+		out.Type = "dir"
+	case filemode.Submodule:
+		out.Type = "submodule"
+	case filemode.Symlink:
+		out.Type = "symlink"
+	default:
+		out.Type = "file"
+	}
+	return out
+}
+
+func cleanPath(p string) string {
+	return strings.Trim(path.Clean("/"+p), "/")
+}
+
+func parentPath(p string) string {
+	if i := strings.LastIndex(p, "/"); i != -1 {
+		return p[:i]
+	}
+	return ""
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package githttp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestContentFind(t *testing.T) {
+	for _, filter := range []bool{true, false} {
+		server, s := newServer(t, filter)
+
+		client, _ := New(server.URL)
+		got, _, err := client.Contents.Find(context.Background(), "octocat/hello-world", "docs/index.md", "master")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(got.Data), "# Documentation\n"; got != want {
+			t.Errorf("Want content %q, got %q", want, got)
+		}
+
+		// with partial clone, only the blob of the file is
+		// fetched, in a separate fetch.
+		if filter && (s.fetches != 2 || s.blobs != 1) {
+			t.Errorf("Expect the blob to be fetched by its sha")
+		}
+		if !filter && s.fetches != 1 {
+			t.Errorf("Expect a single fetch without partial clone")
+		}
+		server.Close()
+	}
+}
+
+func TestContentFind_Tag(t *testing.T) {
+	server, _ := newServer(t, true)
+	defer server.Close()
+
+	client, _ := New(server.URL)
+	got, _, err := client.Contents.Find(context.Background(), "octocat/hello-world", "README.md", "v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(got.Data), "Hello World\n"; got != want {
+		t.Errorf("Want content %q, got %q", want, got)
+	}
+}
+
+func TestContentFind_NotFound(t *testing.T) {
+	server, _ := newServer(t, true)
+	defer server.Close()
+
+	client, _ := New(server.URL)
+	for _, path := range []string{"missing.md", "docs"} {
+		_, _, err := client.Contents.Find(context.Background(), "octocat/hello-world", path, "master")
+		if err != scm.ErrNotFound {
+			t.Errorf("Want scm.ErrNotFound for %s, got %v", path, err)
+		}
+	}
+}
+
+func TestContentList(t *testing.T) {
+	server, s := newServer(t, true)
+	defer server.Close()
+
+	client, _ := New(server.URL)
+	got, _, err := client.Contents.List(context.Background(), "octocat/hello-world", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Path != "README.md" || got[0].Type != "file" || got[1].Path != "docs" || got[1].Type != "dir" {
+		t.Errorf("Unexpected entries %v", got)
+	}
+
+	got, _, err = client.Contents.List(context.Background(), "octocat/hello-world", "docs", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Path != "docs/index.md" {
+		t.Errorf("Unexpected entries %v", got)
+	}
+	if s.blobs != 0 {
+		t.Errorf("Expect the trees to be listed without fetching blobs")
+	}
+}
+
+func TestContentExists(t *testing.T) {
+	server, _ := newServer(t, true)
+	defer server.Close()
+
+	client, _ := New(server.URL)
+	for path, want := range map[string]bool{"docs/index.md": true, "docs": true, "missing.md": false} {
+		got, _, err := client.Contents.Exists(context.Background(), "octocat/hello-world", path, "master")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("Want exists %v for %s, got %v", want, path, got)
+		}
+	}
+}
+
+func TestContentCreate_NotSupported(t *testing.T) {
+	client, _ := New("https://seed.radicle.xyz")
+	_, err := client.Contents.Create(context.Background(), "octocat/hello-world", "README.md", &scm.ContentParams{})
+	if !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error")
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package githttp
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/internal/paging"
+)
+
+type gitService struct {
+	client *wrapper
+}
+
+func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *scm.Response, error) {
+	refs, res, err := s.client.advertise(ctx, repo)
+	if err != nil {
+		return "", res, err
+	}
+	for _, name := range scm.QualifyRefs(ref) {
+		if hash, ok := lookup(refs, name); ok {
+			return hash.String(), res, nil
+		}
+	}
+	return "", res, scm.ErrNotFound
+}
+
+// ResolveRefs resolves the refs from a single advertisement
+// of the refs of the repository.
+func (s *gitService) ResolveRefs(ctx context.Context, repo string, refs []string) (map[string]string, map[string]error) {
+	shas := map[string]string{}
+	errs := map[string]error{}
+	advertised, _, err := s.client.advertise(ctx, repo)
+	for _, ref := range refs {
+		if err != nil {
+			errs[ref] = err
+			continue
+		}
+		errs[ref] = scm.ErrNotFound
+		for _, name := range scm.QualifyRefs(ref) {
+			if hash, ok := lookup(advertised, name); ok {
+				shas[ref] = hash.String()
+				delete(errs, ref)
+				break
+			}
+		}
+	}
+	return shas, errs
+}
+
+func (s *gitService) CreateRef(context.Context, string, string, string) (*scm.Reference, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "CreateRef")
+}
+
+func (s *gitService) UpdateRef(context.Context, string, string, string, bool) (*scm.Reference, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "UpdateRef")
+}
+
+func (s *gitService) DeleteRef(context.Context, string, string) (*scm.Response, error) {
+	return nil, notSupported("Git", "DeleteRef")
+}
+
+func (s *gitService) FindBranch(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
+	return s.findRef(ctx, repo, scm.ExpandRef(name, "refs/heads"))
+}
+
+func (s *gitService) FindTag(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
+	return s.findRef(ctx, repo, scm.ExpandRef(name, "refs/tags"))
+}
+
+func (s *gitService) findRef(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
+	refs, res, err := s.client.advertise(ctx, repo)
+	if err != nil {
+		return nil, res, err
+	}
+	hash, ok := lookup(refs, name)
+	if !ok {
+		return nil, res, scm.ErrNotFound
+	}
+	return convertReference(name, hash), res, nil
+}
+
+func (s *gitService) FindCommit(ctx context.Context, repo, ref string) (*scm.Commit, *scm.Response, error) {
+	commit, _, res, err := s.client.commit(ctx, repo, ref, 1)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertCommit(commit), res, nil
+}
+
+func (s *gitService) ListBranches(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
+	return s.listRefs(ctx, repo, "refs/heads/", opts)
+}
+
+func (s *gitService) ListTags(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
+	return s.listRefs(ctx, repo, "refs/tags/", opts)
+}
+
+// listRefs returns the page of the advertised refs with the
+// prefix, sorted by name.
+func (s *gitService) listRefs(ctx context.Context, repo, prefix string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
+	refs, res, err := s.client.advertise(ctx, repo)
+	if err != nil {
+		return nil, res, err
+	}
+	out := []*scm.Reference{}
+	for name := range refs.References {
+		if strings.HasPrefix(name, prefix) {
+			hash, _ := lookup(refs, name)
+			out = append(out, convertReference(name, hash))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	start, end := paging.Bounds(opts.Page, opts.Size, len(out))
+	return out[start:end], res, nil
+}
+
+// ListCommits returns the page of the history of the ref,
+// newest first. The history is fetched down to the last
+// commit of the page, or entirely if the size is zero.
+func (s *gitService) ListCommits(ctx context.Context, repo string, opts scm.CommitListOptions) ([]*scm.Commit, *scm.Response, error) {
+	ref := opts.Sha
+	if ref == "" {
+		ref = opts.Ref
+	}
+	start, end := paging.Bounds(opts.Page, opts.Size, int(^uint(0)>>1))
+	depth := end
+	if opts.Page == 0 || opts.Size == 0 {
+		depth = 0
+	}
+	commit, _, res, err := s.client.commit(ctx, repo, ref, depth)
+	if err != nil {
+		return nil, res, err
+	}
+	commits, err := walk(commit, end)
+	if err != nil {
+		return nil, res, err
+	}
+	if start > len(commits) {
+		start = len(commits)
+	}
+	out := []*scm.Commit{}
+	for _, v := range commits[start:] {
+		out = append(out, convertCommit(v))
+	}
+	return out, res, nil
+}
+
+// ListChanges returns the files changed by the commit, from
+// its first parent. Only the trees of the commit and its
+// parent are fetched.
+func (s *gitService) ListChanges(ctx context.Context, repo, ref string, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
+	commit, _, res, err := s.client.commit(ctx, repo, ref, 2)
+	if err != nil {
+		return nil, res, err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, res, err
+	}
+	parentTree := &object.Tree{}
+	if len(commit.ParentHashes) != 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, res, err
+		}
+		parentTree, err = parent.Tree()
+		if err != nil {
+			return nil, res, err
+		}
+	}
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, res, err
+	}
+	out := []*scm.Change{}
+	for _, change := range changes {
+		dst, err := convertChange(change)
+		if err != nil {
+			return nil, res, err
+		}
+		out = append(out, dst)
+	}
+	start, end := paging.Bounds(opts.Page, opts.Size, len(out))
+	return out[start:end], res, nil
+}
+
+func (s *gitService) CompareCommits(context.Context, string, string, string) (*scm.Comparison, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "CompareCommits")
+}
+
+func (s *gitService) CompareAcrossForks(context.Context, string, string, string, string) (*scm.Comparison, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "CompareAcrossForks")
+}
+
+// commit fetches the commit the ref points at, with its
+// trees and the history down to the depth, and returns it
+// with the advertised refs.
+func (c *wrapper) commit(ctx context.Context, repo, ref string, depth int) (*object.Commit, *packp.AdvRefs, *scm.Response, error) {
+	refs, res, err := c.advertise(ctx, repo)
+	if err != nil {
+		return nil, nil, res, err
+	}
+	hash, err := resolve(refs, ref)
+	if err != nil {
+		return nil, nil, res, err
+	}
+	objects, res, err := c.fetch(ctx, repo, refs.Capabilities, []plumbing.Hash{hash}, depth, false)
+	if err != nil {
+		return nil, nil, res, err
+	}
+	commit, err := object.GetCommit(objects, hash)
+	if err == plumbing.ErrObjectNotFound {
+		return nil, nil, res, scm.ErrNotFound
+	}
+	return commit, refs, res, err
+}
+
+// walk returns the commits reachable from the commit, newest
+// first, up to the limit. The parents beyond the depth of a
+// shallow fetch are skipped.
+func walk(from *object.Commit, limit int) ([]*object.Commit, error) {
+	seen := map[plumbing.Hash]bool{from.Hash: true}
+	queue := []*object.Commit{from}
+	var out []*object.Commit
+	for len(queue) != 0 && len(out) < limit {
+		next := 0
+		for i, v := range queue {
+			if v.Committer.When.After(queue[next].Committer.When) {
+				next = i
+			}
+		}
+		commit := queue[next]
+		queue = append(queue[:next], queue[next+1:]...)
+		out = append(out, commit)
+
+		for i, hash := range commit.ParentHashes {
+			if seen[hash] {
+				continue
+			}
+			seen[hash] = true
+			parent, err := commit.Parent(i)
+			if err == plumbing.ErrObjectNotFound {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			queue = append(queue, parent)
+		}
+	}
+	return out, nil
+}
+
+func convertReference(name string, hash plumbing.Hash) *scm.Reference {
+	return &scm.Reference{
+		Name: scm.TrimRef(name),
+		Path: name,
+		Sha:  hash.String(),
+	}
+}
+
+func convertCommit(from *object.Commit) *scm.Commit {
+	return &scm.Commit{
+		Sha:     from.Hash.String(),
+		Message: from.Message,
+		Tree: scm.CommitTree{
+			Sha: from.TreeHash.String(),
+		},
+		Author: scm.Signature{
+			Name:  from.Author.Name,
+			Email: from.Author.Email,
+			Date:  from.Author.When,
+		},
+		Committer: scm.Signature{
+			Name:  from.Committer.Name,
+			Email: from.Committer.Email,
+			Date:  from.Committer.When,
+		},
+	}
+}
+
+func convertChange(from *object.Change) (*scm.Change, error) {
+	action, err := from.Action()
+	if err != nil {
+		return nil, err
+	}
+	switch action {
+	case merkletrie.Insert:
+		return &scm.Change{Path: from.To.Name, Added: true, Sha: from.To.TreeEntry.Hash.String()}, nil
+	case merkletrie.Delete:
+		return &scm.Change{Path: from.From.Name, Deleted: true, Sha: from.From.TreeEntry.Hash.String()}, nil
+	default:
+		return &scm.Change{Path: from.To.Name, Sha: from.To.TreeEntry.Hash.String()}, nil
+	}
+}
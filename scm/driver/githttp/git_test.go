@@ -0,0 +1,176 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package githttp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestGitFindRef(t *testing.T) {
+	server, s := newServer(t, true)
+	defer server.Close()
+	head, _ := s.repo.Head()
+
+	client, _ := New(server.URL)
+	for _, ref := range []string{"master", "refs/heads/master", "v1.0.0"} {
+		got, _, err := client.Git.FindRef(context.Background(), "octocat/hello-world", ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := head.Hash().String(); got != want {
+			t.Errorf("Want sha %q of %s, got %q", want, ref, got)
+		}
+	}
+	if _, _, err := client.Git.FindRef(context.Background(), "octocat/hello-world", "missing"); err != scm.ErrNotFound {
+		t.Errorf("Want scm.ErrNotFound, got %v", err)
+	}
+	if s.fetches != 0 {
+		t.Errorf("Expect the refs to be resolved without fetching")
+	}
+}
+
+func TestGitResolveRefs(t *testing.T) {
+	server, s := newServer(t, true)
+	defer server.Close()
+	head, _ := s.repo.Head()
+
+	client, _ := New(server.URL)
+	shas, errs := client.Git.ResolveRefs(context.Background(), "octocat/hello-world", []string{"master", "missing"})
+	if got, want := shas["master"], head.Hash().String(); got != want {
+		t.Errorf("Want sha %q, got %q", want, got)
+	}
+	if errs["missing"] != scm.ErrNotFound || len(errs) != 1 {
+		t.Errorf("Unexpected errors %v", errs)
+	}
+}
+
+func TestGitListBranches(t *testing.T) {
+	server, _ := newServer(t, true)
+	defer server.Close()
+
+	client, _ := New(server.URL)
+	got, _, err := client.Git.ListBranches(context.Background(), "octocat/hello-world", scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Name != "feature" || got[1].Name != "master" || got[1].Path != "refs/heads/master" {
+		t.Errorf("Unexpected branches %v", got)
+	}
+
+	got, _, err = client.Git.ListBranches(context.Background(), "octocat/hello-world", scm.ListOptions{Page: 2, Size: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Name != "master" {
+		t.Errorf("Unexpected page of branches %v", got)
+	}
+}
+
+func TestGitFindTag(t *testing.T) {
+	server, s := newServer(t, true)
+	defer server.Close()
+	head, _ := s.repo.Head()
+
+	client, _ := New(server.URL)
+	got, _, err := client.Git.FindTag(context.Background(), "octocat/hello-world", "v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &scm.Reference{
+		Name: "v1.0.0",
+		Path: "refs/tags/v1.0.0",
+		Sha:  head.Hash().String(),
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitFindCommit(t *testing.T) {
+	server, s := newServer(t, true)
+	defer server.Close()
+	head, _ := s.repo.Head()
+	commit, _ := s.repo.CommitObject(head.Hash())
+
+	client, _ := New(server.URL)
+	got, _, err := client.Git.FindCommit(context.Background(), "octocat/hello-world", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Sha != commit.Hash.String() || got.Message != commit.Message || got.Tree.Sha != commit.TreeHash.String() {
+		t.Errorf("Unexpected commit %+v", got)
+	}
+	if s.blobs != 0 {
+		t.Errorf("Expect the commit to be fetched without blobs")
+	}
+}
+
+func TestGitListCommits(t *testing.T) {
+	server, s := newServer(t, true)
+	defer server.Close()
+
+	client, _ := New(server.URL)
+	got, _, err := client.Git.ListCommits(context.Background(), "octocat/hello-world", scm.CommitListOptions{Ref: "master"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[0].Message != "update docs/index.md" || got[2].Message != "update README.md" {
+		t.Errorf("Unexpected commits %v", got)
+	}
+
+	got, _, err = client.Git.ListCommits(context.Background(), "octocat/hello-world", scm.CommitListOptions{Ref: "master", Page: 2, Size: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, _ := s.repo.Head()
+	commit, _ := s.repo.CommitObject(head.Hash())
+	if len(got) != 1 || got[0].Sha != commit.ParentHashes[0].String() {
+		t.Errorf("Unexpected page of commits %v", got)
+	}
+}
+
+func TestGitListChanges(t *testing.T) {
+	server, _ := newServer(t, true)
+	defer server.Close()
+
+	client, _ := New(server.URL)
+	got, _, err := client.Git.ListChanges(context.Background(), "octocat/hello-world", "master", scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Path != "docs/index.md" || got[0].Added || got[0].Deleted {
+		t.Errorf("Unexpected changes %v", got)
+	}
+
+	got, _, err = client.Git.ListChanges(context.Background(), "octocat/hello-world", "feature", scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Path != "docs/index.md" || !got[0].Added {
+		t.Errorf("Unexpected changes %v", got)
+	}
+}
+
+func TestGitCreateRef_NotSupported(t *testing.T) {
+	client, _ := New("https://seed.radicle.xyz")
+	_, _, err := client.Git.CreateRef(context.Background(), "octocat/hello-world", "feature", "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+	if !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error")
+	}
+	want := &scm.NotSupportedError{Driver: scm.DriverGitHTTP, Service: "Git", Method: "CreateRef"}
+	if diff := cmp.Diff(err, error(want)); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if got, want := err.Error(), "githttp: Git.CreateRef: Not Supported"; got != want {
+		t.Errorf("Want error %q, got %q", want, got)
+	}
+}
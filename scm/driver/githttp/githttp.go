@@ -0,0 +1,278 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package githttp implements a read-only client for the git
+// servers with no REST API, such as Radicle seed nodes, cgit
+// or git http-backend, speaking the smart HTTP protocol.
+//
+// The refs are read from the advertisement of the upload-pack
+// service, as with git ls-remote, and the commits and trees
+// are fetched into memory with a shallow fetch. When the
+// server supports partial clone, the blobs are filtered out
+// and each file is fetched by its sha, so the contents are
+// read without cloning the repository. The repository is the
+// path of the repository on the server, with or without the
+// .git suffix.
+//
+// The fetches are POST requests, which are intercepted by a
+// client in dry run mode.
+package githttp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/format/pktline"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/capability"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/slimm609/go-scm/scm"
+)
+
+// Reference Protocol Documentation:
+//   https://git-scm.com/docs/http-protocol
+//   https://git-scm.com/docs/pack-protocol
+
+// agent is the agent capability sent to the server.
+const agent = "go-scm"
+
+// capabilityFilter is the capability of the servers supporting partial
+// clone, which go-git v5.2.0 does not define.
+const capabilityFilter capability.Capability = "filter"
+
+// New returns a new client for the git server. The uri is
+// the address the repositories are served under, such as
+// https://seed.radicle.xyz.
+func New(uri string) (*scm.Client, error) {
+	base, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path = base.Path + "/"
+	}
+	client := &wrapper{new(scm.Client)}
+	client.BaseURL = base
+	// initialize services
+	client.Driver = scm.DriverGitHTTP
+	client.Contents = &contentService{client}
+	client.Git = &gitService{client}
+	return client.Client, nil
+}
+
+// wraper wraps the Client to provide high level helper functions
+// for speaking the smart HTTP protocol.
+type wrapper struct {
+	*scm.Client
+}
+
+// advertise returns the refs advertised by the upload-pack
+// service of the repository. The refs of an empty repository
+// are empty.
+func (c *wrapper) advertise(ctx context.Context, repo string) (*packp.AdvRefs, *scm.Response, error) {
+	req := &scm.Request{
+		Method: "GET",
+		Path:   endpoint(repo) + "/info/refs?service=git-upload-pack",
+	}
+	res, err := c.do(ctx, req)
+	if err != nil {
+		return nil, res, err
+	}
+	defer res.Body.Close()
+
+	// the dumb protocol serves the refs as plain text, which
+	// the objects cannot be fetched with.
+	if !strings.HasPrefix(res.Header.Get("Content-Type"), "application/x-git-upload-pack-advertisement") {
+		return nil, res, fmt.Errorf("githttp: %s does not support the smart HTTP protocol", repo)
+	}
+
+	out := packp.NewAdvRefs()
+	err = out.Decode(res.Body)
+	if err == packp.ErrEmptyAdvRefs {
+		return packp.NewAdvRefs(), res, nil
+	}
+	return out, res, err
+}
+
+// fetch fetches the wanted objects into a new in-memory
+// storage, with the history down to the depth, or all of it
+// if the depth is zero. The blobs are filtered out unless
+// blobs is set, if the server supports partial clone.
+func (c *wrapper) fetch(ctx context.Context, repo string, caps *capability.List, wants []plumbing.Hash, depth int, blobs bool) (*memory.Storage, *scm.Response, error) {
+	shallow := depth > 0 && caps.Supports(capability.Shallow)
+	filter := !blobs && canFilter(caps)
+
+	// the request holds the wants, with the capabilities on
+	// the first line, and the shallow and filter options.
+	// The client has no objects, so no haves are sent.
+	requested := []string{string(capability.Agent) + "=" + agent}
+	for _, c := range []capability.Capability{capability.OFSDelta, capability.NoProgress} {
+		if caps.Supports(c) {
+			requested = append(requested, string(c))
+		}
+	}
+	if shallow {
+		requested = append(requested, string(capability.Shallow))
+	}
+	if filter {
+		requested = append(requested, string(capabilityFilter))
+	}
+	buf := new(bytes.Buffer)
+	enc := pktline.NewEncoder(buf)
+	for i, want := range wants {
+		if i == 0 {
+			enc.Encodef("want %s %s\n", want, strings.Join(requested, " "))
+		} else {
+			enc.Encodef("want %s\n", want)
+		}
+	}
+	if shallow {
+		enc.Encodef("deepen %d\n", depth)
+	}
+	if filter {
+		enc.Encodef("filter blob:none\n")
+	}
+	enc.Flush()
+	enc.Encodef("done\n")
+
+	req := &scm.Request{
+		Method: "POST",
+		Path:   endpoint(repo) + "/git-upload-pack",
+		Header: http.Header{
+			"Content-Type": {"application/x-git-upload-pack-request"},
+			"Accept":       {"application/x-git-upload-pack-result"},
+		},
+		Body: buf,
+	}
+	res, err := c.do(ctx, req)
+	if err != nil {
+		return nil, res, err
+	}
+	defer res.Body.Close()
+
+	// the response holds the shallow commits of a shallow
+	// fetch and the acknowledgement, followed by the
+	// packfile.
+	r := bufio.NewReader(res.Body)
+	if shallow {
+		if err := new(packp.ShallowUpdate).Decode(r); err != nil {
+			return nil, res, err
+		}
+	}
+	if err := new(packp.ServerResponse).Decode(r, false); err != nil {
+		return nil, res, err
+	}
+	out := memory.NewStorage()
+	if err := packfile.UpdateObjectStorage(out, r); err != nil {
+		return nil, res, err
+	}
+	return out, res, nil
+}
+
+// do executes the http request, mapping the error status
+// codes to errors.
+func (c *wrapper) do(ctx context.Context, req *scm.Request) (*scm.Response, error) {
+	res, err := c.Client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case res.Status == http.StatusNotFound:
+		res.Body.Close()
+		return res, scm.ErrNotFound
+	case res.Status == http.StatusUnauthorized:
+		res.Body.Close()
+		return res, scm.ErrNotAuthorized
+	case res.Status > 300:
+		defer res.Body.Close()
+		body, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+		return res, &Error{
+			Message: strings.TrimSpace(string(body)),
+			status:  res.Status,
+		}
+	}
+	return res, nil
+}
+
+// canFilter returns true if the server supports partial
+// clone, and serves the filtered out objects by sha.
+func canFilter(caps *capability.List) bool {
+	return caps.Supports(capabilityFilter) && caps.Supports(capability.AllowReachableSHA1InWant)
+}
+
+// endpoint returns the path of the repository, with the
+// .git suffix.
+func endpoint(repo string) string {
+	return strings.TrimSuffix(strings.Trim(repo, "/"), ".git") + ".git"
+}
+
+// resolve returns the commit the ref points at. The ref may
+// be a commit sha, HEAD, or a ref in any of the forms
+// accepted by FindRef. The tags are peeled to their commit.
+func resolve(refs *packp.AdvRefs, ref string) (plumbing.Hash, error) {
+	if ref == "" || ref == "HEAD" {
+		if refs.Head == nil {
+			return plumbing.ZeroHash, scm.ErrNotFound
+		}
+		return *refs.Head, nil
+	}
+	if isHash(ref) {
+		return plumbing.NewHash(ref), nil
+	}
+	for _, name := range scm.QualifyRefs(ref) {
+		if hash, ok := lookup(refs, name); ok {
+			return hash, nil
+		}
+	}
+	return plumbing.ZeroHash, scm.ErrNotFound
+}
+
+// lookup returns the hash of the fully qualified ref, peeled
+// to the commit of an annotated tag.
+func lookup(refs *packp.AdvRefs, name string) (plumbing.Hash, bool) {
+	if hash, ok := refs.Peeled[name]; ok {
+		return hash, true
+	}
+	hash, ok := refs.References[name]
+	return hash, ok
+}
+
+// isHash returns true if s is a full hex encoded sha1.
+func isHash(s string) bool {
+	if len(s) != 40 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// Error represents an error response of the git server.
+type Error struct {
+	Message string
+
+	status int
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return http.StatusText(e.status)
+	}
+	return e.Message
+}
+
+// notSupported returns the error of a method of a service
+// the driver does not support.
+func notSupported(service, method string) error {
+	return &scm.NotSupportedError{Driver: scm.DriverGitHTTP, Service: service, Method: method}
+}
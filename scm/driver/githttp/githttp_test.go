@@ -0,0 +1,280 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package githttp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/format/pktline"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/capability"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/local"
+)
+
+func TestClient(t *testing.T) {
+	client, err := New("https://seed.radicle.xyz")
+	if err != nil {
+		t.Error(err)
+	}
+	if got, want := client.BaseURL.String(), "https://seed.radicle.xyz/"; got != want {
+		t.Errorf("Want Client URL %q, got %q", want, got)
+	}
+	if got, want := client.Driver, scm.DriverGitHTTP; got != want {
+		t.Errorf("Want Driver %v, got %v", want, got)
+	}
+}
+
+func TestClient_Error(t *testing.T) {
+	_, err := New("http://a b.com/")
+	if err == nil {
+		t.Errorf("Expect error when invalid URL")
+	}
+}
+
+func TestClient_NotFound(t *testing.T) {
+	server, _ := newServer(t, true)
+	defer server.Close()
+
+	client, _ := New(server.URL)
+	_, _, err := client.Git.FindBranch(context.Background(), "octocat/missing", "master")
+	if err != scm.ErrNotFound {
+		t.Errorf("Want scm.ErrNotFound, got %v", err)
+	}
+}
+
+func TestClient_DumbProtocol(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprintln(w, "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d\trefs/heads/master")
+	}))
+	defer server.Close()
+
+	client, _ := New(server.URL)
+	_, _, err := client.Git.FindBranch(context.Background(), "octocat/hello-world", "master")
+	if err == nil {
+		t.Errorf("Expect error when the server does not support the smart protocol")
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	tests := []struct {
+		repo string
+		want string
+	}{
+		{"octocat/hello-world", "octocat/hello-world.git"},
+		{"octocat/hello-world.git", "octocat/hello-world.git"},
+		{"/z3gqcJUoA1n9HaHKufZs5FCSGazv5/", "z3gqcJUoA1n9HaHKufZs5FCSGazv5.git"},
+	}
+	for _, test := range tests {
+		if got := endpoint(test.repo); got != test.want {
+			t.Errorf("Want endpoint %q for %q, got %q", test.want, test.repo, got)
+		}
+	}
+}
+
+// server is a smart HTTP git server serving the repository
+// octocat/hello-world, which counts the fetched objects.
+type server struct {
+	repo   *git.Repository
+	filter bool
+
+	mu      sync.Mutex
+	fetches int
+	blobs   int
+}
+
+// newServer returns a test server for a repository with
+// three commits on master, a tag and a branch. The filter
+// option enables partial clone.
+func newServer(t *testing.T, filter bool) (*httptest.Server, *server) {
+	repo, err := git.Init(memory.NewStorage(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, _ := local.New(func(string) (*git.Repository, error) {
+		return repo, nil
+	})
+	files := []struct {
+		path string
+		data string
+	}{
+		{"README.md", "Hello World\n"},
+		{"docs/index.md", "# Docs\n"},
+		{"docs/index.md", "# Documentation\n"},
+	}
+	for _, file := range files {
+		params := &scm.ContentParams{
+			Message: "update " + file.path,
+			Data:    []byte(file.data),
+		}
+		_, err := client.Contents.Create(context.Background(), "octocat/hello-world", file.path, params)
+		if err != nil {
+			_, err = client.Contents.Update(context.Background(), "octocat/hello-world", file.path, params)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = repo.CreateTag("v1.0.0", head.Hash(), &git.CreateTagOptions{
+		Tagger:  &object.Signature{Name: "octocat", Email: "octocat@example.com", When: time.Now()},
+		Message: "v1.0.0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = repo.Storer.SetReference(plumbing.NewHashReference("refs/heads/feature", parent.ParentHashes[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &server{repo: repo, filter: filter}
+	return httptest.NewServer(s), s
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/octocat/hello-world.git/info/refs":
+		s.advertise(w, r)
+	case r.Method == "POST" && r.URL.Path == "/octocat/hello-world.git/git-upload-pack":
+		s.uploadPack(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *server) advertise(w http.ResponseWriter, r *http.Request) {
+	refs := packp.NewAdvRefs()
+	refs.Prefix = [][]byte{[]byte("# service=git-upload-pack"), pktline.Flush}
+	refs.Capabilities.Set(capability.OFSDelta)
+	refs.Capabilities.Set(capability.Shallow)
+	if s.filter {
+		refs.Capabilities.Set(capabilityFilter)
+		refs.Capabilities.Set(capability.AllowReachableSHA1InWant)
+	}
+	head, _ := s.repo.Head()
+	hash := head.Hash()
+	refs.Head = &hash
+	iter, _ := s.repo.References()
+	iter.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		refs.References[ref.Name().String()] = ref.Hash()
+		if tag, err := s.repo.TagObject(ref.Hash()); err == nil {
+			refs.Peeled[ref.Name().String()] = tag.Target
+		}
+		return nil
+	})
+	w.Header().Set("Content-Type", "application/x-git-upload-pack-advertisement")
+	refs.Encode(w)
+}
+
+func (s *server) uploadPack(w http.ResponseWriter, r *http.Request) {
+	var wants []plumbing.Hash
+	var depth int
+	var filter bool
+	scanner := pktline.NewScanner(r.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(string(scanner.Bytes()))
+		switch {
+		case strings.HasPrefix(line, "want "):
+			wants = append(wants, plumbing.NewHash(line[5:45]))
+		case strings.HasPrefix(line, "deepen "):
+			depth, _ = strconv.Atoi(line[7:])
+		case line == "filter blob:none":
+			filter = true
+		}
+	}
+
+	st := s.repo.Storer
+	seen := map[plumbing.Hash]bool{}
+	var objects, shallows []plumbing.Hash
+	add := func(hash plumbing.Hash) {
+		if !seen[hash] {
+			seen[hash] = true
+			objects = append(objects, hash)
+		}
+	}
+	var addTree func(hash plumbing.Hash)
+	addTree = func(hash plumbing.Hash) {
+		add(hash)
+		tree, _ := object.GetTree(st, hash)
+		for _, entry := range tree.Entries {
+			if entry.Mode == filemode.Dir {
+				addTree(entry.Hash)
+			} else if !filter {
+				add(entry.Hash)
+			}
+		}
+	}
+	for _, want := range wants {
+		commit, err := object.GetCommit(st, want)
+		if err != nil {
+			// a blob wanted by its sha.
+			add(want)
+			s.mu.Lock()
+			s.blobs++
+			s.mu.Unlock()
+			continue
+		}
+		level := map[plumbing.Hash]int{commit.Hash: 1}
+		queue := []*object.Commit{commit}
+		for len(queue) != 0 {
+			commit, queue = queue[0], queue[1:]
+			add(commit.Hash)
+			addTree(commit.TreeHash)
+			if depth != 0 && level[commit.Hash] == depth {
+				if len(commit.ParentHashes) != 0 {
+					shallows = append(shallows, commit.Hash)
+				}
+				continue
+			}
+			commit.Parents().ForEach(func(parent *object.Commit) error {
+				if _, ok := level[parent.Hash]; !ok {
+					level[parent.Hash] = level[commit.Hash] + 1
+					queue = append(queue, parent)
+				}
+				return nil
+			})
+		}
+	}
+	s.mu.Lock()
+	s.fetches++
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/x-git-upload-pack-result")
+	enc := pktline.NewEncoder(w)
+	if depth != 0 {
+		for _, hash := range shallows {
+			enc.Encodef("shallow %s\n", hash)
+		}
+		enc.Flush()
+	}
+	enc.Encodef("NAK\n")
+	packfile.NewEncoder(w, st, false).Encode(objects, 10)
+}
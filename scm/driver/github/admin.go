@@ -0,0 +1,100 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// adminService implements the administration endpoints of
+// GitHub Enterprise Server, which are not available on
+// github.com.
+type adminService struct {
+	client *wrapper
+}
+
+type enterpriseStats struct {
+	Repos struct {
+		TotalRepos int `json:"total_repos"`
+	} `json:"repos"`
+	Users struct {
+		TotalUsers int `json:"total_users"`
+	} `json:"users"`
+	Orgs struct {
+		TotalOrgs int `json:"total_orgs"`
+	} `json:"orgs"`
+	Issues struct {
+		TotalIssues int `json:"total_issues"`
+	} `json:"issues"`
+	Pulls struct {
+		TotalPulls int `json:"total_pulls"`
+	} `json:"pulls"`
+}
+
+type enterpriseLicense struct {
+	Seats     interface{} `json:"seats"`
+	SeatsUsed int         `json:"seats_used"`
+	Kind      string      `json:"kind"`
+	ExpireAt  string      `json:"expire_at"`
+}
+
+// Stats returns the statistics of the server.
+//
+// See https://docs.github.com/en/enterprise-server@latest/rest/enterprise-admin/admin-stats
+func (s *adminService) Stats(ctx context.Context) (*scm.SiteStats, *scm.Response, error) {
+	out := new(enterpriseStats)
+	res, err := s.client.do(ctx, "GET", "enterprise/stats/all", nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return &scm.SiteStats{
+		Users:         out.Users.TotalUsers,
+		Organizations: out.Orgs.TotalOrgs,
+		Repositories:  out.Repos.TotalRepos,
+		Issues:        out.Issues.TotalIssues,
+		PullRequests:  out.Pulls.TotalPulls,
+	}, res, nil
+}
+
+// License returns the license of the server.
+//
+// See https://docs.github.com/en/enterprise-server@latest/rest/enterprise-admin/license
+func (s *adminService) License(ctx context.Context) (*scm.License, *scm.Response, error) {
+	out := new(enterpriseLicense)
+	res, err := s.client.do(ctx, "GET", "enterprise/settings/license", nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	license := &scm.License{
+		Plan:      out.Kind,
+		SeatsUsed: out.SeatsUsed,
+	}
+	// seats is the string "unlimited" for unlimited licenses.
+	if seats, ok := out.Seats.(float64); ok {
+		license.Seats = int(seats)
+	}
+	if out.ExpireAt != "" {
+		license.Expires, err = time.Parse("2006/01/02 15:04:05 -0700", out.ExpireAt)
+		if err != nil {
+			return nil, res, err
+		}
+		license.Expires = license.Expires.UTC()
+	}
+	return license, res, nil
+}
+
+// ListHooks returns the global webhooks of the server.
+//
+// See https://docs.github.com/en/enterprise-server@latest/rest/enterprise-admin/global-webhooks
+func (s *adminService) ListHooks(ctx context.Context, opts scm.ListOptions) ([]*scm.Hook, *scm.Response, error) {
+	path := fmt.Sprintf("admin/hooks?%s", encodeListOptions(opts))
+	out := []*hook{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertHookList(out), res, err
+}
@@ -0,0 +1,119 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+)
+
+func TestAdminStats(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/enterprise/stats/all").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/admin_stats.json")
+
+	client := NewDefault()
+	got, res, err := client.Admin.Stats(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := &scm.SiteStats{
+		Users:         254,
+		Organizations: 33,
+		Repositories:  212,
+		Issues:        179,
+		PullRequests:  86,
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestAdminLicense(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/enterprise/settings/license").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/admin_license.json")
+
+	client := NewDefault()
+	got, _, err := client.Admin.License(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := &scm.License{
+		Plan:      "standard",
+		Seats:     1400,
+		SeatsUsed: 1316,
+		Expires:   time.Date(2016, time.February, 6, 18, 41, 52, 0, time.UTC),
+	}
+	if !got.Expires.Equal(want.Expires) {
+		t.Errorf("Want expiry %s, got %s", want.Expires, got.Expires)
+	}
+	if got.Expires.Location() != time.UTC {
+		t.Errorf("Want expiry in UTC, got %s", got.Expires.Location())
+	}
+	got.Expires = want.Expires
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestAdminHookList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/admin/hooks").
+		MatchParam("page", "1").
+		MatchParam("per_page", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeaders(mockPageHeaders).
+		File("testdata/hooks.json")
+
+	client := NewDefault()
+	got, res, err := client.Admin.ListHooks(context.Background(), scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Hook{}
+	raw, _ := ioutil.ReadFile("testdata/hooks.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Page", testPage(res))
+}
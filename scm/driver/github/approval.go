@@ -0,0 +1,108 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type workflowRunList struct {
+	WorkflowRuns []*workflowRun `json:"workflow_runs"`
+}
+
+type workflowRun struct {
+	ID         int       `json:"id"`
+	Name       string    `json:"name"`
+	HeadBranch string    `json:"head_branch"`
+	HeadSha    string    `json:"head_sha"`
+	HTMLURL    string    `json:"html_url"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type pendingDeployment struct {
+	Environment struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"environment"`
+	CurrentUserCanApprove bool `json:"current_user_can_approve"`
+	Reviewers             []struct {
+		Type     string `json:"type"`
+		Reviewer struct {
+			Login string `json:"login"`
+			Slug  string `json:"slug"`
+		} `json:"reviewer"`
+	} `json:"reviewers"`
+}
+
+type pendingDeploymentInput struct {
+	EnvironmentIDs []int  `json:"environment_ids"`
+	State          string `json:"state"`
+	Comment        string `json:"comment"`
+}
+
+// ListPendingApprovals returns the pending deployments of
+// the workflow runs waiting for a review. The options
+// paginate the workflow runs.
+func (s *deploymentService) ListPendingApprovals(ctx context.Context, repoFullName string, opts scm.ListOptions) ([]*scm.DeploymentApproval, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/actions/runs?status=waiting&%s", repoFullName, encodeListOptions(opts))
+	runs := new(workflowRunList)
+	res, err := s.client.do(ctx, "GET", path, nil, runs)
+	if err != nil {
+		return nil, res, err
+	}
+	to := []*scm.DeploymentApproval{}
+	for _, run := range runs.WorkflowRuns {
+		path := fmt.Sprintf("repos/%s/actions/runs/%d/pending_deployments", repoFullName, run.ID)
+		out := []*pendingDeployment{}
+		if _, err := s.client.do(ctx, "GET", path, nil, &out); err != nil {
+			return nil, res, err
+		}
+		for _, pending := range out {
+			to = append(to, convertPendingDeployment(pending, run))
+		}
+	}
+	return to, res, nil
+}
+
+func (s *deploymentService) ApproveDeployment(ctx context.Context, repoFullName string, approval *scm.DeploymentApproval, comment string) (*scm.Response, error) {
+	return s.reviewDeployment(ctx, repoFullName, approval, "approved", comment)
+}
+
+func (s *deploymentService) RejectDeployment(ctx context.Context, repoFullName string, approval *scm.DeploymentApproval, comment string) (*scm.Response, error) {
+	return s.reviewDeployment(ctx, repoFullName, approval, "rejected", comment)
+}
+
+func (s *deploymentService) reviewDeployment(ctx context.Context, repoFullName string, approval *scm.DeploymentApproval, state, comment string) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/actions/runs/%s/pending_deployments", repoFullName, approval.ID)
+	in := &pendingDeploymentInput{
+		EnvironmentIDs: []int{approval.EnvironmentID},
+		State:          state,
+		Comment:        comment,
+	}
+	return s.client.do(ctx, "POST", path, in, nil)
+}
+
+func convertPendingDeployment(from *pendingDeployment, run *workflowRun) *scm.DeploymentApproval {
+	to := &scm.DeploymentApproval{
+		ID:            strconv.Itoa(run.ID),
+		Name:          run.Name,
+		Environment:   from.Environment.Name,
+		EnvironmentID: from.Environment.ID,
+		Ref:           run.HeadBranch,
+		Sha:           run.HeadSha,
+		Link:          run.HTMLURL,
+		CanApprove:    from.CurrentUserCanApprove,
+		Created:       run.CreatedAt,
+	}
+	for _, reviewer := range from.Reviewers {
+		if reviewer.Type == "Team" {
+			to.Reviewers = append(to.Reviewers, reviewer.Reviewer.Slug)
+		} else {
+			to.Reviewers = append(to.Reviewers, reviewer.Reviewer.Login)
+		}
+	}
+	return to
+}
@@ -0,0 +1,100 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestDeploymentListPendingApprovals(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/actions/runs").
+		MatchParam("status", "waiting").
+		MatchParam("page", "1").
+		MatchParam("per_page", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeaders(mockPageHeaders).
+		File("testdata/workflow_runs_waiting.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/actions/runs/30433642/pending_deployments").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pending_deployments.json")
+
+	client := NewDefault()
+	got, res, err := client.Deployments.ListPendingApprovals(context.Background(), "octocat/hello-world", scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.DeploymentApproval{}
+	raw, _ := ioutil.ReadFile("testdata/pending_deployments.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+	t.Run("Page", testPage(res))
+}
+
+func TestDeploymentApprove(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/repos/octocat/hello-world/actions/runs/30433642/pending_deployments").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString("[]")
+
+	approval := &scm.DeploymentApproval{ID: "30433642", EnvironmentID: 161088068}
+
+	client := NewDefault()
+	res, err := client.Deployments.ApproveDeployment(context.Background(), "octocat/hello-world", approval, "Ship it!")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestDeploymentReject(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/repos/octocat/hello-world/actions/runs/30433642/pending_deployments").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString("[]")
+
+	approval := &scm.DeploymentApproval{ID: "30433642", EnvironmentID: 161088068}
+
+	client := NewDefault()
+	res, err := client.Deployments.RejectDeployment(context.Background(), "octocat/hello-world", approval, "Not now")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
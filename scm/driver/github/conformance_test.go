@@ -0,0 +1,134 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/conformance"
+	"github.com/slimm609/go-scm/scm/transport"
+
+	"github.com/h2non/gock"
+)
+
+func TestConformance(t *testing.T) {
+	if repo, branch, ok := conformance.FromEnvironment(); ok {
+		client := NewDefault()
+		client.Client = &http.Client{
+			Transport: &transport.BearerToken{
+				Token: os.Getenv(conformance.EnvToken),
+			},
+		}
+		conformance.Run(t, conformance.Config{
+			Client: client,
+			Repo:   repo,
+			Branch: branch,
+		})
+		return
+	}
+
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world-does-not-exist$").
+		Persist().
+		Reply(404).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/error.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/repo.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/branches/master$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/branch.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/branches$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/branches.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/tags$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/tags.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/commits/master$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/commit.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/git/refs/heads/master$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/ref.json")
+
+	conformance.Run(t, conformance.Config{
+		Client: NewDefault(),
+		Repo:   "octocat/hello-world",
+		Branch: "master",
+	})
+}
+
+func TestConformanceWebhooks(t *testing.T) {
+	header := func(event string) http.Header {
+		return http.Header{
+			"X-Github-Event":    {event},
+			"X-Github-Delivery": {"ee8d97b4-1479-43f1-9cac-fbbd1b80da55"},
+		}
+	}
+	body := func(file string) []byte {
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return raw
+	}
+	conformance.RunWebhooks(t, NewDefault().Webhooks, map[string]conformance.WebhookFixture{
+		"push": {
+			Header: header("push"),
+			Body:   body("testdata/webhooks/push.json"),
+			Kind:   scm.WebhookKindPush,
+		},
+		"pull_request_opened": {
+			Header: header("pull_request"),
+			Body:   body("testdata/webhooks/pr_opened.json"),
+			Kind:   scm.WebhookKindPullRequest,
+			Action: scm.ActionOpen,
+		},
+		"pull_request_closed": {
+			Header: header("pull_request"),
+			Body:   body("testdata/webhooks/pr_closed.json"),
+			Kind:   scm.WebhookKindPullRequest,
+			Action: scm.ActionClose,
+		},
+	})
+}
@@ -5,30 +5,139 @@
 package github
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
+	"reflect"
+	"strings"
 	"time"
 
+	githubql "github.com/shurcooL/githubv4"
 	"github.com/slimm609/go-scm/scm"
 )
 
+// findManyBatchSize is the maximum number of files fetched
+// in a single GraphQL query.
+const findManyBatchSize = 50
+
 type contentService struct {
 	client *wrapper
 }
 
 func (s *contentService) Find(ctx context.Context, repo, path, ref string) (*scm.Content, *scm.Response, error) {
 	endpoint := fmt.Sprintf("repos/%s/contents/%s?ref=%s", repo, path, ref)
+	if scm.ContentDataOnly(ctx) {
+		return s.findRaw(ctx, endpoint, path)
+	}
 	out := new(content)
 	res, err := s.client.do(ctx, "GET", endpoint, nil, out)
-	raw, _ := base64.StdEncoding.DecodeString(out.Content)
 	return &scm.Content{
 		Path: out.Path,
-		Data: raw,
+		Data: out.Content,
 		Sha:  out.Sha,
 	}, res, err
 }
 
+// findRaw downloads the file with the raw media type, which
+// is not base64 encoded and has no sha.
+func (s *contentService) findRaw(ctx context.Context, endpoint, path string) (*scm.Content, *scm.Response, error) {
+	req := &scm.Request{
+		Method: "GET",
+		Path:   endpoint,
+		Header: map[string][]string{
+			"Accept": {"application/vnd.github.raw"},
+		},
+	}
+	out := new(bytes.Buffer)
+	res, err := s.client.doRequest(ctx, req, nil, out)
+	return &scm.Content{
+		Path: path,
+		Data: out.Bytes(),
+	}, res, err
+}
+
+// FindMany fetches the files in batches using aliased GraphQL
+// object lookups when the GraphQL client is enabled, and falls
+// back to concurrent REST requests otherwise. Binary and
+// truncated blobs are always fetched using the REST API.
+func (s *contentService) FindMany(ctx context.Context, repo, ref string, paths []string) (map[string]*scm.Content, map[string]error) {
+	if !s.client.graphQLEnabled() {
+		return scm.FindManyContents(ctx, s, repo, ref, paths, scm.DefaultConcurrency)
+	}
+	contents := map[string]*scm.Content{}
+	errs := map[string]error{}
+	var fallback []string
+	for start := 0; start < len(paths); start += findManyBatchSize {
+		end := start + findManyBatchSize
+		if end > len(paths) {
+			end = len(paths)
+		}
+		batch := paths[start:end]
+		blobs, err := s.queryBlobs(ctx, repo, ref, batch)
+		if err != nil {
+			fallback = append(fallback, batch...)
+			continue
+		}
+		for i, path := range batch {
+			blob := blobs[i]
+			switch {
+			case blob == nil:
+				errs[path] = scm.ErrNotFound
+			case blob.Blob.Text == nil || blob.Blob.IsTruncated:
+				fallback = append(fallback, path)
+			default:
+				contents[path] = &scm.Content{
+					Path: path,
+					Data: []byte(*blob.Blob.Text),
+					Sha:  blob.Blob.Oid,
+				}
+			}
+		}
+	}
+	if len(fallback) != 0 {
+		more, moreErrs := scm.FindManyContents(ctx, s, repo, ref, fallback, scm.DefaultConcurrency)
+		for path, content := range more {
+			contents[path] = content
+		}
+		for path, err := range moreErrs {
+			errs[path] = err
+		}
+	}
+	return contents, errs
+}
+
+// queryBlobs looks up the blobs at the given paths in a single
+// GraphQL query, using an aliased object field for each path.
+// The returned slice is index aligned with paths, with nil
+// entries for paths that do not exist. An empty ref selects
+// the default branch, like the REST API.
+func (s *contentService) queryBlobs(ctx context.Context, repo, ref string, paths []string) ([]*blobObject, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	owner, name := scm.Split(repo)
+	vars := map[string]interface{}{
+		"owner": githubql.String(owner),
+		"name":  githubql.String(name),
+	}
+	for i, path := range paths {
+		vars[fmt.Sprintf("e%d", i)] = githubql.String(ref + ":" + path)
+	}
+	query := newRepositoryQuery(reflect.TypeOf(&blobObject{}), "f%d: object(expression: $e%d)", len(paths))
+	if err := s.client.GraphQL.Query(ctx, query.Interface(), vars); err != nil {
+		return nil, err
+	}
+	repository := query.Elem().Field(0)
+	blobs := make([]*blobObject, len(paths))
+	for i := range paths {
+		blobs[i], _ = repository.Field(i).Interface().(*blobObject)
+	}
+	return blobs, nil
+}
+
 func (s *contentService) List(ctx context.Context, repo, path, ref string) ([]*scm.FileEntry, *scm.Response, error) {
 	endpoint := fmt.Sprintf("repos/%s/contents/%s?ref=%s", repo, path, ref)
 	out := []*entry{}
@@ -36,6 +145,36 @@ func (s *contentService) List(ctx context.Context, repo, path, ref string) ([]*s
 	return convertEntryList(out), res, err
 }
 
+func (s *contentService) Exists(ctx context.Context, repo, path, ref string) (bool, *scm.Response, error) {
+	endpoint := fmt.Sprintf("repos/%s/contents/%s?ref=%s", repo, path, ref)
+	res, err := s.client.do(ctx, "HEAD", endpoint, nil, nil)
+	if err == scm.ErrNotFound {
+		return false, res, nil
+	}
+	return err == nil, res, err
+}
+
+// Stat returns the file metadata from the listing of the
+// parent directory, since the contents endpoint embeds the
+// encoded content of a file.
+func (s *contentService) Stat(ctx context.Context, repo, path, ref string) (*scm.FileEntry, *scm.Response, error) {
+	path = strings.Trim(path, "/")
+	dir := ""
+	if i := strings.LastIndex(path, "/"); i != -1 {
+		dir = path[:i]
+	}
+	entries, res, err := s.List(ctx, repo, dir, ref)
+	if err != nil {
+		return nil, res, err
+	}
+	for _, entry := range entries {
+		if entry.Path == path {
+			return entry, res, nil
+		}
+	}
+	return nil, res, scm.ErrNotFound
+}
+
 func (s *contentService) Create(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
 	endpoint := fmt.Sprintf("repos/%s/contents/%s", repo, path)
 	body := &contentBody{
@@ -60,14 +199,96 @@ func (s *contentService) Update(ctx context.Context, repo, path string, params *
 }
 
 func (s *contentService) Delete(ctx context.Context, repo, path, ref string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Contents", "Delete")
 }
 
 type content struct {
-	Name    string `json:"name"`
-	Path    string `json:"path"`
-	Sha     string `json:"sha"`
-	Content string `json:"content"`
+	Name    string     `json:"name"`
+	Path    string     `json:"path"`
+	Sha     string     `json:"sha"`
+	Content base64Data `json:"content"`
+}
+
+// base64Data is the file content, which is base64 encoded in
+// the JSON string. It is decoded from the JSON document as a
+// stream, without copying the encoded content to a string.
+type base64Data []byte
+
+func (d *base64Data) UnmarshalJSON(data []byte) error {
+	if len(data) < 2 || data[0] != '"' {
+		return nil
+	}
+	src := &base64Reader{data: data[1 : len(data)-1]}
+	size := len(src.data)
+	for i := 0; i < len(src.data); i++ {
+		if src.data[i] != '\\' {
+			continue
+		}
+		// the base64 alphabet has no escaped characters, only
+		// the line breaks are escaped.
+		if c := src.data[i+1]; c != 'n' && c != 'r' {
+			return d.unmarshalString(data)
+		}
+		size -= 2
+		i++
+	}
+	out := make([]byte, base64.StdEncoding.DecodedLen(size))
+	n, err := io.ReadFull(base64.NewDecoder(base64.StdEncoding, src), out)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return err
+	}
+	*d = out[:n]
+	return nil
+}
+
+// base64Reader reads the base64 content of a JSON string
+// without its escaped line breaks.
+type base64Reader struct {
+	data []byte
+}
+
+func (r *base64Reader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) && len(r.data) != 0 {
+		if r.data[0] == '\\' {
+			r.data = r.data[2:]
+			continue
+		}
+		end := bytes.IndexByte(r.data, '\\')
+		if end == -1 {
+			end = len(r.data)
+		}
+		m := copy(p[n:], r.data[:end])
+		r.data = r.data[m:]
+		n += m
+	}
+	if n == 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// unmarshalString decodes the content with unusual escape
+// sequences through a string.
+func (d *base64Data) unmarshalString(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	out, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	*d = out
+	return nil
+}
+
+type blobObject struct {
+	Blob struct {
+		Oid         string
+		Text        *string
+		IsTruncated bool
+	} `graphql:"... on Blob"`
 }
 
 type entry struct {
@@ -8,11 +8,14 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
+	"reflect"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/h2non/gock"
+	githubql "github.com/shurcooL/githubv4"
 	"github.com/slimm609/go-scm/scm"
 )
 
@@ -93,6 +96,316 @@ func TestContentList(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestContentStat(t *testing.T) {
+	defer gock.Off()
+
+	file := gock.New("https://api.github.com").
+		Get("/repos/octokit/octokit.rb/contents/lib/octokit.rb")
+	file.Reply(500)
+
+	gock.New("https://api.github.com").
+		Get("/repos/octokit/octokit.rb/contents/lib").
+		MatchParam("ref", "master").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/content_list.json")
+
+	client := NewDefault()
+	got, res, err := client.Contents.Stat(
+		context.Background(),
+		"octokit/octokit.rb",
+		"lib/octokit.rb",
+		"master",
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.FileEntry)
+	raw, _ := ioutil.ReadFile("testdata/content_stat.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if file.Mock.Done() {
+		t.Errorf("Want the file metadata without requesting its contents")
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestContentStat_NotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octokit/octokit.rb/contents/lib").
+		MatchParam("ref", "master").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/content_list.json")
+
+	client := NewDefault()
+	_, _, err := client.Contents.Stat(
+		context.Background(),
+		"octokit/octokit.rb",
+		"lib/missing.rb",
+		"master",
+	)
+	if err != scm.ErrNotFound {
+		t.Errorf("Expect Not Found error, got %v", err)
+	}
+}
+
+func TestContentStat_Dir(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octokit/octokit.rb/contents/lib").
+		MatchParam("ref", "master").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/content_list.json")
+
+	client := NewDefault()
+	got, _, err := client.Contents.Stat(
+		context.Background(),
+		"octokit/octokit.rb",
+		"lib/octokit",
+		"master",
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got.Type != "dir" || got.Path != "lib/octokit" {
+		t.Errorf("Unexpected entry %+v", got)
+	}
+}
+
+func TestContentExists(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Head("/repos/octocat/hello-world/contents/README").
+		MatchParam("ref", "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d").
+		Reply(200).
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	got, res, err := client.Contents.Exists(
+		context.Background(),
+		"octocat/hello-world",
+		"README",
+		"7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !got {
+		t.Errorf("Expect file exists")
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestContentExists_NotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Head("/repos/octocat/hello-world/contents/MISSING").
+		MatchParam("ref", "master").
+		Reply(404).
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	got, _, err := client.Contents.Exists(
+		context.Background(),
+		"octocat/hello-world",
+		"MISSING",
+		"master",
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got {
+		t.Errorf("Expect file does not exist")
+	}
+}
+
+func TestContentFind_Data(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/contents/README").
+		MatchParam("ref", "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d").
+		MatchHeader("Accept", "application/vnd.github.raw").
+		Reply(200).
+		SetHeaders(mockHeaders).
+		BodyString("Hello World!\n")
+
+	client := NewDefault()
+	got, _, err := client.Contents.Find(
+		scm.WithContentData(context.Background()),
+		"octocat/hello-world",
+		"README",
+		"7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := &scm.Content{
+		Path: "README",
+		Data: []byte("Hello World!\n"),
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestBase64Data(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{`"SGVsbG8g\nV29ybGQhCg==\n"`, "Hello World!\n"},
+		{`"SGVsbG8g\r\nV29ybGQhCg=="`, "Hello World!\n"},
+		{`"SGVsbG8gV29ybGQhCg\u003d\u003d"`, "Hello World!\n"},
+		{`""`, ""},
+		{`null`, ""},
+	}
+	for _, test := range tests {
+		var got base64Data
+		if err := json.Unmarshal([]byte(test.data), &got); err != nil {
+			t.Errorf("Want %s decoded, got %s", test.data, err)
+		} else if string(got) != test.want {
+			t.Errorf("Want %s decoded to %q, got %q", test.data, test.want, got)
+		}
+	}
+
+	var got base64Data
+	if err := json.Unmarshal([]byte(`"SGVsbG8*"`), &got); err == nil {
+		t.Errorf("Want error decoding invalid base64")
+	}
+}
+
+func TestContentFindMany(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/contents/README").
+		MatchParam("ref", "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/content.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/contents/MISSING").
+		MatchParam("ref", "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d").
+		Reply(404).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	got, errs := client.Contents.FindMany(
+		context.Background(),
+		"octocat/hello-world",
+		"7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+		[]string{"README", "MISSING"},
+	)
+
+	want := new(scm.Content)
+	raw, _ := ioutil.ReadFile("testdata/content.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got["README"], want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if got, want := errs["MISSING"], scm.ErrNotFound; got != want {
+		t.Errorf("Want error %v, got %v", want, got)
+	}
+}
+
+type mockBlobQuery struct {
+	vars map[string]interface{}
+}
+
+func (m *mockBlobQuery) Query(ctx context.Context, q interface{}, vars map[string]interface{}) error {
+	m.vars = vars
+	repository := reflect.ValueOf(q).Elem().Field(0)
+	text := "Hello World\n"
+	found := &blobObject{}
+	found.Blob.Oid = "980a0d5f19a64b4b30a87d4206aade58726b60e3"
+	found.Blob.Text = &text
+	repository.Field(0).Set(reflect.ValueOf(found))
+	return nil
+}
+
+func TestContentFindMany_GraphQL(t *testing.T) {
+	mock := new(mockBlobQuery)
+	client := NewDefault()
+	client.GraphQL = mock
+
+	got, errs := client.Contents.FindMany(
+		context.Background(),
+		"octocat/hello-world",
+		"master",
+		[]string{"README", "MISSING"},
+	)
+
+	want := &scm.Content{
+		Path: "README",
+		Data: []byte("Hello World\n"),
+		Sha:  "980a0d5f19a64b4b30a87d4206aade58726b60e3",
+	}
+	if diff := cmp.Diff(got["README"], want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if got, want := errs["MISSING"], scm.ErrNotFound; got != want {
+		t.Errorf("Want error %v, got %v", want, got)
+	}
+	if got, want := mock.vars["e1"], interface{}(githubql.String("master:MISSING")); got != want {
+		t.Errorf("Want expression %v, got %v", want, got)
+	}
+}
+
+func TestContentFindMany_GraphQLDefaultBranch(t *testing.T) {
+	mock := new(mockBlobQuery)
+	client := NewDefault()
+	client.GraphQL = mock
+
+	got, errs := client.Contents.FindMany(
+		context.Background(),
+		"octocat/hello-world",
+		"",
+		[]string{"README"},
+	)
+	if err := errs["README"]; err != nil {
+		t.Fatal(err)
+	}
+	if got["README"] == nil {
+		t.Fatalf("Want the file found on the default branch")
+	}
+	if got, want := mock.vars["e0"], interface{}(githubql.String("HEAD:README")); got != want {
+		t.Errorf("Want expression %v, got %v", want, got)
+	}
+}
+
 func TestContentCreate(t *testing.T) {
 	defer gock.Off()
 	message := "just a test message"
@@ -155,7 +468,7 @@ func TestContentUpdate(t *testing.T) {
 func TestContentDelete(t *testing.T) {
 	content := new(contentService)
 	_, err := content.Delete(context.Background(), "octocat/hello-world", "README", "master")
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
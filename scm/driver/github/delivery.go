@@ -0,0 +1,55 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type hookDelivery struct {
+	ID          int64     `json:"id"`
+	GUID        string    `json:"guid"`
+	DeliveredAt time.Time `json:"delivered_at"`
+	Redelivery  bool      `json:"redelivery"`
+	Duration    float64   `json:"duration"`
+	Status      string    `json:"status"`
+	StatusCode  int       `json:"status_code"`
+	Event       string    `json:"event"`
+	Action      string    `json:"action"`
+}
+
+func (s *repositoryService) ListHookDeliveries(ctx context.Context, repo, hookID string, opts scm.ListOptions) ([]*scm.HookDelivery, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/hooks/%s/deliveries?%s", repo, hookID, encodeListOptions(opts))
+	out := []*hookDelivery{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertHookDeliveryList(out), res, err
+}
+
+func (s *repositoryService) RedeliverHookDelivery(ctx context.Context, repo, hookID string, deliveryID int64) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/hooks/%s/deliveries/%d/attempts", repo, hookID, deliveryID)
+	return s.client.do(ctx, "POST", path, nil, nil)
+}
+
+func convertHookDeliveryList(from []*hookDelivery) []*scm.HookDelivery {
+	to := []*scm.HookDelivery{}
+	for _, v := range from {
+		to = append(to, convertHookDelivery(v))
+	}
+	return to
+}
+
+func convertHookDelivery(from *hookDelivery) *scm.HookDelivery {
+	return &scm.HookDelivery{
+		ID:         from.ID,
+		GUID:       from.GUID,
+		Event:      from.Event,
+		Action:     from.Action,
+		Status:     from.Status,
+		StatusCode: from.StatusCode,
+		Redelivery: from.Redelivery,
+		Duration:   time.Duration(from.Duration * float64(time.Second)),
+		Delivered:  from.DeliveredAt,
+	}
+}
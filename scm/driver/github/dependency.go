@@ -0,0 +1,105 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// sbom is the SPDX document returned by the dependency
+// graph export endpoint.
+type sbom struct {
+	DocumentDescribes []string `json:"documentDescribes"`
+	Packages          []struct {
+		SPDXID           string `json:"SPDXID"`
+		Name             string `json:"name"`
+		VersionInfo      string `json:"versionInfo"`
+		LicenseConcluded string `json:"licenseConcluded"`
+		LicenseDeclared  string `json:"licenseDeclared"`
+		ExternalRefs     []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+// GetDependencyGraph returns the packages of the
+// dependency graph SBOM, excluding the repository itself.
+func (s *repositoryService) GetDependencyGraph(ctx context.Context, repo string) (*scm.DependencyGraph, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/dependency-graph/sbom", repo)
+	out := new(struct {
+		SBOM sbom `json:"sbom"`
+	})
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertSBOM(&out.SBOM), res, err
+}
+
+// ExportSBOM returns the dependency graph of the repository
+// as an SPDX JSON document.
+func (s *repositoryService) ExportSBOM(ctx context.Context, repo string) (io.ReadCloser, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/dependency-graph/sbom", repo)
+	out := new(struct {
+		SBOM json.RawMessage `json:"sbom"`
+	})
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(out.SBOM)), res, nil
+}
+
+func convertSBOM(from *sbom) *scm.DependencyGraph {
+	root := map[string]bool{}
+	for _, id := range from.DocumentDescribes {
+		root[id] = true
+	}
+	to := &scm.DependencyGraph{Dependencies: []*scm.Dependency{}}
+	for _, p := range from.Packages {
+		if root[p.SPDXID] {
+			continue
+		}
+		dep := &scm.Dependency{
+			Name:    p.Name,
+			Version: p.VersionInfo,
+			License: convertSPDXLicense(p.LicenseConcluded),
+		}
+		if dep.License == "" {
+			dep.License = convertSPDXLicense(p.LicenseDeclared)
+		}
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				dep.PURL = ref.ReferenceLocator
+				dep.Ecosystem = purlType(ref.ReferenceLocator)
+			}
+		}
+		to.Dependencies = append(to.Dependencies, dep)
+	}
+	return to
+}
+
+// convertSPDXLicense drops the NOASSERTION placeholder.
+func convertSPDXLicense(from string) string {
+	if from == "NOASSERTION" {
+		return ""
+	}
+	return from
+}
+
+// purlType returns the type of a package URL, such as npm
+// for pkg:npm/lodash@4.17.21.
+func purlType(purl string) string {
+	if !strings.HasPrefix(purl, "pkg:") {
+		return ""
+	}
+	purl = strings.TrimPrefix(purl, "pkg:")
+	if i := strings.Index(purl, "/"); i != -1 {
+		return purl[:i]
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestRepositoryGetDependencyGraph(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/dependency-graph/sbom").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/sbom.json")
+
+	client := NewDefault()
+	got, res, err := client.Repositories.GetDependencyGraph(context.Background(), "octocat/hello-world")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.DependencyGraph)
+	raw, _ := ioutil.ReadFile("testdata/sbom.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestRepositoryExportSBOM(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/dependency-graph/sbom").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/sbom.json")
+
+	client := NewDefault()
+	body, res, err := client.Repositories.ExportSBOM(context.Background(), "octocat/hello-world")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer body.Close()
+
+	doc := map[string]interface{}{}
+	if err := json.NewDecoder(body).Decode(&doc); err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := doc["spdxVersion"], "SPDX-2.3"; got != want {
+		t.Errorf("Want spdxVersion %q, got %q", want, got)
+	}
+	if got, want := doc["SPDXID"], "SPDXRef-DOCUMENT"; got != want {
+		t.Errorf("Want SPDXID %q, got %q", want, got)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestPurlType(t *testing.T) {
+	tests := []struct {
+		purl, want string
+	}{
+		{"pkg:npm/%40rails/actioncable@7.0.0", "npm"},
+		{"pkg:pypi/requests@2.31.0", "pypi"},
+		{"pkg:golang", ""},
+		{"npm/lodash", ""},
+		{"", ""},
+	}
+	for _, test := range tests {
+		if got := purlType(test.purl); got != test.want {
+			t.Errorf("Want type %q for %q, got %q", test.want, test.purl, got)
+		}
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/slimm609/go-scm/scm"
@@ -164,15 +163,14 @@ func convertDeployment(from *deployment, fullName string) *scm.Deployment {
 		RepositoryLink:        from.RepositoryLink,
 		StatusLink:            from.StatusLink,
 		Author:                convertUser(from.Author),
-		Created:               from.Created,
-		Updated:               from.Updated,
+		Created:               from.Created.UTC(),
+		Updated:               from.Updated.UTC(),
 		TransientEnvironment:  from.TransientEnvironment,
 		ProductionEnvironment: from.ProductionEnvironment,
 	}
-	names := strings.Split(fullName, "/")
-	if len(names) > 1 {
-		dst.Namespace = names[0]
-		dst.Name = names[1]
+	if r, err := scm.ParseRepo(fullName); err == nil {
+		dst.Namespace = r.Namespace
+		dst.Name = r.Name
 	}
 	return dst
 }
@@ -189,8 +187,8 @@ func convertDeploymentStatus(from *deploymentStatus) *scm.DeploymentStatus {
 		LogLink:         from.LogLink,
 		RepositoryLink:  from.RepositoryLink,
 		TargetLink:      from.TargetLink,
-		Created:         from.Created,
-		Updated:         from.Updated,
+		Created:         from.Created.UTC(),
+		Updated:         from.Updated.UTC(),
 	}
 }
 
@@ -8,8 +8,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
 	"time"
 
+	githubql "github.com/shurcooL/githubv4"
 	"github.com/slimm609/go-scm/scm"
 )
 
@@ -35,49 +38,135 @@ func (s *gitService) FindCommit(ctx context.Context, repo, ref string) (*scm.Com
 //
 // See https://developer.github.com/v3/git/refs/#get-a-reference
 func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *scm.Response, error) {
-	path := fmt.Sprintf("repos/%s/git/refs/%s", repo, ref)
-	var out struct {
-		Object map[string]string `json:"object"`
+	var res *scm.Response
+	var err error
+	for _, qualified := range scm.QualifyRefs(ref) {
+		path := fmt.Sprintf("repos/%s/git/%s", repo, qualified)
+		var out struct {
+			Object map[string]string `json:"object"`
+		}
+		res, err = s.client.do(ctx, "GET", path, nil, &out)
+		if err == nil {
+			return out.Object["sha"], res, nil
+		}
+		if err != scm.ErrNotFound {
+			break
+		}
 	}
-	res, err := s.client.do(ctx, "GET", path, nil, &out)
-	return out.Object["sha"], res, err
+	return "", res, err
+}
+
+// ResolveRefs resolves the refs in batches using aliased
+// GraphQL ref lookups when the GraphQL client is enabled, and
+// falls back to concurrent REST requests otherwise.
+func (s *gitService) ResolveRefs(ctx context.Context, repo string, refs []string) (map[string]string, map[string]error) {
+	if !s.client.graphQLEnabled() {
+		return scm.ResolveRefs(ctx, s, repo, refs, scm.DefaultConcurrency)
+	}
+	shas := map[string]string{}
+	errs := map[string]error{}
+	var fallback []string
+	for start := 0; start < len(refs); start += findManyBatchSize {
+		end := start + findManyBatchSize
+		if end > len(refs) {
+			end = len(refs)
+		}
+		batch := refs[start:end]
+		targets, err := s.queryRefs(ctx, repo, batch)
+		if err != nil {
+			fallback = append(fallback, batch...)
+			continue
+		}
+		for i, ref := range batch {
+			if targets[i] == nil && len(scm.QualifyRefs(ref)) > 1 {
+				// an unqualified name may be a tag.
+				fallback = append(fallback, ref)
+				continue
+			}
+			if targets[i] == nil {
+				errs[ref] = scm.ErrNotFound
+				continue
+			}
+			shas[ref] = targets[i].Target.Oid
+		}
+	}
+	if len(fallback) != 0 {
+		more, moreErrs := scm.ResolveRefs(ctx, s, repo, fallback, scm.DefaultConcurrency)
+		for ref, sha := range more {
+			shas[ref] = sha
+		}
+		for ref, err := range moreErrs {
+			errs[ref] = err
+		}
+	}
+	return shas, errs
+}
+
+// queryRefs looks up the refs in a single GraphQL query, using
+// an aliased ref field for each ref. The returned slice is index
+// aligned with refs, with nil entries for refs that do not exist.
+func (s *gitService) queryRefs(ctx context.Context, repo string, refs []string) ([]*refObject, error) {
+	owner, name := scm.Split(repo)
+	vars := map[string]interface{}{
+		"owner": githubql.String(owner),
+		"name":  githubql.String(name),
+	}
+	for i, ref := range refs {
+		vars[fmt.Sprintf("r%d", i)] = githubql.String(scm.QualifyRef(ref))
+	}
+	query := newRepositoryQuery(reflect.TypeOf(&refObject{}), "f%d: ref(qualifiedName: $r%d)", len(refs))
+	if err := s.client.GraphQL.Query(ctx, query.Interface(), vars); err != nil {
+		return nil, err
+	}
+	repository := query.Elem().Field(0)
+	targets := make([]*refObject, len(refs))
+	for i := range refs {
+		targets[i], _ = repository.Field(i).Interface().(*refObject)
+	}
+	return targets, nil
 }
 
 // CreateRef creates a new ref
+//
+// See https://developer.github.com/v3/git/refs/#create-a-reference
 func (s *gitService) CreateRef(ctx context.Context, repo, ref, sha string) (*scm.Reference, *scm.Response, error) {
 	path := fmt.Sprintf("repos/%s/git/refs", repo)
 	in := &struct {
 		Ref string `json:"ref"`
 		Sha string `json:"sha"`
-	}{Ref: ref, Sha: sha}
-
-	out := &struct {
-		Ref    string `json:"ref"`
-		Object struct {
-			Sha string
-		} `json:"object"`
-	}{}
+	}{Ref: scm.QualifyRef(ref), Sha: sha}
 
+	out := new(reference)
 	res, err := s.client.do(ctx, http.MethodPost, path, in, out)
-	scmRef := &scm.Reference{
-		Name: out.Ref,
-		Sha:  out.Object.Sha,
-		Path: out.Ref,
-	}
-	return scmRef, res, err
+	return convertReference(out), res, err
+}
+
+// UpdateRef moves the given ref to the sha
+//
+// See https://developer.github.com/v3/git/refs/#update-a-reference
+func (s *gitService) UpdateRef(ctx context.Context, repo, ref, sha string, force bool) (*scm.Reference, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/git/refs/%s", repo, strings.TrimPrefix(scm.QualifyRef(ref), "refs/"))
+	in := &struct {
+		Sha   string `json:"sha"`
+		Force bool   `json:"force"`
+	}{Sha: sha, Force: force}
+
+	out := new(reference)
+	res, err := s.client.do(ctx, http.MethodPatch, path, in, out)
+	return convertReference(out), res, err
 }
 
 // DeleteRef deletes the given ref
 //
 // See https://developer.github.com/v3/git/refs/#delete-a-reference
 func (s *gitService) DeleteRef(ctx context.Context, repo, ref string) (*scm.Response, error) {
-	path := fmt.Sprintf("repos/%s/git/refs/%s", repo, ref)
+	path := fmt.Sprintf("repos/%s/git/refs/%s", repo, strings.TrimPrefix(scm.QualifyRef(ref), "refs/"))
 	res, err := s.client.do(ctx, http.MethodDelete, path, nil, nil)
 	return res, err
 }
 
 func (s *gitService) FindTag(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Git", "FindTag")
 }
 
 func (s *gitService) ListBranches(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
@@ -108,6 +197,50 @@ func (s *gitService) ListChanges(ctx context.Context, repo, ref string, _ scm.Li
 	return convertChangeList(out.Files), res, err
 }
 
+// CompareCommits compares the base ref with the head ref of the
+// repository.
+//
+// See https://docs.github.com/en/rest/commits/commits#compare-two-commits
+func (s *gitService) CompareCommits(ctx context.Context, repo, base, head string) (*scm.Comparison, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/compare/%s...%s", repo, base, head)
+	out := new(comparison)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertComparison(out), res, err
+}
+
+// CompareAcrossForks compares the base ref with the head ref of
+// the fork owned by headOwner.
+//
+// See https://docs.github.com/en/rest/commits/commits#compare-two-commits
+func (s *gitService) CompareAcrossForks(ctx context.Context, baseRepo, baseRef, headOwner, headRef string) (*scm.Comparison, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/compare/%s...%s:%s", baseRepo, baseRef, headOwner, headRef)
+	out := new(comparison)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertComparison(out), res, err
+}
+
+type refObject struct {
+	Target struct {
+		Oid string
+	}
+}
+
+type reference struct {
+	Ref    string `json:"ref"`
+	Object struct {
+		Sha string `json:"sha"`
+	} `json:"object"`
+}
+
+type comparison struct {
+	Status          string    `json:"status"`
+	AheadBy         int       `json:"ahead_by"`
+	BehindBy        int       `json:"behind_by"`
+	MergeBaseCommit commit    `json:"merge_base_commit"`
+	Commits         []*commit `json:"commits"`
+	Files           []*file   `json:"files"`
+}
+
 type branch struct {
 	Name      string `json:"name"`
 	Commit    commit `json:"commit"`
@@ -179,6 +312,25 @@ func convertCommit(from *commit) *scm.Commit {
 	}
 }
 
+func convertReference(from *reference) *scm.Reference {
+	return &scm.Reference{
+		Name: from.Ref,
+		Path: from.Ref,
+		Sha:  from.Object.Sha,
+	}
+}
+
+func convertComparison(from *comparison) *scm.Comparison {
+	return &scm.Comparison{
+		Status:    from.Status,
+		AheadBy:   from.AheadBy,
+		BehindBy:  from.BehindBy,
+		MergeBase: from.MergeBaseCommit.Sha,
+		Commits:   convertCommitList(from.Commits),
+		Changes:   convertChangeList(from.Files),
+	}
+}
+
 func convertBranchList(from []*branch) []*scm.Reference {
 	to := []*scm.Reference{}
 	for _, v := range from {
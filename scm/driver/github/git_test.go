@@ -7,14 +7,17 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
+	"reflect"
 	"testing"
 
 	"github.com/slimm609/go-scm/scm"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/h2non/gock"
+	githubql "github.com/shurcooL/githubv4"
 )
 
 func TestGitFindCommit(t *testing.T) {
@@ -47,6 +50,117 @@ func TestGitFindCommit(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestGitFindRef(t *testing.T) {
+	for _, ref := range []string{"master", "heads/master", "refs/heads/master"} {
+		t.Run(ref, func(t *testing.T) {
+			defer gock.Off()
+
+			gock.New("https://api.github.com").
+				Get("/repos/octocat/hello-world/git/refs/heads/master").
+				Reply(200).
+				Type("application/json").
+				SetHeaders(mockHeaders).
+				BodyString(`{"ref":"refs/heads/master","object":{"sha":"7fd1a60b01f91b314f59955a4e4d4e80d8edf11d","type":"commit"}}`)
+
+			client := NewDefault()
+			got, _, err := client.Git.FindRef(context.Background(), "octocat/hello-world", ref)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if want := "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"; got != want {
+				t.Errorf("Want sha %q, got %q", want, got)
+			}
+		})
+	}
+}
+
+func TestGitFindRef_Tag(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/git/refs/heads/v1.0.0").
+		Reply(404).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/git/refs/tags/v1.0.0").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"ref":"refs/tags/v1.0.0","object":{"sha":"7fd1a60b01f91b314f59955a4e4d4e80d8edf11d","type":"commit"}}`)
+
+	client := NewDefault()
+	got, _, err := client.Git.FindRef(context.Background(), "octocat/hello-world", "v1.0.0")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if want := "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"; got != want {
+		t.Errorf("Want sha %q, got %q", want, got)
+	}
+}
+
+type mockRefQuery struct {
+	vars map[string]interface{}
+}
+
+func (m *mockRefQuery) Query(ctx context.Context, q interface{}, vars map[string]interface{}) error {
+	m.vars = vars
+	repository := reflect.ValueOf(q).Elem().Field(0)
+	found := &refObject{}
+	found.Target.Oid = "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"
+	repository.Field(0).Set(reflect.ValueOf(found))
+	return nil
+}
+
+func TestGitResolveRefs_GraphQL(t *testing.T) {
+	mock := new(mockRefQuery)
+	client := NewDefault()
+	client.GraphQL = mock
+
+	got, errs := client.Git.ResolveRefs(context.Background(), "octocat/hello-world", []string{"master", "tags/v1.0.0"})
+	if got, want := got["master"], "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"; got != want {
+		t.Errorf("Want sha %q, got %q", want, got)
+	}
+	if got, want := errs["tags/v1.0.0"], scm.ErrNotFound; got != want {
+		t.Errorf("Want error %v, got %v", want, got)
+	}
+	if got, want := mock.vars["r0"], interface{}(githubql.String("refs/heads/master")); got != want {
+		t.Errorf("Want qualified name %v, got %v", want, got)
+	}
+	if got, want := mock.vars["r1"], interface{}(githubql.String("refs/tags/v1.0.0")); got != want {
+		t.Errorf("Want qualified name %v, got %v", want, got)
+	}
+}
+
+func TestGitResolveRefs(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/git/refs/heads/master").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"ref":"refs/heads/master","object":{"sha":"7fd1a60b01f91b314f59955a4e4d4e80d8edf11d","type":"commit"}}`)
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/git/refs/tags/missing").
+		Reply(404).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	got, errs := client.Git.ResolveRefs(context.Background(), "octocat/hello-world", []string{"master", "refs/tags/missing"})
+	if got, want := got["master"], "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"; got != want {
+		t.Errorf("Want sha %q, got %q", want, got)
+	}
+	if got, want := errs["refs/tags/missing"], scm.ErrNotFound; got != want {
+		t.Errorf("Want error %v, got %v", want, got)
+	}
+}
+
 func TestGitFindBranch(t *testing.T) {
 	defer gock.Off()
 
@@ -80,7 +194,7 @@ func TestGitFindBranch(t *testing.T) {
 func TestGitFindTag(t *testing.T) {
 	git := new(gitService)
 	_, _, err := git.FindTag(context.Background(), "octocat/hello-world", "v1.0")
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -218,18 +332,80 @@ func TestGitListChanges(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestGitCompareCommits(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/compare/master...topic").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/compare.json")
+
+	client := NewDefault()
+	got, res, err := client.Git.CompareCommits(context.Background(), "octocat/hello-world", "master", "topic")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Comparison)
+	raw, _ := ioutil.ReadFile("testdata/compare.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestGitCompareAcrossForks(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/compare/master...octocat-fork:topic").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/compare.json")
+
+	client := NewDefault()
+	got, res, err := client.Git.CompareAcrossForks(context.Background(), "octocat/hello-world", "master", "octocat-fork", "topic")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Comparison)
+	raw, _ := ioutil.ReadFile("testdata/compare.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
 func TestGitCreateRef(t *testing.T) {
 	defer gock.Off()
 
 	gock.New("https://api.github.com").
 		Post("/repos/octocat/hello-world/git/refs").
+		MatchType("json").
+		JSON(map[string]string{"ref": "refs/heads/testing", "sha": "aa218f56b14c9653891f9e74264a383fa43fefbd"}).
 		Reply(http.StatusCreated).
 		Type("application/json").
 		SetHeaders(mockHeaders).
 		File("testdata/ref.json")
 
 	client := NewDefault()
-	got, res, err := client.Git.CreateRef(context.Background(), "octocat/hello-world", "refs/heads/testing", "aa218f56b14c9653891f9e74264a383fa43fefbd")
+	got, res, err := client.Git.CreateRef(context.Background(), "octocat/hello-world", "testing", "aa218f56b14c9653891f9e74264a383fa43fefbd")
 	if err != nil {
 		t.Error(err)
 		return
@@ -247,3 +423,55 @@ func TestGitCreateRef(t *testing.T) {
 	t.Run("Request", testRequest(res))
 	t.Run("Rate", testRate(res))
 }
+
+func TestGitUpdateRef(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Patch("/repos/octocat/hello-world/git/refs/heads/featureA").
+		MatchType("json").
+		JSON(map[string]interface{}{"sha": "aa218f56b14c9653891f9e74264a383fa43fefbd", "force": true}).
+		Reply(http.StatusOK).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/ref.json")
+
+	client := NewDefault()
+	got, res, err := client.Git.UpdateRef(context.Background(), "octocat/hello-world", "refs/heads/featureA", "aa218f56b14c9653891f9e74264a383fa43fefbd", true)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := &scm.Reference{}
+	raw, _ := ioutil.ReadFile("testdata/ref.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestGitDeleteRef(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Delete("/repos/octocat/hello-world/git/refs/tags/v1.0.0").
+		Reply(http.StatusNoContent).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	res, err := client.Git.DeleteRef(context.Background(), "octocat/hello-world", "refs/tags/v1.0.0")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
@@ -10,8 +10,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -26,8 +28,28 @@ import (
 const maxRequestTime = 5 * time.Minute
 
 // NewWebHookService creates a new instance of the webhook service without the rest of the client
-func NewWebHookService() scm.WebhookService {
-	return &webhookService{nil}
+func NewWebHookService(opts ...scm.WebhookServiceOptions) scm.WebhookService {
+	s := &webhookService{}
+	if len(opts) > 0 {
+		s.opts = opts[0]
+	}
+	return s
+}
+
+// searchSortFields are the issue search sort fields, which GitHub
+// names like the normalized fields.
+var searchSortFields = map[string]string{
+	scm.SearchSortCreated:     "created",
+	scm.SearchSortUpdated:     "updated",
+	scm.SearchSortComments:    "comments",
+	"interactions":            "interactions",
+	"reactions":               "reactions",
+	"reactions-+1":            "reactions-+1",
+	"reactions--1":            "reactions--1",
+	"reactions-smile":         "reactions-smile",
+	"reactions-thinking_face": "reactions-thinking_face",
+	"reactions-heart":         "reactions-heart",
+	"reactions-tada":          "reactions-tada",
 }
 
 // New returns a new GitHub API client.
@@ -43,6 +65,12 @@ func New(uri string) (*scm.Client, error) {
 	client.BaseURL = base
 	// initialize services
 	client.Driver = scm.DriverGithub
+	client.SetCaller(client.do)
+	client.Limits = &scm.QueryLimits{
+		PageSizeParam: "per_page",
+		MaxPageSize:   100,
+		SortFields:    searchSortFields,
+	}
 	client.Contents = &contentService{client}
 	client.Deployments = &deploymentService{client}
 	client.Git = &gitService{client}
@@ -52,9 +80,14 @@ func New(uri string) (*scm.Client, error) {
 	client.PullRequests = &pullService{&issueService{client}}
 	client.Repositories = &repositoryService{client}
 	client.Reviews = &reviewService{client}
+	client.Runners = &runnerService{client}
+	client.Security = &securityService{client}
 	client.Users = &userService{client}
-	client.Webhooks = &webhookService{client}
+	client.Variables = &variableService{client}
+	client.Webhooks = &webhookService{client: client}
 	client.Apps = &appService{client}
+	client.Provisioning = &provisioningService{client}
+	client.Admin = &adminService{client}
 
 	graphqlEndpoint := scm.URLJoin(uri, "/graphql")
 	if strings.HasSuffix(uri, "/api/v3") {
@@ -93,6 +126,37 @@ func (d *dynamicGraphQLClient) Query(ctx context.Context, q interface{}, vars ma
 	return nil
 }
 
+// graphQLEnabled returns true if the GraphQL client is able to
+// send requests, which requires a configured http transport.
+func (c *wrapper) graphQLEnabled() bool {
+	d, ok := c.GraphQL.(*dynamicGraphQLClient)
+	if !ok {
+		return c.GraphQL != nil
+	}
+	httpClient := d.wrapper.Client.Client
+	return httpClient != nil && httpClient.Transport != nil
+}
+
+// newRepositoryQuery returns a pointer to a GraphQL query for
+// the repository with n aliased fields of the given type. Field
+// i is tagged using format, which receives i twice so that the
+// alias and the argument variable may be numbered.
+func newRepositoryQuery(typ reflect.Type, format string, n int) reflect.Value {
+	fields := make([]reflect.StructField, 0, n)
+	for i := 0; i < n; i++ {
+		fields = append(fields, reflect.StructField{
+			Name: fmt.Sprintf("F%d", i),
+			Type: typ,
+			Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"`+format+`"`, i, i)),
+		})
+	}
+	return reflect.New(reflect.StructOf([]reflect.StructField{{
+		Name: "Repository",
+		Type: reflect.StructOf(fields),
+		Tag:  `graphql:"repository(owner: $owner, name: $name)"`,
+	}}))
+}
+
 // NewDefault returns a new GitHub API client using the
 // default api.github.com address.
 func NewDefault() *scm.Client {
@@ -136,9 +200,6 @@ func (c *wrapper) doRequest(ctx context.Context, req *scm.Request, in, out inter
 	}
 	defer res.Body.Close()
 
-	// parse the github request id.
-	res.ID = res.Header.Get("X-GitHub-Request-Id")
-
 	// parse the github rate limit details.
 	res.Rate.Limit, _ = strconv.Atoi(
 		res.Header.Get("X-RateLimit-Limit"),
@@ -161,6 +222,9 @@ func (c *wrapper) doRequest(ctx context.Context, req *scm.Request, in, out inter
 		}
 		err := new(Error)
 		json.NewDecoder(res.Body).Decode(err)
+		if res.Status == 403 {
+			err.SSOURL = parseSSOURL(res.Header.Get("X-GitHub-SSO"))
+		}
 		return res, err
 	}
 
@@ -168,6 +232,20 @@ func (c *wrapper) doRequest(ctx context.Context, req *scm.Request, in, out inter
 		return res, nil
 	}
 
+	// if raw output is expected, copy to the provided
+	// buffer and exit.
+	if w, ok := out.(io.Writer); ok {
+		// the buffer is sized from the content length, up
+		// to the 100 MB limit of the raw files of GitHub.
+		if buf, ok := w.(*bytes.Buffer); ok {
+			if size, _ := strconv.Atoi(res.Header.Get("Content-Length")); size > 0 && size <= 100<<20 {
+				buf.Grow(size)
+			}
+		}
+		_, err := io.Copy(w, res.Body)
+		return res, err
+	}
+
 	// if a json response is expected, parse and return
 	// the json response.
 	return res, json.NewDecoder(res.Body).Decode(out)
@@ -176,8 +254,63 @@ func (c *wrapper) doRequest(ctx context.Context, req *scm.Request, in, out inter
 // Error represents a Github error.
 type Error struct {
 	Message string `json:"message"`
+
+	// SSOURL is the url authorizing the token for the SAML
+	// single sign-on of an organization, set when the request
+	// is rejected because the token is not authorized or its
+	// SAML session expired.
+	SSOURL string `json:"-"`
 }
 
 func (e *Error) Error() string {
 	return e.Message
 }
+
+// parseSSOURL returns the url of the X-GitHub-SSO header,
+// e.g. required; url=https://github.com/orgs/octo-org/sso?...
+func parseSSOURL(header string) string {
+	for _, part := range strings.Split(header, ";") {
+		if part = strings.TrimSpace(part); strings.HasPrefix(part, "url=") {
+			return strings.TrimPrefix(part, "url=")
+		}
+	}
+	return ""
+}
+
+// graphqlResponse is the envelope of a GraphQL response.
+type graphqlResponse struct {
+	Data   interface{} `json:"data"`
+	Errors []struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphql sends the GraphQL query or mutation as a plain http
+// request, decoding the response data into out. It is used for
+// the features only available through GraphQL, and reports the
+// http response unlike the GraphQL client.
+func (c *wrapper) graphql(ctx context.Context, query string, vars map[string]interface{}, out interface{}) (*scm.Response, error) {
+	in := map[string]interface{}{
+		"query":     query,
+		"variables": vars,
+	}
+	envelope := &graphqlResponse{Data: out}
+	res, err := c.do(ctx, "POST", c.GraphQLURL.String(), in, envelope)
+	if err != nil {
+		return res, err
+	}
+	if len(envelope.Errors) != 0 {
+		if envelope.Errors[0].Type == "NOT_FOUND" {
+			return res, scm.ErrNotFound
+		}
+		return res, &Error{Message: envelope.Errors[0].Message}
+	}
+	return res, nil
+}
+
+// notSupported returns the error of a method of a service
+// the driver does not support.
+func notSupported(service, method string) error {
+	return &scm.NotSupportedError{Driver: scm.DriverGithub, Service: service, Method: method}
+}
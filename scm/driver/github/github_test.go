@@ -5,8 +5,10 @@
 package github
 
 import (
+	"context"
 	"testing"
 
+	"github.com/h2non/gock"
 	"github.com/slimm609/go-scm/scm"
 )
 
@@ -78,6 +80,43 @@ func TestClient_Error(t *testing.T) {
 	}
 }
 
+func TestClient_Call(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Put("/repos/octocat/hello-world/topics").
+		MatchType("json").
+		JSON(map[string][]string{"names": {"octocat"}}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"names":["octocat"]}`)
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/missing/topics").
+		Reply(404).
+		Type("application/json").
+		BodyString(`{"message":"Not Found"}`)
+
+	client := NewDefault()
+	in := map[string][]string{"names": {"octocat"}}
+	out := new(struct {
+		Names []string `json:"names"`
+	})
+	res, err := client.Call(context.Background(), "PUT", "repos/octocat/hello-world/topics", in, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Names) != 1 || out.Names[0] != "octocat" {
+		t.Errorf("Want the topics decoded, got %v", out.Names)
+	}
+	t.Run("Rate", testRate(res))
+
+	if _, err := client.Call(context.Background(), "GET", "repos/octocat/missing/topics", nil, nil); err != scm.ErrNotFound {
+		t.Errorf("Want not found error, got %v", err)
+	}
+}
+
 func testRate(res *scm.Response) func(t *testing.T) {
 	return func(t *testing.T) {
 		if got, want := res.Rate.Limit, 60; got != want {
@@ -114,5 +153,8 @@ func testRequest(res *scm.Response) func(t *testing.T) {
 		if got, want := res.ID, "DD0E:6011:12F21A8:1926790:5A2064E2"; got != want {
 			t.Errorf("Want X-GitHub-Request-Id %q, got %q", want, got)
 		}
+		if got, want := res.RequestID, "DD0E:6011:12F21A8:1926790:5A2064E2"; got != want {
+			t.Errorf("Want request id %q, got %q", want, got)
+		}
 	}
 }
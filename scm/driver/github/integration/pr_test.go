@@ -84,7 +84,7 @@ func testPullRequestCommentFind(client *scm.Client) func(t *testing.T) {
 func testPullRequestCommentList(client *scm.Client) func(t *testing.T) {
 	return func(t *testing.T) {
 		t.Parallel()
-		opts := scm.ListOptions{}
+		opts := scm.CommentListOptions{}
 		result, _, err := client.PullRequests.ListComments(context.Background(), "octocat/Hello-World", 140, opts)
 		if err != nil {
 			t.Error(err)
@@ -37,6 +37,11 @@ type searchResults struct {
 }
 
 func (s *issueService) Search(ctx context.Context, opts scm.SearchOptions) ([]*scm.SearchIssue, *scm.Response, error) {
+	sort, err := s.client.Limits.SearchSort(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts.Sort = sort
 	suffix := encodeIssueSearchOptions(opts)
 	query := opts.QueryArgument()
 	if suffix != "" {
@@ -125,24 +130,158 @@ func (s *issueService) List(ctx context.Context, repo string, opts scm.IssueList
 	return convertIssueList(out), res, err
 }
 
-func (s *issueService) ListComments(ctx context.Context, repo string, index int, opts scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
-	path := fmt.Sprintf("repos/%s/issues/%d/comments?%s", repo, index, encodeListOptions(opts))
+func (s *issueService) ListComments(ctx context.Context, repo string, index int, opts scm.CommentListOptions) ([]*scm.Comment, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/issues/%d/comments?%s", repo, index, encodeCommentListOptions(opts))
 	out := []*issueComment{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
-	return convertIssueCommentList(out), res, err
+	if err != nil {
+		return nil, res, err
+	}
+	// the issue comments endpoint filters by since but
+	// always returns the comments in ascending id order.
+	return scm.FilterComments(convertIssueCommentList(out), opts), res, nil
 }
 
 func (s *issueService) Create(ctx context.Context, repo string, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
 	path := fmt.Sprintf("repos/%s/issues", repo)
 	in := &issueInput{
-		Title: input.Title,
-		Body:  input.Body,
+		Title:     input.Title,
+		Body:      input.Body,
+		Labels:    input.Labels,
+		Assignees: input.Assignees,
+	}
+	if input.Milestone != 0 {
+		in.Milestone = &input.Milestone
 	}
 	out := new(issue)
 	res, err := s.client.do(ctx, "POST", path, in, out)
 	return convertIssue(out), res, err
 }
 
+func (s *issueService) Update(ctx context.Context, repo string, number int, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/issues/%d", repo, number)
+	in := &issueUpdateInput{
+		Title:     input.Title,
+		Body:      input.Body,
+		State:     input.TargetState(),
+		Labels:    input.Labels,
+		Assignees: input.Assignees,
+	}
+	if in.State != "" {
+		in.StateReason = input.StateReason
+	}
+	if input.Milestone != 0 {
+		in.Milestone = &input.Milestone
+	}
+	out := new(issue)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertIssue(out), res, err
+}
+
+// Pin pins the issue to the repository.
+//
+// See https://docs.github.com/en/graphql/reference/mutations#pinissue
+func (s *issueService) Pin(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.pin(ctx, repo, number, "pinIssue")
+}
+
+// Unpin unpins the issue from the repository.
+//
+// See https://docs.github.com/en/graphql/reference/mutations#unpinissue
+func (s *issueService) Unpin(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.pin(ctx, repo, number, "unpinIssue")
+}
+
+// MinimizeComment hides the issue or pull request comment.
+//
+// See https://docs.github.com/en/graphql/reference/mutations#minimizecomment
+func (s *issueService) MinimizeComment(ctx context.Context, repo string, number int, id int, reason string) (*scm.Response, error) {
+	nodeID, res, err := s.commentNodeID(ctx, repo, id)
+	if err != nil {
+		return res, err
+	}
+	query := `mutation($id: ID!, $classifier: ReportedContentClassifiers!) { minimizeComment(input: {subjectId: $id, classifier: $classifier}) { minimizedComment { isMinimized } } }`
+	vars := map[string]interface{}{"id": nodeID, "classifier": strings.ToUpper(reason)}
+	return s.client.graphql(ctx, query, vars, nil)
+}
+
+// UnminimizeComment shows the hidden issue or pull request
+// comment again.
+//
+// See https://docs.github.com/en/graphql/reference/mutations#unminimizecomment
+func (s *issueService) UnminimizeComment(ctx context.Context, repo string, number int, id int) (*scm.Response, error) {
+	nodeID, res, err := s.commentNodeID(ctx, repo, id)
+	if err != nil {
+		return res, err
+	}
+	query := `mutation($id: ID!) { unminimizeComment(input: {subjectId: $id}) { unminimizedComment { isMinimized } } }`
+	vars := map[string]interface{}{"id": nodeID}
+	return s.client.graphql(ctx, query, vars, nil)
+}
+
+// commentNodeID returns the node id of the comment, used to
+// reference it in graphql mutations.
+func (s *issueService) commentNodeID(ctx context.Context, repo string, id int) (string, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/issues/comments/%d", repo, id)
+	out := new(issueComment)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return out.NodeID, res, err
+}
+
+// pin runs the pin or unpin mutation, which take the node id
+// of the issue rather than its number.
+func (s *issueService) pin(ctx context.Context, repo string, number int, mutation string) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/issues/%d", repo, number)
+	out := new(issue)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return res, err
+	}
+	query := fmt.Sprintf(`mutation($id: ID!) { %s(input: {issueId: $id}) { issue { number } } }`, mutation)
+	vars := map[string]interface{}{"id": out.NodeID}
+	return s.client.graphql(ctx, query, vars, nil)
+}
+
+const pinnedIssuesQuery = `query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) {
+    pinnedIssues(first: 3) {
+      nodes {
+        issue {
+          number
+          title
+          body
+          url
+          state
+          stateReason
+          locked
+          author { login avatarUrl }
+          createdAt
+          updatedAt
+          closedAt
+        }
+      }
+    }
+  }
+}`
+
+// ListPinned returns the pinned issues of the repository.
+//
+// See https://docs.github.com/en/graphql/reference/objects#pinnedissue
+func (s *issueService) ListPinned(ctx context.Context, repo string) ([]*scm.Issue, *scm.Response, error) {
+	owner, name := scm.Split(repo)
+	vars := map[string]interface{}{"owner": owner, "name": name}
+	out := new(pinnedIssues)
+	res, err := s.client.graphql(ctx, pinnedIssuesQuery, vars, out)
+	if err != nil {
+		return nil, res, err
+	}
+	to := []*scm.Issue{}
+	for _, node := range out.Repository.PinnedIssues.Nodes {
+		to = append(to, convertPinnedIssue(node.Issue))
+	}
+	return to, res, nil
+}
+
 func (s *issueService) ListLabels(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
 	path := fmt.Sprintf("repos/%s/issues/%d/labels?%s", repo, number, encodeListOptions(opts))
 	out := []*label{}
@@ -244,43 +383,94 @@ func (s *issueService) ClearMilestone(ctx context.Context, repo string, id int)
 
 type issue struct {
 	ID      int    `json:"id"`
+	NodeID  string `json:"node_id"`
 	HTMLURL string `json:"html_url"`
 	Number  int    `json:"number"`
 	State   string `json:"state"`
+	Reason  string `json:"state_reason"`
 	Title   string `json:"title"`
 	Body    string `json:"body"`
 	User    struct {
 		Login     string `json:"login"`
 		AvatarURL string `json:"avatar_url"`
 	} `json:"user"`
-	Labels []struct {
-		Name string `json:"name"`
-	} `json:"labels"`
+	Labels    []*label  `json:"labels"`
 	Assignees []user    `json:"assignees"`
 	Locked    bool      `json:"locked"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	ClosedAt  time.Time `json:"closed_at"`
 
 	// This will be non-nil if it is a pull request.
 	PullRequest *struct{} `json:"pull_request,omitempty"`
 }
 
+type pinnedIssues struct {
+	Repository struct {
+		PinnedIssues struct {
+			Nodes []struct {
+				Issue *pinnedIssue `json:"issue"`
+			} `json:"nodes"`
+		} `json:"pinnedIssues"`
+	} `json:"repository"`
+}
+
+type pinnedIssue struct {
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	Body        string `json:"body"`
+	URL         string `json:"url"`
+	State       string `json:"state"`
+	StateReason string `json:"stateReason"`
+	Locked      bool   `json:"locked"`
+	Author      struct {
+		Login     string `json:"login"`
+		AvatarURL string `json:"avatarUrl"`
+	} `json:"author"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	ClosedAt  time.Time `json:"closedAt"`
+}
+
 type issueInput struct {
-	Title string `json:"title"`
-	Body  string `json:"body"`
+	Title     string   `json:"title"`
+	Body      string   `json:"body"`
+	Milestone *int     `json:"milestone,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+}
+
+type issueUpdateInput struct {
+	Title       string   `json:"title,omitempty"`
+	Body        string   `json:"body,omitempty"`
+	State       string   `json:"state,omitempty"`
+	StateReason string   `json:"state_reason,omitempty"`
+	Milestone   *int     `json:"milestone,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	Assignees   []string `json:"assignees,omitempty"`
+}
+
+// issueFieldsInput sets the fields of a pull request that
+// github only accepts through the issues api.
+type issueFieldsInput struct {
+	Milestone *int     `json:"milestone,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
 }
 
 type issueComment struct {
 	ID      int    `json:"id"`
+	NodeID  string `json:"node_id"`
 	HTMLURL string `json:"html_url"`
 	User    struct {
 		ID        int    `json:"id"`
 		Login     string `json:"login"`
 		AvatarURL string `json:"avatar_url"`
 	} `json:"user"`
-	Body      string    `json:"body"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Body              string    `json:"body"`
+	AuthorAssociation string    `json:"author_association"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
 }
 
 type issueCommentInput struct {
@@ -339,22 +529,47 @@ func populateRepositoryFromURL(repo *scm.Repository, u string) {
 // the common issue structure.
 func convertIssue(from *issue) *scm.Issue {
 	return &scm.Issue{
-		Number: from.Number,
-		Title:  from.Title,
-		Body:   from.Body,
-		Link:   from.HTMLURL,
-		Labels: convertLabels(from),
-		Locked: from.Locked,
-		State:  from.State,
-		Closed: from.State == "closed",
+		Number:      from.Number,
+		Title:       from.Title,
+		Body:        from.Body,
+		Link:        from.HTMLURL,
+		Labels:      convertLabelObjects(from.Labels),
+		Locked:      from.Locked,
+		State:       from.State,
+		StateReason: from.Reason,
+		Closed:      from.State == "closed",
 		Author: scm.User{
 			Login:  from.User.Login,
 			Avatar: from.User.AvatarURL,
 		},
 		Assignees:   convertUsers(from.Assignees),
 		PullRequest: from.PullRequest != nil,
-		Created:     from.CreatedAt,
-		Updated:     from.UpdatedAt,
+		Created:     from.CreatedAt.UTC(),
+		Updated:     from.UpdatedAt.UTC(),
+		ClosedAt:    from.ClosedAt.UTC(),
+	}
+}
+
+// convertPinnedIssue converts the GraphQL issue, which uses
+// upper case enum values for the state and state reason.
+func convertPinnedIssue(from *pinnedIssue) *scm.Issue {
+	state := strings.ToLower(from.State)
+	return &scm.Issue{
+		Number:      from.Number,
+		Title:       from.Title,
+		Body:        from.Body,
+		Link:        from.URL,
+		Locked:      from.Locked,
+		State:       state,
+		StateReason: strings.ToLower(from.StateReason),
+		Closed:      state == "closed",
+		Author: scm.User{
+			Login:  from.Author.Login,
+			Avatar: from.Author.AvatarURL,
+		},
+		Created:  from.CreatedAt.UTC(),
+		Updated:  from.UpdatedAt.UTC(),
+		ClosedAt: from.ClosedAt.UTC(),
 	}
 }
 
@@ -378,24 +593,18 @@ func convertIssueComment(from *issueComment) *scm.Comment {
 			Login:  from.User.Login,
 			Avatar: from.User.AvatarURL,
 		},
-		Link:    from.HTMLURL,
-		Created: from.CreatedAt,
-		Updated: from.UpdatedAt,
+		Link:              from.HTMLURL,
+		Created:           from.CreatedAt.UTC(),
+		Updated:           from.UpdatedAt.UTC(),
+		AuthorAssociation: from.AuthorAssociation,
 	}
 }
 
-func convertLabels(from *issue) []string {
-	var labels []string
-	for _, label := range from.Labels {
-		labels = append(labels, label.Name)
-	}
-	return labels
-}
-
 func convertLabelObjects(from []*label) []*scm.Label {
 	var labels []*scm.Label
 	for _, label := range from {
 		labels = append(labels, &scm.Label{
+			ID:          label.ID,
 			Name:        label.Name,
 			Description: label.Description,
 			URL:         label.URL,
@@ -418,6 +627,6 @@ func convertListedIssueEvent(from *listedIssueEvent) *scm.ListedIssueEvent {
 		Event:   from.Event,
 		Actor:   *convertUser(&from.Actor),
 		Label:   convertLabel(from.Label),
-		Created: from.Created,
+		Created: from.Created.UTC(),
 	}
 }
@@ -153,6 +153,22 @@ func TestIssueSearch(t *testing.T) {
 	t.Run("Page", testPage(res))
 }
 
+func TestIssueSearch_InvalidSort(t *testing.T) {
+	client := NewDefault()
+	_, _, err := client.Issues.Search(context.Background(), scm.SearchOptions{Query: "bug", Sort: "best-match"})
+	if _, ok := err.(*scm.OptionError); !ok {
+		t.Errorf("Want an OptionError, got %v", err)
+	}
+}
+
+func TestIssueList_InvalidPageSize(t *testing.T) {
+	client := NewDefault()
+	_, _, err := client.Issues.List(context.Background(), "octocat/hello-world", scm.IssueListOptions{Page: 1, Size: 500})
+	if _, ok := err.(*scm.OptionError); !ok {
+		t.Errorf("Want an OptionError, got %v", err)
+	}
+}
+
 func TestIssuePPRSearch(t *testing.T) {
 	defer gock.Off()
 
@@ -209,7 +225,7 @@ func TestIssueListComments(t *testing.T) {
 		File("testdata/issue_comments.json")
 
 	client := NewDefault()
-	got, res, err := client.Issues.ListComments(context.Background(), "octocat/hello-world", 1, scm.ListOptions{Size: 30, Page: 1})
+	got, res, err := client.Issues.ListComments(context.Background(), "octocat/hello-world", 1, scm.CommentListOptions{Size: 30, Page: 1})
 	if err != nil {
 		t.Error(err)
 		return
@@ -354,6 +370,198 @@ func TestIssueEditComment(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestIssueUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Patch("/repos/octocat/hello-world/issues/1").
+		JSON(map[string]interface{}{
+			"title":        "Found a bug",
+			"state":        "closed",
+			"state_reason": "not_planned",
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		JSON(map[string]interface{}{
+			"number":       1,
+			"title":        "Found a bug",
+			"state":        "closed",
+			"state_reason": "not_planned",
+		})
+
+	input := &scm.IssueInput{
+		Title:       "Found a bug",
+		StateReason: scm.IssueStateReasonNotPlanned,
+	}
+
+	client := NewDefault()
+	got, res, err := client.Issues.Update(context.Background(), "octocat/hello-world", 1, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Closed || got.StateReason != scm.IssueStateReasonNotPlanned {
+		t.Errorf("Want issue closed as not planned, got state %s reason %s", got.State, got.StateReason)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestIssuePin(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/issues/1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		JSON(map[string]interface{}{"number": 1, "node_id": "MDU6SXNzdWUx"})
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		JSON(map[string]interface{}{
+			"data": map[string]interface{}{
+				"pinIssue": map[string]interface{}{"issue": map[string]interface{}{"number": 1}},
+			},
+		})
+
+	client := NewDefault()
+	res, err := client.Issues.Pin(context.Background(), "octocat/hello-world", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestIssueMinimizeComment(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/issues/comments/1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/issue_comment.json")
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		JSON(map[string]interface{}{
+			"data": map[string]interface{}{
+				"minimizeComment": map[string]interface{}{"minimizedComment": map[string]interface{}{"isMinimized": true}},
+			},
+		})
+
+	client := NewDefault()
+	res, err := client.Issues.MinimizeComment(context.Background(), "octocat/hello-world", 1347, 1, scm.MinimizeReasonOutdated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestIssueListPinned(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pinned_issues.json")
+
+	client := NewDefault()
+	got, res, err := client.Issues.ListPinned(context.Background(), "octocat/hello-world")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Issue{}
+	raw, _ := ioutil.ReadFile("testdata/pinned_issues.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestIssueListPinnedDryRun(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pinned_issues.json")
+
+	client := NewDefault()
+	client.DryRun = func(*scm.Request, []byte) {
+		t.Errorf("Want GraphQL query sent in dry-run mode")
+	}
+	got, _, err := client.Issues.ListPinned(context.Background(), "octocat/hello-world")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) == 0 {
+		t.Errorf("Want pinned issues in dry-run mode")
+	}
+}
+
+func TestIssueCreateDryRun(t *testing.T) {
+	client := NewDefault()
+	client.DryRun = func(*scm.Request, []byte) {}
+
+	input := scm.IssueInput{
+		Title:  "Found a bug",
+		Labels: []string{"bug"},
+	}
+	if _, _, err := client.Issues.Create(context.Background(), "octocat/hello-world", &input); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIssueListPinnedError(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		JSON(map[string]interface{}{
+			"errors": []map[string]interface{}{
+				{"type": "NOT_FOUND", "message": "Could not resolve to a Repository"},
+			},
+		})
+
+	client := NewDefault()
+	_, _, err := client.Issues.ListPinned(context.Background(), "octocat/hello-world")
+	if err != scm.ErrNotFound {
+		t.Errorf("Want ErrNotFound, got %v", err)
+	}
+}
+
 func TestIssueClose(t *testing.T) {
 	defer gock.Off()
 
@@ -64,11 +64,11 @@ type membership struct {
 }
 
 func (s *organizationService) Create(context.Context, *scm.OrganizationInput) (*scm.Organization, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Organizations", "Create")
 }
 
 func (s *organizationService) Delete(context.Context, string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Organizations", "Delete")
 }
 
 func (s *organizationService) IsMember(ctx context.Context, org string, user string) (bool, *scm.Response, error) {
@@ -0,0 +1,100 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type pages struct {
+	HTMLURL   string `json:"html_url"`
+	Status    string `json:"status"`
+	CNAME     string `json:"cname"`
+	BuildType string `json:"build_type"`
+	Source    struct {
+		Branch string `json:"branch"`
+		Path   string `json:"path"`
+	} `json:"source"`
+	HTTPSEnforced bool `json:"https_enforced"`
+	Public        bool `json:"public"`
+}
+
+type pagesInput struct {
+	BuildType string            `json:"build_type,omitempty"`
+	Source    *pagesSourceInput `json:"source,omitempty"`
+}
+
+type pagesSourceInput struct {
+	Branch string `json:"branch"`
+	Path   string `json:"path,omitempty"`
+}
+
+type pagesBuild struct {
+	Status string `json:"status"`
+	Error  struct {
+		Message string `json:"message"`
+	} `json:"error"`
+	Commit    string    `json:"commit"`
+	Duration  int64     `json:"duration"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (s *repositoryService) GetPages(ctx context.Context, repo string) (*scm.Pages, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pages", repo)
+	out := new(pages)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertPages(out), res, err
+}
+
+func (s *repositoryService) EnablePages(ctx context.Context, repo string, input *scm.PagesInput) (*scm.Pages, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pages", repo)
+	in := &pagesInput{BuildType: input.BuildType}
+	if input.Branch != "" {
+		in.Source = &pagesSourceInput{
+			Branch: input.Branch,
+			Path:   input.Path,
+		}
+	}
+	out := new(pages)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertPages(out), res, err
+}
+
+func (s *repositoryService) DisablePages(ctx context.Context, repo string) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pages", repo)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *repositoryService) GetLatestPagesBuild(ctx context.Context, repo string) (*scm.PagesBuild, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pages/builds/latest", repo)
+	out := new(pagesBuild)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertPagesBuild(out), res, err
+}
+
+func convertPages(from *pages) *scm.Pages {
+	return &scm.Pages{
+		URL:           from.HTMLURL,
+		Status:        from.Status,
+		Branch:        from.Source.Branch,
+		Path:          from.Source.Path,
+		BuildType:     from.BuildType,
+		CNAME:         from.CNAME,
+		HTTPSEnforced: from.HTTPSEnforced,
+		Public:        from.Public,
+	}
+}
+
+func convertPagesBuild(from *pagesBuild) *scm.PagesBuild {
+	return &scm.PagesBuild{
+		Status:   from.Status,
+		Error:    from.Error.Message,
+		Commit:   from.Commit,
+		Duration: time.Duration(from.Duration) * time.Millisecond,
+		Created:  from.CreatedAt,
+		Updated:  from.UpdatedAt,
+	}
+}
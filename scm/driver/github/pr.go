@@ -9,11 +9,9 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 	"time"
 
 	"github.com/slimm609/go-scm/scm"
-	"github.com/slimm609/go-scm/scm/driver/internal/null"
 	errors2 "k8s.io/apimachinery/pkg/util/errors"
 )
 
@@ -36,7 +34,7 @@ func (s *pullService) List(ctx context.Context, repo string, opts scm.PullReques
 	path := fmt.Sprintf("repos/%s/pulls?%s", repo, encodePullRequestListOptions(opts))
 	out := []*pr{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
-	return convertPullRequestList(out), res, err
+	return scm.FilterPullRequests(convertPullRequestList(out), opts), res, err
 }
 
 func (s *pullService) ListChanges(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
@@ -77,6 +75,12 @@ func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRe
 
 	out := new(pr)
 	res, err := s.client.do(ctx, "POST", path, in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if res, err := s.setIssueFields(ctx, repo, out, input); err != nil {
+		return convertPullRequest(out), res, err
+	}
 	return convertPullRequest(out), res, err
 }
 
@@ -98,9 +102,41 @@ func (s *pullService) Update(ctx context.Context, repo string, number int, input
 
 	out := new(pr)
 	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if res, err := s.setIssueFields(ctx, repo, out, input); err != nil {
+		return convertPullRequest(out), res, err
+	}
 	return convertPullRequest(out), res, err
 }
 
+// setIssueFields sets the milestone, labels and assignees of
+// the pull request, which the pulls api ignores, with a single
+// request to the issues api.
+func (s *pullService) setIssueFields(ctx context.Context, repo string, out *pr, input *scm.PullRequestInput) (*scm.Response, error) {
+	if input.Milestone == 0 && len(input.Labels) == 0 && len(input.Assignees) == 0 {
+		return nil, nil
+	}
+	in := &issueFieldsInput{
+		Labels:    input.Labels,
+		Assignees: input.Assignees,
+	}
+	if input.Milestone != 0 {
+		in.Milestone = &input.Milestone
+	}
+	path := fmt.Sprintf("repos/%s/issues/%d", repo, out.Number)
+	fields := new(pr)
+	res, err := s.client.do(ctx, "PATCH", path, in, fields)
+	if err != nil {
+		return res, err
+	}
+	out.Labels = fields.Labels
+	out.Assignees = fields.Assignees
+	out.Milestone = fields.Milestone
+	return res, nil
+}
+
 func (s *pullService) RequestReview(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
 	_, resp, err := s.tryRequestReview(ctx, repo, number, logins)
 	// At least one invalid user. Try adding them individually.
@@ -124,7 +160,8 @@ func (s *pullService) RequestReview(ctx context.Context, repo string, number int
 }
 
 func (s *pullService) UnrequestReview(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	body, err := prepareReviewersBody(logins, strings.Split(repo, "/")[0])
+	org, _ := scm.Split(repo)
+	body, err := prepareReviewersBody(logins, org)
 	if err != nil {
 		return nil, err
 	}
@@ -174,7 +211,8 @@ func prepareReviewersBody(logins []string, org string) (prReviewers, error) {
 }
 
 func (s *pullService) tryRequestReview(ctx context.Context, orgAndRepo string, number int, logins []string) (*scm.PullRequest, *scm.Response, error) {
-	body, err := prepareReviewersBody(logins, strings.Split(orgAndRepo, "/")[0])
+	org, _ := scm.Split(orgAndRepo)
+	body, err := prepareReviewersBody(logins, org)
 	if err != nil {
 		// At least one team not in org,
 		// let RequestReview handle retries and alerting for each login.
@@ -207,28 +245,33 @@ type prBranch struct {
 }
 
 type pr struct {
-	Number             int         `json:"number"`
-	State              string      `json:"state"`
-	Title              string      `json:"title"`
-	Body               string      `json:"body"`
-	Labels             []*label    `json:"labels"`
-	DiffURL            string      `json:"diff_url"`
-	HTMLURL            string      `json:"html_url"`
-	User               user        `json:"user"`
-	RequestedReviewers []user      `json:"requested_reviewers"`
-	Assignees          []user      `json:"assignees"`
-	Head               prBranch    `json:"head"`
-	Base               prBranch    `json:"base"`
-	Draft              bool        `json:"draft"`
-	Merged             bool        `json:"merged"`
-	Mergeable          bool        `json:"mergeable"`
-	MergeableState     string      `json:"mergeable_state"`
-	Rebaseable         bool        `json:"rebaseable"`
-	MergeSha           string      `json:"merge_commit_sha"`
-	Milestone          milestone   `json:"milestone"`
-	MergedAt           null.String `json:"merged_at"`
-	CreatedAt          time.Time   `json:"created_at"`
-	UpdatedAt          time.Time   `json:"updated_at"`
+	Number             int       `json:"number"`
+	State              string    `json:"state"`
+	Title              string    `json:"title"`
+	Body               string    `json:"body"`
+	Labels             []*label  `json:"labels"`
+	DiffURL            string    `json:"diff_url"`
+	HTMLURL            string    `json:"html_url"`
+	User               user      `json:"user"`
+	RequestedReviewers []user    `json:"requested_reviewers"`
+	Assignees          []user    `json:"assignees"`
+	Head               prBranch  `json:"head"`
+	Base               prBranch  `json:"base"`
+	Draft              bool      `json:"draft"`
+	Merged             bool      `json:"merged"`
+	Mergeable          bool      `json:"mergeable"`
+	MergeableState     string    `json:"mergeable_state"`
+	Rebaseable         bool      `json:"rebaseable"`
+	MergeSha           string    `json:"merge_commit_sha"`
+	Milestone          milestone `json:"milestone"`
+	AuthorAssociation  string    `json:"author_association"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+	ClosedAt           time.Time `json:"closed_at"`
+	MergedAt           time.Time `json:"merged_at"`
+	Additions          int       `json:"additions"`
+	Deletions          int       `json:"deletions"`
+	ChangedFiles       int       `json:"changed_files"`
 }
 
 type file struct {
@@ -284,8 +327,15 @@ func convertPullRequest(from *pr) *scm.PullRequest {
 		Author:         *convertUser(&from.User),
 		Assignees:      convertUsers(from.Assignees),
 		Reviewers:      convertUsers(from.RequestedReviewers),
-		Created:        from.CreatedAt,
-		Updated:        from.UpdatedAt,
+		Created:        from.CreatedAt.UTC(),
+		Updated:        from.UpdatedAt.UTC(),
+		ClosedAt:       from.ClosedAt.UTC(),
+		MergedAt:       from.MergedAt.UTC(),
+		Additions:      from.Additions,
+		Deletions:      from.Deletions,
+		ChangedFiles:   from.ChangedFiles,
+
+		AuthorAssociation: from.AuthorAssociation,
 	}
 }
 
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/slimm609/go-scm/scm"
 
@@ -46,6 +47,37 @@ func TestPullFind(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestPullTimestamps(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.Find(context.Background(), "octocat/hello-world", 1347)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, ts := range map[string]time.Time{
+		"Created":  got.Created,
+		"Updated":  got.Updated,
+		"ClosedAt": got.ClosedAt,
+		"MergedAt": got.MergedAt,
+	} {
+		if ts.IsZero() {
+			t.Errorf("Want non-zero %s timestamp", name)
+		}
+		if ts.Location() != time.UTC {
+			t.Errorf("Want %s timestamp in UTC, got %s", name, ts.Location())
+		}
+	}
+}
+
 func TestPullList(t *testing.T) {
 	defer gock.Off()
 
@@ -216,6 +248,54 @@ func TestPullCreate(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestPullCreateIssueFields(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/repos/octocat/hello-world/pulls").
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr_create.json")
+
+	gock.New("https://api.github.com").
+		Patch("/repos/octocat/hello-world/issues/1347").
+		JSON(map[string]interface{}{
+			"milestone": 1,
+			"labels":    []string{"bug"},
+			"assignees": []string{"octocat"},
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/issue.json")
+
+	input := &scm.PullRequestInput{
+		Title:     "Amazing new feature",
+		Body:      "Please pull these awesome changes in!",
+		Head:      "octocat:new-feature",
+		Base:      "master",
+		Milestone: 1,
+		Labels:    []string{"bug"},
+		Assignees: []string{"octocat"},
+	}
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.Create(context.Background(), "octocat/hello-world", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Labels) != 1 || got.Labels[0].Name != "bug" {
+		t.Errorf("Want label bug, got %v", got.Labels)
+	}
+	if len(got.Assignees) != 1 || got.Assignees[0].Login != "octocat" {
+		t.Errorf("Want assignee octocat, got %v", got.Assignees)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
 func TestPullUpdate(t *testing.T) {
 	defer gock.Off()
 
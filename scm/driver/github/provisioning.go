@@ -0,0 +1,140 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type provisioningService struct {
+	client *wrapper
+}
+
+type scimUser struct {
+	ID         string   `json:"id,omitempty"`
+	Schemas    []string `json:"schemas,omitempty"`
+	ExternalID string   `json:"externalId,omitempty"`
+	UserName   string   `json:"userName"`
+	Name       struct {
+		GivenName  string `json:"givenName,omitempty"`
+		FamilyName string `json:"familyName,omitempty"`
+		Formatted  string `json:"formatted,omitempty"`
+	} `json:"name"`
+	Emails []scimEmail `json:"emails,omitempty"`
+	Active bool        `json:"active"`
+}
+
+type scimEmail struct {
+	Value   string `json:"value"`
+	Primary bool   `json:"primary"`
+}
+
+type scimUserList struct {
+	Resources []*scimUser `json:"Resources"`
+}
+
+type scimPatch struct {
+	Schemas    []string        `json:"schemas"`
+	Operations []scimOperation `json:"Operations"`
+}
+
+type scimOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// CreateUser provisions the user in the organization.
+//
+// See https://docs.github.com/en/enterprise-cloud@latest/rest/scim/scim#provision-and-invite-a-scim-user
+func (s *provisioningService) CreateUser(ctx context.Context, org string, input *scm.ProvisionUserInput) (*scm.ProvisionedUser, *scm.Response, error) {
+	path := fmt.Sprintf("scim/v2/organizations/%s/Users", org)
+	in := &scimUser{
+		Schemas:    []string{"urn:ietf:params:scim:schemas:core:2.0:User"},
+		ExternalID: input.ExternalID,
+		UserName:   input.Login,
+		Emails:     []scimEmail{{Value: input.Email, Primary: true}},
+		Active:     true,
+	}
+	in.Name.Formatted = input.Name
+	in.Name.GivenName, in.Name.FamilyName = splitName(input.Name)
+	out := new(scimUser)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertSCIMUser(out), res, nil
+}
+
+// BlockUser blocks the user from the organization.
+//
+// See https://docs.github.com/en/rest/orgs/blocking#block-a-user-from-an-organization
+func (s *provisioningService) BlockUser(ctx context.Context, org, login string) (*scm.Response, error) {
+	path := fmt.Sprintf("orgs/%s/blocks/%s", org, login)
+	return s.client.do(ctx, "PUT", path, nil, nil)
+}
+
+// UnblockUser unblocks the user from the organization.
+func (s *provisioningService) UnblockUser(ctx context.Context, org, login string) (*scm.Response, error) {
+	path := fmt.Sprintf("orgs/%s/blocks/%s", org, login)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+// DeactivateUser sets the SCIM identity of the user inactive,
+// which removes the user from the organization.
+//
+// See https://docs.github.com/en/enterprise-cloud@latest/rest/scim/scim#update-an-attribute-for-a-scim-user
+func (s *provisioningService) DeactivateUser(ctx context.Context, org, login string) (*scm.Response, error) {
+	filter := url.Values{"filter": {fmt.Sprintf("userName eq %q", login)}}
+	path := fmt.Sprintf("scim/v2/organizations/%s/Users?%s", org, filter.Encode())
+	out := new(scimUserList)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return res, err
+	}
+	if len(out.Resources) == 0 {
+		return res, scm.ErrNotFound
+	}
+	path = fmt.Sprintf("scim/v2/organizations/%s/Users/%s", org, out.Resources[0].ID)
+	in := &scimPatch{
+		Schemas:    []string{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+		Operations: []scimOperation{{Op: "replace", Path: "active", Value: false}},
+	}
+	return s.client.do(ctx, "PATCH", path, in, nil)
+}
+
+// splitName splits the full name in the given and family
+// names required by SCIM.
+func splitName(name string) (string, string) {
+	name = strings.TrimSpace(name)
+	if i := strings.LastIndex(name, " "); i != -1 {
+		return name[:i], name[i+1:]
+	}
+	return name, name
+}
+
+func convertSCIMUser(from *scimUser) *scm.ProvisionedUser {
+	to := &scm.ProvisionedUser{
+		ID:         from.ID,
+		Login:      from.UserName,
+		Name:       from.Name.Formatted,
+		ExternalID: from.ExternalID,
+		Active:     from.Active,
+	}
+	if to.Name == "" {
+		to.Name = strings.TrimSpace(from.Name.GivenName + " " + from.Name.FamilyName)
+	}
+	for _, email := range from.Emails {
+		if email.Primary || to.Email == "" {
+			to.Email = email.Value
+		}
+	}
+	return to
+}
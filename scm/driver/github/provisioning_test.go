@@ -0,0 +1,84 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slimm609/go-scm/scm"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+)
+
+func TestProvisioningCreateUser(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/scim/v2/organizations/octo-org/Users").
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/scim_user.json")
+
+	client := NewDefault()
+	input := &scm.ProvisionUserInput{
+		Login:      "mona.octocat@okta.example.com",
+		Name:       "Mona Octocat",
+		Email:      "mona.octocat@okta.example.com",
+		ExternalID: "a7d0f98382",
+	}
+	got, res, err := client.Provisioning.CreateUser(context.Background(), "octo-org", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &scm.ProvisionedUser{
+		ID:         "edefdfedf-050c-11e7-8d32",
+		Login:      "mona.octocat@okta.example.com",
+		Name:       "Mona Octocat",
+		Email:      "mona.octocat@okta.example.com",
+		ExternalID: "a7d0f98382",
+		Active:     true,
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestProvisioningDeactivateUser(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/scim/v2/organizations/octo-org/Users").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/scim_users.json")
+
+	gock.New("https://api.github.com").
+		Patch("/scim/v2/organizations/octo-org/Users/edefdfedf-050c-11e7-8d32").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/scim_user.json")
+
+	client := NewDefault()
+	res, err := client.Provisioning.DeactivateUser(context.Background(), "octo-org", "mona.octocat@okta.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gock.IsPending() {
+		t.Errorf("Pending API calls")
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
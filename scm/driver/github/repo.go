@@ -357,8 +357,8 @@ func convertRepository(from *repository) *scm.Repository {
 		Private:  from.Private,
 		Clone:    from.CloneURL,
 		CloneSSH: from.SSHURL,
-		Created:  from.CreatedAt,
-		Updated:  from.UpdatedAt,
+		Created:  from.CreatedAt.UTC(),
+		Updated:  from.UpdatedAt.UTC(),
 	}
 }
 
@@ -436,16 +436,10 @@ func convertStatusList(from []*status) []*scm.Status {
 	// https://developer.github.com/v3/repos/statuses/#list-statuses-for-a-specific-ref.
 	// We only expose the most recent one to consumers.
 	to := []*scm.Status{}
-	unique := make(map[string]interface{})
 	for _, v := range from {
-		convertedStatus := convertStatus(v)
-		if _, ok := unique[convertedStatus.Label]; ok {
-			continue
-		}
-		to = append(to, convertedStatus)
-		unique[convertedStatus.Label] = nil
+		to = append(to, convertStatus(v))
 	}
-	return to
+	return scm.LatestStatuses(to)
 }
 
 func convertStatus(from *status) *scm.Status {
@@ -468,18 +462,24 @@ func convertState(from string) scm.State {
 		return scm.StatePending
 	case "success":
 		return scm.StateSuccess
+	case "expected":
+		return scm.StateExpected
 	default:
 		return scm.StateUnknown
 	}
 }
 
+// convertFromState maps the normalized state to one of the four
+// commit status states. Neutral and skipped results do not block a
+// merge on GitHub, so they are reported as success, while a result
+// that requires action is reported as a failure.
 func convertFromState(from scm.State) string {
 	switch from {
-	case scm.StatePending, scm.StateRunning:
+	case scm.StatePending, scm.StateRunning, scm.StateExpected:
 		return "pending"
-	case scm.StateSuccess:
+	case scm.StateSuccess, scm.StateNeutral, scm.StateSkipped:
 		return "success"
-	case scm.StateFailure:
+	case scm.StateFailure, scm.StateActionRequired:
 		return "failure"
 	default:
 		return "error"
@@ -532,6 +532,18 @@ func TestConvertFromState(t *testing.T) {
 			src: scm.StateSuccess,
 			dst: "success",
 		},
+		{
+			src: scm.StateNeutral,
+			dst: "success",
+		},
+		{
+			src: scm.StateSkipped,
+			dst: "success",
+		},
+		{
+			src: scm.StateActionRequired,
+			dst: "failure",
+		},
 		{
 			src: scm.StateUnknown,
 			dst: "error",
@@ -544,6 +556,29 @@ func TestConvertFromState(t *testing.T) {
 	}
 }
 
+func TestConvertCheckState(t *testing.T) {
+	tests := []struct {
+		status     string
+		conclusion string
+		dst        scm.State
+	}{
+		{status: "queued", dst: scm.StatePending},
+		{status: "in_progress", dst: scm.StateRunning},
+		{status: "completed", conclusion: "success", dst: scm.StateSuccess},
+		{status: "completed", conclusion: "neutral", dst: scm.StateNeutral},
+		{status: "completed", conclusion: "skipped", dst: scm.StateSkipped},
+		{status: "completed", conclusion: "action_required", dst: scm.StateActionRequired},
+		{status: "completed", conclusion: "cancelled", dst: scm.StateCanceled},
+		{status: "completed", conclusion: "timed_out", dst: scm.StateError},
+		{status: "completed", conclusion: "stale", dst: scm.StateUnknown},
+	}
+	for _, test := range tests {
+		if got, want := convertCheckState(test.status, test.conclusion), test.dst; got != want {
+			t.Errorf("Want check %s/%s converted to %v, got %v", test.status, test.conclusion, want, got)
+		}
+	}
+}
+
 func TestHookEvents(t *testing.T) {
 	tests := []struct {
 		in  scm.HookEvents
@@ -106,10 +106,11 @@ type reviewComment struct {
 		Login     string `json:"login"`
 		AvatarURL string `json:"avatar_url"`
 	} `json:"user"`
-	Body      string    `json:"body"`
-	HTMLURL   string    `json:"html_url"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Body              string    `json:"body"`
+	HTMLURL           string    `json:"html_url"`
+	AuthorAssociation string    `json:"author_association"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
 }
 
 type reviewInput struct {
@@ -171,7 +172,7 @@ func convertReview(from *review) *scm.Review {
 			Login:  from.User.Login,
 			Avatar: from.User.AvatarURL,
 		},
-		Created: from.SubmittedAt,
+		Created: from.SubmittedAt.UTC(),
 	}
 }
 
@@ -195,7 +196,8 @@ func convertReviewComment(from *reviewComment) *scm.ReviewComment {
 			Login:  from.User.Login,
 			Avatar: from.User.AvatarURL,
 		},
-		Created: from.CreatedAt,
-		Updated: from.UpdatedAt,
+		Created:           from.CreatedAt.UTC(),
+		Updated:           from.UpdatedAt.UTC(),
+		AuthorAssociation: from.AuthorAssociation,
 	}
 }
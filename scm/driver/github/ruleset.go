@@ -0,0 +1,199 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type ruleset struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Target      string `json:"target"`
+	Enforcement string `json:"enforcement"`
+	Conditions  struct {
+		RefName struct {
+			Include []string `json:"include"`
+			Exclude []string `json:"exclude"`
+		} `json:"ref_name"`
+	} `json:"conditions"`
+	Rules []*rulesetRule `json:"rules"`
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"_links"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type rulesetRule struct {
+	Type       string          `json:"type"`
+	Parameters json.RawMessage `json:"parameters,omitempty"`
+}
+
+type pullRequestRuleParameters struct {
+	RequiredApprovingReviewCount   int  `json:"required_approving_review_count"`
+	DismissStaleReviewsOnPush      bool `json:"dismiss_stale_reviews_on_push"`
+	RequireCodeOwnerReview         bool `json:"require_code_owner_review"`
+	RequireLastPushApproval        bool `json:"require_last_push_approval"`
+	RequiredReviewThreadResolution bool `json:"required_review_thread_resolution"`
+}
+
+type statusChecksRuleParameters struct {
+	StrictRequiredStatusChecksPolicy bool                   `json:"strict_required_status_checks_policy"`
+	RequiredStatusChecks             []*requiredStatusCheck `json:"required_status_checks"`
+}
+
+type requiredStatusCheck struct {
+	Context string `json:"context"`
+}
+
+type rulesetInput struct {
+	Name        string `json:"name"`
+	Target      string `json:"target,omitempty"`
+	Enforcement string `json:"enforcement"`
+	Conditions  struct {
+		RefName struct {
+			Include []string `json:"include"`
+			Exclude []string `json:"exclude"`
+		} `json:"ref_name"`
+	} `json:"conditions"`
+	Rules []*rulesetRule `json:"rules"`
+}
+
+// ListRulesets returns the rulesets of the repository. The
+// API omits the conditions and rules from the list, use
+// FindRuleset to get them.
+func (s *repositoryService) ListRulesets(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Ruleset, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/rulesets?%s", repo, encodeListOptions(opts))
+	out := []*ruleset{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertRulesetList(out), res, err
+}
+
+func (s *repositoryService) FindRuleset(ctx context.Context, repo string, id int) (*scm.Ruleset, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/rulesets/%d", repo, id)
+	out := new(ruleset)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertRuleset(out), res, err
+}
+
+func (s *repositoryService) CreateRuleset(ctx context.Context, repo string, input *scm.RulesetInput) (*scm.Ruleset, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/rulesets", repo)
+	out := new(ruleset)
+	res, err := s.client.do(ctx, "POST", path, convertRulesetInput(input), out)
+	return convertRuleset(out), res, err
+}
+
+func (s *repositoryService) UpdateRuleset(ctx context.Context, repo string, id int, input *scm.RulesetInput) (*scm.Ruleset, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/rulesets/%d", repo, id)
+	out := new(ruleset)
+	res, err := s.client.do(ctx, "PUT", path, convertRulesetInput(input), out)
+	return convertRuleset(out), res, err
+}
+
+func convertRulesetInput(from *scm.RulesetInput) *rulesetInput {
+	to := &rulesetInput{
+		Name:        from.Name,
+		Target:      from.Target,
+		Enforcement: from.Enforcement,
+		Rules:       []*rulesetRule{},
+	}
+	to.Conditions.RefName.Include = from.Include
+	to.Conditions.RefName.Exclude = from.Exclude
+	if to.Conditions.RefName.Include == nil {
+		to.Conditions.RefName.Include = []string{}
+	}
+	if to.Conditions.RefName.Exclude == nil {
+		to.Conditions.RefName.Exclude = []string{}
+	}
+
+	rules := from.Rules
+	add := func(enabled bool, kind string, params interface{}) {
+		if !enabled {
+			return
+		}
+		rule := &rulesetRule{Type: kind}
+		if params != nil {
+			rule.Parameters, _ = json.Marshal(params)
+		}
+		to.Rules = append(to.Rules, rule)
+	}
+	add(rules.RestrictCreations, "creation", nil)
+	add(rules.RestrictUpdates, "update", nil)
+	add(rules.RestrictDeletions, "deletion", nil)
+	add(rules.RestrictForcePushes, "non_fast_forward", nil)
+	add(rules.RequireLinearHistory, "required_linear_history", nil)
+	add(rules.RequireSignedCommits, "required_signatures", nil)
+	add(rules.RequirePullRequest, "pull_request", &pullRequestRuleParameters{
+		RequiredApprovingReviewCount: rules.RequiredApprovingReviewCount,
+		DismissStaleReviewsOnPush:    rules.DismissStaleReviews,
+		RequireCodeOwnerReview:       rules.RequireCodeOwnerReview,
+	})
+	checks := &statusChecksRuleParameters{
+		StrictRequiredStatusChecksPolicy: rules.StrictStatusChecks,
+	}
+	for _, name := range rules.RequiredStatusChecks {
+		checks.RequiredStatusChecks = append(checks.RequiredStatusChecks, &requiredStatusCheck{Context: name})
+	}
+	add(rules.RequireStatusChecks, "required_status_checks", checks)
+	return to
+}
+
+func convertRulesetList(from []*ruleset) []*scm.Ruleset {
+	to := []*scm.Ruleset{}
+	for _, v := range from {
+		to = append(to, convertRuleset(v))
+	}
+	return to
+}
+
+func convertRuleset(from *ruleset) *scm.Ruleset {
+	to := &scm.Ruleset{
+		ID:          from.ID,
+		Name:        from.Name,
+		Target:      from.Target,
+		Enforcement: from.Enforcement,
+		Include:     from.Conditions.RefName.Include,
+		Exclude:     from.Conditions.RefName.Exclude,
+		Link:        from.Links.HTML.Href,
+		Created:     from.CreatedAt,
+		Updated:     from.UpdatedAt,
+	}
+	for _, rule := range from.Rules {
+		switch rule.Type {
+		case "creation":
+			to.Rules.RestrictCreations = true
+		case "update":
+			to.Rules.RestrictUpdates = true
+		case "deletion":
+			to.Rules.RestrictDeletions = true
+		case "non_fast_forward":
+			to.Rules.RestrictForcePushes = true
+		case "required_linear_history":
+			to.Rules.RequireLinearHistory = true
+		case "required_signatures":
+			to.Rules.RequireSignedCommits = true
+		case "pull_request":
+			params := new(pullRequestRuleParameters)
+			json.Unmarshal(rule.Parameters, params)
+			to.Rules.RequirePullRequest = true
+			to.Rules.RequiredApprovingReviewCount = params.RequiredApprovingReviewCount
+			to.Rules.DismissStaleReviews = params.DismissStaleReviewsOnPush
+			to.Rules.RequireCodeOwnerReview = params.RequireCodeOwnerReview
+		case "required_status_checks":
+			params := new(statusChecksRuleParameters)
+			json.Unmarshal(rule.Parameters, params)
+			to.Rules.RequireStatusChecks = true
+			to.Rules.StrictStatusChecks = params.StrictRequiredStatusChecksPolicy
+			for _, check := range params.RequiredStatusChecks {
+				to.Rules.RequiredStatusChecks = append(to.Rules.RequiredStatusChecks, check.Context)
+			}
+		}
+	}
+	return to
+}
@@ -0,0 +1,177 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestRepositoryListRulesets(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/rulesets").
+		MatchParam("page", "1").
+		MatchParam("per_page", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeaders(mockPageHeaders).
+		File("testdata/rulesets.json")
+
+	client := NewDefault()
+	got, res, err := client.Repositories.ListRulesets(context.Background(), "octocat/hello-world", scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Ruleset{}
+	raw, _ := ioutil.ReadFile("testdata/rulesets.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+	t.Run("Page", testPage(res))
+}
+
+func TestRepositoryFindRuleset(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/rulesets/42").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/ruleset.json")
+
+	client := NewDefault()
+	got, res, err := client.Repositories.FindRuleset(context.Background(), "octocat/hello-world", 42)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Ruleset)
+	raw, _ := ioutil.ReadFile("testdata/ruleset.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestRepositoryCreateRuleset(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/repos/octocat/hello-world/rulesets").
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/ruleset.json")
+
+	input := &scm.RulesetInput{
+		Name:        "main protection",
+		Target:      scm.RulesetTargetBranch,
+		Enforcement: scm.RulesetEnforcementActive,
+		Include:     []string{"~DEFAULT_BRANCH"},
+		Rules: scm.RulesetRules{
+			RestrictDeletions:  true,
+			RequirePullRequest: true,
+		},
+	}
+
+	client := NewDefault()
+	got, res, err := client.Repositories.CreateRuleset(context.Background(), "octocat/hello-world", input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Ruleset)
+	raw, _ := ioutil.ReadFile("testdata/ruleset.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestRepositoryUpdateRuleset(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Put("/repos/octocat/hello-world/rulesets/42").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/ruleset.json")
+
+	input := &scm.RulesetInput{
+		Name:        "main protection",
+		Enforcement: scm.RulesetEnforcementActive,
+	}
+
+	client := NewDefault()
+	got, res, err := client.Repositories.UpdateRuleset(context.Background(), "octocat/hello-world", 42, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Ruleset)
+	raw, _ := ioutil.ReadFile("testdata/ruleset.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestConvertRulesetInput(t *testing.T) {
+	input := &scm.RulesetInput{
+		Name:        "main protection",
+		Target:      scm.RulesetTargetBranch,
+		Enforcement: scm.RulesetEnforcementEvaluate,
+		Include:     []string{"~DEFAULT_BRANCH"},
+		Rules: scm.RulesetRules{
+			RestrictForcePushes:          true,
+			RequirePullRequest:           true,
+			RequiredApprovingReviewCount: 1,
+			RequireStatusChecks:          true,
+			RequiredStatusChecks:         []string{"ci/build"},
+		},
+	}
+	got, _ := json.Marshal(convertRulesetInput(input))
+	want := `{"name":"main protection","target":"branch","enforcement":"evaluate",` +
+		`"conditions":{"ref_name":{"include":["~DEFAULT_BRANCH"],"exclude":[]}},` +
+		`"rules":[{"type":"non_fast_forward"},` +
+		`{"type":"pull_request","parameters":{"required_approving_review_count":1,"dismiss_stale_reviews_on_push":false,"require_code_owner_review":false,"require_last_push_approval":false,"required_review_thread_resolution":false}},` +
+		`{"type":"required_status_checks","parameters":{"strict_required_status_checks_policy":false,"required_status_checks":[{"context":"ci/build"}]}}]}`
+	if string(got) != want {
+		t.Errorf("Unexpected ruleset input\nwant %s\ngot  %s", want, got)
+	}
+}
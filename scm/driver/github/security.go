@@ -0,0 +1,240 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type securityService struct {
+	client *wrapper
+}
+
+// securityAlert is the union of the dependabot, secret
+// scanning and code scanning alert payloads.
+type securityAlert struct {
+	Number          int       `json:"number"`
+	State           string    `json:"state"`
+	HTMLURL         string    `json:"html_url"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	DismissedAt     time.Time `json:"dismissed_at"`
+	DismissedReason string    `json:"dismissed_reason"`
+
+	// dependabot
+	Dependency struct {
+		Package struct {
+			Ecosystem string `json:"ecosystem"`
+			Name      string `json:"name"`
+		} `json:"package"`
+		ManifestPath string `json:"manifest_path"`
+	} `json:"dependency"`
+	SecurityAdvisory struct {
+		Summary     string `json:"summary"`
+		Description string `json:"description"`
+		Severity    string `json:"severity"`
+		Identifiers []struct {
+			Type  string `json:"type"`
+			Value string `json:"value"`
+		} `json:"identifiers"`
+	} `json:"security_advisory"`
+
+	// secret scanning
+	SecretTypeDisplayName string    `json:"secret_type_display_name"`
+	Resolution            string    `json:"resolution"`
+	ResolvedAt            time.Time `json:"resolved_at"`
+
+	// code scanning
+	Rule struct {
+		ID                    string `json:"id"`
+		Severity              string `json:"severity"`
+		SecuritySeverityLevel string `json:"security_severity_level"`
+		Description           string `json:"description"`
+		FullDescription       string `json:"full_description"`
+	} `json:"rule"`
+	MostRecentInstance struct {
+		Location struct {
+			Path string `json:"path"`
+		} `json:"location"`
+	} `json:"most_recent_instance"`
+}
+
+type securityAlertDismissInput struct {
+	State             string `json:"state"`
+	DismissedReason   string `json:"dismissed_reason,omitempty"`
+	DismissedComment  string `json:"dismissed_comment,omitempty"`
+	Resolution        string `json:"resolution,omitempty"`
+	ResolutionComment string `json:"resolution_comment,omitempty"`
+}
+
+func (s *securityService) ListAlerts(ctx context.Context, repo string, opts scm.SecurityAlertListOptions) ([]*scm.SecurityAlert, *scm.Response, error) {
+	base, err := alertPath(repo, opts.Kind, "ListAlerts")
+	if err != nil {
+		return nil, nil, err
+	}
+	path := fmt.Sprintf("%s?%s", base, encodeSecurityAlertListOptions(opts))
+	out := []*securityAlert{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertSecurityAlertList(out, opts.Kind), res, err
+}
+
+func (s *securityService) FindAlert(ctx context.Context, repo string, kind scm.AlertKind, number int) (*scm.SecurityAlert, *scm.Response, error) {
+	base, err := alertPath(repo, kind, "FindAlert")
+	if err != nil {
+		return nil, nil, err
+	}
+	path := fmt.Sprintf("%s/%d", base, number)
+	out := new(securityAlert)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertSecurityAlert(out, kind), res, err
+}
+
+func (s *securityService) DismissAlert(ctx context.Context, repo string, kind scm.AlertKind, number int, input *scm.SecurityAlertDismissInput) (*scm.SecurityAlert, *scm.Response, error) {
+	base, err := alertPath(repo, kind, "DismissAlert")
+	if err != nil {
+		return nil, nil, err
+	}
+	path := fmt.Sprintf("%s/%d", base, number)
+	in := &securityAlertDismissInput{State: "dismissed"}
+	switch kind {
+	case scm.AlertKindDependabot:
+		in.DismissedReason = convertDependabotDismissReason(input.Reason)
+		in.DismissedComment = input.Comment
+	case scm.AlertKindSecretScanning:
+		in.State = "resolved"
+		in.Resolution = input.Reason
+		in.ResolutionComment = input.Comment
+	case scm.AlertKindCodeScanning:
+		in.DismissedReason = convertCodeScanningDismissReason(input.Reason)
+		in.DismissedComment = input.Comment
+	}
+	out := new(securityAlert)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertSecurityAlert(out, kind), res, err
+}
+
+func alertPath(repo string, kind scm.AlertKind, method string) (string, error) {
+	switch kind {
+	case scm.AlertKindDependabot:
+		return fmt.Sprintf("repos/%s/dependabot/alerts", repo), nil
+	case scm.AlertKindSecretScanning:
+		return fmt.Sprintf("repos/%s/secret-scanning/alerts", repo), nil
+	case scm.AlertKindCodeScanning:
+		return fmt.Sprintf("repos/%s/code-scanning/alerts", repo), nil
+	default:
+		return "", notSupported("Security", method)
+	}
+}
+
+func encodeSecurityAlertListOptions(opts scm.SecurityAlertListOptions) string {
+	params := url.Values{}
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Size != 0 {
+		params.Set("per_page", strconv.Itoa(opts.Size))
+	}
+	switch {
+	case opts.State == "":
+	case opts.Kind == scm.AlertKindSecretScanning && opts.State != scm.AlertStateOpen:
+		params.Set("state", "resolved")
+	default:
+		params.Set("state", opts.State)
+	}
+	if opts.Severity != scm.SeverityUnknown && opts.Kind != scm.AlertKindSecretScanning {
+		params.Set("severity", opts.Severity.String())
+	}
+	return params.Encode()
+}
+
+func convertDependabotDismissReason(from string) string {
+	switch from {
+	case scm.DismissReasonFalsePositive:
+		return "inaccurate"
+	case scm.DismissReasonWontFix:
+		return "tolerable_risk"
+	case scm.DismissReasonUsedInTests:
+		return "not_used"
+	default:
+		return from
+	}
+}
+
+func convertCodeScanningDismissReason(from string) string {
+	switch from {
+	case scm.DismissReasonFalsePositive:
+		return "false positive"
+	case scm.DismissReasonWontFix:
+		return "won't fix"
+	case scm.DismissReasonUsedInTests:
+		return "used in tests"
+	default:
+		return from
+	}
+}
+
+func convertSecurityAlertList(from []*securityAlert, kind scm.AlertKind) []*scm.SecurityAlert {
+	to := []*scm.SecurityAlert{}
+	for _, v := range from {
+		to = append(to, convertSecurityAlert(v, kind))
+	}
+	return to
+}
+
+func convertSecurityAlert(from *securityAlert, kind scm.AlertKind) *scm.SecurityAlert {
+	to := &scm.SecurityAlert{
+		Number:          from.Number,
+		Kind:            kind,
+		State:           from.State,
+		Link:            from.HTMLURL,
+		DismissedReason: from.DismissedReason,
+		Created:         from.CreatedAt,
+		Updated:         from.UpdatedAt,
+		Dismissed:       from.DismissedAt,
+	}
+	switch kind {
+	case scm.AlertKindDependabot:
+		if from.State == "auto_dismissed" {
+			to.State = scm.AlertStateDismissed
+		}
+		to.Severity = scm.ToSeverity(from.SecurityAdvisory.Severity)
+		to.Title = from.SecurityAdvisory.Summary
+		to.Desc = from.SecurityAdvisory.Description
+		to.Package = from.Dependency.Package.Name
+		to.Path = from.Dependency.ManifestPath
+		for _, id := range from.SecurityAdvisory.Identifiers {
+			to.Identifiers = append(to.Identifiers, id.Value)
+		}
+	case scm.AlertKindSecretScanning:
+		// secret scanning alerts have no severity, revoked
+		// secrets are fixed and other resolutions dismissed.
+		to.Title = from.SecretTypeDisplayName
+		to.DismissedReason = from.Resolution
+		switch {
+		case from.State == "resolved" && from.Resolution == "revoked":
+			to.State = scm.AlertStateFixed
+		case from.State == "resolved":
+			to.State = scm.AlertStateDismissed
+			to.Dismissed = from.ResolvedAt
+		}
+	case scm.AlertKindCodeScanning:
+		if from.State == "closed" {
+			to.State = scm.AlertStateFixed
+		}
+		to.Severity = scm.ToSeverity(from.Rule.SecuritySeverityLevel)
+		if to.Severity == scm.SeverityUnknown {
+			to.Severity = scm.ToSeverity(from.Rule.Severity)
+		}
+		to.Title = from.Rule.Description
+		to.Desc = from.Rule.FullDescription
+		to.Path = from.MostRecentInstance.Location.Path
+		if from.Rule.ID != "" {
+			to.Identifiers = []string{from.Rule.ID}
+		}
+	}
+	return to
+}
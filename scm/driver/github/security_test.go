@@ -0,0 +1,148 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestSecurityListAlerts(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/dependabot/alerts").
+		MatchParam("state", "dismissed").
+		MatchParam("severity", "high").
+		MatchParam("per_page", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/dependabot_alerts.json")
+
+	client := NewDefault()
+	got, res, err := client.Security.ListAlerts(context.Background(), "octocat/hello-world", scm.SecurityAlertListOptions{
+		Kind:     scm.AlertKindDependabot,
+		State:    scm.AlertStateDismissed,
+		Severity: scm.SeverityHigh,
+		Size:     30,
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.SecurityAlert{}
+	raw, _ := ioutil.ReadFile("testdata/dependabot_alerts.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestSecurityListAlerts_UnknownKind(t *testing.T) {
+	client := NewDefault()
+	_, _, err := client.Security.ListAlerts(context.Background(), "octocat/hello-world", scm.SecurityAlertListOptions{})
+	if !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error, got %v", err)
+	}
+	want := &scm.NotSupportedError{Driver: scm.DriverGithub, Service: "Security", Method: "ListAlerts"}
+	if diff := cmp.Diff(err, error(want)); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSecurityFindAlert(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/code-scanning/alerts/42").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/code_scanning_alert.json")
+
+	client := NewDefault()
+	got, res, err := client.Security.FindAlert(context.Background(), "octocat/hello-world", scm.AlertKindCodeScanning, 42)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.SecurityAlert)
+	raw, _ := ioutil.ReadFile("testdata/code_scanning_alert.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestSecurityDismissAlert(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Patch("/repos/octocat/hello-world/secret-scanning/alerts/42").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/secret_scanning_alert.json")
+
+	input := &scm.SecurityAlertDismissInput{
+		Reason:  scm.DismissReasonUsedInTests,
+		Comment: "Example comment",
+	}
+
+	client := NewDefault()
+	got, res, err := client.Security.DismissAlert(context.Background(), "octocat/hello-world", scm.AlertKindSecretScanning, 42, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.SecurityAlert)
+	raw, _ := ioutil.ReadFile("testdata/secret_scanning_alert.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestConvertDismissReason(t *testing.T) {
+	tests := []struct {
+		reason, dependabot, codeScanning string
+	}{
+		{scm.DismissReasonFalsePositive, "inaccurate", "false positive"},
+		{scm.DismissReasonWontFix, "tolerable_risk", "won't fix"},
+		{scm.DismissReasonUsedInTests, "not_used", "used in tests"},
+		{"fix_started", "fix_started", "fix_started"},
+	}
+	for _, test := range tests {
+		if got := convertDependabotDismissReason(test.reason); got != test.dependabot {
+			t.Errorf("Want dependabot reason %q for %q, got %q", test.dependabot, test.reason, got)
+		}
+		if got := convertCodeScanningDismissReason(test.reason); got != test.codeScanning {
+			t.Errorf("Want code scanning reason %q for %q, got %q", test.codeScanning, test.reason, got)
+		}
+	}
+}
@@ -18,11 +18,35 @@ type userService struct {
 }
 
 func (s *userService) CreateToken(context.Context, string, string) (*scm.UserToken, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Users", "CreateToken")
 }
 
 func (s *userService) DeleteToken(context.Context, int64) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Users", "DeleteToken")
+}
+
+// TokenInfo returns the scopes and expiry of the token, read
+// from the response headers. Fine-grained tokens have no
+// scopes, and tokens without expiry report no expiration.
+func (s *userService) TokenInfo(ctx context.Context) (*scm.TokenInfo, *scm.Response, error) {
+	res, err := s.client.do(ctx, "GET", "user", nil, nil)
+	if err != nil {
+		return nil, res, err
+	}
+	out := &scm.TokenInfo{
+		Scopes: scm.ParseScopes(res.Header.Get("X-OAuth-Scopes")),
+	}
+	if v := res.Header.Get("GitHub-Authentication-Token-Expiration"); v != "" {
+		out.Expires, err = parseTokenExpiration(v)
+		if err != nil {
+			return nil, res, err
+		}
+	}
+	return out, res, nil
+}
+
+func (s *userService) ListTokens(context.Context, scm.ListOptions) ([]*scm.UserToken, *scm.Response, error) {
+	return nil, nil, notSupported("Users", "ListTokens")
 }
 
 func (s *userService) Find(ctx context.Context) (*scm.User, *scm.Response, error) {
@@ -93,7 +117,7 @@ func convertRepositoryInvitation(from *repositoryInvitation) *scm.Invitation {
 		Inviter:     convertUser(&from.Inviter),
 		Permissions: from.Permissions,
 		Link:        from.URL,
-		Created:     from.CreatedAt,
+		Created:     from.CreatedAt.UTC(),
 	}
 }
 
@@ -108,7 +132,17 @@ func convertUser(from *user) *scm.User {
 		Login:   from.Login,
 		Name:    from.Name,
 		Link:    from.HTMLURL,
-		Created: from.Created,
-		Updated: from.Updated,
+		Created: from.Created.UTC(),
+		Updated: from.Updated.UTC(),
+	}
+}
+
+// parseTokenExpiration parses the token expiration header,
+// e.g. 2023-03-15 08:25:09 UTC.
+func parseTokenExpiration(v string) (time.Time, error) {
+	t, err := time.Parse("2006-01-02 15:04:05 MST", v)
+	if err != nil {
+		t, err = time.Parse("2006-01-02 15:04:05 -0700", v)
 	}
+	return t, err
 }
@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/slimm609/go-scm/scm"
 
@@ -164,3 +165,56 @@ func TestUserAcceptInvitation(t *testing.T) {
 	t.Run("Request", testRequest(res))
 	t.Run("Rate", testRate(res))
 }
+
+func TestUserTokenInfo(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/user").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeader("X-OAuth-Scopes", "repo, read:org").
+		SetHeader("GitHub-Authentication-Token-Expiration", "2023-03-15 08:25:09 UTC").
+		File("testdata/user.json")
+
+	client := NewDefault()
+	got, res, err := client.Users.TokenInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &scm.TokenInfo{
+		Scopes:  []string{"repo", "read:org"},
+		Expires: time.Date(2023, 3, 15, 8, 25, 9, 0, time.UTC),
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestUserSSORequired(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/user").
+		Reply(403).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeader("X-GitHub-SSO", "required; url=https://github.com/orgs/octo-org/sso?authorization_request=AZSCKtL4U8yX1H3sCQIVnVgmjmon5fWxks5YrqhJgah0b2tlbl9pZM4EuMz4").
+		BodyString(`{"message": "Resource protected by organization SAML enforcement."}`)
+
+	client := NewDefault()
+	_, _, err := client.Users.TokenInfo(context.Background())
+	githubErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("Want github error, got %v", err)
+	}
+	if want := "https://github.com/orgs/octo-org/sso?authorization_request=AZSCKtL4U8yX1H3sCQIVnVgmjmon5fWxks5YrqhJgah0b2tlbl9pZM4EuMz4"; githubErr.SSOURL != want {
+		t.Errorf("Want sso url %s, got %s", want, githubErr.SSOURL)
+	}
+}
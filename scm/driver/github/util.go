@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/slimm609/go-scm/scm"
 )
@@ -46,6 +47,20 @@ func encodeCommitListOptions(opts scm.CommitListOptions) string {
 	return params.Encode()
 }
 
+func encodeCommentListOptions(opts scm.CommentListOptions) string {
+	params := url.Values{}
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Size != 0 {
+		params.Set("per_page", strconv.Itoa(opts.Size))
+	}
+	if !opts.Since.IsZero() {
+		params.Set("since", opts.Since.UTC().Format(time.RFC3339))
+	}
+	return params.Encode()
+}
+
 func encodeIssueListOptions(opts scm.IssueListOptions) string {
 	params := url.Values{}
 	if opts.Page != 0 {
@@ -59,6 +74,9 @@ func encodeIssueListOptions(opts scm.IssueListOptions) string {
 	} else if opts.Closed {
 		params.Set("state", "closed")
 	}
+	if !opts.UpdatedSince.IsZero() {
+		params.Set("since", opts.UpdatedSince.UTC().Format(time.RFC3339))
+	}
 	return params.Encode()
 }
 
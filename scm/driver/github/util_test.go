@@ -6,6 +6,7 @@ package github
 
 import (
 	"testing"
+	"time"
 
 	"github.com/slimm609/go-scm/scm"
 )
@@ -35,6 +36,19 @@ func Test_encodeCommitListOptions(t *testing.T) {
 	}
 }
 
+func Test_encodeCommentListOptions(t *testing.T) {
+	opts := scm.CommentListOptions{
+		Page:  10,
+		Size:  30,
+		Since: time.Date(2020, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600)),
+	}
+	want := "page=10&per_page=30&since=2020-01-02T02%3A04%3A05Z"
+	got := encodeCommentListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded comment list options %q, got %q", want, got)
+	}
+}
+
 func Test_encodeIssueListOptions(t *testing.T) {
 	opts := scm.IssueListOptions{
 		Page:   10,
@@ -0,0 +1,131 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type variableService struct {
+	client *wrapper
+}
+
+type variable struct {
+	Name      string    `json:"name"`
+	Value     string    `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type variableList struct {
+	TotalCount int         `json:"total_count"`
+	Variables  []*variable `json:"variables"`
+}
+
+type variableInput struct {
+	Name       string `json:"name"`
+	Value      string `json:"value"`
+	Visibility string `json:"visibility,omitempty"`
+}
+
+func (s *variableService) List(ctx context.Context, scope scm.VariableScope, opts scm.ListOptions) ([]*scm.Variable, *scm.Response, error) {
+	base, err := variablePath(scope, "List")
+	if err != nil {
+		return nil, nil, err
+	}
+	path := fmt.Sprintf("%s?%s", base, encodeListOptions(opts))
+	out := new(variableList)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertVariableList(out.Variables, scope), res, err
+}
+
+func (s *variableService) Find(ctx context.Context, scope scm.VariableScope, name string) (*scm.Variable, *scm.Response, error) {
+	base, err := variablePath(scope, "Find")
+	if err != nil {
+		return nil, nil, err
+	}
+	path := fmt.Sprintf("%s/%s", base, name)
+	out := new(variable)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertVariable(out, scope), res, err
+}
+
+// Create creates a new variable. Organization variables
+// are visible to all the repositories of the organization.
+func (s *variableService) Create(ctx context.Context, scope scm.VariableScope, input *scm.VariableInput) (*scm.Response, error) {
+	path, err := variablePath(scope, "Create")
+	if err != nil {
+		return nil, err
+	}
+	in := &variableInput{
+		Name:  input.Name,
+		Value: input.Value,
+	}
+	if scope.Org != "" {
+		in.Visibility = "all"
+	}
+	return s.client.do(ctx, "POST", path, in, nil)
+}
+
+func (s *variableService) Update(ctx context.Context, scope scm.VariableScope, input *scm.VariableInput) (*scm.Response, error) {
+	base, err := variablePath(scope, "Update")
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s", base, input.Name)
+	in := &variableInput{
+		Name:  input.Name,
+		Value: input.Value,
+	}
+	return s.client.do(ctx, "PATCH", path, in, nil)
+}
+
+func (s *variableService) Delete(ctx context.Context, scope scm.VariableScope, name string) (*scm.Response, error) {
+	base, err := variablePath(scope, "Delete")
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s", base, name)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+// variablePath returns the path of the variables of the
+// scope. Organization variables cannot be scoped to an
+// environment.
+func variablePath(scope scm.VariableScope, method string) (string, error) {
+	switch {
+	case scope.Repo != "" && scope.Org != "":
+		return "", scm.ErrInvalidVariableScope
+	case scope.Repo != "" && scope.Environment != "":
+		return fmt.Sprintf("repos/%s/environments/%s/variables", scope.Repo, url.PathEscape(scope.Environment)), nil
+	case scope.Repo != "":
+		return fmt.Sprintf("repos/%s/actions/variables", scope.Repo), nil
+	case scope.Org != "" && scope.Environment != "":
+		return "", notSupported("Variables", method)
+	case scope.Org != "":
+		return fmt.Sprintf("orgs/%s/actions/variables", scope.Org), nil
+	default:
+		return "", scm.ErrInvalidVariableScope
+	}
+}
+
+func convertVariableList(from []*variable, scope scm.VariableScope) []*scm.Variable {
+	to := []*scm.Variable{}
+	for _, v := range from {
+		to = append(to, convertVariable(v, scope))
+	}
+	return to
+}
+
+func convertVariable(from *variable, scope scm.VariableScope) *scm.Variable {
+	return &scm.Variable{
+		Name:        from.Name,
+		Value:       from.Value,
+		Environment: scope.Environment,
+		Created:     from.CreatedAt,
+		Updated:     from.UpdatedAt,
+	}
+}
@@ -0,0 +1,174 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestVariableList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/environments/production/variables").
+		MatchParam("page", "1").
+		MatchParam("per_page", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeaders(mockPageHeaders).
+		File("testdata/variables.json")
+
+	client := NewDefault()
+	scope := scm.VariableScope{Repo: "octocat/hello-world", Environment: "production"}
+	got, res, err := client.Variables.List(context.Background(), scope, scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Variable{}
+	raw, _ := ioutil.ReadFile("testdata/variables.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+	t.Run("Page", testPage(res))
+}
+
+func TestVariableFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/orgs/octo-org/actions/variables/USERNAME").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/variable.json")
+
+	client := NewDefault()
+	got, res, err := client.Variables.Find(context.Background(), scm.VariableScope{Org: "octo-org"}, "USERNAME")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Variable)
+	raw, _ := ioutil.ReadFile("testdata/variable.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestVariableCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/repos/octocat/hello-world/actions/variables").
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString("{}")
+
+	client := NewDefault()
+	input := &scm.VariableInput{Name: "USERNAME", Value: "octocat"}
+	res, err := client.Variables.Create(context.Background(), scm.VariableScope{Repo: "octocat/hello-world"}, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := res.Status, 201; got != want {
+		t.Errorf("Want response status %d, got %d", want, got)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestVariableUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Patch("/repos/octocat/hello-world/actions/variables/USERNAME").
+		Reply(204).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	input := &scm.VariableInput{Name: "USERNAME", Value: "monalisa"}
+	res, err := client.Variables.Update(context.Background(), scm.VariableScope{Repo: "octocat/hello-world"}, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := res.Status, 204; got != want {
+		t.Errorf("Want response status %d, got %d", want, got)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestVariableDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Delete("/repos/octocat/hello-world/actions/variables/USERNAME").
+		Reply(204).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	res, err := client.Variables.Delete(context.Background(), scm.VariableScope{Repo: "octocat/hello-world"}, "USERNAME")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := res.Status, 204; got != want {
+		t.Errorf("Want response status %d, got %d", want, got)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestVariablePath(t *testing.T) {
+	tests := []struct {
+		scope scm.VariableScope
+		path  string
+		err   error
+	}{
+		{scm.VariableScope{Repo: "octocat/hello-world"}, "repos/octocat/hello-world/actions/variables", nil},
+		{scm.VariableScope{Repo: "octocat/hello-world", Environment: "staging env"}, "repos/octocat/hello-world/environments/staging%20env/variables", nil},
+		{scm.VariableScope{Org: "octo-org"}, "orgs/octo-org/actions/variables", nil},
+		{scm.VariableScope{Org: "octo-org", Environment: "production"}, "", scm.ErrNotSupported},
+		{scm.VariableScope{Repo: "octocat/hello-world", Org: "octo-org"}, "", scm.ErrInvalidVariableScope},
+		{scm.VariableScope{}, "", scm.ErrInvalidVariableScope},
+	}
+	for _, test := range tests {
+		path, err := variablePath(test.scope, "List")
+		if path != test.path || !errors.Is(err, test.err) {
+			t.Errorf("Want path %q and error %v for %+v, got %q and %v", test.path, test.err, test.scope, path, err)
+		}
+	}
+}
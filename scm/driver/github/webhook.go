@@ -7,8 +7,6 @@ package github
 import (
 	"encoding/json"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"net/http"
 	"os"
 	"regexp"
@@ -24,12 +22,15 @@ var logWebHooks = os.Getenv("GO_SCM_LOG_WEBHOOKS") == "true"
 
 type webhookService struct {
 	client *wrapper
+	opts   scm.WebhookServiceOptions
 }
 
 func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
-	data, err := ioutil.ReadAll(
-		io.LimitReader(req.Body, 10000000),
-	)
+	return s.ParseMulti(req, scm.Secrets(fn))
+}
+
+func (s *webhookService) ParseMulti(req *http.Request, fn scm.MultiSecretFunc) (scm.Webhook, error) {
+	body, data, err := s.opts.ReadBody(req)
 	if err != nil {
 		return nil, err
 	}
@@ -37,7 +38,7 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	log := logrus.WithFields(map[string]interface{}{
 		"URL":     req.URL,
 		"Headers": req.Header,
-		"Body":    string(data),
+		"Body":    string(body),
 	})
 	if logWebHooks {
 		log.Infof("received webhook")
@@ -51,8 +52,18 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	var hook scm.Webhook
 	event := req.Header.Get("X-GitHub-Event")
 	switch event {
+	case "branch_protection_rule":
+		hook, err = s.parseBranchProtectionRuleHook(data)
 	case "check_run":
 		hook, err = s.parseCheckRunHook(data)
+	case "code_scanning_alert":
+		hook, err = s.parseSecurityAlertHook(data, scm.AlertKindCodeScanning)
+	case "dependabot_alert":
+		hook, err = s.parseSecurityAlertHook(data, scm.AlertKindDependabot)
+	case "secret_scanning_alert":
+		hook, err = s.parseSecurityAlertHook(data, scm.AlertKindSecretScanning)
+	case "repository_vulnerability_alert":
+		hook, err = s.parseRepositoryVulnerabilityAlertHook(data)
 	case "check_suite":
 		hook, err = s.parseCheckSuiteHook(data)
 	case "create":
@@ -75,6 +86,8 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 		hook, err = s.parseInstallationRepositoryHook(data)
 	case "label":
 		hook, err = s.parseLabelHook(data)
+	case "milestone":
+		hook, err = s.parseMilestoneHook(data)
 	case "ping":
 		hook, err = s.parsePingHook(data, guid)
 	case "push":
@@ -89,6 +102,8 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 		hook, err = s.parseReleaseHook(data)
 	case "repository":
 		hook, err = s.parseRepositoryHook(data)
+	case "star":
+		hook, err = s.parseStarHook(data)
 	case "status":
 		hook, err = s.parseStatusHook(data)
 	case "watch":
@@ -100,23 +115,27 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	if err != nil {
 		return nil, err
 	}
+	scm.SetGUID(hook, guid)
+	if s.opts.RawPayload {
+		scm.SetRaw(hook, data)
+	}
 
 	// get the gogs signature key to verify the payload
 	// signature. If no key is provided, no validation
 	// is performed.
-	key, err := fn(hook)
+	keys, err := fn(hook)
 	if err != nil {
 		return hook, err
-	} else if key == "" {
+	} else if len(keys) == 0 {
 		return hook, nil
 	}
 
 	if logWebHooks {
-		log.Infof("Webhook HMAC token: %s", key)
+		log.Infof("Webhook HMAC tokens: %v", keys)
 	}
 
 	sig := req.Header.Get("X-Hub-Signature")
-	if !hmac.ValidatePrefix(data, []byte(key), sig) {
+	if !scm.ValidateAny(keys, func(key string) bool { return hmac.ValidatePrefix(body, []byte(key), sig) }) {
 		return hook, scm.ErrSignatureInvalid
 	}
 
@@ -255,6 +274,69 @@ func (s *webhookService) parseLabelHook(data []byte) (scm.Webhook, error) {
 	return to, err
 }
 
+func (s *webhookService) parseBranchProtectionRuleHook(data []byte) (scm.Webhook, error) {
+	src := new(branchProtectionRuleHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	rule := new(branchProtectionRule)
+	err = json.Unmarshal(src.Rule, rule)
+	if err != nil {
+		return nil, err
+	}
+	to := convertBranchProtectionRuleHook(src, rule)
+	if len(src.Changes) != 0 {
+		// the changes hold the previous values of the edited
+		// fields, so applying them to the rule gives the rule
+		// before the edit.
+		fields := map[string]json.RawMessage{}
+		if err := json.Unmarshal(src.Rule, &fields); err != nil {
+			return nil, err
+		}
+		for name, change := range src.Changes {
+			fields[name] = change.From
+		}
+		raw, _ := json.Marshal(fields)
+		previous := new(branchProtectionRule)
+		if err := json.Unmarshal(raw, previous); err != nil {
+			return nil, err
+		}
+		to.Previous = convertBranchProtectionRule(previous)
+	}
+	return to, nil
+}
+
+func (s *webhookService) parseSecurityAlertHook(data []byte, kind scm.AlertKind) (scm.Webhook, error) {
+	src := new(securityAlertHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	to := convertSecurityAlertHook(src, kind)
+	return to, err
+}
+
+func (s *webhookService) parseRepositoryVulnerabilityAlertHook(data []byte) (scm.Webhook, error) {
+	src := new(repositoryVulnerabilityAlertHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	to := convertRepositoryVulnerabilityAlertHook(src)
+	return to, err
+}
+
+func (s *webhookService) parseMilestoneHook(data []byte) (scm.Webhook, error) {
+	src := new(milestoneHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	to := convertMilestoneHook(src)
+	return to, err
+}
+
 func (s *webhookService) parseReleaseHook(data []byte) (scm.Webhook, error) {
 	src := new(releaseHook)
 	err := json.Unmarshal(data, src)
@@ -413,9 +495,16 @@ type (
 		Installation *installationRef `json:"installation"`
 	}
 
+	// github check run or check suite status
+	checkStatus struct {
+		Status     string `json:"status"`
+		Conclusion string `json:"conclusion"`
+	}
+
 	// github check_run payload
 	checkRunHook struct {
 		Action       string           `json:"action"`
+		CheckRun     checkStatus      `json:"check_run"`
 		Repository   repository       `json:"repository"`
 		Sender       user             `json:"sender"`
 		Label        label            `json:"label"`
@@ -424,15 +513,17 @@ type (
 
 	// github star repo payload
 	starHook struct {
-		Action     string     `json:"action"`
-		Repository repository `json:"repository"`
-		Sender     user       `json:"sender"`
-		StarredAt  time.Time  `json:"starred_at"`
+		Action       string           `json:"action"`
+		Repository   repository       `json:"repository"`
+		Sender       user             `json:"sender"`
+		StarredAt    time.Time        `json:"starred_at"`
+		Installation *installationRef `json:"installation"`
 	}
 
 	// github check_suite payload
 	checkSuiteHook struct {
 		Action       string           `json:"action"`
+		CheckSuite   checkStatus      `json:"check_suite"`
 		Repository   repository       `json:"repository"`
 		Sender       user             `json:"sender"`
 		Label        label            `json:"label"`
@@ -450,6 +541,7 @@ type (
 
 	// github deployment_status payload
 	forkHook struct {
+		Forkee       repository       `json:"forkee"`
 		Repository   repository       `json:"repository"`
 		Sender       user             `json:"sender"`
 		Installation *installationRef `json:"installation"`
@@ -461,9 +553,93 @@ type (
 		Repository   repository       `json:"repository"`
 		Sender       user             `json:"sender"`
 		Label        label            `json:"label"`
+		Changes      labelHookChanges `json:"changes"`
+		Installation *installationRef `json:"installation"`
+	}
+
+	// github branch_protection_rule payload
+	branchProtectionRuleHook struct {
+		Action  string          `json:"action"`
+		Rule    json.RawMessage `json:"rule"`
+		Changes map[string]struct {
+			From json.RawMessage `json:"from"`
+		} `json:"changes"`
+		Repository   repository       `json:"repository"`
+		Sender       user             `json:"sender"`
+		Installation *installationRef `json:"installation"`
+	}
+
+	branchProtectionRule struct {
+		ID                                       int64     `json:"id"`
+		Name                                     string    `json:"name"`
+		PullRequestReviewsEnforcementLevel       string    `json:"pull_request_reviews_enforcement_level"`
+		RequiredApprovingReviewCount             int       `json:"required_approving_review_count"`
+		DismissStaleReviewsOnPush                bool      `json:"dismiss_stale_reviews_on_push"`
+		RequireCodeOwnerReview                   bool      `json:"require_code_owner_review"`
+		RequiredStatusChecks                     []string  `json:"required_status_checks"`
+		RequiredStatusChecksEnforcementLevel     string    `json:"required_status_checks_enforcement_level"`
+		StrictRequiredStatusChecksPolicy         bool      `json:"strict_required_status_checks_policy"`
+		SignatureRequirementEnforcementLevel     string    `json:"signature_requirement_enforcement_level"`
+		LinearHistoryRequirementEnforcementLevel string    `json:"linear_history_requirement_enforcement_level"`
+		AdminEnforced                            bool      `json:"admin_enforced"`
+		AllowForcePushesEnforcementLevel         string    `json:"allow_force_pushes_enforcement_level"`
+		AllowDeletionsEnforcementLevel           string    `json:"allow_deletions_enforcement_level"`
+		CreatedAt                                time.Time `json:"created_at"`
+		UpdatedAt                                time.Time `json:"updated_at"`
+	}
+
+	// github code_scanning_alert, dependabot_alert and
+	// secret_scanning_alert payload
+	securityAlertHook struct {
+		Action       string           `json:"action"`
+		Alert        securityAlert    `json:"alert"`
+		Repository   repository       `json:"repository"`
+		Sender       user             `json:"sender"`
+		Installation *installationRef `json:"installation"`
+	}
+
+	// github repository_vulnerability_alert payload, which
+	// predates the dependabot_alert payload.
+	repositoryVulnerabilityAlertHook struct {
+		Action string `json:"action"`
+		Alert  struct {
+			ID                  int       `json:"id"`
+			AffectedRange       string    `json:"affected_range"`
+			AffectedPackageName string    `json:"affected_package_name"`
+			ExternalReference   string    `json:"external_reference"`
+			ExternalIdentifier  string    `json:"external_identifier"`
+			GhsaID              string    `json:"ghsa_id"`
+			Severity            string    `json:"severity"`
+			CreatedAt           time.Time `json:"created_at"`
+			DismissReason       string    `json:"dismiss_reason"`
+			DismissedAt         time.Time `json:"dismissed_at"`
+		} `json:"alert"`
+		Repository   repository       `json:"repository"`
+		Sender       user             `json:"sender"`
 		Installation *installationRef `json:"installation"`
 	}
 
+	// github milestone payload
+	milestoneHook struct {
+		Action       string           `json:"action"`
+		Milestone    milestone        `json:"milestone"`
+		Repository   repository       `json:"repository"`
+		Sender       user             `json:"sender"`
+		Installation *installationRef `json:"installation"`
+	}
+
+	labelHookChanges struct {
+		Name struct {
+			From string `json:"from"`
+		} `json:"name"`
+		Color struct {
+			From string `json:"from"`
+		} `json:"color"`
+		Description struct {
+			From string `json:"from"`
+		} `json:"description"`
+	}
+
 	// github release payload
 	releaseHook struct {
 		Action       string           `json:"action"`
@@ -483,6 +659,11 @@ type (
 
 	// github status payload
 	statusHook struct {
+		Sha          string           `json:"sha"`
+		State        string           `json:"state"`
+		Context      string           `json:"context"`
+		Description  null.String      `json:"description"`
+		TargetURL    null.String      `json:"target_url"`
 		Repository   repository       `json:"repository"`
 		Sender       user             `json:"sender"`
 		Label        label            `json:"label"`
@@ -538,9 +719,6 @@ type (
 				Email    string `json:"email"`
 				Username string `json:"username"`
 			} `json:"committer"`
-			Added    []interface{} `json:"added"`
-			Removed  []interface{} `json:"removed"`
-			Modified []string      `json:"modified"`
 		} `json:"head_commit"`
 		Commits    []pushCommit `json:"commits"`
 		Repository struct {
@@ -586,6 +764,7 @@ type (
 	}
 
 	label struct {
+		ID          int64  `json:"id"`
 		URL         string `json:"url"`
 		Name        string `json:"name"`
 		Description string `json:"description"`
@@ -647,6 +826,8 @@ type (
 		HTMLURL   string    `json:"html_url"`
 		CreatedAt time.Time `json:"created_at"`
 		UpdatedAt time.Time `json:"updated_at"`
+
+		AuthorAssociation string `json:"author_association"`
 		// Position will be nil if the code has changed such that the comment is no
 		// longer relevant.
 		Position *int `json:"position"`
@@ -783,7 +964,7 @@ func asTimeStamp(t interface{}) *time.Time {
 	}
 	f, ok := t.(float64)
 	if ok {
-		answer := time.Unix(int64(f), 0)
+		answer := time.Unix(int64(f), 0).UTC()
 		return &answer
 	}
 	return nil
@@ -822,15 +1003,17 @@ func convertCheckRunHook(dst *checkRunHook) *scm.CheckRunHook {
 		Sender:       *convertUser(&dst.Sender),
 		Label:        convertLabel(dst.Label),
 		Installation: convertInstallationRef(dst.Installation),
+		State:        convertCheckState(dst.CheckRun.Status, dst.CheckRun.Conclusion),
 	}
 }
 
 func convertStarHook(dst *starHook) *scm.StarHook {
 	return &scm.StarHook{
-		Action:    convertAction(dst.Action),
-		StarredAt: dst.StarredAt,
-		Repo:      *convertRepository(&dst.Repository),
-		Sender:    *convertUser(&dst.Sender),
+		Action:       convertAction(dst.Action),
+		StarredAt:    dst.StarredAt,
+		Repo:         *convertRepository(&dst.Repository),
+		Sender:       *convertUser(&dst.Sender),
+		Installation: convertInstallationRef(dst.Installation),
 	}
 }
 
@@ -841,6 +1024,7 @@ func convertCheckSuiteHook(dst *checkSuiteHook) *scm.CheckSuiteHook {
 		Sender:       *convertUser(&dst.Sender),
 		Label:        convertLabel(dst.Label),
 		Installation: convertInstallationRef(dst.Installation),
+		State:        convertCheckState(dst.CheckSuite.Status, dst.CheckSuite.Conclusion),
 	}
 }
 
@@ -857,6 +1041,7 @@ func convertDeploymentStatusHook(dst *deploymentStatusHook) *scm.DeploymentStatu
 func convertForkHook(dst *forkHook) *scm.ForkHook {
 	return &scm.ForkHook{
 		Repo:         *convertRepository(&dst.Repository),
+		Fork:         *convertRepository(&dst.Forkee),
 		Sender:       *convertUser(&dst.Sender),
 		Installation: convertInstallationRef(dst.Installation),
 	}
@@ -864,10 +1049,121 @@ func convertForkHook(dst *forkHook) *scm.ForkHook {
 
 func convertLabelHook(dst *labelHook) *scm.LabelHook {
 	return &scm.LabelHook{
+		Action: convertAction(dst.Action),
+		Repo:   *convertRepository(&dst.Repository),
+		Sender: *convertUser(&dst.Sender),
+		Label:  convertLabel(dst.Label),
+		Changes: scm.LabelHookChanges{
+			Name:        dst.Changes.Name.From,
+			Color:       dst.Changes.Color.From,
+			Description: dst.Changes.Description.From,
+		},
+		Installation: convertInstallationRef(dst.Installation),
+	}
+}
+
+func convertBranchProtectionRuleHook(dst *branchProtectionRuleHook, rule *branchProtectionRule) *scm.BranchProtectionRuleHook {
+	return &scm.BranchProtectionRuleHook{
 		Action:       convertAction(dst.Action),
 		Repo:         *convertRepository(&dst.Repository),
 		Sender:       *convertUser(&dst.Sender),
-		Label:        convertLabel(dst.Label),
+		Rule:         *convertBranchProtectionRule(rule),
+		Installation: convertInstallationRef(dst.Installation),
+	}
+}
+
+// convertBranchProtectionRule converts a branch protection rule.
+// Enforcement levels are "off", "non_admins" or "everyone", so
+// anything other than "off" enables the setting.
+func convertBranchProtectionRule(from *branchProtectionRule) *scm.BranchProtectionRule {
+	return &scm.BranchProtectionRule{
+		ID:                           from.ID,
+		Pattern:                      from.Name,
+		RequirePullRequestReviews:    from.PullRequestReviewsEnforcementLevel != "off",
+		RequiredApprovingReviewCount: from.RequiredApprovingReviewCount,
+		DismissStaleReviews:          from.DismissStaleReviewsOnPush,
+		RequireCodeOwnerReview:       from.RequireCodeOwnerReview,
+		RequireStatusChecks:          from.RequiredStatusChecksEnforcementLevel != "off",
+		RequiredStatusChecks:         from.RequiredStatusChecks,
+		StrictStatusChecks:           from.StrictRequiredStatusChecksPolicy,
+		RequireSignedCommits:         from.SignatureRequirementEnforcementLevel != "off",
+		RequireLinearHistory:         from.LinearHistoryRequirementEnforcementLevel != "off",
+		EnforceAdmins:                from.AdminEnforced,
+		AllowForcePushes:             from.AllowForcePushesEnforcementLevel != "off",
+		AllowDeletions:               from.AllowDeletionsEnforcementLevel != "off",
+		Created:                      from.CreatedAt,
+		Updated:                      from.UpdatedAt,
+	}
+}
+
+func convertSecurityAlertHook(dst *securityAlertHook, kind scm.AlertKind) *scm.SecurityAlertHook {
+	alert := convertSecurityAlert(&dst.Alert, kind)
+	return &scm.SecurityAlertHook{
+		Action:       convertSecurityAlertAction(dst.Action, alert.State),
+		Repo:         *convertRepository(&dst.Repository),
+		Sender:       *convertUser(&dst.Sender),
+		Alert:        *alert,
+		Installation: convertInstallationRef(dst.Installation),
+	}
+}
+
+func convertRepositoryVulnerabilityAlertHook(dst *repositoryVulnerabilityAlertHook) *scm.SecurityAlertHook {
+	alert := scm.SecurityAlert{
+		Number:          dst.Alert.ID,
+		Kind:            scm.AlertKindDependabot,
+		State:           scm.AlertStateOpen,
+		Severity:        scm.ToSeverity(dst.Alert.Severity),
+		Package:         dst.Alert.AffectedPackageName,
+		Link:            dst.Alert.ExternalReference,
+		DismissedReason: dst.Alert.DismissReason,
+		Created:         dst.Alert.CreatedAt,
+		Dismissed:       dst.Alert.DismissedAt,
+	}
+	for _, id := range []string{dst.Alert.GhsaID, dst.Alert.ExternalIdentifier} {
+		if id != "" {
+			alert.Identifiers = append(alert.Identifiers, id)
+		}
+	}
+	switch dst.Action {
+	case "dismiss":
+		alert.State = scm.AlertStateDismissed
+	case "resolve":
+		alert.State = scm.AlertStateFixed
+	}
+	return &scm.SecurityAlertHook{
+		Action:       convertSecurityAlertAction(dst.Action, alert.State),
+		Repo:         *convertRepository(&dst.Repository),
+		Sender:       *convertUser(&dst.Sender),
+		Alert:        alert,
+		Installation: convertInstallationRef(dst.Installation),
+	}
+}
+
+// convertSecurityAlertAction converts the action of a security
+// alert event. Alerts closed by a fix are closed, and alerts
+// closed otherwise are dismissed.
+func convertSecurityAlertAction(action, state string) scm.Action {
+	switch action {
+	case "create", "created":
+		return scm.ActionCreate
+	case "reopen", "reopened", "reopened_by_user", "auto_reopened", "reintroduced":
+		return scm.ActionReopen
+	case "dismiss", "dismissed", "auto_dismissed", "closed_by_user", "fix", "fixed", "resolve", "resolved", "revoked":
+		if state == scm.AlertStateFixed {
+			return scm.ActionClose
+		}
+		return scm.ActionDismissed
+	default:
+		return convertAction(action)
+	}
+}
+
+func convertMilestoneHook(dst *milestoneHook) *scm.MilestoneHook {
+	return &scm.MilestoneHook{
+		Action:       convertAction(dst.Action),
+		Repo:         *convertRepository(&dst.Repository),
+		Sender:       *convertUser(&dst.Sender),
+		Milestone:    *convertMilestone(&dst.Milestone),
 		Installation: convertInstallationRef(dst.Installation),
 	}
 }
@@ -897,6 +1193,13 @@ func convertStatusHook(dst *statusHook) *scm.StatusHook {
 		Sender:       *convertUser(&dst.Sender),
 		Label:        convertLabel(dst.Label),
 		Installation: convertInstallationRef(dst.Installation),
+		Sha:          dst.Sha,
+		Status: scm.Status{
+			State:  convertState(dst.State),
+			Label:  dst.Context,
+			Desc:   dst.Description.String,
+			Target: dst.TargetURL.String,
+		},
 	}
 }
 
@@ -1044,6 +1347,7 @@ func convertPullRequestChanges(src *pullRequestHookChanges) *scm.PullRequestHook
 
 func convertLabel(src label) scm.Label {
 	return scm.Label{
+		ID:          src.ID,
 		Color:       src.Color,
 		Description: src.Description,
 		Name:        src.Name,
@@ -1110,6 +1414,8 @@ func convertPullRequestComment(comment *reviewCommentFromHook) *scm.Comment {
 		Author:  *convertUser(&comment.User),
 		Created: comment.CreatedAt,
 		Updated: comment.UpdatedAt,
+
+		AuthorAssociation: comment.AuthorAssociation,
 	}
 }
 
@@ -1192,3 +1498,32 @@ func convertAction(src string) (action scm.Action) {
 		return
 	}
 }
+
+// convertCheckState maps a check run or check suite status,
+// and its conclusion once completed, to the normalized state.
+func convertCheckState(status, conclusion string) scm.State {
+	switch status {
+	case "queued", "requested", "waiting", "pending":
+		return scm.StatePending
+	case "in_progress":
+		return scm.StateRunning
+	}
+	switch conclusion {
+	case "success":
+		return scm.StateSuccess
+	case "failure":
+		return scm.StateFailure
+	case "neutral":
+		return scm.StateNeutral
+	case "cancelled":
+		return scm.StateCanceled
+	case "skipped":
+		return scm.StateSkipped
+	case "timed_out":
+		return scm.StateError
+	case "action_required":
+		return scm.StateActionRequired
+	default:
+		return scm.StateUnknown
+	}
+}
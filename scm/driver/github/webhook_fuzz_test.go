@@ -0,0 +1,50 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package github
+
+import (
+	"testing"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/internal/hooktest"
+)
+
+// fuzzEvents are the events parsed by the webhook service.
+var fuzzEvents = []string{
+	"branch_protection_rule", "check_run", "code_scanning_alert",
+	"dependabot_alert", "secret_scanning_alert",
+	"repository_vulnerability_alert", "check_suite", "create", "delete",
+	"deployment", "deployment_status", "fork", "issues", "issue_comment",
+	"installation", "installation_repositories", "label", "milestone",
+	"ping", "push", "pull_request", "pull_request_review",
+	"pull_request_review_comment", "release", "repository", "star",
+	"status", "watch",
+}
+
+// FuzzWebhook parses the corpus of hooktest.Corpus built from
+// the GitHub test payloads.
+func FuzzWebhook(f *testing.F) {
+	payloads, err := hooktest.Payloads("testdata/webhooks")
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, seed := range hooktest.Corpus(payloads, fuzzEvents) {
+		f.Add(seed.Event, seed.ContentType, seed.Mixed, seed.Data)
+	}
+	s := NewWebHookService(scm.WebhookServiceOptions{FormPayload: true})
+	f.Fuzz(func(t *testing.T, event, contentType string, mixed bool, data []byte) {
+		r := hooktest.Request("X-GitHub-Event", event, contentType, mixed, data)
+		hook, err := s.Parse(r, func(scm.Webhook) (string, error) {
+			return "", nil
+		})
+		if err == nil && hook != nil {
+			hook.Kind()
+			hook.Repository()
+		}
+	})
+}
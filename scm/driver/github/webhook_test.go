@@ -6,12 +6,17 @@ package github
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/slimm609/go-scm/scm"
 	"github.com/stretchr/testify/assert"
@@ -31,6 +36,38 @@ func TestWebhooks(t *testing.T) {
 		// push events
 		//
 
+		// branch protection rule
+		{
+			name:   "branch_protection_rule",
+			event:  "branch_protection_rule",
+			before: "testdata/webhooks/branch_protection_rule_edited.json",
+			after:  "testdata/webhooks/branch_protection_rule_edited.json.golden",
+			obj:    new(scm.BranchProtectionRuleHook),
+		},
+
+		// security alerts
+		{
+			name:   "code_scanning_alert",
+			event:  "code_scanning_alert",
+			before: "testdata/webhooks/code_scanning_alert.json",
+			after:  "testdata/webhooks/code_scanning_alert.json.golden",
+			obj:    new(scm.SecurityAlertHook),
+		},
+		{
+			name:   "secret_scanning_alert",
+			event:  "secret_scanning_alert",
+			before: "testdata/webhooks/secret_scanning_alert.json",
+			after:  "testdata/webhooks/secret_scanning_alert.json.golden",
+			obj:    new(scm.SecurityAlertHook),
+		},
+		{
+			name:   "repository_vulnerability_alert",
+			event:  "repository_vulnerability_alert",
+			before: "testdata/webhooks/repository_vulnerability_alert.json",
+			after:  "testdata/webhooks/repository_vulnerability_alert.json.golden",
+			obj:    new(scm.SecurityAlertHook),
+		},
+
 		// fork
 		{
 			name:   "fork",
@@ -40,6 +77,24 @@ func TestWebhooks(t *testing.T) {
 			obj:    new(scm.ForkHook),
 		},
 
+		// star
+		{
+			name:   "star",
+			event:  "star",
+			before: "testdata/webhooks/star.json",
+			after:  "testdata/webhooks/star.json.golden",
+			obj:    new(scm.StarHook),
+		},
+
+		// watch
+		{
+			name:   "watch",
+			event:  "watch",
+			before: "testdata/webhooks/watch.json",
+			after:  "testdata/webhooks/watch.json.golden",
+			obj:    new(scm.WatchHook),
+		},
+
 		// repository
 		{
 			name:   "repository",
@@ -102,6 +157,22 @@ func TestWebhooks(t *testing.T) {
 			after:  "testdata/webhooks/label_deleted.json.golden",
 			obj:    new(scm.LabelHook),
 		},
+		{
+			name:   "label_edited",
+			event:  "label",
+			before: "testdata/webhooks/label_edited.json",
+			after:  "testdata/webhooks/label_edited.json.golden",
+			obj:    new(scm.LabelHook),
+		},
+
+		// milestone
+		{
+			name:   "milestone",
+			event:  "milestone",
+			before: "testdata/webhooks/milestone_closed.json",
+			after:  "testdata/webhooks/milestone_closed.json.golden",
+			obj:    new(scm.MilestoneHook),
+		},
 
 		// ping
 		{
@@ -368,11 +439,25 @@ func TestWebhooks(t *testing.T) {
 				assert.NotNil(t, event.Installation, "InstallationHook.Installation")
 				assert.NotNil(t, event.GetInstallationRef(), "InstallationHook.GetInstallationRef()")
 				assert.NotEmpty(t, event.GetInstallationRef().ID, "InstallationHook.GetInstallationRef().ID")
+				assertUTC(t, event.Installation)
+			case *scm.InstallationRepositoryHook:
+				assertUTC(t, event.Installation)
 			}
 		})
 	}
 }
 
+// assertUTC checks that the times of the installation are in UTC,
+// which the golden files cannot check since the times are compared
+// with their Equal method.
+func assertUTC(t *testing.T, installation *scm.Installation) {
+	for _, v := range []*time.Time{installation.CreatedAt, installation.UpdatedAt} {
+		if v != nil && v.Location() != time.UTC {
+			t.Errorf("Want the installation times in UTC, got %s", v.Location())
+		}
+	}
+}
+
 func TestWebhook_ErrUnknownEvent(t *testing.T) {
 	f, _ := ioutil.ReadFile("testdata/webhooks/push.json")
 	r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
@@ -417,6 +502,114 @@ func TestWebhookValid(t *testing.T) {
 	}
 }
 
+func TestWebhookValid_RotatedSecret(t *testing.T) {
+	f, _ := ioutil.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-GitHub-Event", "push")
+	r.Header.Set("X-GitHub-Delivery", "ee8d97b4-1479-43f1-9cac-fbbd1b80da55")
+	r.Header.Set("X-Hub-Signature", "sha1=e9c4409d39729236fda483f22e7fb7513e5cd273")
+
+	s := new(webhookService)
+	_, err := s.ParseMulti(r, func(scm.Webhook) ([]string, error) {
+		return []string{"newsecret", "topsecret"}, nil
+	})
+	if err != nil {
+		t.Errorf("Expect valid signature, got %v", err)
+	}
+}
+
+func TestWebhookInvalid_RotatedSecret(t *testing.T) {
+	f, _ := ioutil.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-GitHub-Event", "push")
+	r.Header.Set("X-GitHub-Delivery", "ee8d97b4-1479-43f1-9cac-fbbd1b80da55")
+	r.Header.Set("X-Hub-Signature", "sha1=e9c4409d39729236fda483f22e7fb7513e5cd273")
+
+	s := new(webhookService)
+	_, err := s.ParseMulti(r, func(scm.Webhook) ([]string, error) {
+		return []string{"newsecret", "oldsecret"}, nil
+	})
+	if err != scm.ErrSignatureInvalid {
+		t.Errorf("Expect invalid signature error, got %v", err)
+	}
+}
+
+func TestWebhookValid_FormPayload(t *testing.T) {
+	f, _ := ioutil.ReadFile("testdata/webhooks/push.json")
+	form := "payload=" + url.QueryEscape(string(f))
+	mac := hmac.New(sha1.New, []byte("topsecret"))
+	mac.Write([]byte(form))
+
+	r, _ := http.NewRequest("POST", "/", strings.NewReader(form))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-GitHub-Event", "push")
+	r.Header.Set("X-GitHub-Delivery", "ee8d97b4-1479-43f1-9cac-fbbd1b80da55")
+	r.Header.Set("X-Hub-Signature", "sha1="+hex.EncodeToString(mac.Sum(nil)))
+
+	s := NewWebHookService(scm.WebhookServiceOptions{FormPayload: true})
+	hook, err := s.Parse(r, secretFunc)
+	if err != nil {
+		t.Fatalf("Expect valid signature, got %v", err)
+	}
+	if got, want := hook.(*scm.PushHook).Ref, "refs/heads/master"; got != want {
+		t.Errorf("Want ref %q, got %q", want, got)
+	}
+	if raw := hook.(*scm.PushHook).Raw; raw != nil {
+		t.Errorf("Want no raw payload, got %s", raw)
+	}
+}
+
+func TestWebhookRawPayload(t *testing.T) {
+	f, _ := ioutil.ReadFile("testdata/webhooks/push.json")
+	form := "payload=" + url.QueryEscape(string(f))
+	r, _ := http.NewRequest("POST", "/", strings.NewReader(form))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-GitHub-Event", "push")
+	r.Header.Set("X-GitHub-Delivery", "ee8d97b4-1479-43f1-9cac-fbbd1b80da55")
+
+	s := NewWebHookService(scm.WebhookServiceOptions{FormPayload: true, RawPayload: true})
+	hook, err := s.Parse(r, func(scm.Webhook) (string, error) { return "", nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hook.(*scm.PushHook).Raw; string(got) != string(f) {
+		t.Errorf("Want the JSON payload as the raw payload, got %s", got)
+	}
+}
+
 func secretFunc(scm.Webhook) (string, error) {
 	return "topsecret", nil
 }
+
+func BenchmarkWebhookPush(b *testing.B) {
+	f, err := ioutil.ReadFile("testdata/webhooks/push.json")
+	if err != nil {
+		b.Fatal(err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(f, &doc); err != nil {
+		b.Fatal(err)
+	}
+	commits := make([]interface{}, 1000)
+	for i := range commits {
+		commits[i] = doc["head_commit"]
+	}
+	doc["commits"] = commits
+	data, err := json.Marshal(doc)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	s := new(webhookService)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r, _ := http.NewRequest("POST", "/", bytes.NewReader(data))
+		r.Header.Set("X-GitHub-Event", "push")
+		r.Header.Set("X-GitHub-Delivery", "ee8d97b4-1479-43f1-9cac-fbbd1b80da55")
+		if _, err := s.Parse(r, func(scm.Webhook) (string, error) { return "", nil }); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
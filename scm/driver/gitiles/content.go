@@ -0,0 +1,155 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitiles
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type contentService struct {
+	client *wrapper
+}
+
+// Find returns the file, which Gitiles serves base64 encoded
+// in the text format. The sha of the file is not reported.
+func (s *contentService) Find(ctx context.Context, repo, path, ref string) (*scm.Content, *scm.Response, error) {
+	path = strings.Trim(path, "/")
+	endpoint := fmt.Sprintf("%s/+/%s/%s?format=TEXT", repo, revision(ref), path)
+	buf := new(bytes.Buffer)
+	res, err := s.client.do(ctx, endpoint, buf)
+	if err != nil {
+		return nil, res, err
+	}
+	raw, err := base64.StdEncoding.DecodeString(buf.String())
+	return &scm.Content{
+		Path: path,
+		Data: raw,
+	}, res, err
+}
+
+func (s *contentService) FindMany(ctx context.Context, repo, ref string, paths []string) (map[string]*scm.Content, map[string]error) {
+	return scm.FindManyContents(ctx, s, repo, ref, paths, scm.DefaultConcurrency)
+}
+
+func (s *contentService) List(ctx context.Context, repo, dir, ref string) ([]*scm.FileEntry, *scm.Response, error) {
+	dir = strings.Trim(dir, "/")
+	endpoint := fmt.Sprintf("%s/+/%s/%s?format=JSON&long=1", repo, revision(ref), dir)
+	out := new(tree)
+	res, err := s.client.do(ctx, endpoint, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return s.convertEntryList(repo, revision(ref), dir, out.Entries), res, nil
+}
+
+func (s *contentService) Exists(ctx context.Context, repo, path, ref string) (bool, *scm.Response, error) {
+	_, res, err := s.Stat(ctx, repo, path, ref)
+	if err == scm.ErrNotFound {
+		return false, res, nil
+	}
+	return err == nil, res, err
+}
+
+// Stat returns the file metadata from the listing of the
+// parent directory, since Gitiles only lists the trees.
+func (s *contentService) Stat(ctx context.Context, repo, filepath, ref string) (*scm.FileEntry, *scm.Response, error) {
+	filepath = strings.Trim(filepath, "/")
+	dir := path.Dir(filepath)
+	if dir == "." {
+		dir = ""
+	}
+	entries, res, err := s.List(ctx, repo, dir, ref)
+	if err != nil {
+		return nil, res, err
+	}
+	for _, entry := range entries {
+		if entry.Path == filepath {
+			return entry, res, nil
+		}
+	}
+	return nil, res, scm.ErrNotFound
+}
+
+func (s *contentService) Create(context.Context, string, string, *scm.ContentParams) (*scm.Response, error) {
+	return nil, notSupported("Contents", "Create")
+}
+
+func (s *contentService) Update(context.Context, string, string, *scm.ContentParams) (*scm.Response, error) {
+	return nil, notSupported("Contents", "Update")
+}
+
+func (s *contentService) Delete(context.Context, string, string, string) (*scm.Response, error) {
+	return nil, notSupported("Contents", "Delete")
+}
+
+// revision returns the ref, or the HEAD of the repository if
+// the ref is empty.
+func revision(ref string) string {
+	if ref == "" {
+		return "HEAD"
+	}
+	return ref
+}
+
+//
+// native data structures
+//
+
+type (
+	// gitiles tree object.
+	tree struct {
+		ID      string   `json:"id"`
+		Entries []*entry `json:"entries"`
+	}
+
+	// gitiles tree entry. The type is blob, tree or commit,
+	// and the size is only set for a blob.
+	entry struct {
+		Mode int    `json:"mode"`
+		Type string `json:"type"`
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Size int    `json:"size"`
+	}
+)
+
+//
+// native data structure conversion
+//
+
+func (s *contentService) convertEntryList(repo, ref, dir string, from []*entry) []*scm.FileEntry {
+	to := make([]*scm.FileEntry, 0, len(from))
+	for _, v := range from {
+		to = append(to, s.convertEntry(repo, ref, dir, v))
+	}
+	return to
+}
+
+func (s *contentService) convertEntry(repo, ref, dir string, from *entry) *scm.FileEntry {
+	filepath := path.Join(dir, from.Name)
+	to := &scm.FileEntry{
+		Name: from.Name,
+		Path: filepath,
+		Type: "file",
+		Size: from.Size,
+		Sha:  from.ID,
+		Link: s.client.BaseURL.ResolveReference(&url.URL{Path: fmt.Sprintf("%s/+/%s/%s", repo, ref, filepath)}).String(),
+	}
+	switch from.Type {
+	case "tree":
+		to.Type = "dir"
+	case "commit":
+		to.Type = "submodule"
+	}
+	return to
+}
@@ -0,0 +1,118 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitiles
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestContentFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://chromium.googlesource.com").
+		Get(`/chromium/src/\+/main/README.md`).
+		MatchParam("format", "TEXT").
+		Reply(200).
+		Type("text/plain").
+		BodyString("SGVsbG8gV29ybGQhCg==")
+
+	client, _ := New("https://chromium.googlesource.com")
+	got, _, err := client.Contents.Find(context.Background(), "chromium/src", "README.md", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &scm.Content{
+		Path: "README.md",
+		Data: []byte("Hello World!\n"),
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestContentList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://chromium.googlesource.com").
+		Get(`/chromium/src/\+/main/docs`).
+		MatchParam("format", "JSON").
+		MatchParam("long", "1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/tree.json")
+
+	client, _ := New("https://chromium.googlesource.com")
+	got, _, err := client.Contents.List(context.Background(), "chromium/src", "docs", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.FileEntry{}
+	raw, _ := ioutil.ReadFile("testdata/tree.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestContentStat(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://chromium.googlesource.com").
+		Get(`/chromium/src/\+/main/docs`).
+		MatchParam("format", "JSON").
+		Reply(200).
+		Type("application/json").
+		File("testdata/tree.json")
+
+	client, _ := New("https://chromium.googlesource.com")
+	got, _, err := client.Contents.Stat(context.Background(), "chromium/src", "docs/images", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Type != "dir" || got.Path != "docs/images" {
+		t.Errorf("Unexpected entry %v", got)
+	}
+}
+
+func TestContentExists(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://chromium.googlesource.com").
+		Get(`/chromium/src/\+/main/docs`).
+		MatchParam("format", "JSON").
+		Reply(200).
+		Type("application/json").
+		File("testdata/tree.json")
+
+	client, _ := New("https://chromium.googlesource.com")
+	got, _, err := client.Contents.Exists(context.Background(), "chromium/src", "docs/missing.md", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got {
+		t.Errorf("Expect the missing file not to exist")
+	}
+}
+
+func TestContentCreate_NotSupported(t *testing.T) {
+	client, _ := New("https://chromium.googlesource.com")
+	_, err := client.Contents.Create(context.Background(), "chromium/src", "README.md", &scm.ContentParams{})
+	if !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error")
+	}
+}
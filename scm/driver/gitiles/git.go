@@ -0,0 +1,347 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitiles
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type gitService struct {
+	client *wrapper
+}
+
+func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *scm.Response, error) {
+	var res *scm.Response
+	var err error
+	for _, path := range scm.QualifyRefs(ref) {
+		var out *scm.Reference
+		out, res, err = s.findRef(ctx, repo, path)
+		if err == nil {
+			return out.Sha, res, nil
+		} else if err != scm.ErrNotFound {
+			break
+		}
+	}
+	return "", res, err
+}
+
+func (s *gitService) ResolveRefs(ctx context.Context, repo string, refs []string) (map[string]string, map[string]error) {
+	return scm.ResolveRefs(ctx, s, repo, refs, scm.DefaultConcurrency)
+}
+
+func (s *gitService) CreateRef(context.Context, string, string, string) (*scm.Reference, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "CreateRef")
+}
+
+func (s *gitService) UpdateRef(context.Context, string, string, string, bool) (*scm.Reference, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "UpdateRef")
+}
+
+func (s *gitService) DeleteRef(context.Context, string, string) (*scm.Response, error) {
+	return nil, notSupported("Git", "DeleteRef")
+}
+
+func (s *gitService) FindBranch(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
+	return s.findRef(ctx, repo, scm.ExpandRef(name, "refs/heads"))
+}
+
+func (s *gitService) FindTag(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
+	return s.findRef(ctx, repo, scm.ExpandRef(name, "refs/tags"))
+}
+
+// findRef finds the fully qualified ref. Gitiles lists the
+// refs starting with the path, so the ref is looked up in
+// the listing.
+func (s *gitService) findRef(ctx context.Context, repo, ref string) (*scm.Reference, *scm.Response, error) {
+	path := fmt.Sprintf("%s/+%s?format=JSON", repo, ref)
+	out := map[string]*refValue{}
+	res, err := s.client.do(ctx, path, &out)
+	if err != nil {
+		return nil, res, err
+	}
+	for name, v := range out {
+		// the names are relative to the ref, and the ref
+		// itself is named by its last path element.
+		if name == ref || strings.HasSuffix(ref, "/"+name) {
+			return convertRef(ref, v), res, nil
+		}
+	}
+	return nil, res, scm.ErrNotFound
+}
+
+func (s *gitService) FindCommit(ctx context.Context, repo, ref string) (*scm.Commit, *scm.Response, error) {
+	path := fmt.Sprintf("%s/+/%s?format=JSON", repo, ref)
+	out := new(commit)
+	res, err := s.client.do(ctx, path, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertCommit(out, s.commitLink(repo, out.Commit)), res, nil
+}
+
+func (s *gitService) ListBranches(ctx context.Context, repo string, _ scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
+	return s.listRefs(ctx, repo, "refs/heads")
+}
+
+func (s *gitService) ListTags(ctx context.Context, repo string, _ scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
+	return s.listRefs(ctx, repo, "refs/tags")
+}
+
+// listRefs returns all the refs of the namespace sorted by
+// name, as Gitiles does not paginate the refs.
+func (s *gitService) listRefs(ctx context.Context, repo, prefix string) ([]*scm.Reference, *scm.Response, error) {
+	path := fmt.Sprintf("%s/+%s?format=JSON", repo, prefix)
+	out := map[string]*refValue{}
+	res, err := s.client.do(ctx, path, &out)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertRefList(prefix, out), res, nil
+}
+
+// ListCommits returns the page of the log from the sha or the
+// ref, or from the HEAD of the repository. Gitiles paginates
+// the log with the cursor reported in the next url of the
+// response.
+func (s *gitService) ListCommits(ctx context.Context, repo string, opts scm.CommitListOptions) ([]*scm.Commit, *scm.Response, error) {
+	from := opts.Sha
+	if from == "" {
+		from = opts.Ref
+	}
+	if from == "" {
+		from = "HEAD"
+	}
+	out, res, err := s.log(ctx, repo, from, opts.Size, "")
+	if err != nil {
+		return nil, res, err
+	}
+	return s.convertCommitList(repo, out.Log), res, nil
+}
+
+// log returns the page of the log of the revision, which may
+// be a range such as base..head, starting at the cursor.
+func (s *gitService) log(ctx context.Context, repo, rev string, size int, cursor string) (*logPage, *scm.Response, error) {
+	params := url.Values{}
+	params.Set("format", "JSON")
+	if size != 0 {
+		params.Set("n", fmt.Sprint(size))
+	}
+	if cursor != "" {
+		params.Set("s", cursor)
+	}
+	path := fmt.Sprintf("%s/+log/%s?%s", repo, rev, params.Encode())
+	out := new(logPage)
+	res, err := s.client.do(ctx, path, out)
+	if res != nil {
+		res.Page.NextURL = out.Next
+	}
+	return out, res, err
+}
+
+// ListChanges returns the files changed by the commit, from
+// its first parent.
+func (s *gitService) ListChanges(ctx context.Context, repo, ref string, _ scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
+	path := fmt.Sprintf("%s/+/%s?format=JSON", repo, ref)
+	out := new(commit)
+	res, err := s.client.do(ctx, path, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertChangeList(out.TreeDiff), res, nil
+}
+
+// CompareCommits compares the refs with the logs of both
+// ranges, following every page. Gitiles reports neither the
+// merge base nor the changes of a range.
+func (s *gitService) CompareCommits(ctx context.Context, repo, base, head string) (*scm.Comparison, *scm.Response, error) {
+	ahead, res, err := s.logAll(ctx, repo, base+".."+head)
+	if err != nil {
+		return nil, res, err
+	}
+	behind, res, err := s.logAll(ctx, repo, head+".."+base)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertComparison(s.convertCommitList(repo, ahead), len(behind)), res, nil
+}
+
+func (s *gitService) logAll(ctx context.Context, repo, rev string) ([]*commit, *scm.Response, error) {
+	var all []*commit
+	cursor := ""
+	for {
+		out, res, err := s.log(ctx, repo, rev, 0, cursor)
+		if err != nil {
+			return nil, res, err
+		}
+		all = append(all, out.Log...)
+		if out.Next == "" {
+			return all, res, nil
+		}
+		cursor = out.Next
+	}
+}
+
+func (s *gitService) CompareAcrossForks(context.Context, string, string, string, string) (*scm.Comparison, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "CompareAcrossForks")
+}
+
+// commitLink returns the address of the commit, since Gitiles
+// does not report it.
+func (s *gitService) commitLink(repo, sha string) string {
+	return s.client.BaseURL.ResolveReference(&url.URL{Path: repo + "/+/" + sha}).String()
+}
+
+func (s *gitService) convertCommitList(repo string, from []*commit) []*scm.Commit {
+	to := []*scm.Commit{}
+	for _, v := range from {
+		to = append(to, convertCommit(v, s.commitLink(repo, v.Commit)))
+	}
+	return to
+}
+
+//
+// native data structures
+//
+
+type (
+	// gitiles ref object. The peeled value is the commit of
+	// an annotated tag.
+	refValue struct {
+		Value  string `json:"value"`
+		Peeled string `json:"peeled"`
+		Target string `json:"target"`
+	}
+
+	// gitiles commit object. The tree diff is only set when
+	// a single commit is requested.
+	commit struct {
+		Commit    string      `json:"commit"`
+		Tree      string      `json:"tree"`
+		Parents   []string    `json:"parents"`
+		Author    signature   `json:"author"`
+		Committer signature   `json:"committer"`
+		Message   string      `json:"message"`
+		TreeDiff  []*treeDiff `json:"tree_diff"`
+	}
+
+	// gitiles commit signature.
+	signature struct {
+		Name  string    `json:"name"`
+		Email string    `json:"email"`
+		Time  timestamp `json:"time"`
+	}
+
+	// gitiles changed file object. The type is add, modify,
+	// delete, rename or copy.
+	treeDiff struct {
+		Type    string `json:"type"`
+		OldID   string `json:"old_id"`
+		OldPath string `json:"old_path"`
+		NewID   string `json:"new_id"`
+		NewPath string `json:"new_path"`
+	}
+
+	// gitiles page of the log.
+	logPage struct {
+		Log  []*commit `json:"log"`
+		Next string    `json:"next"`
+	}
+)
+
+//
+// native data structure conversion
+//
+
+func convertRefList(prefix string, from map[string]*refValue) []*scm.Reference {
+	to := []*scm.Reference{}
+	for name, v := range from {
+		to = append(to, convertRef(prefix+"/"+name, v))
+	}
+	sort.Slice(to, func(i, j int) bool { return to[i].Path < to[j].Path })
+	return to
+}
+
+func convertRef(ref string, from *refValue) *scm.Reference {
+	sha := from.Value
+	if from.Peeled != "" {
+		sha = from.Peeled
+	}
+	return &scm.Reference{
+		Name: scm.TrimRef(ref),
+		Path: ref,
+		Sha:  sha,
+	}
+}
+
+func convertCommit(from *commit, link string) *scm.Commit {
+	return &scm.Commit{
+		Sha:       from.Commit,
+		Message:   from.Message,
+		Tree:      scm.CommitTree{Sha: from.Tree},
+		Author:    convertSignature(&from.Author),
+		Committer: convertSignature(&from.Committer),
+		Link:      link,
+	}
+}
+
+func convertSignature(from *signature) scm.Signature {
+	return scm.Signature{
+		Name:  from.Name,
+		Email: from.Email,
+		Date:  time.Time(from.Time).UTC(),
+	}
+}
+
+func convertChangeList(from []*treeDiff) []*scm.Change {
+	to := []*scm.Change{}
+	for _, v := range from {
+		to = append(to, convertChange(v))
+	}
+	return to
+}
+
+func convertChange(from *treeDiff) *scm.Change {
+	to := &scm.Change{
+		Path:    from.NewPath,
+		Added:   from.Type == "add",
+		Deleted: from.Type == "delete",
+		Renamed: from.Type == "rename",
+		Sha:     from.NewID,
+	}
+	if to.Deleted {
+		to.Path = from.OldPath
+		to.Sha = from.OldID
+	}
+	if to.Renamed {
+		to.PreviousPath = from.OldPath
+	}
+	return to
+}
+
+func convertComparison(ahead []*scm.Commit, behind int) *scm.Comparison {
+	dst := &scm.Comparison{
+		AheadBy:  len(ahead),
+		BehindBy: behind,
+		Commits:  ahead,
+	}
+	switch {
+	case dst.AheadBy == 0 && dst.BehindBy == 0:
+		dst.Status = "identical"
+	case dst.BehindBy == 0:
+		dst.Status = "ahead"
+	case dst.AheadBy == 0:
+		dst.Status = "behind"
+	default:
+		dst.Status = "diverged"
+	}
+	return dst
+}
@@ -0,0 +1,231 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitiles
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestGitFindCommit(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://chromium.googlesource.com").
+		Get(`/chromium/src/\+/7fd1a60b01f91b314f59955a4e4d4e80d8edf11d`).
+		MatchParam("format", "JSON").
+		Reply(200).
+		Type("application/json").
+		File("testdata/commit.json")
+
+	client, _ := New("https://chromium.googlesource.com")
+	got, _, err := client.Git.FindCommit(context.Background(), "chromium/src", "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Commit)
+	raw, _ := ioutil.ReadFile("testdata/commit.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitFindBranch(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://chromium.googlesource.com").
+		Get(`/chromium/src/\+refs/heads/main`).
+		Reply(200).
+		Type("application/json").
+		BodyString(")]}'\n" + `{"main":{"value":"7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"},"main/next":{"value":"553c2077f0edc3d5dc5d17262f6aa498e69d6f8e"}}`)
+
+	client, _ := New("https://chromium.googlesource.com")
+	got, _, err := client.Git.FindBranch(context.Background(), "chromium/src", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &scm.Reference{
+		Name: "main",
+		Path: "refs/heads/main",
+		Sha:  "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitFindRef_Tag(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://chromium.googlesource.com").
+		Get(`/chromium/src/\+refs/heads/v1.0.0`).
+		Reply(200).
+		Type("application/json").
+		BodyString(")]}'\n{}")
+
+	gock.New("https://chromium.googlesource.com").
+		Get(`/chromium/src/\+refs/tags/v1.0.0`).
+		Reply(200).
+		Type("application/json").
+		BodyString(")]}'\n" + `{"v1.0.0":{"value":"5b0f8c1b4e3a2d1c0f9e8d7c6b5a4f3e2d1c0b9a","peeled":"762941318ee16e59dabbacb1b4049eec22f0d303"}}`)
+
+	client, _ := New("https://chromium.googlesource.com")
+	got, _, err := client.Git.FindRef(context.Background(), "chromium/src", "v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "762941318ee16e59dabbacb1b4049eec22f0d303"; got != want {
+		t.Errorf("Want sha %q, got %q", want, got)
+	}
+}
+
+func TestGitListBranches(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://chromium.googlesource.com").
+		Get(`/chromium/src/\+refs/heads`).
+		MatchParam("format", "JSON").
+		Reply(200).
+		Type("application/json").
+		File("testdata/branches.json")
+
+	client, _ := New("https://chromium.googlesource.com")
+	got, _, err := client.Git.ListBranches(context.Background(), "chromium/src", scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Reference{}
+	raw, _ := ioutil.ReadFile("testdata/branches.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitListTags(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://chromium.googlesource.com").
+		Get(`/chromium/src/\+refs/tags`).
+		MatchParam("format", "JSON").
+		Reply(200).
+		Type("application/json").
+		File("testdata/tags.json")
+
+	client, _ := New("https://chromium.googlesource.com")
+	got, _, err := client.Git.ListTags(context.Background(), "chromium/src", scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Reference{}
+	raw, _ := ioutil.ReadFile("testdata/tags.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitListCommits(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://chromium.googlesource.com").
+		Get(`/chromium/src/\+log/main`).
+		MatchParam("format", "JSON").
+		MatchParam("n", "1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/log.json")
+
+	client, _ := New("https://chromium.googlesource.com")
+	got, res, err := client.Git.ListCommits(context.Background(), "chromium/src", scm.CommitListOptions{Ref: "main", Size: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Sha != "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d" {
+		t.Errorf("Unexpected commits %v", got)
+	}
+	if got, want := res.Page.NextURL, "553c2077f0edc3d5dc5d17262f6aa498e69d6f8e"; got != want {
+		t.Errorf("Want next page cursor %q, got %q", want, got)
+	}
+}
+
+func TestGitListChanges(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://chromium.googlesource.com").
+		Get(`/chromium/src/\+/7fd1a60b01f91b314f59955a4e4d4e80d8edf11d`).
+		MatchParam("format", "JSON").
+		Reply(200).
+		Type("application/json").
+		File("testdata/commit.json")
+
+	client, _ := New("https://chromium.googlesource.com")
+	got, _, err := client.Git.ListChanges(context.Background(), "chromium/src", "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d", scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Change{}
+	raw, _ := ioutil.ReadFile("testdata/changes.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitCompareCommits(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://chromium.googlesource.com").
+		Get(`/chromium/src/\+log/base\.\.head`).
+		MatchParam("format", "JSON").
+		Reply(200).
+		Type("application/json").
+		File("testdata/log.json")
+
+	gock.New("https://chromium.googlesource.com").
+		Get(`/chromium/src/\+log/base\.\.head`).
+		MatchParam("s", "553c2077f0edc3d5dc5d17262f6aa498e69d6f8e").
+		Reply(200).
+		Type("application/json").
+		File("testdata/log_next.json")
+
+	gock.New("https://chromium.googlesource.com").
+		Get(`/chromium/src/\+log/head\.\.base`).
+		Reply(200).
+		Type("application/json").
+		BodyString(")]}'\n" + `{"log":[]}`)
+
+	client, _ := New("https://chromium.googlesource.com")
+	got, _, err := client.Git.CompareCommits(context.Background(), "chromium/src", "base", "head")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != "ahead" || got.AheadBy != 2 || got.BehindBy != 0 || len(got.Commits) != 2 {
+		t.Errorf("Unexpected comparison %+v", got)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect every page of the log to be requested")
+	}
+}
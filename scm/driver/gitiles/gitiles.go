@@ -0,0 +1,166 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gitiles implements a read-only Gitiles client, for
+// the googlesource.com hosts and the Gerrit servers browsed
+// with Gitiles.
+//
+// Gitiles only serves the repositories, so the driver
+// supports the contents, the refs and commits, and the
+// comparison of the refs. The repository is the project path,
+// such as chromium/src or platform/frameworks/base.
+package gitiles
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// Reference API Documentation:
+//   https://gerrit.googlesource.com/gitiles/+/HEAD/Documentation/
+
+// xssiPrefix is the prefix of the Gitiles JSON responses,
+// protecting them from cross-site script inclusion.
+const xssiPrefix = ")]}'"
+
+// New returns a new Gitiles API client. The uri is the
+// address of the host, such as
+// https://chromium.googlesource.com.
+func New(uri string) (*scm.Client, error) {
+	base, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path = base.Path + "/"
+	}
+	client := &wrapper{new(scm.Client)}
+	client.BaseURL = base
+	// initialize services
+	client.Driver = scm.DriverGitiles
+	client.Contents = &contentService{client}
+	client.Git = &gitService{client}
+	client.Repositories = &repositoryService{client}
+	return client.Client, nil
+}
+
+// Archive writes the gzipped tarball of the ref of the
+// repository, or of the directory of the ref if the path is
+// set, which scm has no service for.
+func Archive(ctx context.Context, client *scm.Client, repo, ref, path string, w io.Writer) (*scm.Response, error) {
+	if client.Driver != scm.DriverGitiles {
+		return nil, notSupported("Contents", "Archive")
+	}
+	endpoint := fmt.Sprintf("%s/+archive/%s.tar.gz", repo, ref)
+	if path = strings.Trim(path, "/"); path != "" {
+		endpoint = fmt.Sprintf("%s/+archive/%s/%s.tar.gz", repo, ref, path)
+	}
+	return (&wrapper{client}).do(ctx, endpoint, w)
+}
+
+// wraper wraps the Client to provide high level helper functions
+// for making http requests and unmarshaling the response.
+type wrapper struct {
+	*scm.Client
+}
+
+// do wraps the Client.Do function by creating the Request and
+// unmarshalling the response. Gitiles is read-only, so every
+// request is a GET.
+func (c *wrapper) do(ctx context.Context, path string, out interface{}) (*scm.Response, error) {
+	req := &scm.Request{
+		Method: "GET",
+		Path:   path,
+	}
+
+	// execute the http request
+	res, err := c.Client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// if an error is encountered, return the plain text
+	// error response.
+	if res.Status == http.StatusNotFound {
+		return res, scm.ErrNotFound
+	} else if res.Status == http.StatusUnauthorized {
+		return res, scm.ErrNotAuthorized
+	} else if res.Status > 300 {
+		body, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+		return res, &Error{
+			Message: strings.TrimSpace(string(body)),
+			status:  res.Status,
+		}
+	}
+
+	if out == nil {
+		return res, nil
+	}
+
+	// if raw output is expected, copy to the provided
+	// buffer and exit.
+	if w, ok := out.(io.Writer); ok {
+		_, err := io.Copy(w, res.Body)
+		return res, err
+	}
+
+	// if a json response is expected, strip the xssi prefix,
+	// then parse and return the json response.
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return res, err
+	}
+	body = bytes.TrimPrefix(body, []byte(xssiPrefix))
+	return res, json.Unmarshal(body, out)
+}
+
+// Error represents a Gitiles error.
+type Error struct {
+	Message string
+
+	status int
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return http.StatusText(e.status)
+	}
+	return e.Message
+}
+
+// timestamp is a Gitiles time, such as
+// Tue Apr 02 10:11:12 2019 +0000. The older servers omit the
+// zone of UTC times.
+type timestamp time.Time
+
+func (t *timestamp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	for _, layout := range []string{"Mon Jan 02 15:04:05 2006 -0700", "Mon Jan 02 15:04:05 2006"} {
+		if v, err := time.Parse(layout, s); err == nil {
+			*t = timestamp(v)
+			return nil
+		}
+	}
+	return fmt.Errorf("gitiles: cannot parse time %q", s)
+}
+
+// notSupported returns the error of a method of a service
+// the driver does not support.
+func notSupported(service, method string) error {
+	return &scm.NotSupportedError{Driver: scm.DriverGitiles, Service: service, Method: method}
+}
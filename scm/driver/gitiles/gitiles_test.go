@@ -0,0 +1,101 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitiles
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestClient(t *testing.T) {
+	client, err := New("https://chromium.googlesource.com")
+	if err != nil {
+		t.Error(err)
+	}
+	if got, want := client.BaseURL.String(), "https://chromium.googlesource.com/"; got != want {
+		t.Errorf("Want Client URL %q, got %q", want, got)
+	}
+	if got, want := client.Driver, scm.DriverGitiles; got != want {
+		t.Errorf("Want Driver %v, got %v", want, got)
+	}
+}
+
+func TestClient_Error(t *testing.T) {
+	_, err := New("http://a b.com/")
+	if err == nil {
+		t.Errorf("Expect error when invalid URL")
+	}
+}
+
+func TestClient_ErrorMessage(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://chromium.googlesource.com").
+		Get(`/chromium/src/\+/main`).
+		Reply(400).
+		Type("text/plain").
+		BodyString("Invalid revision\n")
+
+	client, _ := New("https://chromium.googlesource.com")
+	_, _, err := client.Git.FindCommit(context.Background(), "chromium/src", "main")
+	if err == nil || err.Error() != "Invalid revision" {
+		t.Errorf("Want the error message of the response, got %v", err)
+	}
+}
+
+func TestClient_NotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://chromium.googlesource.com").
+		Get("/chromium/missing/").
+		Reply(404).
+		Type("text/plain").
+		BodyString("Not Found")
+
+	client, _ := New("https://chromium.googlesource.com")
+	_, _, err := client.Repositories.Find(context.Background(), "chromium/missing")
+	if err != scm.ErrNotFound {
+		t.Errorf("Want scm.ErrNotFound, got %v", err)
+	}
+}
+
+func TestArchive(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://chromium.googlesource.com").
+		Get(`/chromium/src/\+archive/main/docs.tar.gz`).
+		Reply(200).
+		Type("application/x-gzip").
+		BodyString("archive")
+
+	client, _ := New("https://chromium.googlesource.com")
+	buf := new(bytes.Buffer)
+	_, err := Archive(context.Background(), client, "chromium/src", "main", "/docs/", buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "archive"; got != want {
+		t.Errorf("Want archive %q, got %q", want, got)
+	}
+}
+
+func TestArchive_NotSupported(t *testing.T) {
+	client := &scm.Client{Driver: scm.DriverGithub}
+	_, err := Archive(context.Background(), client, "octocat/hello-world", "master", "", new(bytes.Buffer))
+	if !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Want scm.ErrNotSupported, got %v", err)
+	}
+	want := &scm.NotSupportedError{Driver: scm.DriverGitiles, Service: "Contents", Method: "Archive"}
+	if diff := cmp.Diff(err, error(want)); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitiles
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type repositoryService struct {
+	client *wrapper
+}
+
+func (s *repositoryService) Find(ctx context.Context, repo string) (*scm.Repository, *scm.Response, error) {
+	out := new(repository)
+	res, err := s.client.do(ctx, strings.Trim(repo, "/")+"/?format=JSON", out)
+	if err != nil {
+		return nil, res, err
+	}
+	return s.convertRepository(out), res, nil
+}
+
+// FindPerms returns the permissions of the repository, which
+// may only be read through Gitiles.
+func (s *repositoryService) FindPerms(ctx context.Context, repo string) (*scm.Perm, *scm.Response, error) {
+	_, res, err := s.Find(ctx, repo)
+	if err != nil {
+		return nil, res, err
+	}
+	return &scm.Perm{Pull: true}, res, nil
+}
+
+// List returns all the repositories of the host sorted by
+// name, as Gitiles does not paginate the repositories.
+func (s *repositoryService) List(ctx context.Context, _ scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	out := map[string]*repository{}
+	res, err := s.client.do(ctx, "?format=JSON", &out)
+	if err != nil {
+		return nil, res, err
+	}
+	to := []*scm.Repository{}
+	for _, v := range out {
+		to = append(to, s.convertRepository(v))
+	}
+	sort.Slice(to, func(i, j int) bool { return to[i].FullName < to[j].FullName })
+	return to, res, nil
+}
+
+func (s *repositoryService) ListOrganisation(context.Context, string, scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListOrganisation")
+}
+
+func (s *repositoryService) ListUser(context.Context, string, scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListUser")
+}
+
+func (s *repositoryService) Create(context.Context, *scm.RepositoryInput) (*scm.Repository, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "Create")
+}
+
+func (s *repositoryService) Delete(context.Context, string) (*scm.Response, error) {
+	return nil, notSupported("Repositories", "Delete")
+}
+
+func (s *repositoryService) FindHook(context.Context, string, string) (*scm.Hook, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "FindHook")
+}
+
+func (s *repositoryService) FindUserPermission(context.Context, string, string) (string, *scm.Response, error) {
+	return "", nil, notSupported("Repositories", "FindUserPermission")
+}
+
+func (s *repositoryService) ListLabels(context.Context, string, scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListLabels")
+}
+
+func (s *repositoryService) ListHooks(context.Context, string, scm.ListOptions) ([]*scm.Hook, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListHooks")
+}
+
+func (s *repositoryService) ListStatus(context.Context, string, string, scm.ListOptions) ([]*scm.Status, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListStatus")
+}
+
+func (s *repositoryService) FindCombinedStatus(context.Context, string, string) (*scm.CombinedStatus, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "FindCombinedStatus")
+}
+
+func (s *repositoryService) Fork(context.Context, *scm.RepositoryInput, string) (*scm.Repository, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "Fork")
+}
+
+func (s *repositoryService) CreateHook(context.Context, string, *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "CreateHook")
+}
+
+func (s *repositoryService) CreateStatus(context.Context, string, string, *scm.StatusInput) (*scm.Status, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "CreateStatus")
+}
+
+func (s *repositoryService) DeleteHook(context.Context, string, string) (*scm.Response, error) {
+	return nil, notSupported("Repositories", "DeleteHook")
+}
+
+func (s *repositoryService) IsCollaborator(context.Context, string, string) (bool, *scm.Response, error) {
+	return false, nil, notSupported("Repositories", "IsCollaborator")
+}
+
+func (s *repositoryService) AddCollaborator(context.Context, string, string, string) (bool, bool, *scm.Response, error) {
+	return false, false, nil, notSupported("Repositories", "AddCollaborator")
+}
+
+func (s *repositoryService) ListCollaborators(context.Context, string, scm.ListOptions) ([]scm.User, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListCollaborators")
+}
+
+func (s *repositoryService) GetDependencyGraph(context.Context, string) (*scm.DependencyGraph, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "GetDependencyGraph")
+}
+
+func (s *repositoryService) ExportSBOM(context.Context, string) (io.ReadCloser, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ExportSBOM")
+}
+
+func (s *repositoryService) ListRulesets(context.Context, string, scm.ListOptions) ([]*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListRulesets")
+}
+
+func (s *repositoryService) FindRuleset(context.Context, string, int) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "FindRuleset")
+}
+
+func (s *repositoryService) CreateRuleset(context.Context, string, *scm.RulesetInput) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "CreateRuleset")
+}
+
+func (s *repositoryService) UpdateRuleset(context.Context, string, int, *scm.RulesetInput) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "UpdateRuleset")
+}
+
+func (s *repositoryService) GetPages(context.Context, string) (*scm.Pages, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "GetPages")
+}
+
+func (s *repositoryService) EnablePages(context.Context, string, *scm.PagesInput) (*scm.Pages, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "EnablePages")
+}
+
+func (s *repositoryService) DisablePages(context.Context, string) (*scm.Response, error) {
+	return nil, notSupported("Repositories", "DisablePages")
+}
+
+func (s *repositoryService) GetLatestPagesBuild(context.Context, string) (*scm.PagesBuild, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "GetLatestPagesBuild")
+}
+
+func (s *repositoryService) ListHookDeliveries(context.Context, string, string, scm.ListOptions) ([]*scm.HookDelivery, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListHookDeliveries")
+}
+
+func (s *repositoryService) RedeliverHookDelivery(context.Context, string, string, int64) (*scm.Response, error) {
+	return nil, notSupported("Repositories", "RedeliverHookDelivery")
+}
+
+//
+// native data structures
+//
+
+// gitiles repository object. The name is the project path.
+type repository struct {
+	Name        string `json:"name"`
+	CloneURL    string `json:"clone_url"`
+	Description string `json:"description"`
+}
+
+//
+// native data structure conversion
+//
+
+// convertRepository converts the repository. The namespace is
+// the directory of the project path.
+func (s *repositoryService) convertRepository(from *repository) *scm.Repository {
+	namespace := path.Dir(from.Name)
+	if namespace == "." {
+		namespace = ""
+	}
+	return &scm.Repository{
+		ID:        from.Name,
+		Namespace: namespace,
+		Name:      path.Base(from.Name),
+		FullName:  from.Name,
+		Clone:     from.CloneURL,
+		Link:      s.client.BaseURL.ResolveReference(&url.URL{Path: from.Name + "/"}).String(),
+	}
+}
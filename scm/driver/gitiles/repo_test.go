@@ -0,0 +1,71 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitiles
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestRepositoryFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://chromium.googlesource.com").
+		Get("/chromium/src/").
+		MatchParam("format", "JSON").
+		Reply(200).
+		Type("application/json").
+		File("testdata/repo.json")
+
+	client, _ := New("https://chromium.googlesource.com")
+	got, _, err := client.Repositories.Find(context.Background(), "chromium/src")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Repository)
+	raw, _ := ioutil.ReadFile("testdata/repo.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestRepositoryList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://chromium.googlesource.com").
+		Get("/").
+		MatchParam("format", "JSON").
+		Reply(200).
+		Type("application/json").
+		File("testdata/repos.json")
+
+	client, _ := New("https://chromium.googlesource.com")
+	got, _, err := client.Repositories.List(context.Background(), scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].FullName != "angle/angle" || got[1].FullName != "chromium/src" {
+		t.Errorf("Unexpected repositories %v", got)
+	}
+}
+
+func TestRepositoryCreate_NotSupported(t *testing.T) {
+	client, _ := New("https://chromium.googlesource.com")
+	_, _, err := client.Repositories.Create(context.Background(), &scm.RepositoryInput{})
+	if !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error")
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type adminService struct {
+	client *wrapper
+}
+
+// statistics reports the counts as strings, which include
+// thousands separators on large instances.
+type statistics struct {
+	Users         string `json:"users"`
+	Groups        string `json:"groups"`
+	Projects      string `json:"projects"`
+	Issues        string `json:"issues"`
+	MergeRequests string `json:"merge_requests"`
+}
+
+type license struct {
+	Plan      string `json:"plan"`
+	ExpiresAt string `json:"expires_at"`
+	Licensee  struct {
+		Name    string `json:"Name"`
+		Company string `json:"Company"`
+	} `json:"licensee"`
+	UserLimit   int `json:"user_limit"`
+	ActiveUsers int `json:"active_users"`
+}
+
+func (s *adminService) Stats(ctx context.Context) (*scm.SiteStats, *scm.Response, error) {
+	out := new(statistics)
+	res, err := s.client.do(ctx, "GET", "api/v4/application/statistics", nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return &scm.SiteStats{
+		Users:         parseCount(out.Users),
+		Organizations: parseCount(out.Groups),
+		Repositories:  parseCount(out.Projects),
+		Issues:        parseCount(out.Issues),
+		PullRequests:  parseCount(out.MergeRequests),
+	}, res, nil
+}
+
+func (s *adminService) License(ctx context.Context) (*scm.License, *scm.Response, error) {
+	out := new(license)
+	res, err := s.client.do(ctx, "GET", "api/v4/license", nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	dst := &scm.License{
+		Plan:      out.Plan,
+		Licensee:  out.Licensee.Name,
+		Seats:     out.UserLimit,
+		SeatsUsed: out.ActiveUsers,
+	}
+	if out.Licensee.Company != "" {
+		dst.Licensee = out.Licensee.Company
+	}
+	if out.ExpiresAt != "" {
+		dst.Expires, err = time.Parse("2006-01-02", out.ExpiresAt)
+		if err != nil {
+			return nil, res, err
+		}
+	}
+	return dst, res, nil
+}
+
+func (s *adminService) ListHooks(ctx context.Context, opts scm.ListOptions) ([]*scm.Hook, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/hooks?%s", encodeListOptions(opts))
+	out := []*hook{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertHookList(out), res, err
+}
+
+func parseCount(s string) int {
+	n, _ := strconv.Atoi(strings.Replace(s, ",", "", -1))
+	return n
+}
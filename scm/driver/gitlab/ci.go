@@ -0,0 +1,40 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type ciService struct {
+	client *wrapper
+}
+
+type ciLintInput struct {
+	Content string `json:"content"`
+}
+
+type ciLint struct {
+	Valid      bool     `json:"valid"`
+	Errors     []string `json:"errors"`
+	Warnings   []string `json:"warnings"`
+	MergedYaml string   `json:"merged_yaml"`
+}
+
+func (s *ciService) Lint(ctx context.Context, repo string, config []byte) (*scm.CILintResult, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/ci/lint", encode(repo))
+	in := &ciLintInput{Content: string(config)}
+	out := new(ciLint)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertCILint(out), res, err
+}
+
+func convertCILint(from *ciLint) *scm.CILintResult {
+	return &scm.CILintResult{
+		Valid:    from.Valid,
+		Errors:   from.Errors,
+		Warnings: from.Warnings,
+		Merged:   from.MergedYaml,
+	}
+}
@@ -0,0 +1,42 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestCILint(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/ci/lint").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/ci_lint.json")
+
+	client := NewDefault()
+	got, res, err := client.CI.Lint(context.Background(), "diaspora/diaspora", []byte("build:\n  scripts:\n  - make\n"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.CILintResult)
+	raw, _ := ioutil.ReadFile("testdata/ci_lint.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitlab
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/slimm609/go-scm/scm/conformance"
+	"github.com/slimm609/go-scm/scm/transport"
+
+	"github.com/h2non/gock"
+)
+
+func TestConformance(t *testing.T) {
+	if repo, branch, ok := conformance.FromEnvironment(); ok {
+		client := NewDefault()
+		client.Client = &http.Client{
+			Transport: &transport.PrivateToken{
+				Token: os.Getenv(conformance.EnvToken),
+			},
+		}
+		conformance.Run(t, conformance.Config{
+			Client: client,
+			Repo:   repo,
+			Branch: branch,
+		})
+		return
+	}
+
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora-does-not-exist$").
+		Persist().
+		Reply(404).
+		Type("application/json").
+		BodyString(`{"message":"404 Project Not Found"}`)
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/repo.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/branches/master$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/branch.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/branches$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/branches.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/tags$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/tags.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/commits/master$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/commit.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/commits$").
+		MatchParam("ref_name", "master").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/commits.json")
+
+	conformance.Run(t, conformance.Config{
+		Client: NewDefault(),
+		Repo:   "diaspora/diaspora",
+		Branch: "master",
+	})
+}
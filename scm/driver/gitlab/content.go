@@ -9,6 +9,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/slimm609/go-scm/scm"
@@ -39,6 +40,10 @@ func (s *contentService) Find(ctx context.Context, repo, path, ref string) (*scm
 	}, res, err
 }
 
+func (s *contentService) FindMany(ctx context.Context, repo, ref string, paths []string) (map[string]*scm.Content, map[string]error) {
+	return scm.FindManyContents(ctx, s, repo, ref, paths, scm.DefaultConcurrency)
+}
+
 func (s *contentService) List(ctx context.Context, repo, path, ref string) ([]*scm.FileEntry, *scm.Response, error) {
 	endpoint := fmt.Sprintf("api/v4/projects/%s/repository/tree?path=%s&ref=%s", encode(repo), path, ref)
 	out := []*entry{}
@@ -46,6 +51,37 @@ func (s *contentService) List(ctx context.Context, repo, path, ref string) ([]*s
 	return convertEntryList(out), res, err
 }
 
+func (s *contentService) Exists(ctx context.Context, repo, path, ref string) (bool, *scm.Response, error) {
+	_, res, err := s.Stat(ctx, repo, path, ref)
+	if err == scm.ErrNotFound {
+		return false, res, nil
+	}
+	return err == nil, res, err
+}
+
+// Stat returns the file metadata from the response headers
+// of a HEAD request, which does not include the file content.
+func (s *contentService) Stat(ctx context.Context, repo, path, ref string) (*scm.FileEntry, *scm.Response, error) {
+	path = url.QueryEscape(path)
+	path = strings.Replace(path, ".", "%2E", -1)
+	endpoint := fmt.Sprintf("api/v4/projects/%s/repository/files/%s?ref=%s", encode(repo), path, ref)
+	res, err := s.client.do(ctx, "HEAD", endpoint, nil, nil)
+	if res != nil && res.Status == 404 {
+		return nil, res, scm.ErrNotFound
+	}
+	if err != nil {
+		return nil, res, err
+	}
+	size, _ := strconv.Atoi(res.Header.Get("X-Gitlab-Size"))
+	return &scm.FileEntry{
+		Name: res.Header.Get("X-Gitlab-File-Name"),
+		Path: res.Header.Get("X-Gitlab-File-Path"),
+		Type: "file",
+		Size: size,
+		Sha:  res.Header.Get("X-Gitlab-Blob-Id"),
+	}, res, nil
+}
+
 func (s *contentService) Create(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
 	endpoint := fmt.Sprintf("api/v4/projects/%s/repository/commits", encode(repo))
 
@@ -74,7 +110,7 @@ func (s *contentService) Update(ctx context.Context, repo, path string, params *
 }
 
 func (s *contentService) Delete(ctx context.Context, repo, path, ref string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Contents", "Delete")
 }
 
 type content struct {
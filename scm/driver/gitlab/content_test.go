@@ -8,6 +8,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"testing"
 
@@ -54,6 +55,71 @@ func TestContentFind(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestContentStat(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Head("/api/v4/projects/diaspora/diaspora/repository/files/app/models/key.rb").
+		MatchParam("ref", "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d").
+		Reply(200).
+		SetHeaders(mockHeaders).
+		SetHeaders(map[string]string{
+			"X-Gitlab-File-Name": "key.rb",
+			"X-Gitlab-File-Path": "app/models/key.rb",
+			"X-Gitlab-Size":      "1476",
+			"X-Gitlab-Blob-Id":   "79f7bbd25901e8334750839545a9bd021f0e4c83",
+		})
+
+	client := NewDefault()
+	got, res, err := client.Contents.Stat(
+		context.Background(),
+		"diaspora/diaspora",
+		"app/models/key.rb",
+		"7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.FileEntry)
+	raw, _ := ioutil.ReadFile("testdata/content_stat.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestContentExists_NotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Head("/api/v4/projects/diaspora/diaspora/repository/files/app/models/missing.rb").
+		MatchParam("ref", "master").
+		Reply(404).
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	got, _, err := client.Contents.Exists(
+		context.Background(),
+		"diaspora/diaspora",
+		"app/models/missing.rb",
+		"master",
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got {
+		t.Errorf("Expect file does not exist")
+	}
+}
+
 func TestContentList(t *testing.T) {
 	defer gock.Off()
 
@@ -178,7 +244,7 @@ func TestContentUpdate(t *testing.T) {
 func TestContentDelete(t *testing.T) {
 	content := new(contentService)
 	_, err := content.Delete(context.Background(), "octocat/hello-world", "README", "master")
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -0,0 +1,63 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type hookEvent struct {
+	ID                int64   `json:"id"`
+	Trigger           string  `json:"trigger"`
+	ExecutionDuration float64 `json:"execution_duration"`
+	ResponseStatus    string  `json:"response_status"`
+	RequestHeaders    struct {
+		EventUUID string `json:"X-Gitlab-Event-UUID"`
+	} `json:"request_headers"`
+	RequestData struct {
+		ObjectAttributes struct {
+			Action string `json:"action"`
+		} `json:"object_attributes"`
+	} `json:"request_data"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ListHookDeliveries returns the recent events of the
+// project hook, the last seven days being kept by GitLab.
+func (s *repositoryService) ListHookDeliveries(ctx context.Context, repo, hookID string, opts scm.ListOptions) ([]*scm.HookDelivery, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/hooks/%s/events?%s", encode(repo), hookID, encodeListOptions(opts))
+	out := []*hookEvent{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertHookEventList(out), res, err
+}
+
+func (s *repositoryService) RedeliverHookDelivery(ctx context.Context, repo, hookID string, deliveryID int64) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/hooks/%s/events/%d/resend", encode(repo), hookID, deliveryID)
+	return s.client.do(ctx, "POST", path, nil, nil)
+}
+
+func convertHookEventList(from []*hookEvent) []*scm.HookDelivery {
+	to := []*scm.HookDelivery{}
+	for _, v := range from {
+		to = append(to, convertHookEvent(v))
+	}
+	return to
+}
+
+func convertHookEvent(from *hookEvent) *scm.HookDelivery {
+	code, _ := strconv.Atoi(from.ResponseStatus)
+	return &scm.HookDelivery{
+		ID:         from.ID,
+		GUID:       from.RequestHeaders.EventUUID,
+		Event:      strings.TrimSuffix(from.Trigger, "_hooks"),
+		Action:     from.RequestData.ObjectAttributes.Action,
+		Status:     from.ResponseStatus,
+		StatusCode: code,
+		Duration:   time.Duration(from.ExecutionDuration * float64(time.Second)),
+		Delivered:  from.CreatedAt,
+	}
+}
@@ -0,0 +1,62 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type dependency struct {
+	Name               string `json:"name"`
+	Version            string `json:"version"`
+	PackageManager     string `json:"package_manager"`
+	DependencyFilePath string `json:"dependency_file_path"`
+	Licenses           []struct {
+		Name string `json:"name"`
+	} `json:"licenses"`
+}
+
+// GetDependencyGraph returns the dependency list of the
+// project, following the pagination of the API.
+func (s *repositoryService) GetDependencyGraph(ctx context.Context, repo string) (*scm.DependencyGraph, *scm.Response, error) {
+	to := &scm.DependencyGraph{Dependencies: []*scm.Dependency{}}
+	opts := scm.ListOptions{Page: 1, Size: 100}
+	for {
+		path := fmt.Sprintf("api/v4/projects/%s/dependencies?%s", encode(repo), encodeListOptions(opts))
+		out := []*dependency{}
+		res, err := s.client.do(ctx, "GET", path, nil, &out)
+		if err != nil {
+			return nil, res, err
+		}
+		for _, v := range out {
+			to.Dependencies = append(to.Dependencies, convertDependency(v))
+		}
+		if res.Page.Next == 0 {
+			return to, res, nil
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
+// ExportSBOM is not supported, GitLab exports the
+// dependency list asynchronously as a CycloneDX document.
+func (s *repositoryService) ExportSBOM(context.Context, string) (io.ReadCloser, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ExportSBOM")
+}
+
+func convertDependency(from *dependency) *scm.Dependency {
+	var licenses []string
+	for _, l := range from.Licenses {
+		licenses = append(licenses, l.Name)
+	}
+	return &scm.Dependency{
+		Name:      from.Name,
+		Version:   from.Version,
+		Ecosystem: from.PackageManager,
+		License:   strings.Join(licenses, " AND "),
+		Path:      from.DependencyFilePath,
+	}
+}
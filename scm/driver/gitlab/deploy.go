@@ -0,0 +1,95 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// deploymentService provides the approval of the manual
+// deployment jobs. The deployments API is not supported.
+type deploymentService struct {
+	client *wrapper
+}
+
+type manualJob struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Ref       string    `json:"ref"`
+	WebURL    string    `json:"web_url"`
+	CreatedAt time.Time `json:"created_at"`
+	Commit    struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+}
+
+func (s *deploymentService) Find(context.Context, string, string) (*scm.Deployment, *scm.Response, error) {
+	return nil, nil, notSupported("Deployments", "Find")
+}
+
+func (s *deploymentService) List(context.Context, string, scm.ListOptions) ([]*scm.Deployment, *scm.Response, error) {
+	return nil, nil, notSupported("Deployments", "List")
+}
+
+func (s *deploymentService) Create(context.Context, string, *scm.DeploymentInput) (*scm.Deployment, *scm.Response, error) {
+	return nil, nil, notSupported("Deployments", "Create")
+}
+
+func (s *deploymentService) Delete(context.Context, string, string) (*scm.Response, error) {
+	return nil, notSupported("Deployments", "Delete")
+}
+
+func (s *deploymentService) FindStatus(context.Context, string, string, string) (*scm.DeploymentStatus, *scm.Response, error) {
+	return nil, nil, notSupported("Deployments", "FindStatus")
+}
+
+func (s *deploymentService) ListStatus(context.Context, string, string, scm.ListOptions) ([]*scm.DeploymentStatus, *scm.Response, error) {
+	return nil, nil, notSupported("Deployments", "ListStatus")
+}
+
+func (s *deploymentService) CreateStatus(context.Context, string, string, *scm.DeploymentStatusInput) (*scm.DeploymentStatus, *scm.Response, error) {
+	return nil, nil, notSupported("Deployments", "CreateStatus")
+}
+
+// ListPendingApprovals returns the manual jobs of the
+// project, which wait for a user to play them.
+func (s *deploymentService) ListPendingApprovals(ctx context.Context, repoFullName string, opts scm.ListOptions) ([]*scm.DeploymentApproval, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/jobs?scope[]=manual&%s", encode(repoFullName), encodeListOptions(opts))
+	out := []*manualJob{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertManualJobList(out), res, err
+}
+
+// ApproveDeployment plays the manual job.
+func (s *deploymentService) ApproveDeployment(ctx context.Context, repoFullName string, approval *scm.DeploymentApproval, comment string) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/jobs/%s/play", encode(repoFullName), approval.ID)
+	return s.client.do(ctx, "POST", path, nil, nil)
+}
+
+// RejectDeployment is not supported, manual jobs are
+// rejected by not playing them.
+func (s *deploymentService) RejectDeployment(context.Context, string, *scm.DeploymentApproval, string) (*scm.Response, error) {
+	return nil, notSupported("Deployments", "RejectDeployment")
+}
+
+func convertManualJobList(from []*manualJob) []*scm.DeploymentApproval {
+	to := []*scm.DeploymentApproval{}
+	for _, v := range from {
+		to = append(to, convertManualJob(v))
+	}
+	return to
+}
+
+func convertManualJob(from *manualJob) *scm.DeploymentApproval {
+	return &scm.DeploymentApproval{
+		ID:      strconv.Itoa(from.ID),
+		Name:    from.Name,
+		Ref:     from.Ref,
+		Sha:     from.Commit.ID,
+		Link:    from.WebURL,
+		Created: from.CreatedAt,
+	}
+}
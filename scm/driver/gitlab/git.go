@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,7 +20,7 @@ type gitService struct {
 }
 
 func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *scm.Response, error) {
-	ref = strings.TrimPrefix(ref, "heads/")
+	ref = scm.TrimRef(scm.QualifyRef(ref))
 	path := fmt.Sprintf("api/v4/projects/%s/repository/commits?ref_name=%s", encode(repo), ref)
 	out := []*commit{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
@@ -27,23 +28,26 @@ func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *sc
 	if err != nil {
 		return "", res, err
 	}
-	if len(out) > 0 {
-		commit := out[0]
-		if commit.ID != "" {
-			return commit.ID, res, err
-		}
-	}
-	idx := strings.LastIndex(ref, "/")
-	if idx >= 0 {
-		ref = ref[idx+1:]
+	if len(out) == 0 || out[0].ID == "" {
+		return "", res, scm.ErrNotFound
 	}
-	return ref, res, err
+	return out[0].ID, res, nil
+}
 
+func (s *gitService) ResolveRefs(ctx context.Context, repo string, refs []string) (map[string]string, map[string]error) {
+	return scm.ResolveRefs(ctx, s, repo, refs, scm.DefaultConcurrency)
 }
 
 func (s *gitService) CreateRef(ctx context.Context, repo, ref, sha string) (*scm.Reference, *scm.Response, error) {
+	ref = scm.QualifyRef(ref)
+	if strings.HasPrefix(ref, "refs/tags/") {
+		return s.createTag(ctx, repo, scm.TrimRef(ref), sha)
+	}
+	if !strings.HasPrefix(ref, "refs/heads/") {
+		return nil, nil, notSupported("Git", "CreateRef")
+	}
 	params := url.Values{
-		"branch": []string{ref},
+		"branch": []string{scm.TrimRef(ref)},
 		"ref":    []string{sha},
 	}
 	path := fmt.Sprintf("api/v4/projects/%s/repository/branches?%s", encode(repo), params.Encode())
@@ -63,8 +67,36 @@ func (s *gitService) CreateRef(ctx context.Context, repo, ref, sha string) (*scm
 	return scmRef, res, err
 }
 
+func (s *gitService) createTag(ctx context.Context, repo, name, sha string) (*scm.Reference, *scm.Response, error) {
+	params := url.Values{
+		"tag_name": []string{name},
+		"ref":      []string{sha},
+	}
+	path := fmt.Sprintf("api/v4/projects/%s/repository/tags?%s", encode(repo), params.Encode())
+	out := new(branch)
+	res, err := s.client.do(ctx, "POST", path, nil, out)
+	return convertTag(out), res, err
+}
+
+// UpdateRef is not supported: GitLab cannot move an existing
+// branch or tag in place.
+// UpdateRef is not supported: the GitLab API cannot move a
+// branch or a tag to another commit.
+func (s *gitService) UpdateRef(ctx context.Context, repo, ref, sha string, force bool) (*scm.Reference, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "UpdateRef")
+}
+
 func (s *gitService) DeleteRef(ctx context.Context, repo, ref string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	var path string
+	switch ref = scm.QualifyRef(ref); {
+	case strings.HasPrefix(ref, "refs/heads/"):
+		path = fmt.Sprintf("api/v4/projects/%s/repository/branches/%s", encode(repo), encode(scm.TrimRef(ref)))
+	case strings.HasPrefix(ref, "refs/tags/"):
+		path = fmt.Sprintf("api/v4/projects/%s/repository/tags/%s", encode(repo), encode(scm.TrimRef(ref)))
+	default:
+		return nil, notSupported("Git", "DeleteRef")
+	}
+	return s.client.do(ctx, "DELETE", path, nil, nil)
 }
 
 func (s *gitService) FindBranch(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
@@ -109,6 +141,91 @@ func (s *gitService) ListTags(ctx context.Context, repo string, opts scm.ListOpt
 	return convertTagList(out), res, err
 }
 
+// CompareCommits compares the base ref with the head ref of the
+// repository. GitLab does not report the merge base.
+func (s *gitService) CompareCommits(ctx context.Context, repo, base, head string) (*scm.Comparison, *scm.Response, error) {
+	project := &repository{PathNamespace: repo}
+	return s.compare(ctx, project, project, base, head)
+}
+
+// CompareAcrossForks compares the base ref with the head ref of
+// the fork of baseRepo in the headOwner namespace. GitLab does
+// not report the merge base.
+func (s *gitService) CompareAcrossForks(ctx context.Context, baseRepo, baseRef, headOwner, headRef string) (*scm.Comparison, *scm.Response, error) {
+	r, err := scm.ParseRepo(baseRepo)
+	if err != nil {
+		return nil, nil, err
+	}
+	base := new(repository)
+	res, err := s.client.do(ctx, "GET", fmt.Sprintf("api/v4/projects/%s", encode(baseRepo)), nil, base)
+	if err != nil {
+		return nil, res, err
+	}
+	head := base
+	if !strings.EqualFold(r.Namespace, headOwner) {
+		head, res, err = s.findFork(ctx, base, headOwner)
+		if err != nil {
+			return nil, res, err
+		}
+	}
+	return s.compare(ctx, base, head, baseRef, headRef)
+}
+
+// compare compares the base ref of the base project with the
+// head ref of the head project. GitLab only lists the commits of
+// the head missing from the base, so the refs are also compared
+// the other way round to find how far the head is behind.
+func (s *gitService) compare(ctx context.Context, base, head *repository, baseRef, headRef string) (*scm.Comparison, *scm.Response, error) {
+	ahead := new(comparison)
+	res, err := s.client.do(ctx, "GET", comparePath(base, head, baseRef, headRef), nil, ahead)
+	if err != nil {
+		return nil, res, err
+	}
+	behind := new(comparison)
+	res, err = s.client.do(ctx, "GET", comparePath(head, base, headRef, baseRef), nil, behind)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertComparison(ahead, len(behind.Commits)), res, nil
+}
+
+// findFork returns the fork of the project in the namespace.
+// The name of the fork may differ from the name of the project.
+func (s *gitService) findFork(ctx context.Context, project *repository, namespace string) (*repository, *scm.Response, error) {
+	opts := scm.ListOptions{Page: 1, Size: 100}
+	for {
+		path := fmt.Sprintf("api/v4/projects/%d/forks?%s", project.ID, encodeListOptions(opts))
+		out := []*repository{}
+		res, err := s.client.do(ctx, "GET", path, nil, &out)
+		if err != nil {
+			return nil, res, err
+		}
+		for _, fork := range out {
+			if strings.EqualFold(fork.Namespace.FullPath, namespace) {
+				return fork, res, nil
+			}
+		}
+		if res.Page.Next == 0 {
+			return nil, res, scm.ErrNotFound
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
+// comparePath returns the path of the request listing the
+// commits of the to ref of the to project missing from the from
+// ref of the from project.
+func comparePath(from, to *repository, fromRef, toRef string) string {
+	params := url.Values{
+		"from": []string{fromRef},
+		"to":   []string{toRef},
+	}
+	if from != to {
+		params.Set("from_project_id", strconv.Itoa(from.ID))
+	}
+	return fmt.Sprintf("api/v4/projects/%s/repository/compare?%s", encode(to.PathNamespace), params.Encode())
+}
+
 func (s *gitService) ListChanges(ctx context.Context, repo, ref string, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
 	path := fmt.Sprintf("api/v4/projects/%s/repository/commits/%s/diff", encode(repo), encode(ref))
 	out := []*change{}
@@ -116,6 +233,11 @@ func (s *gitService) ListChanges(ctx context.Context, repo, ref string, opts scm
 	return convertChangeList(out), res, err
 }
 
+type comparison struct {
+	Commits []*commit `json:"commits"`
+	Diffs   []*change `json:"diffs"`
+}
+
 type branch struct {
 	Name   string `json:"name"`
 	Commit struct {
@@ -187,6 +309,26 @@ func convertTagList(from []*branch) []*scm.Reference {
 	return to
 }
 
+func convertComparison(from *comparison, behind int) *scm.Comparison {
+	to := &scm.Comparison{
+		AheadBy:  len(from.Commits),
+		BehindBy: behind,
+		Commits:  convertCommitList(from.Commits),
+		Changes:  convertChangeList(from.Diffs),
+	}
+	switch {
+	case to.AheadBy == 0 && to.BehindBy == 0:
+		to.Status = "identical"
+	case to.BehindBy == 0:
+		to.Status = "ahead"
+	case to.AheadBy == 0:
+		to.Status = "behind"
+	default:
+		to.Status = "diverged"
+	}
+	return to
+}
+
 func convertTag(from *branch) *scm.Reference {
 	return &scm.Reference{
 		Name: scm.TrimRef(from.Name),
@@ -7,6 +7,7 @@ package gitlab
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"testing"
@@ -51,6 +52,38 @@ func TestGitFindCommit(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestGitResolveRefs(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/commits").
+		MatchParam("ref_name", "master").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/commits.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/commits").
+		MatchParam("ref_name", "missing").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`[]`)
+
+	client := NewDefault()
+	got, errs := client.Git.ResolveRefs(context.Background(), "diaspora/diaspora", []string{"master", "missing"})
+	if got, want := got["master"], "6104942438c14ec7bd21c6cd5bd995272b3faff6"; got != want {
+		t.Errorf("Want sha %q, got %q", want, got)
+	}
+	if sha, ok := got["missing"]; ok {
+		t.Errorf("Want no sha for a missing ref, got %q", sha)
+	}
+	if got, want := errs["missing"], scm.ErrNotFound; got != want {
+		t.Errorf("Want error %v, got %v", want, got)
+	}
+}
+
 func TestGitFindBranch(t *testing.T) {
 	defer gock.Off()
 
@@ -244,6 +277,105 @@ func TestGitListChanges(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestGitCompareCommits(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/compare").
+		MatchParam("from", "master").
+		MatchParam("to", "topic").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/compare.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/compare").
+		MatchParam("from", "topic").
+		MatchParam("to", "master").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/compare_empty.json")
+
+	client := NewDefault()
+	got, res, err := client.Git.CompareCommits(context.Background(), "diaspora/diaspora", "master", "topic")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Comparison)
+	raw, _ := ioutil.ReadFile("testdata/compare.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestGitCompareAcrossForks(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/repo.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/32732/forks").
+		MatchParam("page", "1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/forks.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/fork-owner/diaspora-fork/repository/compare").
+		MatchParam("from", "master").
+		MatchParam("to", "topic").
+		MatchParam("from_project_id", "32732").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/compare.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/compare").
+		MatchParam("from", "topic").
+		MatchParam("to", "master").
+		MatchParam("from_project_id", "32802").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/compare.json")
+
+	client := NewDefault()
+	got, res, err := client.Git.CompareAcrossForks(context.Background(), "diaspora/diaspora", "master", "fork-owner", "topic")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Comparison)
+	raw, _ := ioutil.ReadFile("testdata/compare_forks.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
 func TestGitCreateRef(t *testing.T) {
 	baseSHA := "aa218f56b14c9653891f9e74264a383fa43fefbd"
 	defer gock.Off()
@@ -274,3 +406,59 @@ func TestGitCreateRef(t *testing.T) {
 	t.Run("Request", testRequest(res))
 	t.Run("Rate", testRate(res))
 }
+
+func TestGitCreateRef_Tag(t *testing.T) {
+	baseSHA := "2695effb5807a22ff3d138d593fd856244e155e7"
+	defer gock.Off()
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/repository/tags").
+		MatchParam("tag_name", "v1.0.0").
+		MatchParam("ref", baseSHA).
+		Reply(http.StatusCreated).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/tag.json")
+
+	client := NewDefault()
+	got, res, err := client.Git.CreateRef(context.Background(), "diaspora/diaspora", "refs/tags/v1.0.0", baseSHA)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &scm.Reference{}
+	raw, _ := ioutil.ReadFile("testdata/tag.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestGitDeleteRef(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://gitlab.com").
+		Delete("/api/v4/projects/diaspora/diaspora/repository/branches/feature/x").
+		Reply(http.StatusNoContent).
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	res, err := client.Git.DeleteRef(context.Background(), "diaspora/diaspora", "heads/feature/x")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestGitUpdateRef(t *testing.T) {
+	client := NewDefault()
+	_, _, err := client.Git.UpdateRef(context.Background(), "diaspora/diaspora", "master", "2695effb5807a22ff3d138d593fd856244e155e7", false)
+	if !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error")
+	}
+}
@@ -21,8 +21,12 @@ import (
 )
 
 // NewWebHookService creates a new instance of the webhook service without the rest of the client
-func NewWebHookService() scm.WebhookService {
-	return &webhookService{nil}
+func NewWebHookService(opts ...scm.WebhookServiceOptions) scm.WebhookService {
+	s := &webhookService{}
+	if len(opts) > 0 {
+		s.opts = opts[0]
+	}
+	return s
 }
 
 // New returns a new GitLab API client.
@@ -38,6 +42,10 @@ func New(uri string) (*scm.Client, error) {
 	client.BaseURL = base
 	// initialize services
 	client.Driver = scm.DriverGitlab
+	client.SetCaller(client.do)
+	client.Limits = &scm.QueryLimits{PageSizeParam: "per_page", MaxPageSize: 100}
+	client.CI = &ciService{client}
+	client.Deployments = &deploymentService{client}
 	client.Contents = &contentService{client}
 	client.Git = &gitService{client}
 	client.Issues = &issueService{client}
@@ -46,8 +54,13 @@ func New(uri string) (*scm.Client, error) {
 	client.PullRequests = &pullService{client}
 	client.Repositories = &repositoryService{client}
 	client.Reviews = &reviewService{client}
+	client.Runners = &runnerService{client}
+	client.Security = &securityService{client}
 	client.Users = &userService{client}
-	client.Webhooks = &webhookService{client}
+	client.Variables = &variableService{client}
+	client.Webhooks = &webhookService{client: client}
+	client.Provisioning = &provisioningService{client}
+	client.Admin = &adminService{client}
 
 	graphqlEndpoint := scm.URLJoin(uri, "/api/graphql")
 	client.GraphQLURL, err = url.Parse(graphqlEndpoint)
@@ -143,9 +156,6 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	}
 	defer res.Body.Close()
 
-	// parse the gitlab request id.
-	res.ID = res.Header.Get("X-Request-Id")
-
 	// parse the gitlab rate limit details.
 	res.Rate.Limit, _ = strconv.Atoi(
 		res.Header.Get("RateLimit-Limit"),
@@ -163,7 +173,7 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	// if an error is encountered, unmarshal and return the
 	// error response.
 	if res.Status > 300 {
-		err := new(Error)
+		err := &Error{status: res.Status}
 		json.NewDecoder(res.Body).Decode(err)
 		return res, err
 	}
@@ -180,12 +190,20 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 // Error represents a GitLab error.
 type Error struct {
 	Message string `json:"message"`
+
+	status int
 }
 
 func (e *Error) Error() string {
 	return e.Message
 }
 
+// Is returns true if target is scm.ErrNotFound and the
+// error was returned for a missing resource.
+func (e *Error) Is(target error) bool {
+	return target == scm.ErrNotFound && e.status == http.StatusNotFound
+}
+
 type updateNoteOptions struct {
 	Body string `json:"body"`
 }
@@ -213,10 +231,19 @@ func convertLabelEvent(from *labelEvent) *scm.ListedIssueEvent {
 	if from.Action == "remove" {
 		event = "unlabeled"
 	}
-	return &scm.ListedIssueEvent{
-		Event:   event,
-		Actor:   *convertUser(&from.User),
-		Label:   *convertLabel(&from.Label),
-		Created: *from.CreatedAt,
+	dst := &scm.ListedIssueEvent{
+		Event: event,
+		Actor: *convertUser(&from.User),
+		Label: *convertLabel(&from.Label),
+	}
+	if from.CreatedAt != nil {
+		dst.Created = from.CreatedAt.UTC()
 	}
+	return dst
+}
+
+// notSupported returns the error of a method of a service
+// the driver does not support.
+func notSupported(service, method string) error {
+	return &scm.NotSupportedError{Driver: scm.DriverGitlab, Service: service, Method: method}
 }
@@ -87,7 +87,7 @@ func testPullRequestCommentFind(client *scm.Client) func(t *testing.T) {
 func testPullRequestCommentList(client *scm.Client) func(t *testing.T) {
 	return func(t *testing.T) {
 		t.Parallel()
-		opts := scm.ListOptions{}
+		opts := scm.CommentListOptions{}
 		result, _, err := client.PullRequests.ListComments(context.Background(), "gitlab-org/testme", 1, opts)
 		if err != nil {
 			t.Error(err)
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -50,6 +51,20 @@ func (s *issueService) AssignIssue(ctx context.Context, repo string, number int,
 	return s.setAssignees(ctx, repo, number, assigneeIDs)
 }
 
+// findUserIDs returns the ids of the users, which gitlab
+// expects instead of the logins.
+func (c *wrapper) findUserIDs(ctx context.Context, logins []string) ([]int, *scm.Response, error) {
+	var ids []int
+	for _, l := range logins {
+		u, res, err := c.Users.FindLogin(ctx, l)
+		if err != nil {
+			return nil, res, err
+		}
+		ids = append(ids, u.ID)
+	}
+	return ids, nil, nil
+}
+
 func (s *issueService) setAssignees(ctx context.Context, repo string, number int, ids []int) (*scm.Response, error) {
 	in := &updateIssueOptions{
 		AssigneeIDs: ids,
@@ -92,13 +107,7 @@ func (s *issueService) ListLabels(ctx context.Context, repo string, number int,
 	if err != nil {
 		return nil, issueResp, err
 	}
-	var labels []*scm.Label
-	for _, l := range issue.Labels {
-		labels = append(labels, &scm.Label{
-			Name: l,
-		})
-	}
-	return labels, issueResp, err
+	return issue.Labels, issueResp, err
 }
 
 func (s *issueService) AddLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
@@ -145,7 +154,7 @@ func (s *issueService) DeleteLabel(ctx context.Context, repo string, number int,
 }
 
 func (s *issueService) Find(ctx context.Context, repo string, number int) (*scm.Issue, *scm.Response, error) {
-	path := fmt.Sprintf("api/v4/projects/%s/issues/%d", encode(repo), number)
+	path := fmt.Sprintf("api/v4/projects/%s/issues/%d?with_labels_details=true", encode(repo), number)
 	out := new(issue)
 	res, err := s.client.do(ctx, "GET", path, nil, out)
 	return convertIssue(out), res, err
@@ -159,26 +168,77 @@ func (s *issueService) FindComment(ctx context.Context, repo string, index, id i
 }
 
 func (s *issueService) List(ctx context.Context, repo string, opts scm.IssueListOptions) ([]*scm.Issue, *scm.Response, error) {
-	path := fmt.Sprintf("api/v4/projects/%s/issues?%s", encode(repo), encodeIssueListOptions(opts))
+	path := fmt.Sprintf("api/v4/projects/%s/issues?with_labels_details=true&%s", encode(repo), encodeIssueListOptions(opts))
 	out := []*issue{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
 	return convertIssueList(out), res, err
 }
 
-func (s *issueService) ListComments(ctx context.Context, repo string, index int, opts scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
-	path := fmt.Sprintf("api/v4/projects/%s/issues/%d/notes?%s", encode(repo), index, encodeListOptions(opts))
+func (s *issueService) ListComments(ctx context.Context, repo string, index int, opts scm.CommentListOptions) ([]*scm.Comment, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/issues/%d/notes?%s", encode(repo), index, encodeCommentListOptions(opts))
 	out := []*issueComment{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
-	return convertIssueCommentList(out), res, err
+	if err != nil {
+		return nil, res, err
+	}
+	// notes are sorted by the api, but cannot be filtered
+	// by update time.
+	return scm.FilterComments(convertIssueCommentList(out), scm.CommentListOptions{Since: opts.Since}), res, nil
 }
 
 func (s *issueService) Create(ctx context.Context, repo string, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
 	in := url.Values{}
 	in.Set("title", input.Title)
 	in.Set("description", input.Body)
+	if input.Milestone != 0 {
+		in.Set("milestone_id", strconv.Itoa(input.Milestone))
+	}
+	if len(input.Labels) != 0 {
+		in.Set("labels", strings.Join(input.Labels, ","))
+	}
+	ids, res, err := s.client.findUserIDs(ctx, input.Assignees)
+	if err != nil {
+		return nil, res, err
+	}
+	for _, id := range ids {
+		in.Add("assignee_ids[]", strconv.Itoa(id))
+	}
 	path := fmt.Sprintf("api/v4/projects/%s/issues?%s", encode(repo), in.Encode())
 	out := new(issue)
-	res, err := s.client.do(ctx, "POST", path, nil, out)
+	res, err = s.client.do(ctx, "POST", path, nil, out)
+	return convertIssue(out), res, err
+}
+
+func (s *issueService) Update(ctx context.Context, repo string, number int, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	in := &updateIssueOptions{
+		Labels: input.Labels,
+	}
+	if input.Title != "" {
+		in.Title = &input.Title
+	}
+	if input.Body != "" {
+		in.Description = &input.Body
+	}
+	if input.Milestone != 0 {
+		in.MilestoneID = &input.Milestone
+	}
+	// gitlab has no state reason, only the state is applied.
+	switch input.TargetState() {
+	case scm.IssueStateOpen:
+		event := "reopen"
+		in.StateEvent = &event
+	case scm.IssueStateClosed:
+		event := "close"
+		in.StateEvent = &event
+	}
+	ids, res, err := s.client.findUserIDs(ctx, input.Assignees)
+	if err != nil {
+		return nil, res, err
+	}
+	in.AssigneeIDs = ids
+	path := fmt.Sprintf("api/v4/projects/%s/issues/%d", encode(repo), number)
+	out := new(issue)
+	res, err = s.client.do(ctx, "PUT", path, in, out)
 	return convertIssue(out), res, err
 }
 
@@ -204,6 +264,14 @@ func (s *issueService) EditComment(ctx context.Context, repo string, number int,
 	return convertIssueComment(out), res, err
 }
 
+func (s *issueService) MinimizeComment(ctx context.Context, repo string, number int, id int, reason string) (*scm.Response, error) {
+	return nil, notSupported("Issues", "MinimizeComment")
+}
+
+func (s *issueService) UnminimizeComment(ctx context.Context, repo string, number int, id int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "UnminimizeComment")
+}
+
 func (s *issueService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
 	path := fmt.Sprintf("api/v4/projects/%s/issues/%d?state_event=close", encode(repo), number)
 	res, err := s.client.do(ctx, "PUT", path, nil, nil)
@@ -247,6 +315,18 @@ func (s *issueService) ClearMilestone(ctx context.Context, repo string, id int)
 	return s.client.do(ctx, "PUT", path, in, nil)
 }
 
+func (s *issueService) Pin(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "Pin")
+}
+
+func (s *issueService) Unpin(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "Unpin")
+}
+
+func (s *issueService) ListPinned(ctx context.Context, repo string) ([]*scm.Issue, *scm.Response, error) {
+	return nil, nil, notSupported("Issues", "ListPinned")
+}
+
 type updateIssueOptions struct {
 	Title            *string    `json:"title,omitempty"`
 	Description      *string    `json:"description,omitempty"`
@@ -261,14 +341,14 @@ type updateIssueOptions struct {
 }
 
 type issue struct {
-	ID     int      `json:"id"`
-	Number int      `json:"iid"`
-	State  string   `json:"state"`
-	Title  string   `json:"title"`
-	Desc   string   `json:"description"`
-	Link   string   `json:"web_url"`
-	Locked bool     `json:"discussion_locked"`
-	Labels []string `json:"labels"`
+	ID     int    `json:"id"`
+	Number int    `json:"iid"`
+	State  string `json:"state"`
+	Title  string `json:"title"`
+	Desc   string `json:"description"`
+	Link   string `json:"web_url"`
+	Locked bool   `json:"discussion_locked"`
+	Labels labels `json:"labels"`
 	Author struct {
 		Name     string      `json:"name"`
 		Username string      `json:"username"`
@@ -278,6 +358,7 @@ type issue struct {
 	Assignees []*issueAssignee `json:"assignees"`
 	Created   time.Time        `json:"created_at"`
 	Updated   time.Time        `json:"updated_at"`
+	ClosedAt  time.Time        `json:"closed_at"`
 }
 
 type issueAssignee struct {
@@ -325,7 +406,7 @@ func convertIssue(from *issue) *scm.Issue {
 		Body:   from.Desc,
 		State:  gitlabStateToSCMState(from.State),
 		Link:   from.Link,
-		Labels: from.Labels,
+		Labels: convertLabelObjects(from.Labels),
 		Locked: from.Locked,
 		Closed: from.State == "closed",
 		Author: scm.User{
@@ -334,8 +415,9 @@ func convertIssue(from *issue) *scm.Issue {
 			Avatar: from.Author.Avatar.String,
 		},
 		Assignees: convertIssueAssignees(from.Assignee, from.Assignees),
-		Created:   from.Created,
-		Updated:   from.Updated,
+		Created:   from.Created.UTC(),
+		Updated:   from.Updated.UTC(),
+		ClosedAt:  from.ClosedAt.UTC(),
 	}
 }
 
@@ -390,7 +472,7 @@ func convertIssueComment(from *issueComment) *scm.Comment {
 			Login:  from.User.Username,
 			Avatar: from.User.AvatarURL,
 		},
-		Created: from.CreatedAt,
-		Updated: from.UpdatedAt,
+		Created: from.CreatedAt.UTC(),
+		Updated: from.UpdatedAt.UTC(),
 	}
 }
@@ -125,7 +125,7 @@ func TestIssueListComments(t *testing.T) {
 		File("testdata/issue_notes.json")
 
 	client := NewDefault()
-	got, res, err := client.Issues.ListComments(context.Background(), "diaspora/diaspora", 1, scm.ListOptions{Size: 30, Page: 1})
+	got, res, err := client.Issues.ListComments(context.Background(), "diaspora/diaspora", 1, scm.CommentListOptions{Size: 30, Page: 1})
 	if err != nil {
 		t.Error(err)
 		return
@@ -217,6 +217,74 @@ func TestIssueCreate(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestIssueCreateFields(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/users").
+		MatchParam("search", "john_smith").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/user_search.json")
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/issues").
+		MatchParam("milestone_id", "1").
+		MatchParam("labels", "bug,ui").
+		MatchParam("assignee_ids[]", "1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/issue.json")
+
+	input := scm.IssueInput{
+		Title:     "Found a bug",
+		Body:      "I'm having a problem with this.",
+		Milestone: 1,
+		Labels:    []string{"bug", "ui"},
+		Assignees: []string{"john_smith"},
+	}
+
+	client := NewDefault()
+	_, _, err := client.Issues.Create(context.Background(), "diaspora/diaspora", &input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestIssueUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/projects/diaspora/diaspora/issues/1").
+		JSON(map[string]interface{}{
+			"title":       "Found a bug",
+			"state_event": "close",
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/issue.json")
+
+	input := &scm.IssueInput{
+		Title:       "Found a bug",
+		StateReason: scm.IssueStateReasonCompleted,
+	}
+
+	client := NewDefault()
+	_, res, err := client.Issues.Update(context.Background(), "diaspora/diaspora", 1, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
 func TestIssueCreateComment(t *testing.T) {
 	defer gock.Off()
 
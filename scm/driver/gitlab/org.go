@@ -17,11 +17,11 @@ type organizationService struct {
 }
 
 func (s *organizationService) Create(context.Context, *scm.OrganizationInput) (*scm.Organization, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Organizations", "Create")
 }
 
 func (s *organizationService) Delete(context.Context, string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Organizations", "Delete")
 }
 
 func (s *organizationService) IsMember(ctx context.Context, org string, user string) (bool, *scm.Response, error) {
@@ -97,15 +97,15 @@ func (s *organizationService) List(ctx context.Context, opts scm.ListOptions) ([
 }
 
 func (s *organizationService) ListPendingInvitations(ctx context.Context, org string, opts scm.ListOptions) ([]*scm.OrganizationPendingInvite, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Organizations", "ListPendingInvitations")
 }
 
 func (s *organizationService) AcceptOrganizationInvitation(ctx context.Context, org string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Organizations", "AcceptOrganizationInvitation")
 }
 
 func (s *organizationService) ListMemberships(ctx context.Context, opts scm.ListOptions) ([]*scm.Membership, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Organizations", "ListMemberships")
 }
 
 type organization struct {
@@ -0,0 +1,76 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type pages struct {
+	URL           string `json:"url"`
+	ForceHTTPS    bool   `json:"force_https"`
+	PrimaryDomain string `json:"primary_domain"`
+	Deployments   []struct {
+		CreatedAt time.Time `json:"created_at"`
+		URL       string    `json:"url"`
+	} `json:"deployments"`
+}
+
+func (s *repositoryService) GetPages(ctx context.Context, repo string) (*scm.Pages, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/pages", encode(repo))
+	out := new(pages)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertPages(out), res, err
+}
+
+// EnablePages is not supported, GitLab Pages are deployed
+// by the pages job of the CI pipeline.
+func (s *repositoryService) EnablePages(context.Context, string, *scm.PagesInput) (*scm.Pages, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "EnablePages")
+}
+
+func (s *repositoryService) DisablePages(ctx context.Context, repo string) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/pages", encode(repo))
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+// GetLatestPagesBuild returns the latest deployment of the
+// site. GitLab only lists the successful deployments, so
+// the build is always built.
+func (s *repositoryService) GetLatestPagesBuild(ctx context.Context, repo string) (*scm.PagesBuild, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/pages", encode(repo))
+	out := new(pages)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	var latest time.Time
+	for _, deployment := range out.Deployments {
+		if deployment.CreatedAt.After(latest) {
+			latest = deployment.CreatedAt
+		}
+	}
+	if latest.IsZero() {
+		return nil, res, scm.ErrNotFound
+	}
+	return &scm.PagesBuild{
+		Status:  scm.PagesStatusBuilt,
+		Created: latest,
+		Updated: latest,
+	}, res, nil
+}
+
+func convertPages(from *pages) *scm.Pages {
+	to := &scm.Pages{
+		URL:           from.URL,
+		BuildType:     "workflow",
+		CNAME:         from.PrimaryDomain,
+		HTTPSEnforced: from.ForceHTTPS,
+	}
+	if len(from.Deployments) != 0 {
+		to.Status = scm.PagesStatusBuilt
+	}
+	return to
+}
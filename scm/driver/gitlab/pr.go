@@ -22,7 +22,37 @@ type pullService struct {
 }
 
 func (s *pullService) Find(ctx context.Context, repo string, number int) (*scm.PullRequest, *scm.Response, error) {
-	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d", encode(repo), number)
+	pr, res, err := s.find(ctx, repo, number)
+	if err != nil {
+		return nil, res, err
+	}
+	// merge requests do not report diff stats, so they are
+	// computed from the changes. The stats are best effort,
+	// and are left unset if the changes cannot be fetched or
+	// overflow the limits of the server. The errors of a
+	// canceled context are returned.
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/changes", encode(repo), number)
+	out := new(changes)
+	if _, err := s.client.do(ctx, "GET", path, nil, out); err != nil {
+		if ctx.Err() != nil {
+			return nil, res, err
+		}
+		return pr, res, nil
+	}
+	if out.Overflow {
+		return pr, res, nil
+	}
+	for _, c := range out.Changes {
+		additions, deletions := countDiffLines(c.Diff)
+		pr.Additions += additions
+		pr.Deletions += deletions
+	}
+	pr.ChangedFiles = len(out.Changes)
+	return pr, res, nil
+}
+
+func (s *pullService) find(ctx context.Context, repo string, number int) (*scm.PullRequest, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d?with_labels_details=true", encode(repo), number)
 	out := new(pr)
 	res, err := s.client.do(ctx, "GET", path, nil, out)
 	if err != nil {
@@ -43,7 +73,7 @@ func (s *pullService) FindComment(ctx context.Context, repo string, index, id in
 }
 
 func (s *pullService) List(ctx context.Context, repo string, opts scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
-	path := fmt.Sprintf("api/v4/projects/%s/merge_requests?%s", encode(repo), encodePullRequestListOptions(opts))
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests?with_labels_details=true&%s", encode(repo), encodePullRequestListOptions(opts))
 	out := []*pr{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
 	if err != nil {
@@ -63,15 +93,20 @@ func (s *pullService) ListChanges(ctx context.Context, repo string, number int,
 	return convertChangeList(out.Changes), res, err
 }
 
-func (s *pullService) ListComments(ctx context.Context, repo string, index int, opts scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
-	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/notes?%s", encode(repo), index, encodeListOptions(opts))
+func (s *pullService) ListComments(ctx context.Context, repo string, index int, opts scm.CommentListOptions) ([]*scm.Comment, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/notes?%s", encode(repo), index, encodeCommentListOptions(opts))
 	out := []*issueComment{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
-	return convertIssueCommentList(out), res, err
+	if err != nil {
+		return nil, res, err
+	}
+	// notes are sorted by the api, but cannot be filtered
+	// by update time.
+	return scm.FilterComments(convertIssueCommentList(out), scm.CommentListOptions{Since: opts.Since}), res, nil
 }
 
 func (s *pullService) ListLabels(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
-	mr, _, err := s.Find(ctx, repo, number)
+	mr, _, err := s.find(ctx, repo, number)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -171,7 +206,7 @@ func (s *pullService) Reopen(ctx context.Context, repo string, number int) (*scm
 }
 
 func (s *pullService) AssignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	pr, _, err := s.Find(ctx, repo, number)
+	pr, _, err := s.find(ctx, repo, number)
 	if err != nil {
 		return nil, err
 	}
@@ -209,7 +244,7 @@ func (s *pullService) setAssignees(ctx context.Context, repo string, number int,
 }
 
 func (s *pullService) UnassignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	pr, _, err := s.Find(ctx, repo, number)
+	pr, _, err := s.find(ctx, repo, number)
 	if err != nil {
 		return nil, err
 	}
@@ -244,10 +279,19 @@ func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRe
 		SourceBranch: input.Head,
 		TargetBranch: input.Base,
 		Description:  input.Body,
+		Labels:       strings.Join(input.Labels, ","),
+	}
+	if input.Milestone != 0 {
+		in.MilestoneID = &input.Milestone
 	}
+	ids, res, err := s.client.findUserIDs(ctx, input.Assignees)
+	if err != nil {
+		return nil, res, err
+	}
+	in.AssigneeIDs = ids
 
 	out := new(pr)
-	res, err := s.client.do(ctx, "POST", path, in, out)
+	res, err = s.client.do(ctx, "POST", path, in, out)
 	if err != nil {
 		return nil, res, err
 	}
@@ -269,6 +313,20 @@ func (s *pullService) Update(ctx context.Context, repo string, number int, input
 	if input.Base != "" {
 		updateOpts.TargetBranch = &input.Base
 	}
+	if input.Milestone != 0 {
+		updateOpts.MilestoneID = &input.Milestone
+	}
+	if len(input.Labels) != 0 {
+		labels := strings.Join(input.Labels, ",")
+		updateOpts.Labels = &labels
+	}
+	if len(input.Assignees) != 0 {
+		ids, res, err := s.client.findUserIDs(ctx, input.Assignees)
+		if err != nil {
+			return nil, res, err
+		}
+		updateOpts.AssigneeIDs = ids
+	}
 	return s.updateMergeRequestField(ctx, repo, number, updateOpts)
 }
 
@@ -327,7 +385,7 @@ type pr struct {
 	State           string    `json:"state"`
 	SourceProjectID int       `json:"source_project_id"`
 	TargetProjectID int       `json:"target_project_id"`
-	Labels          []*string `json:"labels"`
+	Labels          labels    `json:"labels"`
 	Link            string    `json:"web_url"`
 	WIP             bool      `json:"work_in_progress"`
 	Author          user      `json:"author"`
@@ -336,7 +394,8 @@ type pr struct {
 	TargetBranch    string    `json:"target_branch"`
 	Created         time.Time `json:"created_at"`
 	Updated         time.Time `json:"updated_at"`
-	Closed          time.Time
+	ClosedAt        time.Time `json:"closed_at"`
+	MergedAt        time.Time `json:"merged_at"`
 	DiffRefs        struct {
 		BaseSHA string `json:"base_sha"`
 		HeadSHA string `json:"head_sha"`
@@ -346,7 +405,8 @@ type pr struct {
 }
 
 type changes struct {
-	Changes []*change
+	Changes  []*change
+	Overflow bool `json:"overflow"`
 }
 
 type change struct {
@@ -363,6 +423,9 @@ type prInput struct {
 	Description  string `json:"description"`
 	SourceBranch string `json:"source_branch"`
 	TargetBranch string `json:"target_branch"`
+	MilestoneID  *int   `json:"milestone_id,omitempty"`
+	Labels       string `json:"labels,omitempty"`
+	AssigneeIDs  []int  `json:"assignee_ids,omitempty"`
 }
 
 type pullRequestMergeRequest struct {
@@ -424,7 +487,7 @@ func (s *pullService) convertPullRequest(ctx context.Context, from *pr) (*scm.Pu
 		Title:          from.Title,
 		Body:           from.Desc,
 		State:          gitlabStateToSCMState(from.State),
-		Labels:         convertPullRequestLabels(from.Labels),
+		Labels:         convertLabelObjects(from.Labels),
 		Sha:            from.Sha,
 		Ref:            fmt.Sprintf("refs/merge-requests/%d/head", from.Number),
 		Source:         from.SourceBranch,
@@ -447,22 +510,13 @@ func (s *pullService) convertPullRequest(ctx context.Context, from *pr) (*scm.Pu
 			Sha:  from.DiffRefs.BaseSHA,
 			Repo: *baseRepo,
 		},
-		Created: from.Created,
-		Updated: from.Updated,
+		Created:  from.Created.UTC(),
+		Updated:  from.Updated.UTC(),
+		ClosedAt: from.ClosedAt.UTC(),
+		MergedAt: from.MergedAt.UTC(),
 	}, nil, nil
 }
 
-func convertPullRequestLabels(from []*string) []*scm.Label {
-	var labels []*scm.Label
-	for _, label := range from {
-		l := *label
-		labels = append(labels, &scm.Label{
-			Name: l,
-		})
-	}
-	return labels
-}
-
 func convertChangeList(from []*change) []*scm.Change {
 	to := []*scm.Change{}
 	for _, v := range from {
@@ -477,11 +531,10 @@ func convertChange(from *change) *scm.Change {
 		PreviousPath: from.OldPath,
 		Added:        from.Added,
 		Deleted:      from.Deleted,
-		Additions:    from.Added,
-		Deletions:    from.Deleted,
 		Renamed:      from.Renamed,
 		Patch:        from.Diff,
 	}
+	to.Additions, to.Deletions = countDiffLines(from.Diff)
 	if to.Path == "" {
 		to.Path = from.OldPath
 	}
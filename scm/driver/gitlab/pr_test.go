@@ -7,7 +7,9 @@ package gitlab
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
+	"net/http"
 	"testing"
 	"time"
 
@@ -33,6 +35,13 @@ func TestPullFind(t *testing.T) {
 		SetHeaders(mockHeaders).
 		File("testdata/merge.json")
 
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1347/changes").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_diff.json")
+
 	client := NewDefault()
 	got, res, err := client.PullRequests.Find(context.Background(), "diaspora/diaspora", 1347)
 	if err != nil {
@@ -58,6 +67,108 @@ func TestPullFind(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestPullFindChangesError(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/32732").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/repo.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1347/changes").
+		Reply(500)
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.Find(context.Background(), "diaspora/diaspora", 1347)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Additions != 0 || got.Deletions != 0 || got.ChangedFiles != 0 {
+		t.Errorf("Want the diff stats unset without the changes")
+	}
+}
+
+func TestPullFindChangesCanceled(t *testing.T) {
+	defer gock.Off()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/32732").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/repo.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1347/changes").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"changes":[]}`).
+		Map(func(res *http.Response) *http.Response {
+			cancel()
+			return res
+		})
+
+	client := NewDefault()
+	if _, _, err := client.PullRequests.Find(ctx, "diaspora/diaspora", 1347); !errors.Is(err, context.Canceled) {
+		t.Errorf("Want the context error, got %v", err)
+	}
+}
+
+func TestPullFindChangesOverflow(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/32732").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/repo.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1347/changes").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"changes":[{"new_path":"README.md","diff":"@@ -1 +1 @@\n-a\n+b\n"}],"overflow":true}`)
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.Find(context.Background(), "diaspora/diaspora", 1347)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Additions != 0 || got.Deletions != 0 || got.ChangedFiles != 0 {
+		t.Errorf("Want the diff stats unset with overflowing changes")
+	}
+}
+
 func TestPullList(t *testing.T) {
 	defer gock.Off()
 
@@ -250,7 +361,7 @@ func TestPullListComments(t *testing.T) {
 		File("testdata/merge_notes.json")
 
 	client := NewDefault()
-	got, res, err := client.PullRequests.ListComments(context.Background(), "diaspora/diaspora", 1, scm.ListOptions{Size: 30, Page: 1})
+	got, res, err := client.PullRequests.ListComments(context.Background(), "diaspora/diaspora", 1, scm.CommentListOptions{Size: 30, Page: 1})
 	if err != nil {
 		t.Error(err)
 		return
@@ -0,0 +1,100 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type provisioningService struct {
+	client *wrapper
+}
+
+type adminUser struct {
+	ID         int    `json:"id"`
+	Username   string `json:"username"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	State      string `json:"state"`
+	Identities []struct {
+		Provider  string `json:"provider"`
+		ExternUID string `json:"extern_uid"`
+	} `json:"identities"`
+}
+
+type adminUserInput struct {
+	Username      string `json:"username"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	Password      string `json:"password,omitempty"`
+	ResetPassword bool   `json:"reset_password,omitempty"`
+}
+
+// CreateUser creates the user on the instance, the
+// organization is ignored.
+//
+// See https://docs.gitlab.com/ee/api/users.html#user-creation
+func (s *provisioningService) CreateUser(ctx context.Context, _ string, input *scm.ProvisionUserInput) (*scm.ProvisionedUser, *scm.Response, error) {
+	in := &adminUserInput{
+		Username:      input.Login,
+		Name:          input.Name,
+		Email:         input.Email,
+		Password:      input.Password,
+		ResetPassword: input.Password == "",
+	}
+	out := new(adminUser)
+	res, err := s.client.do(ctx, "POST", "api/v4/users", in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertAdminUser(out), res, nil
+}
+
+// BlockUser blocks the user on the instance.
+//
+// See https://docs.gitlab.com/ee/api/users.html#block-user
+func (s *provisioningService) BlockUser(ctx context.Context, _, login string) (*scm.Response, error) {
+	return s.userAction(ctx, login, "block")
+}
+
+// UnblockUser unblocks the user on the instance.
+func (s *provisioningService) UnblockUser(ctx context.Context, _, login string) (*scm.Response, error) {
+	return s.userAction(ctx, login, "unblock")
+}
+
+// DeactivateUser deactivates the user on the instance. The
+// user is reactivated on the next sign in.
+//
+// See https://docs.gitlab.com/ee/api/users.html#deactivate-user
+func (s *provisioningService) DeactivateUser(ctx context.Context, _, login string) (*scm.Response, error) {
+	return s.userAction(ctx, login, "deactivate")
+}
+
+func (s *provisioningService) userAction(ctx context.Context, login, action string) (*scm.Response, error) {
+	ids, res, err := s.client.findUserIDs(ctx, []string{login})
+	if err != nil {
+		return res, err
+	}
+	path := fmt.Sprintf("api/v4/users/%d/%s", ids[0], action)
+	return s.client.do(ctx, "POST", path, nil, nil)
+}
+
+func convertAdminUser(from *adminUser) *scm.ProvisionedUser {
+	to := &scm.ProvisionedUser{
+		ID:     strconv.Itoa(from.ID),
+		Login:  from.Username,
+		Name:   from.Name,
+		Email:  from.Email,
+		Active: from.State == "active",
+	}
+	if len(from.Identities) != 0 {
+		to.ExternalID = from.Identities[0].ExternUID
+	}
+	return to
+}
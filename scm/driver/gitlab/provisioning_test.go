@@ -0,0 +1,83 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitlab
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slimm609/go-scm/scm"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+)
+
+func TestProvisioningCreateUser(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/users").
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/user.json")
+
+	client := NewDefault()
+	input := &scm.ProvisionUserInput{
+		Login: "john_smith",
+		Name:  "John Smith",
+		Email: "john@example.com",
+	}
+	got, res, err := client.Provisioning.CreateUser(context.Background(), "", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &scm.ProvisionedUser{
+		ID:         "1",
+		Login:      "john_smith",
+		Name:       "John Smith",
+		Email:      "john@example.com",
+		ExternalID: "2435223452345",
+		Active:     true,
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestProvisioningBlockUser(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/users").
+		MatchParam("search", "john_smith").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/user_search.json")
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/users/1/block").
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	res, err := client.Provisioning.BlockUser(context.Background(), "", "john_smith")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gock.IsPending() {
+		t.Errorf("Pending API calls")
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
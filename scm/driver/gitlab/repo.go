@@ -6,7 +6,9 @@ package gitlab
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -17,6 +19,13 @@ import (
 	"github.com/slimm609/go-scm/scm/driver/internal/null"
 )
 
+// statusConflictRetries is the number of times a status
+// rejected with a 409 conflict is sent again, waiting
+// statusConflictBackoff longer before each attempt.
+const statusConflictRetries = 3
+
+var statusConflictBackoff = 250 * time.Millisecond
+
 const (
 	noPermissions         = 0
 	guestPermissions      = 10
@@ -41,11 +50,14 @@ type repository struct {
 	HTTPURL       string      `json:"http_url_to_repo"`
 	Namespace     namespace   `json:"namespace"`
 	Permissions   permissions `json:"permissions"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"last_activity_at"`
 }
 
 type namespace struct {
-	Name string `json:"name"`
-	Path string `json:"path"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	FullPath string `json:"full_path"`
 }
 
 type permissions struct {
@@ -112,6 +124,29 @@ type label struct {
 	Description string `json:"description"`
 }
 
+// labels is a list of labels which unmarshals either the
+// label names, or the label objects returned when the
+// with_labels_details parameter is set.
+type labels []*label
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (l *labels) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err == nil {
+		*l = nil
+		for _, name := range names {
+			*l = append(*l, &label{Name: name})
+		}
+		return nil
+	}
+	var objects []*label
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return err
+	}
+	*l = objects
+	return nil
+}
+
 type member struct {
 	ID          int    `json:"id"`
 	Username    string `json:"username"`
@@ -325,11 +360,11 @@ func (s *repositoryService) List(ctx context.Context, opts scm.ListOptions) ([]*
 }
 
 func (s *repositoryService) ListOrganisation(context.Context, string, scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Repositories", "ListOrganisation")
 }
 
 func (s *repositoryService) ListUser(context.Context, string, scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Repositories", "ListUser")
 }
 
 func (s *repositoryService) ListHooks(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Hook, *scm.Response, error) {
@@ -393,19 +428,77 @@ func (s *repositoryService) CreateStatus(ctx context.Context, repo, ref string,
 	params.Set("name", input.Label)
 	params.Set("target_url", input.Target)
 	params.Set("description", input.Desc)
+	if input.PipelineID != 0 {
+		params.Set("pipeline_id", strconv.Itoa(input.PipelineID))
+	}
 	path := fmt.Sprintf("api/v4/projects/%s/statuses/%s?%s", encode(repo), ref, params.Encode())
 	out := new(status)
 	res, err := s.client.do(ctx, "POST", path, nil, out)
+	for retry := 1; retry <= statusConflictRetries && res != nil && res.Status == http.StatusConflict; retry++ {
+		// the status is being created concurrently by the
+		// pipeline, the request succeeds once it exists.
+		select {
+		case <-ctx.Done():
+			return nil, res, ctx.Err()
+		case <-time.After(time.Duration(retry) * statusConflictBackoff):
+		}
+		out = new(status)
+		res, err = s.client.do(ctx, "POST", path, nil, out)
+	}
+	if e, ok := err.(*Error); ok && strings.Contains(e.Message, "Cannot transition status") {
+		return s.findTransitioned(ctx, repo, ref, input, res, e)
+	}
 	return convertStatus(out), res, err
 }
 
+// findTransitioned handles a status update rejected because
+// the pipeline status machine does not allow the transition,
+// e.g. from running to running. The update is a no-op if the
+// status already has the requested state, and otherwise fails
+// with scm.StateCannotBeChanged. An error looking up the
+// status is returned as is.
+func (s *repositoryService) findTransitioned(ctx context.Context, repo, ref string, input *scm.StatusInput, res *scm.Response, cause *Error) (*scm.Status, *scm.Response, error) {
+	params := url.Values{}
+	params.Set("name", input.Label)
+	if input.PipelineID != 0 {
+		params.Set("pipeline_id", strconv.Itoa(input.PipelineID))
+	}
+	path := fmt.Sprintf("api/v4/projects/%s/repository/commits/%s/statuses?%s", encode(repo), ref, params.Encode())
+	out := []*status{}
+	if res, err := s.client.do(ctx, "GET", path, nil, &out); err != nil {
+		return nil, res, err
+	}
+	for _, existing := range convertStatusList(out) {
+		if existing.Label == input.Label && existing.State == input.State {
+			return existing, res, nil
+		}
+	}
+	return nil, res, scm.StateCannotBeChanged{Message: cause.Message}
+}
+
 func (s *repositoryService) DeleteHook(ctx context.Context, repo string, id string) (*scm.Response, error) {
 	path := fmt.Sprintf("api/v4/projects/%s/hooks/%s", encode(repo), id)
 	return s.client.do(ctx, "DELETE", path, nil, nil)
 }
 
 func (s *repositoryService) Delete(context.Context, string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Repositories", "Delete")
+}
+
+func (s *repositoryService) ListRulesets(context.Context, string, scm.ListOptions) ([]*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListRulesets")
+}
+
+func (s *repositoryService) FindRuleset(context.Context, string, int) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "FindRuleset")
+}
+
+func (s *repositoryService) CreateRuleset(context.Context, string, *scm.RulesetInput) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "CreateRuleset")
+}
+
+func (s *repositoryService) UpdateRuleset(context.Context, string, int, *scm.RulesetInput) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "UpdateRuleset")
 }
 
 // helper function to convert from the gogs repository list to
@@ -430,6 +523,8 @@ func convertRepository(from *repository) *scm.Repository {
 		Private:   convertPrivate(from.Visibility),
 		Clone:     from.HTTPURL,
 		CloneSSH:  from.SSHURL,
+		Created:   from.CreatedAt.UTC(),
+		Updated:   from.UpdatedAt.UTC(),
 		Perm: &scm.Perm{
 			Pull:  true,
 			Push:  canPush(from),
@@ -471,6 +566,8 @@ type status struct {
 	Target  null.String `json:"target_url"`
 	Created time.Time   `json:"created_at"`
 	Updated time.Time   `json:"updated_at"`
+
+	PipelineID int `json:"pipeline_id"`
 }
 
 func convertStatusList(from []*status) []*scm.Status {
@@ -483,10 +580,11 @@ func convertStatusList(from []*status) []*scm.Status {
 
 func convertStatus(from *status) *scm.Status {
 	return &scm.Status{
-		State:  convertState(from.Status),
-		Label:  from.Name,
-		Desc:   from.Desc.String,
-		Target: from.Target.String,
+		State:      convertState(from.Status),
+		Label:      from.Name,
+		Desc:       from.Desc.String,
+		Target:     from.Target.String,
+		PipelineID: from.PipelineID,
 	}
 }
 
@@ -516,27 +614,36 @@ func convertState(from string) scm.State {
 		return scm.StateCanceled
 	case "failed":
 		return scm.StateFailure
-	case "pending":
+	case "created", "waiting_for_resource", "preparing", "pending", "scheduled":
 		return scm.StatePending
 	case "running":
 		return scm.StateRunning
 	case "success":
 		return scm.StateSuccess
+	case "manual":
+		return scm.StateActionRequired
+	case "skipped":
+		return scm.StateSkipped
 	default:
 		return scm.StateUnknown
 	}
 }
 
+// convertFromState maps the normalized state to a commit status
+// state. GitLab has no neutral state, so neutral results are
+// reported as success.
 func convertFromState(from scm.State) string {
 	switch from {
-	case scm.StatePending:
+	case scm.StatePending, scm.StateExpected:
 		return "pending"
 	case scm.StateRunning:
 		return "running"
-	case scm.StateSuccess:
+	case scm.StateSuccess, scm.StateNeutral:
 		return "success"
 	case scm.StateCanceled:
 		return "canceled"
+	case scm.StateSkipped:
+		return "skipped"
 	default:
 		return "failed"
 	}
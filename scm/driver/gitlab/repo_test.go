@@ -8,7 +8,9 @@ import (
 	"context"
 	"encoding/json"
 	"io/ioutil"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/h2non/gock"
@@ -384,6 +386,126 @@ func TestStatusCreate(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestStatusCreatePipeline(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e").
+		MatchParam("name", "default").
+		MatchParam("pipeline_id", "42").
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		JSON(map[string]interface{}{"name": "default", "status": "running", "pipeline_id": 42})
+
+	in := &scm.StatusInput{
+		Label:      "default",
+		State:      scm.StateRunning,
+		PipelineID: 42,
+	}
+
+	client := NewDefault()
+	got, _, err := client.Repositories.CreateStatus(context.Background(), "diaspora/diaspora", "6dcb09b5b57875f334f61aebed695e2e4193db5e", in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.PipelineID != 42 {
+		t.Errorf("Want pipeline id 42, got %d", got.PipelineID)
+	}
+}
+
+func TestStatusCreateTransition(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e").
+		Times(2).
+		Reply(400).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		JSON(map[string]string{"message": "Cannot transition status via :enqueue from :pending"})
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e/statuses").
+		MatchParam("name", "default").
+		Times(2).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/statuses.json")
+
+	client := NewDefault()
+	in := &scm.StatusInput{Label: "default", State: scm.StatePending}
+	got, _, err := client.Repositories.CreateStatus(context.Background(), "diaspora/diaspora", "6dcb09b5b57875f334f61aebed695e2e4193db5e", in)
+	if err != nil {
+		t.Fatalf("Want the no-op transition to succeed, got %v", err)
+	}
+	if got.Label != "default" || got.State != scm.StatePending {
+		t.Errorf("Want the existing status, got %+v", got)
+	}
+
+	in = &scm.StatusInput{Label: "default", State: scm.StateSuccess}
+	_, _, err = client.Repositories.CreateStatus(context.Background(), "diaspora/diaspora", "6dcb09b5b57875f334f61aebed695e2e4193db5e", in)
+	if _, ok := err.(scm.StateCannotBeChanged); !ok {
+		t.Errorf("Want StateCannotBeChanged, got %v", err)
+	}
+}
+
+func TestStatusCreateConflict(t *testing.T) {
+	defer gock.Off()
+	defer func(backoff time.Duration) { statusConflictBackoff = backoff }(statusConflictBackoff)
+	statusConflictBackoff = time.Millisecond
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e").
+		Times(statusConflictRetries + 1).
+		Reply(409).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		JSON(map[string]string{"message": "409 Conflict"})
+
+	client := NewDefault()
+	in := &scm.StatusInput{Label: "default", State: scm.StatePending}
+	_, res, err := client.Repositories.CreateStatus(context.Background(), "diaspora/diaspora", "6dcb09b5b57875f334f61aebed695e2e4193db5e", in)
+	if err == nil {
+		t.Fatal("Want an error once the retries are exhausted")
+	}
+	if res.Status != http.StatusConflict {
+		t.Errorf("Want status %d, got %d", http.StatusConflict, res.Status)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Want %d attempts", statusConflictRetries+1)
+	}
+}
+
+func TestStatusCreateTransitionLookupError(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/statuses/6dcb09b5b57875f334f61aebed695e2e4193db5e").
+		Reply(400).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		JSON(map[string]string{"message": "Cannot transition status via :enqueue from :pending"})
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e/statuses").
+		Reply(500).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		JSON(map[string]string{"message": "500 Internal Server Error"})
+
+	client := NewDefault()
+	in := &scm.StatusInput{Label: "default", State: scm.StatePending}
+	_, res, err := client.Repositories.CreateStatus(context.Background(), "diaspora/diaspora", "6dcb09b5b57875f334f61aebed695e2e4193db5e", in)
+	if _, ok := err.(scm.StateCannotBeChanged); ok || err == nil {
+		t.Errorf("Want the lookup error, got %v", err)
+	}
+	if res.Status != http.StatusInternalServerError {
+		t.Errorf("Want status %d, got %d", http.StatusInternalServerError, res.Status)
+	}
+}
+
 func TestRepositoryHookFind(t *testing.T) {
 	defer gock.Off()
 
@@ -565,6 +687,18 @@ func TestConvertState(t *testing.T) {
 			src: "success",
 			dst: scm.StateSuccess,
 		},
+		{
+			src: "created",
+			dst: scm.StatePending,
+		},
+		{
+			src: "manual",
+			dst: scm.StateActionRequired,
+		},
+		{
+			src: "skipped",
+			dst: scm.StateSkipped,
+		},
 		{
 			src: "invalid",
 			dst: scm.StateUnknown,
@@ -606,6 +740,14 @@ func TestConvertFromState(t *testing.T) {
 			src: scm.StateSuccess,
 			dst: "success",
 		},
+		{
+			src: scm.StateNeutral,
+			dst: "success",
+		},
+		{
+			src: scm.StateSkipped,
+			dst: "skipped",
+		},
 		{
 			src: scm.StateUnknown,
 			dst: "failed",
@@ -784,3 +926,31 @@ func TestCanAdmin(t *testing.T) {
 		}
 	}
 }
+
+func TestLabelsUnmarshal(t *testing.T) {
+	tests := []struct {
+		in  string
+		out labels
+	}{
+		{
+			in:  `["bug", "ui"]`,
+			out: labels{{Name: "bug"}, {Name: "ui"}},
+		},
+		{
+			in:  `[{"id": 1, "name": "bug", "color": "#d9534f", "description": "Bug reports"}]`,
+			out: labels{{ID: 1, Name: "bug", Color: "#d9534f", Description: "Bug reports"}},
+		},
+	}
+
+	for _, test := range tests {
+		var got labels
+		if err := json.Unmarshal([]byte(test.in), &got); err != nil {
+			t.Error(err)
+			continue
+		}
+		if diff := cmp.Diff(got, test.out); diff != "" {
+			t.Errorf("Unexpected Results for %s", test.in)
+			t.Log(diff)
+		}
+	}
+}
@@ -6,6 +6,7 @@ package gitlab
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/slimm609/go-scm/scm"
@@ -14,7 +15,7 @@ import (
 func TestReviewFind(t *testing.T) {
 	service := new(reviewService)
 	_, _, err := service.Find(context.Background(), "diaspora/diaspora", 1, 1)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -22,7 +23,7 @@ func TestReviewFind(t *testing.T) {
 func TestReviewList(t *testing.T) {
 	service := new(reviewService)
 	_, _, err := service.List(context.Background(), "diaspora/diaspora", 1, scm.ListOptions{})
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -30,7 +31,7 @@ func TestReviewList(t *testing.T) {
 func TestReviewCreate(t *testing.T) {
 	service := new(reviewService)
 	_, _, err := service.Create(context.Background(), "diaspora/diaspora", 1, nil)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -38,7 +39,7 @@ func TestReviewCreate(t *testing.T) {
 func TestReviewDelete(t *testing.T) {
 	service := new(reviewService)
 	_, err := service.Delete(context.Background(), "diaspora/diaspora", 1, 1)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
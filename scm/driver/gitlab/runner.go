@@ -0,0 +1,81 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type runnerService struct {
+	client *wrapper
+}
+
+type runner struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	Paused      bool   `json:"paused"`
+}
+
+type runnerInput struct {
+	RunnerType string `json:"runner_type"`
+	ProjectID  int    `json:"project_id"`
+}
+
+type runnerToken struct {
+	ID             int       `json:"id"`
+	Token          string    `json:"token"`
+	TokenExpiresAt time.Time `json:"token_expires_at"`
+}
+
+func (s *runnerService) List(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Runner, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/runners?%s", encode(repo), encodeListOptions(opts))
+	out := []*runner{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertRunnerList(out), res, err
+}
+
+// CreateToken creates a project runner and returns its
+// authentication token, since runner registration tokens
+// are deprecated by GitLab.
+func (s *runnerService) CreateToken(ctx context.Context, repo string) (*scm.RunnerToken, *scm.Response, error) {
+	project := new(repository)
+	path := fmt.Sprintf("api/v4/projects/%s", encode(repo))
+	res, err := s.client.do(ctx, "GET", path, nil, project)
+	if err != nil {
+		return nil, res, err
+	}
+	in := &runnerInput{
+		RunnerType: "project_type",
+		ProjectID:  project.ID,
+	}
+	out := new(runnerToken)
+	res, err = s.client.do(ctx, "POST", "api/v4/user/runners", in, out)
+	return &scm.RunnerToken{Token: out.Token, Expires: out.TokenExpiresAt}, res, err
+}
+
+func (s *runnerService) Delete(ctx context.Context, repo string, id int) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v4/runners/%d", id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func convertRunnerList(from []*runner) []*scm.Runner {
+	to := []*scm.Runner{}
+	for _, v := range from {
+		to = append(to, convertRunner(v))
+	}
+	return to
+}
+
+func convertRunner(from *runner) *scm.Runner {
+	return &scm.Runner{
+		ID:     from.ID,
+		Name:   from.Name,
+		Desc:   from.Description,
+		Status: from.Status,
+		Paused: from.Paused,
+	}
+}
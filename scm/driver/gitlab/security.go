@@ -0,0 +1,108 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type securityService struct {
+	client *wrapper
+}
+
+type vulnerability struct {
+	ID          int       `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	State       string    `json:"state"`
+	Severity    string    `json:"severity"`
+	ReportType  string    `json:"report_type"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	DismissedAt time.Time `json:"dismissed_at"`
+}
+
+type vulnerabilityDismissInput struct {
+	Comment         string `json:"comment,omitempty"`
+	DismissalReason string `json:"dismissal_reason,omitempty"`
+}
+
+// ListAlerts returns the vulnerabilities of the project. The
+// state and severity filters are not supported by the API.
+func (s *securityService) ListAlerts(ctx context.Context, repo string, opts scm.SecurityAlertListOptions) ([]*scm.SecurityAlert, *scm.Response, error) {
+	if opts.Kind != "" && opts.Kind != scm.AlertKindVulnerability {
+		return nil, nil, notSupported("Security", "ListAlerts")
+	}
+	path := fmt.Sprintf("api/v4/projects/%s/vulnerabilities?%s", encode(repo), encodeListOptions(scm.ListOptions{Page: opts.Page, Size: opts.Size}))
+	out := []*vulnerability{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertVulnerabilityList(out), res, err
+}
+
+func (s *securityService) FindAlert(ctx context.Context, repo string, kind scm.AlertKind, number int) (*scm.SecurityAlert, *scm.Response, error) {
+	if kind != scm.AlertKindVulnerability {
+		return nil, nil, notSupported("Security", "FindAlert")
+	}
+	path := fmt.Sprintf("api/v4/vulnerabilities/%d", number)
+	out := new(vulnerability)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertVulnerability(out), res, err
+}
+
+func (s *securityService) DismissAlert(ctx context.Context, repo string, kind scm.AlertKind, number int, input *scm.SecurityAlertDismissInput) (*scm.SecurityAlert, *scm.Response, error) {
+	if kind != scm.AlertKindVulnerability {
+		return nil, nil, notSupported("Security", "DismissAlert")
+	}
+	path := fmt.Sprintf("api/v4/vulnerabilities/%d/dismiss", number)
+	in := &vulnerabilityDismissInput{
+		Comment:         input.Comment,
+		DismissalReason: convertVulnerabilityDismissReason(input.Reason),
+	}
+	out := new(vulnerability)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertVulnerability(out), res, err
+}
+
+func convertVulnerabilityDismissReason(from string) string {
+	switch from {
+	case scm.DismissReasonWontFix:
+		return "acceptable_risk"
+	default:
+		return from
+	}
+}
+
+func convertVulnerabilityList(from []*vulnerability) []*scm.SecurityAlert {
+	to := []*scm.SecurityAlert{}
+	for _, v := range from {
+		to = append(to, convertVulnerability(v))
+	}
+	return to
+}
+
+func convertVulnerability(from *vulnerability) *scm.SecurityAlert {
+	return &scm.SecurityAlert{
+		Number:    from.ID,
+		Kind:      scm.AlertKindVulnerability,
+		State:     convertVulnerabilityState(from.State),
+		Severity:  scm.ToSeverity(from.Severity),
+		Title:     from.Title,
+		Desc:      from.Description,
+		Created:   from.CreatedAt,
+		Updated:   from.UpdatedAt,
+		Dismissed: from.DismissedAt,
+	}
+}
+
+func convertVulnerabilityState(from string) string {
+	switch from {
+	case "detected", "confirmed":
+		return scm.AlertStateOpen
+	case "resolved":
+		return scm.AlertStateFixed
+	default:
+		return from
+	}
+}
@@ -0,0 +1,87 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestSecurityListAlerts(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/vulnerabilities").
+		MatchParam("page", "1").
+		MatchParam("per_page", "20").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/vulnerabilities.json")
+
+	client := NewDefault()
+	got, res, err := client.Security.ListAlerts(context.Background(), "diaspora/diaspora", scm.SecurityAlertListOptions{Page: 1, Size: 20})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.SecurityAlert{}
+	raw, _ := ioutil.ReadFile("testdata/vulnerabilities.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestSecurityListAlerts_NotSupported(t *testing.T) {
+	client := NewDefault()
+	_, _, err := client.Security.ListAlerts(context.Background(), "diaspora/diaspora", scm.SecurityAlertListOptions{Kind: scm.AlertKindDependabot})
+	if !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error, got %v", err)
+	}
+}
+
+func TestSecurityDismissAlert(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/vulnerabilities/2/dismiss").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/vulnerability_dismissed.json")
+
+	input := &scm.SecurityAlertDismissInput{
+		Reason: scm.DismissReasonFalsePositive,
+	}
+
+	client := NewDefault()
+	got, res, err := client.Security.DismissAlert(context.Background(), "diaspora/diaspora", scm.AlertKindVulnerability, 2, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.SecurityAlert)
+	raw, _ := ioutil.ReadFile("testdata/vulnerability_dismissed.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/slimm609/go-scm/scm"
 	"github.com/slimm609/go-scm/scm/driver/internal/null"
@@ -19,11 +20,35 @@ type userService struct {
 }
 
 func (s *userService) CreateToken(context.Context, string, string) (*scm.UserToken, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Users", "CreateToken")
 }
 
 func (s *userService) DeleteToken(context.Context, int64) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Users", "DeleteToken")
+}
+
+func (s *userService) TokenInfo(ctx context.Context) (*scm.TokenInfo, *scm.Response, error) {
+	out := new(personalAccessToken)
+	res, err := s.client.do(ctx, "GET", "api/v4/personal_access_tokens/self", nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	info := &scm.TokenInfo{
+		Name:   out.Name,
+		Scopes: out.Scopes,
+	}
+	if out.ExpiresAt.Valid && out.ExpiresAt.String != "" {
+		// tokens expire at midnight UTC on the expiry date.
+		info.Expires, err = time.Parse("2006-01-02", out.ExpiresAt.String)
+		if err != nil {
+			return nil, res, err
+		}
+	}
+	return info, res, nil
+}
+
+func (s *userService) ListTokens(context.Context, scm.ListOptions) ([]*scm.UserToken, *scm.Response, error) {
+	return nil, nil, notSupported("Users", "ListTokens")
 }
 
 func (s *userService) Find(ctx context.Context) (*scm.User, *scm.Response, error) {
@@ -77,11 +102,19 @@ func (s *userService) FindEmail(ctx context.Context) (string, *scm.Response, err
 }
 
 func (s *userService) ListInvitations(context.Context) ([]*scm.Invitation, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Users", "ListInvitations")
 }
 
 func (s *userService) AcceptInvitation(context.Context, int64) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Users", "AcceptInvitation")
+}
+
+type personalAccessToken struct {
+	ID        int         `json:"id"`
+	Name      string      `json:"name"`
+	Scopes    []string    `json:"scopes"`
+	Active    bool        `json:"active"`
+	ExpiresAt null.String `json:"expires_at"`
 }
 
 type user struct {
@@ -90,15 +123,17 @@ type user struct {
 	Name     string      `json:"name"`
 	Email    null.String `json:"email"`
 	Avatar   string      `json:"avatar_url"`
+	Created  time.Time   `json:"created_at"`
 }
 
 func convertUser(from *user) *scm.User {
 	return &scm.User{
-		ID:     from.ID,
-		Avatar: from.Avatar,
-		Email:  from.Email.String,
-		Login:  from.Username,
-		Name:   from.Name,
+		ID:      from.ID,
+		Avatar:  from.Avatar,
+		Email:   from.Email.String,
+		Login:   from.Username,
+		Name:    from.Name,
+		Created: from.Created.UTC(),
 	}
 }
 
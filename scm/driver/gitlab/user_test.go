@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/slimm609/go-scm/scm"
 
@@ -143,3 +144,35 @@ func TestUserEmailFind(t *testing.T) {
 	t.Run("Request", testRequest(res))
 	t.Run("Rate", testRate(res))
 }
+
+func TestUserTokenInfo(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/personal_access_tokens/self").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/token_self.json")
+
+	client := NewDefault()
+	got, res, err := client.Users.TokenInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.TokenInfo)
+	raw, _ := ioutil.ReadFile("testdata/token_self.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if got.ExpiresWithin(time.Hour) {
+		t.Errorf("Want token not expiring within the hour")
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
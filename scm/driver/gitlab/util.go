@@ -39,6 +39,26 @@ func encodeMemberListOptions(opts scm.ListOptions) string {
 	return params.Encode()
 }
 
+func encodeCommentListOptions(opts scm.CommentListOptions) string {
+	params := url.Values{}
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Size != 0 {
+		params.Set("per_page", strconv.Itoa(opts.Size))
+	}
+	switch opts.Sort {
+	case scm.CommentSortCreated:
+		params.Set("order_by", "created_at")
+	case scm.CommentSortUpdated:
+		params.Set("order_by", "updated_at")
+	}
+	if opts.Direction != "" {
+		params.Set("sort", opts.Direction)
+	}
+	return params.Encode()
+}
+
 func encodeCommitListOptions(opts scm.CommitListOptions) string {
 	params := url.Values{}
 	if opts.Page != 0 {
@@ -68,6 +88,9 @@ func encodeIssueListOptions(opts scm.IssueListOptions) string {
 	} else if opts.Open {
 		params.Set("state", "opened")
 	}
+	if !opts.UpdatedSince.IsZero() {
+		params.Set("updated_after", opts.UpdatedSince.Format(scm.SearchTimeFormat))
+	}
 	return params.Encode()
 }
 
@@ -157,3 +180,23 @@ func gitlabStateToSCMState(glState string) string {
 		return "closed"
 	}
 }
+
+// countDiffLines returns the number of lines added and
+// removed by the hunks of a unified diff.
+func countDiffLines(diff string) (additions, deletions int) {
+	inHunk := false
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case !inHunk:
+		case strings.HasPrefix(line, "+"):
+			additions++
+		case strings.HasPrefix(line, "-"):
+			deletions++
+		case strings.HasPrefix(line, "diff "):
+			inHunk = false
+		}
+	}
+	return additions, deletions
+}
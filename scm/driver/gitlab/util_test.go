@@ -47,6 +47,20 @@ func Test_encodeCommitListOptions(t *testing.T) {
 	}
 }
 
+func Test_encodeCommentListOptions(t *testing.T) {
+	opts := scm.CommentListOptions{
+		Page:      10,
+		Size:      30,
+		Sort:      scm.CommentSortUpdated,
+		Direction: scm.SortAscending,
+	}
+	want := "order_by=updated_at&page=10&per_page=30&sort=asc"
+	got := encodeCommentListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded comment list options %q, got %q", want, got)
+	}
+}
+
 func Test_encodeIssueListOptions(t *testing.T) {
 	opts := scm.IssueListOptions{
 		Page:   10,
@@ -133,3 +147,15 @@ func Test_encodePullRequestListOptions_Closed(t *testing.T) {
 		t.Errorf("Want encoded pr list options %q, got %q", want, got)
 	}
 }
+
+func Test_countDiffLines(t *testing.T) {
+	t.Parallel()
+	diff := "@@ -1,3 +1,4 @@\n-1.9.7\n+1.9.8\n+1.9.9\n context\n--- removed line\n"
+	additions, deletions := countDiffLines(diff)
+	if got, want := additions, 2; got != want {
+		t.Errorf("Want %d additions, got %d", want, got)
+	}
+	if got, want := deletions, 2; got != want {
+		t.Errorf("Want %d deletions, got %d", want, got)
+	}
+}
@@ -0,0 +1,137 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type variableService struct {
+	client *wrapper
+}
+
+type variable struct {
+	Key              string `json:"key"`
+	Value            string `json:"value"`
+	Masked           bool   `json:"masked"`
+	EnvironmentScope string `json:"environment_scope"`
+}
+
+type variableInput struct {
+	Key              string `json:"key,omitempty"`
+	Value            string `json:"value"`
+	Masked           bool   `json:"masked"`
+	EnvironmentScope string `json:"environment_scope,omitempty"`
+}
+
+// List returns the variables of the project or group.
+// Masked variables are secrets and are not listed.
+func (s *variableService) List(ctx context.Context, scope scm.VariableScope, opts scm.ListOptions) ([]*scm.Variable, *scm.Response, error) {
+	base, err := variablePath(scope)
+	if err != nil {
+		return nil, nil, err
+	}
+	path := fmt.Sprintf("%s?%s", base, encodeListOptions(opts))
+	out := []*variable{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertVariableList(out, scope), res, err
+}
+
+func (s *variableService) Find(ctx context.Context, scope scm.VariableScope, name string) (*scm.Variable, *scm.Response, error) {
+	base, err := variablePath(scope)
+	if err != nil {
+		return nil, nil, err
+	}
+	path := fmt.Sprintf("%s/%s%s", base, name, encodeEnvironmentScope(scope))
+	out := new(variable)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertVariable(out), res, err
+}
+
+func (s *variableService) Create(ctx context.Context, scope scm.VariableScope, input *scm.VariableInput) (*scm.Response, error) {
+	path, err := variablePath(scope)
+	if err != nil {
+		return nil, err
+	}
+	in := &variableInput{
+		Key:              input.Name,
+		Value:            input.Value,
+		EnvironmentScope: scope.Environment,
+	}
+	return s.client.do(ctx, "POST", path, in, nil)
+}
+
+func (s *variableService) Update(ctx context.Context, scope scm.VariableScope, input *scm.VariableInput) (*scm.Response, error) {
+	base, err := variablePath(scope)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s%s", base, input.Name, encodeEnvironmentScope(scope))
+	in := &variableInput{
+		Value:            input.Value,
+		EnvironmentScope: scope.Environment,
+	}
+	return s.client.do(ctx, "PUT", path, in, nil)
+}
+
+func (s *variableService) Delete(ctx context.Context, scope scm.VariableScope, name string) (*scm.Response, error) {
+	base, err := variablePath(scope)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s%s", base, name, encodeEnvironmentScope(scope))
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func variablePath(scope scm.VariableScope) (string, error) {
+	switch {
+	case scope.Repo != "" && scope.Org != "":
+		return "", scm.ErrInvalidVariableScope
+	case scope.Repo != "":
+		return fmt.Sprintf("api/v4/projects/%s/variables", encode(scope.Repo)), nil
+	case scope.Org != "":
+		return fmt.Sprintf("api/v4/groups/%s/variables", encode(scope.Org)), nil
+	default:
+		return "", scm.ErrInvalidVariableScope
+	}
+}
+
+// encodeEnvironmentScope returns the query selecting the
+// variable of the environment, since a key may be defined
+// once per environment.
+func encodeEnvironmentScope(scope scm.VariableScope) string {
+	if scope.Environment == "" {
+		return ""
+	}
+	params := url.Values{}
+	params.Set("filter[environment_scope]", scope.Environment)
+	return "?" + params.Encode()
+}
+
+func convertVariableList(from []*variable, scope scm.VariableScope) []*scm.Variable {
+	to := []*scm.Variable{}
+	for _, v := range from {
+		if v.Masked {
+			continue
+		}
+		if scope.Environment != "" && v.EnvironmentScope != scope.Environment {
+			continue
+		}
+		to = append(to, convertVariable(v))
+	}
+	return to
+}
+
+func convertVariable(from *variable) *scm.Variable {
+	to := &scm.Variable{
+		Name:        from.Key,
+		Value:       from.Value,
+		Environment: from.EnvironmentScope,
+	}
+	if to.Environment == "*" {
+		to.Environment = ""
+	}
+	return to
+}
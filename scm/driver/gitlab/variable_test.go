@@ -0,0 +1,178 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestVariableList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/variables").
+		MatchParam("page", "1").
+		MatchParam("per_page", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeaders(mockPageHeaders).
+		File("testdata/variables.json")
+
+	client := NewDefault()
+	got, res, err := client.Variables.List(context.Background(), scm.VariableScope{Repo: "diaspora/diaspora"}, scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Variable{}
+	raw, _ := ioutil.ReadFile("testdata/variables.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+	t.Run("Page", testPage(res))
+}
+
+func TestVariableList_Environment(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/groups/diaspora/variables").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/variables.json")
+
+	client := NewDefault()
+	scope := scm.VariableScope{Org: "diaspora", Environment: "production"}
+	got, _, err := client.Variables.List(context.Background(), scope, scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(got) != 1 || got[0].Name != "TEST_VARIABLE_2" {
+		t.Errorf("Want only the production variable, got %+v", got)
+	}
+}
+
+func TestVariableFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/variables/TEST_VARIABLE_2").
+		MatchParam("filter[environment_scope]", "production").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/variable.json")
+
+	client := NewDefault()
+	scope := scm.VariableScope{Repo: "diaspora/diaspora", Environment: "production"}
+	got, res, err := client.Variables.Find(context.Background(), scope, "TEST_VARIABLE_2")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Variable)
+	raw, _ := ioutil.ReadFile("testdata/variable.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestVariableCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/variables").
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/variable.json")
+
+	client := NewDefault()
+	scope := scm.VariableScope{Repo: "diaspora/diaspora", Environment: "production"}
+	input := &scm.VariableInput{Name: "TEST_VARIABLE_2", Value: "TEST_2"}
+	res, err := client.Variables.Create(context.Background(), scope, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := res.Status, 201; got != want {
+		t.Errorf("Want response status %d, got %d", want, got)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestVariableUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/groups/diaspora/variables/TEST_VARIABLE_1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/variable.json")
+
+	client := NewDefault()
+	input := &scm.VariableInput{Name: "TEST_VARIABLE_1", Value: "updated"}
+	res, err := client.Variables.Update(context.Background(), scm.VariableScope{Org: "diaspora"}, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := res.Status, 200; got != want {
+		t.Errorf("Want response status %d, got %d", want, got)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestVariableDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Delete("/api/v4/projects/diaspora/diaspora/variables/TEST_VARIABLE_1").
+		Reply(204).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	res, err := client.Variables.Delete(context.Background(), scm.VariableScope{Repo: "diaspora/diaspora"}, "TEST_VARIABLE_1")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := res.Status, 204; got != want {
+		t.Errorf("Want response status %d, got %d", want, got)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
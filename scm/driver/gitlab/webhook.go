@@ -6,26 +6,28 @@ package gitlab
 
 import (
 	"context"
-	"crypto/subtle"
 	"encoding/json"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/slimm609/go-scm/pkg/hmac"
 	"github.com/slimm609/go-scm/scm"
 )
 
 type webhookService struct {
 	client *wrapper
+	opts   scm.WebhookServiceOptions
 }
 
 func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
-	data, err := ioutil.ReadAll(
-		io.LimitReader(req.Body, 10000000),
-	)
+	return s.ParseMulti(req, scm.Secrets(fn))
+}
+
+func (s *webhookService) ParseMulti(req *http.Request, fn scm.MultiSecretFunc) (scm.Webhook, error) {
+	_, data, err := s.opts.ReadBody(req)
 	if err != nil {
 		return nil, err
 	}
@@ -39,26 +41,37 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 		return nil, scm.UnknownWebhook{Event: event}
 	case "Merge Request Hook":
 		hook, err = parsePullRequestHook(data)
+	case "Milestone Hook":
+		hook, err = parseMilestoneHook(data)
 	case "Note Hook":
 		hook, err = s.parseCommentHook(data)
+	case "Pipeline Hook":
+		hook, err = parsePipelineHook(data)
+	case "System Hook":
+		hook, err = parseSystemHook(data)
 	default:
 		return nil, scm.UnknownWebhook{Event: event}
 	}
 	if err != nil {
 		return nil, err
 	}
+	scm.SetGUID(hook, req.Header.Get("X-Gitlab-Event-UUID"))
+	if s.opts.RawPayload {
+		scm.SetRaw(hook, data)
+	}
 
 	// get the gitlab shared token to verify the payload
 	// authenticity. If no key is provided, no validation
 	// is performed.
-	token, err := fn(hook)
+	tokens, err := fn(hook)
 	if err != nil {
 		return hook, err
-	} else if token == "" {
+	} else if len(tokens) == 0 {
 		return hook, nil
 	}
 
-	if subtle.ConstantTimeCompare([]byte(req.Header.Get("X-Gitlab-Token")), []byte(token)) == 0 {
+	header := req.Header.Get("X-Gitlab-Token")
+	if !scm.ValidateAny(tokens, func(token string) bool { return hmac.Equal(header, token) }) {
 		return hook, scm.ErrSignatureInvalid
 	}
 	return hook, nil
@@ -130,11 +143,62 @@ func (s *webhookService) parseCommentHook(data []byte) (scm.Webhook, error) {
 	}
 }
 
+func parseMilestoneHook(data []byte) (scm.Webhook, error) {
+	src := new(milestoneHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	return convertMilestoneHook(src), nil
+}
+
+func parsePipelineHook(data []byte) (scm.Webhook, error) {
+	src := new(pipelineHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	return convertPipelineHook(src), nil
+}
+
+// parseSystemHook parses an instance-wide system hook. Push,
+// tag push and merge request system hooks share the payloads of
+// the equivalent project hooks.
+func parseSystemHook(data []byte) (scm.Webhook, error) {
+	src := new(systemHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case src.ObjectKind == "push", src.ObjectKind == "tag_push":
+		return parsePushHook(data)
+	case src.ObjectKind == "merge_request":
+		return parsePullRequestHook(data)
+	case src.EventName == "repository_update":
+		return convertSystemRepositoryUpdateHook(src), nil
+	case strings.HasPrefix(src.EventName, "project_"):
+		return convertSystemProjectHook(src), nil
+	case strings.HasPrefix(src.EventName, "group_"):
+		return convertSystemGroupHook(src), nil
+	case strings.HasSuffix(src.EventName, "_team"), strings.HasSuffix(src.EventName, "_group"):
+		return convertSystemMemberHook(src), nil
+	case strings.HasPrefix(src.EventName, "user_"):
+		return convertSystemUserHook(src), nil
+	default:
+		return nil, scm.UnknownWebhook{Event: src.EventName}
+	}
+}
+
 func convertPushHook(src *pushHook) *scm.PushHook {
 	repo := *convertRepositoryHook(&src.Project)
 	dst := &scm.PushHook{
-		Ref:  scm.ExpandRef(src.Ref, "refs/heads/"),
-		Repo: repo,
+		Ref:     scm.ExpandRef(src.Ref, "refs/heads/"),
+		Repo:    repo,
+		Before:  src.Before,
+		After:   src.After,
+		Created: src.Before == scm.EmptyCommit,
+		Deleted: src.After == scm.EmptyCommit,
 		Commit: scm.Commit{
 			Sha:     src.CheckoutSha,
 			Message: "", // NOTE this is set below
@@ -166,6 +230,69 @@ func convertPushHook(src *pushHook) *scm.PushHook {
 	return dst
 }
 
+// convertMilestoneHook converts a milestone hook. The payload
+// does not include the user that triggered the event.
+func convertMilestoneHook(src *milestoneHook) *scm.MilestoneHook {
+	attrs := src.ObjectAttributes
+	dst := &scm.MilestoneHook{
+		Repo: *convertRepositoryHook(&src.Project),
+	}
+	if m := convertMilestone(&milestone{
+		ID:          attrs.ID,
+		IID:         attrs.IID,
+		Title:       attrs.Title,
+		Description: attrs.Description,
+		State:       attrs.State,
+		DueDate:     attrs.DueDate,
+	}); m != nil {
+		dst.Milestone = *m
+	}
+	switch src.Action {
+	case "create":
+		dst.Action = scm.ActionCreate
+	case "close":
+		dst.Action = scm.ActionClose
+	case "reopen":
+		dst.Action = scm.ActionReopen
+	case "delete":
+		dst.Action = scm.ActionDelete
+	}
+	return dst
+}
+
+// convertPipelineHook converts a pipeline hook to a status hook.
+// The pipeline name is used as the status context, falling back to
+// the pipeline source when unnamed.
+func convertPipelineHook(src *pipelineHook) *scm.StatusHook {
+	attrs := src.ObjectAttributes
+	label := attrs.Name
+	if label == "" {
+		label = attrs.Source
+	}
+	target := attrs.URL
+	if target == "" && src.Project.WebURL != "" {
+		target = fmt.Sprintf("%s/-/pipelines/%d", src.Project.WebURL, attrs.ID)
+	}
+	return &scm.StatusHook{
+		Repo: *convertRepositoryHook(&src.Project),
+		Sender: scm.User{
+			ID:     src.User.ID,
+			Login:  src.User.Username,
+			Name:   src.User.Name,
+			Email:  src.User.Email,
+			Avatar: src.User.AvatarURL,
+		},
+		Sha: attrs.Sha,
+		Status: scm.Status{
+			State:      convertState(attrs.Status),
+			Label:      label,
+			Desc:       attrs.DetailedStatus,
+			Target:     target,
+			PipelineID: attrs.ID,
+		},
+	}
+}
+
 func converBranchHook(src *pushHook) *scm.BranchHook {
 	action := scm.ActionCreate
 	commit := src.After
@@ -228,10 +355,13 @@ func convertPullRequestHook(src *pullRequestHook) *scm.PullRequestHook {
 	case "update":
 		action = scm.ActionUpdate
 	}
-	fork := scm.Join(
-		src.ObjectAttributes.Source.Namespace,
-		src.ObjectAttributes.Source.Name,
-	)
+	fork := ""
+	if src.ObjectAttributes.Source != nil {
+		fork = scm.Join(
+			src.ObjectAttributes.Source.Namespace,
+			src.ObjectAttributes.Source.Name,
+		)
+	}
 	repo := *convertRepositoryHook(&src.Project)
 	ref := fmt.Sprintf("refs/merge-requests/%d/head", src.ObjectAttributes.Iid)
 	sha := src.ObjectAttributes.LastCommit.ID
@@ -248,14 +378,14 @@ func convertPullRequestHook(src *pullRequestHook) *scm.PullRequestHook {
 		Head: scm.PullRequestBranch{
 			Sha: sha,
 		},
-		Source: src.ObjectAttributes.SourceBranch,
-		Target: src.ObjectAttributes.TargetBranch,
-		Fork:   fork,
-		Link:   src.ObjectAttributes.URL,
-		Closed: src.ObjectAttributes.State != "opened",
-		Merged: src.ObjectAttributes.State == "merged",
-		// Created   : src.ObjectAttributes.CreatedAt,
-		// Updated  : src.ObjectAttributes.UpdatedAt, // 2017-12-10 17:01:11 UTC
+		Source:  src.ObjectAttributes.SourceBranch,
+		Target:  src.ObjectAttributes.TargetBranch,
+		Fork:    fork,
+		Link:    src.ObjectAttributes.URL,
+		Closed:  src.ObjectAttributes.State != "opened",
+		Merged:  src.ObjectAttributes.State == "merged",
+		Created: parseTimestamp(src.ObjectAttributes.CreatedAt),
+		Updated: parseTimestamp(src.ObjectAttributes.UpdatedAt),
 		Author: scm.User{
 			Login:  src.User.Username,
 			Name:   src.User.Name,
@@ -265,6 +395,17 @@ func convertPullRequestHook(src *pullRequestHook) *scm.PullRequestHook {
 	}
 	pr.Base.Repo = *convertRepositoryHook(src.ObjectAttributes.Target)
 	pr.Head.Repo = *convertRepositoryHook(src.ObjectAttributes.Source)
+	for _, l := range src.Labels {
+		if l == nil {
+			continue
+		}
+		pr.Labels = append(pr.Labels, &scm.Label{
+			ID:          int64(l.ID),
+			Name:        l.Title,
+			Description: l.Description,
+			Color:       l.Color,
+		})
+	}
 	changes := scm.PullRequestHookChanges{
 		Base: scm.PullRequestHookBranch{
 			Sha: scm.PullRequestHookBranchFrom{
@@ -287,28 +428,31 @@ func convertPullRequestHook(src *pullRequestHook) *scm.PullRequestHook {
 }
 
 func (s *webhookService) convertMergeRequestCommentHook(src *commentHook) *scm.PullRequestCommentHook {
-	user, _, _ := s.client.Users.FindLogin(context.TODO(), strconv.Itoa(src.ObjectAttributes.AuthorID))
-	author, res, err := s.client.Users.FindLogin(context.TODO(), strconv.Itoa(src.MergeRequest.AuthorID))
-	if err != nil {
-		fmt.Printf("author error: %s\n", err)
-		fmt.Printf("error status: %d\n", res.Status)
-		b, _ := ioutil.ReadAll(res.Body)
-		fmt.Printf("body: %s\n", string(b))
+	// the users are looked up with the client, and are empty
+	// if they cannot be found or the service has no client.
+	user, author := new(scm.User), new(scm.User)
+	if s.client != nil {
+		if found, _, err := s.client.Users.FindLogin(context.TODO(), strconv.Itoa(src.ObjectAttributes.AuthorID)); err == nil {
+			user = found
+		}
+		if found, _, err := s.client.Users.FindLogin(context.TODO(), strconv.Itoa(src.MergeRequest.AuthorID)); err == nil {
+			author = found
+		}
 	}
 
-	fork := scm.Join(
-		src.MergeRequest.Source.Namespace,
-		src.MergeRequest.Source.Name,
-	)
+	fork := ""
+	if src.MergeRequest.Source != nil {
+		fork = scm.Join(
+			src.MergeRequest.Source.Namespace,
+			src.MergeRequest.Source.Name,
+		)
+	}
 
 	repo := *convertRepositoryHook(&src.Project)
 
 	ref := fmt.Sprintf("refs/merge-requests/%d/head", src.MergeRequest.Iid)
 	sha := src.MergeRequest.LastCommit.ID
 
-	// Mon Jan 2 15:04:05 -0700 MST 2006
-	prCreatedAt, _ := time.Parse("2006-01-02 15:04:05 MST", src.MergeRequest.CreatedAt)
-	prUpdatedAt, _ := time.Parse("2006-01-02 15:04:05 MST", src.MergeRequest.UpdatedAt)
 	pr := scm.PullRequest{
 		Number: src.MergeRequest.Iid,
 		Title:  src.MergeRequest.Title,
@@ -329,16 +473,13 @@ func (s *webhookService) convertMergeRequestCommentHook(src *commentHook) *scm.P
 		Link:    src.MergeRequest.URL,
 		Closed:  src.MergeRequest.State != "opened",
 		Merged:  src.MergeRequest.State == "merged",
-		Created: prCreatedAt,
-		Updated: prUpdatedAt, // 2017-12-10 17:01:11 UTC
+		Created: parseTimestamp(src.MergeRequest.CreatedAt),
+		Updated: parseTimestamp(src.MergeRequest.UpdatedAt),
 		Author:  *author,
 	}
 	pr.Base.Repo = *convertRepositoryHook(src.MergeRequest.Target)
 	pr.Head.Repo = *convertRepositoryHook(src.MergeRequest.Source)
 
-	createdAt, _ := time.Parse("2006-01-02 15:04:05 MST", src.ObjectAttributes.CreatedAt)
-	updatedAt, _ := time.Parse("2006-01-02 15:04:05 MST", src.ObjectAttributes.UpdatedAt)
-
 	return &scm.PullRequestCommentHook{
 		Action:      scm.ActionCreate,
 		Repo:        repo,
@@ -347,14 +488,31 @@ func (s *webhookService) convertMergeRequestCommentHook(src *commentHook) *scm.P
 			ID:      src.ObjectAttributes.ID,
 			Body:    src.ObjectAttributes.Note,
 			Author:  *user, // TODO: is the user the author id ??
-			Created: createdAt,
-			Updated: updatedAt,
+			Created: parseTimestamp(src.ObjectAttributes.CreatedAt),
+			Updated: parseTimestamp(src.ObjectAttributes.UpdatedAt),
 		},
 		Sender: *user,
 	}
 }
 
+// parseTimestamp parses a webhook timestamp in UTC. Older
+// GitLab versions send "2017-12-10 17:01:11 UTC" instead of
+// RFC 3339. The zero time is returned if neither matches.
+func parseTimestamp(s string) time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05 MST", "2006-01-02 15:04:05 -0700"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC()
+		}
+	}
+	return time.Time{}
+}
+
+// convertRepositoryHook converts the project of a payload. The
+// repository is empty if the payload omits the project.
 func convertRepositoryHook(from *project) *scm.Repository {
+	if from == nil {
+		return new(scm.Repository)
+	}
 	namespace, name := scm.Split(from.PathWithNamespace)
 	return &scm.Repository{
 		ID:        strconv.Itoa(from.ID),
@@ -369,7 +527,150 @@ func convertRepositoryHook(from *project) *scm.Repository {
 	}
 }
 
+func convertSystemProjectHook(src *systemHook) *scm.SystemProjectHook {
+	createdAt := parseTimestamp(src.CreatedAt)
+	updatedAt := parseTimestamp(src.UpdatedAt)
+	namespace, name := scm.Split(src.PathWithNamespace)
+	return &scm.SystemProjectHook{
+		Event: src.EventName,
+		Repo: scm.Repository{
+			ID:        strconv.Itoa(src.ProjectID),
+			Namespace: namespace,
+			Name:      name,
+			FullName:  src.PathWithNamespace,
+			Private:   src.ProjectVisibility == "private",
+			Created:   createdAt,
+			Updated:   updatedAt,
+		},
+		OldFullName: src.OldPathWithNamespace,
+		Owner: scm.User{
+			Name:  src.OwnerName,
+			Email: src.OwnerEmail,
+		},
+	}
+}
+
+func convertSystemUserHook(src *systemHook) *scm.SystemUserHook {
+	createdAt := parseTimestamp(src.CreatedAt)
+	updatedAt := parseTimestamp(src.UpdatedAt)
+	return &scm.SystemUserHook{
+		Event: src.EventName,
+		User: scm.User{
+			ID:      src.UserID,
+			Login:   src.Username,
+			Name:    src.Name,
+			Email:   src.Email,
+			Created: createdAt,
+			Updated: updatedAt,
+		},
+		OldLogin: src.OldUsername,
+	}
+}
+
+func convertSystemGroupHook(src *systemHook) *scm.SystemGroupHook {
+	return &scm.SystemGroupHook{
+		Event:       src.EventName,
+		ID:          src.GroupID,
+		Name:        src.Name,
+		Path:        src.Path,
+		FullPath:    src.FullPath,
+		OldFullPath: src.OldFullPath,
+	}
+}
+
+func convertSystemMemberHook(src *systemHook) *scm.SystemMemberHook {
+	dst := &scm.SystemMemberHook{
+		Event: src.EventName,
+		User: scm.User{
+			ID:    src.UserID,
+			Login: src.UserUsername,
+			Name:  src.UserName,
+			Email: src.UserEmail,
+		},
+		Access: src.AccessLevel,
+	}
+	if strings.HasSuffix(src.EventName, "_group") {
+		dst.Access = src.GroupAccess
+		dst.Group = src.GroupPath
+		return dst
+	}
+	namespace, name := scm.Split(src.ProjectPathWithNamespace)
+	dst.Repo = scm.Repository{
+		ID:        strconv.Itoa(src.ProjectID),
+		Namespace: namespace,
+		Name:      name,
+		FullName:  src.ProjectPathWithNamespace,
+		Private:   src.ProjectVisibility == "private",
+	}
+	return dst
+}
+
+func convertSystemRepositoryUpdateHook(src *systemHook) *scm.SystemRepositoryUpdateHook {
+	dst := &scm.SystemRepositoryUpdateHook{
+		Sender: scm.User{
+			ID:     src.UserID,
+			Name:   src.UserName,
+			Email:  src.UserEmail,
+			Avatar: src.UserAvatar,
+		},
+		Changes: []scm.RefChange{},
+	}
+	if src.Project != nil {
+		dst.Repo = *convertRepositoryHook(src.Project)
+		dst.Repo.ID = strconv.Itoa(src.ProjectID)
+	}
+	for _, change := range src.Changes {
+		dst.Changes = append(dst.Changes, scm.RefChange{
+			Ref:    change.Ref,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+	return dst
+}
+
 type (
+	// systemHook is the union of the GitLab system hook
+	// payloads, discriminated by the event name.
+	systemHook struct {
+		ObjectKind  string `json:"object_kind"`
+		EventName   string `json:"event_name"`
+		CreatedAt   string `json:"created_at"`
+		UpdatedAt   string `json:"updated_at"`
+		Name        string `json:"name"`
+		Path        string `json:"path"`
+		FullPath    string `json:"full_path"`
+		OldFullPath string `json:"old_full_path"`
+		Email       string `json:"email"`
+		Username    string `json:"username"`
+		OldUsername string `json:"old_username"`
+
+		PathWithNamespace    string `json:"path_with_namespace"`
+		OldPathWithNamespace string `json:"old_path_with_namespace"`
+		ProjectID            int    `json:"project_id"`
+		ProjectVisibility    string `json:"project_visibility"`
+		OwnerName            string `json:"owner_name"`
+		OwnerEmail           string `json:"owner_email"`
+
+		ProjectPathWithNamespace string `json:"project_path_with_namespace"`
+		AccessLevel              string `json:"access_level"`
+		GroupID                  int    `json:"group_id"`
+		GroupPath                string `json:"group_path"`
+		GroupAccess              string `json:"group_access"`
+		UserID                   int    `json:"user_id"`
+		UserName                 string `json:"user_name"`
+		UserUsername             string `json:"user_username"`
+		UserEmail                string `json:"user_email"`
+		UserAvatar               string `json:"user_avatar"`
+
+		Project *project `json:"project"`
+		Changes []struct {
+			Before string `json:"before"`
+			After  string `json:"after"`
+			Ref    string `json:"ref"`
+		} `json:"changes"`
+	}
+
 	project struct {
 		ID                int         `json:"id"`
 		Name              string      `json:"name"`
@@ -429,6 +730,44 @@ type (
 		} `json:"repository"`
 	}
 
+	milestoneHook struct {
+		ObjectKind       string  `json:"object_kind"`
+		Action           string  `json:"action"`
+		Project          project `json:"project"`
+		ObjectAttributes struct {
+			ID          int     `json:"id"`
+			IID         int     `json:"iid"`
+			Title       string  `json:"title"`
+			Description string  `json:"description"`
+			State       string  `json:"state"`
+			DueDate     isoTime `json:"due_date"`
+		} `json:"object_attributes"`
+	}
+
+	pipelineHook struct {
+		ObjectKind       string `json:"object_kind"`
+		ObjectAttributes struct {
+			ID             int    `json:"id"`
+			Name           string `json:"name"`
+			Ref            string `json:"ref"`
+			Tag            bool   `json:"tag"`
+			Sha            string `json:"sha"`
+			BeforeSha      string `json:"before_sha"`
+			Source         string `json:"source"`
+			Status         string `json:"status"`
+			DetailedStatus string `json:"detailed_status"`
+			URL            string `json:"url"`
+		} `json:"object_attributes"`
+		User struct {
+			ID        int    `json:"id"`
+			Name      string `json:"name"`
+			Username  string `json:"username"`
+			AvatarURL string `json:"avatar_url"`
+			Email     string `json:"email"`
+		} `json:"user"`
+		Project project `json:"project"`
+	}
+
 	commentHook struct {
 		ObjectKind string `json:"object_kind"`
 		User       struct {
@@ -661,6 +1000,14 @@ type (
 		} `json:"repository"`
 	}
 
+	// gitlab label as included in webhook payloads.
+	hookLabel struct {
+		ID          int    `json:"id"`
+		Title       string `json:"title"`
+		Color       string `json:"color"`
+		Description string `json:"description"`
+	}
+
 	pullRequestHook struct {
 		ObjectKind string `json:"object_kind"`
 		User       struct {
@@ -718,7 +1065,7 @@ type (
 			Action              string      `json:"action"`
 			OldRev              string      `json:"oldrev"`
 		} `json:"object_attributes"`
-		Labels  []interface{} `json:"labels"`
+		Labels  []*hookLabel `json:"labels"`
 		Changes struct {
 		} `json:"changes"`
 		Repository struct {
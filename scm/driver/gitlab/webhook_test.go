@@ -12,6 +12,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/slimm609/go-scm/scm"
 
@@ -109,6 +110,63 @@ func TestWebhooks(t *testing.T) {
 			after:  "testdata/webhooks/pull_request_merge.json.golden",
 			obj:    new(scm.PullRequestHook),
 		},
+		// milestone hooks
+		{
+			event:  "Milestone Hook",
+			before: "testdata/webhooks/milestone_close.json",
+			after:  "testdata/webhooks/milestone_close.json.golden",
+			obj:    new(scm.MilestoneHook),
+		},
+		// pipeline hooks
+		{
+			event:  "Pipeline Hook",
+			before: "testdata/webhooks/pipeline.json",
+			after:  "testdata/webhooks/pipeline.json.golden",
+			obj:    new(scm.StatusHook),
+		},
+		// system hooks
+		{
+			event:  "System Hook",
+			before: "testdata/webhooks/push.json",
+			after:  "testdata/webhooks/push.json.golden",
+			obj:    new(scm.PushHook),
+		},
+		{
+			event:  "System Hook",
+			before: "testdata/webhooks/system_project_rename.json",
+			after:  "testdata/webhooks/system_project_rename.json.golden",
+			obj:    new(scm.SystemProjectHook),
+		},
+		{
+			event:  "System Hook",
+			before: "testdata/webhooks/system_user_create.json",
+			after:  "testdata/webhooks/system_user_create.json.golden",
+			obj:    new(scm.SystemUserHook),
+		},
+		{
+			event:  "System Hook",
+			before: "testdata/webhooks/system_group_rename.json",
+			after:  "testdata/webhooks/system_group_rename.json.golden",
+			obj:    new(scm.SystemGroupHook),
+		},
+		{
+			event:  "System Hook",
+			before: "testdata/webhooks/system_user_add_to_team.json",
+			after:  "testdata/webhooks/system_user_add_to_team.json.golden",
+			obj:    new(scm.SystemMemberHook),
+		},
+		{
+			event:  "System Hook",
+			before: "testdata/webhooks/system_user_add_to_group.json",
+			after:  "testdata/webhooks/system_user_add_to_group.json.golden",
+			obj:    new(scm.SystemMemberHook),
+		},
+		{
+			event:  "System Hook",
+			before: "testdata/webhooks/system_repository_update.json",
+			after:  "testdata/webhooks/system_repository_update.json.golden",
+			obj:    new(scm.SystemRepositoryUpdateHook),
+		},
 		// pull request comment hooks
 		// {
 		// 	event:  "Note Hook",
@@ -190,6 +248,22 @@ func TestWebhook_SignatureValid(t *testing.T) {
 	}
 }
 
+func TestWebhook_SignatureRotated(t *testing.T) {
+	f, _ := ioutil.ReadFile("testdata/webhooks/branch_delete.json")
+	r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-Gitlab-Event", "Push Hook")
+	r.Header.Set("X-Gitlab-Token", "topsecret")
+	r.Header.Set("X-Request-Id", "ee8d97b4-1479-43f1-9cac-fbbd1b80da55")
+
+	s := new(webhookService)
+	_, err := s.ParseMulti(r, func(scm.Webhook) ([]string, error) {
+		return []string{"newsecret", "topsecret"}, nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 func TestWebhook_SignatureInvalid(t *testing.T) {
 	f, _ := ioutil.ReadFile("testdata/webhooks/branch_delete.json")
 	r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
@@ -218,6 +292,46 @@ func TestWebhook_SignatureMissing(t *testing.T) {
 	}
 }
 
+func TestWebhook_GUID(t *testing.T) {
+	f, _ := ioutil.ReadFile("testdata/webhooks/branch_delete.json")
+	r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-Gitlab-Event", "Push Hook")
+	r.Header.Set("X-Gitlab-Token", "topsecret")
+	r.Header.Set("X-Gitlab-Event-UUID", "13792a34-cac6-4fda-95a8-c58e00a3954e")
+
+	s := new(webhookService)
+	hook, err := s.Parse(r, secretFunc)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := scm.GUID(hook), "13792a34-cac6-4fda-95a8-c58e00a3954e"; got != want {
+		t.Errorf("Want guid %q, got %q", want, got)
+	}
+}
+
 func secretFunc(scm.Webhook) (string, error) {
 	return "topsecret", nil
 }
+
+func TestParseTimestamp(t *testing.T) {
+	want := time.Date(2017, time.December, 10, 17, 1, 11, 0, time.UTC)
+	tests := []string{
+		"2017-12-10 17:01:11 UTC",
+		"2017-12-10T17:01:11Z",
+		"2017-12-10T18:01:11+01:00",
+		"2017-12-10 18:01:11 +0100",
+	}
+	for _, test := range tests {
+		got := parseTimestamp(test)
+		if !got.Equal(want) {
+			t.Errorf("Want time %s for %q, got %s", want, test, got)
+		}
+		if got.Location() != time.UTC {
+			t.Errorf("Want time in UTC for %q, got %s", test, got.Location())
+		}
+	}
+	if got := parseTimestamp(""); !got.IsZero() {
+		t.Errorf("Want zero time for an empty timestamp, got %s", got)
+	}
+}
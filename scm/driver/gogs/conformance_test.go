@@ -0,0 +1,58 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gogs
+
+import (
+	"testing"
+
+	"github.com/slimm609/go-scm/scm/conformance"
+
+	"github.com/h2non/gock"
+)
+
+func TestConformance(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs-does-not-exist$").
+		Persist().
+		Reply(404).
+		Type("text/plain")
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/repo.json")
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/branches/master$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/branch.json")
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/branches$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/branches.json")
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/commits/master$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/commits.json")
+
+	client, _ := New("https://try.gogs.io")
+	conformance.Run(t, conformance.Config{
+		Client: client,
+		Repo:   "gogits/gogs",
+		Branch: "master",
+	})
+}
@@ -6,6 +6,7 @@ package gogs
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/h2non/gock"
@@ -40,10 +41,69 @@ func TestContentFind(t *testing.T) {
 	}
 }
 
+func TestContentExists(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Head("/api/v1/repos/gogits/gogs/raw/master/README.md").
+		Reply(200)
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.Contents.Exists(
+		context.Background(),
+		"gogits/gogs",
+		"README.md",
+		"master",
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	if !got {
+		t.Errorf("Expect file exists")
+	}
+}
+
+func TestContentStat(t *testing.T) {
+	defer gock.Off()
+
+	file := gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/contents/docs/README.md")
+	file.Reply(500)
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/contents/docs").
+		MatchParam("ref", "master").
+		Reply(200).
+		Type("application/json").
+		BodyString(`[{"name":"README.md","path":"docs/README.md","sha":"4a1a5f1d5fb1a1a8c8b4c62e4a1f0a5f4c2e6f3a","type":"file","size":12}]`)
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.Contents.Stat(
+		context.Background(),
+		"gogits/gogs",
+		"docs/README.md",
+		"master",
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := got.Type, "file"; got != want {
+		t.Errorf("Want file Type %q, got %q", want, got)
+	}
+	if got, want := got.Size, 12; got != want {
+		t.Errorf("Want file Size %d, got %d", want, got)
+	}
+	if file.Mock.Done() {
+		t.Errorf("Want the file metadata without requesting its contents")
+	}
+}
+
 func TestContentCreate(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, err := client.Contents.Create(context.Background(), "gogits/gogs", "README.md", nil)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -51,7 +111,7 @@ func TestContentCreate(t *testing.T) {
 func TestContentUpdate(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, err := client.Contents.Update(context.Background(), "gogits/gogs", "README.md", nil)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -59,7 +119,7 @@ func TestContentUpdate(t *testing.T) {
 func TestContentDelete(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, err := client.Contents.Delete(context.Background(), "gogits/gogs", "README.md", "master")
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
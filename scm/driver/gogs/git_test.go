@@ -7,6 +7,7 @@ package gogs
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"testing"
 
@@ -47,7 +48,7 @@ func TestCommitFind(t *testing.T) {
 func TestCommitList(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, _, err := client.Git.ListCommits(context.Background(), "gogits/gogs", scm.CommitListOptions{})
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -55,7 +56,7 @@ func TestCommitList(t *testing.T) {
 func TestChangeList(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, _, err := client.Git.ListChanges(context.Background(), "gogits/gogs", "f05f642b892d59a0a9ef6a31f6c905a24b5db13a", scm.ListOptions{})
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -121,7 +122,7 @@ func TestBranchList(t *testing.T) {
 func TestTagFind(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, _, err := client.Git.FindTag(context.Background(), "gogits/gogs", "v1.0.0")
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -129,7 +130,7 @@ func TestTagFind(t *testing.T) {
 func TestTagList(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, _, err := client.Git.ListTags(context.Background(), "gogits/gogs", scm.ListOptions{})
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
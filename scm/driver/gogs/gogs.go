@@ -19,8 +19,12 @@ import (
 )
 
 // NewWebHookService creates a new instance of the webhook service without the rest of the client
-func NewWebHookService() scm.WebhookService {
-	return &webhookService{nil}
+func NewWebHookService(opts ...scm.WebhookServiceOptions) scm.WebhookService {
+	s := &webhookService{}
+	if len(opts) > 0 {
+		s.opts = opts[0]
+	}
+	return s
 }
 
 // New returns a new Gogs API client.
@@ -36,6 +40,7 @@ func New(uri string) (*scm.Client, error) {
 	client.BaseURL = base
 	// initialize services
 	client.Driver = scm.DriverGogs
+	client.SetCaller(client.do)
 	client.Contents = &contentService{client}
 	client.Git = &gitService{client}
 	client.Issues = &issueService{client}
@@ -45,7 +50,7 @@ func New(uri string) (*scm.Client, error) {
 	client.Repositories = &repositoryService{client}
 	client.Reviews = &reviewService{client}
 	client.Users = &userService{client}
-	client.Webhooks = &webhookService{client}
+	client.Webhooks = &webhookService{client: client}
 	return client.Client, nil
 }
 
@@ -82,7 +87,9 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 
 	// if an error is encountered, unmarshal and return the
 	// error response.
-	if res.Status > 300 {
+	if res.Status == http.StatusNotFound {
+		return res, scm.ErrNotFound
+	} else if res.Status > 300 {
 		return res, errors.New(
 			http.StatusText(res.Status),
 		)
@@ -103,3 +110,9 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	// the json response.
 	return res, json.NewDecoder(res.Body).Decode(out)
 }
+
+// notSupported returns the error of a method of a service
+// the driver does not support.
+func notSupported(service, method string) error {
+	return &scm.NotSupportedError{Driver: scm.DriverGogs, Service: service, Method: method}
+}
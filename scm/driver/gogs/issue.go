@@ -7,6 +7,7 @@ package gogs
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/slimm609/go-scm/scm"
@@ -18,31 +19,31 @@ type issueService struct {
 
 func (s *issueService) Search(context.Context, scm.SearchOptions) ([]*scm.SearchIssue, *scm.Response, error) {
 	// TODO implemment
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Issues", "Search")
 }
 
 func (s *issueService) AssignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "AssignIssue")
 }
 
 func (s *issueService) UnassignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "UnassignIssue")
 }
 
 func (s *issueService) ListEvents(context.Context, string, int, scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Issues", "ListEvents")
 }
 
 func (s *issueService) ListLabels(context.Context, string, int, scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Issues", "ListLabels")
 }
 
 func (s *issueService) AddLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "AddLabel")
 }
 
 func (s *issueService) DeleteLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "DeleteLabel")
 }
 
 func (s *issueService) Find(ctx context.Context, repo string, number int) (*scm.Issue, *scm.Response, error) {
@@ -53,21 +54,27 @@ func (s *issueService) Find(ctx context.Context, repo string, number int) (*scm.
 }
 
 func (s *issueService) FindComment(ctx context.Context, repo string, index, id int) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Issues", "FindComment")
 }
 
-func (s *issueService) List(ctx context.Context, repo string, _ scm.IssueListOptions) ([]*scm.Issue, *scm.Response, error) {
+func (s *issueService) List(ctx context.Context, repo string, opts scm.IssueListOptions) ([]*scm.Issue, *scm.Response, error) {
 	path := fmt.Sprintf("api/v1/repos/%s/issues", repo)
 	out := []*issue{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
-	return convertIssueList(out), res, err
+	return scm.FilterIssues(convertIssueList(out), opts.UpdatedSince), res, err
 }
 
-func (s *issueService) ListComments(ctx context.Context, repo string, index int, _ scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
+func (s *issueService) ListComments(ctx context.Context, repo string, index int, opts scm.CommentListOptions) ([]*scm.Comment, *scm.Response, error) {
 	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/comments", repo, index)
+	if !opts.Since.IsZero() {
+		path += "?since=" + url.QueryEscape(opts.Since.UTC().Format(time.RFC3339))
+	}
 	out := []*issueComment{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
-	return convertIssueCommentList(out), res, err
+	if err != nil {
+		return nil, res, err
+	}
+	return scm.FilterComments(convertIssueCommentList(out), opts), res, nil
 }
 
 func (s *issueService) Create(ctx context.Context, repo string, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
@@ -81,6 +88,34 @@ func (s *issueService) Create(ctx context.Context, repo string, input *scm.Issue
 	return convertIssue(out), res, err
 }
 
+func (s *issueService) Update(ctx context.Context, repo string, number int, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	// gogs issues have a single assignee and the labels
+	// cannot be edited through the api.
+	if len(input.Labels) != 0 || len(input.Assignees) > 1 {
+		return nil, nil, notSupported("Issues", "Update")
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d", repo, number)
+	in := &issueEditInput{
+		Title: input.Title,
+	}
+	if input.Body != "" {
+		in.Body = &input.Body
+	}
+	if len(input.Assignees) == 1 {
+		in.Assignee = &input.Assignees[0]
+	}
+	if input.Milestone != 0 {
+		milestone := int64(input.Milestone)
+		in.Milestone = &milestone
+	}
+	if state := input.TargetState(); state != "" {
+		in.State = &state
+	}
+	out := new(issue)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertIssue(out), res, err
+}
+
 func (s *issueService) CreateComment(ctx context.Context, repo string, index int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
 	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/comments", repo, index)
 	in := &issueCommentInput{
@@ -97,31 +132,61 @@ func (s *issueService) DeleteComment(ctx context.Context, repo string, index, id
 }
 
 func (s *issueService) EditComment(ctx context.Context, repo string, number int, id int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/comments/%d", repo, number, id)
+	in := &issueCommentInput{
+		Body: input.Body,
+	}
+	out := new(issueComment)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertIssueComment(out), res, err
+}
+
+func (s *issueService) MinimizeComment(ctx context.Context, repo string, number int, id int, reason string) (*scm.Response, error) {
+	return nil, notSupported("Issues", "MinimizeComment")
+}
+
+func (s *issueService) UnminimizeComment(ctx context.Context, repo string, number int, id int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "UnminimizeComment")
 }
 
 func (s *issueService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "Close")
 }
 
 func (s *issueService) Reopen(ctx context.Context, repo string, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "Reopen")
 }
 
 func (s *issueService) Lock(ctx context.Context, repo string, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "Lock")
 }
 
 func (s *issueService) Unlock(ctx context.Context, repo string, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Issues", "Unlock")
 }
 
 func (s *issueService) SetMilestone(ctx context.Context, repo string, issueID int, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d", repo, issueID)
+	in := &issueMilestoneInput{Milestone: int64(number)}
+	return s.client.do(ctx, "PATCH", path, in, nil)
 }
 
 func (s *issueService) ClearMilestone(ctx context.Context, repo string, id int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d", repo, id)
+	in := &issueMilestoneInput{Milestone: 0}
+	return s.client.do(ctx, "PATCH", path, in, nil)
+}
+
+func (s *issueService) Pin(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "Pin")
+}
+
+func (s *issueService) Unpin(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "Unpin")
+}
+
+func (s *issueService) ListPinned(ctx context.Context, repo string) ([]*scm.Issue, *scm.Response, error) {
+	return nil, nil, notSupported("Issues", "ListPinned")
 }
 
 //
@@ -137,22 +202,45 @@ type (
 		Title       string    `json:"title"`
 		Body        string    `json:"body"`
 		State       string    `json:"state"`
-		Labels      []string  `json:"labels"`
+		Labels      []*label  `json:"labels"`
 		Comments    int       `json:"comments"`
 		Created     time.Time `json:"created_at"`
 		Updated     time.Time `json:"updated_at"`
 		PullRequest *struct {
-			Merged   bool        `json:"merged"`
-			MergedAt interface{} `json:"merged_at"`
+			Merged   bool      `json:"merged"`
+			MergedAt time.Time `json:"merged_at"`
 		} `json:"pull_request"`
 	}
 
+	// gogs label response object.
+	label struct {
+		ID    int64  `json:"id"`
+		Name  string `json:"name"`
+		Color string `json:"color"`
+		URL   string `json:"url"`
+	}
+
 	// gogs issue request object.
 	issueInput struct {
 		Title string `json:"title"`
 		Body  string `json:"body"`
 	}
 
+	// gogs issue edit request object.
+	issueEditInput struct {
+		Title     string  `json:"title,omitempty"`
+		Body      *string `json:"body,omitempty"`
+		Assignee  *string `json:"assignee,omitempty"`
+		Milestone *int64  `json:"milestone,omitempty"`
+		State     *string `json:"state,omitempty"`
+	}
+
+	// gogs issue milestone request object. A zero
+	// milestone clears the milestone.
+	issueMilestoneInput struct {
+		Milestone int64 `json:"milestone"`
+	}
+
 	// gogs issue comment response object.
 	issueComment struct {
 		ID        int       `json:"id"`
@@ -188,10 +276,24 @@ func convertIssue(from *issue) *scm.Issue {
 		Body:    from.Body,
 		Link:    "", // TODO construct the link to the issue.
 		Closed:  from.State == "closed",
+		Labels:  convertLabels(from.Labels),
 		Author:  *convertUser(&from.User),
-		Created: from.Created,
-		Updated: from.Updated,
+		Created: from.Created.UTC(),
+		Updated: from.Updated.UTC(),
+	}
+}
+
+func convertLabels(from []*label) []*scm.Label {
+	var labels []*scm.Label
+	for _, label := range from {
+		labels = append(labels, &scm.Label{
+			ID:    label.ID,
+			Name:  label.Name,
+			Color: label.Color,
+			URL:   label.URL,
+		})
 	}
+	return labels
 }
 
 func convertIssueCommentList(from []*issueComment) []*scm.Comment {
@@ -207,7 +309,7 @@ func convertIssueComment(from *issueComment) *scm.Comment {
 		ID:      from.ID,
 		Body:    from.Body,
 		Author:  *convertUser(&from.User),
-		Created: from.CreatedAt,
-		Updated: from.UpdatedAt,
+		Created: from.CreatedAt.UTC(),
+		Updated: from.UpdatedAt.UTC(),
 	}
 }
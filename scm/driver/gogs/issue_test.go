@@ -7,6 +7,7 @@ package gogs
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"testing"
 
@@ -102,7 +103,7 @@ func TestIssueCreate(t *testing.T) {
 func TestIssueClose(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, err := client.Issues.Close(context.Background(), "gogits/go-gogs-client", 1)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -110,7 +111,7 @@ func TestIssueClose(t *testing.T) {
 func TestIssueLock(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, err := client.Issues.Lock(context.Background(), "gogits/go-gogs-client", 1)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -118,7 +119,7 @@ func TestIssueLock(t *testing.T) {
 func TestIssueUnlock(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, err := client.Issues.Unlock(context.Background(), "gogits/go-gogs-client", 1)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -130,7 +131,7 @@ func TestIssueUnlock(t *testing.T) {
 func TestIssueCommentFind(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, _, err := client.Issues.FindComment(context.Background(), "gogits/go-gogs-client", 1, 1)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -145,7 +146,7 @@ func TestIssueCommentList(t *testing.T) {
 		File("testdata/comments.json")
 
 	client, _ := New("https://try.gogs.io")
-	got, _, err := client.Issues.ListComments(context.Background(), "gogits/gogs", 1, scm.ListOptions{})
+	got, _, err := client.Issues.ListComments(context.Background(), "gogits/gogs", 1, scm.CommentListOptions{})
 	if err != nil {
 		t.Error(err)
 	}
@@ -189,6 +190,35 @@ func TestIssueCommentCreate(t *testing.T) {
 	}
 }
 
+func TestIssueCommentEdit(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Patch("/api/v1/repos/gogits/gogs/issues/1/comments/74").
+		Reply(200).
+		Type("application/json").
+		File("testdata/comment.json")
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.Issues.EditComment(context.Background(), "gogits/gogs", 1, 74, &scm.CommentInput{Body: "what?"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(scm.Comment)
+	raw, _ := ioutil.ReadFile("testdata/comment.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	if gock.IsPending() {
+		t.Errorf("Pending API calls")
+	}
+}
+
 func TestIssueCommentDelete(t *testing.T) {
 	defer gock.Off()
 
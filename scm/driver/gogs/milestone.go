@@ -2,6 +2,8 @@ package gogs
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/slimm609/go-scm/scm"
 )
@@ -11,21 +13,101 @@ type milestoneService struct {
 }
 
 func (s *milestoneService) Find(ctx context.Context, repo string, id int) (*scm.Milestone, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v1/repos/%s/milestones/%d", repo, id)
+	out := new(milestone)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertMilestone(out), res, err
 }
 
+// List returns the milestones of the repository. Gogs does not
+// paginate milestones, so the page options are ignored.
 func (s *milestoneService) List(ctx context.Context, repo string, opts scm.MilestoneListOptions) ([]*scm.Milestone, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v1/repos/%s/milestones", repo)
+	out := []*milestone{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertMilestoneList(out, opts), res, err
 }
 
 func (s *milestoneService) Create(ctx context.Context, repo string, input *scm.MilestoneInput) (*scm.Milestone, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v1/repos/%s/milestones", repo)
+	in := &milestoneInput{
+		Title:       input.Title,
+		Description: input.Description,
+		Deadline:    input.DueDate,
+	}
+	out := new(milestone)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	if err != nil || input.State != "closed" {
+		return convertMilestone(out), res, err
+	}
+	// gogs always creates open milestones
+	return s.Update(ctx, repo, int(out.ID), &scm.MilestoneInput{State: input.State})
 }
 
 func (s *milestoneService) Delete(ctx context.Context, repo string, id int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v1/repos/%s/milestones/%d", repo, id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
 }
 
 func (s *milestoneService) Update(ctx context.Context, repo string, id int, input *scm.MilestoneInput) (*scm.Milestone, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v1/repos/%s/milestones/%d", repo, id)
+	in := &milestoneInput{
+		Title:       input.Title,
+		Description: input.Description,
+		Deadline:    input.DueDate,
+	}
+	switch input.State {
+	case "open":
+		in.State = "open"
+	case "close", "closed":
+		in.State = "closed"
+	}
+	out := new(milestone)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertMilestone(out), res, err
+}
+
+//
+// native data structures
+//
+
+type milestone struct {
+	ID          int64      `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	State       string     `json:"state"`
+	Deadline    *time.Time `json:"due_on"`
+}
+
+type milestoneInput struct {
+	Title       string     `json:"title,omitempty"`
+	Description string     `json:"description,omitempty"`
+	State       string     `json:"state,omitempty"`
+	Deadline    *time.Time `json:"due_on,omitempty"`
+}
+
+//
+// native data structure conversion
+//
+
+func convertMilestoneList(from []*milestone, opts scm.MilestoneListOptions) []*scm.Milestone {
+	to := []*scm.Milestone{}
+	for _, m := range from {
+		if opts.Open != opts.Closed && (m.State == "open") != opts.Open {
+			continue
+		}
+		to = append(to, convertMilestone(m))
+	}
+	return to
+}
+
+func convertMilestone(from *milestone) *scm.Milestone {
+	return &scm.Milestone{
+		Number:      int(from.ID),
+		ID:          int(from.ID),
+		Title:       from.Title,
+		Description: from.Description,
+		State:       from.State,
+		DueDate:     from.Deadline,
+	}
 }
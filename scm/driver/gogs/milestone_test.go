@@ -0,0 +1,171 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gogs
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestMilestoneFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/milestones/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/milestone.json")
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.Milestones.Find(context.Background(), "gogits/gogs", 1)
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(scm.Milestone)
+	raw, _ := ioutil.ReadFile("testdata/milestone.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestMilestoneList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/milestones").
+		Reply(200).
+		Type("application/json").
+		File("testdata/milestones.json")
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.Milestones.List(context.Background(), "gogits/gogs", scm.MilestoneListOptions{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*scm.Milestone{}
+	raw, _ := ioutil.ReadFile("testdata/milestones.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestMilestoneList_Closed(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/milestones").
+		Reply(200).
+		Type("application/json").
+		File("testdata/milestones.json")
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.Milestones.List(context.Background(), "gogits/gogs", scm.MilestoneListOptions{Closed: true})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Want open milestones filtered out, got %d", len(got))
+	}
+}
+
+func TestMilestoneCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Post("/api/v1/repos/gogits/gogs/milestones").
+		Reply(201).
+		Type("application/json").
+		File("testdata/milestone.json")
+
+	client, _ := New("https://try.gogs.io")
+	dueDate, _ := time.Parse(time.RFC3339, "2020-09-11T19:32:38.046Z")
+	input := &scm.MilestoneInput{
+		Title:       "string",
+		Description: "string",
+		State:       "open",
+		DueDate:     &dueDate,
+	}
+	got, _, err := client.Milestones.Create(context.Background(), "gogits/gogs", input)
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(scm.Milestone)
+	raw, _ := ioutil.ReadFile("testdata/milestone.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestMilestoneUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Patch("/api/v1/repos/gogits/gogs/milestones/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/milestone.json")
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.Milestones.Update(context.Background(), "gogits/gogs", 1, &scm.MilestoneInput{State: "open"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(scm.Milestone)
+	raw, _ := ioutil.ReadFile("testdata/milestone.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestMilestoneDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Delete("/api/v1/repos/gogits/gogs/milestones/1").
+		Reply(204)
+
+	client, _ := New("https://try.gogs.io")
+	_, err := client.Milestones.Delete(context.Background(), "gogits/gogs", 1)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIssueSetMilestone(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Patch("/api/v1/repos/gogits/gogs/issues/1").
+		Reply(201)
+
+	client, _ := New("https://try.gogs.io")
+	_, err := client.Issues.SetMilestone(context.Background(), "gogits/gogs", 1, 1)
+	if err != nil {
+		t.Error(err)
+	}
+}
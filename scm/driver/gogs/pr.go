@@ -6,6 +6,7 @@ package gogs
 
 import (
 	"context"
+	"time"
 
 	"github.com/slimm609/go-scm/scm"
 )
@@ -15,96 +16,100 @@ type pullService struct {
 }
 
 func (s *pullService) Find(context.Context, string, int) (*scm.PullRequest, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("PullRequests", "Find")
 }
 
 func (s *pullService) FindComment(context.Context, string, int, int) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("PullRequests", "FindComment")
 }
 
 func (s *pullService) List(context.Context, string, scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("PullRequests", "List")
 }
 
-func (s *pullService) ListComments(context.Context, string, int, scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+func (s *pullService) ListComments(context.Context, string, int, scm.CommentListOptions) ([]*scm.Comment, *scm.Response, error) {
+	return nil, nil, notSupported("PullRequests", "ListComments")
 }
 
 func (s *pullService) ListChanges(context.Context, string, int, scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("PullRequests", "ListChanges")
 }
 
 func (s *pullService) ListLabels(context.Context, string, int, scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("PullRequests", "ListLabels")
 }
 
 func (s *pullService) ListEvents(context.Context, string, int, scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("PullRequests", "ListEvents")
 }
 
 func (s *pullService) AddLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
 	// TODO implement
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "AddLabel")
 }
 
 func (s *pullService) DeleteLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "DeleteLabel")
 }
 
 func (s *pullService) CreateComment(context.Context, string, int, *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("PullRequests", "CreateComment")
 }
 
 func (s *pullService) DeleteComment(context.Context, string, int, int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "DeleteComment")
 }
 
 func (s *pullService) EditComment(ctx context.Context, repo string, number int, id int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("PullRequests", "EditComment")
 }
 
 func (s *pullService) Merge(context.Context, string, int, *scm.PullRequestMergeOptions) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "Merge")
 }
 
 func (s *pullService) Update(ctx context.Context, repo string, number int, prInput *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("PullRequests", "Update")
 }
 
 func (s *pullService) Close(context.Context, string, int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "Close")
 }
 
 func (s *pullService) Reopen(context.Context, string, int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "Reopen")
 }
 
 func (s *pullService) AssignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "AssignIssue")
 }
 
 func (s *pullService) UnassignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "UnassignIssue")
 }
 
 func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("PullRequests", "Create")
 }
 
 func (s *pullService) RequestReview(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "RequestReview")
 }
 
 func (s *pullService) UnrequestReview(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "UnrequestReview")
 }
 
+// SetMilestone sets the milestone of the pull request. Gogs
+// pull requests are issues, so the issue endpoint is used.
 func (s *pullService) SetMilestone(ctx context.Context, repo string, prID int, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	issues := &issueService{client: s.client}
+	return issues.SetMilestone(ctx, repo, prID, number)
 }
 
 func (s *pullService) ClearMilestone(ctx context.Context, repo string, prID int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	issues := &issueService{client: s.client}
+	return issues.ClearMilestone(ctx, repo, prID)
 }
 
 //
@@ -118,6 +123,7 @@ type pullRequest struct {
 	Title      string     `json:"title"`
 	Body       string     `json:"body"`
 	State      string     `json:"state"`
+	Labels     []*label   `json:"labels"`
 	HeadBranch string     `json:"head_branch"`
 	HeadRepo   repository `json:"head_repo"`
 	BaseBranch string     `json:"base_branch"`
@@ -125,6 +131,7 @@ type pullRequest struct {
 	HTMLURL    string     `json:"html_url"`
 	Mergeable  bool       `json:"mergeable"`
 	Merged     bool       `json:"merged"`
+	MergedAt   time.Time  `json:"merged_at"`
 }
 
 //
@@ -133,13 +140,15 @@ type pullRequest struct {
 
 func convertPullRequestFromIssue(src *issue) *scm.PullRequest {
 	return &scm.PullRequest{
-		Number:  src.Number,
-		Title:   src.Title,
-		Body:    src.Body,
-		Closed:  src.State == "closed",
-		Author:  *convertUser(&src.User),
-		Merged:  src.PullRequest.Merged,
-		Created: src.Created,
-		Updated: src.Updated,
+		Number:   src.Number,
+		Title:    src.Title,
+		Body:     src.Body,
+		Closed:   src.State == "closed",
+		Labels:   convertLabels(src.Labels),
+		Author:   *convertUser(&src.User),
+		Merged:   src.PullRequest.Merged,
+		Created:  src.Created.UTC(),
+		Updated:  src.Updated.UTC(),
+		MergedAt: src.PullRequest.MergedAt.UTC(),
 	}
 }
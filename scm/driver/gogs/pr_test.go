@@ -6,6 +6,7 @@ package gogs
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/slimm609/go-scm/scm"
@@ -18,7 +19,7 @@ import (
 func TestPullRequestFind(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, _, err := client.PullRequests.Find(context.Background(), "gogits/gogs", 1)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -26,7 +27,7 @@ func TestPullRequestFind(t *testing.T) {
 func TestPullRequestList(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, _, err := client.PullRequests.List(context.Background(), "gogits/gogs", scm.PullRequestListOptions{})
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -34,7 +35,7 @@ func TestPullRequestList(t *testing.T) {
 func TestPullRequestClose(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, err := client.PullRequests.Close(context.Background(), "gogits/gogs", 1)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -42,7 +43,7 @@ func TestPullRequestClose(t *testing.T) {
 func TestPullRequestMerge(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, err := client.PullRequests.Merge(context.Background(), "gogits/gogs", 1, nil)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -54,7 +55,7 @@ func TestPullRequestMerge(t *testing.T) {
 func TestPullRequestChanges(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, _, err := client.PullRequests.ListChanges(context.Background(), "gogits/gogs", 1, scm.ListOptions{})
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -66,15 +67,15 @@ func TestPullRequestChanges(t *testing.T) {
 func TestPullRequestCommentFind(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, _, err := client.PullRequests.FindComment(context.Background(), "gogits/gogs", 1, 1)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
 
 func TestPullRequestCommentList(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
-	_, _, err := client.PullRequests.ListComments(context.Background(), "gogits/gogs", 1, scm.ListOptions{})
-	if err != scm.ErrNotSupported {
+	_, _, err := client.PullRequests.ListComments(context.Background(), "gogits/gogs", 1, scm.CommentListOptions{})
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -82,7 +83,7 @@ func TestPullRequestCommentList(t *testing.T) {
 func TestPullRequestCommentCreate(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, _, err := client.PullRequests.CreateComment(context.Background(), "gogits/gogs", 1, &scm.CommentInput{})
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -90,7 +91,7 @@ func TestPullRequestCommentCreate(t *testing.T) {
 func TestPullRequestCommentDelete(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, err := client.PullRequests.DeleteComment(context.Background(), "gogits/gogs", 1, 1)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -105,7 +106,7 @@ func TestPullCreate(t *testing.T) {
 	}
 
 	_, _, err := client.PullRequests.Create(context.Background(), "gogits/gogs", input)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -7,6 +7,7 @@ package gogs
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"testing"
 
@@ -264,7 +265,7 @@ func TestHookEvents(t *testing.T) {
 func TestStatusList(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, _, err := client.Repositories.ListStatus(context.Background(), "gogits/gogs", "master", scm.ListOptions{})
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -272,7 +273,7 @@ func TestStatusList(t *testing.T) {
 func TestStatusCreate(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, _, err := client.Repositories.CreateStatus(context.Background(), "gogits/gogs", "master", &scm.StatusInput{})
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
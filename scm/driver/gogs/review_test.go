@@ -6,6 +6,7 @@ package gogs
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/slimm609/go-scm/scm"
@@ -14,7 +15,7 @@ import (
 func TestReviewFind(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, _, err := client.Reviews.Find(context.Background(), "gogits/gogs", 1, 1)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -22,7 +23,7 @@ func TestReviewFind(t *testing.T) {
 func TestReviewList(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, _, err := client.Reviews.List(context.Background(), "gogits/gogs", 1, scm.ListOptions{})
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -30,7 +31,7 @@ func TestReviewList(t *testing.T) {
 func TestReviewCreate(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, _, err := client.Reviews.Create(context.Background(), "gogits/gogs", 1, nil)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -38,7 +39,7 @@ func TestReviewCreate(t *testing.T) {
 func TestReviewDelete(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
 	_, err := client.Reviews.Delete(context.Background(), "gogits/gogs", 1, 1)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -16,11 +16,19 @@ type userService struct {
 }
 
 func (s *userService) CreateToken(context.Context, string, string) (*scm.UserToken, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Users", "CreateToken")
 }
 
 func (s *userService) DeleteToken(context.Context, int64) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Users", "DeleteToken")
+}
+
+func (s *userService) TokenInfo(context.Context) (*scm.TokenInfo, *scm.Response, error) {
+	return nil, nil, notSupported("Users", "TokenInfo")
+}
+
+func (s *userService) ListTokens(context.Context, scm.ListOptions) ([]*scm.UserToken, *scm.Response, error) {
+	return nil, nil, notSupported("Users", "ListTokens")
 }
 
 func (s *userService) Find(ctx context.Context) (*scm.User, *scm.Response, error) {
@@ -42,11 +50,11 @@ func (s *userService) FindEmail(ctx context.Context) (string, *scm.Response, err
 }
 
 func (s *userService) ListInvitations(context.Context) ([]*scm.Invitation, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Users", "ListInvitations")
 }
 
 func (s *userService) AcceptInvitation(context.Context, int64) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("Users", "AcceptInvitation")
 }
 
 //
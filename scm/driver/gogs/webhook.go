@@ -8,8 +8,6 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"net/http"
 
 	"github.com/slimm609/go-scm/pkg/hmac"
@@ -18,12 +16,15 @@ import (
 
 type webhookService struct {
 	client *wrapper
+	opts   scm.WebhookServiceOptions
 }
 
 func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
-	data, err := ioutil.ReadAll(
-		io.LimitReader(req.Body, 10000000),
-	)
+	return s.ParseMulti(req, scm.Secrets(fn))
+}
+
+func (s *webhookService) ParseMulti(req *http.Request, fn scm.MultiSecretFunc) (scm.Webhook, error) {
+	body, data, err := s.opts.ReadBody(req)
 	if err != nil {
 		return nil, err
 	}
@@ -49,14 +50,18 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	if err != nil {
 		return nil, err
 	}
+	scm.SetGUID(hook, req.Header.Get("X-Gogs-Delivery"))
+	if s.opts.RawPayload {
+		scm.SetRaw(hook, data)
+	}
 
 	// get the gogs signature key to verify the payload
 	// signature. If no key is provided, no validation
 	// is performed.
-	key, err := fn(hook)
+	keys, err := fn(hook)
 	if err != nil {
 		return hook, err
-	} else if key == "" {
+	} else if len(keys) == 0 {
 		return hook, nil
 	}
 
@@ -65,7 +70,7 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 		return hook, scm.ErrSignatureInvalid
 	}
 
-	if !hmac.Validate(sha256.New, data, []byte(key), sig) {
+	if !scm.ValidateAny(keys, func(key string) bool { return hmac.Validate(sha256.New, body, []byte(key), sig) }) {
 		return hook, scm.ErrSignatureInvalid
 	}
 
@@ -197,28 +202,38 @@ func convertBranchHook(dst *createHook, action scm.Action) *scm.BranchHook {
 }
 
 func convertPushHook(dst *pushHook) *scm.PushHook {
-	return &scm.PushHook{
-		Ref: scm.ExpandRef(dst.Ref, "refs/heads/"),
+	hook := &scm.PushHook{
+		Ref:     scm.ExpandRef(dst.Ref, "refs/heads/"),
+		Before:  dst.Before,
+		After:   dst.After,
+		Created: dst.Before == scm.EmptyCommit,
+		Deleted: dst.After == scm.EmptyCommit,
 		Commit: scm.Commit{
-			Sha:     dst.After,
-			Message: dst.Commits[0].Message,
-			Link:    dst.Compare,
-			Author: scm.Signature{
-				Login: dst.Commits[0].Author.Username,
-				Email: dst.Commits[0].Author.Email,
-				Name:  dst.Commits[0].Author.Name,
-				Date:  dst.Commits[0].Timestamp,
-			},
-			Committer: scm.Signature{
-				Login: dst.Commits[0].Committer.Username,
-				Email: dst.Commits[0].Committer.Email,
-				Name:  dst.Commits[0].Committer.Name,
-				Date:  dst.Commits[0].Timestamp,
-			},
+			Sha:  dst.After,
+			Link: dst.Compare,
 		},
 		Repo:   *convertRepository(&dst.Repository),
 		Sender: *convertUser(&dst.Sender),
 	}
+	// the commit details are omitted if the push has no commits,
+	// e.g. when a branch is deleted.
+	if len(dst.Commits) != 0 {
+		commit := dst.Commits[0]
+		hook.Commit.Message = commit.Message
+		hook.Commit.Author = scm.Signature{
+			Login: commit.Author.Username,
+			Email: commit.Author.Email,
+			Name:  commit.Author.Name,
+			Date:  commit.Timestamp,
+		}
+		hook.Commit.Committer = scm.Signature{
+			Login: commit.Committer.Username,
+			Email: commit.Committer.Email,
+			Name:  commit.Committer.Name,
+			Date:  commit.Timestamp,
+		}
+	}
+	return hook
 }
 
 func convertPullRequestHook(dst *pullRequestHook) *scm.PullRequestHook {
@@ -234,15 +249,23 @@ func convertPullRequestHook(dst *pullRequestHook) *scm.PullRequestHook {
 				Email:  dst.PullRequest.User.Email,
 				Avatar: dst.PullRequest.User.Avatar,
 			},
-			Merged: dst.PullRequest.Merged,
-			// Created: nil,
-			// Updated: nil,
-			Source: dst.PullRequest.HeadBranch,
-			Target: dst.PullRequest.BaseBranch,
-			Link:   dst.PullRequest.HTMLURL,
-			Fork:   dst.PullRequest.HeadRepo.FullName,
-			Ref:    fmt.Sprintf("refs/pull/%d/head", dst.PullRequest.Number),
+			Labels:   convertLabels(dst.PullRequest.Labels),
+			Merged:   dst.PullRequest.Merged,
+			MergedAt: dst.PullRequest.MergedAt.UTC(),
+			Source:   dst.PullRequest.HeadBranch,
+			Target:   dst.PullRequest.BaseBranch,
+			Link:     dst.PullRequest.HTMLURL,
+			Fork:     dst.PullRequest.HeadRepo.FullName,
+			Ref:      fmt.Sprintf("refs/pull/%d/head", dst.PullRequest.Number),
 			// Sha:    "",
+			Base: scm.PullRequestBranch{
+				Ref:  dst.PullRequest.BaseBranch,
+				Repo: *convertRepository(&dst.PullRequest.BaseRepo),
+			},
+			Head: scm.PullRequestBranch{
+				Ref:  dst.PullRequest.HeadBranch,
+				Repo: *convertRepository(&dst.PullRequest.HeadRepo),
+			},
 		},
 		Repo:   *convertRepository(&dst.Repository),
 		Sender: *convertUser(&dst.Sender),
@@ -0,0 +1,196 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hooktest provides malformed webhook payloads and
+// requests for the fuzz tests of the webhook parsers of the
+// drivers.
+package hooktest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+)
+
+// EventHeaders are the event headers of the drivers, which
+// are set together on the requests with mixed headers.
+var EventHeaders = []string{
+	"X-Event-Key",
+	"X-Fake-Event",
+	"X-Gitea-Event",
+	"X-Gitee-Event",
+	"X-GitHub-Event",
+	"X-Gitlab-Event",
+	"X-Gogs-Event",
+	"X-Webhook-Event",
+}
+
+// ContentTypes are the content types of the requests of the
+// fuzz tests, cycled through by the seeds.
+var ContentTypes = []string{
+	"application/json",
+	"application/x-www-form-urlencoded",
+	"text/plain",
+	"",
+}
+
+// Payloads returns the JSON payloads of the directory, e.g.
+// testdata/webhooks, sorted by file name.
+func Payloads(dir string) ([][]byte, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	var out [][]byte
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, data)
+	}
+	return out, nil
+}
+
+// Seeds returns the payload, its truncations, and its form
+// encoding as the payload parameter.
+func Seeds(payload []byte) [][]byte {
+	out := [][]byte{payload}
+	for _, n := range []int{0, 1, len(payload) / 2, len(payload) - 1} {
+		if n >= 0 && n < len(payload) {
+			out = append(out, payload[:n])
+		}
+	}
+	form := url.Values{"payload": {string(payload)}}
+	return append(out, []byte(form.Encode()))
+}
+
+// Seed is a seed of the fuzz targets of the webhook parsers,
+// which are the arguments of Request.
+type Seed struct {
+	Event       string
+	ContentType string
+	Mixed       bool
+	Data        []byte
+}
+
+// Corpus returns the seeds of the payloads parsed as each
+// event. The payloads, their truncations and their form
+// encoding cycle through the content types and mixed event
+// headers. The variants of Nulls are JSON requests, so the
+// converters are fed the nulled or emptied objects and arrays.
+func Corpus(payloads [][]byte, events []string) []Seed {
+	var out []Seed
+	n := 0
+	for _, payload := range payloads {
+		for _, data := range Seeds(payload) {
+			for _, event := range events {
+				out = append(out, Seed{event, ContentTypes[n%len(ContentTypes)], n%3 == 0, data})
+				n++
+			}
+		}
+		for _, data := range Nulls(payload) {
+			for _, event := range events {
+				out = append(out, Seed{event, "application/json", false, data})
+			}
+		}
+	}
+	return out
+}
+
+// Request returns a webhook request with the body, and the
+// event header of the driver set to the event. With mixed
+// headers, the request also carries the event headers of the
+// other drivers and a second event header of the driver, all
+// set to the event.
+func Request(header, event, contentType string, mixed bool, body []byte) *http.Request {
+	req, _ := http.NewRequest("POST", "/hook", bytes.NewReader(body))
+	if mixed {
+		for _, other := range EventHeaders {
+			req.Header.Set(other, event)
+		}
+	}
+	req.Header.Set(header, event)
+	if mixed {
+		req.Header.Add(header, "ping")
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return req
+}
+
+// Nulls returns the variants of the JSON payload in which
+// one object or array, at any depth, is null or empty. The
+// first element of the arrays is varied, the others are
+// kept. A payload which is not valid JSON has no variants.
+func Nulls(data []byte) [][]byte {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil
+	}
+	var out [][]byte
+	for _, v := range variants(doc) {
+		b, err := json.Marshal(v)
+		if err == nil {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// variants returns the variants of the decoded JSON value.
+func variants(doc interface{}) []interface{} {
+	var out []interface{}
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			for _, child := range replacements(v[key]) {
+				out = append(out, with(v, key, child))
+			}
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			break
+		}
+		for _, child := range replacements(v[0]) {
+			out = append(out, append([]interface{}{child}, v[1:]...))
+		}
+	}
+	return out
+}
+
+// replacements returns the values replacing the value in the
+// variants of its parent.
+func replacements(value interface{}) []interface{} {
+	var out []interface{}
+	switch value.(type) {
+	case map[string]interface{}:
+		out = append(out, nil, map[string]interface{}{})
+	case []interface{}:
+		out = append(out, nil, []interface{}{})
+	}
+	return append(out, variants(value)...)
+}
+
+// with returns a copy of the object with the value of the key
+// replaced.
+func with(obj map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		out[k] = v
+	}
+	out[key] = value
+	return out
+}
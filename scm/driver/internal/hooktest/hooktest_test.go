@@ -0,0 +1,51 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hooktest
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNulls(t *testing.T) {
+	got := []string{}
+	for _, data := range Nulls([]byte(`{"a":1,"b":{"c":[{"d":2},{"e":3}]}}`)) {
+		got = append(got, string(data))
+	}
+	want := []string{
+		`{"a":1,"b":null}`,
+		`{"a":1,"b":{}}`,
+		`{"a":1,"b":{"c":null}}`,
+		`{"a":1,"b":{"c":[]}}`,
+		`{"a":1,"b":{"c":[null,{"e":3}]}}`,
+		`{"a":1,"b":{"c":[{},{"e":3}]}}`,
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestNulls_Invalid(t *testing.T) {
+	if got := Nulls([]byte(`{"a":`)); len(got) != 0 {
+		t.Errorf("Want no variants of an invalid payload, got %d", len(got))
+	}
+}
+
+func TestCorpus(t *testing.T) {
+	seeds := Corpus([][]byte{[]byte(`{"a":{}}`)}, []string{"push", "issues"})
+	// the payload, 4 truncations and the form encoding, and
+	// 2 variants of Nulls, as each event.
+	if got, want := len(seeds), 16; got != want {
+		t.Fatalf("Want %d seeds, got %d", want, got)
+	}
+	if got := seeds[1]; got.Event != "issues" || got.ContentType != ContentTypes[1] {
+		t.Errorf("Want the events and content types cycled, got %+v", got)
+	}
+	if got := seeds[len(seeds)-1]; got.ContentType != "application/json" || got.Mixed || string(got.Data) != `{"a":{}}` {
+		t.Errorf("Want the variants of Nulls sent as JSON, got %+v", got)
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package paging provides page arithmetic shared by the
+// drivers that paginate in-memory lists.
+package paging
+
+// Bounds returns the bounds of the page within a list of
+// the given number of items. A zero page or size returns
+// the whole list, matching the default value of
+// scm.ListOptions.
+func Bounds(page, size, items int) (start, end int) {
+	if page == 0 || size == 0 {
+		return 0, items
+	}
+	start = (page - 1) * size
+	if start > items {
+		start = items
+	}
+	end = start + size
+	if end > items {
+		end = items
+	}
+	return start, end
+}
@@ -0,0 +1,30 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package paging
+
+import "testing"
+
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		page      int
+		size      int
+		items     int
+		wantStart int
+		wantEnd   int
+	}{
+		{1, 5, 10, 0, 5},
+		{2, 5, 10, 5, 10},
+		{2, 5, 9, 5, 9},
+		{4, 5, 10, 10, 10}, // this results in an empty slice
+		{0, 0, 10, 0, 10},  // this is the default 0 value for ListOption
+	}
+
+	for _, tt := range tests {
+		start, end := Bounds(tt.page, tt.size, tt.items)
+		if tt.wantStart != start || tt.wantEnd != end {
+			t.Fatalf("Bounds(%d, %d, %d) got items[%d:%d], want items[%d:%d]", tt.page, tt.size, tt.items, start, end, tt.wantStart, tt.wantEnd)
+		}
+	}
+}
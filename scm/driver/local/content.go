@@ -0,0 +1,391 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package local
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/fake"
+)
+
+type contentService struct {
+	repos *repositories
+	data  *fake.Data
+}
+
+func (s *contentService) Find(ctx context.Context, repo, path, ref string) (*scm.Content, *scm.Response, error) {
+	tree, err := s.tree(repo, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := tree.File(cleanPath(path))
+	if err == object.ErrFileNotFound {
+		return nil, nil, scm.ErrNotFound
+	} else if err != nil {
+		return nil, nil, err
+	}
+	data, err := file.Contents()
+	if err != nil {
+		return nil, nil, err
+	}
+	return &scm.Content{
+		Path: path,
+		Data: []byte(data),
+		Sha:  file.Hash.String(),
+	}, nil, nil
+}
+
+func (s *contentService) FindMany(ctx context.Context, repo, ref string, paths []string) (map[string]*scm.Content, map[string]error) {
+	return scm.FindManyContents(ctx, s, repo, ref, paths, scm.DefaultConcurrency)
+}
+
+func (s *contentService) List(ctx context.Context, repo, path, ref string) ([]*scm.FileEntry, *scm.Response, error) {
+	tree, err := s.tree(repo, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	dir := cleanPath(path)
+	if dir != "" {
+		tree, err = tree.Tree(dir)
+		if err == object.ErrDirectoryNotFound {
+			return nil, nil, scm.ErrNotFound
+		} else if err != nil {
+			return nil, nil, err
+		}
+	}
+	r, err := s.repos.get(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	var out []*scm.FileEntry
+	for _, entry := range tree.Entries {
+		dst, err := convertEntry(r, dir, entry)
+		if err != nil {
+			return nil, nil, err
+		}
+		out = append(out, dst)
+	}
+	return out, nil, nil
+}
+
+func (s *contentService) Exists(ctx context.Context, repo, path, ref string) (bool, *scm.Response, error) {
+	_, res, err := s.Stat(ctx, repo, path, ref)
+	if err == scm.ErrNotFound {
+		return false, res, nil
+	} else if err != nil {
+		return false, res, err
+	}
+	return true, res, nil
+}
+
+func (s *contentService) Stat(ctx context.Context, repo, path, ref string) (*scm.FileEntry, *scm.Response, error) {
+	tree, err := s.tree(repo, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	entry, err := tree.FindEntry(cleanPath(path))
+	if err == object.ErrEntryNotFound || err == object.ErrDirectoryNotFound {
+		return nil, nil, scm.ErrNotFound
+	} else if err != nil {
+		return nil, nil, err
+	}
+	r, err := s.repos.get(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	out, err := convertEntry(r, parentPath(cleanPath(path)), *entry)
+	return out, nil, err
+}
+
+func (s *contentService) Create(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	return nil, s.commit(repo, path, branchOf(params.Branch), params.Message, func(existing *object.TreeEntry) error {
+		if existing != nil {
+			return fmt.Errorf("file '%s' already exists", path)
+		}
+		return nil
+	}, params.Data, false)
+}
+
+func (s *contentService) Update(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	return nil, s.commit(repo, path, branchOf(params.Branch), params.Message, func(existing *object.TreeEntry) error {
+		if existing == nil {
+			return scm.ErrNotFound
+		}
+		if params.Sha != "" && params.Sha != existing.Hash.String() {
+			return fmt.Errorf("file '%s' does not match sha %s", path, params.Sha)
+		}
+		return nil
+	}, params.Data, false)
+}
+
+func (s *contentService) Delete(ctx context.Context, repo, path, ref string) (*scm.Response, error) {
+	message := fmt.Sprintf("Delete %s", path)
+	return nil, s.commit(repo, path, branchOf(ref), message, func(existing *object.TreeEntry) error {
+		if existing == nil {
+			return scm.ErrNotFound
+		}
+		return nil
+	}, nil, true)
+}
+
+// tree returns the tree of the commit the ref points at.
+func (s *contentService) tree(repo, ref string) (*object.Tree, error) {
+	r, err := s.repos.get(repo)
+	if err != nil {
+		return nil, err
+	}
+	var commit *object.Commit
+	if ref == "" {
+		head, err := r.Head()
+		if err == plumbing.ErrReferenceNotFound {
+			return nil, scm.ErrNotFound
+		} else if err != nil {
+			return nil, err
+		}
+		commit, err = r.CommitObject(head.Hash())
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		commit, err = findCommit(r, ref)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return commit.Tree()
+}
+
+// commit writes a new commit on top of the branch which
+// sets the content of the file at path to data, or deletes
+// the file if remove is set. The check function is called
+// with the existing tree entry of the file, or nil, and
+// aborts the commit if it returns an error. The branch is
+// created if it does not exist.
+func (s *contentService) commit(repo, path, branch, message string, check func(*object.TreeEntry) error, data []byte, remove bool) error {
+	r, err := s.repos.get(repo)
+	if err != nil {
+		return err
+	}
+	s.repos.write.Lock()
+	defer s.repos.write.Unlock()
+
+	name := plumbing.NewBranchReferenceName(branch)
+	var parents []plumbing.Hash
+	var tree *object.Tree
+	head, err := r.Reference(name, true)
+	switch err {
+	case nil:
+		parent, err := r.CommitObject(head.Hash())
+		if err != nil {
+			return err
+		}
+		tree, err = parent.Tree()
+		if err != nil {
+			return err
+		}
+		parents = append(parents, parent.Hash)
+	case plumbing.ErrReferenceNotFound:
+		// the first commit on the branch.
+	default:
+		return err
+	}
+
+	filePath := cleanPath(path)
+	var existing *object.TreeEntry
+	if tree != nil {
+		existing, err = tree.FindEntry(filePath)
+		if err != nil && err != object.ErrEntryNotFound && err != object.ErrDirectoryNotFound {
+			return err
+		}
+	}
+	if err := check(existing); err != nil {
+		return err
+	}
+
+	var entry *object.TreeEntry
+	if !remove {
+		blob, err := writeBlob(r.Storer, data)
+		if err != nil {
+			return err
+		}
+		entry = &object.TreeEntry{Mode: filemode.Regular, Hash: blob}
+	}
+	treeHash, err := writeTree(r.Storer, tree, strings.Split(filePath, "/"), entry, true)
+	if err != nil {
+		return err
+	}
+
+	signature := object.Signature{
+		Name:  s.data.CurrentUser.Login,
+		Email: s.data.CurrentUser.Email,
+		When:  time.Now(),
+	}
+	if message == "" {
+		message = fmt.Sprintf("Update %s", path)
+	}
+	commit := &object.Commit{
+		Author:       signature,
+		Committer:    signature,
+		Message:      message,
+		TreeHash:     treeHash,
+		ParentHashes: parents,
+	}
+	obj := r.Storer.NewEncodedObject()
+	if err := commit.Encode(obj); err != nil {
+		return err
+	}
+	hash, err := r.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return err
+	}
+	return r.Storer.CheckAndSetReference(plumbing.NewHashReference(name, hash), head)
+}
+
+// writeBlob stores the data as a blob object.
+func writeBlob(st storer.EncodedObjectStorer, data []byte) (plumbing.Hash, error) {
+	obj := st.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+	obj.SetSize(int64(len(data)))
+	w, err := obj.Writer()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return plumbing.ZeroHash, err
+	}
+	if err := w.Close(); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return st.SetEncodedObject(obj)
+}
+
+// writeTree stores a copy of the tree in which the entry at
+// the given path is replaced by entry, or removed if entry
+// is nil, and returns its hash. Subtrees left empty are
+// removed, and a zero hash is returned for an empty subtree.
+func writeTree(st storer.EncodedObjectStorer, tree *object.Tree, parts []string, entry *object.TreeEntry, root bool) (plumbing.Hash, error) {
+	var entries []object.TreeEntry
+	if tree != nil {
+		entries = append(entries, tree.Entries...)
+	}
+	index := -1
+	for i, e := range entries {
+		if e.Name == parts[0] {
+			index = i
+			break
+		}
+	}
+
+	var replacement *object.TreeEntry
+	if len(parts) == 1 {
+		if entry != nil {
+			replacement = &object.TreeEntry{Name: parts[0], Mode: entry.Mode, Hash: entry.Hash}
+		}
+	} else {
+		var subtree *object.Tree
+		if index != -1 && entries[index].Mode == filemode.Dir {
+			var err error
+			subtree, err = object.GetTree(st, entries[index].Hash)
+			if err != nil {
+				return plumbing.ZeroHash, err
+			}
+		}
+		hash, err := writeTree(st, subtree, parts[1:], entry, false)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		if !hash.IsZero() {
+			replacement = &object.TreeEntry{Name: parts[0], Mode: filemode.Dir, Hash: hash}
+		}
+	}
+
+	switch {
+	case index != -1 && replacement != nil:
+		entries[index] = *replacement
+	case index != -1:
+		entries = append(entries[:index], entries[index+1:]...)
+	case replacement != nil:
+		entries = append(entries, *replacement)
+	}
+	if len(entries) == 0 && !root {
+		return plumbing.ZeroHash, nil
+	}
+
+	// git orders tree entries by name, comparing directory
+	// names as if they had a trailing slash.
+	sort.Slice(entries, func(i, j int) bool {
+		return sortName(entries[i]) < sortName(entries[j])
+	})
+	obj := st.NewEncodedObject()
+	if err := (&object.Tree{Entries: entries}).Encode(obj); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return st.SetEncodedObject(obj)
+}
+
+func sortName(entry object.TreeEntry) string {
+	if entry.Mode == filemode.Dir {
+		return entry.Name + "/"
+	}
+	return entry.Name
+}
+
+func convertEntry(r *git.Repository, dir string, from object.TreeEntry) (*scm.FileEntry, error) {
+	out := &scm.FileEntry{
+		Name: from.Name,
+		Path: path.Join(dir, from.Name),
+		Sha:  from.Hash.String(),
+	}
+	switch from.Mode {
+	case filemode.Dir:
+		out.Type = "dir"
+	case filemode.Submodule:
+		out.Type = "submodule"
+	case filemode.Symlink:
+		out.Type = "symlink"
+	default:
+		out.Type = "file"
+	}
+	if out.Type == "file" || out.Type == "symlink" {
+		blob, err := r.BlobObject(from.Hash)
+		if err != nil {
+			return nil, err
+		}
+		out.Size = int(blob.Size)
+	}
+	return out, nil
+}
+
+// branchOf returns the branch to commit to, defaulting to
+// master.
+func branchOf(branch string) string {
+	if branch == "" {
+		return "master"
+	}
+	return scm.TrimRef(branch)
+}
+
+func cleanPath(p string) string {
+	return strings.Trim(path.Clean("/"+p), "/")
+}
+
+func parentPath(p string) string {
+	if i := strings.LastIndex(p, "/"); i != -1 {
+		return p[:i]
+	}
+	return ""
+}
@@ -0,0 +1,425 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package local
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/internal/paging"
+)
+
+type gitService struct {
+	repos *repositories
+}
+
+func (s *gitService) FindBranch(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
+	return s.findRef(repo, plumbing.ReferenceName(scm.ExpandRef(name, "refs/heads")))
+}
+
+func (s *gitService) FindCommit(ctx context.Context, repo, ref string) (*scm.Commit, *scm.Response, error) {
+	r, err := s.repos.get(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	commit, err := findCommit(r, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	return convertCommit(commit), nil, nil
+}
+
+func (s *gitService) FindTag(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
+	return s.findRef(repo, plumbing.ReferenceName(scm.ExpandRef(name, "refs/tags")))
+}
+
+func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *scm.Response, error) {
+	for _, path := range scm.QualifyRefs(ref) {
+		out, res, err := s.findRef(repo, plumbing.ReferenceName(path))
+		if err == scm.ErrNotFound {
+			continue
+		} else if err != nil {
+			return "", res, err
+		}
+		return out.Sha, res, nil
+	}
+	return "", nil, scm.ErrNotFound
+}
+
+func (s *gitService) ResolveRefs(ctx context.Context, repo string, refs []string) (map[string]string, map[string]error) {
+	return scm.ResolveRefs(ctx, s, repo, refs, scm.DefaultConcurrency)
+}
+
+func (s *gitService) ListBranches(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
+	r, err := s.repos.get(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	iter, err := r.Branches()
+	if err != nil {
+		return nil, nil, err
+	}
+	refs, err := convertReferenceIter(r, iter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return paginate(refs, opts), nil, nil
+}
+
+func (s *gitService) ListTags(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
+	r, err := s.repos.get(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	iter, err := r.Tags()
+	if err != nil {
+		return nil, nil, err
+	}
+	refs, err := convertReferenceIter(r, iter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return paginate(refs, opts), nil, nil
+}
+
+func (s *gitService) ListCommits(ctx context.Context, repo string, opts scm.CommitListOptions) ([]*scm.Commit, *scm.Response, error) {
+	r, err := s.repos.get(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	ref := opts.Sha
+	if ref == "" {
+		ref = opts.Ref
+	}
+	var from plumbing.Hash
+	if ref == "" {
+		head, err := r.Head()
+		if err == plumbing.ErrReferenceNotFound {
+			return nil, nil, scm.ErrNotFound
+		} else if err != nil {
+			return nil, nil, err
+		}
+		from = head.Hash()
+	} else {
+		commit, err := findCommit(r, ref)
+		if err != nil {
+			return nil, nil, err
+		}
+		from = commit.Hash
+	}
+	iter, err := r.Log(&git.LogOptions{From: from})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer iter.Close()
+
+	start, end := paging.Bounds(opts.Page, opts.Size, int(^uint(0)>>1))
+	var out []*scm.Commit
+	index := 0
+	err = iter.ForEach(func(commit *object.Commit) error {
+		if index >= end {
+			return storer.ErrStop
+		}
+		if index >= start {
+			out = append(out, convertCommit(commit))
+		}
+		index++
+		return nil
+	})
+	return out, nil, err
+}
+
+func (s *gitService) ListChanges(ctx context.Context, repo, ref string, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
+	r, err := s.repos.get(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	commit, err := findCommit(r, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, nil, err
+	}
+	parentTree := &object.Tree{}
+	if len(commit.ParentHashes) != 0 {
+		parent, err := r.CommitObject(commit.ParentHashes[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		parentTree, err = parent.Tree()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, nil, err
+	}
+	var out []*scm.Change
+	for _, change := range changes {
+		dst, err := convertChange(change)
+		if err != nil {
+			return nil, nil, err
+		}
+		out = append(out, dst)
+	}
+	start, end := paging.Bounds(opts.Page, opts.Size, len(out))
+	return out[start:end], nil, nil
+}
+
+func (s *gitService) CompareCommits(ctx context.Context, repo, base, head string) (*scm.Comparison, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "CompareCommits")
+}
+
+func (s *gitService) CompareAcrossForks(ctx context.Context, baseRepo, baseRef, headOwner, headRef string) (*scm.Comparison, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "CompareAcrossForks")
+}
+
+func (s *gitService) CreateRef(ctx context.Context, repo, ref, sha string) (*scm.Reference, *scm.Response, error) {
+	r, err := s.repos.get(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.repos.write.Lock()
+	defer s.repos.write.Unlock()
+
+	name := plumbing.ReferenceName(scm.QualifyRef(ref))
+	if _, err := r.Reference(name, false); err == nil {
+		return nil, nil, fmt.Errorf("reference '%s' already exists", name)
+	} else if err != plumbing.ErrReferenceNotFound {
+		return nil, nil, err
+	}
+	hash, err := parseHash(r, sha)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := plumbing.NewHashReference(name, hash)
+	if err := r.Storer.SetReference(out); err != nil {
+		return nil, nil, err
+	}
+	return convertReference(out, hash), nil, nil
+}
+
+func (s *gitService) UpdateRef(ctx context.Context, repo, ref, sha string, force bool) (*scm.Reference, *scm.Response, error) {
+	r, err := s.repos.get(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.repos.write.Lock()
+	defer s.repos.write.Unlock()
+
+	name := plumbing.ReferenceName(scm.QualifyRef(ref))
+	old, err := r.Reference(name, false)
+	if err == plumbing.ErrReferenceNotFound {
+		return nil, nil, scm.ErrNotFound
+	} else if err != nil {
+		return nil, nil, err
+	}
+	hash, err := parseHash(r, sha)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !force {
+		ok, err := isFastForward(r, old.Hash(), hash)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			return nil, nil, fmt.Errorf("update of reference '%s' to %s is not a fast-forward", name, sha)
+		}
+	}
+	out := plumbing.NewHashReference(name, hash)
+	if err := r.Storer.CheckAndSetReference(out, old); err != nil {
+		return nil, nil, err
+	}
+	return convertReference(out, hash), nil, nil
+}
+
+func (s *gitService) DeleteRef(ctx context.Context, repo, ref string) (*scm.Response, error) {
+	r, err := s.repos.get(repo)
+	if err != nil {
+		return nil, err
+	}
+	s.repos.write.Lock()
+	defer s.repos.write.Unlock()
+
+	name := plumbing.ReferenceName(scm.QualifyRef(ref))
+	if _, err := r.Reference(name, false); err == plumbing.ErrReferenceNotFound {
+		return nil, scm.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return nil, r.Storer.RemoveReference(name)
+}
+
+// findRef returns the reference with the given name, with
+// tags peeled to the commit they point at.
+func (s *gitService) findRef(repo string, name plumbing.ReferenceName) (*scm.Reference, *scm.Response, error) {
+	r, err := s.repos.get(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	ref, err := r.Reference(name, true)
+	if err == plumbing.ErrReferenceNotFound {
+		return nil, nil, scm.ErrNotFound
+	} else if err != nil {
+		return nil, nil, err
+	}
+	hash, err := peel(r, ref.Hash())
+	if err != nil {
+		return nil, nil, err
+	}
+	return convertReference(ref, hash), nil, nil
+}
+
+// findCommit returns the commit the ref points at. The ref
+// may be a commit sha, a branch or a tag name, or a ref in
+// any of the forms accepted by FindRef.
+func findCommit(r *git.Repository, ref string) (*object.Commit, error) {
+	if isHash(ref) {
+		commit, err := r.CommitObject(plumbing.NewHash(ref))
+		if err == nil {
+			return commit, nil
+		} else if err != plumbing.ErrObjectNotFound {
+			return nil, err
+		}
+	}
+	names := []plumbing.ReferenceName{
+		plumbing.ReferenceName(scm.QualifyRef(ref)),
+		plumbing.ReferenceName(scm.ExpandRef(ref, "refs/tags")),
+	}
+	for _, name := range names {
+		out, err := r.Reference(name, true)
+		if err == plumbing.ErrReferenceNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		hash, err := peel(r, out.Hash())
+		if err != nil {
+			return nil, err
+		}
+		return r.CommitObject(hash)
+	}
+	return nil, scm.ErrNotFound
+}
+
+// parseHash returns the hash of an existing commit.
+func parseHash(r *git.Repository, sha string) (plumbing.Hash, error) {
+	if !isHash(sha) {
+		return plumbing.ZeroHash, fmt.Errorf("invalid commit sha '%s'", sha)
+	}
+	hash := plumbing.NewHash(sha)
+	if _, err := r.CommitObject(hash); err == plumbing.ErrObjectNotFound {
+		return plumbing.ZeroHash, scm.ErrNotFound
+	} else if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return hash, nil
+}
+
+// peel returns the commit an annotated tag points at, or
+// the hash itself if it does not point at a tag object.
+func peel(r *git.Repository, hash plumbing.Hash) (plumbing.Hash, error) {
+	tag, err := r.TagObject(hash)
+	if err == plumbing.ErrObjectNotFound {
+		return hash, nil
+	} else if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return tag.Target, nil
+}
+
+// isFastForward returns true if the commit from is an
+// ancestor of, or the same commit as, the commit to.
+func isFastForward(r *git.Repository, from, to plumbing.Hash) (bool, error) {
+	if from == to {
+		return true, nil
+	}
+	fromCommit, err := r.CommitObject(from)
+	if err != nil {
+		return false, err
+	}
+	toCommit, err := r.CommitObject(to)
+	if err != nil {
+		return false, err
+	}
+	return fromCommit.IsAncestor(toCommit)
+}
+
+// isHash returns true if s is a full hex encoded sha1.
+func isHash(s string) bool {
+	if len(s) != 40 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+func convertReferenceIter(r *git.Repository, iter storer.ReferenceIter) ([]*scm.Reference, error) {
+	defer iter.Close()
+	var out []*scm.Reference
+	err := iter.ForEach(func(ref *plumbing.Reference) error {
+		hash, err := peel(r, ref.Hash())
+		if err != nil {
+			return err
+		}
+		out = append(out, convertReference(ref, hash))
+		return nil
+	})
+	return out, err
+}
+
+func convertReference(from *plumbing.Reference, hash plumbing.Hash) *scm.Reference {
+	return &scm.Reference{
+		Name: scm.TrimRef(from.Name().String()),
+		Path: from.Name().String(),
+		Sha:  hash.String(),
+	}
+}
+
+func convertCommit(from *object.Commit) *scm.Commit {
+	return &scm.Commit{
+		Sha:     from.Hash.String(),
+		Message: from.Message,
+		Tree: scm.CommitTree{
+			Sha: from.TreeHash.String(),
+		},
+		Author: scm.Signature{
+			Name:  from.Author.Name,
+			Email: from.Author.Email,
+			Date:  from.Author.When,
+		},
+		Committer: scm.Signature{
+			Name:  from.Committer.Name,
+			Email: from.Committer.Email,
+			Date:  from.Committer.When,
+		},
+	}
+}
+
+func convertChange(from *object.Change) (*scm.Change, error) {
+	action, err := from.Action()
+	if err != nil {
+		return nil, err
+	}
+	switch action {
+	case merkletrie.Insert:
+		return &scm.Change{Path: from.To.Name, Added: true}, nil
+	case merkletrie.Delete:
+		return &scm.Change{Path: from.From.Name, Deleted: true}, nil
+	default:
+		return &scm.Change{Path: from.To.Name}, nil
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package local implements a client backed by local git
+// repositories. Git and content operations are served by
+// go-git, while issues, pull requests and the remaining
+// resources are kept in memory by the fake driver. This
+// enables fully offline end-to-end tests and air-gapped
+// tooling.
+package local
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/fake"
+	"github.com/slimm609/go-scm/scm/driver/internal/paging"
+)
+
+// Opener returns the git repository for the repository
+// full name, e.g. octocat/hello-world.
+type Opener func(fullName string) (*git.Repository, error)
+
+// New returns a new local client serving git and content
+// operations from the repositories returned by open. The
+// fake data backing the remaining services is returned so
+// it can be pre-loaded or inspected.
+func New(open Opener) (*scm.Client, *fake.Data) {
+	client, data := fake.NewDefault()
+	client.Driver = scm.DriverLocal
+	client.BaseURL = &url.URL{Scheme: "file", Path: "/"}
+
+	repos := &repositories{
+		open:  open,
+		cache: map[string]*git.Repository{},
+	}
+	client.Git = &gitService{repos: repos}
+	client.Contents = &contentService{repos: repos, data: data}
+	return client, data
+}
+
+// NewDefault returns a new local client for the bare
+// repositories stored in dir, where the repository
+// octocat/hello-world is stored in dir/octocat/hello-world.git.
+// Repositories that do not exist are not found, see
+// NewDefaultInit to create them.
+func NewDefault(dir string) (*scm.Client, *fake.Data) {
+	return New(dirOpener(dir, false))
+}
+
+// NewDefaultInit returns a new local client like NewDefault,
+// except that the repositories that do not exist are created
+// on first use.
+func NewDefaultInit(dir string) (*scm.Client, *fake.Data) {
+	return New(dirOpener(dir, true))
+}
+
+// dirOpener returns the opener of the bare repositories stored
+// in dir. The repositories whose full name escapes dir, e.g.
+// with a .. element, are rejected.
+func dirOpener(dir string, create bool) Opener {
+	root := filepath.Clean(dir)
+	return func(fullName string) (*git.Repository, error) {
+		path := filepath.Join(root, filepath.FromSlash(fullName)+".git")
+		if !strings.HasPrefix(path, root+string(filepath.Separator)) {
+			return nil, fmt.Errorf("local: repository %q is outside of %s", fullName, root)
+		}
+		repo, err := git.PlainOpen(path)
+		if err == git.ErrRepositoryNotExists {
+			if !create {
+				return nil, scm.ErrNotFound
+			}
+			return git.PlainInit(path, true)
+		}
+		return repo, err
+	}
+}
+
+// NewMemory returns a new local client for repositories
+// held in memory. Repositories are created empty on first
+// use.
+func NewMemory() (*scm.Client, *fake.Data) {
+	return New(func(string) (*git.Repository, error) {
+		return git.Init(memory.NewStorage(), nil)
+	})
+}
+
+// repositories caches the repositories opened by the
+// client and serializes writes to them.
+type repositories struct {
+	mu    sync.Mutex
+	open  Opener
+	cache map[string]*git.Repository
+
+	// write is held while updating references, so
+	// compare-and-swap style updates do not interleave.
+	write sync.Mutex
+}
+
+// get returns the repository for the full name, opening
+// it on first use.
+func (r *repositories) get(fullName string) (*git.Repository, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if repo, ok := r.cache[fullName]; ok {
+		return repo, nil
+	}
+	repo, err := r.open(fullName)
+	if err != nil {
+		return nil, err
+	}
+	r.cache[fullName] = repo
+	return repo, nil
+}
+
+// paginate returns the page of the references sorted by
+// name.
+func paginate(refs []*scm.Reference, opts scm.ListOptions) []*scm.Reference {
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Name < refs[j].Name
+	})
+	start, end := paging.Bounds(opts.Page, opts.Size, len(refs))
+	return refs[start:end]
+}
+
+// notSupported returns the error of a method of a service
+// the driver does not support.
+func notSupported(service, method string) error {
+	return &scm.NotSupportedError{Driver: scm.DriverLocal, Service: service, Method: method}
+}
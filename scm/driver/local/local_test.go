@@ -0,0 +1,216 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package local
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestClient(t *testing.T) {
+	client, _ := NewMemory()
+	if got, want := client.Driver, scm.DriverLocal; got != want {
+		t.Errorf("Want driver %s, got %s", want, got)
+	}
+}
+
+func TestContents(t *testing.T) {
+	ctx := context.Background()
+	client, _ := NewMemory()
+
+	_, err := client.Contents.Create(ctx, "octocat/hello-world", "docs/README.md", &scm.ContentParams{
+		Message: "add README",
+		Data:    []byte("Hello World"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, _, err := client.Contents.Find(ctx, "octocat/hello-world", "docs/README.md", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(content.Data), "Hello World"; got != want {
+		t.Errorf("Want content %q, got %q", want, got)
+	}
+
+	_, err = client.Contents.Update(ctx, "octocat/hello-world", "docs/README.md", &scm.ContentParams{
+		Message: "update README",
+		Data:    []byte("Hello Octocat"),
+		Sha:     content.Sha,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Contents.Update(ctx, "octocat/hello-world", "docs/README.md", &scm.ContentParams{
+		Data: []byte("Stale"),
+		Sha:  content.Sha,
+	})
+	if err == nil {
+		t.Errorf("Expect error updating a file with a stale sha")
+	}
+
+	entries, _, err := client.Contents.List(ctx, "octocat/hello-world", "docs", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Path != "docs/README.md" || entries[0].Type != "file" || entries[0].Size != 13 {
+		t.Errorf("Unexpected entries %+v", entries)
+	}
+
+	if _, err := client.Contents.Delete(ctx, "octocat/hello-world", "docs/README.md", "master"); err != nil {
+		t.Fatal(err)
+	}
+	exists, _, err := client.Contents.Exists(ctx, "octocat/hello-world", "docs/README.md", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exists {
+		t.Errorf("Expect file deleted")
+	}
+	if _, _, err := client.Contents.Stat(ctx, "octocat/hello-world", "docs", "master"); err != scm.ErrNotFound {
+		t.Errorf("Expect empty directory removed, got %v", err)
+	}
+}
+
+func TestGit(t *testing.T) {
+	ctx := context.Background()
+	client, _ := NewMemory()
+
+	for _, file := range []string{"a.txt", "b.txt"} {
+		_, err := client.Contents.Create(ctx, "octocat/hello-world", file, &scm.ContentParams{
+			Message: "add " + file,
+			Data:    []byte(file),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	commits, _, err := client.Git.ListCommits(ctx, "octocat/hello-world", scm.CommitListOptions{Ref: "master"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 || commits[0].Message != "add b.txt" || commits[1].Message != "add a.txt" {
+		t.Fatalf("Unexpected commits %+v", commits)
+	}
+	head, first := commits[0].Sha, commits[1].Sha
+
+	changes, _, err := client.Git.ListChanges(ctx, "octocat/hello-world", head, scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]*scm.Change{{Path: "b.txt", Added: true}}, changes); diff != "" {
+		t.Errorf("Unexpected changes\n%s", diff)
+	}
+
+	if _, _, err := client.Git.CreateRef(ctx, "octocat/hello-world", "refs/tags/v1.0.0", first); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Git.CreateRef(ctx, "octocat/hello-world", "feature", first); err != nil {
+		t.Fatal(err)
+	}
+
+	branches, _, err := client.Git.ListBranches(ctx, "octocat/hello-world", scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*scm.Reference{
+		{Name: "feature", Path: "refs/heads/feature", Sha: first},
+		{Name: "master", Path: "refs/heads/master", Sha: head},
+	}
+	if diff := cmp.Diff(want, branches); diff != "" {
+		t.Errorf("Unexpected branches\n%s", diff)
+	}
+
+	tag, _, err := client.Git.FindTag(ctx, "octocat/hello-world", "v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag.Sha != first {
+		t.Errorf("Want tag sha %s, got %s", first, tag.Sha)
+	}
+
+	sha, _, err := client.Git.FindRef(ctx, "octocat/hello-world", "heads/master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sha != head {
+		t.Errorf("Want ref sha %s, got %s", head, sha)
+	}
+
+	if _, _, err := client.Git.UpdateRef(ctx, "octocat/hello-world", "master", first, false); err == nil {
+		t.Errorf("Expect error for a non fast-forward update")
+	}
+	if _, _, err := client.Git.UpdateRef(ctx, "octocat/hello-world", "feature", head, false); err != nil {
+		t.Errorf("Expect fast-forward update to succeed, got %v", err)
+	}
+
+	if _, err := client.Git.DeleteRef(ctx, "octocat/hello-world", "feature"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Git.FindBranch(ctx, "octocat/hello-world", "feature"); err != scm.ErrNotFound {
+		t.Errorf("Expect branch deleted, got %v", err)
+	}
+}
+
+func TestNewDefault(t *testing.T) {
+	dir, err := ioutil.TempDir("", "local")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	client, _ := NewDefault(dir)
+	_, err = client.Contents.Create(ctx, "octocat/hello-world", "README.md", &scm.ContentParams{
+		Data: []byte("Hello World"),
+	})
+	if err != scm.ErrNotFound {
+		t.Errorf("Want the repository not created, got %v", err)
+	}
+
+	client, _ = NewDefaultInit(dir)
+	_, err = client.Contents.Create(ctx, "octocat/hello-world", "README.md", &scm.ContentParams{
+		Data: []byte("Hello World"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a new client reads the repository back from disk.
+	client, _ = NewDefault(dir)
+	content, _, err := client.Contents.Find(ctx, "octocat/hello-world", "README.md", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(content.Data), "Hello World"; got != want {
+		t.Errorf("Want content %q, got %q", want, got)
+	}
+}
+
+func TestNewDefault_Escape(t *testing.T) {
+	dir, err := ioutil.TempDir("", "local")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	client, _ := NewDefaultInit(filepath.Join(dir, "repos"))
+	for _, name := range []string{"../octocat/hello-world", "octocat/../../hello-world"} {
+		if _, _, err := client.Git.FindBranch(context.Background(), name, "master"); err == nil || err == scm.ErrNotFound {
+			t.Errorf("Want repository %q outside of the directory rejected", name)
+		}
+	}
+	if entries, _ := ioutil.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Want no repository created outside of the directory, got %d entries", len(entries))
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sourcehut
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+type (
+	// BuildInput provides the input fields required for
+	// submitting a build to builds.sr.ht.
+	BuildInput struct {
+		// Manifest is the YAML build manifest.
+		Manifest string
+		Note     string
+		Tags     []string
+
+		// Secrets enables the secrets of the manifest.
+		Secrets bool
+	}
+
+	// Job represents a builds.sr.ht job. The status is
+	// PENDING, QUEUED, RUNNING, SUCCESS, FAILED, TIMEOUT or
+	// CANCELLED.
+	Job struct {
+		ID      int
+		Status  string
+		Note    string
+		Tags    []string
+		Link    string
+		Created time.Time
+	}
+)
+
+// SubmitBuild submits the build manifest to the builds.sr.ht
+// service of the SourceHut client, which scm has no service
+// for.
+func SubmitBuild(ctx context.Context, client *scm.Client, input *BuildInput) (*Job, *scm.Response, error) {
+	if client.Driver != scm.DriverSourcehut {
+		return nil, nil, notSupported("Builds", "SubmitBuild")
+	}
+	query := `mutation($manifest: String!, $tags: [String!], $note: String, $secrets: Boolean) {
+  submit(manifest: $manifest, tags: $tags, note: $note, secrets: $secrets) {
+    id status note tags created owner { canonicalName }
+  }
+}`
+	vars := map[string]interface{}{
+		"manifest": input.Manifest,
+		"tags":     input.Tags,
+		"note":     input.Note,
+		"secrets":  input.Secrets,
+	}
+	out := new(struct {
+		Submit job `json:"submit"`
+	})
+	c := &wrapper{client}
+	res, err := c.graphql(ctx, "builds", query, vars, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertJob(&out.Submit, c), res, nil
+}
+
+//
+// native data structures
+//
+
+// builds.sr.ht job object.
+type job struct {
+	ID      int       `json:"id"`
+	Status  string    `json:"status"`
+	Note    string    `json:"note"`
+	Tags    []string  `json:"tags"`
+	Created time.Time `json:"created"`
+	Owner   entity    `json:"owner"`
+}
+
+//
+// native data structure conversion
+//
+
+func convertJob(from *job, c *wrapper) *Job {
+	return &Job{
+		ID:      from.ID,
+		Status:  from.Status,
+		Note:    from.Note,
+		Tags:    from.Tags,
+		Link:    fmt.Sprintf("%s%s/job/%d", c.service("builds"), from.Owner.CanonicalName, from.ID),
+		Created: from.Created.UTC(),
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sourcehut
+
+import (
+	"context"
+	"encoding/base64"
+	"path"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+const (
+	// selection of the tree entry of the path.
+	pathSelection = `path(revspec: $revspec, path: $path) { ` + entryFields + ` }`
+
+	entryFields = `name object { id type ... on TextBlob { size text } ... on BinaryBlob { size base64 } }`
+)
+
+type contentService struct {
+	client *wrapper
+}
+
+func (s *contentService) Find(ctx context.Context, repo, path, ref string) (*scm.Content, *scm.Response, error) {
+	out, res, err := s.find(ctx, repo, path, ref, pathSelection)
+	if err != nil {
+		return nil, res, err
+	}
+	if out.Object.Type != "BLOB" {
+		return nil, res, scm.ErrNotFound
+	}
+	data := []byte(out.Object.Text)
+	if out.Object.Base64 != "" {
+		data, err = base64.StdEncoding.DecodeString(out.Object.Base64)
+	}
+	return &scm.Content{
+		Path: strings.Trim(path, "/"),
+		Data: data,
+		Sha:  out.Object.ID,
+	}, res, err
+}
+
+func (s *contentService) find(ctx context.Context, repo, path, ref, selection string) (*treeEntry, *scm.Response, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	params := ", $revspec: String!"
+	vars := map[string]interface{}{
+		"revspec": ref,
+	}
+	// the tree of the root is selected without a path.
+	if strings.Contains(selection, "$path") {
+		params += ", $path: String!"
+		vars["path"] = strings.Trim(path, "/")
+	}
+	out := new(struct {
+		Path *treeEntry `json:"path"`
+	})
+	res, err := s.client.owned(ctx, "git", repo, params, selection, vars, out)
+	if err != nil {
+		return nil, res, err
+	} else if out.Path == nil {
+		return nil, res, scm.ErrNotFound
+	}
+	return out.Path, res, nil
+}
+
+func (s *contentService) FindMany(ctx context.Context, repo, ref string, paths []string) (map[string]*scm.Content, map[string]error) {
+	return scm.FindManyContents(ctx, s, repo, ref, paths, scm.DefaultConcurrency)
+}
+
+// List returns the entries of the directory. The root of the
+// repository is the tree of the revision.
+func (s *contentService) List(ctx context.Context, repo, dir, ref string) ([]*scm.FileEntry, *scm.Response, error) {
+	dir = strings.Trim(dir, "/")
+	selection := `path(revspec: $revspec, path: $path) { name object { id type ... on Tree { entries { results { ` + entryFields + ` } } } } }`
+	if dir == "" {
+		selection = `path: revparse_single(revspec: $revspec) { object: tree { id type entries { results { ` + entryFields + ` } } } }`
+	}
+	out, res, err := s.find(ctx, repo, dir, ref, selection)
+	if err != nil {
+		return nil, res, err
+	}
+	if out.Object.Type != "TREE" {
+		return nil, res, scm.ErrNotFound
+	}
+	return convertEntryList(out.Object.Entries.Results, dir), res, nil
+}
+
+func (s *contentService) Exists(ctx context.Context, repo, path, ref string) (bool, *scm.Response, error) {
+	_, res, err := s.Stat(ctx, repo, path, ref)
+	if err == scm.ErrNotFound {
+		return false, res, nil
+	}
+	return err == nil, res, err
+}
+
+func (s *contentService) Stat(ctx context.Context, repo, path, ref string) (*scm.FileEntry, *scm.Response, error) {
+	out, res, err := s.find(ctx, repo, path, ref, pathSelection)
+	if err != nil {
+		return nil, res, err
+	}
+	dir := strings.Trim(path, "/")
+	if i := strings.LastIndex(dir, "/"); i != -1 {
+		dir = dir[:i]
+	} else {
+		dir = ""
+	}
+	return convertEntry(out, dir), res, nil
+}
+
+func (s *contentService) Create(context.Context, string, string, *scm.ContentParams) (*scm.Response, error) {
+	return nil, notSupported("Contents", "Create")
+}
+
+func (s *contentService) Update(context.Context, string, string, *scm.ContentParams) (*scm.Response, error) {
+	return nil, notSupported("Contents", "Update")
+}
+
+func (s *contentService) Delete(context.Context, string, string, string) (*scm.Response, error) {
+	return nil, notSupported("Contents", "Delete")
+}
+
+//
+// native data structures
+//
+
+type (
+	// git.sr.ht tree entry object.
+	treeEntry struct {
+		Name   string `json:"name"`
+		Object object `json:"object"`
+	}
+
+	// git.sr.ht git object. The type is COMMIT, TREE, BLOB
+	// or TAG, the text or base64 content is set for a blob,
+	// and the entries for a tree.
+	object struct {
+		ID      string `json:"id"`
+		Type    string `json:"type"`
+		Size    int    `json:"size"`
+		Text    string `json:"text"`
+		Base64  string `json:"base64"`
+		Entries struct {
+			Results []*treeEntry `json:"results"`
+		} `json:"entries"`
+	}
+)
+
+//
+// native data structure conversion
+//
+
+func convertEntryList(from []*treeEntry, dir string) []*scm.FileEntry {
+	to := make([]*scm.FileEntry, 0, len(from))
+	for _, v := range from {
+		to = append(to, convertEntry(v, dir))
+	}
+	return to
+}
+
+func convertEntry(from *treeEntry, dir string) *scm.FileEntry {
+	to := &scm.FileEntry{
+		Name: from.Name,
+		Path: path.Join(dir, from.Name),
+		Type: "file",
+		Size: from.Object.Size,
+		Sha:  from.Object.ID,
+	}
+	if from.Object.Type == "TREE" {
+		to.Type = "dir"
+	}
+	return to
+}
@@ -0,0 +1,116 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sourcehut
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestContentFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		BodyString(`"path":"README","revspec":"master"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/content.json")
+
+	client := NewDefault()
+	got, _, err := client.Contents.Find(context.Background(), "octocat/hello-world", "README", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &scm.Content{
+		Path: "README",
+		Data: []byte("Hello World!\n"),
+		Sha:  "980a0d5f19a64b4b30a87d4206aade58726b60e3",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestContentFind_Binary(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"data":{"user":{"repository":{"path":{"name":"logo.png","object":{"id":"5c8e","type":"BLOB","size":4,"base64":"iVBORw=="}}}}}}`)
+
+	client := NewDefault()
+	got, _, err := client.Contents.Find(context.Background(), "octocat/hello-world", "logo.png", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "\x89PNG"; string(got.Data) != want {
+		t.Errorf("Want data %q, got %q", want, got.Data)
+	}
+}
+
+func TestContentList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		BodyString(`"path":"docs"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/content_list.json")
+
+	client := NewDefault()
+	got, _, err := client.Contents.List(context.Background(), "octocat/hello-world", "docs/", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.FileEntry{}
+	raw, _ := ioutil.ReadFile("testdata/content_list.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestContentExists(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"data":{"user":{"repository":{"path":null}}}}`)
+
+	client := NewDefault()
+	got, _, err := client.Contents.Exists(context.Background(), "octocat/hello-world", "missing", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got {
+		t.Errorf("Expect the missing file not to exist")
+	}
+}
+
+func TestContentCreate_NotSupported(t *testing.T) {
+	client := NewDefault()
+	_, err := client.Contents.Create(context.Background(), "octocat/hello-world", "README", &scm.ContentParams{})
+	if !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error")
+	}
+}
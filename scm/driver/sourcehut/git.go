@@ -0,0 +1,234 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sourcehut
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+const commitFields = `id message author { name email time } committer { name email time } tree { id }`
+
+type gitService struct {
+	client *wrapper
+}
+
+func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *scm.Response, error) {
+	var res *scm.Response
+	var err error
+	for _, path := range scm.QualifyRefs(ref) {
+		var commit *scm.Commit
+		commit, res, err = s.FindCommit(ctx, repo, path)
+		if err == nil {
+			return commit.Sha, res, nil
+		} else if err != scm.ErrNotFound {
+			break
+		}
+	}
+	return "", res, err
+}
+
+func (s *gitService) ResolveRefs(ctx context.Context, repo string, refs []string) (map[string]string, map[string]error) {
+	return scm.ResolveRefs(ctx, s, repo, refs, scm.DefaultConcurrency)
+}
+
+func (s *gitService) CreateRef(context.Context, string, string, string) (*scm.Reference, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "CreateRef")
+}
+
+func (s *gitService) UpdateRef(context.Context, string, string, string, bool) (*scm.Reference, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "UpdateRef")
+}
+
+func (s *gitService) DeleteRef(context.Context, string, string) (*scm.Response, error) {
+	return nil, notSupported("Git", "DeleteRef")
+}
+
+func (s *gitService) FindBranch(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
+	return s.findRef(ctx, repo, scm.ExpandRef(name, "refs/heads"))
+}
+
+func (s *gitService) FindTag(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
+	return s.findRef(ctx, repo, scm.ExpandRef(name, "refs/tags"))
+}
+
+func (s *gitService) findRef(ctx context.Context, repo, ref string) (*scm.Reference, *scm.Response, error) {
+	commit, res, err := s.FindCommit(ctx, repo, ref)
+	if err != nil {
+		return nil, res, err
+	}
+	return &scm.Reference{
+		Name: scm.TrimRef(ref),
+		Path: ref,
+		Sha:  commit.Sha,
+	}, res, nil
+}
+
+// FindCommit finds the commit of the revision, which may be
+// a sha, a ref or any git revision.
+func (s *gitService) FindCommit(ctx context.Context, repo, ref string) (*scm.Commit, *scm.Response, error) {
+	vars := map[string]interface{}{
+		"revspec": ref,
+	}
+	out := new(struct {
+		Commit *commit `json:"revparse_single"`
+	})
+	selection := `revparse_single(revspec: $revspec) { ` + commitFields + ` }`
+	res, err := s.client.owned(ctx, "git", repo, ", $revspec: String!", selection, vars, out)
+	if err != nil {
+		return nil, res, err
+	} else if out.Commit == nil {
+		return nil, res, scm.ErrNotFound
+	}
+	return convertCommit(out.Commit), res, nil
+}
+
+func (s *gitService) ListBranches(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
+	return s.listRefs(ctx, repo, "refs/heads/", opts)
+}
+
+func (s *gitService) ListTags(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
+	return s.listRefs(ctx, repo, "refs/tags/", opts)
+}
+
+// listRefs returns the references of the page with the
+// prefix, since sr.ht lists the branches and the tags
+// together.
+func (s *gitService) listRefs(ctx context.Context, repo, prefix string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
+	vars := map[string]interface{}{
+		"cursor": pageCursor(opts),
+	}
+	out := new(struct {
+		References referenceCursor `json:"references"`
+	})
+	selection := `references(cursor: $cursor) { results { name target } cursor }`
+	res, err := s.client.owned(ctx, "git", repo, ", $cursor: Cursor", selection, vars, out)
+	if err != nil {
+		return nil, res, err
+	}
+	populatePageValues(res, out.References.Cursor)
+	return convertReferenceList(out.References.Results, prefix), res, nil
+}
+
+// ListCommits returns the first page of the log from the sha
+// or the ref, or from the HEAD of the repository.
+func (s *gitService) ListCommits(ctx context.Context, repo string, opts scm.CommitListOptions) ([]*scm.Commit, *scm.Response, error) {
+	from := opts.Sha
+	if from == "" {
+		from = opts.Ref
+	}
+	vars := map[string]interface{}{}
+	if from != "" {
+		vars["from"] = from
+	}
+	out := new(struct {
+		Log struct {
+			Results []*commit `json:"results"`
+		} `json:"log"`
+	})
+	selection := `log(from: $from) { results { ` + commitFields + ` } }`
+	res, err := s.client.owned(ctx, "git", repo, ", $from: String", selection, vars, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertCommitList(out.Log.Results), res, nil
+}
+
+func (s *gitService) ListChanges(context.Context, string, string, scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "ListChanges")
+}
+
+func (s *gitService) CompareCommits(context.Context, string, string, string) (*scm.Comparison, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "CompareCommits")
+}
+
+func (s *gitService) CompareAcrossForks(context.Context, string, string, string, string) (*scm.Comparison, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "CompareAcrossForks")
+}
+
+//
+// native data structures
+//
+
+type (
+	// git.sr.ht reference object. The target is the
+	// object the reference points to.
+	reference struct {
+		Name   string `json:"name"`
+		Target string `json:"target"`
+	}
+
+	// git.sr.ht page of references.
+	referenceCursor struct {
+		Results []*reference `json:"results"`
+		Cursor  *string      `json:"cursor"`
+	}
+
+	// git.sr.ht commit object.
+	commit struct {
+		ID        string    `json:"id"`
+		Message   string    `json:"message"`
+		Author    signature `json:"author"`
+		Committer signature `json:"committer"`
+		Tree      struct {
+			ID string `json:"id"`
+		} `json:"tree"`
+	}
+
+	// git.sr.ht commit signature.
+	signature struct {
+		Name  string    `json:"name"`
+		Email string    `json:"email"`
+		Time  time.Time `json:"time"`
+	}
+)
+
+//
+// native data structure conversion
+//
+
+func convertReferenceList(from []*reference, prefix string) []*scm.Reference {
+	to := []*scm.Reference{}
+	for _, v := range from {
+		if !strings.HasPrefix(v.Name, prefix) {
+			continue
+		}
+		to = append(to, &scm.Reference{
+			Name: scm.TrimRef(v.Name),
+			Path: v.Name,
+			Sha:  v.Target,
+		})
+	}
+	return to
+}
+
+func convertCommitList(from []*commit) []*scm.Commit {
+	to := []*scm.Commit{}
+	for _, v := range from {
+		to = append(to, convertCommit(v))
+	}
+	return to
+}
+
+func convertCommit(from *commit) *scm.Commit {
+	return &scm.Commit{
+		Sha:       from.ID,
+		Message:   from.Message,
+		Tree:      scm.CommitTree{Sha: from.Tree.ID},
+		Author:    convertSignature(&from.Author),
+		Committer: convertSignature(&from.Committer),
+	}
+}
+
+func convertSignature(from *signature) scm.Signature {
+	return scm.Signature{
+		Name:  from.Name,
+		Email: from.Email,
+		Date:  from.Time.UTC(),
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sourcehut
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestGitFindCommit(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		BodyString(`"revspec":"7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/commit.json")
+
+	client := NewDefault()
+	got, _, err := client.Git.FindCommit(context.Background(), "octocat/hello-world", "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Commit)
+	raw, _ := ioutil.ReadFile("testdata/commit.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitFindBranch(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		BodyString(`"revspec":"refs/heads/master"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/commit.json")
+
+	client := NewDefault()
+	got, _, err := client.Git.FindBranch(context.Background(), "octocat/hello-world", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &scm.Reference{
+		Name: "master",
+		Path: "refs/heads/master",
+		Sha:  "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitFindRef_Tag(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		BodyString(`"revspec":"refs/heads/v1.0.0"`).
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"data":{"user":{"repository":{"revparse_single":null}}}}`)
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		BodyString(`"revspec":"refs/tags/v1.0.0"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/commit.json")
+
+	client := NewDefault()
+	got, _, err := client.Git.FindRef(context.Background(), "octocat/hello-world", "v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"; got != want {
+		t.Errorf("Want sha %q, got %q", want, got)
+	}
+}
+
+func TestGitListBranches(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		Reply(200).
+		Type("application/json").
+		File("testdata/refs.json")
+
+	client := NewDefault()
+	got, _, err := client.Git.ListBranches(context.Background(), "octocat/hello-world", scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Reference{}
+	raw, _ := ioutil.ReadFile("testdata/branches.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitListTags(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		Reply(200).
+		Type("application/json").
+		File("testdata/refs.json")
+
+	client := NewDefault()
+	got, _, err := client.Git.ListTags(context.Background(), "octocat/hello-world", scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Reference{}
+	raw, _ := ioutil.ReadFile("testdata/tags.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitListChanges_NotSupported(t *testing.T) {
+	client := NewDefault()
+	_, _, err := client.Git.ListChanges(context.Background(), "octocat/hello-world", "master", scm.ListOptions{})
+	if !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error")
+	}
+}
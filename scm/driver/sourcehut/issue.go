@@ -0,0 +1,392 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sourcehut
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+const (
+	ticketFields = `id created updated subject body status submitter { canonicalName } assignees { canonicalName } labels { id name backgroundColor }`
+
+	eventFields = `id created changes { eventType ... on Comment { text author { canonicalName } } }`
+)
+
+type issueService struct {
+	client *wrapper
+}
+
+func (s *issueService) Find(ctx context.Context, repo string, number int) (*scm.Issue, *scm.Response, error) {
+	out, res, err := s.find(ctx, repo, number, ticketFields)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertIssue(out, s.link(repo)), res, nil
+}
+
+func (s *issueService) find(ctx context.Context, repo string, number int, fields string) (*ticket, *scm.Response, error) {
+	vars := map[string]interface{}{
+		"id": number,
+	}
+	out := new(struct {
+		Ticket *ticket `json:"ticket"`
+	})
+	selection := `ticket(id: $id) { ` + fields + ` }`
+	res, err := s.client.owned(ctx, "todo", repo, ", $id: Int!", selection, vars, out)
+	if err != nil {
+		return nil, res, err
+	} else if out.Ticket == nil {
+		return nil, res, scm.ErrNotFound
+	}
+	return out.Ticket, res, nil
+}
+
+// tracker returns the identifier of the tracker, which the
+// mutations take.
+func (s *issueService) tracker(ctx context.Context, repo string) (int, *scm.Response, error) {
+	out := new(struct {
+		ID int `json:"id"`
+	})
+	res, err := s.client.owned(ctx, "todo", repo, "", "id", nil, out)
+	return out.ID, res, err
+}
+
+// link returns the address of the tracker, since sr.ht does
+// not report the address of the tickets.
+func (s *issueService) link(repo string) *url.URL {
+	owner, name := splitRepo(repo)
+	return s.client.service("todo").ResolveReference(&url.URL{Path: fmt.Sprintf("~%s/%s/", owner, name)})
+}
+
+func (s *issueService) FindComment(context.Context, string, int, int) (*scm.Comment, *scm.Response, error) {
+	return nil, nil, notSupported("Issues", "FindComment")
+}
+
+// List returns the first page of the tickets of the tracker,
+// filtered by state.
+func (s *issueService) List(ctx context.Context, repo string, opts scm.IssueListOptions) ([]*scm.Issue, *scm.Response, error) {
+	out := new(struct {
+		Tickets struct {
+			Results []*ticket `json:"results"`
+			Cursor  *string   `json:"cursor"`
+		} `json:"tickets"`
+	})
+	selection := `tickets { results { ` + ticketFields + ` } cursor }`
+	res, err := s.client.owned(ctx, "todo", repo, "", selection, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	populatePageValues(res, out.Tickets.Cursor)
+	issues := []*scm.Issue{}
+	for _, v := range convertIssueList(out.Tickets.Results, s.link(repo)) {
+		if (v.Closed && opts.Closed) || (!v.Closed && opts.Open) || (!opts.Open && !opts.Closed) {
+			issues = append(issues, v)
+		}
+	}
+	return scm.FilterIssues(issues, opts.UpdatedSince), res, nil
+}
+
+func (s *issueService) Search(context.Context, scm.SearchOptions) ([]*scm.SearchIssue, *scm.Response, error) {
+	return nil, nil, notSupported("Issues", "Search")
+}
+
+// ListComments returns the comments of the first page of the
+// events of the ticket.
+func (s *issueService) ListComments(ctx context.Context, repo string, index int, opts scm.CommentListOptions) ([]*scm.Comment, *scm.Response, error) {
+	out, res, err := s.find(ctx, repo, index, `events { results { `+eventFields+` } }`)
+	if err != nil {
+		return nil, res, err
+	}
+	link := s.link(repo)
+	comments := []*scm.Comment{}
+	for _, v := range out.Events.Results {
+		if comment := convertComment(v, index, link); comment != nil {
+			comments = append(comments, comment)
+		}
+	}
+	return scm.FilterComments(comments, opts), res, nil
+}
+
+func (s *issueService) ListLabels(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
+	out, res, err := s.find(ctx, repo, number, `labels { id name backgroundColor }`)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertLabelList(out.Labels), res, nil
+}
+
+func (s *issueService) ListEvents(context.Context, string, int, scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
+	return nil, nil, notSupported("Issues", "ListEvents")
+}
+
+// Create submits the ticket to the tracker of the repository.
+// The labels and the assignees are not set, since they are
+// edited by separate mutations.
+func (s *issueService) Create(ctx context.Context, repo string, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	id, res, err := s.tracker(ctx, repo)
+	if err != nil {
+		return nil, res, err
+	}
+	query := `mutation($trackerId: Int!, $input: SubmitTicketInput!) {
+  submitTicket(trackerId: $trackerId, input: $input) { ` + ticketFields + ` }
+}`
+	vars := map[string]interface{}{
+		"trackerId": id,
+		"input": map[string]interface{}{
+			"subject": input.Title,
+			"body":    input.Body,
+		},
+	}
+	out := new(struct {
+		SubmitTicket ticket `json:"submitTicket"`
+	})
+	res, err = s.client.graphql(ctx, "todo", query, vars, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertIssue(&out.SubmitTicket, s.link(repo)), res, nil
+}
+
+func (s *issueService) Update(context.Context, string, int, *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	return nil, nil, notSupported("Issues", "Update")
+}
+
+func (s *issueService) CreateComment(ctx context.Context, repo string, number int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
+	id, res, err := s.tracker(ctx, repo)
+	if err != nil {
+		return nil, res, err
+	}
+	query := `mutation($trackerId: Int!, $ticketId: Int!, $input: SubmitCommentInput!) {
+  submitComment(trackerId: $trackerId, ticketId: $ticketId, input: $input) { ` + eventFields + ` }
+}`
+	vars := map[string]interface{}{
+		"trackerId": id,
+		"ticketId":  number,
+		"input": map[string]interface{}{
+			"text": input.Body,
+		},
+	}
+	out := new(struct {
+		SubmitComment event `json:"submitComment"`
+	})
+	res, err = s.client.graphql(ctx, "todo", query, vars, out)
+	if err != nil {
+		return nil, res, err
+	}
+	comment := convertComment(&out.SubmitComment, number, s.link(repo))
+	if comment == nil {
+		comment = &scm.Comment{ID: out.SubmitComment.ID, Body: input.Body}
+	}
+	return comment, res, nil
+}
+
+func (s *issueService) DeleteComment(context.Context, string, int, int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "DeleteComment")
+}
+
+func (s *issueService) EditComment(context.Context, string, int, int, *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
+	return nil, nil, notSupported("Issues", "EditComment")
+}
+
+func (s *issueService) MinimizeComment(context.Context, string, int, int, string) (*scm.Response, error) {
+	return nil, notSupported("Issues", "MinimizeComment")
+}
+
+func (s *issueService) UnminimizeComment(context.Context, string, int, int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "UnminimizeComment")
+}
+
+// Close resolves the ticket as fixed.
+func (s *issueService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setStatus(ctx, repo, number, map[string]interface{}{
+		"status":     "RESOLVED",
+		"resolution": "FIXED",
+	})
+}
+
+// Reopen reports the ticket again.
+func (s *issueService) Reopen(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setStatus(ctx, repo, number, map[string]interface{}{
+		"status": "REPORTED",
+	})
+}
+
+func (s *issueService) setStatus(ctx context.Context, repo string, number int, input map[string]interface{}) (*scm.Response, error) {
+	id, res, err := s.tracker(ctx, repo)
+	if err != nil {
+		return res, err
+	}
+	query := `mutation($trackerId: Int!, $ticketId: Int!, $input: UpdateStatusInput!) {
+  updateTicketStatus(trackerId: $trackerId, ticketId: $ticketId, input: $input) { id }
+}`
+	vars := map[string]interface{}{
+		"trackerId": id,
+		"ticketId":  number,
+		"input":     input,
+	}
+	return s.client.graphql(ctx, "todo", query, vars, nil)
+}
+
+func (s *issueService) Lock(context.Context, string, int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "Lock")
+}
+
+func (s *issueService) Unlock(context.Context, string, int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "Unlock")
+}
+
+func (s *issueService) AddLabel(context.Context, string, int, string) (*scm.Response, error) {
+	return nil, notSupported("Issues", "AddLabel")
+}
+
+func (s *issueService) DeleteLabel(context.Context, string, int, string) (*scm.Response, error) {
+	return nil, notSupported("Issues", "DeleteLabel")
+}
+
+func (s *issueService) AssignIssue(context.Context, string, int, []string) (*scm.Response, error) {
+	return nil, notSupported("Issues", "AssignIssue")
+}
+
+func (s *issueService) UnassignIssue(context.Context, string, int, []string) (*scm.Response, error) {
+	return nil, notSupported("Issues", "UnassignIssue")
+}
+
+func (s *issueService) SetMilestone(context.Context, string, int, int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "SetMilestone")
+}
+
+func (s *issueService) ClearMilestone(context.Context, string, int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "ClearMilestone")
+}
+
+func (s *issueService) Pin(context.Context, string, int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "Pin")
+}
+
+func (s *issueService) Unpin(context.Context, string, int) (*scm.Response, error) {
+	return nil, notSupported("Issues", "Unpin")
+}
+
+func (s *issueService) ListPinned(context.Context, string) ([]*scm.Issue, *scm.Response, error) {
+	return nil, nil, notSupported("Issues", "ListPinned")
+}
+
+//
+// native data structures
+//
+
+type (
+	// todo.sr.ht ticket object. The id is the number of the
+	// ticket in its tracker, and the status is REPORTED,
+	// CONFIRMED, IN_PROGRESS, PENDING or RESOLVED.
+	ticket struct {
+		ID        int       `json:"id"`
+		Created   time.Time `json:"created"`
+		Updated   time.Time `json:"updated"`
+		Subject   string    `json:"subject"`
+		Body      string    `json:"body"`
+		Status    string    `json:"status"`
+		Submitter entity    `json:"submitter"`
+		Assignees []*entity `json:"assignees"`
+		Labels    []*label  `json:"labels"`
+		Events    struct {
+			Results []*event `json:"results"`
+		} `json:"events"`
+	}
+
+	// todo.sr.ht label object.
+	label struct {
+		ID              int64  `json:"id"`
+		Name            string `json:"name"`
+		BackgroundColor string `json:"backgroundColor"`
+	}
+
+	// todo.sr.ht ticket event, of which the comment changes
+	// are requested.
+	event struct {
+		ID      int       `json:"id"`
+		Created time.Time `json:"created"`
+		Changes []struct {
+			EventType string `json:"eventType"`
+			Text      string `json:"text"`
+			Author    entity `json:"author"`
+		} `json:"changes"`
+	}
+)
+
+//
+// native data structure conversion
+//
+
+func convertIssueList(from []*ticket, tracker *url.URL) []*scm.Issue {
+	to := []*scm.Issue{}
+	for _, v := range from {
+		to = append(to, convertIssue(v, tracker))
+	}
+	return to
+}
+
+func convertIssue(from *ticket, tracker *url.URL) *scm.Issue {
+	closed := from.Status == "RESOLVED"
+	to := &scm.Issue{
+		Number:  from.ID,
+		Title:   from.Subject,
+		Body:    from.Body,
+		Link:    tracker.ResolveReference(&url.URL{Path: fmt.Sprint(from.ID)}).String(),
+		State:   scm.IssueStateOpen,
+		Labels:  convertLabelList(from.Labels),
+		Closed:  closed,
+		Author:  *convertEntity(&from.Submitter),
+		Created: from.Created.UTC(),
+		Updated: from.Updated.UTC(),
+	}
+	if closed {
+		to.State = scm.IssueStateClosed
+	}
+	for _, v := range from.Assignees {
+		to.Assignees = append(to.Assignees, *convertEntity(v))
+	}
+	return to
+}
+
+func convertLabelList(from []*label) []*scm.Label {
+	var labels []*scm.Label
+	for _, label := range from {
+		labels = append(labels, &scm.Label{
+			ID:    label.ID,
+			Name:  label.Name,
+			Color: label.BackgroundColor,
+		})
+	}
+	return labels
+}
+
+// convertComment returns the comment of the event, or nil if
+// the event has no comment change.
+func convertComment(from *event, number int, tracker *url.URL) *scm.Comment {
+	for _, change := range from.Changes {
+		if change.EventType != "COMMENT" {
+			continue
+		}
+		link := tracker.ResolveReference(&url.URL{
+			Path:     fmt.Sprint(number),
+			Fragment: fmt.Sprintf("event-%d", from.ID),
+		})
+		return &scm.Comment{
+			ID:      from.ID,
+			Body:    change.Text,
+			Author:  *convertEntity(&change.Author),
+			Link:    link.String(),
+			Created: from.Created.UTC(),
+			Updated: from.Created.UTC(),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sourcehut
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestIssueFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://todo.sr.ht").
+		Post("/query").
+		BodyString(`"id":12,"name":"hello-world","owner":"octocat"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	client := NewDefault()
+	got, _, err := client.Issues.Find(context.Background(), "octocat/hello-world", 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Issue)
+	raw, _ := ioutil.ReadFile("testdata/issue.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://todo.sr.ht").
+		Post("/query").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issues.json")
+
+	client := NewDefault()
+	got, _, err := client.Issues.List(context.Background(), "octocat/hello-world", scm.IssueListOptions{Open: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Issue{}
+	raw, _ := ioutil.ReadFile("testdata/issues.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueListComments(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://todo.sr.ht").
+		Post("/query").
+		Reply(200).
+		Type("application/json").
+		File("testdata/comments.json")
+
+	client := NewDefault()
+	got, _, err := client.Issues.ListComments(context.Background(), "octocat/hello-world", 12, scm.CommentListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Comment{}
+	raw, _ := ioutil.ReadFile("testdata/comments.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueCreateComment(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://todo.sr.ht").
+		Post("/query").
+		Reply(200).
+		Type("application/json").
+		File("testdata/tracker.json")
+
+	gock.New("https://todo.sr.ht").
+		Post("/query").
+		BodyString(`"ticketId":12,"trackerId":7`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/comment.json")
+
+	client := NewDefault()
+	got, _, err := client.Issues.CreateComment(context.Background(), "octocat/hello-world", 12, &scm.CommentInput{Body: "Fixed in master"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != 303 || got.Body != "Fixed in master" || got.Author.Login != "octocat" {
+		t.Errorf("Unexpected comment %v", got)
+	}
+}
+
+func TestIssueClose(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://todo.sr.ht").
+		Post("/query").
+		Reply(200).
+		Type("application/json").
+		File("testdata/tracker.json")
+
+	gock.New("https://todo.sr.ht").
+		Post("/query").
+		BodyString(`"status":"RESOLVED"`).
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"data":{"updateTicketStatus":{"id":304}}}`)
+
+	client := NewDefault()
+	_, err := client.Issues.Close(context.Background(), "octocat/hello-world", 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the ticket to be resolved")
+	}
+}
+
+func TestIssueLock_NotSupported(t *testing.T) {
+	client := NewDefault()
+	_, err := client.Issues.Lock(context.Background(), "octocat/hello-world", 12)
+	if !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error")
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sourcehut
+
+import (
+	"context"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// pullService is a stub: sr.ht accepts the changes as patches
+// sent to the mailing lists, it has no pull requests.
+type pullService struct {
+	client *wrapper
+}
+
+func (s *pullService) Find(context.Context, string, int) (*scm.PullRequest, *scm.Response, error) {
+	return nil, nil, notSupported("PullRequests", "Find")
+}
+
+func (s *pullService) Update(context.Context, string, int, *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
+	return nil, nil, notSupported("PullRequests", "Update")
+}
+
+func (s *pullService) FindComment(context.Context, string, int, int) (*scm.Comment, *scm.Response, error) {
+	return nil, nil, notSupported("PullRequests", "FindComment")
+}
+
+func (s *pullService) List(context.Context, string, scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
+	return nil, nil, notSupported("PullRequests", "List")
+}
+
+func (s *pullService) ListChanges(context.Context, string, int, scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
+	return nil, nil, notSupported("PullRequests", "ListChanges")
+}
+
+func (s *pullService) ListComments(context.Context, string, int, scm.CommentListOptions) ([]*scm.Comment, *scm.Response, error) {
+	return nil, nil, notSupported("PullRequests", "ListComments")
+}
+
+func (s *pullService) ListLabels(context.Context, string, int, scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
+	return nil, nil, notSupported("PullRequests", "ListLabels")
+}
+
+func (s *pullService) ListEvents(context.Context, string, int, scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
+	return nil, nil, notSupported("PullRequests", "ListEvents")
+}
+
+func (s *pullService) Merge(context.Context, string, int, *scm.PullRequestMergeOptions) (*scm.Response, error) {
+	return nil, notSupported("PullRequests", "Merge")
+}
+
+func (s *pullService) Close(context.Context, string, int) (*scm.Response, error) {
+	return nil, notSupported("PullRequests", "Close")
+}
+
+func (s *pullService) Reopen(context.Context, string, int) (*scm.Response, error) {
+	return nil, notSupported("PullRequests", "Reopen")
+}
+
+func (s *pullService) CreateComment(context.Context, string, int, *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
+	return nil, nil, notSupported("PullRequests", "CreateComment")
+}
+
+func (s *pullService) DeleteComment(context.Context, string, int, int) (*scm.Response, error) {
+	return nil, notSupported("PullRequests", "DeleteComment")
+}
+
+func (s *pullService) EditComment(context.Context, string, int, int, *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
+	return nil, nil, notSupported("PullRequests", "EditComment")
+}
+
+func (s *pullService) AddLabel(context.Context, string, int, string) (*scm.Response, error) {
+	return nil, notSupported("PullRequests", "AddLabel")
+}
+
+func (s *pullService) DeleteLabel(context.Context, string, int, string) (*scm.Response, error) {
+	return nil, notSupported("PullRequests", "DeleteLabel")
+}
+
+func (s *pullService) AssignIssue(context.Context, string, int, []string) (*scm.Response, error) {
+	return nil, notSupported("PullRequests", "AssignIssue")
+}
+
+func (s *pullService) UnassignIssue(context.Context, string, int, []string) (*scm.Response, error) {
+	return nil, notSupported("PullRequests", "UnassignIssue")
+}
+
+func (s *pullService) Create(context.Context, string, *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
+	return nil, nil, notSupported("PullRequests", "Create")
+}
+
+func (s *pullService) RequestReview(context.Context, string, int, []string) (*scm.Response, error) {
+	return nil, notSupported("PullRequests", "RequestReview")
+}
+
+func (s *pullService) UnrequestReview(context.Context, string, int, []string) (*scm.Response, error) {
+	return nil, notSupported("PullRequests", "UnrequestReview")
+}
+
+func (s *pullService) SetMilestone(context.Context, string, int, int) (*scm.Response, error) {
+	return nil, notSupported("PullRequests", "SetMilestone")
+}
+
+func (s *pullService) ClearMilestone(context.Context, string, int) (*scm.Response, error) {
+	return nil, notSupported("PullRequests", "ClearMilestone")
+}
@@ -0,0 +1,302 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sourcehut
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+const repositoryFields = `id name description visibility created updated owner { canonicalName } HEAD { name }`
+
+type repositoryService struct {
+	client *wrapper
+}
+
+func (s *repositoryService) Find(ctx context.Context, repo string) (*scm.Repository, *scm.Response, error) {
+	out, res, err := s.find(ctx, repo)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertRepository(out, s.client.BaseURL), res, nil
+}
+
+func (s *repositoryService) find(ctx context.Context, repo string) (*repository, *scm.Response, error) {
+	out := new(repository)
+	res, err := s.client.owned(ctx, "git", repo, "", repositoryFields, nil, out)
+	return out, res, err
+}
+
+func (s *repositoryService) FindHook(context.Context, string, string) (*scm.Hook, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "FindHook")
+}
+
+// FindPerms returns the permissions of the repository. The
+// sr.ht API does not report the permissions of the user, so
+// the owner is the admin of the repository and the other
+// users may only pull it.
+func (s *repositoryService) FindPerms(ctx context.Context, repo string) (*scm.Perm, *scm.Response, error) {
+	me, _, err := s.client.Users.Find(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	out, res, err := s.find(ctx, repo)
+	if err != nil {
+		return nil, res, err
+	}
+	owner := entityLogin(&out.Owner) == me.Login
+	return &scm.Perm{Pull: true, Push: owner, Admin: owner}, res, nil
+}
+
+func (s *repositoryService) FindUserPermission(context.Context, string, string) (string, *scm.Response, error) {
+	return "", nil, notSupported("Repositories", "FindUserPermission")
+}
+
+func (s *repositoryService) List(ctx context.Context, opts scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	query := `query($cursor: Cursor) {
+  me { repositories(cursor: $cursor) { results { ` + repositoryFields + ` } cursor } }
+}`
+	vars := map[string]interface{}{
+		"cursor": pageCursor(opts),
+	}
+	out := new(struct {
+		Me struct {
+			Repositories repositoryCursor `json:"repositories"`
+		} `json:"me"`
+	})
+	res, err := s.client.graphql(ctx, "git", query, vars, out)
+	populatePageValues(res, out.Me.Repositories.Cursor)
+	return convertRepositoryList(out.Me.Repositories.Results, s.client.BaseURL), res, err
+}
+
+// ListOrganisation returns the repositories of the user, as
+// sr.ht has no organisations.
+func (s *repositoryService) ListOrganisation(ctx context.Context, org string, opts scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	return s.ListUser(ctx, org, opts)
+}
+
+func (s *repositoryService) ListUser(ctx context.Context, username string, opts scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	query := `query($owner: String!, $cursor: Cursor) {
+  user(username: $owner) { repositories(cursor: $cursor) { results { ` + repositoryFields + ` } cursor } }
+}`
+	vars := map[string]interface{}{
+		"owner":  strings.TrimPrefix(username, "~"),
+		"cursor": pageCursor(opts),
+	}
+	out := new(struct {
+		User *struct {
+			Repositories repositoryCursor `json:"repositories"`
+		} `json:"user"`
+	})
+	res, err := s.client.graphql(ctx, "git", query, vars, out)
+	if err != nil {
+		return nil, res, err
+	} else if out.User == nil {
+		return nil, res, scm.ErrNotFound
+	}
+	populatePageValues(res, out.User.Repositories.Cursor)
+	return convertRepositoryList(out.User.Repositories.Results, s.client.BaseURL), res, nil
+}
+
+func (s *repositoryService) ListLabels(context.Context, string, scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListLabels")
+}
+
+func (s *repositoryService) ListHooks(context.Context, string, scm.ListOptions) ([]*scm.Hook, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListHooks")
+}
+
+func (s *repositoryService) ListStatus(context.Context, string, string, scm.ListOptions) ([]*scm.Status, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListStatus")
+}
+
+func (s *repositoryService) FindCombinedStatus(context.Context, string, string) (*scm.CombinedStatus, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "FindCombinedStatus")
+}
+
+// Create creates the repository of the authenticated user,
+// as sr.ht has no organisations.
+func (s *repositoryService) Create(ctx context.Context, input *scm.RepositoryInput) (*scm.Repository, *scm.Response, error) {
+	query := `mutation($name: String!, $visibility: Visibility!, $description: String) {
+  createRepository(name: $name, visibility: $visibility, description: $description) { ` + repositoryFields + ` }
+}`
+	visibility := "PUBLIC"
+	if input.Private {
+		visibility = "PRIVATE"
+	}
+	vars := map[string]interface{}{
+		"name":        input.Name,
+		"visibility":  visibility,
+		"description": input.Description,
+	}
+	out := new(struct {
+		CreateRepository repository `json:"createRepository"`
+	})
+	res, err := s.client.graphql(ctx, "git", query, vars, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertRepository(&out.CreateRepository, s.client.BaseURL), res, nil
+}
+
+func (s *repositoryService) Fork(context.Context, *scm.RepositoryInput, string) (*scm.Repository, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "Fork")
+}
+
+func (s *repositoryService) CreateHook(context.Context, string, *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "CreateHook")
+}
+
+func (s *repositoryService) CreateStatus(context.Context, string, string, *scm.StatusInput) (*scm.Status, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "CreateStatus")
+}
+
+func (s *repositoryService) DeleteHook(context.Context, string, string) (*scm.Response, error) {
+	return nil, notSupported("Repositories", "DeleteHook")
+}
+
+// Delete deletes the repository, which is looked up first
+// since the mutation takes the repository identifier.
+func (s *repositoryService) Delete(ctx context.Context, repo string) (*scm.Response, error) {
+	out, res, err := s.find(ctx, repo)
+	if err != nil {
+		return res, err
+	}
+	query := `mutation($id: Int!) { deleteRepository(id: $id) { id } }`
+	vars := map[string]interface{}{
+		"id": out.ID,
+	}
+	return s.client.graphql(ctx, "git", query, vars, nil)
+}
+
+func (s *repositoryService) IsCollaborator(context.Context, string, string) (bool, *scm.Response, error) {
+	return false, nil, notSupported("Repositories", "IsCollaborator")
+}
+
+func (s *repositoryService) AddCollaborator(context.Context, string, string, string) (bool, bool, *scm.Response, error) {
+	return false, false, nil, notSupported("Repositories", "AddCollaborator")
+}
+
+func (s *repositoryService) ListCollaborators(context.Context, string, scm.ListOptions) ([]scm.User, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListCollaborators")
+}
+
+func (s *repositoryService) GetDependencyGraph(context.Context, string) (*scm.DependencyGraph, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "GetDependencyGraph")
+}
+
+func (s *repositoryService) ExportSBOM(context.Context, string) (io.ReadCloser, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ExportSBOM")
+}
+
+func (s *repositoryService) ListRulesets(context.Context, string, scm.ListOptions) ([]*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListRulesets")
+}
+
+func (s *repositoryService) FindRuleset(context.Context, string, int) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "FindRuleset")
+}
+
+func (s *repositoryService) CreateRuleset(context.Context, string, *scm.RulesetInput) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "CreateRuleset")
+}
+
+func (s *repositoryService) UpdateRuleset(context.Context, string, int, *scm.RulesetInput) (*scm.Ruleset, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "UpdateRuleset")
+}
+
+func (s *repositoryService) GetPages(context.Context, string) (*scm.Pages, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "GetPages")
+}
+
+func (s *repositoryService) EnablePages(context.Context, string, *scm.PagesInput) (*scm.Pages, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "EnablePages")
+}
+
+func (s *repositoryService) DisablePages(context.Context, string) (*scm.Response, error) {
+	return nil, notSupported("Repositories", "DisablePages")
+}
+
+func (s *repositoryService) GetLatestPagesBuild(context.Context, string) (*scm.PagesBuild, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "GetLatestPagesBuild")
+}
+
+func (s *repositoryService) ListHookDeliveries(context.Context, string, string, scm.ListOptions) ([]*scm.HookDelivery, *scm.Response, error) {
+	return nil, nil, notSupported("Repositories", "ListHookDeliveries")
+}
+
+func (s *repositoryService) RedeliverHookDelivery(context.Context, string, string, int64) (*scm.Response, error) {
+	return nil, notSupported("Repositories", "RedeliverHookDelivery")
+}
+
+//
+// native data structures
+//
+
+type (
+	// git.sr.ht repository object. The visibility is
+	// PUBLIC, UNLISTED or PRIVATE.
+	repository struct {
+		ID          int        `json:"id"`
+		Name        string     `json:"name"`
+		Description string     `json:"description"`
+		Visibility  string     `json:"visibility"`
+		Created     time.Time  `json:"created"`
+		Updated     time.Time  `json:"updated"`
+		Owner       entity     `json:"owner"`
+		HEAD        *reference `json:"HEAD"`
+	}
+
+	// git.sr.ht page of repositories.
+	repositoryCursor struct {
+		Results []*repository `json:"results"`
+		Cursor  *string       `json:"cursor"`
+	}
+)
+
+//
+// native data structure conversion
+//
+
+func convertRepositoryList(from []*repository, base *url.URL) []*scm.Repository {
+	to := []*scm.Repository{}
+	for _, v := range from {
+		to = append(to, convertRepository(v, base))
+	}
+	return to
+}
+
+// convertRepository converts the repository. The links are
+// made from the address of the git service, since sr.ht does
+// not report them.
+func convertRepository(from *repository, base *url.URL) *scm.Repository {
+	owner := entityLogin(&from.Owner)
+	link := fmt.Sprintf("%s://%s/~%s/%s", base.Scheme, base.Host, owner, from.Name)
+	to := &scm.Repository{
+		Namespace: owner,
+		Name:      from.Name,
+		FullName:  scm.Join(owner, from.Name),
+		Private:   from.Visibility == "PRIVATE",
+		Clone:     link,
+		CloneSSH:  fmt.Sprintf("git@%s:~%s/%s", base.Hostname(), owner, from.Name),
+		Link:      link,
+		Created:   from.Created.UTC(),
+		Updated:   from.Updated.UTC(),
+	}
+	if from.ID != 0 {
+		to.ID = strconv.Itoa(from.ID)
+	}
+	if from.HEAD != nil {
+		to.Branch = scm.TrimRef(from.HEAD.Name)
+	}
+	return to
+}
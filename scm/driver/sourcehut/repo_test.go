@@ -0,0 +1,175 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sourcehut
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestRepositoryFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		BodyString(`"name":"hello-world","owner":"octocat"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/repo.json")
+
+	client := NewDefault()
+	got, _, err := client.Repositories.Find(context.Background(), "~octocat/hello-world")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Repository)
+	raw, _ := ioutil.ReadFile("testdata/repo.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestRepositoryPerms(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		Reply(200).
+		Type("application/json").
+		File("testdata/user.json")
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		Reply(200).
+		Type("application/json").
+		File("testdata/repo.json")
+
+	client := NewDefault()
+	got, _, err := client.Repositories.FindPerms(context.Background(), "octocat/hello-world")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &scm.Perm{Pull: true, Push: true, Admin: true}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestRepositoryList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		Reply(200).
+		Type("application/json").
+		File("testdata/repos.json")
+
+	client := NewDefault()
+	got, res, err := client.Repositories.List(context.Background(), scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Repository{}
+	raw, _ := ioutil.ReadFile("testdata/repos.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	if got, want := res.Page.NextURL, "MTIz"; got != want {
+		t.Errorf("Want next page cursor %q, got %q", want, got)
+	}
+}
+
+func TestRepositoryList_Cursor(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		BodyString(`"cursor":"MTIz"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/repos.json")
+
+	client := NewDefault()
+	_, _, err := client.Repositories.List(context.Background(), scm.ListOptions{URL: "MTIz"})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRepositoryCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		BodyString(`"visibility":"PRIVATE"`).
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"data":{"createRepository":{"id":43,"name":"dotfiles","visibility":"PRIVATE","owner":{"canonicalName":"~octocat"}}}}`)
+
+	client := NewDefault()
+	input := &scm.RepositoryInput{
+		Name:    "dotfiles",
+		Private: true,
+	}
+	got, _, err := client.Repositories.Create(context.Background(), input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.FullName != "octocat/dotfiles" || !got.Private {
+		t.Errorf("Unexpected repository %v", got)
+	}
+}
+
+func TestRepositoryDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		Reply(200).
+		Type("application/json").
+		File("testdata/repo.json")
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		BodyString(`"id":42`).
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"data":{"deleteRepository":{"id":42}}}`)
+
+	client := NewDefault()
+	_, err := client.Repositories.Delete(context.Background(), "octocat/hello-world")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the repository to be deleted")
+	}
+}
+
+func TestRepositoryHooks_NotSupported(t *testing.T) {
+	client := NewDefault()
+	_, _, err := client.Repositories.ListHooks(context.Background(), "octocat/hello-world", scm.ListOptions{})
+	if !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error")
+	}
+}
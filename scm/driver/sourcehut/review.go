@@ -0,0 +1,49 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sourcehut
+
+import (
+	"context"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// reviewService is a stub: sr.ht reviews the patches sent
+// to the mailing lists, it has no pull request reviews.
+type reviewService struct {
+	client *wrapper
+}
+
+func (s *reviewService) Find(context.Context, string, int, int) (*scm.Review, *scm.Response, error) {
+	return nil, nil, notSupported("Reviews", "Find")
+}
+
+func (s *reviewService) List(context.Context, string, int, scm.ListOptions) ([]*scm.Review, *scm.Response, error) {
+	return nil, nil, notSupported("Reviews", "List")
+}
+
+func (s *reviewService) Create(context.Context, string, int, *scm.ReviewInput) (*scm.Review, *scm.Response, error) {
+	return nil, nil, notSupported("Reviews", "Create")
+}
+
+func (s *reviewService) Delete(context.Context, string, int, int) (*scm.Response, error) {
+	return nil, notSupported("Reviews", "Delete")
+}
+
+func (s *reviewService) ListComments(context.Context, string, int, int, scm.ListOptions) ([]*scm.ReviewComment, *scm.Response, error) {
+	return nil, nil, notSupported("Reviews", "ListComments")
+}
+
+func (s *reviewService) Update(context.Context, string, int, int, string) (*scm.Review, *scm.Response, error) {
+	return nil, nil, notSupported("Reviews", "Update")
+}
+
+func (s *reviewService) Submit(context.Context, string, int, int, *scm.ReviewSubmitInput) (*scm.Review, *scm.Response, error) {
+	return nil, nil, notSupported("Reviews", "Submit")
+}
+
+func (s *reviewService) Dismiss(context.Context, string, int, int, string) (*scm.Review, *scm.Response, error) {
+	return nil, nil, notSupported("Reviews", "Dismiss")
+}
@@ -0,0 +1,276 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sourcehut implements a SourceHut (sr.ht) client.
+//
+// SourceHut splits the forge in services with their own
+// GraphQL API: the repositories, refs and contents are read
+// from git.sr.ht, the tickets of todo.sr.ht are exposed as
+// the issues, and builds are submitted to builds.sr.ht. The
+// tracker of a repository is the tracker of the same owner
+// and name, which is the sr.ht convention.
+//
+// The webhooks of sr.ht deliver the result of a GraphQL
+// query chosen when the webhook is created. The payloads are
+// parsed when the webhook is created with GitWebhookQuery or
+// TodoWebhookQuery.
+package sourcehut
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// Reference API Documentation:
+//   https://man.sr.ht/graphql.md
+//   https://man.sr.ht/git.sr.ht/graphql.md
+//   https://man.sr.ht/todo.sr.ht/graphql.md
+//   https://man.sr.ht/builds.sr.ht/graphql.md
+
+// NewWebHookService creates a new instance of the webhook service without the rest of the client
+func NewWebHookService(opts ...scm.WebhookServiceOptions) scm.WebhookService {
+	s := &webhookService{}
+	if len(opts) > 0 {
+		s.opts = opts[0]
+	}
+	return s
+}
+
+// New returns a new SourceHut API client. The uri is the
+// address of the git service, such as https://git.sr.ht. The
+// todo and builds services are found by replacing the git
+// label of the host, or by prefixing the host with their
+// name when it has no git label.
+func New(uri string) (*scm.Client, error) {
+	base, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path = base.Path + "/"
+	}
+	client := &wrapper{new(scm.Client)}
+	client.BaseURL = base
+	// initialize services
+	client.Driver = scm.DriverSourcehut
+	client.SetCaller(client.do)
+	client.Contents = &contentService{client}
+	client.Git = &gitService{client}
+	client.Issues = &issueService{client}
+	client.Milestones = &milestoneService{client}
+	client.Organizations = &organizationService{client}
+	client.PullRequests = &pullService{client}
+	client.Repositories = &repositoryService{client}
+	client.Reviews = &reviewService{client}
+	client.Users = &userService{client}
+	client.Webhooks = &webhookService{client: client}
+	return client.Client, nil
+}
+
+// NewDefault returns a new SourceHut API client using the
+// default git.sr.ht address.
+func NewDefault() *scm.Client {
+	client, _ := New("https://git.sr.ht")
+	return client
+}
+
+// wraper wraps the Client to provide high level helper functions
+// for making http requests and unmarshaling the response.
+type wrapper struct {
+	*scm.Client
+}
+
+// service returns the address of the named sr.ht service,
+// which shares the scheme and the domain of the git service.
+func (c *wrapper) service(name string) *url.URL {
+	return serviceURL(c.BaseURL, name)
+}
+
+func serviceURL(base *url.URL, name string) *url.URL {
+	host := base.Host
+	if name != "git" {
+		host = name + "." + strings.TrimPrefix(host, "git.")
+	}
+	return &url.URL{Scheme: base.Scheme, Host: host, Path: "/"}
+}
+
+// do wraps the Client.Do function by creating the Request and
+// unmarshalling the response.
+func (c *wrapper) do(ctx context.Context, method, path string, in, out interface{}) (*scm.Response, error) {
+	req := &scm.Request{
+		Method: method,
+		Path:   path,
+	}
+	// if we are posting or putting data, we need to
+	// write it to the body of the request.
+	if in != nil {
+		buf := new(bytes.Buffer)
+		json.NewEncoder(buf).Encode(in) // #nosec
+		req.Header = map[string][]string{
+			"Content-Type": {"application/json"},
+		}
+		req.Body = buf
+	}
+
+	// execute the http request
+	res, err := c.Client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// if an error is encountered, unmarshal and return the
+	// error response.
+	if res.Status == http.StatusNotFound {
+		return res, scm.ErrNotFound
+	} else if res.Status == http.StatusUnauthorized {
+		return res, scm.ErrNotAuthorized
+	} else if res.Status > 300 {
+		err := &Error{status: res.Status}
+		envelope := new(graphqlResponse)
+		json.NewDecoder(res.Body).Decode(envelope) // #nosec
+		if len(envelope.Errors) != 0 {
+			err.Message = envelope.Errors[0].Message
+		}
+		return res, err
+	}
+
+	if out == nil {
+		return res, nil
+	}
+
+	// if raw output is expected, copy to the provided
+	// buffer and exit.
+	if w, ok := out.(io.Writer); ok {
+		_, err := io.Copy(w, res.Body)
+		return res, err
+	}
+
+	// if a json response is expected, parse and return
+	// the json response.
+	return res, json.NewDecoder(res.Body).Decode(out)
+}
+
+// graphqlResponse is the envelope of a GraphQL response.
+type graphqlResponse struct {
+	Data   interface{} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphql sends the GraphQL query or mutation to the query
+// endpoint of the named service, decoding the response data
+// into out.
+func (c *wrapper) graphql(ctx context.Context, service, query string, vars map[string]interface{}, out interface{}) (*scm.Response, error) {
+	in := map[string]interface{}{
+		"query":     query,
+		"variables": vars,
+	}
+	endpoint := c.service(service).ResolveReference(&url.URL{Path: "query"})
+	envelope := &graphqlResponse{Data: out}
+	res, err := c.do(ctx, "POST", endpoint.String(), in, envelope)
+	if err != nil {
+		return res, err
+	}
+	if len(envelope.Errors) != 0 {
+		return res, &Error{Message: envelope.Errors[0].Message}
+	}
+	return res, nil
+}
+
+// ownedResponse is the data of a query of a repository or a
+// tracker of the user.
+type ownedResponse struct {
+	User *ownedUser `json:"user"`
+}
+
+type ownedUser struct {
+	Repository interface{} `json:"repository"`
+	Tracker    interface{} `json:"tracker"`
+}
+
+// owned sends the query of the selection of the repository
+// of git.sr.ht, or of the tracker of todo.sr.ht, with the
+// owner and the name of the repository, decoding the selected
+// object into out. The params declare the variables of the
+// selection.
+func (c *wrapper) owned(ctx context.Context, service, repo, params, selection string, vars map[string]interface{}, out interface{}) (*scm.Response, error) {
+	field := "repository"
+	if service == "todo" {
+		field = "tracker"
+	}
+	query := fmt.Sprintf(`query($owner: String!, $name: String!%s) {
+  user(username: $owner) { %s(name: $name) { %s } }
+}`, params, field, selection)
+	if vars == nil {
+		vars = map[string]interface{}{}
+	}
+	vars["owner"], vars["name"] = splitRepo(repo)
+	data := &ownedResponse{
+		User: &ownedUser{Repository: out, Tracker: out},
+	}
+	res, err := c.graphql(ctx, service, query, vars, data)
+	if err != nil {
+		return res, err
+	}
+	if data.User == nil || (field == "repository" && data.User.Repository == nil) ||
+		(field == "tracker" && data.User.Tracker == nil) {
+		return res, scm.ErrNotFound
+	}
+	return res, nil
+}
+
+// splitRepo returns the owner and the name of the repository,
+// accepting the owner with or without its canonical tilde.
+func splitRepo(repo string) (owner, name string) {
+	owner, name = scm.Split(repo)
+	return strings.TrimPrefix(owner, "~"), name
+}
+
+// pageCursor returns the cursor of the requested page, which
+// is passed in the url of the list options.
+func pageCursor(opts scm.ListOptions) interface{} {
+	if opts.URL == "" {
+		return nil
+	}
+	return opts.URL
+}
+
+// populatePageValues sets the cursor of the next page, which
+// is reported in the next url of the response.
+func populatePageValues(res *scm.Response, cursor *string) {
+	if res == nil || cursor == nil {
+		return
+	}
+	res.Page.NextURL = *cursor
+}
+
+// Error represents a SourceHut error.
+type Error struct {
+	Message string `json:"message"`
+
+	status int
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return http.StatusText(e.status)
+	}
+	return e.Message
+}
+
+// notSupported returns the error of a method of a service
+// the driver does not support.
+func notSupported(service, method string) error {
+	return &scm.NotSupportedError{Driver: scm.DriverSourcehut, Service: service, Method: method}
+}
@@ -0,0 +1,177 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sourcehut
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestClient(t *testing.T) {
+	client, err := New("https://git.sr.ht")
+	if err != nil {
+		t.Error(err)
+	}
+	if got, want := client.BaseURL.String(), "https://git.sr.ht/"; got != want {
+		t.Errorf("Want Client URL %q, got %q", want, got)
+	}
+}
+
+func TestClient_Default(t *testing.T) {
+	client := NewDefault()
+	if got, want := client.BaseURL.String(), "https://git.sr.ht/"; got != want {
+		t.Errorf("Want Client URL %q, got %q", want, got)
+	}
+	if got, want := client.Driver, scm.DriverSourcehut; got != want {
+		t.Errorf("Want Driver %v, got %v", want, got)
+	}
+}
+
+func TestClient_Error(t *testing.T) {
+	_, err := New("http://a b.com/")
+	if err == nil {
+		t.Errorf("Expect error when invalid URL")
+	}
+}
+
+func TestClient_Services(t *testing.T) {
+	client := NewDefault()
+	services := map[string]interface{}{
+		"Contents":      client.Contents,
+		"Git":           client.Git,
+		"Issues":        client.Issues,
+		"Milestones":    client.Milestones,
+		"Organizations": client.Organizations,
+		"PullRequests":  client.PullRequests,
+		"Repositories":  client.Repositories,
+		"Reviews":       client.Reviews,
+		"Users":         client.Users,
+		"Webhooks":      client.Webhooks,
+	}
+	for name, service := range services {
+		if reflect.ValueOf(service).IsNil() {
+			t.Errorf("Want the %s service set", name)
+		}
+	}
+
+	ctx := context.Background()
+	if _, _, err := client.PullRequests.List(ctx, "~octocat/hello-world", scm.PullRequestListOptions{}); !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error from the pull request service, got %v", err)
+	}
+	if _, _, err := client.Reviews.List(ctx, "~octocat/hello-world", 1, scm.ListOptions{}); !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error from the review service, got %v", err)
+	}
+	if _, _, err := client.Milestones.List(ctx, "~octocat/hello-world", scm.MilestoneListOptions{}); !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error from the milestone service, got %v", err)
+	}
+	if _, _, err := client.Organizations.Find(ctx, "octocat"); !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Expect Not Supported error from the organization service, got %v", err)
+	}
+}
+
+func TestServiceURL(t *testing.T) {
+	tests := []struct {
+		base, service, want string
+	}{
+		{"https://git.sr.ht/", "git", "https://git.sr.ht/"},
+		{"https://git.sr.ht/", "todo", "https://todo.sr.ht/"},
+		{"https://git.sr.ht/", "builds", "https://builds.sr.ht/"},
+		{"http://git.example.com:5001/", "todo", "http://todo.example.com:5001/"},
+		{"https://example.com/", "todo", "https://todo.example.com/"},
+	}
+	for _, test := range tests {
+		client, _ := New(test.base)
+		got := (&wrapper{client}).service(test.service).String()
+		if got != test.want {
+			t.Errorf("Want %s service of %s %q, got %q", test.service, test.base, test.want, got)
+		}
+	}
+}
+
+func TestClient_GraphQLError(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"data":null,"errors":[{"message":"Access denied"}]}`)
+
+	client := NewDefault()
+	_, _, err := client.Users.Find(context.Background())
+	if err == nil || err.Error() != "Access denied" {
+		t.Errorf("Want the error message of the response, got %v", err)
+	}
+}
+
+func TestClient_NotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"data":{"user":null}}`)
+
+	client := NewDefault()
+	_, _, err := client.Repositories.Find(context.Background(), "~octocat/hello-world")
+	if err != scm.ErrNotFound {
+		t.Errorf("Want scm.ErrNotFound, got %v", err)
+	}
+}
+
+func TestSubmitBuild(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://builds.sr.ht").
+		Post("/query").
+		BodyString(`"manifest":"image: alpine/edge`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/build.json")
+
+	input := &BuildInput{
+		Manifest: "image: alpine/edge\ntasks:\n  - test: make test\n",
+		Note:     "Test build",
+		Tags:     []string{"hello-world"},
+	}
+	got, _, err := SubmitBuild(context.Background(), NewDefault(), input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Job{
+		ID:      1024,
+		Status:  "PENDING",
+		Note:    "Test build",
+		Tags:    []string{"hello-world"},
+		Link:    "https://builds.sr.ht/~octocat/job/1024",
+		Created: time.Date(2021, 4, 3, 8, 0, 0, 0, time.UTC),
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSubmitBuild_NotSupported(t *testing.T) {
+	client := &scm.Client{Driver: scm.DriverGithub}
+	_, _, err := SubmitBuild(context.Background(), client, &BuildInput{})
+	if !errors.Is(err, scm.ErrNotSupported) {
+		t.Errorf("Want scm.ErrNotSupported, got %v", err)
+	}
+	want := &scm.NotSupportedError{Driver: scm.DriverSourcehut, Service: "Builds", Method: "SubmitBuild"}
+	if diff := cmp.Diff(err, error(want)); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
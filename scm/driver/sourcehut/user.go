@@ -0,0 +1,130 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sourcehut
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+const userFields = `id canonicalName username email url created updated`
+
+type userService struct {
+	client *wrapper
+}
+
+func (s *userService) CreateToken(context.Context, string, string) (*scm.UserToken, *scm.Response, error) {
+	return nil, nil, notSupported("Users", "CreateToken")
+}
+
+func (s *userService) DeleteToken(context.Context, int64) (*scm.Response, error) {
+	return nil, notSupported("Users", "DeleteToken")
+}
+
+func (s *userService) TokenInfo(context.Context) (*scm.TokenInfo, *scm.Response, error) {
+	return nil, nil, notSupported("Users", "TokenInfo")
+}
+
+func (s *userService) ListTokens(context.Context, scm.ListOptions) ([]*scm.UserToken, *scm.Response, error) {
+	return nil, nil, notSupported("Users", "ListTokens")
+}
+
+func (s *userService) Find(ctx context.Context) (*scm.User, *scm.Response, error) {
+	query := `query { me { ` + userFields + ` } }`
+	out := new(struct {
+		Me user `json:"me"`
+	})
+	res, err := s.client.graphql(ctx, "git", query, nil, out)
+	return convertUser(&out.Me), res, err
+}
+
+func (s *userService) FindLogin(ctx context.Context, login string) (*scm.User, *scm.Response, error) {
+	query := `query($username: String!) { user(username: $username) { ` + userFields + ` } }`
+	vars := map[string]interface{}{
+		"username": strings.TrimPrefix(login, "~"),
+	}
+	out := new(struct {
+		User *user `json:"user"`
+	})
+	res, err := s.client.graphql(ctx, "git", query, vars, out)
+	if err != nil {
+		return nil, res, err
+	} else if out.User == nil {
+		return nil, res, scm.ErrNotFound
+	}
+	return convertUser(out.User), res, nil
+}
+
+func (s *userService) FindEmail(ctx context.Context) (string, *scm.Response, error) {
+	user, res, err := s.Find(ctx)
+	return user.Email, res, err
+}
+
+func (s *userService) ListInvitations(context.Context) ([]*scm.Invitation, *scm.Response, error) {
+	return nil, nil, notSupported("Users", "ListInvitations")
+}
+
+func (s *userService) AcceptInvitation(context.Context, int64) (*scm.Response, error) {
+	return nil, notSupported("Users", "AcceptInvitation")
+}
+
+//
+// native data structures
+//
+
+type (
+	// sr.ht user object. The canonical name is the
+	// username prefixed with a tilde.
+	user struct {
+		ID            int       `json:"id"`
+		CanonicalName string    `json:"canonicalName"`
+		Username      string    `json:"username"`
+		Email         string    `json:"email"`
+		URL           string    `json:"url"`
+		Created       time.Time `json:"created"`
+		Updated       time.Time `json:"updated"`
+	}
+
+	// sr.ht entity object, the owner or the author of a
+	// resource, of which only the canonical name is
+	// requested.
+	entity struct {
+		CanonicalName string `json:"canonicalName"`
+	}
+)
+
+//
+// native data structure conversion
+//
+
+func convertUser(from *user) *scm.User {
+	login := from.Username
+	if login == "" {
+		login = strings.TrimPrefix(from.CanonicalName, "~")
+	}
+	return &scm.User{
+		ID:      from.ID,
+		Login:   login,
+		Email:   from.Email,
+		Link:    from.URL,
+		Created: from.Created.UTC(),
+		Updated: from.Updated.UTC(),
+	}
+}
+
+func convertEntity(from *entity) *scm.User {
+	return &scm.User{
+		Login: entityLogin(from),
+	}
+}
+
+// entityLogin returns the login of the entity, which is the
+// canonical name without its tilde.
+func entityLogin(from *entity) string {
+	return strings.TrimPrefix(from.CanonicalName, "~")
+}
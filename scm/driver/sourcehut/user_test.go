@@ -0,0 +1,80 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sourcehut
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestUserFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		Reply(200).
+		Type("application/json").
+		File("testdata/user.json")
+
+	client := NewDefault()
+	got, _, err := client.Users.Find(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.User)
+	raw, _ := ioutil.ReadFile("testdata/user.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestUserFindLogin(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		BodyString(`"username":"octocat"`).
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"data":{"user":{"id":1,"canonicalName":"~octocat","username":"octocat"}}}`)
+
+	client := NewDefault()
+	got, _, err := client.Users.FindLogin(context.Background(), "~octocat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Login != "octocat" {
+		t.Errorf("Want login octocat, got %q", got.Login)
+	}
+}
+
+func TestUserFindEmail(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.sr.ht").
+		Post("/query").
+		Reply(200).
+		Type("application/json").
+		File("testdata/user.json")
+
+	client := NewDefault()
+	email, _, err := client.Users.FindEmail(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := email, "octocat@example.com"; got != want {
+		t.Errorf("Want user Email %q, got %q", want, got)
+	}
+}
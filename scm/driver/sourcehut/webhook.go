@@ -0,0 +1,343 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sourcehut
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+const (
+	// GitWebhookQuery is the query of the git.sr.ht webhooks
+	// parsed by the webhook service, to be passed when the
+	// webhook is created. The GIT_POST_RECEIVE event is
+	// parsed as a push.
+	GitWebhookQuery = `query {
+  webhook {
+    uuid event date
+    ... on GitEvent {
+      repository { ` + repositoryFields + ` }
+      pusher { canonicalName }
+      updates {
+        ref { name }
+        old { id }
+        new { id ... on Commit { ` + commitFields + ` } }
+      }
+    }
+  }
+}`
+
+	// TodoWebhookQuery is the query of the todo.sr.ht
+	// webhooks parsed by the webhook service, to be passed
+	// when the webhook is created. The TICKET_CREATED and
+	// TICKET_UPDATE events are parsed as issues, and the
+	// EVENT_CREATED events of a comment as issue comments.
+	TodoWebhookQuery = `query {
+  webhook {
+    uuid event date
+    ... on TicketEvent {
+      ticket { ` + ticketFields + ` tracker { ` + trackerFields + ` } }
+    }
+    ... on EventCreated {
+      newEvent {
+        ` + eventFields + `
+        ticket { ` + ticketFields + ` tracker { ` + trackerFields + ` } }
+      }
+    }
+  }
+}`
+
+	trackerFields = `name created updated visibility owner { canonicalName }`
+
+	// defaultGitURL is the address of the git service of
+	// the webhook service created without a client.
+	defaultGitURL = "https://git.sr.ht/"
+)
+
+type webhookService struct {
+	client *wrapper
+	opts   scm.WebhookServiceOptions
+}
+
+func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
+	return s.ParseMulti(req, scm.Secrets(fn))
+}
+
+func (s *webhookService) ParseMulti(req *http.Request, fn scm.MultiSecretFunc) (scm.Webhook, error) {
+	body, data, err := s.opts.ReadBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var hook scm.Webhook
+	event := req.Header.Get("X-Webhook-Event")
+	switch event {
+	case "GIT_POST_RECEIVE":
+		hook, err = s.parsePushHook(data)
+	case "TICKET_CREATED", "TICKET_UPDATE":
+		hook, err = s.parseIssueHook(data)
+	case "EVENT_CREATED":
+		hook, err = s.parseIssueCommentHook(data)
+	default:
+		return nil, scm.UnknownWebhook{Event: event}
+	}
+	if err != nil {
+		return nil, err
+	}
+	scm.SetGUID(hook, req.Header.Get("X-Webhook-Delivery"))
+	if s.opts.RawPayload {
+		scm.SetRaw(hook, data)
+	}
+
+	// get the base64 encoded public keys of the sr.ht
+	// instance to verify the payload signature. If no key
+	// is provided, no validation is performed.
+	keys, err := fn(hook)
+	if err != nil {
+		return hook, err
+	} else if len(keys) == 0 {
+		return hook, nil
+	}
+
+	signature := req.Header.Get("X-Payload-Signature")
+	if signature == "" {
+		return hook, scm.ErrSignatureInvalid
+	}
+	nonce := req.Header.Get("X-Payload-Nonce")
+	if !scm.ValidateAny(keys, func(key string) bool { return validateSignature(body, nonce, signature, key) }) {
+		return hook, scm.ErrSignatureInvalid
+	}
+
+	return hook, nil
+}
+
+// validateSignature reports whether the signature is the
+// base64 encoded Ed25519 signature of the body followed by
+// the nonce, made with the private key of the public key.
+func validateSignature(body []byte, nonce, signature, key string) bool {
+	pub, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return false
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	msg := append(append([]byte{}, body...), nonce...)
+	return ed25519.Verify(ed25519.PublicKey(pub), msg, sig)
+}
+
+// base returns the address of the git service, which the
+// links of the payload are made from.
+func (s *webhookService) base() *url.URL {
+	if s.client != nil {
+		return s.client.BaseURL
+	}
+	base, _ := url.Parse(defaultGitURL)
+	return base
+}
+
+func (s *webhookService) parsePushHook(data []byte) (scm.Webhook, error) {
+	dst := new(gitWebhook)
+	if err := json.Unmarshal(data, dst); err != nil {
+		return nil, err
+	}
+	return convertPushHook(&dst.Data.Webhook, s.base()), nil
+}
+
+func (s *webhookService) parseIssueHook(data []byte) (scm.Webhook, error) {
+	dst := new(todoWebhook)
+	if err := json.Unmarshal(data, dst); err != nil {
+		return nil, err
+	}
+	src := &dst.Data.Webhook
+	if src.Ticket == nil {
+		return nil, scm.UnknownWebhook{Event: src.Event}
+	}
+	return convertIssueHook(src, s.base()), nil
+}
+
+func (s *webhookService) parseIssueCommentHook(data []byte) (scm.Webhook, error) {
+	dst := new(todoWebhook)
+	if err := json.Unmarshal(data, dst); err != nil {
+		return nil, err
+	}
+	src := &dst.Data.Webhook
+	if src.NewEvent == nil {
+		return nil, scm.UnknownWebhook{Event: src.Event}
+	}
+	hook := convertIssueCommentHook(src, s.base())
+	if hook == nil {
+		// only the events of a comment are parsed.
+		return nil, scm.UnknownWebhook{Event: src.Event}
+	}
+	return hook, nil
+}
+
+//
+// native data structures
+//
+
+type (
+	// git.sr.ht webhook payload of the GitWebhookQuery.
+	gitWebhook struct {
+		Data struct {
+			Webhook gitEvent `json:"webhook"`
+		} `json:"data"`
+	}
+
+	// git.sr.ht git event.
+	gitEvent struct {
+		UUID       string       `json:"uuid"`
+		Event      string       `json:"event"`
+		Date       time.Time    `json:"date"`
+		Repository repository   `json:"repository"`
+		Pusher     entity       `json:"pusher"`
+		Updates    []*updateRef `json:"updates"`
+	}
+
+	// git.sr.ht updated reference. The old object is null
+	// when the reference is created, and the new object when
+	// it is deleted.
+	updateRef struct {
+		Ref struct {
+			Name string `json:"name"`
+		} `json:"ref"`
+		Old *struct {
+			ID string `json:"id"`
+		} `json:"old"`
+		New *commit `json:"new"`
+	}
+
+	// todo.sr.ht webhook payload of the TodoWebhookQuery.
+	todoWebhook struct {
+		Data struct {
+			Webhook todoEvent `json:"webhook"`
+		} `json:"data"`
+	}
+
+	// todo.sr.ht ticket or event created event.
+	todoEvent struct {
+		UUID     string        `json:"uuid"`
+		Event    string        `json:"event"`
+		Date     time.Time     `json:"date"`
+		Ticket   *hookTicket   `json:"ticket"`
+		NewEvent *hookEventNew `json:"newEvent"`
+	}
+
+	// todo.sr.ht ticket of a webhook payload, with its
+	// tracker.
+	hookTicket struct {
+		ticket
+		Tracker tracker `json:"tracker"`
+	}
+
+	// todo.sr.ht event of a webhook payload, with its
+	// ticket.
+	hookEventNew struct {
+		event
+		Ticket hookTicket `json:"ticket"`
+	}
+
+	// todo.sr.ht tracker object.
+	tracker struct {
+		Name       string    `json:"name"`
+		Created    time.Time `json:"created"`
+		Updated    time.Time `json:"updated"`
+		Visibility string    `json:"visibility"`
+		Owner      entity    `json:"owner"`
+	}
+)
+
+//
+// native data structure conversion
+//
+
+// convertPushHook converts the first updated reference of
+// the push, since sr.ht reports the references updated by a
+// push together.
+func convertPushHook(src *gitEvent, base *url.URL) *scm.PushHook {
+	dst := &scm.PushHook{
+		Repo:   *convertRepository(&src.Repository, base),
+		Sender: *convertEntity(&src.Pusher),
+	}
+	if len(src.Updates) == 0 || src.Updates[0] == nil {
+		return dst
+	}
+	update := src.Updates[0]
+	dst.Ref = update.Ref.Name
+	dst.Before = scm.EmptyCommit
+	if update.Old != nil {
+		dst.Before = update.Old.ID
+	}
+	dst.After = scm.EmptyCommit
+	if update.New != nil {
+		dst.After = update.New.ID
+		dst.Commit = *convertCommit(update.New)
+		dst.Commit.Link = fmt.Sprintf("%s/commit/%s", dst.Repo.Link, update.New.ID)
+	}
+	dst.Created = dst.Before == scm.EmptyCommit
+	dst.Deleted = dst.After == scm.EmptyCommit
+	if !dst.Created && !dst.Deleted {
+		dst.Compare = fmt.Sprintf("%s/log/%s", dst.Repo.Link, dst.After)
+	}
+	return dst
+}
+
+func convertIssueHook(src *todoEvent, base *url.URL) *scm.IssueHook {
+	dst := &scm.IssueHook{
+		Action: scm.ActionUpdate,
+		Issue:  *convertIssue(&src.Ticket.ticket, trackerLink(&src.Ticket.Tracker, base)),
+		Repo:   *convertTracker(&src.Ticket.Tracker, base),
+		Sender: *convertEntity(&src.Ticket.Submitter),
+	}
+	if src.Event == "TICKET_CREATED" {
+		dst.Action = scm.ActionOpen
+	}
+	return dst
+}
+
+// convertIssueCommentHook returns the hook of the comment of
+// the event, or nil if the event has no comment change.
+func convertIssueCommentHook(src *todoEvent, base *url.URL) *scm.IssueCommentHook {
+	ticket := &src.NewEvent.Ticket
+	link := trackerLink(&ticket.Tracker, base)
+	comment := convertComment(&src.NewEvent.event, ticket.ID, link)
+	if comment == nil {
+		return nil
+	}
+	return &scm.IssueCommentHook{
+		Action:  scm.ActionCreate,
+		Issue:   *convertIssue(&ticket.ticket, link),
+		Comment: *comment,
+		Repo:    *convertTracker(&ticket.Tracker, base),
+		Sender:  comment.Author,
+	}
+}
+
+// convertTracker converts the tracker to the repository of
+// the same owner and name.
+func convertTracker(from *tracker, base *url.URL) *scm.Repository {
+	return convertRepository(&repository{
+		Name:       from.Name,
+		Visibility: from.Visibility,
+		Created:    from.Created,
+		Updated:    from.Updated,
+		Owner:      from.Owner,
+	}, base)
+}
+
+func trackerLink(from *tracker, base *url.URL) *url.URL {
+	return serviceURL(base, "todo").ResolveReference(&url.URL{
+		Path: fmt.Sprintf("%s/%s/", from.Owner.CanonicalName, from.Name),
+	})
+}
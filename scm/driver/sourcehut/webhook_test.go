@@ -0,0 +1,176 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sourcehut
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/slimm609/go-scm/scm"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// signingKey is the key signing the test payloads, in place
+// of the key of the sr.ht instance.
+var signingKey = ed25519.NewKeyFromSeed(bytes.Repeat([]byte{7}, ed25519.SeedSize))
+
+func TestWebhooks(t *testing.T) {
+	tests := []struct {
+		event  string
+		before string
+		after  string
+		obj    interface{}
+	}{
+		// push hooks
+		{
+			event:  "GIT_POST_RECEIVE",
+			before: "testdata/webhooks/push.json",
+			after:  "testdata/webhooks/push.json.golden",
+			obj:    new(scm.PushHook),
+		},
+		{
+			event:  "GIT_POST_RECEIVE",
+			before: "testdata/webhooks/push_created.json",
+			after:  "testdata/webhooks/push_created.json.golden",
+			obj:    new(scm.PushHook),
+		},
+		// issue hooks
+		{
+			event:  "TICKET_CREATED",
+			before: "testdata/webhooks/ticket_created.json",
+			after:  "testdata/webhooks/ticket_created.json.golden",
+			obj:    new(scm.IssueHook),
+		},
+		// issue comment hooks
+		{
+			event:  "EVENT_CREATED",
+			before: "testdata/webhooks/event_comment.json",
+			after:  "testdata/webhooks/event_comment.json.golden",
+			obj:    new(scm.IssueCommentHook),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.before, func(t *testing.T) {
+			before, err := ioutil.ReadFile(test.before)
+			if err != nil {
+				t.Fatal(err)
+			}
+			after, err := ioutil.ReadFile(test.after)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r, _ := http.NewRequest("POST", "/", bytes.NewBuffer(before))
+			r.Header.Set("X-Webhook-Event", test.event)
+			r.Header.Set("X-Webhook-Delivery", "3f1f7c1e-9a63-4b0e-8d3c-6a0d5e2f4b77")
+			sign(r, before, "d1e2a3b4")
+
+			s := new(webhookService)
+			o, err := s.Parse(r, secretFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = json.Unmarshal(after, test.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(test.obj, o); diff != "" {
+				t.Errorf("Error unmarshaling %s", test.before)
+				t.Log(diff)
+			}
+		})
+	}
+}
+
+func TestWebhook_ErrUnknownEvent(t *testing.T) {
+	f, _ := ioutil.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("POST", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-Webhook-Event", "REPO_CREATED")
+
+	s := new(webhookService)
+	_, err := s.Parse(r, secretFunc)
+	if !scm.IsUnknownWebhook(err) {
+		t.Errorf("Expect unknown event error, got %v", err)
+	}
+}
+
+func TestWebhook_StatusChangeEvent(t *testing.T) {
+	f, _ := ioutil.ReadFile("testdata/webhooks/event_comment.json")
+	f = bytes.Replace(f, []byte(`"eventType": "COMMENT"`), []byte(`"eventType": "STATUS_CHANGE"`), 1)
+	r, _ := http.NewRequest("POST", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-Webhook-Event", "EVENT_CREATED")
+
+	s := new(webhookService)
+	_, err := s.Parse(r, secretFunc)
+	if !scm.IsUnknownWebhook(err) {
+		t.Errorf("Expect unknown event error, got %v", err)
+	}
+}
+
+func TestWebhookInvalid(t *testing.T) {
+	f, _ := ioutil.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("POST", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-Webhook-Event", "GIT_POST_RECEIVE")
+	sign(r, f, "d1e2a3b4")
+	r.Header.Set("X-Payload-Nonce", "d1e2a3b5")
+
+	s := new(webhookService)
+	_, err := s.Parse(r, secretFunc)
+	if err != scm.ErrSignatureInvalid {
+		t.Errorf("Expect invalid signature error, got %v", err)
+	}
+}
+
+func TestWebhookMissingSignature(t *testing.T) {
+	f, _ := ioutil.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("POST", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-Webhook-Event", "GIT_POST_RECEIVE")
+
+	s := new(webhookService)
+	_, err := s.Parse(r, secretFunc)
+	if err != scm.ErrSignatureInvalid {
+		t.Errorf("Expect invalid signature error, got %v", err)
+	}
+}
+
+func TestWebhook_ClientLinks(t *testing.T) {
+	f, _ := ioutil.ReadFile("testdata/webhooks/ticket_created.json")
+	r, _ := http.NewRequest("POST", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-Webhook-Event", "TICKET_CREATED")
+
+	client, _ := New("https://git.example.com")
+	hook, err := client.Webhooks.Parse(r, func(scm.Webhook) (string, error) { return "", nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	issue := hook.(*scm.IssueHook)
+	if got, want := issue.Issue.Link, "https://todo.example.com/~octocat/hello-world/12"; got != want {
+		t.Errorf("Want issue link %q, got %q", want, got)
+	}
+	if got, want := issue.Repo.Link, "https://git.example.com/~octocat/hello-world"; got != want {
+		t.Errorf("Want repository link %q, got %q", want, got)
+	}
+}
+
+// sign sets the signature headers of the payload.
+func sign(r *http.Request, body []byte, nonce string) {
+	sig := ed25519.Sign(signingKey, append(append([]byte{}, body...), nonce...))
+	r.Header.Set("X-Payload-Signature", base64.StdEncoding.EncodeToString(sig))
+	r.Header.Set("X-Payload-Nonce", nonce)
+}
+
+func secretFunc(scm.Webhook) (string, error) {
+	pub := signingKey.Public().(ed25519.PublicKey)
+	return base64.StdEncoding.EncodeToString(pub), nil
+}
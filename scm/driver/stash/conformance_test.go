@@ -0,0 +1,59 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stash
+
+import (
+	"testing"
+
+	"github.com/slimm609/go-scm/scm/conformance"
+
+	"github.com/h2non/gock"
+)
+
+func TestConformance(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo-does-not-exist$").
+		Persist().
+		Reply(404).
+		Type("application/json").
+		File("testdata/error.json")
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/repo.json")
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/branches$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/branches.json")
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/tags$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/tags.json")
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/commits/master$").
+		Persist().
+		Reply(200).
+		Type("application/json").
+		File("testdata/commit.json")
+
+	client, _ := New("http://example.com:7990")
+	conformance.Run(t, conformance.Config{
+		Client: client,
+		Repo:   "PRJ/my-repo",
+		Branch: "master",
+	})
+}
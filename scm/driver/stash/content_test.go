@@ -7,6 +7,7 @@ package stash
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"testing"
 
@@ -42,10 +43,60 @@ func TestContentFind(t *testing.T) {
 	}
 }
 
+func TestContentStat(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/browse/docs/README").
+		MatchParam("at", "master").
+		MatchParam("type", "true").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"type":"FILE"}`)
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Contents.Stat(context.Background(), "PRJ/my-repo", "docs/README", "master")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := &scm.FileEntry{
+		Name: "README",
+		Path: "docs/README",
+		Type: "file",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestContentExists(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/browse/MISSING").
+		MatchParam("at", "master").
+		MatchParam("type", "true").
+		Reply(404).
+		Type("application/json").
+		BodyString(`{"errors":[{"context":null,"message":"The path \"MISSING\" does not exist at revision \"master\"","exceptionName":"com.atlassian.bitbucket.content.NoSuchPathException"}]}`)
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Contents.Exists(context.Background(), "PRJ/my-repo", "MISSING", "master")
+	if err != nil {
+		t.Error(err)
+	}
+	if got {
+		t.Errorf("Expect file does not exist")
+	}
+}
+
 func TestContentCreate(t *testing.T) {
 	content := new(contentService)
 	_, err := content.Create(context.Background(), "atlassian/atlaskit", "README", nil)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -53,7 +104,7 @@ func TestContentCreate(t *testing.T) {
 func TestContentUpdate(t *testing.T) {
 	content := new(contentService)
 	_, err := content.Update(context.Background(), "atlassian/atlaskit", "README", nil)
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -61,7 +112,7 @@ func TestContentUpdate(t *testing.T) {
 func TestContentDelete(t *testing.T) {
 	content := new(contentService)
 	_, err := content.Delete(context.Background(), "atlassian/atlaskit", "README", "master")
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -0,0 +1,37 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stash
+
+import (
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/poll"
+)
+
+// DefaultPollInterval is the time between two polls of the event
+// source returned by NewEventSource. Bitbucket Server installs are
+// usually on the same network as their clients, so they are polled
+// more often than the hosted providers.
+const DefaultPollInterval = 10 * time.Second
+
+// NewEventSource returns an event source polling the branches and
+// pull requests of the Bitbucket Server repositories every interval,
+// for air-gapped installs where Bitbucket Server cannot reach the
+// webhook endpoint. The interval defaults to DefaultPollInterval.
+//
+// Bitbucket Server has no event stream in its REST API, the plugins
+// streaming events can be supported by other implementations of
+// scm.EventSource.
+func NewEventSource(client *scm.Client, repos []string, interval time.Duration) scm.EventSource {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	return &poll.Poller{
+		Client:   client,
+		Repos:    repos,
+		Interval: interval,
+	}
+}
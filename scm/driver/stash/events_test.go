@@ -0,0 +1,81 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stash
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestEventSource(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo").
+		Reply(200).
+		Type("application/json").
+		File("testdata/repo.json")
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/branches").
+		Reply(200).
+		Type("application/json").
+		File("testdata/branches.json")
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests").
+		Reply(200).
+		Type("application/json").
+		File("testdata/prs.json")
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/branches").
+		Reply(200).
+		Type("application/json").
+		JSON(map[string]interface{}{
+			"isLastPage": true,
+			"values": []map[string]interface{}{
+				{"id": "refs/heads/master", "displayId": "master", "latestCommit": "6fa1fc9a44ba1e1ab0a4cbb9ab3ceaa1a4c0e7cf"},
+			},
+		})
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests").
+		MatchParam("state", "all").
+		Reply(200).
+		Type("application/json").
+		File("testdata/prs.json")
+
+	client, _ := New("http://example.com:7990")
+	source := NewEventSource(client, []string{"PRJ/my-repo"}, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	hooks := make(chan scm.Webhook)
+	go source.Run(ctx, hooks)
+
+	select {
+	case hook := <-hooks:
+		push, ok := hook.(*scm.PushHook)
+		if !ok {
+			t.Fatalf("Want a push hook, got %T", hook)
+		}
+		if got, want := push.Ref, "refs/heads/master"; got != want {
+			t.Errorf("Want ref %s, got %s", want, got)
+		}
+		if got, want := push.Before, "11ce869211917dd65610e70fcee454943b35ac6e"; got != want {
+			t.Errorf("Want before %s, got %s", want, got)
+		}
+		if got, want := push.After, "6fa1fc9a44ba1e1ab0a4cbb9ab3ceaa1a4c0e7cf"; got != want {
+			t.Errorf("Want after %s, got %s", want, got)
+		}
+	case <-ctx.Done():
+		t.Fatal("Want a push hook, got none")
+	}
+}
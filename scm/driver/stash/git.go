@@ -9,7 +9,6 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
-	"time"
 
 	"github.com/slimm609/go-scm/scm"
 )
@@ -22,7 +21,7 @@ type gitService struct {
 }
 
 func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *scm.Response, error) {
-	ref = strings.TrimPrefix(ref, "heads/")
+	ref = scm.TrimRef(scm.QualifyRef(ref))
 	namespace, name := scm.Split(repo)
 	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/commits/%s", namespace, name, url.PathEscape(ref))
 	out := commit{}
@@ -30,22 +29,54 @@ func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *sc
 	if err != nil {
 		return "", res, err
 	}
-	if out.ID != "" {
-		return out.ID, res, err
+	if out.ID == "" {
+		return "", res, scm.ErrNotFound
 	}
-	idx := strings.LastIndex(ref, "/")
-	if idx >= 0 {
-		ref = ref[idx+1:]
-	}
-	return ref, res, err
+	return out.ID, res, nil
+}
+
+func (s *gitService) ResolveRefs(ctx context.Context, repo string, refs []string) (map[string]string, map[string]error) {
+	return scm.ResolveRefs(ctx, s, repo, refs, scm.DefaultConcurrency)
 }
 
 func (s *gitService) CreateRef(ctx context.Context, repo, ref, sha string) (*scm.Reference, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	namespace, name := scm.Split(repo)
+	ref = scm.QualifyRef(ref)
+	in := &refInput{
+		Name:       scm.TrimRef(ref),
+		StartPoint: sha,
+	}
+	switch {
+	case strings.HasPrefix(ref, "refs/heads/"):
+		path := fmt.Sprintf("rest/branch-utils/1.0/projects/%s/repos/%s/branches", namespace, name)
+		out := new(branch)
+		res, err := s.client.do(ctx, "POST", path, in, out)
+		return convertBranch(out), res, err
+	case strings.HasPrefix(ref, "refs/tags/"):
+		path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/tags", namespace, name)
+		out := new(branch)
+		res, err := s.client.do(ctx, "POST", path, in, out)
+		return convertTag(out), res, err
+	}
+	return nil, nil, notSupported("Git", "CreateRef")
+}
+
+func (s *gitService) UpdateRef(ctx context.Context, repo, ref, sha string, force bool) (*scm.Reference, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "UpdateRef")
 }
 
 func (s *gitService) DeleteRef(ctx context.Context, repo, ref string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	namespace, name := scm.Split(repo)
+	switch ref = scm.QualifyRef(ref); {
+	case strings.HasPrefix(ref, "refs/heads/"):
+		path := fmt.Sprintf("rest/branch-utils/1.0/projects/%s/repos/%s/branches", namespace, name)
+		in := &refInput{Name: ref}
+		return s.client.do(ctx, "DELETE", path, in, nil)
+	case strings.HasPrefix(ref, "refs/tags/"):
+		path := fmt.Sprintf("rest/git/1.0/projects/%s/repos/%s/tags/%s", namespace, name, url.PathEscape(scm.TrimRef(ref)))
+		return s.client.do(ctx, "DELETE", path, nil, nil)
+	}
+	return nil, notSupported("Git", "DeleteRef")
 }
 
 func (s *gitService) FindBranch(ctx context.Context, repo, branch string) (*scm.Reference, *scm.Response, error) {
@@ -93,6 +124,9 @@ func (s *gitService) ListBranches(ctx context.Context, repo string, opts scm.Lis
 	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/branches?%s", namespace, name, encodeListOptions(opts))
 	out := new(branches)
 	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
 	if !out.pagination.LastPage.Bool {
 		res.Page.First = 1
 		res.Page.Next = opts.Page + 1
@@ -101,7 +135,7 @@ func (s *gitService) ListBranches(ctx context.Context, repo string, opts scm.Lis
 }
 
 func (s *gitService) ListCommits(ctx context.Context, repo string, opts scm.CommitListOptions) ([]*scm.Commit, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("Git", "ListCommits")
 }
 
 func (s *gitService) ListTags(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
@@ -128,6 +162,19 @@ func (s *gitService) ListChanges(ctx context.Context, repo, ref string, opts scm
 	return convertDiffstats(out), res, err
 }
 
+func (s *gitService) CompareCommits(ctx context.Context, repo, base, head string) (*scm.Comparison, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "CompareCommits")
+}
+
+func (s *gitService) CompareAcrossForks(ctx context.Context, baseRepo, baseRef, headOwner, headRef string) (*scm.Comparison, *scm.Response, error) {
+	return nil, nil, notSupported("Git", "CompareAcrossForks")
+}
+
+type refInput struct {
+	Name       string `json:"name"`
+	StartPoint string `json:"startPoint,omitempty"`
+}
+
 type branch struct {
 	ID              string `json:"id"`
 	DisplayID       string `json:"displayId"`
@@ -271,14 +318,14 @@ func convertCommit(from *commit) *scm.Commit {
 		Author: scm.Signature{
 			Name:   from.Author.DisplayName,
 			Email:  from.Author.EmailAddress,
-			Date:   time.Unix(from.AuthorTimestamp/1000, 0),
+			Date:   convertTimestamp(from.AuthorTimestamp),
 			Login:  from.Author.Slug,
 			Avatar: avatarLink(from.Author.EmailAddress),
 		},
 		Committer: scm.Signature{
 			Name:   from.Committer.DisplayName,
 			Email:  from.Committer.EmailAddress,
-			Date:   time.Unix(from.CommitterTimestamp/1000, 0),
+			Date:   convertTimestamp(from.CommitterTimestamp),
 			Login:  from.Committer.Slug,
 			Avatar: avatarLink(from.Committer.EmailAddress),
 		},
@@ -7,6 +7,7 @@ package stash
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"testing"
 
@@ -96,7 +97,7 @@ func TestGitFindTag(t *testing.T) {
 func TestGitListCommits(t *testing.T) {
 	client, _ := New("http://example.com:7990")
 	_, _, err := client.Git.ListCommits(context.Background(), "PRJ/my-repo", scm.CommitListOptions{Ref: "master", Page: 1, Size: 30})
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -129,6 +130,20 @@ func TestGitListBranches(t *testing.T) {
 	// t.Run("Page", testPage(res))
 }
 
+func TestGitListBranches_Error(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/branches").
+		ReplyError(errors.New("connection refused"))
+
+	client, _ := New("http://example.com:7990")
+	_, _, err := client.Git.ListBranches(context.Background(), "PRJ/my-repo", scm.ListOptions{Page: 1, Size: 30})
+	if err == nil {
+		t.Error("Expect an error listing the branches")
+	}
+}
+
 func TestGitListTags(t *testing.T) {
 	defer gock.Off()
 
@@ -182,3 +197,42 @@ func TestGitListChanges(t *testing.T) {
 		t.Log(diff)
 	}
 }
+
+func TestGitCreateRef(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Post("/rest/branch-utils/1.0/projects/PRJ/repos/my-repo/branches").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"id":"refs/heads/master","displayId":"master","type":"BRANCH","latestCommit":"11ce869211917dd65610e70fcee454943b35ac6e"}`)
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Git.CreateRef(context.Background(), "PRJ/my-repo", "master", "11ce869211917dd65610e70fcee454943b35ac6e")
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(scm.Reference)
+	raw, _ := ioutil.ReadFile("testdata/branch.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitDeleteRef(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Delete("/rest/git/1.0/projects/PRJ/repos/my-repo/tags/v1.0.0").
+		Reply(204)
+
+	client, _ := New("http://example.com:7990")
+	_, err := client.Git.DeleteRef(context.Background(), "PRJ/my-repo", "tags/v1.0.0")
+	if err != nil {
+		t.Error(err)
+	}
+}
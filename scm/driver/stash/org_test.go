@@ -7,6 +7,7 @@ package stash
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"testing"
 
@@ -18,7 +19,7 @@ import (
 func TestOrganizationFind(t *testing.T) {
 	client, _ := New("https://api.bitbucket.org")
 	_, _, err := client.Organizations.Find(context.Background(), "atlassian")
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
@@ -26,7 +27,7 @@ func TestOrganizationFind(t *testing.T) {
 func TestOrganizationList(t *testing.T) {
 	client, _ := New("https://api.bitbucket.org")
 	_, _, err := client.Organizations.List(context.Background(), scm.ListOptions{Size: 30, Page: 1})
-	if err != scm.ErrNotSupported {
+	if !errors.Is(err, scm.ErrNotSupported) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
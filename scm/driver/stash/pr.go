@@ -6,11 +6,11 @@ package stash
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
-	"time"
 
 	"github.com/slimm609/go-scm/scm"
 )
@@ -24,7 +24,28 @@ func (s *pullService) Find(ctx context.Context, repo string, number int) (*scm.P
 	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d", namespace, name, number)
 	out := new(pullRequest)
 	res, err := s.client.do(ctx, "GET", path, nil, out)
-	return convertPullRequest(out), res, err
+	if err != nil {
+		return nil, res, err
+	}
+	pr := convertPullRequest(out)
+	// pull requests do not report diff stats, so they are
+	// computed from the diff. The stats are best effort, and
+	// are left unset if the diff cannot be fetched or is
+	// truncated. The errors of a canceled context or of the
+	// authorization are returned.
+	path = fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/diff?contextLines=0", namespace, name, number)
+	diff := new(prDiff)
+	if _, err := s.client.do(ctx, "GET", path, nil, diff); err != nil {
+		if ctx.Err() != nil || errors.Is(err, scm.ErrNotAuthorized) {
+			return nil, res, err
+		}
+		return pr, res, nil
+	}
+	if !diff.truncated() {
+		pr.Additions, pr.Deletions = diff.count()
+		pr.ChangedFiles = len(diff.Diffs)
+	}
+	return pr, res, nil
 }
 
 func (s *pullService) FindComment(ctx context.Context, repo string, number int, id int) (*scm.Comment, *scm.Response, error) {
@@ -37,14 +58,17 @@ func (s *pullService) FindComment(ctx context.Context, repo string, number int,
 
 func (s *pullService) List(ctx context.Context, repo string, opts scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests", namespace, name)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests?%s", namespace, name, encodePullRequestListOptions(opts))
 	out := new(pullRequests)
 	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
 	if !out.pagination.LastPage.Bool {
 		res.Page.First = 1
 		res.Page.Next = opts.Page + 1
 	}
-	return convertPullRequests(out), res, err
+	return scm.FilterPullRequests(convertPullRequests(out), opts), res, err
 }
 
 func (s *pullService) ListChanges(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
@@ -60,22 +84,22 @@ func (s *pullService) ListChanges(ctx context.Context, repo string, number int,
 }
 
 func (s *pullService) ListLabels(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("PullRequests", "ListLabels")
 }
 
 func (s *pullService) ListEvents(context.Context, string, int, scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return nil, nil, notSupported("PullRequests", "ListEvents")
 }
 
 func (s *pullService) AddLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "AddLabel")
 }
 
 func (s *pullService) DeleteLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "DeleteLabel")
 }
 
-func (s *pullService) ListComments(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
+func (s *pullService) ListComments(ctx context.Context, repo string, number int, opts scm.CommentListOptions) ([]*scm.Comment, *scm.Response, error) {
 	// TODO(bradrydzewski) the challenge with comments is that we need to use
 	// the activities endpoint, which returns entries that may or may not be
 	// comments. This complicates how we handle counts and pagination.
@@ -84,13 +108,20 @@ func (s *pullService) ListComments(ctx context.Context, repo string, number int,
 
 	projectName, repoName := scm.Split(repo)
 	out := new(pullRequestActivities)
-	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/activities", projectName, repoName, number)
+	listOpts := scm.ListOptions{Page: opts.Page, Size: opts.Size}
+	if listOpts.Size == 0 {
+		listOpts.Size = defaultPageSize
+	}
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/activities?%s", projectName, repoName, number, encodeListOptions(listOpts))
 	res, err := s.client.do(ctx, "GET", path, nil, out)
-	if !out.pagination.LastPage.Bool {
+	if err != nil {
+		return nil, res, err
+	}
+	if !out.pagination.LastPage.Bool && out.pagination.NextPage.Valid {
 		res.Page.First = 1
-		res.Page.Next = opts.Page + 1
+		res.Page.Next = int(out.pagination.NextPage.Int64)/listOpts.Size + 1
 	}
-	return convertPullRequestActivities(out), res, err
+	return scm.FilterComments(convertPullRequestActivities(out), opts), res, nil
 }
 
 func (s *pullService) Merge(ctx context.Context, repo string, number int, options *scm.PullRequestMergeOptions) (*scm.Response, error) {
@@ -282,11 +313,11 @@ func (s *pullService) UnrequestReview(ctx context.Context, repo string, number i
 }
 
 func (s *pullService) SetMilestone(ctx context.Context, repo string, prID int, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "SetMilestone")
 }
 
 func (s *pullService) ClearMilestone(ctx context.Context, repo string, prID int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return nil, notSupported("PullRequests", "ClearMilestone")
 }
 
 type createPRInput struct {
@@ -324,6 +355,7 @@ type pullRequest struct {
 	Closed       bool          `json:"closed"`
 	CreatedDate  int64         `json:"createdDate"`
 	UpdatedDate  int64         `json:"updatedDate"`
+	ClosedDate   int64         `json:"closedDate"`
 	FromRef      prRepoRef     `json:"fromRef"`
 	ToRef        prRepoRef     `json:"toRef"`
 	Locked       bool          `json:"locked"`
@@ -355,6 +387,65 @@ type pullRequests struct {
 	Values []*pullRequest `json:"values"`
 }
 
+type prDiff struct {
+	Diffs []struct {
+		Hunks []struct {
+			Segments []struct {
+				Type  string `json:"type"`
+				Lines []struct {
+					Line string `json:"line"`
+				} `json:"lines"`
+				Truncated bool `json:"truncated"`
+			} `json:"segments"`
+			Truncated bool `json:"truncated"`
+		} `json:"hunks"`
+		Truncated bool `json:"truncated"`
+	} `json:"diffs"`
+	Truncated bool `json:"truncated"`
+}
+
+// truncated returns true if the server left out a part of
+// the diff, e.g. the files or lines over its limits.
+func (d *prDiff) truncated() bool {
+	if d.Truncated {
+		return true
+	}
+	for _, diff := range d.Diffs {
+		if diff.Truncated {
+			return true
+		}
+		for _, hunk := range diff.Hunks {
+			if hunk.Truncated {
+				return true
+			}
+			for _, segment := range hunk.Segments {
+				if segment.Truncated {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// count returns the number of lines added and removed
+// by the diff.
+func (d *prDiff) count() (additions, deletions int) {
+	for _, diff := range d.Diffs {
+		for _, hunk := range diff.Hunks {
+			for _, segment := range hunk.Segments {
+				switch segment.Type {
+				case "ADDED":
+					additions += len(segment.Lines)
+				case "REMOVED":
+					deletions += len(segment.Lines)
+				}
+			}
+		}
+	}
+	return additions, deletions
+}
+
 func convertPullRequests(from *pullRequests) []*scm.PullRequest {
 	to := []*scm.PullRequest{}
 	for _, v := range from.Values {
@@ -370,7 +461,7 @@ func convertPullRequest(from *pullRequest) *scm.PullRequest {
 	)
 	toRepo := convertRepository(&from.ToRef.Repository)
 	fromRepo := convertRepository(&from.FromRef.Repository)
-	return &scm.PullRequest{
+	dst := &scm.PullRequest{
 		Number: from.ID,
 		Title:  from.Title,
 		Body:   from.Description,
@@ -394,8 +485,9 @@ func convertPullRequest(from *pullRequest) *scm.PullRequest {
 		Closed:    from.Closed,
 		Merged:    from.State == "MERGED",
 		Reviewers: convertReviewers(from.Reviewers),
-		Created:   time.Unix(from.CreatedDate/1000, 0),
-		Updated:   time.Unix(from.UpdatedDate/1000, 0),
+		Created:   convertTimestamp(from.CreatedDate),
+		Updated:   convertTimestamp(from.UpdatedDate),
+		ClosedAt:  convertTimestamp(from.ClosedDate),
 		Author: scm.User{
 			Login:  from.Author.User.Slug,
 			Name:   from.Author.User.DisplayName,
@@ -403,6 +495,10 @@ func convertPullRequest(from *pullRequest) *scm.PullRequest {
 			Avatar: avatarLink(from.Author.User.EmailAddress),
 		},
 	}
+	if dst.Merged {
+		dst.MergedAt = dst.ClosedAt
+	}
+	return dst
 }
 
 type pullRequestComment struct {
@@ -480,8 +576,8 @@ func convertPullRequestComment(from *pullRequestComment) *scm.Comment {
 		ID:      from.ID,
 		Body:    from.Text,
 		Version: from.Version,
-		Created: time.Unix(from.CreatedDate/1000, 0),
-		Updated: time.Unix(from.UpdatedDate/1000, 0),
+		Created: convertTimestamp(from.CreatedDate),
+		Updated: convertTimestamp(from.UpdatedDate),
 		Author: scm.User{
 			Login:  from.Author.Slug,
 			Name:   from.Author.DisplayName,
@@ -7,8 +7,11 @@ package stash
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/slimm609/go-scm/scm"
 
@@ -25,6 +28,13 @@ func TestPullFind(t *testing.T) {
 		Type("application/json").
 		File("testdata/pr.json")
 
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/diff").
+		MatchParam("contextLines", "0").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_diff.json")
+
 	client, _ := New("http://example.com:7990")
 	got, _, err := client.PullRequests.Find(context.Background(), "PRJ/my-repo", 1)
 	if err != nil {
@@ -41,6 +51,101 @@ func TestPullFind(t *testing.T) {
 	}
 }
 
+func TestPullFindDiffError(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/diff").
+		Reply(500)
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.PullRequests.Find(context.Background(), "PRJ/my-repo", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Additions != 0 || got.Deletions != 0 || got.ChangedFiles != 0 {
+		t.Errorf("Want the diff stats unset without the diff")
+	}
+}
+
+func TestPullFindDiffCanceled(t *testing.T) {
+	defer gock.Off()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/diff").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_diff.json").
+		Map(func(res *http.Response) *http.Response {
+			cancel()
+			return res
+		})
+
+	client, _ := New("http://example.com:7990")
+	if _, _, err := client.PullRequests.Find(ctx, "PRJ/my-repo", 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("Want the context error, got %v", err)
+	}
+}
+
+func TestPullFindDiffNotAuthorized(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/diff").
+		Reply(401)
+
+	client, _ := New("http://example.com:7990")
+	if _, _, err := client.PullRequests.Find(context.Background(), "PRJ/my-repo", 1); err != scm.ErrNotAuthorized {
+		t.Errorf("Want ErrNotAuthorized, got %v", err)
+	}
+}
+
+func TestPullFindDiffTruncated(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/diff").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"diffs":[{"hunks":[{"segments":[{"type":"ADDED","lines":[{"line":"# my-repo"}]}]}]}],"truncated":true}`)
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.PullRequests.Find(context.Background(), "PRJ/my-repo", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Additions != 0 || got.Deletions != 0 || got.ChangedFiles != 0 {
+		t.Errorf("Want the diff stats unset with a truncated diff")
+	}
+}
+
 func TestPullUpdate(t *testing.T) {
 	defer gock.Off()
 
@@ -70,6 +175,8 @@ func TestPullUpdate(t *testing.T) {
 	want := new(scm.PullRequest)
 	raw, _ := ioutil.ReadFile("testdata/pr.json.golden")
 	json.Unmarshal(raw, &want)
+	// diff stats are only computed by Find
+	want.Additions, want.Deletions, want.ChangedFiles = 0, 0, 0
 
 	if diff := cmp.Diff(got, want); diff != "" {
 		t.Errorf("Unexpected Results")
@@ -112,7 +219,7 @@ func TestPullListComments(t *testing.T) {
 		File("testdata/pr_comments.json")
 
 	client, _ := New("http://example.com:7990")
-	got, _, err := client.PullRequests.ListComments(context.Background(), "PRJ/my-repo", 1, scm.ListOptions{})
+	got, _, err := client.PullRequests.ListComments(context.Background(), "PRJ/my-repo", 1, scm.CommentListOptions{})
 	if err != nil {
 		t.Error(err)
 	}
@@ -127,6 +234,43 @@ func TestPullListComments(t *testing.T) {
 	}
 }
 
+func TestPullListCommentsSince(t *testing.T) {
+	defer gock.Off()
+
+	raw, _ := ioutil.ReadFile("testdata/pr_comments.json")
+	first := map[string]interface{}{}
+	json.Unmarshal(raw, &first)
+	first["isLastPage"] = false
+	first["nextPageStart"] = 100
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/activities").
+		MatchParam("limit", "100").
+		Reply(200).
+		Type("application/json").
+		JSON(first)
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/activities").
+		MatchParam("start", "100").
+		MatchParam("limit", "100").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_comments.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := scm.ListCommentsSince(context.Background(), client.PullRequests, "PRJ/my-repo", 1, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 2; len(got) != want {
+		t.Errorf("Want %d comments, got %d", want, len(got))
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
 func TestPullDeleteComment(t *testing.T) {
 	defer gock.Off()
 
@@ -341,6 +485,8 @@ func TestPullCreate(t *testing.T) {
 	want := new(scm.PullRequest)
 	raw, _ := ioutil.ReadFile("testdata/pr.json.golden")
 	json.Unmarshal(raw, &want)
+	// diff stats are only computed by Find
+	want.Additions, want.Deletions, want.ChangedFiles = 0, 0, 0
 
 	if diff := cmp.Diff(got, want); diff != "" {
 		t.Errorf("Unexpected Results")
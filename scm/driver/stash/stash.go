@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -21,8 +22,12 @@ import (
 //   https://docs.atlassian.com/bitbucket-server/rest/5.11.1/bitbucket-rest.html
 
 // NewWebHookService creates a new instance of the webhook service without the rest of the client
-func NewWebHookService() scm.WebhookService {
-	return &webhookService{nil}
+func NewWebHookService(opts ...scm.WebhookServiceOptions) scm.WebhookService {
+	s := &webhookService{}
+	if len(opts) > 0 {
+		s.opts = opts[0]
+	}
+	return s
 }
 
 // New returns a new Stash API client.
@@ -38,6 +43,7 @@ func New(uri string) (*scm.Client, error) {
 	client.BaseURL = base
 	// initialize services
 	client.Driver = scm.DriverStash
+	client.SetCaller(client.do)
 	client.Contents = &contentService{client}
 	client.Git = &gitService{client}
 	client.Issues = &issueService{client}
@@ -47,7 +53,7 @@ func New(uri string) (*scm.Client, error) {
 	client.Repositories = &repositoryService{client}
 	client.Reviews = &reviewService{client}
 	client.Users = &userService{client}
-	client.Webhooks = &webhookService{client}
+	client.Webhooks = &webhookService{client: client}
 	return client.Client, nil
 }
 
@@ -93,7 +99,7 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	if res.Status == 401 {
 		return res, scm.ErrNotAuthorized
 	} else if res.Status > 300 {
-		err := new(Error)
+		err := &Error{status: res.Status}
 		json.NewDecoder(res.Body).Decode(err) // #nosec
 		return res, err
 	}
@@ -132,6 +138,8 @@ type Error struct {
 		CurrentVersion  int    `json:"currentVersion"`
 		ExpectedVersion int    `json:"expectedVersion"`
 	} `json:"errors"`
+
+	status int
 }
 
 func (e *Error) Error() string {
@@ -140,3 +148,15 @@ func (e *Error) Error() string {
 	}
 	return e.Errors[0].Message
 }
+
+// Is returns true if target is scm.ErrNotFound and the
+// error was returned for a missing resource.
+func (e *Error) Is(target error) bool {
+	return target == scm.ErrNotFound && e.status == http.StatusNotFound
+}
+
+// notSupported returns the error of a method of a service
+// the driver does not support.
+func notSupported(service, method string) error {
+	return &scm.NotSupportedError{Driver: scm.DriverStash, Service: service, Method: method}
+}
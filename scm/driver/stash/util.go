@@ -7,10 +7,24 @@ package stash
 import (
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/slimm609/go-scm/scm"
 )
 
+// convertTimestamp converts the millisecond timestamps of the
+// api to a time in UTC, or the zero time if it is not set.
+func convertTimestamp(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.Unix(ms/1000, 0).UTC()
+}
+
+// defaultPageSize is the page size the api uses when no
+// limit is given.
+const defaultPageSize = 25
+
 func encodeListOptions(opts scm.ListOptions) string {
 	params := url.Values{}
 	if opts.Page > 1 {
@@ -6,10 +6,25 @@ package stash
 
 import (
 	"testing"
+	"time"
 
 	"github.com/slimm609/go-scm/scm"
 )
 
+func Test_convertTimestamp(t *testing.T) {
+	if got := convertTimestamp(0); !got.IsZero() {
+		t.Errorf("Want zero time for an unset timestamp, got %s", got)
+	}
+	got := convertTimestamp(1530819200099)
+	want := time.Date(2018, time.July, 5, 19, 33, 20, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("Want time %s, got %s", want, got)
+	}
+	if got.Location() != time.UTC {
+		t.Errorf("Want time in UTC, got %s", got.Location())
+	}
+}
+
 func Test_encodeListOptions(t *testing.T) {
 	tests := []struct {
 		page int
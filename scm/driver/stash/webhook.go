@@ -7,8 +7,6 @@ package stash
 import (
 	"encoding/json"
 	"errors"
-	"io"
-	"io/ioutil"
 	"net/http"
 	"time"
 
@@ -28,13 +26,16 @@ import (
 
 type webhookService struct {
 	client *wrapper
+	opts   scm.WebhookServiceOptions
 }
 
 // Parse for the bitbucket server webhook payloads see: https://confluence.atlassian.com/bitbucketserver/event-payload-938025882.html
 func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
-	data, err := ioutil.ReadAll(
-		io.LimitReader(req.Body, 10000000),
-	)
+	return s.ParseMulti(req, scm.Secrets(fn))
+}
+
+func (s *webhookService) ParseMulti(req *http.Request, fn scm.MultiSecretFunc) (scm.Webhook, error) {
+	body, data, err := s.opts.ReadBody(req)
 	if err != nil {
 		return nil, err
 	}
@@ -50,6 +51,9 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 		hook, err = s.parsePullRequestComment(data)
 	case "pr:reviewer:approved", "pr:reviewer:unapproved", "pr:reviewer:needs_work":
 		hook, err = s.parsePullRequestApproval(data)
+	case "diagnostics:ping":
+		// test connection requests do not include a repository.
+		hook = &scm.PingHook{}
 	default:
 		return nil, scm.UnknownWebhook{Event: event}
 	}
@@ -59,19 +63,23 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	if hook == nil {
 		return nil, nil
 	}
+	scm.SetGUID(hook, req.Header.Get("X-Request-Id"))
+	if s.opts.RawPayload {
+		scm.SetRaw(hook, data)
+	}
 
 	// get the gogs signature key to verify the payload
 	// signature. If no key is provided, no validation
 	// is performed.
-	key, err := fn(hook)
+	keys, err := fn(hook)
 	if err != nil {
 		return hook, err
-	} else if key == "" {
+	} else if len(keys) == 0 {
 		return hook, nil
 	}
 
 	sig := req.Header.Get("X-Hub-Signature")
-	if !hmac.ValidatePrefix(data, []byte(key), sig) {
+	if !scm.ValidateAny(keys, func(key string) bool { return hmac.ValidatePrefix(body, []byte(key), sig) }) {
 		return hook, scm.ErrSignatureInvalid
 	}
 
@@ -84,9 +92,12 @@ func (s *webhookService) parsePushHook(data []byte) (scm.Webhook, error) {
 	if err != nil {
 		return nil, err
 	}
-	if len(dst.Changes) == 0 {
+	if len(dst.Changes) == 0 || dst.Changes[0] == nil {
 		return nil, errors.New("Push hook has empty changeset")
 	}
+	if dst.Repository == nil {
+		return nil, errors.New("Push hook has no repository")
+	}
 	change := dst.Changes[0]
 	switch {
 	case change.Ref.Type == "BRANCH" && change.Type != "UPDATE":
@@ -104,6 +115,9 @@ func (s *webhookService) parsePullRequest(data []byte) (scm.Webhook, error) {
 	if err != nil {
 		return nil, err
 	}
+	if src.PullRequest == nil {
+		return nil, errors.New("Pull request hook has no pull request")
+	}
 	dst := convertPullRequestHook(src)
 	switch src.EventKey {
 	case "pr:opened":
@@ -128,6 +142,9 @@ func (s *webhookService) parsePullRequestComment(data []byte) (scm.Webhook, erro
 	if err != nil {
 		return nil, err
 	}
+	if src.PullRequest == nil {
+		return nil, errors.New("Pull request hook has no pull request")
+	}
 	dst := convertPullRequestCommentHook(src)
 	return dst, nil
 }
@@ -138,6 +155,9 @@ func (s *webhookService) parsePullRequestApproval(data []byte) (scm.Webhook, err
 	if err != nil {
 		return nil, err
 	}
+	if src.PullRequest == nil {
+		return nil, errors.New("Pull request hook has no pull request")
+	}
 	dst := convertPullRequestApprovalHook(src)
 	switch src.EventKey {
 	case "pr:reviewer:approved":
@@ -221,11 +241,15 @@ type pullRequestApprovalHook struct {
 func convertPushHook(src *pushHook) *scm.PushHook {
 	change := src.Changes[0]
 	repo := convertRepository(src.Repository)
-	sender := convertUser(src.Actor)
+	sender := convertUserValue(src.Actor)
 	signer := convertSignature(src.Actor)
 	signer.Date, _ = time.Parse("2006-01-02T15:04:05+0000", src.Date)
 	return &scm.PushHook{
-		Ref: change.RefID,
+		Ref:     change.RefID,
+		Before:  change.FromHash,
+		After:   change.ToHash,
+		Created: change.Type == "ADD",
+		Deleted: change.Type == "DELETE",
 		Commit: scm.Commit{
 			Sha:       change.ToHash,
 			Message:   "",
@@ -234,13 +258,13 @@ func convertPushHook(src *pushHook) *scm.PushHook {
 			Committer: signer,
 		},
 		Repo:   *repo,
-		Sender: *sender,
+		Sender: sender,
 	}
 }
 
 func convertTagHook(src *pushHook) *scm.TagHook {
 	change := src.Changes[0]
-	sender := convertUser(src.Actor)
+	sender := convertUserValue(src.Actor)
 	repo := convertRepository(src.Repository)
 
 	dst := &scm.TagHook{
@@ -250,7 +274,7 @@ func convertTagHook(src *pushHook) *scm.TagHook {
 		},
 		Action: scm.ActionCreate,
 		Repo:   *repo,
-		Sender: *sender,
+		Sender: sender,
 	}
 	if change.Type == "DELETE" {
 		dst.Action = scm.ActionDelete
@@ -261,7 +285,7 @@ func convertTagHook(src *pushHook) *scm.TagHook {
 
 func convertBranchHook(src *pushHook) *scm.BranchHook {
 	change := src.Changes[0]
-	sender := convertUser(src.Actor)
+	sender := convertUserValue(src.Actor)
 	repo := convertRepository(src.Repository)
 
 	dst := &scm.BranchHook{
@@ -271,7 +295,7 @@ func convertBranchHook(src *pushHook) *scm.BranchHook {
 		},
 		Action: scm.ActionCreate,
 		Repo:   *repo,
-		Sender: *sender,
+		Sender: sender,
 	}
 	if change.Type == "DELETE" {
 		dst.Action = scm.ActionDelete
@@ -281,6 +305,9 @@ func convertBranchHook(src *pushHook) *scm.BranchHook {
 }
 
 func convertSignature(actor *user) scm.Signature {
+	if actor == nil {
+		return scm.Signature{}
+	}
 	return scm.Signature{
 		Name:   actor.DisplayName,
 		Email:  actor.EmailAddress,
@@ -293,7 +320,7 @@ func convertPullRequestHook(src *pullRequestHook) *scm.PullRequestHook {
 	toRepo := convertRepository(&src.PullRequest.ToRef.Repository)
 	fromRepo := convertRepository(&src.PullRequest.FromRef.Repository)
 	pr := convertPullRequest(src.PullRequest)
-	sender := convertUser(src.Actor)
+	sender := convertUserValue(src.Actor)
 	pr.Base.Repo = *toRepo
 	pr.Head.Repo = *fromRepo
 	if pr.Base.Ref == "" {
@@ -306,7 +333,7 @@ func convertPullRequestHook(src *pullRequestHook) *scm.PullRequestHook {
 		Action:      scm.ActionOpen,
 		Repo:        *toRepo,
 		PullRequest: *pr,
-		Sender:      *sender,
+		Sender:      sender,
 	}
 }
 
@@ -314,18 +341,18 @@ func convertPullRequestCommentHook(src *pullRequestCommentHook) *scm.PullRequest
 	toRepo := convertRepository(&src.PullRequest.ToRef.Repository)
 	fromRepo := convertRepository(&src.PullRequest.FromRef.Repository)
 	pr := convertPullRequest(src.PullRequest)
-	author := src.Comment.Author
-	if author == nil {
-		author = src.Author
+	author := src.Author
+	if src.Comment != nil && src.Comment.Author != nil {
+		author = src.Comment.Author
 	}
-	sender := convertUser(author)
+	sender := convertUserValue(author)
 	pr.Base.Repo = *toRepo
 	pr.Head.Repo = *fromRepo
 	return &scm.PullRequestCommentHook{
 		Action:      scm.ActionCreate,
 		Repo:        *toRepo,
 		PullRequest: *pr,
-		Sender:      *sender,
+		Sender:      sender,
 		Comment:     convertComment(src.Comment),
 	}
 }
@@ -339,8 +366,8 @@ func convertComment(src *prComment) scm.Comment {
 		if author != nil {
 			dst.Author = *author
 		}
-		dst.Created = time.Unix(src.CreatedAt/1000, 0)
-		dst.Updated = time.Unix(src.UpdatedAt/1000, 0)
+		dst.Created = convertTimestamp(src.CreatedAt)
+		dst.Updated = convertTimestamp(src.UpdatedAt)
 	}
 	return dst
 }
@@ -358,8 +385,10 @@ func convertPullRequestApprovalHook(src *pullRequestApprovalHook) *scm.ReviewHoo
 		pr.Head.Ref = fromRepo.Branch
 	}
 	review := scm.Review{
-		State:  convertReviewStateFromEvent(src.EventKey),
-		Author: *convertUser(&src.Participant.User),
+		State: convertReviewStateFromEvent(src.EventKey),
+	}
+	if src.Participant != nil {
+		review.Author = convertUserValue(&src.Participant.User)
 	}
 
 	return &scm.ReviewHook{
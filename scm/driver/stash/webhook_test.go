@@ -156,6 +156,19 @@ func TestWebhooks(t *testing.T) {
 			after:  "testdata/webhooks/pr_needs_work.json.golden",
 			obj:    new(scm.ReviewHook),
 		},
+
+		//
+		// ping events
+		//
+
+		// test connection
+		{
+			sig:    "71295b197fa25f4356d2fb9965df3f2379d903d7",
+			event:  "diagnostics:ping",
+			before: "testdata/webhooks/ping.json",
+			after:  "testdata/webhooks/ping.json.golden",
+			obj:    new(scm.PingHook),
+		},
 	}
 
 	for _, test := range tests {
@@ -60,3 +60,21 @@ type MissingHeader struct {
 func (e MissingHeader) Error() string {
 	return fmt.Sprintf("400 Bad Request: Missing Header: %s", e.Header)
 }
+
+// NotSupportedError is returned by a method of a service the
+// driver does not support. It matches ErrNotSupported with
+// errors.Is.
+type NotSupportedError struct {
+	Driver  Driver
+	Service string
+	Method  string
+}
+
+func (e *NotSupportedError) Error() string {
+	return fmt.Sprintf("%s: %s.%s: %s", e.Driver, e.Service, e.Method, ErrNotSupported)
+}
+
+// Is returns true if target is ErrNotSupported.
+func (e *NotSupportedError) Is(target error) bool {
+	return target == ErrNotSupported
+}
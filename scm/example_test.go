@@ -472,7 +472,7 @@ func ExampleComment_list() {
 		log.Fatal(err)
 	}
 
-	opts := scm.ListOptions{
+	opts := scm.CommentListOptions{
 		Page: 1,
 		Size: 30,
 	}
@@ -0,0 +1,109 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// GitCredential is the credential of the git operations over HTTPS, such as
+// git clone and git push.
+type GitCredential struct {
+	Username string
+	Password string
+}
+
+// EnvironmentTokenSource returns a token source reading the token of $GIT_TOKEN,
+// or of the file at $GIT_TOKEN_FILE for every request, so a rotated token file is
+// used without recreating the client
+func EnvironmentTokenSource() scm.TokenSource {
+	return scm.TokenSourceFunc(func(context.Context) (*scm.Token, error) {
+		if token := os.Getenv("GIT_TOKEN"); token != "" {
+			return &scm.Token{Token: token}, nil
+		}
+		name := os.Getenv("GIT_TOKEN_FILE")
+		if name == "" {
+			return nil, fmt.Errorf("No Git OAuth token specified for $GIT_TOKEN or $GIT_TOKEN_FILE")
+		}
+		b, err := ioutil.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		return &scm.Token{Token: strings.TrimSpace(string(b))}, nil
+	})
+}
+
+// GitCredentialFromEnvironment returns the credential of the git operations on
+// the host, configured by the environment variables of NewClientFromEnvironment,
+// so git and the API share the same credentials. The username defaults to the
+// username the provider expects with a token, and can be set with $GIT_USER. It
+// returns nil if the host is not the git server.
+func GitCredentialFromEnvironment(ctx context.Context, host string) (*GitCredential, error) {
+	if repoURL := os.Getenv("GIT_REPO_URL"); repoURL != "" {
+		u, err := url.Parse(repoURL)
+		if err != nil {
+			return nil, err
+		}
+		password, ok := u.User.Password()
+		if !ok || !strings.EqualFold(u.Host, host) {
+			return nil, nil
+		}
+		driver, err := DefaultIdentifier.Identify(u.Host)
+		if err != nil {
+			return nil, err
+		}
+		return &GitCredential{Username: gitUsername(driver, u.User.Username()), Password: password}, nil
+	}
+
+	driver := os.Getenv("GIT_KIND")
+	if driver == "" {
+		driver = "github"
+	}
+	server := os.Getenv("GIT_SERVER")
+	if server == "" {
+		server = defaultGitServers[driver]
+	}
+	u, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" || !strings.EqualFold(u.Host, host) {
+		return nil, nil
+	}
+	token, err := EnvironmentTokenSource().Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &GitCredential{Username: gitUsername(driver, os.Getenv("GIT_USER")), Password: token.Token}, nil
+}
+
+// defaultGitServers are the servers of the drivers without $GIT_SERVER.
+var defaultGitServers = map[string]string{
+	"github":         "https://github.com",
+	"gitlab":         "https://gitlab.com",
+	"bitbucket":      "https://bitbucket.org",
+	"bitbucketcloud": "https://bitbucket.org",
+	"gitee":          "https://gitee.com",
+	"sourcehut":      "https://git.sr.ht",
+}
+
+// gitUsername returns the username of the git operations authenticated with
+// a token, unless a username is set.
+func gitUsername(driver, username string) string {
+	if username != "" {
+		return username
+	}
+	switch driver {
+	case "gitlab":
+		return "oauth2"
+	case "bitbucket", "bitbucketcloud":
+		return "x-token-auth"
+	default:
+		return "x-access-token"
+	}
+}
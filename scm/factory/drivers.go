@@ -34,8 +34,12 @@ func Mapping(host, driver string) MappingFunc {
 // NewDriverIdentifier creates and returns a new HostDriverIdentifier.
 func NewDriverIdentifier(extras ...MappingFunc) HostDriverIdentifier {
 	u := HostDriverIdentifier{
-		"github.com": "github",
-		"gitlab.com": "gitlab",
+		"github.com":                "github",
+		"gitlab.com":                "gitlab",
+		"gitee.com":                 "gitee",
+		"git.sr.ht":                 "sourcehut",
+		"chromium.googlesource.com": "gitiles",
+		"android.googlesource.com":  "gitiles",
 	}
 	for _, e := range extras {
 		e(u)
@@ -16,6 +16,9 @@ func TestIdentify(t *testing.T) {
 	}{
 		{"simple github", "github.com", "github", "", ""},
 		{"simple gitlab", "gitlab.com", "gitlab", "", ""},
+		{"simple gitee", "gitee.com", "gitee", "", ""},
+		{"simple sourcehut", "git.sr.ht", "sourcehut", "", ""},
+		{"simple gitiles", "chromium.googlesource.com", "gitiles", "", ""},
 		{"from environment mapping", "gl.example.com", "gitlab", "gl.example.com=gitlab,gh.github.com=github", ""},
 		{"unknown host", "scm.example.com", "", "", "unable to identify driver"},
 	}
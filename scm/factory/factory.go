@@ -9,14 +9,21 @@ import (
 	"strings"
 
 	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/cache"
 	"github.com/slimm609/go-scm/scm/driver/bitbucket"
 	"github.com/slimm609/go-scm/scm/driver/fake"
 	"github.com/slimm609/go-scm/scm/driver/gitea"
+	"github.com/slimm609/go-scm/scm/driver/gitee"
+	"github.com/slimm609/go-scm/scm/driver/githttp"
 	"github.com/slimm609/go-scm/scm/driver/github"
+	"github.com/slimm609/go-scm/scm/driver/gitiles"
 	"github.com/slimm609/go-scm/scm/driver/gitlab"
 	"github.com/slimm609/go-scm/scm/driver/gogs"
+	"github.com/slimm609/go-scm/scm/driver/local"
+	"github.com/slimm609/go-scm/scm/driver/sourcehut"
 	"github.com/slimm609/go-scm/scm/driver/stash"
 	"github.com/slimm609/go-scm/scm/transport"
+	scmoauth2 "github.com/slimm609/go-scm/scm/transport/oauth2"
 	"golang.org/x/oauth2"
 )
 
@@ -55,7 +62,30 @@ func NewClientWithBasicAuth(driver, serverURL, user, password string, opts ...Cl
 	return client, err
 }
 
-// NewClient creates a new client for a given driver, serverURL and OAuth token
+// NewClientWithKerberos creates a new Bitbucket Server client authenticating with
+// the Kerberos SPNEGO tokens of the source, so no password is stored
+func NewClientWithKerberos(serverURL string, source transport.NegotiateTokenSource, opts ...ClientOptionFunc) (*scm.Client, error) {
+	if serverURL == "" {
+		return nil, ErrMissingGitServerURL
+	}
+	client, err := stash.New(serverURL)
+	if err != nil {
+		return client, err
+	}
+	client.Client = &http.Client{
+		Transport: &transport.Negotiate{
+			Source: source,
+		},
+	}
+	for _, o := range opts {
+		o(client)
+	}
+	return client, nil
+}
+
+// NewClient creates a new client for a given driver, serverURL and OAuth token.
+// Passing a raw token is deprecated: it cannot be rotated without creating a new
+// client, use NewClientWithTokenSource instead.
 func NewClient(driver, serverURL, oauthToken string, opts ...ClientOptionFunc) (*scm.Client, error) {
 	if driver == "" {
 		driver = "github"
@@ -77,12 +107,28 @@ func NewClient(driver, serverURL, oauthToken string, opts ...ClientOptionFunc) (
 			return nil, ErrMissingGitServerURL
 		}
 		client, err = gitea.NewWithToken(serverURL, oauthToken)
+	case "gitee":
+		if serverURL != "" {
+			client, err = gitee.New(ensureGiteeEndpoint(serverURL))
+		} else {
+			client = gitee.NewDefault()
+		}
+	case "githttp":
+		if serverURL == "" {
+			return nil, ErrMissingGitServerURL
+		}
+		client, err = githttp.New(serverURL)
 	case "github":
 		if serverURL != "" {
 			client, err = github.New(ensureGHEEndpoint(serverURL))
 		} else {
 			client = github.NewDefault()
 		}
+	case "gitiles":
+		if serverURL == "" {
+			return nil, ErrMissingGitServerURL
+		}
+		client, err = gitiles.New(serverURL)
 	case "gitlab":
 		if serverURL != "" {
 			client, err = gitlab.New(serverURL)
@@ -94,13 +140,28 @@ func NewClient(driver, serverURL, oauthToken string, opts ...ClientOptionFunc) (
 			return nil, ErrMissingGitServerURL
 		}
 		client, err = gogs.New(serverURL)
+	case "local":
+		if serverURL == "" {
+			return nil, ErrMissingGitServerURL
+		}
+		client, _ = local.NewDefault(strings.TrimPrefix(serverURL, "file://"))
+	case "sourcehut":
+		if serverURL != "" {
+			client, err = sourcehut.New(serverURL)
+		} else {
+			client = sourcehut.NewDefault()
+		}
 	case "stash", "bitbucketserver":
 		if serverURL == "" {
 			return nil, ErrMissingGitServerURL
 		}
 		client, err = stash.New(serverURL)
 	default:
-		return nil, fmt.Errorf("Unsupported $GIT_KIND value: %s", driver)
+		d, ok := registered(driver)
+		if !ok {
+			return nil, fmt.Errorf("Unsupported $GIT_KIND value: %s", driver)
+		}
+		client, err = d.NewClient(serverURL)
 	}
 	if err != nil {
 		return client, err
@@ -132,8 +193,53 @@ func NewClient(driver, serverURL, oauthToken string, opts ...ClientOptionFunc) (
 	return client, err
 }
 
+// NewClientWithTokenSource creates a new client for a given driver and serverURL,
+// reading the token of every request from the source, e.g. Vault or a Kubernetes
+// secret, so a rotated token is used without recreating the client
+func NewClientWithTokenSource(driver, serverURL string, source scm.TokenSource, opts ...ClientOptionFunc) (*scm.Client, error) {
+	if driver == "" {
+		driver = "github"
+	}
+	var client *scm.Client
+	var err error
+
+	switch driver {
+	case "gitea":
+		if serverURL == "" {
+			return nil, ErrMissingGitServerURL
+		}
+		client, err = gitea.NewWithTokenSource(serverURL, source)
+	case "gitlab", "bitbucketcloud":
+		client, err = NewClient(driver, serverURL, "")
+		if err == nil {
+			client.Client = &http.Client{
+				Transport: &transport.PrivateToken{
+					Source: source,
+				},
+			}
+		}
+	default:
+		client, err = NewClient(driver, serverURL, "")
+		if err == nil {
+			client.Client = &http.Client{
+				Transport: &scmoauth2.Transport{
+					Source: source,
+				},
+			}
+		}
+	}
+	if err != nil {
+		return client, err
+	}
+	for _, o := range opts {
+		o(client)
+	}
+	return client, err
+}
+
 // NewClientFromEnvironment creates a new client using environment variables $GIT_KIND, $GIT_SERVER, $GIT_TOKEN
-// defaulting to github if no $GIT_KIND or $GIT_SERVER
+// defaulting to github if no $GIT_KIND or $GIT_SERVER. Without $GIT_TOKEN, the token is read from the file at
+// $GIT_TOKEN_FILE for every request
 func NewClientFromEnvironment() (*scm.Client, error) {
 	if repoURL := os.Getenv("GIT_REPO_URL"); repoURL != "" {
 		return FromRepoURL(repoURL)
@@ -141,14 +247,19 @@ func NewClientFromEnvironment() (*scm.Client, error) {
 	driver := os.Getenv("GIT_KIND")
 	serverURL := os.Getenv("GIT_SERVER")
 	oauthToken := os.Getenv("GIT_TOKEN")
-	if oauthToken == "" {
+	var client *scm.Client
+	var err error
+	if oauthToken != "" {
+		client, err = NewClient(driver, serverURL, oauthToken)
+	} else if os.Getenv("GIT_TOKEN_FILE") != "" {
+		client, err = NewClientWithTokenSource(driver, serverURL, EnvironmentTokenSource())
+	} else {
 		return nil, fmt.Errorf("No Git OAuth token specified for $GIT_TOKEN")
 	}
-	client, err := NewClient(driver, serverURL, oauthToken)
 	if driver == "" {
 		driver = client.Driver.String()
 	}
-	fmt.Printf("using driver: %s and serverURL: %s\n", driver, serverURL)
+	fmt.Fprintf(os.Stderr, "using driver: %s and serverURL: %s\n", driver, serverURL)
 	return client, err
 }
 
@@ -185,6 +296,14 @@ func ensureGHEEndpoint(u string) string {
 	return u
 }
 
+// ensureGiteeEndpoint lets ensure we have the /api/v5 suffix on the URL
+func ensureGiteeEndpoint(u string) string {
+	if !strings.Contains(u, "/api/") {
+		u = scm.URLJoin(u, "/api/v5")
+	}
+	return u
+}
+
 // ensureBBCEndpoint lets ensure we have the /api/v3 suffix on the URL
 func ensureBBCEndpoint(u string) string {
 	if strings.HasPrefix(u, "https://bitbucket.org") || strings.HasPrefix(u, "http://bitbucket.org") {
@@ -200,29 +319,109 @@ func Client(httpClient *http.Client) ClientOptionFunc {
 	}
 }
 
-// NewWebHookService creates a new instance of the webhook service without the rest of the client
-func NewWebHookService(driver string) (scm.WebhookService, error) {
+// DryRun enables the client dry-run mode, where mutating requests are passed to fn
+// instead of being sent, see scm.Client.DryRun
+func DryRun(fn scm.DryRunFunc) ClientOptionFunc {
+	return func(c *scm.Client) {
+		c.DryRun = fn
+	}
+}
+
+// Retry retries the failed requests of the client according to the policy,
+// see scm.Client.Retry
+func Retry(policy *scm.RetryPolicy) ClientOptionFunc {
+	return func(c *scm.Client) {
+		c.Retry = policy
+	}
+}
+
+// Cache enables the read-through cache of repositories, file contents and users,
+// see cache.Wrap
+func Cache(opts cache.Options) ClientOptionFunc {
+	return func(c *scm.Client) {
+		cache.Wrap(c, opts)
+	}
+}
+
+// Dedup collapses the concurrent identical GET requests of the client into a
+// single request, see transport.Dedup. Set the HTTP client of the client first.
+func Dedup() ClientOptionFunc {
+	return func(c *scm.Client) {
+		wrapTransport(c, func(base http.RoundTripper) http.RoundTripper {
+			return &transport.Dedup{Base: base}
+		})
+	}
+}
+
+// Headers sets the headers on every request of the client, e.g. the assertions
+// required by an identity-aware proxy, see transport.Headers. Set the HTTP client
+// of the client first.
+func Headers(header http.Header) ClientOptionFunc {
+	return func(c *scm.Client) {
+		wrapTransport(c, func(base http.RoundTripper) http.RoundTripper {
+			return &transport.Headers{Base: base, Static: header}
+		})
+	}
+}
+
+// HeaderFunc sets the headers returned by fn on the requests of the client, see
+// transport.Headers. Set the HTTP client of the client first.
+func HeaderFunc(fn func(*http.Request) (http.Header, error)) ClientOptionFunc {
+	return func(c *scm.Client) {
+		wrapTransport(c, func(base http.RoundTripper) http.RoundTripper {
+			return &transport.Headers{Base: base, Func: fn}
+		})
+	}
+}
+
+// wrapTransport sets the transport returned by wrap on a copy of the HTTP
+// client of the client, passing it the transport it wraps.
+func wrapTransport(c *scm.Client, wrap func(base http.RoundTripper) http.RoundTripper) {
+	httpClient := http.DefaultClient
+	if c.Client != nil {
+		httpClient = c.Client
+	}
+	copied := *httpClient
+	copied.Transport = wrap(httpClient.Transport)
+	c.Client = &copied
+}
+
+// NewWebHookService creates a new instance of the webhook service without the rest of the client.
+// The optional options configure how webhook requests are read.
+func NewWebHookService(driver string, opts ...scm.WebhookServiceOptions) (scm.WebhookService, error) {
 	if driver == "" {
 		driver = "github"
 	}
 	var service scm.WebhookService
 	switch driver {
 	case "bitbucket", "bitbucketcloud":
-		service = bitbucket.NewWebHookService()
+		service = bitbucket.NewWebHookService(opts...)
 	case "fake", "fakegit":
 		// TODO: support fake
 	case "gitea":
-		service = gitea.NewWebHookService()
+		service = gitea.NewWebHookService(opts...)
+	case "gitee":
+		service = gitee.NewWebHookService(opts...)
 	case "github":
-		service = github.NewWebHookService()
+		service = github.NewWebHookService(opts...)
 	case "gitlab":
-		service = gitlab.NewWebHookService()
+		service = gitlab.NewWebHookService(opts...)
 	case "gogs":
-		service = gogs.NewWebHookService()
+		service = gogs.NewWebHookService(opts...)
+	case "sourcehut":
+		service = sourcehut.NewWebHookService(opts...)
 	case "stash", "bitbucketserver":
-		service = stash.NewWebHookService()
+		service = stash.NewWebHookService(opts...)
 	default:
-		return nil, fmt.Errorf("Unsupported GIT_KIND value: %s", driver)
+		d, ok := registered(driver)
+		if !ok {
+			return nil, fmt.Errorf("Unsupported GIT_KIND value: %s", driver)
+		}
+		webhooks, ok := d.(WebHookDriver)
+		if !ok {
+			return nil, fmt.Errorf("Driver %s does not support webhook services", driver)
+		}
+		service = webhooks.NewWebHookService(opts...)
 	}
 
 	return service, nil
@@ -0,0 +1,117 @@
+// Package kube creates clients from the git credentials stored in a
+// Kubernetes Secret, as stored by the Jenkins X and Tekton controllers.
+//
+// The Secret has the following keys:
+//
+//	kind      the driver, e.g. github, gitlab, gitea or stash
+//	url       the server URL, optional for github and gitlab
+//	token     the token, or
+//	username  the username and
+//	password  the password
+//
+// The package does not depend on the Kubernetes client: pass the Data of
+// a Secret fetched with client-go to NewClient, or the directory of a
+// Secret mounted as a volume to NewClientFromDir.
+package kube
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/factory"
+	"github.com/slimm609/go-scm/scm/transport"
+)
+
+// The keys of the Secret.
+const (
+	KeyKind     = "kind"
+	KeyURL      = "url"
+	KeyToken    = "token"
+	KeyUsername = "username"
+	KeyPassword = "password"
+)
+
+// NewClient creates a new client from the data of a Secret.
+func NewClient(data map[string][]byte, opts ...factory.ClientOptionFunc) (*scm.Client, error) {
+	kind, serverURL := value(data, KeyKind), value(data, KeyURL)
+	if kind == "" {
+		return nil, fmt.Errorf("the secret has no %s", KeyKind)
+	}
+	if token := value(data, KeyToken); token != "" {
+		return factory.NewClientWithTokenSource(kind, serverURL, staticToken(token), opts...)
+	}
+	username, password := value(data, KeyUsername), value(data, KeyPassword)
+	if username == "" || password == "" {
+		return nil, fmt.Errorf("the secret has neither a %s nor a %s and a %s", KeyToken, KeyUsername, KeyPassword)
+	}
+	if kind == "gitea" {
+		return factory.NewClientWithBasicAuth(kind, serverURL, username, password, opts...)
+	}
+	client, err := factory.NewClient(kind, serverURL, "")
+	if err != nil {
+		return nil, err
+	}
+	client.Client = &http.Client{
+		Transport: &transport.BasicAuth{
+			Username: username,
+			Password: password,
+		},
+	}
+	for _, o := range opts {
+		o(client)
+	}
+	return client, nil
+}
+
+// NewClientFromDir creates a new client from a Secret mounted as a volume
+// at dir. The token file is read again for every request, so the client uses
+// the token rotated by Kubernetes without being recreated.
+func NewClientFromDir(dir string, opts ...factory.ClientOptionFunc) (*scm.Client, error) {
+	data := map[string][]byte{}
+	for _, key := range []string{KeyKind, KeyURL, KeyToken, KeyUsername, KeyPassword} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, key))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		data[key] = b
+	}
+	if value(data, KeyToken) == "" {
+		return NewClient(data, opts...)
+	}
+	kind, serverURL := value(data, KeyKind), value(data, KeyURL)
+	if kind == "" {
+		return nil, fmt.Errorf("the secret has no %s", KeyKind)
+	}
+	return factory.NewClientWithTokenSource(kind, serverURL, fileToken(filepath.Join(dir, KeyToken)), opts...)
+}
+
+// value returns the trimmed value of the key, since the values
+// written with echo end with a newline.
+func value(data map[string][]byte, key string) string {
+	return strings.TrimSpace(string(data[key]))
+}
+
+func staticToken(token string) scm.TokenSource {
+	return scm.TokenSourceFunc(func(context.Context) (*scm.Token, error) {
+		return &scm.Token{Token: token}, nil
+	})
+}
+
+func fileToken(name string) scm.TokenSource {
+	return scm.TokenSourceFunc(func(context.Context) (*scm.Token, error) {
+		b, err := ioutil.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		return &scm.Token{Token: strings.TrimSpace(string(b))}, nil
+	})
+}
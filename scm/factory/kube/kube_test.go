@@ -0,0 +1,79 @@
+package kube
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/transport"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClient(t *testing.T) {
+	client, err := NewClient(map[string][]byte{
+		"kind":     []byte("stash"),
+		"url":      []byte("https://stash.example.com\n"),
+		"username": []byte("jenkins"),
+		"password": []byte("secret"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, scm.DriverStash, client.Driver)
+	basic, ok := client.Client.Transport.(*transport.BasicAuth)
+	if assert.True(t, ok, "Want a basic auth transport") {
+		assert.Equal(t, "jenkins", basic.Username)
+	}
+
+	client, err = NewClient(map[string][]byte{
+		"kind":  []byte("gitlab"),
+		"token": []byte("glpat"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, scm.DriverGitlab, client.Driver)
+
+	_, err = NewClient(map[string][]byte{"token": []byte("glpat")})
+	assert.Error(t, err, "Want an error without kind")
+
+	_, err = NewClient(map[string][]byte{"kind": []byte("github"), "username": []byte("jenkins")})
+	assert.Error(t, err, "Want an error without credentials")
+}
+
+func TestNewClientFromDir(t *testing.T) {
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Private-Token"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "username": "john_smith"}`))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "go-scm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "kind"), []byte("gitlab\n"), 0600)
+	ioutil.WriteFile(filepath.Join(dir, "url"), []byte(server.URL), 0600)
+	ioutil.WriteFile(filepath.Join(dir, "token"), []byte("first\n"), 0600)
+
+	client, err := NewClientFromDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Users.Find(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ioutil.WriteFile(filepath.Join(dir, "token"), []byte("second\n"), 0600)
+	if _, _, err := client.Users.Find(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"first", "second"}, tokens)
+}
@@ -0,0 +1,131 @@
+package factory
+
+import (
+	"context"
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxy(t *testing.T) {
+	var requested string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.String()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "username": "john_smith"}`))
+	}))
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	client, err := NewClient("gitlab", "http://gitlab.internal", "token", Proxy(proxyURL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, _, err := client.Users.Find(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "john_smith", user.Login)
+	assert.Equal(t, "http://gitlab.internal/api/v4/user", requested)
+}
+
+func TestUnixSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-scm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "api.sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var token string
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("Private-Token")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "username": "john_smith"}`))
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	client, err := NewClient("gitlab", "http://gitlab.internal", "token", UnixSocket(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, _, err := client.Users.Find(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "john_smith", user.Login)
+	assert.Equal(t, "token", token)
+}
+
+func TestWithClientCertificate(t *testing.T) {
+	var presented int
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented = len(r.TLS.PeerCertificates)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "username": "john_smith"}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	client, err := NewClient("gitlab", server.URL, "token",
+		Client(server.Client()),
+		WithClientCertificate("testdata/client.crt", "testdata/client.key"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = client.Users.Find(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, presented)
+}
+
+func TestWithClientCertificate_Gitea(t *testing.T) {
+	var presented int
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented = len(r.TLS.PeerCertificates)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "login": "jcitizen"}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	// the Gitea SDK checks the server version when the client
+	// is created, before the options are applied.
+	defer gock.Off()
+	gock.New(server.URL).
+		Get("/api/v1/version").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"version": "1.12.4"}`)
+
+	client, err := NewClient("gitea", server.URL, "token",
+		Client(server.Client()),
+		WithClientCertificate("testdata/client.crt", "testdata/client.key"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, _, err := client.Users.Find(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "jcitizen", user.Login)
+	assert.Equal(t, 1, presented)
+}
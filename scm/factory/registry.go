@@ -0,0 +1,90 @@
+package factory
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// Driver is a driver shipped outside of this module, registered with
+// Register so the factory functions create its clients by name.
+type Driver interface {
+	// NewClient returns a new client for the server URL, which is empty
+	// if no server URL is specified.
+	NewClient(serverURL string) (*scm.Client, error)
+}
+
+// WebHookDriver is a Driver which parses webhooks without a client, so
+// NewWebHookService accepts its name.
+type WebHookDriver interface {
+	Driver
+
+	// NewWebHookService returns a new webhook service.
+	NewWebHookService(opts ...scm.WebhookServiceOptions) scm.WebhookService
+}
+
+// Constructor is an adapter to register a function creating the clients
+// of a driver as a Driver.
+type Constructor func(serverURL string) (*scm.Client, error)
+
+// NewClient calls f(serverURL).
+func (f Constructor) NewClient(serverURL string) (*scm.Client, error) {
+	return f(serverURL)
+}
+
+// builtinDrivers are the names of the drivers of the factory switch
+// statements, which cannot be registered.
+var builtinDrivers = map[string]bool{
+	"bitbucket":       true,
+	"bitbucketcloud":  true,
+	"fake":            true,
+	"fakegit":         true,
+	"gitea":           true,
+	"gitee":           true,
+	"githttp":         true,
+	"github":          true,
+	"gitiles":         true,
+	"gitlab":          true,
+	"gogs":            true,
+	"local":           true,
+	"sourcehut":       true,
+	"stash":           true,
+	"bitbucketserver": true,
+}
+
+var (
+	driversMu sync.RWMutex
+	drivers   = map[string]Driver{}
+)
+
+// Register makes a driver available by name to NewClient, NewClientWithTokenSource,
+// FromRepoURL and, if it is a WebHookDriver, NewWebHookService. It is meant to be
+// called from the init function of the package of the driver. The token of the
+// client is set as with the built-in drivers, as an OAuth bearer token, unless a
+// Client option replaces the http client.
+//
+// Register panics if the driver is nil, or if the name is empty, the name of a
+// built-in driver or already registered.
+func Register(name string, driver Driver) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	if driver == nil {
+		panic("factory: Register driver is nil")
+	}
+	if name == "" || builtinDrivers[name] {
+		panic(fmt.Sprintf("factory: Register of reserved driver name %q", name))
+	}
+	if _, dup := drivers[name]; dup {
+		panic(fmt.Sprintf("factory: Register called twice for driver %q", name))
+	}
+	drivers[name] = driver
+}
+
+// registered returns the registered driver of the name.
+func registered(name string) (Driver, bool) {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	driver, ok := drivers[name]
+	return driver, ok
+}
@@ -0,0 +1,95 @@
+package factory
+
+import (
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/fake"
+	"github.com/slimm609/go-scm/scm/driver/github"
+)
+
+type webhookDriver struct{}
+
+func (webhookDriver) NewClient(serverURL string) (*scm.Client, error) {
+	client, _ := fake.NewDefault()
+	return client, nil
+}
+
+func (webhookDriver) NewWebHookService(opts ...scm.WebhookServiceOptions) scm.WebhookService {
+	return github.NewWebHookService(opts...)
+}
+
+func TestRegister(t *testing.T) {
+	var got string
+	Register("register-test", Constructor(func(serverURL string) (*scm.Client, error) {
+		got = serverURL
+		client, _ := fake.NewDefault()
+		return client, nil
+	}))
+
+	client, err := NewClient("register-test", "https://scm.example.com", "abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client == nil || got != "https://scm.example.com" {
+		t.Errorf("Expect the client created by the registered driver")
+	}
+	if _, err := NewWebHookService("register-test"); err == nil {
+		t.Errorf("Expect error creating the webhook service of a driver without webhooks")
+	}
+}
+
+func TestRegister_WebHookDriver(t *testing.T) {
+	Register("register-webhook-test", webhookDriver{})
+
+	service, err := NewWebHookService("register-webhook-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if service == nil {
+		t.Errorf("Expect the webhook service of the registered driver")
+	}
+}
+
+func TestRegister_Panics(t *testing.T) {
+	Register("register-panic-test", webhookDriver{})
+
+	tests := []struct {
+		name   string
+		driver Driver
+	}{
+		{"nil driver", nil},
+		{"", webhookDriver{}},
+		{"github", webhookDriver{}},
+		{"register-panic-test", webhookDriver{}},
+	}
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expect Register of %q to panic", test.name)
+				}
+			}()
+			Register(test.name, test.driver)
+		}()
+	}
+}
+
+func TestBuiltinDrivers(t *testing.T) {
+	defer gock.Off()
+
+	// the gitea client requests the version of the server.
+	gock.New("https://scm.example.com").
+		Get("/api/v1/version").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"version": "1.12.4"}`)
+
+	for name := range builtinDrivers {
+		_, err := NewClient(name, "https://scm.example.com", "")
+		if err != nil {
+			t.Errorf("Expect built-in driver %s, got %v", name, err)
+		}
+	}
+}
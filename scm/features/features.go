@@ -0,0 +1,75 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package features reports which methods of the services of
+// scm.Client each driver implements. The matrix is generated
+// from the sources of the drivers: a driver supports the
+// methods of the services it sets on the client, except the
+// methods which only return scm.ErrNotSupported.
+package features
+
+//go:generate go run gen.go
+
+import "sort"
+
+// Matrix maps the driver names to the services of scm.Client
+// and their methods, to whether the driver supports them.
+type Matrix map[string]map[string]map[string]bool
+
+// All returns the feature matrix of all drivers. The services
+// a driver does not set on the client map all their methods
+// to false.
+func All() Matrix {
+	out := Matrix{}
+	for driver, set := range drivers {
+		out[driver] = map[string]map[string]bool{}
+		for service, methods := range services {
+			out[driver][service] = map[string]bool{}
+			for _, method := range methods {
+				out[driver][service][method] = false
+			}
+			for _, method := range set[service] {
+				out[driver][service][method] = true
+			}
+		}
+	}
+	return out
+}
+
+// Drivers returns the names of the drivers, sorted by name.
+func Drivers() []string {
+	var out []string
+	for driver := range drivers {
+		out = append(out, driver)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Services returns the services of scm.Client, sorted by name.
+func Services() []string {
+	var out []string
+	for service := range services {
+		out = append(out, service)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Methods returns the methods of the service, sorted by name.
+func Methods(service string) []string {
+	return append([]string(nil), services[service]...)
+}
+
+// Supports reports whether the driver supports the method of
+// the service. The driver name is the one of scm.Driver, as in
+// client.Driver.String().
+func Supports(driver, service, method string) bool {
+	for _, v := range drivers[driver][service] {
+		if v == method {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package features
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/slimm609/go-scm/scm/features/internal/scan"
+)
+
+func TestGenerated(t *testing.T) {
+	result, err := scan.Scan("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := scan.Generate(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadFile("matrix.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(got), string(want)); diff != "" {
+		t.Errorf("matrix.go is out of date, run go generate")
+		t.Log(diff)
+	}
+}
+
+func TestSupports(t *testing.T) {
+	tests := []struct {
+		driver, service, method string
+		want                    bool
+	}{
+		{"github", "Git", "FindCommit", true},
+		{"github", "Contents", "Delete", false},
+		{"gitiles", "Contents", "Find", true},
+		{"gitiles", "Contents", "Create", false},
+		{"githttp", "Issues", "Find", false},
+		{"bitbucket", "Issues", "Find", false},
+		{"local", "Issues", "Find", true},
+		{"local", "Contents", "Create", true},
+		{"unknown", "Git", "FindCommit", false},
+	}
+	for _, test := range tests {
+		if got := Supports(test.driver, test.service, test.method); got != test.want {
+			t.Errorf("Want Supports(%q, %q, %q) %v, got %v", test.driver, test.service, test.method, test.want, got)
+		}
+	}
+}
+
+func TestAll(t *testing.T) {
+	all := All()
+	if got, want := len(all), len(Drivers()); got != want {
+		t.Errorf("Want %d drivers, got %d", want, got)
+	}
+	for _, driver := range Drivers() {
+		for _, service := range Services() {
+			for _, method := range Methods(service) {
+				supported, ok := all[driver][service][method]
+				if !ok {
+					t.Errorf("Want %s %s.%s in the matrix", driver, service, method)
+				}
+				if want := Supports(driver, service, method); supported != want {
+					t.Errorf("Want %s %s.%s %v, got %v", driver, service, method, want, supported)
+				}
+			}
+		}
+	}
+	all["github"]["Git"]["FindCommit"] = false
+	if !Supports("github", "Git", "FindCommit") {
+		t.Errorf("Expect All to return a copy")
+	}
+}
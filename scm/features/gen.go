@@ -0,0 +1,36 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build ignore
+// +build ignore
+
+// This program generates matrix.go from the sources of the
+// drivers. Invoke it with go generate.
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+
+	"github.com/slimm609/go-scm/scm/features/internal/scan"
+)
+
+func main() {
+	root := flag.String("root", "..", "directory of the scm package")
+	out := flag.String("o", "matrix.go", "output file")
+	flag.Parse()
+
+	result, err := scan.Scan(*root)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := scan.Generate(result)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,435 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package scan builds the feature matrix of the drivers from
+// their sources, for the generator of the features package.
+package scan
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// driverImport is the import path prefix of the drivers.
+const driverImport = "github.com/slimm609/go-scm/scm/driver/"
+
+// Result is the feature matrix of the drivers.
+type Result struct {
+	// Services maps the services of scm.Client to their
+	// methods, sorted by name.
+	Services map[string][]string
+
+	// Drivers maps the driver names to the services they
+	// set and their supported methods, sorted by name.
+	Drivers map[string]map[string][]string
+}
+
+// Scan scans the scm package in the root directory and the
+// drivers in its driver directory. A driver supports the
+// methods of the services it sets on the client, except the
+// methods which only return scm.ErrNotSupported. The
+// services a driver does not set are taken from the driver
+// it creates its client with, if any.
+func Scan(root string) (*Result, error) {
+	services, err := scanServices(root)
+	if err != nil {
+		return nil, err
+	}
+	dirs, err := ioutil.ReadDir(filepath.Join(root, "driver"))
+	if err != nil {
+		return nil, err
+	}
+	drivers := map[string]*driver{}
+	for _, dir := range dirs {
+		if !dir.IsDir() || dir.Name() == "internal" {
+			continue
+		}
+		d, err := scanDriver(filepath.Join(root, "driver", dir.Name()), services)
+		if err != nil {
+			return nil, err
+		}
+		drivers[dir.Name()] = d
+	}
+
+	out := &Result{
+		Services: services,
+		Drivers:  map[string]map[string][]string{},
+	}
+	for name := range drivers {
+		out.Drivers[name] = resolve(name, drivers, map[string]bool{})
+	}
+	return out, nil
+}
+
+// driver is the scanned source of a driver.
+type driver struct {
+	// supported maps the services set by the driver to
+	// their supported methods.
+	supported map[string][]string
+
+	// base is the driver the client is created with.
+	base string
+}
+
+// resolve returns the services of the driver, with the
+// services it does not set taken from its base driver.
+func resolve(name string, drivers map[string]*driver, seen map[string]bool) map[string][]string {
+	seen[name] = true
+	d := drivers[name]
+	out := map[string][]string{}
+	if d.base != "" && !seen[d.base] && drivers[d.base] != nil {
+		for service, methods := range resolve(d.base, drivers, seen) {
+			out[service] = methods
+		}
+	}
+	for service, methods := range d.supported {
+		out[service] = methods
+	}
+	return out
+}
+
+// scanServices returns the service fields of scm.Client and
+// the methods of their interfaces.
+func scanServices(root string) (map[string][]string, error) {
+	files, err := parseDir(root)
+	if err != nil {
+		return nil, err
+	}
+	interfaces := map[string]*ast.InterfaceType{}
+	var client *ast.StructType
+	for _, file := range files {
+		for name, spec := range typeSpecs(file) {
+			switch t := spec.Type.(type) {
+			case *ast.InterfaceType:
+				interfaces[name] = t
+			case *ast.StructType:
+				if name == "Client" {
+					client = t
+				}
+			}
+		}
+	}
+	if client == nil {
+		return nil, fmt.Errorf("scan: scm.Client not found in %s", root)
+	}
+
+	out := map[string][]string{}
+	for _, field := range client.Fields.List {
+		ident, ok := field.Type.(*ast.Ident)
+		if !ok || interfaces[ident.Name] == nil {
+			continue
+		}
+		methods := interfaceMethods(ident.Name, interfaces)
+		sort.Strings(methods)
+		for _, name := range field.Names {
+			out[name.Name] = methods
+		}
+	}
+	return out, nil
+}
+
+// interfaceMethods returns the methods of the interface,
+// including the methods of the embedded interfaces.
+func interfaceMethods(name string, interfaces map[string]*ast.InterfaceType) []string {
+	var out []string
+	for _, field := range interfaces[name].Methods.List {
+		if len(field.Names) == 0 {
+			if ident, ok := field.Type.(*ast.Ident); ok && interfaces[ident.Name] != nil {
+				out = append(out, interfaceMethods(ident.Name, interfaces)...)
+			}
+			continue
+		}
+		for _, name := range field.Names {
+			out = append(out, name.Name)
+		}
+	}
+	return out
+}
+
+// scanDriver returns the services set by the driver in the
+// directory, and their supported methods.
+func scanDriver(dir string, services map[string][]string) (*driver, error) {
+	files, err := parseDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// the methods and the embedded types of the types of the
+	// package, to find the methods promoted from an embedded
+	// type.
+	methods := map[string]map[string]*ast.FuncDecl{}
+	embedded := map[string][]string{}
+	external := map[string]bool{}
+	for _, file := range files {
+		for name, spec := range typeSpecs(file) {
+			st, ok := spec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			for _, field := range st.Fields.List {
+				if len(field.Names) != 0 {
+					continue
+				}
+				switch t := deref(field.Type).(type) {
+				case *ast.Ident:
+					embedded[name] = append(embedded[name], t.Name)
+				case *ast.SelectorExpr:
+					external[name] = true
+				}
+			}
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+				continue
+			}
+			recv, ok := deref(fn.Recv.List[0].Type).(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if methods[recv.Name] == nil {
+				methods[recv.Name] = map[string]*ast.FuncDecl{}
+			}
+			methods[recv.Name][fn.Name.Name] = fn
+		}
+	}
+
+	out := &driver{supported: map[string][]string{}}
+	for _, file := range files {
+		imports := driverImports(file)
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch n := node.(type) {
+			case *ast.AssignStmt:
+				for i, lhs := range n.Lhs {
+					sel, ok := lhs.(*ast.SelectorExpr)
+					if !ok || services[sel.Sel.Name] == nil || i >= len(n.Rhs) {
+						continue
+					}
+					if _, set := out.supported[sel.Sel.Name]; set {
+						continue
+					}
+					// the drivers set their services to composite
+					// literals, any other assignment is to a field
+					// of the same name.
+					name, ok := compositeType(n.Rhs[i])
+					if !ok {
+						continue
+					}
+					var supported []string
+					for _, method := range services[sel.Sel.Name] {
+						fn, found := findMethod(name, method, methods, embedded, external, map[string]bool{})
+						if found && (fn == nil || !isStub(fn)) {
+							supported = append(supported, method)
+						}
+					}
+					out.supported[sel.Sel.Name] = supported
+				}
+			case *ast.CallExpr:
+				sel, ok := n.Fun.(*ast.SelectorExpr)
+				if !ok || !strings.HasPrefix(sel.Sel.Name, "New") {
+					break
+				}
+				if pkg, ok := sel.X.(*ast.Ident); ok && imports[pkg.Name] != "" && out.base == "" {
+					out.base = imports[pkg.Name]
+				}
+			}
+			return true
+		})
+	}
+	return out, nil
+}
+
+// findMethod returns the declaration of the method of the
+// type, or of the types it embeds. The declaration is nil if
+// the method may be promoted from a type of another package.
+func findMethod(typ, method string, methods map[string]map[string]*ast.FuncDecl, embedded map[string][]string, external map[string]bool, seen map[string]bool) (*ast.FuncDecl, bool) {
+	if seen[typ] {
+		return nil, false
+	}
+	seen[typ] = true
+	if fn, ok := methods[typ][method]; ok {
+		return fn, true
+	}
+	for _, name := range embedded[typ] {
+		if fn, ok := findMethod(name, method, methods, embedded, external, seen); ok {
+			return fn, true
+		}
+	}
+	return nil, external[typ]
+}
+
+// isStub returns true if the body of the function is a
+// single return statement returning scm.ErrNotSupported, or
+// the error of the notSupported function of the driver.
+func isStub(fn *ast.FuncDecl) bool {
+	if fn.Body == nil || len(fn.Body.List) != 1 {
+		return false
+	}
+	ret, ok := fn.Body.List[0].(*ast.ReturnStmt)
+	if !ok {
+		return false
+	}
+	for _, result := range ret.Results {
+		if sel, ok := result.(*ast.SelectorExpr); ok && sel.Sel.Name == "ErrNotSupported" {
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "scm" {
+				return true
+			}
+		}
+		if call, ok := result.(*ast.CallExpr); ok {
+			if fun, ok := call.Fun.(*ast.Ident); ok && fun.Name == "notSupported" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// compositeType returns the name of the type of a composite
+// literal, or of the address of a composite literal.
+func compositeType(expr ast.Expr) (string, bool) {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return "", false
+	}
+	ident, ok := lit.Type.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	return ident.Name, true
+}
+
+// driverImports maps the names of the drivers imported by
+// the file to the driver names.
+func driverImports(file *ast.File) map[string]string {
+	out := map[string]string{}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if !strings.HasPrefix(path, driverImport) {
+			continue
+		}
+		name := strings.TrimPrefix(path, driverImport)
+		if strings.Contains(name, "/") {
+			continue
+		}
+		if spec.Name != nil {
+			out[spec.Name.Name] = name
+		} else {
+			out[name] = name
+		}
+	}
+	return out
+}
+
+func typeSpecs(file *ast.File) map[string]*ast.TypeSpec {
+	out := map[string]*ast.TypeSpec{}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			if ts, ok := spec.(*ast.TypeSpec); ok {
+				out[ts.Name.Name] = ts
+			}
+		}
+	}
+	return out
+}
+
+func deref(expr ast.Expr) ast.Expr {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		return star.X
+	}
+	return expr
+}
+
+// parseDir parses the non-test files of the package in the
+// directory.
+func parseDir(dir string) ([]*ast.File, error) {
+	fset := token.NewFileSet()
+	filter := func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}
+	pkgs, err := parser.ParseDir(fset, dir, filter, 0)
+	if err != nil {
+		return nil, err
+	}
+	var out []*ast.File
+	for name, pkg := range pkgs {
+		if strings.HasSuffix(name, "_test") || name == "main" {
+			continue
+		}
+		for _, file := range pkg.Files {
+			out = append(out, file)
+		}
+	}
+	return out, nil
+}
+
+// Generate writes the Go source of the matrix of the
+// features package.
+func Generate(result *Result) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteString("// Code generated by gen.go. DO NOT EDIT.\n\n")
+	buf.WriteString("package features\n\n")
+
+	buf.WriteString("// services maps the services of scm.Client to their methods.\n")
+	buf.WriteString("var services = map[string][]string{\n")
+	for _, service := range sortedKeys(result.Services) {
+		fmt.Fprintf(buf, "%q: {%s},\n", service, quoteList(result.Services[service]))
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// drivers maps the drivers to the services they set and\n")
+	buf.WriteString("// their supported methods.\n")
+	buf.WriteString("var drivers = map[string]map[string][]string{\n")
+	for _, name := range sortedDrivers(result.Drivers) {
+		fmt.Fprintf(buf, "%q: {\n", name)
+		for _, service := range sortedKeys(result.Drivers[name]) {
+			fmt.Fprintf(buf, "%q: {%s},\n", service, quoteList(result.Drivers[name][service]))
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
+
+func quoteList(list []string) string {
+	quoted := make([]string, len(list))
+	for i, v := range list {
+		quoted[i] = strconv.Quote(v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func sortedKeys(m map[string][]string) []string {
+	var out []string
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func sortedDrivers(m map[string]map[string][]string) []string {
+	var out []string
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
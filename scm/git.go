@@ -6,6 +6,7 @@ package scm
 
 import (
 	"context"
+	"sync"
 	"time"
 )
 
@@ -45,6 +46,17 @@ type (
 		Size int
 	}
 
+	// Comparison represents the difference between a base
+	// ref and a head ref, which may live in a fork.
+	Comparison struct {
+		Status    string // ahead, behind, diverged or identical
+		AheadBy   int
+		BehindBy  int
+		MergeBase string
+		Commits   []*Commit
+		Changes   []*Change
+	}
+
 	// Signature identifies a git commit creator.
 	Signature struct {
 		Name  string
@@ -77,16 +89,60 @@ type (
 		// ListChanges returns the changeset between two commits.
 		ListChanges(ctx context.Context, repo, ref string, opts ListOptions) ([]*Change, *Response, error)
 
+		// CompareCommits compares the base and head refs of the
+		// repository. The commits are those reachable from head
+		// but not from base.
+		CompareCommits(ctx context.Context, repo, base, head string) (*Comparison, *Response, error)
+
+		// CompareAcrossForks compares the baseRef of baseRepo with
+		// the headRef of the fork of baseRepo owned by headOwner.
+		CompareAcrossForks(ctx context.Context, baseRepo, baseRef, headOwner, headRef string) (*Comparison, *Response, error)
+
 		// ListTags returns a list of git tags.
 		ListTags(ctx context.Context, repo string, opts ListOptions) ([]*Reference, *Response, error)
 
-		// FindRef returns the SHA of the given ref, such as "heads/master".
+		// FindRef returns the SHA of the given ref. The ref may
+		// be fully qualified (refs/heads/master), relative to
+		// refs/ (heads/master), or an unqualified name, which is
+		// looked up as a branch and then as a tag.
 		FindRef(ctx context.Context, repo, ref string) (string, *Response, error)
 
-		// DeleteRef deletes the given ref
+		// ResolveRefs returns the SHAs of the given refs, keyed
+		// by ref. Refs that could not be resolved are reported
+		// in the error map.
+		ResolveRefs(ctx context.Context, repo string, refs []string) (map[string]string, map[string]error)
+
+		// DeleteRef deletes the given ref. The ref accepts the
+		// same forms as FindRef.
 		DeleteRef(ctx context.Context, repo, ref string) (*Response, error)
 
-		// CreateRef creates a new ref
+		// CreateRef creates a new ref pointing at the given sha.
+		// The ref accepts the same forms as FindRef.
 		CreateRef(ctx context.Context, repo, ref, sha string) (*Reference, *Response, error)
+
+		// UpdateRef moves an existing ref to the given sha. A
+		// non fast-forward update is rejected unless force is set.
+		// Only the GitHub, local and fake drivers support it, the
+		// others return ErrNotSupported.
+		UpdateRef(ctx context.Context, repo, ref, sha string, force bool) (*Reference, *Response, error)
 	}
 )
+
+// ResolveRefs resolves the refs concurrently using the FindRef
+// method of the git service, with at most limit requests in
+// flight. Drivers without a native batch endpoint use this to
+// implement GitService.ResolveRefs.
+func ResolveRefs(ctx context.Context, service GitService, repo string, refs []string, limit int) (map[string]string, map[string]error) {
+	var mu sync.Mutex
+	shas := map[string]string{}
+	errs := forEachLimit(ctx, refs, limit, func(ref string) error {
+		sha, _, err := service.FindRef(ctx, repo, ref)
+		if err == nil {
+			mu.Lock()
+			shas[ref] = sha
+			mu.Unlock()
+		}
+		return err
+	})
+	return shas, errs
+}
@@ -0,0 +1,66 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"strings"
+)
+
+// botSuffix is appended by GitHub to the login of the bot user
+// acting for a GitHub App, e.g. "my-app[bot]".
+const botSuffix = "[bot]"
+
+// Identity is the user the client is authenticated as.
+type Identity struct {
+	ID    int
+	Login string
+}
+
+// Matches reports whether the login is the identity, so a bot can
+// skip its own comments and events. Logins are compared case
+// insensitively, and the "[bot]" suffix of the login of a GitHub
+// App is ignored on both sides.
+func (i *Identity) Matches(login string) bool {
+	trim := func(s string) string {
+		return strings.TrimSuffix(strings.ToLower(s), botSuffix)
+	}
+	return i.Login != "" && trim(i.Login) == trim(login)
+}
+
+// BotIdentity returns the user the client is authenticated as. It
+// is resolved with Users.Find on the first call and memoized, and
+// errors are not memoized. GitHub rejects Users.Find with the
+// token of a GitHub App installation, so set the identity of the
+// app bot with SetBotIdentity instead.
+func (c *Client) BotIdentity(ctx context.Context) (*Identity, error) {
+	c.identityMu.Lock()
+	defer c.identityMu.Unlock()
+	if c.identity != nil {
+		id := *c.identity
+		return &id, nil
+	}
+	user, _, err := c.Users.Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.identity = &Identity{ID: user.ID, Login: user.Login}
+	id := *c.identity
+	return &id, nil
+}
+
+// SetBotIdentity sets the identity returned by BotIdentity, e.g.
+// the "my-app[bot]" login of a GitHub App. A nil identity is
+// resolved again on the next call.
+func (c *Client) SetBotIdentity(id *Identity) {
+	c.identityMu.Lock()
+	defer c.identityMu.Unlock()
+	if id == nil {
+		c.identity = nil
+		return
+	}
+	copied := *id
+	c.identity = &copied
+}
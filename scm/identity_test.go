@@ -0,0 +1,50 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"testing"
+)
+
+// countingUsers counts the lookups of the authenticated user.
+type countingUsers struct {
+	UserService
+	calls int
+}
+
+func (s *countingUsers) Find(ctx context.Context) (*User, *Response, error) {
+	s.calls++
+	return &User{ID: 1, Login: "octocat"}, nil, nil
+}
+
+func TestBotIdentity(t *testing.T) {
+	users := &countingUsers{}
+	client := &Client{Users: users}
+	for i := 0; i < 2; i++ {
+		id, err := client.BotIdentity(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id.ID != 1 || id.Login != "octocat" {
+			t.Errorf("Want the authenticated user, got %v", id)
+		}
+	}
+	if users.calls != 1 {
+		t.Errorf("Want the identity memoized, got %d lookups", users.calls)
+	}
+
+	client.SetBotIdentity(&Identity{Login: "my-app[bot]"})
+	id, _ := client.BotIdentity(context.Background())
+	for login, want := range map[string]bool{
+		"my-app[bot]": true,
+		"My-App":      true,
+		"octocat":     false,
+	} {
+		if got := id.Matches(login); got != want {
+			t.Errorf("Want %s matched %v, got %v", login, want, got)
+		}
+	}
+}
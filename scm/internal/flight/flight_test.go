@@ -0,0 +1,127 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flight
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDo(t *testing.T) {
+	var g Group
+	var calls, shared int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err, s := g.Do(context.Background(), "key", func(context.Context) (interface{}, error) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(20 * time.Millisecond)
+				return "value", nil
+			})
+			if v != "value" || err != nil {
+				t.Errorf("Want value, got %v, %v", v, err)
+			}
+			if s {
+				atomic.AddInt32(&shared, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls+shared != 10 || calls == 10 {
+		t.Errorf("Want the concurrent calls collapsed, got %d calls and %d shared results", calls, shared)
+	}
+}
+
+func TestDo_Canceled(t *testing.T) {
+	var g Group
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fn := func(ctx context.Context) (interface{}, error) {
+		close(started)
+		select {
+		case <-release:
+			return "value", ctx.Err()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	// the first caller gives up, the call keeps running for
+	// the second caller.
+	first, cancel := context.WithCancel(context.Background())
+	errc := make(chan error)
+	go func() {
+		_, err, _ := g.Do(first, "key", fn)
+		errc <- err
+	}()
+	<-started
+	type result struct {
+		v      interface{}
+		err    error
+		shared bool
+	}
+	resc := make(chan result)
+	go func() {
+		v, err, shared := g.Do(context.Background(), "key", fn)
+		resc <- result{v, err, shared}
+	}()
+	for {
+		g.mu.Lock()
+		waiters := g.calls["key"].waiters
+		g.mu.Unlock()
+		if waiters == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Errorf("Want the first caller canceled, got %v", err)
+	}
+	close(release)
+	r := <-resc
+	if r.v != "value" || r.err != nil || !r.shared {
+		t.Errorf("Want the shared value, got %v, %v, %v", r.v, r.err, r.shared)
+	}
+}
+
+func TestDo_CanceledAll(t *testing.T) {
+	var g Group
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error, 1)
+	started := make(chan struct{})
+	go func() {
+		<-started
+		cancel()
+	}()
+	_, err, _ := g.Do(ctx, "key", func(ctx context.Context) (interface{}, error) {
+		close(started)
+		<-ctx.Done()
+		canceled <- ctx.Err()
+		return nil, ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Want the caller canceled, got %v", err)
+	}
+	if err := <-canceled; !errors.Is(err, context.Canceled) {
+		t.Errorf("Want the call canceled once its callers gave up, got %v", err)
+	}
+}
+
+func TestDo_Panic(t *testing.T) {
+	var g Group
+	_, err, _ := g.Do(context.Background(), "key", func(context.Context) (interface{}, error) {
+		panic("boom")
+	})
+	if !errors.Is(err, errPanicked) {
+		t.Errorf("Want the panic returned as an error, got %v", err)
+	}
+}
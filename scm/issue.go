@@ -6,6 +6,7 @@ package scm
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"time"
 )
@@ -18,7 +19,8 @@ type (
 		Body        string
 		Link        string
 		State       string
-		Labels      []string
+		StateReason string
+		Labels      []*Label
 		Closed      bool
 		Locked      bool
 		Author      User
@@ -26,6 +28,10 @@ type (
 		PullRequest bool
 		Created     time.Time
 		Updated     time.Time
+
+		// ClosedAt is the time the issue was closed, or the
+		// zero time if the issue is open.
+		ClosedAt time.Time
 	}
 
 	// SearchIssue for the results of a search which queries across repositories
@@ -39,6 +45,22 @@ type (
 	IssueInput struct {
 		Title string
 		Body  string
+
+		// State is IssueStateOpen or IssueStateClosed, and
+		// StateReason is one of the IssueStateReason values.
+		// The state is derived from the reason when only the
+		// reason is set. Both are ignored by Create, and an
+		// empty value leaves the issue unchanged on Update.
+		State       string
+		StateReason string
+
+		// Milestone, Labels and Assignees are applied when
+		// the issue is created, instead of with follow-up
+		// requests, by the drivers that support it. Milestone
+		// is the number accepted by SetMilestone.
+		Milestone int
+		Labels    []string
+		Assignees []string
 	}
 
 	// IssueListOptions provides options for querying a
@@ -48,6 +70,23 @@ type (
 		Size   int
 		Open   bool
 		Closed bool
+
+		// UpdatedSince optionally lists only the issues
+		// updated at or after the time. The drivers that
+		// cannot filter on the provider filter each page.
+		UpdatedSince time.Time
+	}
+
+	// CommentListOptions provides options for querying a
+	// list of issue or pull request comments. Since and
+	// Sort are sent to the providers supporting them and
+	// applied to the returned page by the other drivers.
+	CommentListOptions struct {
+		Page      int
+		Size      int
+		Since     time.Time
+		Sort      string
+		Direction string
 	}
 
 	// Comment represents a comment.
@@ -59,6 +98,11 @@ type (
 		Version int
 		Created time.Time
 		Updated time.Time
+
+		// AuthorAssociation is the relationship of the author
+		// with the repository, one of the AuthorAssociation
+		// values, if reported by the provider.
+		AuthorAssociation string
 	}
 
 	// CommentInput provides the input fields required for
@@ -97,7 +141,7 @@ type (
 		Search(context.Context, SearchOptions) ([]*SearchIssue, *Response, error)
 
 		// ListComments returns the issue comment list.
-		ListComments(context.Context, string, int, ListOptions) ([]*Comment, *Response, error)
+		ListComments(context.Context, string, int, CommentListOptions) ([]*Comment, *Response, error)
 
 		// ListLabels returns the labels on an issue
 		ListLabels(context.Context, string, int, ListOptions) ([]*Label, *Response, error)
@@ -108,6 +152,11 @@ type (
 		// Create creates a new issue.
 		Create(context.Context, string, *IssueInput) (*Issue, *Response, error)
 
+		// Update updates the title, body, state, milestone,
+		// labels and assignees of an issue. Empty fields are
+		// left unchanged.
+		Update(context.Context, string, int, *IssueInput) (*Issue, *Response, error)
+
 		// CreateComment creates a new issue comment.
 		CreateComment(context.Context, string, int, *CommentInput) (*Comment, *Response, error)
 
@@ -117,6 +166,15 @@ type (
 		// EditComment edits an existing issue comment.
 		EditComment(context.Context, string, int, int, *CommentInput) (*Comment, *Response, error)
 
+		// MinimizeComment hides an issue or pull request
+		// comment for the reason, one of the MinimizeReason
+		// values.
+		MinimizeComment(ctx context.Context, repo string, number int, id int, reason string) (*Response, error)
+
+		// UnminimizeComment shows a hidden issue or pull
+		// request comment again.
+		UnminimizeComment(ctx context.Context, repo string, number int, id int) (*Response, error)
+
 		// Close closes an issue.
 		Close(context.Context, string, int) (*Response, error)
 
@@ -146,9 +204,174 @@ type (
 
 		// ClearMilestone removes the milestone from an issue
 		ClearMilestone(ctx context.Context, repo string, id int) (*Response, error)
+
+		// Pin pins an issue to the repository.
+		Pin(ctx context.Context, repo string, number int) (*Response, error)
+
+		// Unpin unpins an issue from the repository.
+		Unpin(ctx context.Context, repo string, number int) (*Response, error)
+
+		// ListPinned returns the pinned issues of the repository.
+		ListPinned(ctx context.Context, repo string) ([]*Issue, *Response, error)
 	}
 )
 
+// Issue states accepted by IssueInput.
+const (
+	IssueStateOpen   = "open"
+	IssueStateClosed = "closed"
+)
+
+// Issue state reasons, following the GitHub state_reason
+// values. Drivers without state reasons only apply the state
+// the reason implies.
+const (
+	IssueStateReasonCompleted  = "completed"
+	IssueStateReasonNotPlanned = "not_planned"
+	IssueStateReasonReopened   = "reopened"
+)
+
+// Author associations of a comment, following the GitHub
+// author_association values.
+const (
+	AuthorAssociationOwner                = "OWNER"
+	AuthorAssociationMember               = "MEMBER"
+	AuthorAssociationCollaborator         = "COLLABORATOR"
+	AuthorAssociationContributor          = "CONTRIBUTOR"
+	AuthorAssociationFirstTimeContributor = "FIRST_TIME_CONTRIBUTOR"
+	AuthorAssociationFirstTimer           = "FIRST_TIMER"
+	AuthorAssociationNone                 = "NONE"
+)
+
+// Reasons a comment is minimized for.
+const (
+	MinimizeReasonSpam      = "spam"
+	MinimizeReasonAbuse     = "abuse"
+	MinimizeReasonOffTopic  = "off_topic"
+	MinimizeReasonOutdated  = "outdated"
+	MinimizeReasonDuplicate = "duplicate"
+	MinimizeReasonResolved  = "resolved"
+)
+
+// Edited returns true if the comment was updated after it
+// was created.
+func (c *Comment) Edited() bool {
+	return !c.Updated.IsZero() && c.Updated.After(c.Created)
+}
+
+// Comment sort fields and directions accepted by
+// CommentListOptions.
+const (
+	CommentSortCreated = "created"
+	CommentSortUpdated = "updated"
+
+	SortAscending  = "asc"
+	SortDescending = "desc"
+)
+
+// CommentLister lists the comments of an issue or a pull
+// request, and is implemented by IssueService and
+// PullRequestService.
+type CommentLister interface {
+	ListComments(context.Context, string, int, CommentListOptions) ([]*Comment, *Response, error)
+}
+
+// ListCommentsSince lists the comments of the issue or pull
+// request updated at or after since, following the
+// pagination, oldest first.
+func ListCommentsSince(ctx context.Context, lister CommentLister, repo string, number int, since time.Time) ([]*Comment, *Response, error) {
+	var all []*Comment
+	opts := CommentListOptions{
+		Page:      1,
+		Size:      100,
+		Since:     since,
+		Sort:      CommentSortUpdated,
+		Direction: SortAscending,
+	}
+	for {
+		comments, res, err := lister.ListComments(ctx, repo, number, opts)
+		if err != nil {
+			return nil, res, err
+		}
+		all = append(all, comments...)
+		if res == nil || res.Page.Next == 0 {
+			return FilterComments(all, opts), res, nil
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
+// FilterComments returns the comments updated at or after
+// opts.Since, sorted by opts.Sort in opts.Direction. The
+// comments are returned in their original order if Sort is
+// empty. Drivers use it for the options the provider does
+// not support.
+func FilterComments(comments []*Comment, opts CommentListOptions) []*Comment {
+	out := make([]*Comment, 0, len(comments))
+	for _, c := range comments {
+		if !opts.Since.IsZero() && commentUpdated(c).Before(opts.Since) {
+			continue
+		}
+		out = append(out, c)
+	}
+	if opts.Sort == "" {
+		return out
+	}
+	key := func(c *Comment) time.Time {
+		if opts.Sort == CommentSortUpdated {
+			return commentUpdated(c)
+		}
+		return c.Created
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if opts.Direction == SortDescending {
+			return key(out[i]).After(key(out[j]))
+		}
+		return key(out[i]).Before(key(out[j]))
+	})
+	return out
+}
+
+// FilterIssues returns the issues updated at or after since.
+// Drivers use it when the provider cannot filter the list.
+func FilterIssues(issues []*Issue, since time.Time) []*Issue {
+	if since.IsZero() {
+		return issues
+	}
+	out := make([]*Issue, 0, len(issues))
+	for _, issue := range issues {
+		if !issue.Updated.Before(since) {
+			out = append(out, issue)
+		}
+	}
+	return out
+}
+
+// commentUpdated returns the update time of the comment,
+// which is the creation time if it was never edited.
+func commentUpdated(c *Comment) time.Time {
+	if c.Updated.IsZero() {
+		return c.Created
+	}
+	return c.Updated
+}
+
+// TargetState returns the state the issue is moved to by the
+// input, derived from the state reason if the state is not
+// set, or an empty string if the state is unchanged.
+func (i *IssueInput) TargetState() string {
+	if i.State != "" {
+		return i.State
+	}
+	switch i.StateReason {
+	case IssueStateReasonCompleted, IssueStateReasonNotPlanned:
+		return IssueStateClosed
+	case IssueStateReasonReopened:
+		return IssueStateOpen
+	}
+	return ""
+}
+
 // QueryArgument returns the query argument for the search using '+' to separate the search terms while escaping :
 func (o *SearchOptions) QueryArgument() string {
 	query := o.Query
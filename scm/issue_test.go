@@ -0,0 +1,78 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// pagedComments serves the comment pages of an issue.
+type pagedComments struct {
+	pages [][]*Comment
+}
+
+func (s *pagedComments) ListComments(ctx context.Context, repo string, number int, opts CommentListOptions) ([]*Comment, *Response, error) {
+	res := &Response{}
+	if opts.Page < len(s.pages) {
+		res.Page.Next = opts.Page + 1
+	}
+	return s.pages[opts.Page-1], res, nil
+}
+
+func TestListCommentsSince(t *testing.T) {
+	at := func(minute int) time.Time {
+		return time.Date(2020, 1, 1, 0, minute, 0, 0, time.UTC)
+	}
+	lister := &pagedComments{
+		pages: [][]*Comment{
+			{
+				{ID: 1, Created: at(1)},
+				{ID: 2, Created: at(2), Updated: at(9)},
+			},
+			{
+				{ID: 3, Created: at(5)},
+				{ID: 4, Created: at(7)},
+			},
+		},
+	}
+	got, _, err := ListCommentsSince(context.Background(), lister, "octocat/hello-world", 1, at(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Comment{
+		{ID: 3, Created: at(5)},
+		{ID: 4, Created: at(7)},
+		{ID: 2, Created: at(2), Updated: at(9)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected comments\n%s", diff)
+	}
+}
+
+func TestFilterComments(t *testing.T) {
+	at := func(minute int) time.Time {
+		return time.Date(2020, 1, 1, 0, minute, 0, 0, time.UTC)
+	}
+	comments := []*Comment{
+		{ID: 1, Created: at(1)},
+		{ID: 2, Created: at(3)},
+		{ID: 3, Created: at(2)},
+	}
+	got := FilterComments(comments, CommentListOptions{Sort: CommentSortCreated, Direction: SortDescending})
+	var ids []int
+	for _, c := range got {
+		ids = append(ids, c.ID)
+	}
+	if diff := cmp.Diff([]int{2, 3, 1}, ids); diff != "" {
+		t.Errorf("Unexpected comment order\n%s", diff)
+	}
+	if got := FilterComments(comments, CommentListOptions{}); len(got) != 3 || got[0].ID != 1 {
+		t.Errorf("Want comments unchanged without options")
+	}
+}
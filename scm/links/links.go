@@ -0,0 +1,218 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package links builds the canonical web URLs of repositories,
+// pull requests, commits, files and compare views for each
+// driver, so deep links do not have to be constructed by hand.
+//
+//	builder, _ := links.New(scm.DriverStash, "https://stash.example.com")
+//	builder.PullRequest("PRJ/my-repo", 42)
+//	// https://stash.example.com/projects/PRJ/repos/my-repo/pull-requests/42/overview
+package links
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// Builder builds the web URLs of a provider.
+type Builder struct {
+	driver scm.Driver
+	server string
+}
+
+// New returns a Builder for the driver and the web address of
+// the server, e.g. https://github.com or the address of a self
+// hosted GitLab.
+func New(driver scm.Driver, server string) (*Builder, error) {
+	switch driver {
+	case scm.DriverGithub, scm.DriverGitlab, scm.DriverGitea, scm.DriverGogs, scm.DriverBitbucket, scm.DriverStash:
+	default:
+		return nil, fmt.Errorf("links: unsupported driver %s", driver)
+	}
+	u, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("links: invalid server address %q", server)
+	}
+	return &Builder{driver: driver, server: strings.TrimSuffix(u.String(), "/")}, nil
+}
+
+// FromClient returns a Builder for the server the client
+// talks to, deriving the web address from the API address.
+func FromClient(client *scm.Client) (*Builder, error) {
+	if client.BaseURL == nil {
+		return nil, fmt.Errorf("links: client has no base url")
+	}
+	u := *client.BaseURL
+	u.RawQuery, u.Fragment = "", ""
+	switch {
+	case u.Host == "api.github.com":
+		u.Host, u.Path = "github.com", ""
+	case u.Host == "api.bitbucket.org":
+		u.Host, u.Path = "bitbucket.org", ""
+	case client.Driver == scm.DriverGithub:
+		// github enterprise serves the api from /api/v3.
+		u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/api/v3")
+	case client.Driver == scm.DriverGitea || client.Driver == scm.DriverGogs:
+		u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/api/v1")
+	}
+	return New(client.Driver, u.String())
+}
+
+// Repository returns the URL of the repository home page.
+func (b *Builder) Repository(repo string) string {
+	if b.driver == scm.DriverStash {
+		namespace, name := scm.Split(repo)
+		if strings.HasPrefix(namespace, "~") {
+			return fmt.Sprintf("%s/users/%s/repos/%s", b.server, escape(namespace[1:]), escape(name))
+		}
+		return fmt.Sprintf("%s/projects/%s/repos/%s", b.server, escape(namespace), escape(name))
+	}
+	return b.server + "/" + escapePath(repo)
+}
+
+// PullRequest returns the URL of the pull request.
+func (b *Builder) PullRequest(repo string, number int) string {
+	base := b.Repository(repo)
+	switch b.driver {
+	case scm.DriverGitlab:
+		return fmt.Sprintf("%s/-/merge_requests/%d", base, number)
+	case scm.DriverGitea, scm.DriverGogs:
+		return fmt.Sprintf("%s/pulls/%d", base, number)
+	case scm.DriverBitbucket:
+		return fmt.Sprintf("%s/pull-requests/%d", base, number)
+	case scm.DriverStash:
+		return fmt.Sprintf("%s/pull-requests/%d/overview", base, number)
+	}
+	return fmt.Sprintf("%s/pull/%d", base, number)
+}
+
+// Commit returns the URL of the commit.
+func (b *Builder) Commit(repo, sha string) string {
+	base := b.Repository(repo)
+	switch b.driver {
+	case scm.DriverGitlab:
+		return base + "/-/commit/" + escape(sha)
+	case scm.DriverBitbucket, scm.DriverStash:
+		return base + "/commits/" + escape(sha)
+	}
+	return base + "/commit/" + escape(sha)
+}
+
+// File returns the URL of the file at the ref. The line is
+// highlighted unless it is zero.
+func (b *Builder) File(repo, ref, path string, line int) string {
+	base := b.Repository(repo)
+	path = escapePath(strings.TrimPrefix(path, "/"))
+	ref = scm.TrimRef(ref)
+	var out string
+	switch b.driver {
+	case scm.DriverGitlab:
+		out = base + "/-/blob/" + escapePath(ref) + "/" + path
+	case scm.DriverGitea, scm.DriverGogs, scm.DriverBitbucket:
+		out = base + "/src/" + escapePath(ref) + "/" + path
+	case scm.DriverStash:
+		out = base + "/browse/" + path + "?at=" + url.QueryEscape(qualify(ref))
+	default:
+		out = base + "/blob/" + escapePath(ref) + "/" + path
+	}
+	if line == 0 {
+		return out
+	}
+	switch b.driver {
+	case scm.DriverBitbucket:
+		return out + "#lines-" + strconv.Itoa(line)
+	case scm.DriverStash:
+		return out + "#" + strconv.Itoa(line)
+	}
+	return out + "#L" + strconv.Itoa(line)
+}
+
+// Compare returns the URL of the comparison of the head ref
+// with the base ref.
+func (b *Builder) Compare(repo, base, head string) string {
+	home := b.Repository(repo)
+	base, head = scm.TrimRef(base), scm.TrimRef(head)
+	switch b.driver {
+	case scm.DriverGitlab:
+		return home + "/-/compare/" + escapePath(base) + "..." + escapePath(head)
+	case scm.DriverBitbucket:
+		return home + "/branches/compare/" + escapePath(head) + "%0D" + escapePath(base)
+	case scm.DriverStash:
+		params := url.Values{
+			"sourceBranch": {qualify(head)},
+			"targetBranch": {qualify(base)},
+		}
+		return home + "/compare/commits?" + params.Encode()
+	}
+	return home + "/compare/" + escapePath(base) + "..." + escapePath(head)
+}
+
+// NewPullRequest returns the URL of the form opening a pull
+// request from the head branch into the base branch.
+func (b *Builder) NewPullRequest(repo, base, head string) string {
+	home := b.Repository(repo)
+	base, head = scm.TrimRef(base), scm.TrimRef(head)
+	switch b.driver {
+	case scm.DriverGitlab:
+		params := url.Values{
+			"merge_request[source_branch]": {head},
+			"merge_request[target_branch]": {base},
+		}
+		return home + "/-/merge_requests/new?" + params.Encode()
+	case scm.DriverBitbucket:
+		params := url.Values{"source": {head}, "dest": {base}}
+		return home + "/pull-requests/new?" + params.Encode()
+	case scm.DriverStash:
+		params := url.Values{
+			"sourceBranch": {qualify(head)},
+			"targetBranch": {qualify(base)},
+		}
+		return home + "/pull-requests?create&" + params.Encode()
+	case scm.DriverGithub:
+		return home + "/compare/" + escapePath(base) + "..." + escapePath(head) + "?expand=1"
+	}
+	return home + "/compare/" + escapePath(base) + "..." + escapePath(head)
+}
+
+// qualify returns the fully qualified name of a branch,
+// leaving commit shas and qualified refs unchanged.
+func qualify(ref string) string {
+	if strings.HasPrefix(ref, "refs/") || isSha(ref) {
+		return ref
+	}
+	return "refs/heads/" + ref
+}
+
+func isSha(s string) bool {
+	if len(s) != 40 {
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
+	}
+	return true
+}
+
+func escape(s string) string {
+	return url.PathEscape(s)
+}
+
+// escapePath escapes each segment of a slash separated path.
+func escapePath(p string) string {
+	parts := strings.Split(p, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
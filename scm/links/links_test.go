@@ -0,0 +1,136 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package links
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestLinks(t *testing.T) {
+	tests := []struct {
+		driver  scm.Driver
+		server  string
+		repo    string
+		pr      string
+		commit  string
+		file    string
+		compare string
+		create  string
+	}{
+		{
+			driver:  scm.DriverGithub,
+			server:  "https://github.com/",
+			repo:    "octocat/hello-world",
+			pr:      "https://github.com/octocat/hello-world/pull/42",
+			commit:  "https://github.com/octocat/hello-world/commit/7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+			file:    "https://github.com/octocat/hello-world/blob/feature/x/docs/my%20file.md#L10",
+			compare: "https://github.com/octocat/hello-world/compare/master...feature/x",
+			create:  "https://github.com/octocat/hello-world/compare/master...feature/x?expand=1",
+		},
+		{
+			driver:  scm.DriverGitlab,
+			server:  "https://gitlab.com",
+			repo:    "group/subgroup/project",
+			pr:      "https://gitlab.com/group/subgroup/project/-/merge_requests/42",
+			commit:  "https://gitlab.com/group/subgroup/project/-/commit/7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+			file:    "https://gitlab.com/group/subgroup/project/-/blob/feature/x/docs/my%20file.md#L10",
+			compare: "https://gitlab.com/group/subgroup/project/-/compare/master...feature/x",
+			create:  "https://gitlab.com/group/subgroup/project/-/merge_requests/new?merge_request%5Bsource_branch%5D=feature%2Fx&merge_request%5Btarget_branch%5D=master",
+		},
+		{
+			driver:  scm.DriverGitea,
+			server:  "https://try.gitea.io",
+			repo:    "go-gitea/gitea",
+			pr:      "https://try.gitea.io/go-gitea/gitea/pulls/42",
+			commit:  "https://try.gitea.io/go-gitea/gitea/commit/7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+			file:    "https://try.gitea.io/go-gitea/gitea/src/feature/x/docs/my%20file.md#L10",
+			compare: "https://try.gitea.io/go-gitea/gitea/compare/master...feature/x",
+			create:  "https://try.gitea.io/go-gitea/gitea/compare/master...feature/x",
+		},
+		{
+			driver:  scm.DriverBitbucket,
+			server:  "https://bitbucket.org",
+			repo:    "atlassian/stash-example-plugin",
+			pr:      "https://bitbucket.org/atlassian/stash-example-plugin/pull-requests/42",
+			commit:  "https://bitbucket.org/atlassian/stash-example-plugin/commits/7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+			file:    "https://bitbucket.org/atlassian/stash-example-plugin/src/feature/x/docs/my%20file.md#lines-10",
+			compare: "https://bitbucket.org/atlassian/stash-example-plugin/branches/compare/feature/x%0Dmaster",
+			create:  "https://bitbucket.org/atlassian/stash-example-plugin/pull-requests/new?dest=master&source=feature%2Fx",
+		},
+		{
+			driver:  scm.DriverStash,
+			server:  "https://stash.example.com",
+			repo:    "PRJ/my-repo",
+			pr:      "https://stash.example.com/projects/PRJ/repos/my-repo/pull-requests/42/overview",
+			commit:  "https://stash.example.com/projects/PRJ/repos/my-repo/commits/7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+			file:    "https://stash.example.com/projects/PRJ/repos/my-repo/browse/docs/my%20file.md?at=refs%2Fheads%2Ffeature%2Fx#10",
+			compare: "https://stash.example.com/projects/PRJ/repos/my-repo/compare/commits?sourceBranch=refs%2Fheads%2Ffeature%2Fx&targetBranch=refs%2Fheads%2Fmaster",
+			create:  "https://stash.example.com/projects/PRJ/repos/my-repo/pull-requests?create&sourceBranch=refs%2Fheads%2Ffeature%2Fx&targetBranch=refs%2Fheads%2Fmaster",
+		},
+	}
+	sha := "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d"
+	for _, test := range tests {
+		b, err := New(test.driver, test.server)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, c := range []struct{ got, want string }{
+			{b.PullRequest(test.repo, 42), test.pr},
+			{b.Commit(test.repo, sha), test.commit},
+			{b.File(test.repo, "refs/heads/feature/x", "docs/my file.md", 10), test.file},
+			{b.Compare(test.repo, "master", "feature/x"), test.compare},
+			{b.NewPullRequest(test.repo, "refs/heads/master", "feature/x"), test.create},
+		} {
+			if c.got != c.want {
+				t.Errorf("%s: want link %s, got %s", test.driver, c.want, c.got)
+			}
+		}
+	}
+}
+
+func TestStashPersonalRepository(t *testing.T) {
+	b, _ := New(scm.DriverStash, "https://stash.example.com")
+	want := "https://stash.example.com/users/jcitizen/repos/my-repo"
+	if got := b.Repository("~jcitizen/my-repo"); got != want {
+		t.Errorf("Want link %s, got %s", want, got)
+	}
+}
+
+func TestNewUnsupported(t *testing.T) {
+	if _, err := New(scm.DriverCoding, "https://coding.net"); err == nil {
+		t.Errorf("Expect error for unsupported driver")
+	}
+	if _, err := New(scm.DriverGithub, "github.com"); err == nil {
+		t.Errorf("Expect error for server without scheme")
+	}
+}
+
+func TestFromClient(t *testing.T) {
+	tests := []struct {
+		driver scm.Driver
+		api    string
+		want   string
+	}{
+		{scm.DriverGithub, "https://api.github.com/", "https://github.com/octocat/hello-world"},
+		{scm.DriverGithub, "https://github.example.com/api/v3/", "https://github.example.com/octocat/hello-world"},
+		{scm.DriverBitbucket, "https://api.bitbucket.org/", "https://bitbucket.org/octocat/hello-world"},
+		{scm.DriverGitea, "https://try.gitea.io/api/v1", "https://try.gitea.io/octocat/hello-world"},
+		{scm.DriverGitlab, "https://gitlab.example.com/", "https://gitlab.example.com/octocat/hello-world"},
+	}
+	for _, test := range tests {
+		client := &scm.Client{Driver: test.driver}
+		client.BaseURL, _ = url.Parse(test.api)
+		b, err := FromClient(client)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := b.Repository("octocat/hello-world"); got != test.want {
+			t.Errorf("Want link %s, got %s", test.want, got)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package multi fans read requests out to the clients of several
+// providers and merges their results, e.g. to list the pull
+// requests of a user on both GitHub and GitLab.
+package multi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// Client fans requests out to a set of clients, named after
+// their provider. The results carry the name of the provider of
+// the client that returned them.
+type Client struct {
+	clients map[string]*scm.Client
+	names   []string
+}
+
+// New returns a client fanning requests out to the clients, keyed
+// by a name identifying the provider, e.g. "github.com".
+func New(clients map[string]*scm.Client) *Client {
+	c := &Client{clients: clients}
+	for name := range clients {
+		c.names = append(c.names, name)
+	}
+	sort.Strings(c.names)
+	return c
+}
+
+// Names returns the names of the clients, sorted.
+func (c *Client) Names() []string {
+	return append([]string(nil), c.names...)
+}
+
+// Error is the error of one of the clients.
+type Error struct {
+	Provider string
+	Err      error
+}
+
+func (e *Error) Error() string {
+	return e.Provider + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error of the client.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Errors are the errors of the clients that failed. The results
+// of the other clients are returned along with them, and the
+// partial results of a failed client are dropped.
+type Errors []*Error
+
+func (e Errors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Each calls fn concurrently with each client. A failing client
+// does not stop the others, and the errors are returned as Errors,
+// ordered by client name. A panic of fn, e.g. calling a service
+// the driver of the client does not set, is recovered and
+// returned as the error of the client.
+func (c *Client) Each(ctx context.Context, fn func(ctx context.Context, name string, client *scm.Client) error) error {
+	errs := make([]error, len(c.names))
+	var wg sync.WaitGroup
+	for i, name := range c.names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					errs[i] = fmt.Errorf("panic: %v", r)
+				}
+			}()
+			errs[i] = fn(ctx, name, c.clients[name])
+		}(i, name)
+	}
+	wg.Wait()
+	var out Errors
+	for i, err := range errs {
+		if err != nil {
+			out = append(out, &Error{Provider: c.names[i], Err: err})
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// User is the authenticated user of a client.
+type User struct {
+	Provider string
+	*scm.User
+}
+
+// FindUsers returns the authenticated user of every client.
+func (c *Client) FindUsers(ctx context.Context) ([]*User, error) {
+	results := make([][]*User, len(c.names))
+	err := c.each(ctx, func(ctx context.Context, i int, client *scm.Client) error {
+		user, _, err := client.Users.Find(ctx)
+		if err != nil {
+			return err
+		}
+		results[i] = []*User{{Provider: c.names[i], User: user}}
+		return nil
+	})
+	var out []*User
+	for _, r := range results {
+		out = append(out, r...)
+	}
+	return out, err
+}
+
+// Repository is a repository of a client.
+type Repository struct {
+	Provider string
+	*scm.Repository
+}
+
+// ListRepositories lists the repositories of the authenticated
+// user on every client, following the pagination from opts.
+func (c *Client) ListRepositories(ctx context.Context, opts scm.ListOptions) ([]*Repository, error) {
+	results := make([][]*Repository, len(c.names))
+	err := c.each(ctx, func(ctx context.Context, i int, client *scm.Client) error {
+		var list []*Repository
+		opts := opts
+		for {
+			repos, res, err := client.Repositories.List(ctx, opts)
+			if err != nil {
+				return err
+			}
+			for _, repo := range repos {
+				list = append(list, &Repository{Provider: c.names[i], Repository: repo})
+			}
+			if res == nil || res.Page.Next == 0 {
+				results[i] = list
+				return nil
+			}
+			opts.Page = res.Page.Next
+		}
+	})
+	var out []*Repository
+	for _, r := range results {
+		out = append(out, r...)
+	}
+	return out, err
+}
+
+// PullRequest is a pull request of a client.
+type PullRequest struct {
+	Provider string
+	*scm.PullRequest
+}
+
+// ListPullRequests lists the pull requests of the repositories,
+// keyed by client name, following the pagination from opts.
+// Repositories of a name without a client are ignored.
+func (c *Client) ListPullRequests(ctx context.Context, repos map[string][]string, opts scm.PullRequestListOptions) ([]*PullRequest, error) {
+	results := make([][]*PullRequest, len(c.names))
+	err := c.each(ctx, func(ctx context.Context, i int, client *scm.Client) error {
+		var list []*PullRequest
+		for _, repo := range repos[c.names[i]] {
+			opts := opts
+			for {
+				prs, res, err := client.PullRequests.List(ctx, repo, opts)
+				if err != nil {
+					return err
+				}
+				for _, pr := range prs {
+					list = append(list, &PullRequest{Provider: c.names[i], PullRequest: pr})
+				}
+				if res == nil || res.Page.Next == 0 {
+					break
+				}
+				opts.Page = res.Page.Next
+			}
+		}
+		results[i] = list
+		return nil
+	})
+	var out []*PullRequest
+	for _, r := range results {
+		out = append(out, r...)
+	}
+	return out, err
+}
+
+// SearchIssue is an issue or pull request found by a client.
+type SearchIssue struct {
+	Provider string
+	*scm.SearchIssue
+}
+
+// SearchIssues runs the search on every client. The query syntax
+// differs between providers, so use the clients of providers
+// sharing the syntax, or Each with a query per client.
+func (c *Client) SearchIssues(ctx context.Context, opts scm.SearchOptions) ([]*SearchIssue, error) {
+	results := make([][]*SearchIssue, len(c.names))
+	err := c.each(ctx, func(ctx context.Context, i int, client *scm.Client) error {
+		issues, _, err := client.Issues.Search(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			results[i] = append(results[i], &SearchIssue{Provider: c.names[i], SearchIssue: issue})
+		}
+		return nil
+	})
+	var out []*SearchIssue
+	for _, r := range results {
+		out = append(out, r...)
+	}
+	return out, err
+}
+
+// each calls fn with the index of each client in names, so the
+// results can be merged in name order.
+func (c *Client) each(ctx context.Context, fn func(ctx context.Context, i int, client *scm.Client) error) error {
+	index := make(map[string]int, len(c.names))
+	for i, name := range c.names {
+		index[name] = i
+	}
+	return c.Each(ctx, func(ctx context.Context, name string, client *scm.Client) error {
+		return fn(ctx, index[name], client)
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/fake"
+)
+
+func TestListRepositories(t *testing.T) {
+	github, githubData := fake.NewDefault()
+	githubData.Repositories = []*scm.Repository{{FullName: "octocat/hello-world"}}
+	gitlab, gitlabData := fake.NewDefault()
+	gitlabData.Repositories = []*scm.Repository{{FullName: "diaspora/client"}, {FullName: "diaspora/server"}}
+
+	client := New(map[string]*scm.Client{"gitlab.com": gitlab, "github.com": github})
+	repos, err := client.ListRepositories(context.Background(), scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, repo := range repos {
+		got = append(got, repo.Provider+"/"+repo.FullName)
+	}
+	want := []string{
+		"github.com/octocat/hello-world",
+		"gitlab.com/diaspora/client",
+		"gitlab.com/diaspora/server",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Want repositories %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Want repository %s at index %d, got %s", want[i], i, got[i])
+		}
+	}
+}
+
+func TestFindUsers_Error(t *testing.T) {
+	github, githubData := fake.NewDefault()
+	githubData.CurrentUser = scm.User{Login: "octocat"}
+	gitlab, gitlabData := fake.NewDefault()
+	failure := errors.New("connection refused")
+	gitlabData.MethodErrors["Users.Find"] = []error{failure}
+
+	client := New(map[string]*scm.Client{"github.com": github, "gitlab.com": gitlab})
+	users, err := client.FindUsers(context.Background())
+	if len(users) != 1 || users[0].Login != "octocat" || users[0].Provider != "github.com" {
+		t.Errorf("Want the user of github.com only, got %v", users)
+	}
+	errs, ok := err.(Errors)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("Want a single client error, got %v", err)
+	}
+	if errs[0].Provider != "gitlab.com" || !errors.Is(errs[0], failure) {
+		t.Errorf("Want the gitlab.com error, got %v", errs[0])
+	}
+}
+
+func TestFindUsers_Panic(t *testing.T) {
+	github, githubData := fake.NewDefault()
+	githubData.CurrentUser = scm.User{Login: "octocat"}
+	gitlab, _ := fake.NewDefault()
+	gitlab.Users = nil
+
+	client := New(map[string]*scm.Client{"github.com": github, "gitlab.com": gitlab})
+	users, err := client.FindUsers(context.Background())
+	if len(users) != 1 || users[0].Login != "octocat" {
+		t.Errorf("Want the user of github.com only, got %v", users)
+	}
+	errs, ok := err.(Errors)
+	if !ok || len(errs) != 1 || errs[0].Provider != "gitlab.com" {
+		t.Fatalf("Want the panic of gitlab.com returned as its error, got %v", err)
+	}
+}
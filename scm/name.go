@@ -0,0 +1,108 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Repo is the parsed full name of a repository. The
+// namespace is the owner of the repository: a user or an
+// organization on GitHub and Gitea, a group path including
+// the subgroups on GitLab, a workspace on Bitbucket Cloud and
+// a project key, or ~user for a personal repository, on
+// Bitbucket Server.
+type Repo struct {
+	Namespace string
+	Name      string
+}
+
+// segmentRe matches the characters allowed in the namespace
+// and name segments of a repository by every provider.
+var segmentRe = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// ParseRepo parses the full name of a repository, e.g.
+// octocat/hello-world or group/subgroup/project. The name is
+// the last path segment and the namespace everything before
+// it, so GitLab subgroups are kept in the namespace.
+func ParseRepo(fullname string) (Repo, error) {
+	s := strings.Trim(strings.TrimSpace(fullname), "/")
+	i := strings.LastIndex(s, "/")
+	if i == -1 {
+		return Repo{}, fmt.Errorf("invalid repository name %q: want namespace/name", fullname)
+	}
+	r := Repo{Namespace: s[:i], Name: s[i+1:]}
+	for _, segment := range strings.Split(r.Namespace, "/") {
+		if !segmentRe.MatchString(strings.TrimPrefix(segment, "~")) {
+			return Repo{}, fmt.Errorf("invalid repository name %q: invalid namespace %q", fullname, r.Namespace)
+		}
+	}
+	if !segmentRe.MatchString(r.Name) {
+		return Repo{}, fmt.Errorf("invalid repository name %q: invalid name %q", fullname, r.Name)
+	}
+	return r, nil
+}
+
+// ParseRepoFor parses the full name of a repository and
+// validates it for the driver. Only GitLab accepts nested
+// namespaces, and only Bitbucket Server accepts the ~user
+// namespace of a personal repository.
+func ParseRepoFor(driver Driver, fullname string) (Repo, error) {
+	r, err := ParseRepo(fullname)
+	if err != nil {
+		return r, err
+	}
+	if err := r.Validate(driver); err != nil {
+		return Repo{}, err
+	}
+	return r, nil
+}
+
+// Validate returns an error if the repository name is not
+// valid for the driver.
+func (r Repo) Validate(driver Driver) error {
+	if strings.Contains(r.Namespace, "/") && driver != DriverGitlab && driver != DriverFake {
+		return fmt.Errorf("invalid repository name %q: %s does not support nested namespaces", r.String(), driver)
+	}
+	if strings.Contains(r.Namespace, "~") {
+		if driver != DriverStash || !strings.HasPrefix(r.Namespace, "~") || len(r.Namespace) == 1 || strings.Count(r.Namespace, "~") > 1 {
+			return fmt.Errorf("invalid repository name %q: invalid namespace %q for %s", r.String(), r.Namespace, driver)
+		}
+	}
+	return nil
+}
+
+// String returns the full name of the repository.
+func (r Repo) String() string {
+	return Join(r.Namespace, r.Name)
+}
+
+// Owner returns the top level namespace, i.e. the user,
+// organization, workspace or project owning the repository.
+// This is the root group of a GitLab repository nested in
+// subgroups.
+func (r Repo) Owner() string {
+	if i := strings.Index(r.Namespace, "/"); i != -1 {
+		return r.Namespace[:i]
+	}
+	return r.Namespace
+}
+
+// IsPersonal returns true if the repository is the personal
+// repository of a Bitbucket Server user.
+func (r Repo) IsPersonal() bool {
+	return strings.HasPrefix(r.Namespace, "~")
+}
+
+// User returns the owner of a personal Bitbucket Server
+// repository, or an empty string.
+func (r Repo) User() string {
+	if r.IsPersonal() {
+		return r.Namespace[1:]
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import "testing"
+
+func TestParseRepo(t *testing.T) {
+	tests := []struct {
+		value     string
+		namespace string
+		name      string
+		owner     string
+		err       bool
+	}{
+		{value: "octocat/hello-world", namespace: "octocat", name: "hello-world", owner: "octocat"},
+		{value: "/octocat/hello-world/", namespace: "octocat", name: "hello-world", owner: "octocat"},
+		{value: "group/subgroup/project", namespace: "group/subgroup", name: "project", owner: "group"},
+		{value: "~jcitizen/my-repo", namespace: "~jcitizen", name: "my-repo", owner: "~jcitizen"},
+		{value: "PRJ/my_repo.go", namespace: "PRJ", name: "my_repo.go", owner: "PRJ"},
+		{value: "hello-world", err: true},
+		{value: "octocat/", err: true},
+		{value: "octocat//hello-world", err: true},
+		{value: "octocat/hello world", err: true},
+	}
+	for _, test := range tests {
+		r, err := ParseRepo(test.value)
+		if test.err {
+			if err == nil {
+				t.Errorf("Expect error parsing %q", test.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if r.Namespace != test.namespace || r.Name != test.name {
+			t.Errorf("Want %s/%s parsing %q, got %s/%s", test.namespace, test.name, test.value, r.Namespace, r.Name)
+		}
+		if got := r.Owner(); got != test.owner {
+			t.Errorf("Want owner %s, got %s", test.owner, got)
+		}
+	}
+}
+
+func TestParseRepoFor(t *testing.T) {
+	tests := []struct {
+		driver Driver
+		value  string
+		err    bool
+	}{
+		{driver: DriverGitlab, value: "group/subgroup/project"},
+		{driver: DriverGithub, value: "group/subgroup/project", err: true},
+		{driver: DriverBitbucket, value: "workspace/repo"},
+		{driver: DriverStash, value: "~jcitizen/my-repo"},
+		{driver: DriverStash, value: "~/my-repo", err: true},
+		{driver: DriverGithub, value: "~jcitizen/my-repo", err: true},
+	}
+	for _, test := range tests {
+		_, err := ParseRepoFor(test.driver, test.value)
+		if test.err && err == nil {
+			t.Errorf("Expect error parsing %q for %s", test.value, test.driver)
+		}
+		if !test.err && err != nil {
+			t.Errorf("Unexpected error parsing %q for %s: %s", test.value, test.driver, err)
+		}
+	}
+}
+
+func TestRepoUser(t *testing.T) {
+	r, _ := ParseRepo("~jcitizen/my-repo")
+	if !r.IsPersonal() || r.User() != "jcitizen" {
+		t.Errorf("Want personal repository of jcitizen, got %q", r.User())
+	}
+	if got, want := r.String(), "~jcitizen/my-repo"; got != want {
+		t.Errorf("Want full name %s, got %s", want, got)
+	}
+}
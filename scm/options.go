@@ -0,0 +1,101 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Search sort fields accepted by SearchOptions, translated by the
+// drivers to the fields of the provider.
+const (
+	SearchSortCreated  = "created"
+	SearchSortUpdated  = "updated"
+	SearchSortComments = "comments"
+)
+
+// OptionError is returned, before the request is sent, for a list
+// or search option the provider does not accept, instead of the
+// provider silently clamping the option or rejecting the request.
+type OptionError struct {
+	Option string
+	Value  string
+	Reason string
+}
+
+func (e *OptionError) Error() string {
+	return fmt.Sprintf("invalid %s %q: %s", e.Option, e.Value, e.Reason)
+}
+
+// QueryLimits describes the list and search options accepted by a
+// provider. The drivers set the limits of the client, and the
+// client checks the page size of the requests against them.
+type QueryLimits struct {
+	// PageSizeParam is the query parameter of the page size,
+	// e.g. "per_page".
+	PageSizeParam string
+
+	// MaxPageSize is the largest page size the provider
+	// returns.
+	MaxPageSize int
+
+	// SortFields maps the search sort fields to the fields
+	// of the provider.
+	SortFields map[string]string
+}
+
+// ValidateList returns an OptionError if the provider does not
+// accept the list options. Nil limits accept any options.
+func (l *QueryLimits) ValidateList(opts ListOptions) error {
+	if l == nil {
+		return nil
+	}
+	if opts.Page < 0 {
+		return &OptionError{Option: "page", Value: strconv.Itoa(opts.Page), Reason: "must not be negative"}
+	}
+	return l.validateSize(opts.Size)
+}
+
+// SearchSort returns the field of the provider to sort the search
+// results by, or an OptionError if the provider cannot sort by the
+// field. An empty field, or any field if the limits are nil, is
+// returned unchanged.
+func (l *QueryLimits) SearchSort(opts SearchOptions) (string, error) {
+	if l == nil || opts.Sort == "" {
+		return opts.Sort, nil
+	}
+	if field, ok := l.SortFields[opts.Sort]; ok {
+		return field, nil
+	}
+	return "", &OptionError{Option: "sort", Value: opts.Sort, Reason: "unsupported sort field"}
+}
+
+// validateQuery checks the page size of the request query.
+func (l *QueryLimits) validateQuery(query url.Values) error {
+	if l.PageSizeParam == "" {
+		return nil
+	}
+	s := query.Get(l.PageSizeParam)
+	if s == "" {
+		return nil
+	}
+	size, err := strconv.Atoi(s)
+	if err != nil {
+		return &OptionError{Option: "page size", Value: s, Reason: "not a number"}
+	}
+	return l.validateSize(size)
+}
+
+func (l *QueryLimits) validateSize(size int) error {
+	if size < 0 {
+		return &OptionError{Option: "page size", Value: strconv.Itoa(size), Reason: "must not be negative"}
+	}
+	if l.MaxPageSize > 0 && size > l.MaxPageSize {
+		return &OptionError{Option: "page size", Value: strconv.Itoa(size), Reason: fmt.Sprintf("exceeds the maximum of %d", l.MaxPageSize)}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import "testing"
+
+func TestQueryLimits(t *testing.T) {
+	limits := &QueryLimits{
+		PageSizeParam: "per_page",
+		MaxPageSize:   100,
+		SortFields:    map[string]string{SearchSortUpdated: "updated_at"},
+	}
+	for _, opts := range []ListOptions{{Page: -1}, {Size: -1}, {Size: 101}} {
+		if _, ok := limits.ValidateList(opts).(*OptionError); !ok {
+			t.Errorf("Want an OptionError for %+v", opts)
+		}
+	}
+	if err := limits.ValidateList(ListOptions{Page: 2, Size: 100}); err != nil {
+		t.Error(err)
+	}
+
+	sort, err := limits.SearchSort(SearchOptions{Sort: SearchSortUpdated})
+	if err != nil || sort != "updated_at" {
+		t.Errorf("Want the sort field translated, got %q, %v", sort, err)
+	}
+	if _, err := limits.SearchSort(SearchOptions{Sort: SearchSortComments}); err == nil {
+		t.Errorf("Want an error for an unsupported sort field")
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrStopPaging is returned by the callback of the List*Func
+// functions to stop listing without error.
+var ErrStopPaging = errors.New("Stop Paging")
+
+type (
+	// RepositoryLister lists the repositories of the user,
+	// and is implemented by RepositoryService.
+	RepositoryLister interface {
+		List(context.Context, ListOptions) ([]*Repository, *Response, error)
+	}
+
+	// IssueLister lists the issues of a repository, and is
+	// implemented by IssueService.
+	IssueLister interface {
+		List(context.Context, string, IssueListOptions) ([]*Issue, *Response, error)
+	}
+
+	// PullRequestLister lists the pull requests of a
+	// repository, and is implemented by PullRequestService.
+	PullRequestLister interface {
+		List(context.Context, string, PullRequestListOptions) ([]*PullRequest, *Response, error)
+	}
+
+	// CommitLister lists the commits of a repository, and is
+	// implemented by GitService.
+	CommitLister interface {
+		ListCommits(context.Context, string, CommitListOptions) ([]*Commit, *Response, error)
+	}
+)
+
+// ListRepositoriesFunc calls fn with each repository of the
+// user, from the page of the options, fetching one page at a
+// time. It stops at the first error of fn, which is returned
+// unless it is ErrStopPaging.
+func ListRepositoriesFunc(ctx context.Context, lister RepositoryLister, opts ListOptions, fn func(*Repository) error) error {
+	return eachPage(func() (*Response, error) {
+		repos, res, err := lister.List(ctx, opts)
+		if err != nil {
+			return res, err
+		}
+		for _, repo := range repos {
+			if err := fn(repo); err != nil {
+				return res, err
+			}
+		}
+		return res, nil
+	}, func(res *Response) bool {
+		return nextList(&opts, res.Page)
+	})
+}
+
+// ListIssuesFunc calls fn with each issue of the repository,
+// like ListRepositoriesFunc.
+func ListIssuesFunc(ctx context.Context, lister IssueLister, repo string, opts IssueListOptions, fn func(*Issue) error) error {
+	return eachPage(func() (*Response, error) {
+		issues, res, err := lister.List(ctx, repo, opts)
+		if err != nil {
+			return res, err
+		}
+		for _, issue := range issues {
+			if err := fn(issue); err != nil {
+				return res, err
+			}
+		}
+		return res, nil
+	}, func(res *Response) bool {
+		return nextPage(&opts.Page, res.Page)
+	})
+}
+
+// ListPullRequestsFunc calls fn with each pull request of the
+// repository, like ListRepositoriesFunc.
+func ListPullRequestsFunc(ctx context.Context, lister PullRequestLister, repo string, opts PullRequestListOptions, fn func(*PullRequest) error) error {
+	return eachPage(func() (*Response, error) {
+		prs, res, err := lister.List(ctx, repo, opts)
+		if err != nil {
+			return res, err
+		}
+		for _, pr := range prs {
+			if err := fn(pr); err != nil {
+				return res, err
+			}
+		}
+		return res, nil
+	}, func(res *Response) bool {
+		return nextPage(&opts.Page, res.Page)
+	})
+}
+
+// ListChangesFunc calls fn with each change of the pull
+// request, like ListRepositoriesFunc.
+func ListChangesFunc(ctx context.Context, lister ChangeLister, repo string, number int, opts ListOptions, fn func(*Change) error) error {
+	return eachPage(func() (*Response, error) {
+		changes, res, err := lister.ListChanges(ctx, repo, number, opts)
+		if err != nil {
+			return res, err
+		}
+		for _, change := range changes {
+			if err := fn(change); err != nil {
+				return res, err
+			}
+		}
+		return res, nil
+	}, func(res *Response) bool {
+		return nextList(&opts, res.Page)
+	})
+}
+
+// ListCommentsFunc calls fn with each comment of the issue or
+// pull request, like ListRepositoriesFunc.
+func ListCommentsFunc(ctx context.Context, lister CommentLister, repo string, number int, opts CommentListOptions, fn func(*Comment) error) error {
+	return eachPage(func() (*Response, error) {
+		comments, res, err := lister.ListComments(ctx, repo, number, opts)
+		if err != nil {
+			return res, err
+		}
+		for _, comment := range comments {
+			if err := fn(comment); err != nil {
+				return res, err
+			}
+		}
+		return res, nil
+	}, func(res *Response) bool {
+		return nextPage(&opts.Page, res.Page)
+	})
+}
+
+// ListCommitsFunc calls fn with each commit of the repository,
+// like ListRepositoriesFunc.
+func ListCommitsFunc(ctx context.Context, lister CommitLister, repo string, opts CommitListOptions, fn func(*Commit) error) error {
+	return eachPage(func() (*Response, error) {
+		commits, res, err := lister.ListCommits(ctx, repo, opts)
+		if err != nil {
+			return res, err
+		}
+		for _, commit := range commits {
+			if err := fn(commit); err != nil {
+				return res, err
+			}
+		}
+		return res, nil
+	}, func(res *Response) bool {
+		return nextPage(&opts.Page, res.Page)
+	})
+}
+
+// eachPage fetches the pages until next reports no next page
+// of the response, or fetch fails.
+func eachPage(fetch func() (*Response, error), next func(*Response) bool) error {
+	for {
+		res, err := fetch()
+		if errors.Is(err, ErrStopPaging) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if res == nil || !next(res) {
+			return nil
+		}
+	}
+}
+
+// nextPage sets the page to the next page, and reports
+// whether there is one.
+func nextPage(page *int, from Page) bool {
+	if from.Next == 0 {
+		return false
+	}
+	*page = from.Next
+	return true
+}
+
+// nextList sets the options to the next page, which is the
+// cursor of the next page for the drivers paging by URL, and
+// reports whether there is one.
+func nextList(opts *ListOptions, from Page) bool {
+	if from.NextURL == "" {
+		return nextPage(&opts.Page, from)
+	}
+	opts.URL = from.NextURL
+	opts.Page = from.Next
+	return true
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// cursorRepositories serves the repository pages of a user,
+// which are paged by URL.
+type cursorRepositories struct {
+	pages map[string][]*Repository
+	next  map[string]string
+}
+
+func (s *cursorRepositories) List(ctx context.Context, opts ListOptions) ([]*Repository, *Response, error) {
+	res := &Response{}
+	res.Page.NextURL = s.next[opts.URL]
+	return s.pages[opts.URL], res, nil
+}
+
+func TestListChangesFunc(t *testing.T) {
+	lister := newPagedChanges(3)
+	var got []*Change
+	err := ListChangesFunc(context.Background(), lister, "octocat/hello-world", 1, ListOptions{Page: 1}, func(change *Change) error {
+		got = append(got, change)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want []*Change
+	for _, page := range lister.pages {
+		want = append(want, page...)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected changes\n%s", diff)
+	}
+}
+
+func TestListChangesFunc_Stop(t *testing.T) {
+	lister := newPagedChanges(3)
+	var got []string
+	err := ListChangesFunc(context.Background(), lister, "octocat/hello-world", 1, ListOptions{Page: 1}, func(change *Change) error {
+		got = append(got, change.Path)
+		if change.Path == "1/a" {
+			return ErrStopPaging
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"0/a", "0/b", "1/a"}; !cmp.Equal(want, got) {
+		t.Errorf("Want changes %v, got %v", want, got)
+	}
+}
+
+func TestListChangesFunc_Error(t *testing.T) {
+	lister := newPagedChanges(3)
+	lister.fail = 2
+	n := 0
+	err := ListChangesFunc(context.Background(), lister, "octocat/hello-world", 1, ListOptions{Page: 1}, func(*Change) error {
+		n++
+		return nil
+	})
+	if err == nil || err.Error() != "page failed" {
+		t.Errorf("Want the error of the failed page, got %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Want the changes of the first page, got %d", n)
+	}
+
+	want := errors.New("callback failed")
+	err = ListChangesFunc(context.Background(), newPagedChanges(3), "octocat/hello-world", 1, ListOptions{Page: 1}, func(*Change) error {
+		return want
+	})
+	if err != want {
+		t.Errorf("Want the error of the callback, got %v", err)
+	}
+}
+
+func TestListRepositoriesFunc_URL(t *testing.T) {
+	lister := &cursorRepositories{
+		pages: map[string][]*Repository{
+			"":        {{Name: "a"}},
+			"/page/2": {{Name: "b"}},
+		},
+		next: map[string]string{"": "/page/2"},
+	}
+	var got []string
+	err := ListRepositoriesFunc(context.Background(), lister, ListOptions{}, func(repo *Repository) error {
+		got = append(got, repo.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b"}; !cmp.Equal(want, got) {
+		t.Errorf("Want repositories %v, got %v", want, got)
+	}
+}
+
+func TestListCommentsFunc(t *testing.T) {
+	lister := &pagedComments{
+		pages: [][]*Comment{{{ID: 1}, {ID: 2}}, {{ID: 3}}},
+	}
+	var got []int
+	err := ListCommentsFunc(context.Background(), lister, "octocat/hello-world", 1, CommentListOptions{Page: 1}, func(comment *Comment) error {
+		got = append(got, comment.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 2, 3}; !cmp.Equal(want, got) {
+		t.Errorf("Want comments %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parse
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// Conventional is the metadata of a conventional commit
+// message, see https://www.conventionalcommits.org.
+type Conventional struct {
+	Type        string
+	Scope       string
+	Description string
+	Body        string
+
+	// Breaking is true if the header is marked with an
+	// exclamation mark or the message has a BREAKING CHANGE
+	// footer, whose text is stored in BreakingNote.
+	Breaking     bool
+	BreakingNote string
+}
+
+// Entities are the entities extracted from a pull request
+// description or commit message.
+type Entities struct {
+	Issues    []*IssueRef
+	CoAuthors []scm.Signature
+
+	// Conventional is nil unless the text is a conventional
+	// commit message.
+	Conventional *Conventional
+
+	// Breaking is true if the text marks a breaking change.
+	Breaking bool
+}
+
+var (
+	headerRe   = regexp.MustCompile(`^(\w+)(?:\(([^()\r\n]+)\))?(!)?: +(\S.*)$`)
+	breakingRe = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: *(.*(?:\n[ \t]+.*)*)`)
+	coAuthorRe = regexp.MustCompile(`(?im)^co-authored-by: *([^<\r\n]*?) *<([^>\r\n]+)>[ \t]*$`)
+)
+
+// Parse extracts the entities from the pull request
+// description or commit message, following the issue
+// reference syntax of the driver.
+func Parse(driver scm.Driver, text string) *Entities {
+	out := &Entities{
+		Issues:    Issues(driver, text),
+		CoAuthors: CoAuthors(text),
+	}
+	if c, ok := ParseConventional(text); ok {
+		out.Conventional = c
+		out.Breaking = c.Breaking
+	} else {
+		out.Breaking = breakingRe.MatchString(normalize(text))
+	}
+	return out
+}
+
+// CoAuthors returns the co-authors listed in the
+// Co-authored-by trailers of the text.
+func CoAuthors(text string) []scm.Signature {
+	var out []scm.Signature
+	seen := map[string]bool{}
+	for _, m := range coAuthorRe.FindAllStringSubmatch(normalize(text), -1) {
+		email := strings.ToLower(m[2])
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+		out = append(out, scm.Signature{Name: m[1], Email: m[2]})
+	}
+	return out
+}
+
+// ParseConventional parses the conventional commit message. It
+// returns false if the message header does not follow the
+// type(scope): description form.
+func ParseConventional(message string) (*Conventional, bool) {
+	message = normalize(message)
+	parts := strings.SplitN(message, "\n", 2)
+	m := headerRe.FindStringSubmatch(strings.TrimSpace(parts[0]))
+	if m == nil {
+		return nil, false
+	}
+	out := &Conventional{
+		Type:        strings.ToLower(m[1]),
+		Scope:       m[2],
+		Description: strings.TrimSpace(m[4]),
+		Breaking:    m[3] == "!",
+	}
+	if len(parts) == 2 {
+		out.Body = strings.TrimSpace(parts[1])
+	}
+	if b := breakingRe.FindStringSubmatch(out.Body); b != nil {
+		out.Breaking = true
+		out.BreakingNote = strings.TrimSpace(b[1])
+	}
+	return out, true
+}
+
+// normalize converts the line endings to \n.
+func normalize(text string) string {
+	return strings.Replace(text, "\r\n", "\n", -1)
+}
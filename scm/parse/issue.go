@@ -0,0 +1,156 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package parse extracts entities from pull request
+// descriptions and commit messages: linked issues, co-author
+// trailers and conventional commit metadata. Issue references
+// follow the syntax of the provider, e.g. GitLab accepts a
+// list of issues after a closing keyword.
+package parse
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// IssueRef is a reference to an issue.
+type IssueRef struct {
+	// Repo is the full name of the repository of the issue,
+	// or empty for an issue of the same repository.
+	Repo string
+
+	// Number is the issue number.
+	Number int
+
+	// Key is the issue key of references to an external
+	// issue tracker, e.g. JIRA-123.
+	Key string
+
+	// Keyword is the lower-cased closing keyword preceding
+	// the reference, e.g. fixes.
+	Keyword string
+
+	// Closing is true if merging the pull request or commit
+	// closes the issue.
+	Closing bool
+}
+
+var (
+	// issueRe matches issue references by number, by
+	// repository and number, or by issue URL.
+	issueRe = regexp.MustCompile(`(?:^|[\s(\[,:;])(?:([\w.-]+/[\w./-]*[\w-])?#(\d+)|https?://[^\s/]+/([\w.-]+/[\w./-]*?)(?:/-)?/issues/(\d+))\b`)
+
+	// keyRe matches external issue tracker keys.
+	keyRe = regexp.MustCompile(`\b([A-Z][A-Z0-9_]+)-(\d+)\b`)
+
+	// chainRe matches the separator between the issues of a
+	// list following a single keyword.
+	chainRe = regexp.MustCompile(`(?i)^(?:\s*,\s*|\s+and\s+|\s*,\s*and\s+)$`)
+
+	keywordsDefault   = `close|closes|closed|fix|fixes|fixed|resolve|resolves|resolved`
+	keywordsGitlab    = `close|closes|closed|closing|fix|fixes|fixed|fixing|resolve|resolves|resolved|resolving|implement|implements|implemented|implementing`
+	keywordsBitbucket = `close|closes|closed|closing|fix|fixes|fixed|fixing|resolve|resolves|resolved|resolving`
+
+	keywordDefaultRe   = keywordRe(keywordsDefault)
+	keywordGitlabRe    = keywordRe(keywordsGitlab)
+	keywordBitbucketRe = keywordRe(keywordsBitbucket)
+)
+
+// keywordRe returns the regular expression matching a closing
+// keyword at the end of the text preceding a reference.
+func keywordRe(keywords string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)\b(` + keywords + `)\b:?\s*$`)
+}
+
+// Issues returns the issues referenced in the text, following
+// the reference syntax of the driver. An issue referenced more
+// than once is returned once, as closing if any reference is.
+func Issues(driver scm.Driver, text string) []*IssueRef {
+	keyword := keywordDefaultRe
+	switch driver {
+	case scm.DriverGitlab:
+		keyword = keywordGitlabRe
+	case scm.DriverBitbucket:
+		keyword = keywordBitbucketRe
+	}
+
+	var refs []*IssueRef
+	var last *IssueRef
+	end := 0
+	for _, m := range issueRe.FindAllStringSubmatchIndex(text, -1) {
+		ref := &IssueRef{}
+		var start int
+		if m[4] != -1 {
+			start = m[4] - len("#")
+			if m[2] != -1 {
+				start = m[2]
+				ref.Repo = text[m[2]:m[3]]
+			}
+			ref.Number, _ = strconv.Atoi(text[m[4]:m[5]])
+		} else {
+			start = strings.Index(text[m[0]:m[1]], "http") + m[0]
+			ref.Repo = text[m[6]:m[7]]
+			ref.Number, _ = strconv.Atoi(text[m[8]:m[9]])
+		}
+
+		between := text[end:start]
+		switch {
+		case driver == scm.DriverGitlab && last != nil && last.Closing && chainRe.MatchString(between):
+			// gitlab closes every issue of a list following
+			// a keyword, e.g. Closes #1, #2 and #3.
+			ref.Keyword, ref.Closing = last.Keyword, true
+		default:
+			if k := keyword.FindStringSubmatch(between); k != nil {
+				ref.Keyword, ref.Closing = strings.ToLower(k[1]), true
+			}
+		}
+		refs = append(refs, ref)
+		last, end = ref, m[1]
+	}
+
+	if driver == scm.DriverStash {
+		for _, m := range keyRe.FindAllStringSubmatch(text, -1) {
+			number, _ := strconv.Atoi(m[2])
+			refs = append(refs, &IssueRef{Key: m[0], Number: number})
+		}
+	}
+	return dedupe(refs)
+}
+
+// ClosingIssues returns the issues the text closes, following
+// the reference syntax of the driver.
+func ClosingIssues(driver scm.Driver, text string) []*IssueRef {
+	var out []*IssueRef
+	for _, ref := range Issues(driver, text) {
+		if ref.Closing {
+			out = append(out, ref)
+		}
+	}
+	return out
+}
+
+// dedupe removes the duplicate references, preserving the
+// order of the first reference.
+func dedupe(refs []*IssueRef) []*IssueRef {
+	var out []*IssueRef
+	index := map[string]int{}
+	for _, ref := range refs {
+		id := ref.Key
+		if id == "" {
+			id = ref.Repo + "#" + strconv.Itoa(ref.Number)
+		}
+		if i, ok := index[id]; ok {
+			if ref.Closing && !out[i].Closing {
+				out[i] = ref
+			}
+			continue
+		}
+		index[id] = len(out)
+		out = append(out, ref)
+	}
+	return out
+}
@@ -0,0 +1,154 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/slimm609/go-scm/scm"
+)
+
+func TestIssues(t *testing.T) {
+	tests := []struct {
+		driver scm.Driver
+		text   string
+		want   []*IssueRef
+	}{
+		{
+			driver: scm.DriverGithub,
+			text:   "Fixes #123 and relates to octocat/hello-world#4",
+			want: []*IssueRef{
+				{Number: 123, Keyword: "fixes", Closing: true},
+				{Repo: "octocat/hello-world", Number: 4},
+			},
+		},
+		{
+			driver: scm.DriverGithub,
+			text:   "resolved: https://github.com/octocat/hello-world/issues/7\nsee #7",
+			want: []*IssueRef{
+				{Repo: "octocat/hello-world", Number: 7, Keyword: "resolved", Closing: true},
+				{Number: 7},
+			},
+		},
+		{
+			// github only closes the issue directly following
+			// the keyword.
+			driver: scm.DriverGithub,
+			text:   "Closes #1, #2",
+			want: []*IssueRef{
+				{Number: 1, Keyword: "closes", Closing: true},
+				{Number: 2},
+			},
+		},
+		{
+			driver: scm.DriverGitlab,
+			text:   "Closes #1, #2 and gitlab-org/gitlab#3",
+			want: []*IssueRef{
+				{Number: 1, Keyword: "closes", Closing: true},
+				{Number: 2, Keyword: "closes", Closing: true},
+				{Repo: "gitlab-org/gitlab", Number: 3, Keyword: "closes", Closing: true},
+			},
+		},
+		{
+			driver: scm.DriverGitlab,
+			text:   "Implements https://gitlab.com/gitlab-org/gitlab/-/issues/42",
+			want: []*IssueRef{
+				{Repo: "gitlab-org/gitlab", Number: 42, Keyword: "implements", Closing: true},
+			},
+		},
+		{
+			// implement is not a closing keyword on github.
+			driver: scm.DriverGithub,
+			text:   "Implements #42",
+			want:   []*IssueRef{{Number: 42}},
+		},
+		{
+			driver: scm.DriverStash,
+			text:   "PROJ-12: fix the build, see #3",
+			want: []*IssueRef{
+				{Number: 3},
+				{Key: "PROJ-12", Number: 12},
+			},
+		},
+		{
+			// anchors and ids inside words are not references.
+			driver: scm.DriverGithub,
+			text:   "see main.go#L12 and color:#fff",
+			want:   nil,
+		},
+	}
+	for _, test := range tests {
+		got := Issues(test.driver, test.text)
+		if diff := cmp.Diff(test.want, got); diff != "" {
+			t.Errorf("Unexpected issues for %q\n%s", test.text, diff)
+		}
+	}
+}
+
+func TestClosingIssues(t *testing.T) {
+	got := ClosingIssues(scm.DriverBitbucket, "Fixing #3, see #4")
+	want := []*IssueRef{{Number: 3, Keyword: "fixing", Closing: true}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected issues\n%s", diff)
+	}
+}
+
+func TestParseConventional(t *testing.T) {
+	tests := []struct {
+		message string
+		want    *Conventional
+	}{
+		{
+			message: "feat(api): add the releases service",
+			want:    &Conventional{Type: "feat", Scope: "api", Description: "add the releases service"},
+		},
+		{
+			message: "fix!: drop the legacy token header",
+			want:    &Conventional{Type: "fix", Description: "drop the legacy token header", Breaking: true},
+		},
+		{
+			message: "refactor: split the client\r\n\r\nMoves the transport.\r\n\r\nBREAKING CHANGE: the Client\r\n  field is removed",
+			want: &Conventional{
+				Type:         "refactor",
+				Description:  "split the client",
+				Body:         "Moves the transport.\n\nBREAKING CHANGE: the Client\n  field is removed",
+				Breaking:     true,
+				BreakingNote: "the Client\n  field is removed",
+			},
+		},
+		{message: "Update README.md"},
+		{message: "feat:missing space"},
+	}
+	for _, test := range tests {
+		got, ok := ParseConventional(test.message)
+		if ok != (test.want != nil) {
+			t.Errorf("Want conventional %v for %q", test.want != nil, test.message)
+			continue
+		}
+		if diff := cmp.Diff(test.want, got); diff != "" {
+			t.Errorf("Unexpected metadata for %q\n%s", test.message, diff)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	text := "Update the webhook parser\n\nFixes #12\n\nBREAKING CHANGE: the Action field is renamed\n\n" +
+		"Co-authored-by: Octo Cat <octocat@github.com>\n" +
+		"co-authored-by: Mona <MONA@github.com>\n" +
+		"Co-Authored-By: Mona Lisa <mona@github.com>\n"
+	got := Parse(scm.DriverGithub, text)
+	want := &Entities{
+		Issues: []*IssueRef{{Number: 12, Keyword: "fixes", Closing: true}},
+		CoAuthors: []scm.Signature{
+			{Name: "Octo Cat", Email: "octocat@github.com"},
+			{Name: "Mona", Email: "MONA@github.com"},
+		},
+		Breaking: true,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected entities\n%s", diff)
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package poll emulates webhooks by polling the branches and pull
+// requests of repositories, for environments where the provider
+// cannot deliver webhooks, e.g. because inbound connections are
+// not allowed.
+package poll
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// DefaultInterval is the time between two polls, unless
+// Poller.Interval is set.
+const DefaultInterval = time.Minute
+
+// Poller polls the repositories and emits the hooks the provider
+// would have delivered: a PushHook or a BranchHook for every
+// branch created, moved or deleted, and a PullRequestHook for
+// every pull request opened, synchronized, closed, merged or
+// reopened. Changes made and reverted between two polls are not
+// seen, and the hooks have no sender.
+//
+// The first poll of a repository takes a snapshot and emits no
+// hook. The GUID of the hooks identifies the change, so a change
+// seen by two pollers has the same GUID.
+type Poller struct {
+	Client *scm.Client
+
+	// Repos are the repositories polled.
+	Repos []string
+
+	// Interval is the time between two polls. It defaults to
+	// DefaultInterval.
+	Interval time.Duration
+
+	// OnError optionally receives the errors of the polls of
+	// a repository. The poller keeps polling the repository.
+	OnError func(repo string, err error)
+
+	state map[string]*snapshot
+}
+
+var _ scm.EventSource = (*Poller)(nil)
+
+// snapshot is the state of a repository at the previous poll.
+type snapshot struct {
+	repo     *scm.Repository
+	branches map[string]string
+	pulls    map[int]*scm.PullRequest
+	polled   time.Time
+}
+
+// Run polls the repositories every Interval and sends the hooks
+// to the channel, until the context is done.
+func (p *Poller) Run(ctx context.Context, hooks chan<- scm.Webhook) error {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, repo := range p.Repos {
+			found, err := p.PollRepo(ctx, repo)
+			if err != nil && p.OnError != nil {
+				p.OnError(repo, err)
+			}
+			for _, hook := range found {
+				select {
+				case hooks <- hook:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// PollRepo polls the repository once and returns the hooks of the
+// changes since the previous poll. The snapshot is only updated if
+// the poll succeeds, so the changes are returned by the next poll
+// after an error.
+func (p *Poller) PollRepo(ctx context.Context, repo string) ([]scm.Webhook, error) {
+	prev := p.state[repo]
+	next := &snapshot{polled: time.Now()}
+	if prev != nil {
+		next.repo = prev.repo
+	} else {
+		r, _, err := p.Client.Repositories.Find(ctx, repo)
+		if err != nil {
+			return nil, err
+		}
+		next.repo = r
+	}
+
+	branches, err := p.listBranches(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	next.branches = branches
+
+	var since *time.Time
+	if prev != nil {
+		// the provider and local clocks may differ, the
+		// pull requests seen twice are compared anyway
+		t := prev.polled.Add(-time.Minute)
+		since = &t
+	}
+	pulls, err := p.listPullRequests(ctx, repo, since)
+	if err != nil {
+		return nil, err
+	}
+	next.pulls = pulls
+
+	var hooks []scm.Webhook
+	if prev != nil {
+		hooks = append(hooks, branchHooks(next.repo, prev.branches, next.branches)...)
+		hooks = append(hooks, pullRequestHooks(next.repo, prev.pulls, next.pulls)...)
+		// pull requests not updated since the previous poll
+		// are not listed again
+		for number, pr := range prev.pulls {
+			if _, ok := next.pulls[number]; !ok {
+				next.pulls[number] = pr
+			}
+		}
+	}
+	if p.state == nil {
+		p.state = map[string]*snapshot{}
+	}
+	p.state[repo] = next
+	return hooks, nil
+}
+
+func (p *Poller) listBranches(ctx context.Context, repo string) (map[string]string, error) {
+	branches := map[string]string{}
+	opts := scm.ListOptions{Page: 1, Size: 100}
+	for {
+		refs, res, err := p.Client.Git.ListBranches(ctx, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range refs {
+			branches[ref.Name] = ref.Sha
+		}
+		if res == nil || res.Page.Next == 0 {
+			return branches, nil
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
+func (p *Poller) listPullRequests(ctx context.Context, repo string, since *time.Time) (map[int]*scm.PullRequest, error) {
+	pulls := map[int]*scm.PullRequest{}
+	opts := scm.PullRequestListOptions{Page: 1, Size: 100, Open: true, Closed: since != nil, UpdatedAfter: since}
+	for {
+		prs, res, err := p.Client.PullRequests.List(ctx, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, pr := range prs {
+			pulls[pr.Number] = pr
+		}
+		if res == nil || res.Page.Next == 0 {
+			return pulls, nil
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
+// branchHooks returns the hooks of the branches created, moved
+// and deleted.
+func branchHooks(repo *scm.Repository, prev, next map[string]string) []scm.Webhook {
+	var hooks []scm.Webhook
+	for _, name := range sortedNames(next) {
+		sha := next[name]
+		before, ok := prev[name]
+		switch {
+		case !ok:
+			hooks = append(hooks,
+				&scm.BranchHook{
+					Ref:    scm.Reference{Name: name, Path: scm.ExpandRef(name, "refs/heads"), Sha: sha},
+					Repo:   *repo,
+					Action: scm.ActionCreate,
+					GUID:   guid(repo, "branch", name, "created", sha),
+				},
+				pushHook(repo, name, scm.EmptyCommit, sha),
+			)
+		case before != sha:
+			hooks = append(hooks, pushHook(repo, name, before, sha))
+		}
+	}
+	for _, name := range sortedNames(prev) {
+		sha := prev[name]
+		if _, ok := next[name]; !ok {
+			hooks = append(hooks,
+				&scm.BranchHook{
+					Ref:    scm.Reference{Name: name, Path: scm.ExpandRef(name, "refs/heads"), Sha: sha},
+					Repo:   *repo,
+					Action: scm.ActionDelete,
+					GUID:   guid(repo, "branch", name, "deleted", sha),
+				},
+				pushHook(repo, name, sha, scm.EmptyCommit),
+			)
+		}
+	}
+	return hooks
+}
+
+func pushHook(repo *scm.Repository, branch, before, after string) *scm.PushHook {
+	return &scm.PushHook{
+		Ref:     scm.ExpandRef(branch, "refs/heads"),
+		Repo:    *repo,
+		Before:  before,
+		After:   after,
+		Created: before == scm.EmptyCommit,
+		Deleted: after == scm.EmptyCommit,
+		Commit:  scm.Commit{Sha: after},
+		GUID:    guid(repo, "push", branch, before, after),
+	}
+}
+
+// pullRequestHooks returns the hooks of the pull requests opened,
+// synchronized, closed, merged and reopened.
+func pullRequestHooks(repo *scm.Repository, prev, next map[int]*scm.PullRequest) []scm.Webhook {
+	var numbers []int
+	for number := range next {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+	var hooks []scm.Webhook
+	for _, number := range numbers {
+		pr := next[number]
+		var actions []scm.Action
+		old, ok := prev[number]
+		switch {
+		case !ok:
+			actions = append(actions, scm.ActionOpen)
+			if pr.Merged {
+				actions = append(actions, scm.ActionMerge)
+			} else if pr.Closed {
+				actions = append(actions, scm.ActionClose)
+			}
+		case !old.Closed && pr.Closed && pr.Merged:
+			actions = append(actions, scm.ActionMerge)
+		case !old.Closed && pr.Closed:
+			actions = append(actions, scm.ActionClose)
+		case old.Closed && !pr.Closed:
+			actions = append(actions, scm.ActionReopen)
+		}
+		if ok && !pr.Closed && old.Sha != pr.Sha {
+			actions = append(actions, scm.ActionSync)
+		}
+		for _, action := range actions {
+			hooks = append(hooks, &scm.PullRequestHook{
+				Action:      action,
+				Repo:        *repo,
+				PullRequest: *pr,
+				GUID:        guid(repo, "pull_request", fmt.Sprint(number), action.String(), pr.Sha),
+			})
+		}
+	}
+	return hooks
+}
+
+func sortedNames(branches map[string]string) []string {
+	names := make([]string, 0, len(branches))
+	for name := range branches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func guid(repo *scm.Repository, parts ...string) string {
+	s := "poll:" + repo.FullName
+	for _, part := range parts {
+		s += ":" + part
+	}
+	return s
+}
@@ -0,0 +1,71 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package poll
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/fake"
+)
+
+func TestPollRepo(t *testing.T) {
+	client, data := fake.NewDefault()
+	data.Repositories = []*scm.Repository{{FullName: "octocat/hello-world"}}
+	data.Refs["octocat/hello-world"] = map[string]string{
+		"refs/heads/master":  "a",
+		"refs/heads/feature": "b",
+	}
+	data.PullRequests[1] = &scm.PullRequest{Number: 1, Sha: "b"}
+
+	p := &Poller{Client: client}
+	ctx := context.Background()
+	hooks, err := p.PollRepo(ctx, "octocat/hello-world")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hooks) != 0 {
+		t.Errorf("Want no hooks for the first poll, got %d", len(hooks))
+	}
+
+	data.Refs["octocat/hello-world"] = map[string]string{
+		"refs/heads/master": "c",
+		"refs/heads/fix":    "d",
+	}
+	data.PullRequests[1] = &scm.PullRequest{Number: 1, Sha: "e", Updated: time.Now()}
+	data.PullRequests[2] = &scm.PullRequest{Number: 2, Sha: "d", Updated: time.Now()}
+	hooks, err = p.PollRepo(ctx, "octocat/hello-world")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, hook := range hooks {
+		switch hook := hook.(type) {
+		case *scm.PushHook:
+			got = append(got, fmt.Sprintf("push %s %s..%s", hook.Ref, hook.Before[:1], hook.After[:1]))
+		case *scm.BranchHook:
+			got = append(got, fmt.Sprintf("branch %s %s", hook.Ref.Name, hook.Action))
+		case *scm.PullRequestHook:
+			got = append(got, fmt.Sprintf("pull_request %d %s", hook.PullRequest.Number, hook.Action))
+		}
+	}
+	want := []string{
+		"branch fix created",
+		"push refs/heads/fix 0..d",
+		"push refs/heads/master a..c",
+		"branch feature deleted",
+		"push refs/heads/feature b..0",
+		"pull_request 1 synchronized",
+		"pull_request 2 opened",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
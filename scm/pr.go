@@ -6,7 +6,9 @@ package scm
 
 import (
 	"context"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -42,6 +44,23 @@ type (
 		Created        time.Time
 		Updated        time.Time
 
+		// ClosedAt and MergedAt are the times the pull request
+		// was closed and merged, or the zero time if it is
+		// open or was closed without merging.
+		ClosedAt time.Time
+		MergedAt time.Time
+
+		// Additions, Deletions and ChangedFiles summarize the
+		// diff of the pull request. They are populated when
+		// finding a single pull request.
+		Additions    int
+		Deletions    int
+		ChangedFiles int
+
+		// AuthorAssociation is the relationship of the author
+		// with the repository, if reported by the provider.
+		AuthorAssociation string
+
 		// Link links to the main pull request page
 		Link string
 
@@ -55,6 +74,14 @@ type (
 		Head  string
 		Base  string
 		Body  string
+
+		// Milestone, Labels and Assignees are applied when
+		// the pull request is created or updated, instead of
+		// with follow-up requests, by the drivers that support
+		// it. Milestone is the number accepted by SetMilestone.
+		Milestone int
+		Labels    []string
+		Assignees []string
 	}
 
 	// Milestone the milestotne
@@ -84,8 +111,12 @@ type (
 
 	// PullRequestBranch contains information about a particular branch in a PR.
 	PullRequestBranch struct {
-		Ref  string
-		Sha  string
+		Ref string
+		Sha string
+
+		// Repo is the repository of the branch, which is the
+		// fork for the head of a pull request from a fork. It
+		// is empty if the fork was deleted.
 		Repo Repository
 	}
 
@@ -137,7 +168,7 @@ type (
 		ListChanges(context.Context, string, int, ListOptions) ([]*Change, *Response, error)
 
 		// ListComments returns the pull request comment list.
-		ListComments(context.Context, string, int, ListOptions) ([]*Comment, *Response, error)
+		ListComments(context.Context, string, int, CommentListOptions) ([]*Comment, *Response, error)
 
 		// ListLabels returns the labels on a pull request
 		ListLabels(context.Context, string, int, ListOptions) ([]*Label, *Response, error)
@@ -202,6 +233,113 @@ const (
 	MergeableStateUnknown MergeableState = ""
 )
 
+// FilterPullRequests returns the pull requests within the update
+// and creation time bounds of the options. Drivers use it when the
+// provider cannot filter the list.
+func FilterPullRequests(prs []*PullRequest, opts PullRequestListOptions) []*PullRequest {
+	if opts.UpdatedAfter == nil && opts.UpdatedBefore == nil && opts.CreatedAfter == nil && opts.CreatedBefore == nil {
+		return prs
+	}
+	out := make([]*PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		switch {
+		case opts.UpdatedAfter != nil && pr.Updated.Before(*opts.UpdatedAfter),
+			opts.UpdatedBefore != nil && pr.Updated.After(*opts.UpdatedBefore),
+			opts.CreatedAfter != nil && pr.Created.Before(*opts.CreatedAfter),
+			opts.CreatedBefore != nil && pr.Created.After(*opts.CreatedBefore):
+			continue
+		}
+		out = append(out, pr)
+	}
+	return out
+}
+
+// ChangeLister lists the changes of a pull request, and is
+// implemented by PullRequestService.
+type ChangeLister interface {
+	ListChanges(context.Context, string, int, ListOptions) ([]*Change, *Response, error)
+}
+
+// ListAllChanges lists the changes of the pull request from
+// the page of the options, following the pagination. Once the
+// first page reports the last page, as GitHub does, the other
+// pages are fetched concurrently with at most limit requests
+// in flight, which defaults to DefaultConcurrency. Otherwise
+// the pages are fetched one after the other. The changes are
+// returned in page order, with the response of the last page.
+func ListAllChanges(ctx context.Context, lister ChangeLister, repo string, number int, opts ListOptions, limit int) ([]*Change, *Response, error) {
+	if opts.Page == 0 {
+		opts.Page = 1
+	}
+	all, res, err := lister.ListChanges(ctx, repo, number, opts)
+	if err != nil {
+		return nil, res, err
+	}
+	if res != nil && res.Page.Next != 0 && res.Page.Last >= res.Page.Next {
+		return listChangePages(ctx, lister, repo, number, opts, limit, all, res)
+	}
+	for res != nil && res.Page.Next != 0 {
+		opts.Page = res.Page.Next
+		var changes []*Change
+		changes, res, err = lister.ListChanges(ctx, repo, number, opts)
+		if err != nil {
+			return nil, res, err
+		}
+		all = append(all, changes...)
+	}
+	return all, res, nil
+}
+
+// listChangePages fetches the pages following the first page
+// of changes concurrently, up to the last page of the first
+// response. The first failure cancels the other requests.
+func listChangePages(ctx context.Context, lister ChangeLister, repo string, number int, opts ListOptions, limit int, first []*Change, res *Response) ([]*Change, *Response, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	from := res.Page.Next
+	keys := make([]string, res.Page.Last-from+1)
+	for i := range keys {
+		keys[i] = strconv.Itoa(from + i)
+	}
+	var (
+		mu        sync.Mutex
+		pages     = map[string][]*Change{}
+		responses = map[string]*Response{}
+		failed    error
+		failedRes *Response
+	)
+	forEachLimit(ctx, keys, limit, func(key string) error {
+		page := opts
+		page.Page, _ = strconv.Atoi(key)
+		changes, res, err := lister.ListChanges(ctx, repo, number, page)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			if failed == nil {
+				failed, failedRes = err, res
+				cancel()
+			}
+			return err
+		}
+		pages[key], responses[key] = changes, res
+		return nil
+	})
+	if failed == nil {
+		// the requests waiting for a slot fail with the
+		// error of a context canceled by the caller.
+		failed = ctx.Err()
+	}
+	if failed != nil {
+		return nil, failedRes, failed
+	}
+	all := first
+	for _, key := range keys {
+		all = append(all, pages[key]...)
+	}
+	return all, responses[keys[len(keys)-1]], nil
+}
+
 // Repository returns the base repository where the PR will merge to
 func (pr *PullRequest) Repository() Repository {
 	return pr.Base.Repo
@@ -0,0 +1,101 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// pagedChanges serves the change pages of a pull request,
+// reporting the last page if last is set.
+type pagedChanges struct {
+	pages [][]*Change
+	last  bool
+	fail  int
+
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (s *pagedChanges) ListChanges(ctx context.Context, repo string, number int, opts ListOptions) ([]*Change, *Response, error) {
+	s.mu.Lock()
+	s.inFlight++
+	if s.inFlight > s.peak {
+		s.peak = s.inFlight
+	}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.inFlight--
+		s.mu.Unlock()
+	}()
+	// the later pages respond first.
+	time.Sleep(time.Duration(len(s.pages)-opts.Page) * time.Millisecond)
+
+	res := &Response{}
+	if opts.Page == s.fail {
+		return nil, res, errors.New("page failed")
+	}
+	if opts.Page < len(s.pages) {
+		res.Page.Next = opts.Page + 1
+		if s.last {
+			res.Page.Last = len(s.pages)
+		}
+	}
+	return s.pages[opts.Page-1], res, nil
+}
+
+func newPagedChanges(n int) *pagedChanges {
+	s := &pagedChanges{}
+	for i := 0; i < n; i++ {
+		s.pages = append(s.pages, []*Change{
+			{Path: strconv.Itoa(i) + "/a"},
+			{Path: strconv.Itoa(i) + "/b"},
+		})
+	}
+	return s
+}
+
+func TestListAllChanges(t *testing.T) {
+	for _, last := range []bool{false, true} {
+		lister := newPagedChanges(10)
+		lister.last = last
+		got, _, err := ListAllChanges(context.Background(), lister, "octocat/hello-world", 1, ListOptions{Size: 2}, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var want []*Change
+		for _, page := range lister.pages {
+			want = append(want, page...)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Unexpected changes\n%s", diff)
+		}
+		switch {
+		case last && (lister.peak < 2 || lister.peak > 4):
+			t.Errorf("Want up to 4 concurrent requests with the last page, got %d", lister.peak)
+		case !last && lister.peak != 1:
+			t.Errorf("Want sequential requests without the last page, got %d", lister.peak)
+		}
+	}
+}
+
+func TestListAllChanges_Error(t *testing.T) {
+	lister := newPagedChanges(10)
+	lister.last = true
+	lister.fail = 6
+	_, _, err := ListAllChanges(context.Background(), lister, "octocat/hello-world", 1, ListOptions{}, 0)
+	if err == nil || err.Error() != "page failed" {
+		t.Errorf("Want the error of the failed page, got %v", err)
+	}
+}
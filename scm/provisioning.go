@@ -0,0 +1,61 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import "context"
+
+type (
+	// ProvisionedUser represents a user account managed
+	// through the provisioning service.
+	ProvisionedUser struct {
+		// ID is the SCIM identity on GitHub and the user
+		// id on GitLab.
+		ID         string
+		Login      string
+		Name       string
+		Email      string
+		ExternalID string
+		Active     bool
+	}
+
+	// ProvisionUserInput provides the input fields required
+	// for provisioning a user account.
+	ProvisionUserInput struct {
+		Login      string
+		Name       string
+		Email      string
+		ExternalID string
+
+		// Password is the initial password of the account
+		// on GitLab. A password reset link is emailed to the
+		// user when it is empty.
+		Password string
+	}
+
+	// ProvisioningService provides access to the account
+	// lifecycle of users, for enterprise automation. The
+	// GitHub implementation provisions the members of an
+	// organization with SCIM, while GitLab provisions the
+	// users of the instance with the admin API and ignores
+	// the organization. Both require an administrator token.
+	//
+	// The service is optional: the Provisioning field of the
+	// client is nil for drivers that do not support it.
+	ProvisioningService interface {
+		// CreateUser provisions a user account.
+		CreateUser(ctx context.Context, org string, input *ProvisionUserInput) (*ProvisionedUser, *Response, error)
+
+		// BlockUser blocks the user.
+		BlockUser(ctx context.Context, org, login string) (*Response, error)
+
+		// UnblockUser unblocks the user.
+		UnblockUser(ctx context.Context, org, login string) (*Response, error)
+
+		// DeactivateUser deactivates the user account, which
+		// removes the provisioned member from the organization
+		// on GitHub.
+		DeactivateUser(ctx context.Context, org, login string) (*Response, error)
+	}
+)
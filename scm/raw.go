@@ -0,0 +1,29 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import "context"
+
+// RawKey is the key to use with the context.WithValue
+// function to keep the raw payloads of the responses of the
+// requests made with a context.
+type RawKey struct{}
+
+// WithRaw returns a copy of parent in which the body of the
+// JSON responses is kept in Response.Raw, for the provider
+// fields the types of this package do not have. Other bodies,
+// such as file contents and archives, are not kept. The
+// requests the Gitea driver sends through the Gitea SDK have
+// no raw body.
+func WithRaw(parent context.Context) context.Context {
+	return context.WithValue(parent, RawKey{}, true)
+}
+
+// RawPayload reports whether the body of the responses of
+// the requests made with the context is kept.
+func RawPayload(ctx context.Context) bool {
+	raw, _ := ctx.Value(RawKey{}).(bool)
+	return raw
+}
@@ -6,6 +6,7 @@ package scm
 
 import (
 	"context"
+	"io"
 	"time"
 )
 
@@ -20,7 +21,52 @@ const (
 	AdminPermission = "admin"
 )
 
+// Ruleset targets and enforcement levels.
+const (
+	RulesetTargetBranch = "branch"
+	RulesetTargetTag    = "tag"
+
+	RulesetEnforcementActive   = "active"
+	RulesetEnforcementEvaluate = "evaluate"
+	RulesetEnforcementDisabled = "disabled"
+)
+
+// Pages build states.
+const (
+	PagesStatusQueued   = "queued"
+	PagesStatusBuilding = "building"
+	PagesStatusBuilt    = "built"
+	PagesStatusErrored  = "errored"
+)
+
 type (
+	// DependencyGraph represents the dependencies of a
+	// repository.
+	DependencyGraph struct {
+		Dependencies []*Dependency
+	}
+
+	// Dependency represents a package a repository depends
+	// on.
+	Dependency struct {
+		Name    string
+		Version string
+
+		// Ecosystem is the package ecosystem, such as npm,
+		// pypi or maven.
+		Ecosystem string
+
+		// PURL is the package URL, if known.
+		PURL string
+
+		// License is the license expression, if known.
+		License string
+
+		// Path is the manifest or lock file declaring the
+		// dependency, if known.
+		Path string
+	}
+
 	// Repository represents a git repository.
 	Repository struct {
 		ID        string
@@ -79,6 +125,20 @@ type (
 		NativeEvents []string
 	}
 
+	// HookDelivery represents a delivery of a repository
+	// webhook.
+	HookDelivery struct {
+		ID         int64
+		GUID       string
+		Event      string
+		Action     string
+		Status     string
+		StatusCode int
+		Redelivery bool
+		Duration   time.Duration
+		Delivered  time.Time
+	}
+
 	// HookEvents represents supported hook events.
 	HookEvents struct {
 		Branch             bool
@@ -106,6 +166,11 @@ type (
 		Desc   string
 		Target string
 		Link   string
+
+		// PipelineID is the pipeline the status belongs to,
+		// for providers grouping statuses in pipelines such
+		// as GitLab.
+		PipelineID int
 	}
 
 	// StatusInput provides the input fields required for
@@ -116,6 +181,102 @@ type (
 		Desc   string
 		Target string
 		Link   string
+
+		// PipelineID optionally targets the status at a
+		// pipeline, for providers grouping statuses in
+		// pipelines such as GitLab.
+		PipelineID int
+	}
+
+	// Ruleset represents a set of rules enforced on the
+	// branches or tags of a repository.
+	Ruleset struct {
+		ID          int
+		Name        string
+		Target      string
+		Enforcement string
+
+		// Include and Exclude are the ref name patterns the
+		// ruleset applies to, such as refs/heads/main or
+		// ~DEFAULT_BRANCH.
+		Include []string
+		Exclude []string
+
+		Rules   RulesetRules
+		Link    string
+		Created time.Time
+		Updated time.Time
+	}
+
+	// RulesetInput provides the input fields required for
+	// creating or updating a ruleset.
+	RulesetInput struct {
+		Name        string
+		Target      string
+		Enforcement string
+		Include     []string
+		Exclude     []string
+		Rules       RulesetRules
+	}
+
+	// RulesetRules is the provider neutral model of the
+	// rules enforced on the matching refs. The zero value
+	// enforces no rule.
+	RulesetRules struct {
+		RestrictCreations   bool
+		RestrictUpdates     bool
+		RestrictDeletions   bool
+		RestrictForcePushes bool
+
+		RequireLinearHistory bool
+		RequireSignedCommits bool
+
+		RequirePullRequest           bool
+		RequiredApprovingReviewCount int
+		DismissStaleReviews          bool
+		RequireCodeOwnerReview       bool
+
+		RequireStatusChecks  bool
+		RequiredStatusChecks []string
+		StrictStatusChecks   bool
+	}
+
+	// Pages represents the static site published from a
+	// repository, such as GitHub Pages or GitLab Pages.
+	Pages struct {
+		URL    string
+		Status string
+
+		// Branch and Path are the publishing source, for
+		// sites built from a branch.
+		Branch string
+		Path   string
+
+		// BuildType is legacy for sites built from a branch
+		// and workflow for sites deployed by a workflow.
+		BuildType string
+
+		CNAME         string
+		HTTPSEnforced bool
+		Public        bool
+	}
+
+	// PagesInput provides the input fields required for
+	// enabling the static site of a repository.
+	PagesInput struct {
+		Branch    string
+		Path      string
+		BuildType string
+	}
+
+	// PagesBuild represents a build of the static site.
+	PagesBuild struct {
+		Status   string
+		Error    string
+		Commit   string
+		Duration time.Duration
+		Created  time.Time
+		Updated  time.Time
 	}
 
 	// RepositoryService provides access to repository resources.
@@ -165,6 +326,13 @@ type (
 		// DeleteHook deletes a repository webhook.
 		DeleteHook(context.Context, string, string) (*Response, error)
 
+		// ListHookDeliveries returns the recent deliveries of
+		// a repository webhook.
+		ListHookDeliveries(ctx context.Context, repo, hookID string, opts ListOptions) ([]*HookDelivery, *Response, error)
+
+		// RedeliverHookDelivery sends a webhook delivery again.
+		RedeliverHookDelivery(ctx context.Context, repo, hookID string, deliveryID int64) (*Response, error)
+
 		// IsCollaborator returns true if the user is a collaborator on the repository
 		IsCollaborator(ctx context.Context, repo string, user string) (bool, *Response, error)
 
@@ -179,9 +347,109 @@ type (
 
 		// Delete deletes a repository
 		Delete(ctx context.Context, repo string) (*Response, error)
+
+		// GetDependencyGraph returns the dependencies of the
+		// repository.
+		GetDependencyGraph(ctx context.Context, repo string) (*DependencyGraph, *Response, error)
+
+		// ExportSBOM returns the software bill of materials of
+		// the repository as an SPDX JSON document. The caller
+		// must close the returned reader.
+		ExportSBOM(ctx context.Context, repo string) (io.ReadCloser, *Response, error)
+
+		// ListRulesets returns the rulesets of the repository.
+		ListRulesets(ctx context.Context, repo string, opts ListOptions) ([]*Ruleset, *Response, error)
+
+		// FindRuleset returns a ruleset by id.
+		FindRuleset(ctx context.Context, repo string, id int) (*Ruleset, *Response, error)
+
+		// CreateRuleset creates a new ruleset.
+		CreateRuleset(ctx context.Context, repo string, input *RulesetInput) (*Ruleset, *Response, error)
+
+		// UpdateRuleset updates a ruleset. Provider rules
+		// with no equivalent in the neutral model are
+		// replaced.
+		UpdateRuleset(ctx context.Context, repo string, id int, input *RulesetInput) (*Ruleset, *Response, error)
+
+		// GetPages returns the static site of the repository.
+		GetPages(ctx context.Context, repo string) (*Pages, *Response, error)
+
+		// EnablePages enables the static site of the
+		// repository.
+		EnablePages(ctx context.Context, repo string, input *PagesInput) (*Pages, *Response, error)
+
+		// DisablePages disables the static site of the
+		// repository.
+		DisablePages(ctx context.Context, repo string) (*Response, error)
+
+		// GetLatestPagesBuild returns the latest build of the
+		// static site of the repository.
+		GetLatestPagesBuild(ctx context.Context, repo string) (*PagesBuild, *Response, error)
 	}
 )
 
+// LatestStatuses folds the status history of a ref into the
+// latest status of each context. The statuses are expected in
+// reverse chronological order, as returned by the providers,
+// so the first status of each context is kept.
+func LatestStatuses(statuses []*Status) []*Status {
+	out := []*Status{}
+	seen := map[string]bool{}
+	for _, status := range statuses {
+		if seen[status.Label] {
+			continue
+		}
+		seen[status.Label] = true
+		out = append(out, status)
+	}
+	return out
+}
+
+// ListLatestStatuses lists the statuses of the ref, following
+// the pagination, and returns the latest status of each
+// context. A context whose history spans several pages is
+// folded, unlike when deduplicating page by page.
+func ListLatestStatuses(ctx context.Context, service RepositoryService, repo, ref string) ([]*Status, *Response, error) {
+	var all []*Status
+	opts := ListOptions{Page: 1, Size: 100}
+	for {
+		statuses, res, err := service.ListStatus(ctx, repo, ref, opts)
+		if err != nil {
+			return nil, res, err
+		}
+		all = append(all, statuses...)
+		if res == nil || res.Page.Next == 0 {
+			return LatestStatuses(all), res, nil
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
+// RulesetFromBranchProtection converts a branch protection
+// rule to the equivalent ruleset input, so that branch
+// protection policies can be applied as rulesets.
+func RulesetFromBranchProtection(rule *BranchProtectionRule) *RulesetInput {
+	return &RulesetInput{
+		Name:        rule.Pattern,
+		Target:      RulesetTargetBranch,
+		Enforcement: RulesetEnforcementActive,
+		Include:     []string{"refs/heads/" + rule.Pattern},
+		Rules: RulesetRules{
+			RestrictDeletions:            !rule.AllowDeletions,
+			RestrictForcePushes:          !rule.AllowForcePushes,
+			RequireLinearHistory:         rule.RequireLinearHistory,
+			RequireSignedCommits:         rule.RequireSignedCommits,
+			RequirePullRequest:           rule.RequirePullRequestReviews,
+			RequiredApprovingReviewCount: rule.RequiredApprovingReviewCount,
+			DismissStaleReviews:          rule.DismissStaleReviews,
+			RequireCodeOwnerReview:       rule.RequireCodeOwnerReview,
+			RequireStatusChecks:          rule.RequireStatusChecks,
+			RequiredStatusChecks:         rule.RequiredStatusChecks,
+			StrictStatusChecks:           rule.StrictStatusChecks,
+		},
+	}
+}
+
 // TODO(bradrydzewski): Add endpoint to get a repository deploy key
 // TODO(bradrydzewski): Add endpoint to list repository deploy keys
 // TODO(bradrydzewski): Add endpoint to create a repository deploy key
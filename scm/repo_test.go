@@ -0,0 +1,82 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// pagedStatuses serves the status pages of a ref.
+type pagedStatuses struct {
+	RepositoryService
+	pages [][]*Status
+}
+
+func (s *pagedStatuses) ListStatus(ctx context.Context, repo, ref string, opts ListOptions) ([]*Status, *Response, error) {
+	res := &Response{}
+	if opts.Page < len(s.pages) {
+		res.Page.Next = opts.Page + 1
+	}
+	return s.pages[opts.Page-1], res, nil
+}
+
+func TestListLatestStatuses(t *testing.T) {
+	service := &pagedStatuses{
+		pages: [][]*Status{
+			{
+				{State: StateSuccess, Label: "ci/build"},
+				{State: StatePending, Label: "ci/test"},
+				{State: StatePending, Label: "ci/build"},
+			},
+			{
+				{State: StatePending, Label: "ci/lint"},
+				{State: StatePending, Label: "ci/test"},
+			},
+		},
+	}
+	got, _, err := ListLatestStatuses(context.Background(), service, "octocat/hello-world", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Status{
+		{State: StateSuccess, Label: "ci/build"},
+		{State: StatePending, Label: "ci/test"},
+		{State: StatePending, Label: "ci/lint"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected statuses\n%s", diff)
+	}
+}
+
+func TestRulesetFromBranchProtection(t *testing.T) {
+	rule := &BranchProtectionRule{
+		Pattern:                      "main",
+		RequirePullRequestReviews:    true,
+		RequiredApprovingReviewCount: 2,
+		RequireStatusChecks:          true,
+		RequiredStatusChecks:         []string{"ci/build"},
+		AllowDeletions:               true,
+	}
+	want := &RulesetInput{
+		Name:        "main",
+		Target:      RulesetTargetBranch,
+		Enforcement: RulesetEnforcementActive,
+		Include:     []string{"refs/heads/main"},
+		Rules: RulesetRules{
+			RestrictForcePushes:          true,
+			RequirePullRequest:           true,
+			RequiredApprovingReviewCount: 2,
+			RequireStatusChecks:          true,
+			RequiredStatusChecks:         []string{"ci/build"},
+		},
+	}
+	if diff := cmp.Diff(RulesetFromBranchProtection(rule), want); diff != "" {
+		t.Errorf("Unexpected ruleset")
+		t.Log(diff)
+	}
+}
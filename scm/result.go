@@ -0,0 +1,174 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.21
+// +build go1.21
+
+// The module declares go 1.13, and Go 1.18 to 1.20 compile
+// the module with that language version, which has no type
+// parameters. Since Go 1.21, the build constraint of the file
+// raises its language version, so the generic list results
+// are built with Go 1.21 or later without raising the Go
+// version required by the rest of the module.
+
+package scm
+
+import (
+	"context"
+	"errors"
+)
+
+type (
+	// ListResult is a page of listed objects, with the page
+	// and rate limit details of the response.
+	ListResult[T any] struct {
+		Items    []*T
+		Page     Page
+		Rate     Rate
+		Response *Response
+	}
+
+	// PageFunc lists the page of objects following the page
+	// of the previous result, or the page of the options of
+	// the endpoint if the previous page is the zero Page.
+	PageFunc[T any] func(ctx context.Context, prev Page) (*ListResult[T], error)
+)
+
+// NewListResult returns the result of the objects and the
+// response of a list method of the services, e.g.
+// NewListResult(client.Repositories.List(ctx, opts)).
+func NewListResult[T any](items []*T, res *Response, err error) (*ListResult[T], error) {
+	out := &ListResult[T]{Items: items, Response: res}
+	if res != nil {
+		out.Page = res.Page
+		out.Rate = res.Rate
+	}
+	return out, err
+}
+
+// HasNext reports whether there is a page following the
+// result.
+func (r *ListResult[T]) HasNext() bool {
+	return r.Page.Next != 0 || r.Page.NextURL != ""
+}
+
+// First lists the page of the options of the endpoint.
+func (f PageFunc[T]) First(ctx context.Context) (*ListResult[T], error) {
+	return f(ctx, Page{})
+}
+
+// Next lists the page following the result.
+func (f PageFunc[T]) Next(ctx context.Context, prev *ListResult[T]) (*ListResult[T], error) {
+	return f(ctx, prev.Page)
+}
+
+// ListAll lists the objects of the pages of the endpoint,
+// from the page of its options, following the pagination.
+// The result has the page and rate limit details of the last
+// page.
+func ListAll[T any](ctx context.Context, pages PageFunc[T]) (*ListResult[T], error) {
+	var all []*T
+	res, err := pages.First(ctx)
+	for err == nil {
+		all = append(all, res.Items...)
+		if !res.HasNext() {
+			res.Items = all
+			return res, nil
+		}
+		res, err = pages.Next(ctx, res)
+	}
+	return res, err
+}
+
+// Each calls fn with each object of the pages of the endpoint,
+// fetching one page at a time. It stops at the first error of
+// fn, which is returned unless it is ErrStopPaging.
+func Each[T any](ctx context.Context, pages PageFunc[T], fn func(*T) error) error {
+	res, err := pages.First(ctx)
+	for err == nil {
+		for _, item := range res.Items {
+			if err := fn(item); errors.Is(err, ErrStopPaging) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+		}
+		if !res.HasNext() {
+			return nil
+		}
+		res, err = pages.Next(ctx, res)
+	}
+	return err
+}
+
+// RepositoryPages returns the pages of the repositories of the
+// user.
+func RepositoryPages(lister RepositoryLister, opts ListOptions) PageFunc[Repository] {
+	return func(ctx context.Context, prev Page) (*ListResult[Repository], error) {
+		opts := opts
+		if prev != (Page{}) {
+			nextList(&opts, prev)
+		}
+		return NewListResult(lister.List(ctx, opts))
+	}
+}
+
+// IssuePages returns the pages of the issues of the repository.
+func IssuePages(lister IssueLister, repo string, opts IssueListOptions) PageFunc[Issue] {
+	return func(ctx context.Context, prev Page) (*ListResult[Issue], error) {
+		opts := opts
+		if prev != (Page{}) {
+			nextPage(&opts.Page, prev)
+		}
+		return NewListResult(lister.List(ctx, repo, opts))
+	}
+}
+
+// PullRequestPages returns the pages of the pull requests of
+// the repository.
+func PullRequestPages(lister PullRequestLister, repo string, opts PullRequestListOptions) PageFunc[PullRequest] {
+	return func(ctx context.Context, prev Page) (*ListResult[PullRequest], error) {
+		opts := opts
+		if prev != (Page{}) {
+			nextPage(&opts.Page, prev)
+		}
+		return NewListResult(lister.List(ctx, repo, opts))
+	}
+}
+
+// ChangePages returns the pages of the changes of the pull
+// request.
+func ChangePages(lister ChangeLister, repo string, number int, opts ListOptions) PageFunc[Change] {
+	return func(ctx context.Context, prev Page) (*ListResult[Change], error) {
+		opts := opts
+		if prev != (Page{}) {
+			nextList(&opts, prev)
+		}
+		return NewListResult(lister.ListChanges(ctx, repo, number, opts))
+	}
+}
+
+// CommentPages returns the pages of the comments of the issue
+// or pull request.
+func CommentPages(lister CommentLister, repo string, number int, opts CommentListOptions) PageFunc[Comment] {
+	return func(ctx context.Context, prev Page) (*ListResult[Comment], error) {
+		opts := opts
+		if prev != (Page{}) {
+			nextPage(&opts.Page, prev)
+		}
+		return NewListResult(lister.ListComments(ctx, repo, number, opts))
+	}
+}
+
+// CommitPages returns the pages of the commits of the
+// repository.
+func CommitPages(lister CommitLister, repo string, opts CommitListOptions) PageFunc[Commit] {
+	return func(ctx context.Context, prev Page) (*ListResult[Commit], error) {
+		opts := opts
+		if prev != (Page{}) {
+			nextPage(&opts.Page, prev)
+		}
+		return NewListResult(lister.ListCommits(ctx, repo, opts))
+	}
+}
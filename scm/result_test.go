@@ -0,0 +1,105 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.21
+// +build go1.21
+
+package scm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewListResult(t *testing.T) {
+	res := &Response{Status: 200}
+	res.Page.Next = 2
+	res.Rate.Remaining = 10
+	got, err := NewListResult([]*Change{{Path: "a"}}, res, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.HasNext() || got.Page.Next != 2 || got.Rate.Remaining != 10 || got.Response != res {
+		t.Errorf("Unexpected result %+v", got)
+	}
+
+	want := errors.New("list failed")
+	got, err = NewListResult[Change](nil, nil, want)
+	if err != want || got.HasNext() {
+		t.Errorf("Want the error and an empty result, got %+v and %v", got, err)
+	}
+}
+
+func TestListAll(t *testing.T) {
+	lister := newPagedChanges(3)
+	got, err := ListAll(context.Background(), ChangePages(lister, "octocat/hello-world", 1, ListOptions{Page: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want []*Change
+	for _, page := range lister.pages {
+		want = append(want, page...)
+	}
+	if diff := cmp.Diff(want, got.Items); diff != "" {
+		t.Errorf("Unexpected changes\n%s", diff)
+	}
+	if got.HasNext() {
+		t.Errorf("Want the page of the last result")
+	}
+
+	lister.fail = 2
+	if _, err := ListAll(context.Background(), ChangePages(lister, "octocat/hello-world", 1, ListOptions{Page: 1})); err == nil {
+		t.Errorf("Want the error of the failed page")
+	}
+}
+
+func TestListAll_URL(t *testing.T) {
+	lister := &cursorRepositories{
+		pages: map[string][]*Repository{
+			"":        {{Name: "a"}},
+			"/page/2": {{Name: "b"}},
+		},
+		next: map[string]string{"": "/page/2"},
+	}
+	pages := RepositoryPages(lister, ListOptions{})
+	got, err := ListAll(context.Background(), pages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Items) != 2 || got.Items[1].Name != "b" {
+		t.Errorf("Unexpected repositories %v", got.Items)
+	}
+
+	// the pages do not change the options of the endpoint.
+	first, err := pages.First(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Items) != 1 || first.Items[0].Name != "a" {
+		t.Errorf("Want the first page again, got %v", first.Items)
+	}
+}
+
+func TestEach(t *testing.T) {
+	lister := &pagedComments{
+		pages: [][]*Comment{{{ID: 1}, {ID: 2}}, {{ID: 3}}},
+	}
+	var got []int
+	err := Each(context.Background(), CommentPages(lister, "octocat/hello-world", 1, CommentListOptions{Page: 1}), func(comment *Comment) error {
+		got = append(got, comment.ID)
+		if comment.ID == 2 {
+			return ErrStopPaging
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 2}; !cmp.Equal(want, got) {
+		t.Errorf("Want comments %v, got %v", want, got)
+	}
+}
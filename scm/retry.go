@@ -0,0 +1,191 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryKey is the key to use with the context.WithValue
+// function to associate a retry policy with a context.
+type RetryKey struct{}
+
+// RetryPolicy configures how the requests failing with a
+// transport error or a retryable status are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts, including the
+	// first one. The requests are not retried if it is less
+	// than 2.
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry, doubled
+	// for each following retry. It defaults to one second.
+	Backoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts,
+	// including the delay requested by a Retry-After header.
+	// It defaults to 30 seconds.
+	MaxBackoff time.Duration
+
+	// Methods are the retried request methods. They default
+	// to GET and HEAD, since retrying a request that is not
+	// idempotent may apply it twice.
+	Methods []string
+
+	// Statuses are the retried response statuses. They
+	// default to 429, 502, 503 and 504.
+	Statuses []int
+}
+
+// WithRetry returns a copy of parent in which the requests are
+// retried according to the policy, which overrides the Retry
+// policy of the client. A nil policy disables the retries.
+func WithRetry(parent context.Context, policy *RetryPolicy) context.Context {
+	return context.WithValue(parent, RetryKey{}, policy)
+}
+
+// RetryPolicy returns the retry policy of the requests made
+// with the context, which defaults to the Retry policy of the
+// client.
+func (c *Client) RetryPolicy(ctx context.Context) *RetryPolicy {
+	if policy, ok := ctx.Value(RetryKey{}).(*RetryPolicy); ok {
+		return policy
+	}
+	return c.Retry
+}
+
+// retries reports whether the requests of the method are
+// retried.
+func (p *RetryPolicy) retries(method string) bool {
+	if p == nil || p.MaxAttempts < 2 {
+		return false
+	}
+	if len(p.Methods) == 0 {
+		return method == http.MethodGet || method == http.MethodHead
+	}
+	for _, m := range p.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// retryable reports whether the response status is retried.
+func (p *RetryPolicy) retryable(status int) bool {
+	if len(p.Statuses) == 0 {
+		switch status {
+		case http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	for _, s := range p.Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// delay returns the delay before the retry following the
+// attempt, honoring the Retry-After header of the response.
+func (p *RetryPolicy) delay(attempt int, res *http.Response) time.Duration {
+	backoff, max := p.Backoff, p.MaxBackoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+	d := backoff
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if res != nil {
+		if secs, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && secs >= 0 {
+			d = time.Duration(secs) * time.Second
+		}
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
+
+// sleep waits for the duration, or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// discard drains and closes the body of a response that is
+// not returned, so the connection can be reused.
+func discard(res *http.Response) {
+	io.Copy(ioutil.Discard, io.LimitReader(res.Body, 4096))
+	res.Body.Close()
+}
+
+// contextBody is a response body failing with the context error
+// once the context is done, even if the transport does not
+// interrupt the reads of the body, e.g. a custom transport
+// buffering a slow response.
+type contextBody struct {
+	ctx  context.Context
+	body io.ReadCloser
+	stop chan struct{}
+	once sync.Once
+}
+
+// newContextBody returns the body closed when the context is
+// done. The body must be closed to release the goroutine
+// watching the context.
+func newContextBody(ctx context.Context, body io.ReadCloser) io.ReadCloser {
+	if ctx.Done() == nil || body == nil {
+		return body
+	}
+	b := &contextBody{ctx: ctx, body: body, stop: make(chan struct{})}
+	go func() {
+		select {
+		case <-ctx.Done():
+			body.Close()
+		case <-b.stop:
+		}
+	}()
+	return b
+}
+
+func (b *contextBody) Read(p []byte) (int, error) {
+	if err := b.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := b.body.Read(p)
+	if err != nil && err != io.EOF {
+		if ctxErr := b.ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
+	}
+	return n, err
+}
+
+func (b *contextBody) Close() error {
+	b.once.Do(func() { close(b.stop) })
+	return b.body.Close()
+}
@@ -0,0 +1,121 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientRetry(t *testing.T) {
+	var attempts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		attempts = append(attempts, r.Method+" "+string(body))
+		if n := len(attempts); n == 1 || n == 3 || n == 4 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	base, _ := url.Parse(server.URL + "/")
+	client := &Client{
+		BaseURL: base,
+		Retry:   &RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond},
+	}
+	ctx := context.Background()
+	res, err := client.Do(ctx, &Request{Method: "GET", Path: "repos"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if got, want := res.Status, 200; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+
+	// mutating requests are not retried by default
+	res, err = client.Do(ctx, &Request{Method: "POST", Path: "repos", Body: strings.NewReader("{}")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if got, want := res.Status, 503; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+
+	// the retried body is sent again
+	ctx = WithRetry(ctx, &RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond, Methods: []string{"POST"}})
+	res, err = client.Do(ctx, &Request{Method: "POST", Path: "repos", Body: strings.NewReader("{}")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if got, want := res.Status, 200; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+
+	want := []string{"GET ", "GET ", "POST {}", "POST {}", "POST {}"}
+	if got := strings.Join(attempts, ","); got != strings.Join(want, ",") {
+		t.Errorf("Want attempts %q, got %q", want, attempts)
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := &RetryPolicy{Backoff: time.Second, MaxBackoff: 5 * time.Second}
+	tests := []struct {
+		attempt    int
+		retryAfter string
+		want       time.Duration
+	}{
+		{1, "", time.Second},
+		{2, "", 2 * time.Second},
+		{3, "", 4 * time.Second},
+		{4, "", 5 * time.Second},
+		{1, "3", 3 * time.Second},
+		{1, "60", 5 * time.Second},
+	}
+	for _, test := range tests {
+		res := &http.Response{Header: http.Header{}}
+		if test.retryAfter != "" {
+			res.Header.Set("Retry-After", test.retryAfter)
+		}
+		if got := policy.delay(test.attempt, res); got != test.want {
+			t.Errorf("Want delay %s for attempt %d, got %s", test.want, test.attempt, got)
+		}
+	}
+}
+
+func TestContextBody(t *testing.T) {
+	block := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		<-block
+	}))
+	defer server.Close()
+	defer close(block)
+
+	base, _ := url.Parse(server.URL + "/")
+	client := &Client{BaseURL: base}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	res, err := client.Do(ctx, &Request{Method: "GET", Path: "archive"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	_, err = ioutil.ReadAll(res.Body)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Want the context deadline error, got %v", err)
+	}
+}
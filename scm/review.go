@@ -6,6 +6,7 @@ package scm
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 )
 
@@ -33,6 +34,10 @@ type (
 		Author  User
 		Created time.Time
 		Updated time.Time
+
+		// AuthorAssociation is the relationship of the author
+		// with the repository, if reported by the provider.
+		AuthorAssociation string
 	}
 
 	// ReviewHook represents a review web hook
@@ -44,6 +49,7 @@ type (
 		Installation *InstallationRef
 		// GUID is included in the header of the request received by Github.
 		GUID string
+		Raw  json.RawMessage `json:"-"`
 	}
 
 	// ReviewCommentInput provides the input fields required for
@@ -0,0 +1,59 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"time"
+)
+
+// Normalized runner states. Providers reporting other
+// states, such as the GitLab stale state, are passed as is.
+const (
+	RunnerStatusOnline  = "online"
+	RunnerStatusOffline = "offline"
+)
+
+type (
+	// Runner represents a CI runner or agent registered
+	// to a repository.
+	Runner struct {
+		ID     int
+		Name   string
+		Desc   string
+		OS     string
+		Status string
+		Busy   bool
+		Paused bool
+		Labels []string
+	}
+
+	// RunnerToken represents a token used to register a
+	// new runner.
+	RunnerToken struct {
+		Token   string
+		Expires time.Time
+	}
+
+	// RunnerService provides access to the CI runners of a
+	// repository, GitHub Actions self-hosted runners, GitLab
+	// runners and Gitea act runners.
+	//
+	// The service is optional: the Runners field of the
+	// client is nil for drivers that do not support it.
+	RunnerService interface {
+		// List returns the runners of the repository.
+		List(ctx context.Context, repo string, opts ListOptions) ([]*Runner, *Response, error)
+
+		// CreateToken returns a new token to register a
+		// runner to the repository. On GitLab the runner
+		// is created up front and the token is its
+		// authentication token.
+		CreateToken(ctx context.Context, repo string) (*RunnerToken, *Response, error)
+
+		// Delete removes a runner.
+		Delete(ctx context.Context, repo string, id int) (*Response, error)
+	}
+)
@@ -0,0 +1,176 @@
+package scm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Severity represents the normalized severity of a
+// security alert.
+type Severity int
+
+// Severity values, ordered from least to most severe.
+const (
+	SeverityUnknown Severity = iota
+	SeverityInfo
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+// String returns a string representation of the Severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// ToSeverity converts the given text to a severity. The
+// code scanning levels note, warning and error map to low,
+// medium and high.
+func ToSeverity(s string) Severity {
+	switch strings.ToLower(s) {
+	case "info", "none":
+		return SeverityInfo
+	case "low", "note":
+		return SeverityLow
+	case "medium", "moderate", "warning":
+		return SeverityMedium
+	case "high", "error":
+		return SeverityHigh
+	case "critical":
+		return SeverityCritical
+	default:
+		return SeverityUnknown
+	}
+}
+
+// MarshalJSON marshals Severity to JSON
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%s"`, s.String())), nil
+}
+
+// UnmarshalJSON unmarshals JSON to Severity
+func (s *Severity) UnmarshalJSON(b []byte) error {
+	*s = ToSeverity(strings.Trim(string(b), `"`))
+	return nil
+}
+
+// AlertKind identifies the scanner that raised a
+// security alert.
+type AlertKind string
+
+// AlertKind values.
+const (
+	// AlertKindDependabot is for GitHub Dependabot alerts
+	AlertKindDependabot AlertKind = "dependabot"
+	// AlertKindSecretScanning is for GitHub secret scanning alerts
+	AlertKindSecretScanning AlertKind = "secret_scanning"
+	// AlertKindCodeScanning is for GitHub code scanning alerts
+	AlertKindCodeScanning AlertKind = "code_scanning"
+	// AlertKindVulnerability is for GitLab vulnerability findings
+	AlertKindVulnerability AlertKind = "vulnerability"
+)
+
+// Normalized security alert states.
+const (
+	AlertStateOpen      = "open"
+	AlertStateDismissed = "dismissed"
+	AlertStateFixed     = "fixed"
+)
+
+// Dismissal reasons shared by the providers. Drivers map
+// them to the provider reason, other reasons are sent as is.
+const (
+	DismissReasonFalsePositive = "false_positive"
+	DismissReasonWontFix       = "wont_fix"
+	DismissReasonUsedInTests   = "used_in_tests"
+)
+
+type (
+	// SecurityAlert represents a security alert raised by a
+	// dependency, secret or code scanner.
+	SecurityAlert struct {
+		Number   int
+		Kind     AlertKind
+		State    string
+		Severity Severity
+		Title    string
+		Desc     string
+
+		// Package is the vulnerable dependency, for
+		// dependency alerts.
+		Package string
+
+		// Path is the manifest or source file the alert
+		// was raised for.
+		Path string
+
+		// Identifiers are the advisory identifiers, such as
+		// GHSA and CVE ids.
+		Identifiers []string
+
+		Link            string
+		DismissedReason string
+		Created         time.Time
+		Updated         time.Time
+		Dismissed       time.Time
+	}
+
+	// SecurityAlertListOptions provides options for querying
+	// a list of security alerts.
+	SecurityAlertListOptions struct {
+		Page int
+		Size int
+
+		// Kind selects the alerts to list. It is required
+		// by drivers with several scanners, such as GitHub.
+		Kind AlertKind
+
+		// State optionally filters the alerts by state, one
+		// of the normalized alert states.
+		State string
+
+		// Severity optionally filters the alerts by severity.
+		Severity Severity
+	}
+
+	// SecurityAlertDismissInput provides the input fields
+	// required to dismiss a security alert.
+	SecurityAlertDismissInput struct {
+		Reason  string
+		Comment string
+	}
+
+	// SecurityService provides access to the security alerts
+	// of a repository, GitHub Dependabot, secret scanning and
+	// code scanning alerts and GitLab vulnerability findings.
+	//
+	// The service is optional: the Security field of the
+	// client is nil for drivers that do not support it.
+	SecurityService interface {
+		// ListAlerts returns the security alerts of the
+		// repository.
+		ListAlerts(ctx context.Context, repo string, opts SecurityAlertListOptions) ([]*SecurityAlert, *Response, error)
+
+		// FindAlert returns a security alert by number.
+		FindAlert(ctx context.Context, repo string, kind AlertKind, number int) (*SecurityAlert, *Response, error)
+
+		// DismissAlert dismisses a security alert.
+		DismissAlert(ctx context.Context, repo string, kind AlertKind, number int, input *SecurityAlertDismissInput) (*SecurityAlert, *Response, error)
+	}
+)
@@ -0,0 +1,43 @@
+package scm
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSeverityJSON(t *testing.T) {
+	for i := SeverityUnknown; i <= SeverityCritical; i++ {
+		in := Severity(i)
+		t.Run(in.String(), func(t *testing.T) {
+			b, err := json.Marshal(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var out Severity
+			if err := json.Unmarshal(b, &out); err != nil {
+				t.Fatal(err)
+			}
+
+			if in != out {
+				t.Errorf("%s != %s", in, out)
+			}
+		})
+	}
+}
+
+func TestToSeverity(t *testing.T) {
+	tests := map[string]Severity{
+		"note":     SeverityLow,
+		"moderate": SeverityMedium,
+		"warning":  SeverityMedium,
+		"error":    SeverityHigh,
+		"CRITICAL": SeverityCritical,
+		"":         SeverityUnknown,
+	}
+	for in, want := range tests {
+		if got := ToSeverity(in); got != want {
+			t.Errorf("Want severity %s for %q, got %s", want, in, got)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package semver provides helpers to list the tags of a
+// repository as semantic versions, find the latest release
+// and compute the next version from conventional commits.
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version, see https://semver.org.
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+	Metadata   string
+}
+
+var versionRe = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// Parse parses the version, with an optional v prefix.
+func Parse(s string) (*Version, error) {
+	m := versionRe.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("invalid semantic version %q", s)
+	}
+	v := &Version{Prerelease: m[4], Metadata: m[5]}
+	v.Major, _ = strconv.Atoi(m[1])
+	v.Minor, _ = strconv.Atoi(m[2])
+	v.Patch, _ = strconv.Atoi(m[3])
+	return v, nil
+}
+
+// String returns the version without the v prefix.
+func (v *Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Metadata != "" {
+		s += "+" + v.Metadata
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 if v is lower than, equal to or
+// greater than o, following the semantic versioning
+// precedence rules. Build metadata is ignored.
+func (v *Version) Compare(o *Version) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+	switch {
+	case v.Prerelease == o.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case o.Prerelease == "":
+		return -1
+	}
+	a, b := strings.Split(v.Prerelease, "."), strings.Split(o.Prerelease, ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := compareIdentifier(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return sign(len(a) - len(b))
+}
+
+// compareIdentifier compares prerelease identifiers. Numeric
+// identifiers have lower precedence than alphanumeric ones.
+func compareIdentifier(a, b string) int {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return sign(x - y)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func sign(d int) int {
+	switch {
+	case d < 0:
+		return -1
+	case d > 0:
+		return 1
+	}
+	return 0
+}
+
+// Bump is the increment of a version.
+type Bump int
+
+// Version increments.
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+// Inc returns the version incremented by the bump. The
+// prerelease and metadata are dropped, so a prerelease is
+// promoted to its release when the bump does not go past it,
+// e.g. 1.2.0-rc.1 becomes 1.2.0 on a minor bump. A breaking
+// change increments the minor version of a 0.y.z version.
+func (v *Version) Inc(bump Bump) *Version {
+	out := &Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	if bump == BumpMajor && v.Major == 0 {
+		bump = BumpMinor
+	}
+	pre := v.Prerelease != ""
+	switch bump {
+	case BumpMajor:
+		if !pre || v.Minor != 0 || v.Patch != 0 {
+			out.Major++
+		}
+		out.Minor, out.Patch = 0, 0
+	case BumpMinor:
+		if !pre || v.Patch != 0 {
+			out.Minor++
+		}
+		out.Patch = 0
+	case BumpPatch:
+		if !pre {
+			out.Patch++
+		}
+	default:
+		out.Prerelease, out.Metadata = v.Prerelease, v.Metadata
+	}
+	return out
+}
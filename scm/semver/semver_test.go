@@ -0,0 +1,202 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/fake"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+		err   bool
+	}{
+		{value: "v1.2.3", want: "1.2.3"},
+		{value: "1.0.0-rc.1+build.5", want: "1.0.0-rc.1+build.5"},
+		{value: "1.2", err: true},
+		{value: "01.2.3", err: true},
+		{value: "1.2.3-", err: true},
+		{value: "latest", err: true},
+	}
+	for _, test := range tests {
+		v, err := Parse(test.value)
+		if test.err {
+			if err == nil {
+				t.Errorf("Expect error parsing %q", test.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if got := v.String(); got != test.want {
+			t.Errorf("Want version %s, got %s", test.want, got)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// sorted by precedence, from https://semver.org.
+	versions := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.10.0",
+		"2.0.0",
+	}
+	for i := 1; i < len(versions); i++ {
+		a, _ := Parse(versions[i-1])
+		b, _ := Parse(versions[i])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("Want %s lower than %s", a, b)
+		}
+	}
+	a, _ := Parse("1.0.0+build.1")
+	b, _ := Parse("1.0.0+build.2")
+	if a.Compare(b) != 0 {
+		t.Errorf("Want build metadata ignored")
+	}
+}
+
+func TestInc(t *testing.T) {
+	tests := []struct {
+		version string
+		bump    Bump
+		want    string
+	}{
+		{"1.2.3", BumpPatch, "1.2.4"},
+		{"1.2.3", BumpMinor, "1.3.0"},
+		{"1.2.3", BumpMajor, "2.0.0"},
+		{"1.2.3", BumpNone, "1.2.3"},
+		{"0.4.1", BumpMajor, "0.5.0"},
+		{"1.2.0-rc.1", BumpMinor, "1.2.0"},
+		{"1.2.0-rc.1", BumpMajor, "2.0.0"},
+		{"2.0.0-beta", BumpMajor, "2.0.0"},
+	}
+	for _, test := range tests {
+		v, _ := Parse(test.version)
+		if got := v.Inc(test.bump).String(); got != test.want {
+			t.Errorf("Want %s incremented to %s, got %s", test.version, test.want, got)
+		}
+	}
+}
+
+func TestBumpFor(t *testing.T) {
+	if got := BumpFor("docs: update README", "chore: bump deps"); got != BumpNone {
+		t.Errorf("Want no bump, got %d", got)
+	}
+	if got := BumpFor("fix: handle nil", "feat(api): add releases"); got != BumpMinor {
+		t.Errorf("Want minor bump, got %d", got)
+	}
+	if got := BumpFor("feat!: drop v3 api", "fix: handle nil"); got != BumpMajor {
+		t.Errorf("Want major bump, got %d", got)
+	}
+}
+
+// unpagedGit ignores the page parameter when listing tags,
+// like some self-hosted servers.
+type unpagedGit struct {
+	scm.GitService
+}
+
+func (s unpagedGit) ListTags(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
+	return s.GitService.ListTags(ctx, repo, scm.ListOptions{})
+}
+
+func TestListTags(t *testing.T) {
+	client, data := fake.NewDefault()
+	repo := "octocat/hello-world"
+	head := data.CommitOnBranch(repo, "master", "chore: initial commit")
+	for i := 0; i < 150; i++ {
+		data.Refs[repo]["refs/tags/v0."+strconv.Itoa(i)+".0"] = head.Sha
+	}
+	data.Refs[repo]["refs/tags/v1.0.0-rc.1"] = head.Sha
+	data.Refs[repo]["refs/tags/api/v2.0.0"] = head.Sha
+	data.Refs[repo]["refs/tags/nightly"] = head.Sha
+
+	for _, git := range []scm.GitService{client.Git, unpagedGit{client.Git}} {
+		tags, err := ListTags(context.Background(), git, repo, "v")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := len(tags), 151; got != want {
+			t.Fatalf("Want %d tags, got %d", want, got)
+		}
+		if got, want := tags[0].Name, "v1.0.0-rc.1"; got != want {
+			t.Errorf("Want highest tag %s, got %s", want, got)
+		}
+
+		latest, err := Latest(context.Background(), git, repo, "v")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := latest.Name, "v0.149.0"; got != want {
+			t.Errorf("Want latest release %s, got %s", want, got)
+		}
+	}
+
+	latest, err := Latest(context.Background(), client.Git, repo, "api/v")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := latest.Version.String(), "2.0.0"; got != want {
+		t.Errorf("Want latest api release %s, got %s", want, got)
+	}
+}
+
+func TestNext(t *testing.T) {
+	client, data := fake.NewDefault()
+	repo := "octocat/hello-world"
+	data.CommitOnBranch(repo, "master", "feat: initial commit")
+	data.Refs[repo]["refs/tags/v1.4.2"] = data.Refs[repo]["refs/heads/master"]
+	data.CommitOnBranch(repo, "master", "fix: handle nil")
+
+	next, err := Next(context.Background(), client, repo, "v", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := next.String(), "1.4.3"; got != want {
+		t.Errorf("Want next version %s, got %s", want, got)
+	}
+
+	data.CommitOnBranch(repo, "master", "feat: add releases")
+	next, err = Next(context.Background(), client, repo, "v", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := next.String(), "1.5.0"; got != want {
+		t.Errorf("Want next version %s, got %s", want, got)
+	}
+}
+
+func TestNext_NoRelease(t *testing.T) {
+	client, data := fake.NewDefault()
+	repo := "octocat/hello-world"
+	data.CommitOnBranch(repo, "master", "feat: initial commit")
+	for i := 0; i < pageSize; i++ {
+		data.CommitOnBranch(repo, "master", "chore: update dependencies")
+	}
+
+	next, err := Next(context.Background(), client, repo, "v", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := next.String(), "0.1.0"; got != want {
+		t.Errorf("Want next version %s, got %s", want, got)
+	}
+}
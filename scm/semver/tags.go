@@ -0,0 +1,182 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package semver
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/parse"
+)
+
+// pageSize is the number of tags or commits requested per
+// page.
+const pageSize = 100
+
+// Tag is a git tag named after a semantic version.
+type Tag struct {
+	Name    string
+	Sha     string
+	Version *Version
+}
+
+// ListTags returns the tags of the repository named after a
+// semantic version, sorted from the highest version. Only the
+// tags starting with the prefix, e.g. "v" or "api/v", are
+// returned, and the prefix is stripped before parsing.
+//
+// All the pages are fetched. Drivers that do not report the
+// next page are paged until a short or empty page, and drivers
+// that ignore the page parameter are detected by a page
+// without new tags.
+func ListTags(ctx context.Context, git scm.GitService, repo, prefix string) ([]*Tag, error) {
+	var tags []*Tag
+	seen := map[string]bool{}
+	opts := scm.ListOptions{Page: 1, Size: pageSize}
+	for {
+		refs, res, err := git.ListTags(ctx, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		added := 0
+		for _, ref := range refs {
+			if seen[ref.Name] {
+				continue
+			}
+			seen[ref.Name] = true
+			added++
+			if !strings.HasPrefix(ref.Name, prefix) {
+				continue
+			}
+			v, err := Parse(strings.TrimPrefix(ref.Name, prefix))
+			if err != nil {
+				continue
+			}
+			tags = append(tags, &Tag{Name: ref.Name, Sha: ref.Sha, Version: v})
+		}
+		switch {
+		case added == 0:
+		case res != nil && res.Page.Next != 0:
+			opts.Page = res.Page.Next
+			continue
+		case len(refs) >= opts.Size:
+			opts.Page++
+			continue
+		}
+		break
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].Version.Compare(tags[j].Version) > 0
+	})
+	return tags, nil
+}
+
+// Latest returns the tag of the highest release, ignoring
+// prereleases, or scm.ErrNotFound if there is none.
+func Latest(ctx context.Context, git scm.GitService, repo, prefix string) (*Tag, error) {
+	tags, err := ListTags(ctx, git, repo, prefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		if tag.Version.Prerelease == "" {
+			return tag, nil
+		}
+	}
+	return nil, scm.ErrNotFound
+}
+
+// BumpFor returns the increment required by the conventional
+// commit messages: major for a breaking change, minor for a
+// feature and patch for a fix or performance improvement.
+func BumpFor(messages ...string) Bump {
+	bump := BumpNone
+	for _, message := range messages {
+		c, ok := parse.ParseConventional(message)
+		if !ok {
+			continue
+		}
+		switch {
+		case c.Breaking:
+			bump = BumpMajor
+		case c.Type == "feat" && bump < BumpMinor:
+			bump = BumpMinor
+		case (c.Type == "fix" || c.Type == "perf") && bump < BumpPatch:
+			bump = BumpPatch
+		}
+	}
+	return bump
+}
+
+// Next returns the version following the latest release for
+// the commits reachable from ref since the release. The first
+// version is computed from 0.0.0 if the repository has no
+// release yet. The latest release is returned unchanged if no
+// commit requires a new version.
+func Next(ctx context.Context, client *scm.Client, repo, prefix, ref string) (*Version, error) {
+	if _, err := scm.ParseRepo(repo); err != nil {
+		return nil, err
+	}
+	current := &Version{}
+	var commits []*scm.Commit
+	latest, err := Latest(ctx, client.Git, repo, prefix)
+	switch err {
+	case nil:
+		current = latest.Version
+		comparison, _, err := client.Git.CompareCommits(ctx, repo, latest.Name, ref)
+		if err != nil {
+			return nil, err
+		}
+		commits = comparison.Commits
+	case scm.ErrNotFound:
+		commits, err = listCommits(ctx, client.Git, repo, ref)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	messages := make([]string, len(commits))
+	for i, commit := range commits {
+		messages[i] = commit.Message
+	}
+	return current.Inc(BumpFor(messages...)), nil
+}
+
+// listCommits returns all the commits reachable from ref. The
+// pages are fetched like the tags in ListTags.
+func listCommits(ctx context.Context, git scm.GitService, repo, ref string) ([]*scm.Commit, error) {
+	var commits []*scm.Commit
+	seen := map[string]bool{}
+	opts := scm.CommitListOptions{Ref: ref, Page: 1, Size: pageSize}
+	for {
+		page, res, err := git.ListCommits(ctx, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		added := 0
+		for _, commit := range page {
+			if seen[commit.Sha] {
+				continue
+			}
+			seen[commit.Sha] = true
+			added++
+			commits = append(commits, commit)
+		}
+		switch {
+		case added == 0:
+		case res != nil && res.Page.Next != 0:
+			opts.Page = res.Page.Next
+			continue
+		case len(page) >= opts.Size:
+			opts.Page++
+			continue
+		}
+		return commits, nil
+	}
+}
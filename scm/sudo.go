@@ -0,0 +1,46 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"net/http"
+)
+
+// SudoKey is the key to use with the context.WithValue
+// function to associate the user impersonated by the requests
+// with a context.
+type SudoKey struct{}
+
+// WithSudo returns a copy of parent in which the requests are
+// made on behalf of the user. Impersonation requires an
+// administrator token and is supported by GitLab and Gitea;
+// the requests of the other drivers fail with ErrNotSupported.
+// On GitHub, act on behalf of a user with a GitHub App
+// user-to-server token instead.
+func WithSudo(parent context.Context, user string) context.Context {
+	return context.WithValue(parent, SudoKey{}, user)
+}
+
+// SudoUser returns the user impersonated by the requests made
+// with the context, which defaults to the Sudo user of the
+// client.
+func (c *Client) SudoUser(ctx context.Context) string {
+	if user, ok := ctx.Value(SudoKey{}).(string); ok && user != "" {
+		return user
+	}
+	return c.Sudo
+}
+
+// setSudo sets the user impersonated by the request, using
+// the Sudo header accepted by GitLab and Gitea.
+func setSudo(driver Driver, req *http.Request, user string) error {
+	switch driver {
+	case DriverGitlab, DriverGitea:
+		req.Header.Set("Sudo", user)
+		return nil
+	}
+	return ErrNotSupported
+}
@@ -0,0 +1,241 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sync incrementally mirrors the pull requests, issues,
+// comments and repositories of a provider, e.g. into a database,
+// passing only the objects updated since the previous run.
+package sync
+
+import (
+	"context"
+	"fmt"
+	gosync "sync"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+)
+
+// DefaultPageSize is the page size of the list requests, unless
+// Syncer.PageSize is set.
+const DefaultPageSize = 100
+
+// Store persists the cursors of the synchronization, which are the
+// latest update times of the objects passed so far.
+type Store interface {
+	// Load returns the cursor of the key, or the zero time
+	// if the key was never synchronized.
+	Load(ctx context.Context, key string) (time.Time, error)
+
+	// Save stores the cursor of the key.
+	Save(ctx context.Context, key string, cursor time.Time) error
+}
+
+// MemoryStore is a Store keeping the cursors in memory.
+type MemoryStore struct {
+	mu      gosync.Mutex
+	cursors map[string]time.Time
+}
+
+// Load returns the cursor of the key.
+func (m *MemoryStore) Load(ctx context.Context, key string) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cursors[key], nil
+}
+
+// Save stores the cursor of the key.
+func (m *MemoryStore) Save(ctx context.Context, key string, cursor time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.cursors == nil {
+		m.cursors = map[string]time.Time{}
+	}
+	m.cursors[key] = cursor
+	return nil
+}
+
+// Syncer passes the objects updated since the cursor saved by the
+// previous run to a callback, and saves the new cursor once all
+// the objects have been passed. The cursor is left unchanged if
+// the callback fails, so the objects are passed again by the next
+// run. The callbacks must be idempotent, since the objects updated
+// within Overlap of the cursor are passed again.
+type Syncer struct {
+	Client *scm.Client
+	Store  Store
+
+	// Overlap is subtracted from the cursor to catch the
+	// objects updated while the previous run was listing,
+	// and the clock skew between the provider nodes.
+	Overlap time.Duration
+
+	// PageSize is the page size of the list requests. It
+	// defaults to DefaultPageSize.
+	PageSize int
+}
+
+// PullRequests passes the pull requests of the repository updated
+// since the previous run to fn.
+func (s *Syncer) PullRequests(ctx context.Context, repo string, fn func(*scm.PullRequest) error) error {
+	key := "pulls/" + repo
+	c, err := s.begin(ctx, key)
+	if err != nil {
+		return err
+	}
+	opts := scm.PullRequestListOptions{Page: 1, Size: s.pageSize(), Open: true, Closed: true}
+	if !c.since.IsZero() {
+		opts.UpdatedAfter = &c.since
+	}
+	for {
+		prs, res, err := s.Client.PullRequests.List(ctx, repo, opts)
+		if err != nil {
+			return err
+		}
+		for _, pr := range prs {
+			if !c.include(pr.Updated) {
+				continue
+			}
+			if err := fn(pr); err != nil {
+				return err
+			}
+		}
+		if res == nil || res.Page.Next == 0 {
+			return s.commit(ctx, key, c)
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
+// Issues passes the issues of the repository updated since the
+// previous run to fn.
+func (s *Syncer) Issues(ctx context.Context, repo string, fn func(*scm.Issue) error) error {
+	key := "issues/" + repo
+	c, err := s.begin(ctx, key)
+	if err != nil {
+		return err
+	}
+	opts := scm.IssueListOptions{Page: 1, Size: s.pageSize(), Open: true, Closed: true, UpdatedSince: c.since}
+	for {
+		issues, res, err := s.Client.Issues.List(ctx, repo, opts)
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			if !c.include(issue.Updated) {
+				continue
+			}
+			if err := fn(issue); err != nil {
+				return err
+			}
+		}
+		if res == nil || res.Page.Next == 0 {
+			return s.commit(ctx, key, c)
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
+// Comments passes the comments of the issue or pull request
+// updated since the previous run to fn. The lister is the
+// IssueService or the PullRequestService of the client.
+func (s *Syncer) Comments(ctx context.Context, lister scm.CommentLister, repo string, number int, fn func(*scm.Comment) error) error {
+	key := fmt.Sprintf("comments/%s/%d", repo, number)
+	c, err := s.begin(ctx, key)
+	if err != nil {
+		return err
+	}
+	comments, _, err := scm.ListCommentsSince(ctx, lister, repo, number, c.since)
+	if err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		updated := comment.Updated
+		if updated.IsZero() {
+			updated = comment.Created
+		}
+		if !c.include(updated) {
+			continue
+		}
+		if err := fn(comment); err != nil {
+			return err
+		}
+	}
+	return s.commit(ctx, key, c)
+}
+
+// Repositories passes the repositories of the authenticated user
+// updated since the previous run to fn. The providers cannot
+// filter the repositories by update time, so all the repositories
+// are listed.
+func (s *Syncer) Repositories(ctx context.Context, fn func(*scm.Repository) error) error {
+	key := "repos"
+	c, err := s.begin(ctx, key)
+	if err != nil {
+		return err
+	}
+	opts := scm.ListOptions{Page: 1, Size: s.pageSize()}
+	for {
+		repos, res, err := s.Client.Repositories.List(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, repo := range repos {
+			if !c.include(repo.Updated) {
+				continue
+			}
+			if err := fn(repo); err != nil {
+				return err
+			}
+		}
+		if res == nil || res.Page.Next == 0 {
+			return s.commit(ctx, key, c)
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
+// cursor tracks the latest update time of the objects passed.
+type cursor struct {
+	saved  time.Time
+	since  time.Time
+	latest time.Time
+}
+
+// include reports whether the object updated at the time is
+// passed, and advances the cursor.
+func (c *cursor) include(updated time.Time) bool {
+	if !c.since.IsZero() && updated.Before(c.since) {
+		return false
+	}
+	if updated.After(c.latest) {
+		c.latest = updated
+	}
+	return true
+}
+
+func (s *Syncer) begin(ctx context.Context, key string) (*cursor, error) {
+	saved, err := s.Store.Load(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	c := &cursor{saved: saved, latest: saved}
+	if !saved.IsZero() {
+		c.since = saved.Add(-s.Overlap)
+	}
+	return c, nil
+}
+
+func (s *Syncer) commit(ctx context.Context, key string, c *cursor) error {
+	if !c.latest.After(c.saved) {
+		return nil
+	}
+	return s.Store.Save(ctx, key, c.latest)
+}
+
+func (s *Syncer) pageSize() int {
+	if s.PageSize > 0 {
+		return s.PageSize
+	}
+	return DefaultPageSize
+}
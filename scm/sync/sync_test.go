@@ -0,0 +1,63 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/slimm609/go-scm/scm"
+	"github.com/slimm609/go-scm/scm/driver/fake"
+)
+
+func TestSyncPullRequests(t *testing.T) {
+	at := func(minute int) time.Time {
+		return time.Date(2020, 1, 1, 0, minute, 0, 0, time.UTC)
+	}
+	client, data := fake.NewDefault()
+	data.PullRequests[1] = &scm.PullRequest{Number: 1, Updated: at(1)}
+	data.PullRequests[2] = &scm.PullRequest{Number: 2, Updated: at(5)}
+
+	store := &MemoryStore{}
+	s := &Syncer{Client: client, Store: store}
+	ctx := context.Background()
+	sync := func() []int {
+		var numbers []int
+		err := s.PullRequests(ctx, "octocat/hello-world", func(pr *scm.PullRequest) error {
+			numbers = append(numbers, pr.Number)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return numbers
+	}
+
+	if got := sync(); len(got) != 2 {
+		t.Errorf("Want all the pull requests passed by the first run, got %v", got)
+	}
+	if got, _ := store.Load(ctx, "pulls/octocat/hello-world"); !got.Equal(at(5)) {
+		t.Errorf("Want cursor %s, got %s", at(5), got)
+	}
+
+	data.PullRequests[1].Updated = at(7)
+	if got := sync(); len(got) != 2 || got[0] != 1 {
+		t.Errorf("Want the updated pull request and the one at the cursor, got %v", got)
+	}
+
+	boom := errors.New("boom")
+	data.PullRequests[2].Updated = at(9)
+	err := s.PullRequests(ctx, "octocat/hello-world", func(pr *scm.PullRequest) error {
+		return boom
+	})
+	if err != boom {
+		t.Errorf("Want the callback error, got %v", err)
+	}
+	if got, _ := store.Load(ctx, "pulls/octocat/hello-world"); !got.Equal(at(7)) {
+		t.Errorf("Want the cursor unchanged by a failed run, got %s", got)
+	}
+}
@@ -23,6 +23,12 @@ type (
 		Token(context.Context) (*Token, error)
 	}
 
+	// TokenSourceFunc is a function implementing the
+	// TokenSource interface, e.g. reading the token from
+	// Vault or a Kubernetes secret, so a rotated token is
+	// used without recreating the client.
+	TokenSourceFunc func(context.Context) (*Token, error)
+
 	// TokenKey is the key to use with the context.WithValue
 	// function to associate an Token value with a context.
 	TokenKey struct{}
@@ -32,3 +38,8 @@ type (
 func WithContext(parent context.Context, token *Token) context.Context {
 	return context.WithValue(parent, TokenKey{}, token)
 }
+
+// Token returns the token returned by the function.
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
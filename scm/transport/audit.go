@@ -0,0 +1,149 @@
+// Copyright 2018 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditEntry describes an outbound API call captured by the
+// Audit transport. Credentials are redacted.
+type AuditEntry struct {
+	Time     time.Time     `json:"time"`
+	Method   string        `json:"method"`
+	URL      string        `json:"url"`
+	Header   http.Header   `json:"header,omitempty"`
+	Status   int           `json:"status,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Reason   string        `json:"reason,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// AuditSink receives the entries captured by the Audit
+// transport. Implementations must be safe for concurrent use.
+type AuditSink interface {
+	Record(entry *AuditEntry)
+}
+
+// AuditSinkFunc adapts a function to the AuditSink interface.
+type AuditSinkFunc func(entry *AuditEntry)
+
+// Record calls f(entry).
+func (f AuditSinkFunc) Record(entry *AuditEntry) { f(entry) }
+
+// AuditLog returns an AuditSink writing the entries to w as
+// JSON, one entry per line.
+func AuditLog(w io.Writer) AuditSink {
+	return &auditLog{enc: json.NewEncoder(w)}
+}
+
+type auditLog struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func (l *auditLog) Record(entry *AuditEntry) {
+	l.mu.Lock()
+	l.enc.Encode(entry)
+	l.mu.Unlock()
+}
+
+type auditReasonKey struct{}
+
+// WithAuditReason returns a copy of the context carrying the
+// reason recorded by the Audit transport for requests made
+// with it.
+func WithAuditReason(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, auditReasonKey{}, reason)
+}
+
+// AuditReason returns the reason stored in the context, or
+// an empty string.
+func AuditReason(ctx context.Context) string {
+	reason, _ := ctx.Value(auditReasonKey{}).(string)
+	return reason
+}
+
+// Audit is an http.RoundTripper that records every request
+// sent through it to a sink, for compliance logging. The
+// credential headers and query parameters are redacted, as
+// are the configured secrets.
+type Audit struct {
+	Base http.RoundTripper
+
+	// Sink receives the audit entries.
+	Sink AuditSink
+
+	// Secrets lists additional values, such as tokens that
+	// are embedded in the request path, that are redacted
+	// from the recorded URL.
+	Secrets []string
+}
+
+// RoundTrip sends the request to the base transport and
+// records the transaction.
+func (t *Audit) RoundTrip(r *http.Request) (*http.Response, error) {
+	start := time.Now()
+	res, err := t.base().RoundTrip(r)
+
+	entry := &AuditEntry{
+		Time:     start,
+		Method:   r.Method,
+		URL:      t.sanitize(sanitizeURL(r.URL)),
+		Header:   redactHeader(r.Header),
+		Duration: time.Since(start),
+		Reason:   AuditReason(r.Context()),
+	}
+	if err != nil {
+		entry.Error = t.sanitize(err.Error())
+	} else {
+		entry.Status = res.StatusCode
+	}
+	t.Sink.Record(entry)
+	return res, err
+}
+
+// sanitize redacts the configured secrets from s.
+func (t *Audit) sanitize(s string) string {
+	for _, secret := range t.Secrets {
+		if secret != "" {
+			s = strings.Replace(s, secret, redacted, -1)
+		}
+	}
+	return s
+}
+
+// base returns the base transport. If no base transport
+// is configured, the default transport is returned.
+func (t *Audit) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// redactHeader returns a copy of the header with the values
+// of the credential and impersonation headers redacted.
+func redactHeader(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make(http.Header, len(h))
+	for k, s := range h {
+		out[k] = append([]string(nil), s...)
+	}
+	for _, key := range sensitiveHeaders {
+		if out.Get(key) != "" {
+			out.Set(key, redacted)
+		}
+	}
+	return out
+}
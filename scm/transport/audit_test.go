@@ -0,0 +1,124 @@
+// Copyright 2018 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/h2non/gock"
+)
+
+func TestAudit(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gitea.io").
+		Post("/api/v1/repos/go-gitea/gitea/statuses/6dcb09b").
+		Reply(201)
+
+	var entries []*AuditEntry
+	client := &http.Client{
+		Transport: &BearerToken{
+			Token: "mF_9.B5f-4.1JqM",
+			Base: &Audit{
+				Sink: AuditSinkFunc(func(entry *AuditEntry) {
+					entries = append(entries, entry)
+				}),
+				Secrets: []string{"s3cr3t"},
+			},
+		},
+	}
+
+	req, _ := http.NewRequest("POST", "https://try.gitea.io/api/v1/repos/go-gitea/gitea/statuses/6dcb09b?token=s3cr3t", nil)
+	req = req.WithContext(WithAuditReason(req.Context(), "release 1.0"))
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if len(entries) != 1 {
+		t.Fatalf("Want 1 audit entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if got, want := entry.Method, "POST"; got != want {
+		t.Errorf("Want method %s, got %s", want, got)
+	}
+	if got, want := entry.URL, "https://try.gitea.io/api/v1/repos/go-gitea/gitea/statuses/6dcb09b?token=REDACTED"; got != want {
+		t.Errorf("Want url %s, got %s", want, got)
+	}
+	if got, want := entry.Header.Get("Authorization"), "REDACTED"; got != want {
+		t.Errorf("Want authorization header %s, got %s", want, got)
+	}
+	if got, want := entry.Status, 201; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+	if got, want := entry.Reason, "release 1.0"; got != want {
+		t.Errorf("Want reason %q, got %q", want, got)
+	}
+	if strings.Contains(entry.URL+entry.Header.Get("Authorization"), "s3cr3t") {
+		t.Errorf("Expect secrets redacted")
+	}
+}
+
+func TestAudit_GitLabHeaders(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/user").
+		Reply(200)
+
+	var entries []*AuditEntry
+	client := &http.Client{
+		Transport: &Audit{
+			Sink: AuditSinkFunc(func(entry *AuditEntry) {
+				entries = append(entries, entry)
+			}),
+		},
+	}
+
+	req, _ := http.NewRequest("GET", "https://gitlab.com/api/v4/user", nil)
+	req.Header.Set("Job-Token", "mF_9.B5f-4.1JqM")
+	req.Header.Set("Sudo", "jcitizen")
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if len(entries) != 1 {
+		t.Fatalf("Want 1 audit entry, got %d", len(entries))
+	}
+	for _, key := range []string{"Job-Token", "Sudo"} {
+		if got, want := entries[0].Header.Get(key), "REDACTED"; got != want {
+			t.Errorf("Want %s header %s, got %s", key, want, got)
+		}
+	}
+	if got, want := req.Header.Get("Job-Token"), "mF_9.B5f-4.1JqM"; got != want {
+		t.Errorf("Want request header %s unchanged, got %s", want, got)
+	}
+}
+
+func TestAuditLog(t *testing.T) {
+	buf := new(bytes.Buffer)
+	sink := AuditLog(buf)
+	sink.Record(&AuditEntry{Method: "GET", URL: "https://api.github.com/user", Status: 200})
+	sink.Record(&AuditEntry{Method: "DELETE", URL: "https://api.github.com/repos/octocat/hello-world", Status: 204})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Want 2 lines, got %d", len(lines))
+	}
+	entry := new(AuditEntry)
+	if err := json.Unmarshal([]byte(lines[1]), entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Method != "DELETE" || entry.Status != 204 {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2018 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport
+
+import (
+	"crypto/tls"
+	"os"
+	"sync"
+	"time"
+)
+
+// ClientCertificate loads the TLS client certificate presented
+// to servers requiring mutual TLS from PEM encoded files. The
+// files are loaded again when they are modified, so a rotated
+// certificate is presented by the next connections without
+// restarting the process. Set GetClientCertificate as the
+// function of the same name of a tls.Config.
+type ClientCertificate struct {
+	CertFile string
+	KeyFile  string
+
+	mu       sync.Mutex
+	cert     *tls.Certificate
+	modified time.Time
+}
+
+// NewClientCertificate returns the client certificate of the
+// files, failing if the files cannot be loaded.
+func NewClientCertificate(certFile, keyFile string) (*ClientCertificate, error) {
+	c := &ClientCertificate{CertFile: certFile, KeyFile: keyFile}
+	if _, err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetClientCertificate returns the certificate, loading the
+// files again if they were modified since they were loaded.
+// If the modified files cannot be loaded, e.g. because the
+// certificate was written but not the key yet, the previous
+// certificate is returned.
+func (c *ClientCertificate) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return c.load()
+}
+
+func (c *ClientCertificate) load() (*tls.Certificate, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	modified, err := c.modTime()
+	if err == nil && c.cert != nil && !modified.After(c.modified) {
+		return c.cert, nil
+	}
+	cert, loadErr := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if loadErr != nil {
+		if c.cert != nil {
+			return c.cert, nil
+		}
+		return nil, loadErr
+	}
+	c.cert = &cert
+	c.modified = modified
+	return c.cert, nil
+}
+
+// modTime returns the latest modification time of the files.
+func (c *ClientCertificate) modTime() (time.Time, error) {
+	var latest time.Time
+	for _, name := range []string{c.CertFile, c.KeyFile} {
+		info, err := os.Stat(name)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
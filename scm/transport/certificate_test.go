@@ -0,0 +1,98 @@
+// Copyright 2018 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestClientCertificate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-scm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+
+	writeCertificate(t, certFile, keyFile, "first")
+	cert, err := NewClientCertificate(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := commonName(t, cert), "first"; got != want {
+		t.Errorf("Want certificate %s, got %s", want, got)
+	}
+
+	// the rotated certificate is loaded
+	writeCertificate(t, certFile, keyFile, "second")
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(certFile, later, later)
+	if got, want := commonName(t, cert), "second"; got != want {
+		t.Errorf("Want certificate %s, got %s", want, got)
+	}
+
+	// the previous certificate is kept if the files are invalid
+	ioutil.WriteFile(keyFile, []byte("invalid"), 0600)
+	later = later.Add(time.Minute)
+	os.Chtimes(keyFile, later, later)
+	if got, want := commonName(t, cert), "second"; got != want {
+		t.Errorf("Want certificate %s, got %s", want, got)
+	}
+}
+
+func TestClientCertificate_Missing(t *testing.T) {
+	_, err := NewClientCertificate("testdata/missing.crt", "testdata/missing.key")
+	if err == nil {
+		t.Errorf("Want an error loading missing files")
+	}
+}
+
+func commonName(t *testing.T, c *ClientCertificate) string {
+	cert, err := c.GetClientCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed.Subject.CommonName
+}
+
+func writeCertificate(t *testing.T, certFile, keyFile, name string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/slimm609/go-scm/scm/internal/flight"
+)
+
+// Dedup is an http.RoundTripper that collapses concurrent
+// identical GET and HEAD requests into a single request, e.g.
+// the lookups of the same pull request by the goroutines handling
+// a burst of webhooks. Requests are identical if they have the
+// same URL and headers. The waiting requests get a copy of the
+// response of the request in flight. A request whose context is
+// canceled fails on its own, and the request in flight is only
+// canceled once all the identical requests are.
+type Dedup struct {
+	Base http.RoundTripper
+
+	group flight.Group
+}
+
+type dedupResponse struct {
+	res  *http.Response
+	body []byte
+}
+
+// RoundTrip sends the request, or waits for the identical
+// request in flight.
+func (t *Dedup) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return t.base().RoundTrip(r)
+	}
+	key := new(bytes.Buffer)
+	key.WriteString(r.Method + " " + r.URL.String() + "\n")
+	r.Header.Write(key)
+
+	v, err, _ := t.group.Do(r.Context(), key.String(), func(ctx context.Context) (interface{}, error) {
+		res, err := t.base().RoundTrip(r.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		body, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		return &dedupResponse{res: res, body: body}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	shared := v.(*dedupResponse)
+	res := new(http.Response)
+	*res = *shared.res
+	res.Header = shared.res.Header.Clone()
+	res.Body = ioutil.NopCloser(bytes.NewReader(shared.body))
+	res.Request = r
+	return res, nil
+}
+
+// base returns the base transport. If no base transport
+// is configured, the default transport is returned.
+func (t *Dedup) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
@@ -0,0 +1,51 @@
+// Copyright 2018 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport
+
+import "net/http"
+
+// Headers is an http.RoundTripper that sets headers on the
+// requests, such as the assertions required by an identity-aware
+// proxy in front of a self-hosted git server, e.g. the
+// Cf-Access-Jwt-Assertion header of Cloudflare Access. The
+// headers replace the headers of the same name of the request.
+type Headers struct {
+	Base http.RoundTripper
+
+	// Static are the headers set on every request.
+	Static http.Header
+
+	// Func optionally returns the headers of a request, e.g.
+	// a short-lived token read from the context of the
+	// request. An error fails the request.
+	Func func(*http.Request) (http.Header, error)
+}
+
+// RoundTrip sets the headers of the request.
+func (t *Headers) RoundTrip(r *http.Request) (*http.Response, error) {
+	r2 := cloneRequest(r)
+	for k, v := range t.Static {
+		r2.Header[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+	}
+	if t.Func != nil {
+		header, err := t.Func(r)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range header {
+			r2.Header[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+		}
+	}
+	return t.base().RoundTrip(r2)
+}
+
+// base returns the base transport. If no base transport
+// is configured, the default transport is returned.
+func (t *Headers) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
@@ -0,0 +1,57 @@
+// Copyright 2018 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+)
+
+func TestHeaders(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://git.example.com").
+		Get("/api/v4/user").
+		MatchHeader("Cf-Access-Jwt-Assertion", "eyJhbGciOiJSUzI1NiJ9").
+		MatchHeader("X-Auth-Request-Email", "octocat@github.com").
+		Reply(200)
+
+	client := &http.Client{
+		Transport: &Headers{
+			Static: http.Header{"cf-access-jwt-assertion": {"eyJhbGciOiJSUzI1NiJ9"}},
+			Func: func(r *http.Request) (http.Header, error) {
+				return http.Header{"X-Auth-Request-Email": {"octocat@github.com"}}, nil
+			},
+		},
+	}
+
+	res, err := client.Get("https://git.example.com/api/v4/user")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer res.Body.Close()
+	if got, want := res.StatusCode, 200; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+}
+
+func TestHeaders_Error(t *testing.T) {
+	failure := errors.New("token expired")
+	client := &http.Client{
+		Transport: &Headers{
+			Func: func(r *http.Request) (http.Header, error) {
+				return nil, failure
+			},
+		},
+	}
+	_, err := client.Get("https://git.example.com/api/v4/user")
+	if !errors.Is(err, failure) {
+		t.Errorf("Want the header error, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"net/http"
+)
+
+// NegotiateTokenSource returns the SPNEGO tokens of a Kerberos
+// client, e.g. the client of the github.com/jcmturner/gokrb5
+// spnego package logged in with a keytab or credential cache.
+type NegotiateTokenSource interface {
+	// Token returns the token initiating the security context
+	// with the service principal, e.g. HTTP/stash.example.com.
+	Token(ctx context.Context, spn string) ([]byte, error)
+}
+
+// NegotiateTokenFunc is a function implementing the
+// NegotiateTokenSource interface.
+type NegotiateTokenFunc func(ctx context.Context, spn string) ([]byte, error)
+
+// Token returns the token of the service principal.
+func (f NegotiateTokenFunc) Token(ctx context.Context, spn string) ([]byte, error) {
+	return f(ctx, spn)
+}
+
+// Negotiate is an http.RoundTripper that makes HTTP requests,
+// wrapping a base RoundTripper and adding an Authorization
+// header with a SPNEGO token, as used by Kerberos single sign-on
+// in Active Directory environments.
+type Negotiate struct {
+	Base http.RoundTripper
+
+	Source NegotiateTokenSource
+
+	// SPN is the service principal name of the server. It
+	// defaults to HTTP/ followed by the host of the request.
+	SPN string
+}
+
+// RoundTrip adds the Authorization header to the request.
+func (t *Negotiate) RoundTrip(r *http.Request) (*http.Response, error) {
+	// Do not overwrite the authorization header if exists.
+	if r.Header.Get("Authorization") != "" {
+		return t.base().RoundTrip(r)
+	}
+	spn := t.SPN
+	if spn == "" {
+		host := r.URL.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		spn = "HTTP/" + host
+	}
+	token, err := t.Source.Token(r.Context(), spn)
+	if err != nil {
+		return nil, err
+	}
+	r2 := cloneRequest(r)
+	r2.Header.Set("Authorization", "Negotiate "+base64.StdEncoding.EncodeToString(token))
+	return t.base().RoundTrip(r2)
+}
+
+// base returns the base transport. If no base transport
+// is configured, the default transport is returned.
+func (t *Negotiate) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
@@ -0,0 +1,42 @@
+// Copyright 2018 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+)
+
+func TestNegotiate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://stash.example.com:7990").
+		Get("/rest/api/1.0/users").
+		MatchHeader("Authorization", "Negotiate dG9rZW4=").
+		Reply(200)
+
+	var got string
+	client := &http.Client{
+		Transport: &Negotiate{
+			Source: NegotiateTokenFunc(func(ctx context.Context, spn string) ([]byte, error) {
+				got = spn
+				return []byte("token"), nil
+			}),
+		},
+	}
+
+	res, err := client.Get("https://stash.example.com:7990/rest/api/1.0/users")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer res.Body.Close()
+	if want := "HTTP/stash.example.com"; got != want {
+		t.Errorf("Want service principal %s, got %s", want, got)
+	}
+}
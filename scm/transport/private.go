@@ -4,7 +4,11 @@
 
 package transport
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/slimm609/go-scm/scm"
+)
 
 // PrivateToken is an http.RoundTripper that makes HTTP
 // requests, wrapping a base RoundTripper and adding an
@@ -13,6 +17,11 @@ type PrivateToken struct {
 	Base http.RoundTripper
 
 	Token string // GitLab personal token
+
+	// Source optionally returns the token of every request,
+	// so a rotated token is used without recreating the
+	// client. It overrides Token.
+	Source scm.TokenSource
 }
 
 // RoundTrip adds the PrivateToken header to the request.
@@ -21,8 +30,19 @@ func (t *PrivateToken) RoundTrip(r *http.Request) (*http.Response, error) {
 	if r.Header.Get("Private-Token") != "" {
 		return t.base().RoundTrip(r)
 	}
+	token := t.Token
+	if t.Source != nil {
+		tok, err := t.Source.Token(r.Context())
+		if err != nil {
+			return nil, err
+		}
+		if tok == nil {
+			return t.base().RoundTrip(r)
+		}
+		token = tok.Token
+	}
 	r2 := cloneRequest(r)
-	r2.Header.Set("Private-Token", t.Token)
+	r2.Header.Set("Private-Token", token)
 	return t.base().RoundTrip(r2)
 }
 
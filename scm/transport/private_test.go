@@ -5,10 +5,12 @@
 package transport
 
 import (
+	"context"
 	"net/http"
 	"testing"
 
 	"github.com/h2non/gock"
+	"github.com/slimm609/go-scm/scm"
 )
 
 func TestPrivateToken(t *testing.T) {
@@ -60,3 +62,31 @@ func TestPrivateToken_DontOverwriteHeader(t *testing.T) {
 	}
 	defer res.Body.Close()
 }
+
+func TestPrivateToken_Source(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/user").
+		MatchHeader("Private-Token", "rotated").
+		Reply(200)
+
+	client := &http.Client{
+		Transport: &PrivateToken{
+			Token: "expired",
+			Source: scm.TokenSourceFunc(func(ctx context.Context) (*scm.Token, error) {
+				return &scm.Token{Token: "rotated"}, nil
+			}),
+		},
+	}
+
+	res, err := client.Get("https://gitlab.com/api/v4/user")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer res.Body.Close()
+	if got, want := res.StatusCode, 200; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+}
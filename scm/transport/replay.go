@@ -0,0 +1,301 @@
+// Copyright 2018 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ReplayMode defines how the Replay transport handles requests.
+type ReplayMode int
+
+// Replay modes.
+const (
+	// ReplayModeReplay serves responses from recorded
+	// fixtures and never reaches the network.
+	ReplayModeReplay ReplayMode = iota
+
+	// ReplayModeRecord sends requests to the base transport
+	// and records the responses as fixtures.
+	ReplayModeRecord
+)
+
+// EnvRecord is the environment variable that switches the
+// Replay transport into record mode when set to a non-empty
+// value. See ReplayModeFromEnvironment.
+const EnvRecord = "SCM_RECORD"
+
+// redacted replaces sensitive values in recorded fixtures.
+const redacted = "REDACTED"
+
+// sensitiveHeaders are the headers that carry credentials, or
+// the user impersonated with them, and are never written to a
+// fixture.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Job-Token",
+	"Private-Token",
+	"Proxy-Authorization",
+	"Set-Cookie",
+	"Sudo",
+	"X-Api-Key",
+}
+
+// sensitiveParams are the query parameters that carry
+// credentials and are redacted before a request is matched
+// or recorded.
+var sensitiveParams = []string{
+	"access_token",
+	"client_secret",
+	"private_token",
+	"token",
+}
+
+// ReplayModeFromEnvironment returns ReplayModeRecord if the
+// SCM_RECORD environment variable is set, and ReplayModeReplay
+// otherwise.
+func ReplayModeFromEnvironment() ReplayMode {
+	if os.Getenv(EnvRecord) != "" {
+		return ReplayModeRecord
+	}
+	return ReplayModeReplay
+}
+
+// Replay is an http.RoundTripper that records real API
+// responses to fixture files and replays them in tests.
+// Credentials are stripped from recorded fixtures.
+type Replay struct {
+	Base http.RoundTripper
+
+	// Dir is the directory holding the fixture files,
+	// typically testdata/replay.
+	Dir string
+
+	// Mode selects whether responses are recorded or
+	// replayed.
+	Mode ReplayMode
+
+	// Secrets lists additional values, such as the token
+	// used for recording, that are redacted from recorded
+	// response bodies.
+	Secrets []string
+}
+
+// fixture is the on-disk format of a recorded transaction.
+type fixture struct {
+	Request struct {
+		Method string `json:"method"`
+		URL    string `json:"url"`
+	} `json:"request"`
+	Response struct {
+		Status int         `json:"status"`
+		Header http.Header `json:"header"`
+		Body   string      `json:"body"`
+	} `json:"response"`
+}
+
+// RoundTrip records or replays the response for the request.
+func (t *Replay) RoundTrip(r *http.Request) (*http.Response, error) {
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+	path := t.fixture(r, body)
+	if t.Mode == ReplayModeRecord {
+		return t.record(r, path)
+	}
+	return t.replay(r, path)
+}
+
+// replay serves the response from the recorded fixture.
+func (t *Replay) replay(r *http.Request, path string) (*http.Response, error) {
+	raw, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("transport: no recorded fixture for %s %s: run with %s=1 to record it",
+			r.Method, sanitizeURL(r.URL), EnvRecord)
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := new(fixture)
+	if err := json.Unmarshal(raw, out); err != nil {
+		return nil, fmt.Errorf("transport: invalid fixture %s: %s", path, err)
+	}
+	body := []byte(out.Response.Body)
+	header := out.Response.Header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", out.Response.Status, http.StatusText(out.Response.Status)),
+		StatusCode:    out.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       r,
+	}, nil
+}
+
+// record sends the request to the base transport and writes
+// the sanitized response to the fixture file.
+func (t *Replay) record(r *http.Request, path string) (*http.Response, error) {
+	res, err := t.base().RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	out := new(fixture)
+	out.Request.Method = r.Method
+	out.Request.URL = sanitizeURL(r.URL)
+	out.Response.Status = res.StatusCode
+	out.Response.Header = sanitizeHeader(res.Header)
+	out.Response.Body = string(t.sanitizeBody(r, body))
+
+	raw, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(path, raw, 0644); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Fixture returns the path of the fixture file for the
+// request. The name is derived from the method and path, with
+// a hash suffix when the request has a query string or a body,
+// so requests to the same endpoint with different payloads,
+// such as GraphQL queries, are recorded separately.
+func (t *Replay) Fixture(r *http.Request) string {
+	body, _ := readBody(r)
+	return t.fixture(r, body)
+}
+
+func (t *Replay) fixture(r *http.Request, body []byte) string {
+	name := strings.Trim(r.URL.Path, "/")
+	name = strings.NewReplacer("/", "_", "%", "_", ":", "_").Replace(name)
+	name = r.Method + "_" + name
+	query := sanitizeQuery(r.URL.Query()).Encode()
+	if query != "" || len(body) != 0 {
+		key := []byte(query)
+		if len(body) != 0 {
+			// the query alone is hashed without a body, so
+			// the fixtures recorded before bodies were hashed
+			// still match.
+			key = append(append(key, 0), body...)
+		}
+		sum := sha1.Sum(key)
+		name = name + "_" + hex.EncodeToString(sum[:])[:8]
+	}
+	return filepath.Join(t.Dir, name+".json")
+}
+
+// readBody returns the body of the request, and replaces it
+// so the request can still be sent.
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+// sanitizeBody redacts credentials sent with the request, and
+// any configured secrets, from the response body.
+func (t *Replay) sanitizeBody(r *http.Request, body []byte) []byte {
+	var secrets []string
+	for _, key := range sensitiveHeaders {
+		if key == "Sudo" {
+			// the impersonated user is not a secret, and
+			// redacting its login would break the fixtures.
+			continue
+		}
+		for _, value := range r.Header[http.CanonicalHeaderKey(key)] {
+			fields := strings.Fields(value)
+			if len(fields) != 0 {
+				secrets = append(secrets, fields[len(fields)-1])
+			}
+		}
+	}
+	secrets = append(secrets, t.Secrets...)
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		body = bytes.Replace(body, []byte(secret), []byte(redacted), -1)
+	}
+	return body
+}
+
+// base returns the base transport. If no base transport
+// is configured, the default transport is returned.
+func (t *Replay) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// sanitizeHeader returns a copy of the header without the
+// credential headers.
+func sanitizeHeader(h http.Header) http.Header {
+	out := make(http.Header, len(h))
+	for k, s := range h {
+		out[k] = append([]string(nil), s...)
+	}
+	for _, key := range sensitiveHeaders {
+		out.Del(key)
+	}
+	return out
+}
+
+// sanitizeQuery returns a copy of the query with credential
+// parameters redacted.
+func sanitizeQuery(q url.Values) url.Values {
+	out := make(url.Values, len(q))
+	for k, s := range q {
+		out[k] = append([]string(nil), s...)
+	}
+	for _, key := range sensitiveParams {
+		if _, ok := out[key]; ok {
+			out.Set(key, redacted)
+		}
+	}
+	return out
+}
+
+// sanitizeURL returns the URL with credential parameters and
+// user information redacted.
+func sanitizeURL(u *url.URL) string {
+	u2 := *u
+	u2.User = nil
+	u2.RawQuery = sanitizeQuery(u.Query()).Encode()
+	return u2.String()
+}
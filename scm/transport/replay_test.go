@@ -0,0 +1,173 @@
+// Copyright 2018 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transport
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/h2non/gock"
+)
+
+func TestReplay(t *testing.T) {
+	defer gock.Off()
+
+	dir, err := ioutil.TempDir("", "replay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	gock.New("https://api.github.com").
+		Get("/user").
+		Reply(200).
+		Type("application/json").
+		SetHeader("Set-Cookie", "session=secret").
+		SetHeader("X-RateLimit-Limit", "5000").
+		BodyString(`{"login":"octocat","token":"mF_9.B5f-4.1JqM"}`)
+
+	recorder := &http.Client{
+		Transport: &BearerToken{
+			Token: "mF_9.B5f-4.1JqM",
+			Base: &Replay{
+				Dir:  dir,
+				Mode: ReplayModeRecord,
+			},
+		},
+	}
+	res, err := recorder.Get("https://api.github.com/user?access_token=mF_9.B5f-4.1JqM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	gock.Off()
+
+	player := &http.Client{
+		Transport: &Replay{Dir: dir},
+	}
+	res, err = player.Get("https://api.github.com/user?access_token=mF_9.B5f-4.1JqM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if got, want := res.StatusCode, 200; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+	if got, want := res.Header.Get("X-RateLimit-Limit"), "5000"; got != want {
+		t.Errorf("Want header %q, got %q", want, got)
+	}
+	if got := res.Header.Get("Set-Cookie"); got != "" {
+		t.Errorf("Want Set-Cookie header removed, got %q", got)
+	}
+	body, _ := ioutil.ReadAll(res.Body)
+	if got, want := string(body), `{"login":"octocat","token":"REDACTED"}`; got != want {
+		t.Errorf("Want body %s, got %s", want, got)
+	}
+
+	files, _ := ioutil.ReadDir(dir)
+	for _, file := range files {
+		raw, _ := ioutil.ReadFile(dir + "/" + file.Name())
+		if strings.Contains(string(raw), "mF_9.B5f-4.1JqM") {
+			t.Errorf("Want token redacted from fixture %s", file.Name())
+		}
+	}
+}
+
+func TestReplay_GitLabHeaders(t *testing.T) {
+	defer gock.Off()
+
+	dir, err := ioutil.TempDir("", "replay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/user").
+		Reply(200).
+		Type("application/json").
+		SetHeader("Sudo", "jcitizen").
+		BodyString(`{"username":"jcitizen","token":"mF_9.B5f-4.1JqM"}`)
+
+	recorder := &http.Client{
+		Transport: &Replay{Dir: dir, Mode: ReplayModeRecord},
+	}
+	req, _ := http.NewRequest("GET", "https://gitlab.com/api/v4/user", nil)
+	req.Header.Set("Job-Token", "mF_9.B5f-4.1JqM")
+	req.Header.Set("Sudo", "jcitizen")
+	res, err := recorder.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	gock.Off()
+
+	player := &http.Client{
+		Transport: &Replay{Dir: dir},
+	}
+	res, err = player.Get("https://gitlab.com/api/v4/user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if got := res.Header.Get("Sudo"); got != "" {
+		t.Errorf("Want Sudo header removed, got %q", got)
+	}
+	body, _ := ioutil.ReadAll(res.Body)
+	if got, want := string(body), `{"username":"jcitizen","token":"REDACTED"}`; got != want {
+		t.Errorf("Want body %s, got %s", want, got)
+	}
+}
+
+func TestReplay_Missing(t *testing.T) {
+	client := &http.Client{
+		Transport: &Replay{Dir: "testdata/does-not-exist"},
+	}
+	_, err := client.Get("https://api.github.com/user")
+	if err == nil {
+		t.Errorf("Expect error for a missing fixture")
+	}
+}
+
+func TestReplay_Fixture(t *testing.T) {
+	replay := &Replay{Dir: "testdata"}
+	a, _ := http.NewRequest("GET", "https://api.github.com/repos/octocat/hello-world/branches?page=1&access_token=a", nil)
+	b, _ := http.NewRequest("GET", "https://api.github.com/repos/octocat/hello-world/branches?page=1&access_token=b", nil)
+	c, _ := http.NewRequest("GET", "https://api.github.com/repos/octocat/hello-world/branches?page=2", nil)
+
+	if got, want := replay.Fixture(a), replay.Fixture(b); got != want {
+		t.Errorf("Want fixture independent of credentials, got %q and %q", got, want)
+	}
+	if replay.Fixture(a) == replay.Fixture(c) {
+		t.Errorf("Want distinct fixtures for distinct queries")
+	}
+	if got, want := replay.Fixture(c), "testdata/GET_repos_octocat_hello-world_branches_"; !strings.HasPrefix(got, want) {
+		t.Errorf("Want fixture prefix %q, got %q", want, got)
+	}
+}
+
+func TestReplay_FixtureBody(t *testing.T) {
+	replay := &Replay{Dir: "testdata"}
+	a, _ := http.NewRequest("POST", "https://api.github.com/graphql", strings.NewReader(`{"query":"query { viewer { login } }"}`))
+	b, _ := http.NewRequest("POST", "https://api.github.com/graphql", strings.NewReader(`{"query":"query { rateLimit { limit } }"}`))
+
+	if replay.Fixture(a) == replay.Fixture(b) {
+		t.Errorf("Want distinct fixtures for distinct bodies")
+	}
+	if got, want := replay.Fixture(a), replay.Fixture(a); got != want {
+		t.Errorf("Want the body restored after computing the fixture, got %q and %q", got, want)
+	}
+	body, _ := ioutil.ReadAll(a.Body)
+	if got, want := string(body), `{"query":"query { viewer { login } }"}`; got != want {
+		t.Errorf("Want body %s, got %s", want, got)
+	}
+}
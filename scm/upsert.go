@@ -0,0 +1,109 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// CommentUpserter lists, creates and edits the comments of an
+// issue or a pull request, and is implemented by IssueService
+// and PullRequestService.
+type CommentUpserter interface {
+	CommentLister
+	CreateComment(context.Context, string, int, *CommentInput) (*Comment, *Response, error)
+	EditComment(context.Context, string, int, int, *CommentInput) (*Comment, *Response, error)
+}
+
+// CommentMarker returns the hidden HTML comment that UpsertComment
+// appends to the comment body to find the comment again.
+func CommentMarker(marker string) string {
+	return "<!-- " + marker + " -->"
+}
+
+// UpsertComment edits the oldest comment of the issue or pull
+// request that contains the hidden marker, or creates the comment
+// if there is none, so a bot keeps a single comment up to date
+// instead of posting a new one on every run. The comment is left
+// untouched if its body is unchanged.
+//
+// The lookup and the write are separate requests, so two callers
+// racing on the same marker can both create a comment.
+func UpsertComment(ctx context.Context, upserter CommentUpserter, repo string, number int, marker, body string) (*Comment, *Response, error) {
+	hidden := CommentMarker(marker)
+	if !strings.Contains(body, hidden) {
+		body = body + "\n\n" + hidden
+	}
+	comments, res, err := ListCommentsSince(ctx, upserter, repo, number, time.Time{})
+	if err != nil {
+		return nil, res, err
+	}
+	for _, c := range comments {
+		if !strings.Contains(c.Body, hidden) {
+			continue
+		}
+		if c.Body == body {
+			return c, res, nil
+		}
+		return upserter.EditComment(ctx, repo, number, c.ID, &CommentInput{Body: body})
+	}
+	return upserter.CreateComment(ctx, repo, number, &CommentInput{Body: body})
+}
+
+// PullRequestFinderCreator lists and creates pull requests, and
+// is implemented by PullRequestService.
+type PullRequestFinderCreator interface {
+	List(context.Context, string, PullRequestListOptions) ([]*PullRequest, *Response, error)
+	Create(context.Context, string, *PullRequestInput) (*PullRequest, *Response, error)
+}
+
+// PullRequestMatcher reports whether the open pull request is the
+// one described by the input.
+type PullRequestMatcher func(pr *PullRequest, input *PullRequestInput) bool
+
+// MatchBranches matches the pull request from the input Head into
+// the input Base. The Head may be qualified with the owner of the
+// fork, as in "octocat:patch-1".
+func MatchBranches(pr *PullRequest, input *PullRequestInput) bool {
+	if pr.Base.Ref != input.Base {
+		return false
+	}
+	if pr.Head.Ref == input.Head {
+		return true
+	}
+	return pr.Head.Repo.Namespace != "" && pr.Head.Repo.Namespace+":"+pr.Head.Ref == input.Head
+}
+
+// FindOrCreatePullRequest returns the open pull request matched by
+// match, or creates one from the input if there is none. A nil
+// match defaults to MatchBranches.
+//
+// The lookup and the creation are separate requests, so two
+// callers racing on the same input can both try to create the
+// pull request, and the provider rejects the second one.
+func FindOrCreatePullRequest(ctx context.Context, prs PullRequestFinderCreator, repo string, input *PullRequestInput, match PullRequestMatcher) (*PullRequest, *Response, error) {
+	if match == nil {
+		match = MatchBranches
+	}
+	opts := PullRequestListOptions{Page: 1, Size: 100, Open: true}
+	for {
+		list, res, err := prs.List(ctx, repo, opts)
+		if err != nil {
+			return nil, res, err
+		}
+		for _, pr := range list {
+			if !pr.Closed && match(pr, input) {
+				return pr, res, nil
+			}
+		}
+		if res == nil || res.Page.Next == 0 {
+			break
+		}
+		opts.Page = res.Page.Next
+	}
+	return prs.Create(ctx, repo, input)
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"testing"
+)
+
+// memComments keeps the comments of a single issue.
+type memComments struct {
+	comments []*Comment
+	edits    int
+}
+
+func (s *memComments) ListComments(ctx context.Context, repo string, number int, opts CommentListOptions) ([]*Comment, *Response, error) {
+	return s.comments, &Response{}, nil
+}
+
+func (s *memComments) CreateComment(ctx context.Context, repo string, number int, input *CommentInput) (*Comment, *Response, error) {
+	c := &Comment{ID: len(s.comments) + 1, Body: input.Body}
+	s.comments = append(s.comments, c)
+	return c, &Response{}, nil
+}
+
+func (s *memComments) EditComment(ctx context.Context, repo string, number, id int, input *CommentInput) (*Comment, *Response, error) {
+	s.edits++
+	c := s.comments[id-1]
+	c.Body = input.Body
+	return c, &Response{}, nil
+}
+
+func TestUpsertComment(t *testing.T) {
+	ctx := context.Background()
+	s := &memComments{comments: []*Comment{{ID: 1, Body: "lgtm"}}}
+
+	c, _, err := UpsertComment(ctx, s, "octocat/hello-world", 1, "coverage", "85%")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := c.Body, "85%\n\n<!-- coverage -->"; got != want {
+		t.Errorf("Want body %q, got %q", want, got)
+	}
+
+	if _, _, err := UpsertComment(ctx, s, "octocat/hello-world", 1, "coverage", "85%"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := UpsertComment(ctx, s, "octocat/hello-world", 1, "coverage", "90%"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(s.comments), 2; got != want {
+		t.Errorf("Want %d comments, got %d", want, got)
+	}
+	if got, want := s.edits, 1; got != want {
+		t.Errorf("Want %d edits, got %d", want, got)
+	}
+	if got, want := s.comments[1].Body, "90%\n\n<!-- coverage -->"; got != want {
+		t.Errorf("Want body %q, got %q", want, got)
+	}
+}
+
+// memPullRequests keeps the pull requests of a single repository.
+type memPullRequests struct {
+	prs []*PullRequest
+}
+
+func (s *memPullRequests) List(ctx context.Context, repo string, opts PullRequestListOptions) ([]*PullRequest, *Response, error) {
+	return s.prs, nil, nil
+}
+
+func (s *memPullRequests) Create(ctx context.Context, repo string, input *PullRequestInput) (*PullRequest, *Response, error) {
+	pr := &PullRequest{
+		Number: len(s.prs) + 1,
+		Title:  input.Title,
+		Base:   PullRequestBranch{Ref: input.Base},
+		Head:   PullRequestBranch{Ref: input.Head},
+	}
+	s.prs = append(s.prs, pr)
+	return pr, nil, nil
+}
+
+func TestFindOrCreatePullRequest(t *testing.T) {
+	ctx := context.Background()
+	s := &memPullRequests{prs: []*PullRequest{
+		{
+			Number: 1,
+			Closed: true,
+			Base:   PullRequestBranch{Ref: "master"},
+			Head:   PullRequestBranch{Ref: "patch-1"},
+		},
+		{
+			Number: 2,
+			Base:   PullRequestBranch{Ref: "master"},
+			Head:   PullRequestBranch{Ref: "patch-1", Repo: Repository{Namespace: "octocat"}},
+		},
+	}}
+
+	pr, _, err := FindOrCreatePullRequest(ctx, s, "octocat/hello-world", &PullRequestInput{Head: "octocat:patch-1", Base: "master"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := pr.Number, 2; got != want {
+		t.Errorf("Want pull request %d, got %d", want, got)
+	}
+
+	pr, _, err = FindOrCreatePullRequest(ctx, s, "octocat/hello-world", &PullRequestInput{Head: "patch-2", Base: "master"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := pr.Number, 3; got != want {
+		t.Errorf("Want pull request %d, got %d", want, got)
+	}
+}
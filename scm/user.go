@@ -6,6 +6,7 @@ package scm
 
 import (
 	"context"
+	"strings"
 	"time"
 )
 
@@ -23,12 +24,26 @@ type (
 		Updated time.Time
 	}
 
-	// UserToken represents a user token.
+	// UserToken represents a user token. The token value is
+	// only known when the token is created.
 	UserToken struct {
 		ID    int64
+		Name  string
 		Token string
 	}
 
+	// TokenInfo describes the access token authenticating
+	// the client.
+	TokenInfo struct {
+		Name   string
+		Scopes []string
+
+		// Expires is the expiry time of the token, or the
+		// zero time if the token does not expire or the
+		// provider does not report it.
+		Expires time.Time
+	}
+
 	// Invitation represents a repo invitation
 	Invitation struct {
 		ID          int64
@@ -51,6 +66,16 @@ type (
 		// DeleteToken deletes a user token.
 		DeleteToken(context.Context, int64) (*Response, error)
 
+		// ListTokens returns the tokens of the authenticated
+		// user.
+		ListTokens(context.Context, ListOptions) ([]*UserToken, *Response, error)
+
+		// TokenInfo returns the scopes and expiry of the
+		// access token authenticating the client. The Gitea,
+		// Gogs and Bitbucket Server drivers return
+		// ErrNotSupported.
+		TokenInfo(context.Context) (*TokenInfo, *Response, error)
+
 		// FindEmail returns the authenticated user email.
 		FindEmail(context.Context) (string, *Response, error)
 
@@ -64,3 +89,21 @@ type (
 		AcceptInvitation(context.Context, int64) (*Response, error)
 	}
 )
+
+// ExpiresWithin returns true if the token expires within the
+// duration, so tooling can warn before the credentials expire.
+func (t *TokenInfo) ExpiresWithin(d time.Duration) bool {
+	return !t.Expires.IsZero() && time.Until(t.Expires) < d
+}
+
+// ParseScopes parses the comma separated list of scopes of
+// the X-OAuth-Scopes header.
+func ParseScopes(header string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(header, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
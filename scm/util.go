@@ -62,6 +62,50 @@ func ExpandRef(name, prefix string) string {
 	return prefix + "/" + name
 }
 
+// QualifyRef returns ref expanded to the fully qualified
+// reference path. Forms relative to refs/ such as heads/master,
+// tags/v1.0.0 and pull/1/head are accepted, and the other names
+// are assumed to be branches. Use QualifyRefs to look up a
+// branch name as a tag too.
+func QualifyRef(ref string) string {
+	if strings.HasPrefix(ref, "refs/") {
+		return ref
+	}
+	for _, namespace := range refNamespaces {
+		if strings.HasPrefix(ref, namespace) {
+			return "refs/" + ref
+		}
+	}
+	return "refs/heads/" + ref
+}
+
+// refNamespaces are the reference namespaces of the forms of
+// QualifyRef relative to refs/, such as the pull request heads
+// of GitHub, GitLab and Bitbucket Server and the changes of
+// Gerrit.
+var refNamespaces = []string{
+	"heads/",
+	"tags/",
+	"pull/",
+	"merge-requests/",
+	"pull-requests/",
+	"changes/",
+	"notes/",
+	"remotes/",
+}
+
+// QualifyRefs returns the fully qualified reference paths ref
+// may name, in the order they should be looked up. An
+// unqualified name is a branch or, failing that, a tag, and
+// the other forms name a single reference.
+func QualifyRefs(ref string) []string {
+	qualified := QualifyRef(ref)
+	if qualified == "refs/heads/"+ref {
+		return []string{qualified, "refs/tags/" + ref}
+	}
+	return []string{qualified}
+}
+
 // IsTag returns true if the reference path points to
 // a tag object.
 func IsTag(ref string) bool {
@@ -5,6 +5,7 @@
 package scm
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -71,6 +72,95 @@ func TestTrimRef(t *testing.T) {
 	}
 }
 
+func TestQualifyRef(t *testing.T) {
+	tests := []struct {
+		before, after string
+	}{
+		{
+			before: "master",
+			after:  "refs/heads/master",
+		},
+		{
+			before: "feature/x",
+			after:  "refs/heads/feature/x",
+		},
+		{
+			before: "heads/master",
+			after:  "refs/heads/master",
+		},
+		{
+			before: "tags/v1.0.0",
+			after:  "refs/tags/v1.0.0",
+		},
+		{
+			before: "refs/tags/v1.0.0",
+			after:  "refs/tags/v1.0.0",
+		},
+		{
+			before: "refs/releases/v1",
+			after:  "refs/releases/v1",
+		},
+		{
+			before: "pull/1/head",
+			after:  "refs/pull/1/head",
+		},
+		{
+			before: "refs/pull/1/merge",
+			after:  "refs/pull/1/merge",
+		},
+		{
+			before: "merge-requests/1/head",
+			after:  "refs/merge-requests/1/head",
+		},
+		{
+			before: "pull-requests/1/from",
+			after:  "refs/pull-requests/1/from",
+		},
+		{
+			before: "changes/34/1234/1",
+			after:  "refs/changes/34/1234/1",
+		},
+	}
+	for _, test := range tests {
+		if got, want := QualifyRef(test.before), test.after; got != want {
+			t.Errorf("Got reference %s, want %s", got, want)
+		}
+	}
+}
+
+func TestQualifyRefs(t *testing.T) {
+	tests := []struct {
+		before string
+		after  []string
+	}{
+		{
+			before: "v1.0.0",
+			after:  []string{"refs/heads/v1.0.0", "refs/tags/v1.0.0"},
+		},
+		{
+			before: "heads/master",
+			after:  []string{"refs/heads/master"},
+		},
+		{
+			before: "tags/v1.0.0",
+			after:  []string{"refs/tags/v1.0.0"},
+		},
+		{
+			before: "refs/releases/v1",
+			after:  []string{"refs/releases/v1"},
+		},
+		{
+			before: "pull/1/head",
+			after:  []string{"refs/pull/1/head"},
+		},
+	}
+	for _, test := range tests {
+		if got, want := QualifyRefs(test.before), test.after; !reflect.DeepEqual(got, want) {
+			t.Errorf("Got references %v, want %v", got, want)
+		}
+	}
+}
+
 func TestExpandRef(t *testing.T) {
 	tests := []struct {
 		name, prefix, after string
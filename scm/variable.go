@@ -0,0 +1,71 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrInvalidVariableScope indicates a variable scope sets
+// neither or both of the repository and the organization.
+var ErrInvalidVariableScope = errors.New("Invalid Variable Scope")
+
+type (
+	// Variable represents a non-secret CI variable.
+	Variable struct {
+		Name  string
+		Value string
+
+		// Environment is the deployment environment the
+		// variable is scoped to, or empty for all
+		// environments.
+		Environment string
+
+		Created time.Time
+		Updated time.Time
+	}
+
+	// VariableInput provides the input fields required for
+	// creating or updating a variable.
+	VariableInput struct {
+		Name  string
+		Value string
+	}
+
+	// VariableScope identifies the owner of variables, a
+	// repository or an organization, optionally narrowed
+	// to a deployment environment. Exactly one of Repo and
+	// Org must be set.
+	VariableScope struct {
+		Repo        string
+		Org         string
+		Environment string
+	}
+
+	// VariableService provides access to the non-secret CI
+	// variables of repositories and organizations, such as
+	// GitHub Actions variables and GitLab CI variables.
+	//
+	// The service is optional: the Variables field of the
+	// client is nil for drivers that do not support it.
+	VariableService interface {
+		// List returns the variables of the scope.
+		List(ctx context.Context, scope VariableScope, opts ListOptions) ([]*Variable, *Response, error)
+
+		// Find returns a variable by name.
+		Find(ctx context.Context, scope VariableScope, name string) (*Variable, *Response, error)
+
+		// Create creates a new variable.
+		Create(ctx context.Context, scope VariableScope, input *VariableInput) (*Response, error)
+
+		// Update updates the value of a variable.
+		Update(ctx context.Context, scope VariableScope, input *VariableInput) (*Response, error)
+
+		// Delete deletes a variable.
+		Delete(ctx context.Context, scope VariableScope, name string) (*Response, error)
+	}
+)
@@ -5,8 +5,17 @@
 package scm
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"io/ioutil"
+	"mime"
 	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
 	"time"
 )
 
@@ -16,6 +25,8 @@ type WebhookKind string
 const (
 	// WebhookKindBranch is for branch events
 	WebhookKindBranch WebhookKind = "branch"
+	// WebhookKindBranchProtectionRule is for branch protection rule events
+	WebhookKindBranchProtectionRule WebhookKind = "branch_protection_rule"
 	// WebhookKindCheckRun is for check run events
 	WebhookKindCheckRun WebhookKind = "check_run"
 	// WebhookKindCheckSuite is for check suite events
@@ -36,6 +47,8 @@ const (
 	WebhookKindIssueComment WebhookKind = "issue_comment"
 	// WebhookKindLabel is for label events
 	WebhookKindLabel WebhookKind = "label"
+	// WebhookKindMilestone is for milestone events
+	WebhookKindMilestone WebhookKind = "milestone"
 	// WebhookKindPing is for ping events
 	WebhookKindPing WebhookKind = "ping"
 	// WebhookKindPullRequest is for pull request events
@@ -52,10 +65,22 @@ const (
 	WebhookKindReview WebhookKind = "review"
 	// WebhookKindReviewCommentHook is for review comment events
 	WebhookKindReviewCommentHook WebhookKind = "review_comment"
+	// WebhookKindSecurityAlert is for security alert events
+	WebhookKindSecurityAlert WebhookKind = "security_alert"
 	// WebhookKindStar is for star events
 	WebhookKindStar WebhookKind = "star"
 	// WebhookKindStatus is for status events
 	WebhookKindStatus WebhookKind = "status"
+	// WebhookKindSystemGroup is for system group events
+	WebhookKindSystemGroup WebhookKind = "system_group"
+	// WebhookKindSystemMember is for system membership events
+	WebhookKindSystemMember WebhookKind = "system_member"
+	// WebhookKindSystemProject is for system project events
+	WebhookKindSystemProject WebhookKind = "system_project"
+	// WebhookKindSystemRepositoryUpdate is for system repository update events
+	WebhookKindSystemRepositoryUpdate WebhookKind = "system_repository_update"
+	// WebhookKindSystemUser is for system user events
+	WebhookKindSystemUser WebhookKind = "system_user"
 	// WebhookKindTag is for tag events
 	WebhookKindTag WebhookKind = "tag"
 	// WebhookKindWatch is for watch events
@@ -66,8 +91,20 @@ var (
 	// ErrSignatureInvalid is returned when the webhook
 	// signature is invalid or cannot be calculated.
 	ErrSignatureInvalid = errors.New("Invalid webhook signature")
+
+	// ErrPayloadTooLarge is returned when the webhook
+	// payload exceeds the maximum body size.
+	ErrPayloadTooLarge = errors.New("Webhook payload too large")
+
+	// ErrContentTypeNotAllowed is returned when the webhook
+	// content type is not one of the allowed content types.
+	ErrContentTypeNotAllowed = errors.New("Webhook content type not allowed")
 )
 
+// DefaultWebhookMaxBodySize is the maximum webhook payload
+// size used when no size is configured.
+const DefaultWebhookMaxBodySize = 10000000
+
 type (
 	// Webhook defines a webhook for repository events.
 	Webhook interface {
@@ -76,6 +113,38 @@ type (
 		Kind() WebhookKind
 	}
 
+	// EventSource delivers the events of repositories as
+	// webhooks, for environments where the provider cannot
+	// deliver webhooks, e.g. because inbound connections are
+	// not allowed. Run sends the webhooks to the channel until
+	// the context is done, and returns the context error.
+	EventSource interface {
+		Run(ctx context.Context, hooks chan<- Webhook) error
+	}
+
+	// WebhookServiceOptions configures how a webhook service
+	// reads webhook requests.
+	WebhookServiceOptions struct {
+		// MaxBodySize is the maximum payload size in bytes.
+		// It defaults to DefaultWebhookMaxBodySize.
+		MaxBodySize int64
+
+		// ContentTypes are the accepted media types, such as
+		// application/json. Any content type is accepted if
+		// empty.
+		ContentTypes []string
+
+		// FormPayload enables payloads delivered as the
+		// payload field of a form, as sent by GitHub when the
+		// webhook content type is application/x-www-form-urlencoded.
+		FormPayload bool
+
+		// RawPayload keeps the JSON payload in the Raw field
+		// of the parsed webhooks, for the provider fields the
+		// webhooks do not have.
+		RawPayload bool
+	}
+
 	// Label on a PR
 	Label struct {
 		ID          int64
@@ -85,12 +154,16 @@ type (
 		Color       string
 	}
 
-	// PingHook a ping webhook.
+	// PingHook a ping webhook, sent when a webhook is created
+	// or its connection is tested. Providers that test webhooks
+	// by delivering a regular event, such as GitLab and Gitea,
+	// never send a ping.
 	PingHook struct {
 		Repo         Repository
 		Sender       User
 		Installation *InstallationRef
 		GUID         string
+		Raw          json.RawMessage `json:"-"`
 	}
 
 	// PushCommit represents general info about a commit.
@@ -104,19 +177,26 @@ type (
 
 	// PushHook represents a push hook, eg push events.
 	PushHook struct {
-		Ref          string
-		BaseRef      string
-		Repo         Repository
-		Before       string
-		After        string
-		Created      bool
-		Deleted      bool
+		Ref     string
+		BaseRef string
+		Repo    Repository
+
+		// Before and After are the commits the ref pointed
+		// to before and after the push. Before is EmptyCommit
+		// when the ref was created, and After is EmptyCommit
+		// when the ref was deleted.
+		Before  string
+		After   string
+		Created bool
+		Deleted bool
+
 		Forced       bool
 		Compare      string
 		Commits      []PushCommit
 		Commit       Commit
 		Sender       User
 		GUID         string
+		Raw          json.RawMessage `json:"-"`
 		Installation *InstallationRef
 	}
 
@@ -128,6 +208,8 @@ type (
 		Action       Action
 		Sender       User
 		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
 	}
 
 	// CheckRunHook represents a check run event
@@ -137,6 +219,11 @@ type (
 		Sender       User
 		Label        Label
 		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
+		// State is the check run status, or its conclusion
+		// once the check run has completed.
+		State State
 	}
 
 	// CheckSuiteHook represents a check suite event
@@ -146,6 +233,11 @@ type (
 		Sender       User
 		Label        Label
 		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
+		// State is the check suite status, or its conclusion
+		// once the check suite has completed.
+		State State
 	}
 
 	// DeploymentStatusHook represents a check suite event
@@ -155,13 +247,20 @@ type (
 		Sender       User
 		Label        Label
 		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
 	}
 
-	// ForkHook represents a fork event
+	// ForkHook represents a fork event. Repo is the
+	// repository that was forked and Fork is the newly
+	// created repository.
 	ForkHook struct {
 		Repo         Repository
+		Fork         Repository
 		Sender       User
 		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
 	}
 
 	// TagHook represents a tag event, eg create and delete
@@ -172,6 +271,8 @@ type (
 		Action       Action
 		Sender       User
 		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
 	}
 
 	// IssueHook represents an issue event, eg issues.
@@ -181,6 +282,8 @@ type (
 		Issue        Issue
 		Sender       User
 		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
 	}
 
 	// IssueCommentHook represents an issue comment event,
@@ -192,6 +295,8 @@ type (
 		Comment      Comment
 		Sender       User
 		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
 	}
 
 	// InstallationHook represents an installation of a GitHub App
@@ -200,6 +305,8 @@ type (
 		Repos        []*Repository
 		Sender       User
 		Installation *Installation
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
 	}
 
 	// InstallationRepositoryHook represents an installation of a GitHub App
@@ -210,6 +317,8 @@ type (
 		ReposRemoved        []*Repository
 		Sender              User
 		Installation        *Installation
+		GUID                string
+		Raw                 json.RawMessage `json:"-"`
 	}
 
 	// InstallationRef references a GitHub app install on a webhook
@@ -224,7 +333,81 @@ type (
 		Repo         Repository
 		Sender       User
 		Label        Label
+		Changes      LabelHookChanges
 		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
+	}
+
+	// BranchProtectionRule represents a branch protection
+	// rule applied to the branches matching a pattern.
+	BranchProtectionRule struct {
+		ID                           int64
+		Pattern                      string
+		RequirePullRequestReviews    bool
+		RequiredApprovingReviewCount int
+		DismissStaleReviews          bool
+		RequireCodeOwnerReview       bool
+		RequireStatusChecks          bool
+		RequiredStatusChecks         []string
+		StrictStatusChecks           bool
+		RequireSignedCommits         bool
+		RequireLinearHistory         bool
+		EnforceAdmins                bool
+		AllowForcePushes             bool
+		AllowDeletions               bool
+		Created                      time.Time
+		Updated                      time.Time
+	}
+
+	// BranchProtectionRuleHook represents a branch protection
+	// rule event, eg created, edited or deleted. For edits,
+	// Previous holds the rule as it was before the change,
+	// which can be compared with Rule to detect weakened
+	// protections. This is currently GitHub-specific.
+	BranchProtectionRuleHook struct {
+		Action       Action
+		Repo         Repository
+		Sender       User
+		Rule         BranchProtectionRule
+		Previous     *BranchProtectionRule
+		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
+	}
+
+	// SecurityAlertHook represents a security alert event,
+	// raised by a dependency, secret or code scanner. The
+	// alert state tells how a closed alert was resolved.
+	// This is currently GitHub-specific.
+	SecurityAlertHook struct {
+		Action       Action
+		Repo         Repository
+		Sender       User
+		Alert        SecurityAlert
+		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
+	}
+
+	// MilestoneHook represents a milestone event, eg
+	// created, edited, closed or deleted.
+	MilestoneHook struct {
+		Action       Action
+		Repo         Repository
+		Sender       User
+		Milestone    Milestone
+		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
+	}
+
+	// LabelHookChanges holds the previous values of the
+	// label fields changed by an edit.
+	LabelHookChanges struct {
+		Name        string
+		Color       string
+		Description string
 	}
 
 	// ReleaseHook represents a release event
@@ -234,6 +417,8 @@ type (
 		Sender       User
 		Label        Label
 		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
 	}
 
 	// RepositoryHook represents a repository event
@@ -242,6 +427,8 @@ type (
 		Repo         Repository
 		Sender       User
 		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
 	}
 
 	// StatusHook represents a status event
@@ -251,6 +438,15 @@ type (
 		Sender       User
 		Label        Label
 		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
+
+		// Sha is the commit the status was reported for.
+		Sha string
+
+		// Status is the reported commit status. Its Label
+		// is the status context.
+		Status Status
 	}
 
 	// Account represents the account of a GitHub app install
@@ -287,6 +483,7 @@ type (
 		Sender       User
 		Changes      PullRequestHookChanges
 		GUID         string
+		Raw          json.RawMessage `json:"-"`
 		Installation *InstallationRef
 	}
 
@@ -299,6 +496,8 @@ type (
 		Comment      Comment
 		Sender       User
 		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
 	}
 
 	// ReviewCommentHook represents a pull request review
@@ -309,6 +508,8 @@ type (
 		PullRequest  PullRequest
 		Review       Review
 		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
 	}
 
 	// DeployHook represents a deployment event. This is
@@ -323,6 +524,8 @@ type (
 		TargetURL    string
 		Task         string
 		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
 	}
 
 	// WatchHook represents a watch event. This is currently GitHub-specific.
@@ -331,26 +534,119 @@ type (
 		Repo         Repository
 		Sender       User
 		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
 	}
 
 	// StarHook represents a star event. This is currently GitHub-specific.
 	StarHook struct {
-		Action    Action
-		StarredAt time.Time
-		Repo      Repository
-		Sender    User
+		Action       Action
+		StarredAt    time.Time
+		Repo         Repository
+		Sender       User
+		Installation *InstallationRef
+		GUID         string
+		Raw          json.RawMessage `json:"-"`
+	}
+
+	// SystemProjectHook represents an instance-wide project
+	// event, e.g. project_create or project_rename. This is
+	// currently GitLab-specific.
+	SystemProjectHook struct {
+		Event       string
+		Repo        Repository
+		OldFullName string
+		Owner       User
+		GUID        string
+		Raw         json.RawMessage `json:"-"`
+	}
+
+	// SystemUserHook represents an instance-wide user event,
+	// e.g. user_create or user_rename. This is currently
+	// GitLab-specific.
+	SystemUserHook struct {
+		Event    string
+		User     User
+		OldLogin string
+		GUID     string
+		Raw      json.RawMessage `json:"-"`
+	}
+
+	// SystemGroupHook represents an instance-wide group event,
+	// e.g. group_create or group_rename. This is currently
+	// GitLab-specific.
+	SystemGroupHook struct {
+		Event       string
+		ID          int
+		Name        string
+		Path        string
+		FullPath    string
+		OldFullPath string
+		GUID        string
+		Raw         json.RawMessage `json:"-"`
+	}
+
+	// SystemMemberHook represents a change in project or group
+	// membership, e.g. user_add_to_team or user_add_to_group.
+	// Repo is set for project membership events and Group for
+	// group membership events. This is currently
+	// GitLab-specific.
+	SystemMemberHook struct {
+		Event  string
+		User   User
+		Access string
+		Repo   Repository
+		Group  string
+		GUID   string
+		Raw    json.RawMessage `json:"-"`
+	}
+
+	// SystemRepositoryUpdateHook represents an instance-wide
+	// repository_update event. This is currently
+	// GitLab-specific.
+	SystemRepositoryUpdateHook struct {
+		Repo    Repository
+		Sender  User
+		Changes []RefChange
+		GUID    string
+		Raw     json.RawMessage `json:"-"`
+	}
+
+	// RefChange represents a ref update in a
+	// SystemRepositoryUpdateHook.
+	RefChange struct {
+		Ref    string
+		Before string
+		After  string
 	}
 
 	// SecretFunc provides the Webhook parser with the
 	// secret key used to validate webhook authenticity.
 	SecretFunc func(webhook Webhook) (string, error)
 
+	// MultiSecretFunc provides the Webhook parser with the
+	// candidate secret keys used to validate webhook
+	// authenticity. The webhook is accepted if it validates
+	// against any key, which allows a secret to be rotated
+	// without downtime.
+	MultiSecretFunc func(webhook Webhook) ([]string, error)
+
 	// WebhookService provides abstract functions for
 	// parsing and validating webhooks requests.
 	WebhookService interface {
 		// Parse returns the parsed the repository webhook payload.
 		Parse(req *http.Request, fn SecretFunc) (Webhook, error)
 	}
+
+	// MultiSecretParser is implemented by the WebhookServices
+	// validating the webhooks against several candidate
+	// secrets. Use ParseMulti, which supports the other
+	// WebhookServices too.
+	MultiSecretParser interface {
+		// ParseMulti returns the parsed repository webhook
+		// payload, validated against the candidate secrets.
+		ParseMulti(req *http.Request, fn MultiSecretFunc) (Webhook, error)
+	}
 )
 
 // Kind returns the kind of webhook
@@ -359,6 +655,32 @@ func (h *PingHook) Kind() WebhookKind { return WebhookKindPing }
 // Kind returns the kind of webhook
 func (h *PushHook) Kind() WebhookKind { return WebhookKindPush }
 
+// IsTag returns true if the push updated a tag.
+func (h *PushHook) IsTag() bool { return IsTag(QualifyRef(h.Ref)) }
+
+// IsBranchDeletion returns true if the push deleted a branch.
+func (h *PushHook) IsBranchDeletion() bool { return h.Deleted && h.Branch() != "" }
+
+// Branch returns the name of the pushed branch, or an empty
+// string if the push updated a tag.
+func (h *PushHook) Branch() string {
+	ref := QualifyRef(h.Ref)
+	if !strings.HasPrefix(ref, "refs/heads/") {
+		return ""
+	}
+	return strings.TrimPrefix(ref, "refs/heads/")
+}
+
+// Tag returns the name of the pushed tag, or an empty
+// string if the push updated a branch.
+func (h *PushHook) Tag() string {
+	ref := QualifyRef(h.Ref)
+	if !IsTag(ref) {
+		return ""
+	}
+	return strings.TrimPrefix(ref, "refs/tags/")
+}
+
 // Kind returns the kind of webhook
 func (h *BranchHook) Kind() WebhookKind { return WebhookKindBranch }
 
@@ -392,6 +714,15 @@ func (h *InstallationHook) Kind() WebhookKind { return WebhookKindInstallation }
 // Kind returns the kind of webhook
 func (h *LabelHook) Kind() WebhookKind { return WebhookKindLabel }
 
+// Kind returns the kind of webhook
+func (h *BranchProtectionRuleHook) Kind() WebhookKind { return WebhookKindBranchProtectionRule }
+
+// Kind returns the kind of webhook
+func (h *SecurityAlertHook) Kind() WebhookKind { return WebhookKindSecurityAlert }
+
+// Kind returns the kind of webhook
+func (h *MilestoneHook) Kind() WebhookKind { return WebhookKindMilestone }
+
 // Kind returns the kind of webhook
 func (h *StatusHook) Kind() WebhookKind { return WebhookKindStatus }
 
@@ -422,6 +753,21 @@ func (h *WatchHook) Kind() WebhookKind { return WebhookKindWatch }
 // Kind returns the kind of webhook
 func (h *StarHook) Kind() WebhookKind { return WebhookKindStar }
 
+// Kind returns the kind of webhook
+func (h *SystemProjectHook) Kind() WebhookKind { return WebhookKindSystemProject }
+
+// Kind returns the kind of webhook
+func (h *SystemUserHook) Kind() WebhookKind { return WebhookKindSystemUser }
+
+// Kind returns the kind of webhook
+func (h *SystemGroupHook) Kind() WebhookKind { return WebhookKindSystemGroup }
+
+// Kind returns the kind of webhook
+func (h *SystemMemberHook) Kind() WebhookKind { return WebhookKindSystemMember }
+
+// Kind returns the kind of webhook
+func (h *SystemRepositoryUpdateHook) Kind() WebhookKind { return WebhookKindSystemRepositoryUpdate }
+
 // Repository defines the repository webhook and provides a convenient way to get the associated repository without
 // having to cast the type.
 func (h *PingHook) Repository() Repository { return h.Repo }
@@ -470,6 +816,18 @@ func (h *ReviewHook) Repository() Repository { return h.Repo }
 // having to cast the type.
 func (h *LabelHook) Repository() Repository { return h.Repo }
 
+// Repository defines the repository webhook and provides a convenient way to get the associated repository without
+// having to cast the type.
+func (h *BranchProtectionRuleHook) Repository() Repository { return h.Repo }
+
+// Repository defines the repository webhook and provides a convenient way to get the associated repository without
+// having to cast the type.
+func (h *SecurityAlertHook) Repository() Repository { return h.Repo }
+
+// Repository defines the repository webhook and provides a convenient way to get the associated repository without
+// having to cast the type.
+func (h *MilestoneHook) Repository() Repository { return h.Repo }
+
 // Repository defines the repository webhook and provides a convenient way to get the associated repository without
 // having to cast the type.
 func (h *StatusHook) Repository() Repository { return h.Repo }
@@ -506,6 +864,26 @@ func (h *WatchHook) Repository() Repository { return h.Repo }
 // having to cast the type.
 func (h *StarHook) Repository() Repository { return h.Repo }
 
+// Repository defines the repository webhook and provides a convenient way to get the associated repository without
+// having to cast the type.
+func (h *SystemProjectHook) Repository() Repository { return h.Repo }
+
+// Repository defines the repository webhook and provides a convenient way to get the associated repository without
+// having to cast the type.
+func (h *SystemUserHook) Repository() Repository { return Repository{} }
+
+// Repository defines the repository webhook and provides a convenient way to get the associated repository without
+// having to cast the type.
+func (h *SystemGroupHook) Repository() Repository { return Repository{} }
+
+// Repository defines the repository webhook and provides a convenient way to get the associated repository without
+// having to cast the type.
+func (h *SystemMemberHook) Repository() Repository { return h.Repo }
+
+// Repository defines the repository webhook and provides a convenient way to get the associated repository without
+// having to cast the type.
+func (h *SystemRepositoryUpdateHook) Repository() Repository { return h.Repo }
+
 // Repository defines the repository webhook and provides a convenient way to get the associated repository without
 // having to cast the type.
 func (h *InstallationHook) Repository() Repository {
@@ -524,6 +902,194 @@ func (h *InstallationRepositoryHook) Repository() Repository {
 	return Repository{}
 }
 
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *PingHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *PushHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *BranchHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *DeployHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *TagHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *IssueHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *IssueCommentHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *PullRequestHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *PullRequestCommentHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *ReviewHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *ReviewCommentHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *LabelHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *BranchProtectionRuleHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *SecurityAlertHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *MilestoneHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *StatusHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *CheckRunHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *CheckSuiteHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *DeploymentStatusHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *ReleaseHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *RepositoryHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *ForkHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *WatchHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *StarHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *SystemProjectHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *SystemUserHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *SystemGroupHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *SystemMemberHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *SystemRepositoryUpdateHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *InstallationHook) GetGUID() string { return h.GUID }
+
+// GetGUID returns the delivery identifier of the webhook, or an
+// empty string if the provider does not send one.
+func (h *InstallationRepositoryHook) GetGUID() string { return h.GUID }
+
+func (h *PingHook) setGUID(guid string)                   { h.GUID = guid }
+func (h *PushHook) setGUID(guid string)                   { h.GUID = guid }
+func (h *BranchHook) setGUID(guid string)                 { h.GUID = guid }
+func (h *DeployHook) setGUID(guid string)                 { h.GUID = guid }
+func (h *TagHook) setGUID(guid string)                    { h.GUID = guid }
+func (h *IssueHook) setGUID(guid string)                  { h.GUID = guid }
+func (h *IssueCommentHook) setGUID(guid string)           { h.GUID = guid }
+func (h *PullRequestHook) setGUID(guid string)            { h.GUID = guid }
+func (h *PullRequestCommentHook) setGUID(guid string)     { h.GUID = guid }
+func (h *ReviewHook) setGUID(guid string)                 { h.GUID = guid }
+func (h *ReviewCommentHook) setGUID(guid string)          { h.GUID = guid }
+func (h *LabelHook) setGUID(guid string)                  { h.GUID = guid }
+func (h *BranchProtectionRuleHook) setGUID(guid string)   { h.GUID = guid }
+func (h *SecurityAlertHook) setGUID(guid string)          { h.GUID = guid }
+func (h *MilestoneHook) setGUID(guid string)              { h.GUID = guid }
+func (h *StatusHook) setGUID(guid string)                 { h.GUID = guid }
+func (h *CheckRunHook) setGUID(guid string)               { h.GUID = guid }
+func (h *CheckSuiteHook) setGUID(guid string)             { h.GUID = guid }
+func (h *DeploymentStatusHook) setGUID(guid string)       { h.GUID = guid }
+func (h *ReleaseHook) setGUID(guid string)                { h.GUID = guid }
+func (h *RepositoryHook) setGUID(guid string)             { h.GUID = guid }
+func (h *ForkHook) setGUID(guid string)                   { h.GUID = guid }
+func (h *WatchHook) setGUID(guid string)                  { h.GUID = guid }
+func (h *StarHook) setGUID(guid string)                   { h.GUID = guid }
+func (h *SystemProjectHook) setGUID(guid string)          { h.GUID = guid }
+func (h *SystemUserHook) setGUID(guid string)             { h.GUID = guid }
+func (h *SystemGroupHook) setGUID(guid string)            { h.GUID = guid }
+func (h *SystemMemberHook) setGUID(guid string)           { h.GUID = guid }
+func (h *SystemRepositoryUpdateHook) setGUID(guid string) { h.GUID = guid }
+func (h *InstallationHook) setGUID(guid string)           { h.GUID = guid }
+func (h *InstallationRepositoryHook) setGUID(guid string) { h.GUID = guid }
+
+func (h *PingHook) setRaw(payload json.RawMessage)                   { h.Raw = payload }
+func (h *PushHook) setRaw(payload json.RawMessage)                   { h.Raw = payload }
+func (h *BranchHook) setRaw(payload json.RawMessage)                 { h.Raw = payload }
+func (h *DeployHook) setRaw(payload json.RawMessage)                 { h.Raw = payload }
+func (h *TagHook) setRaw(payload json.RawMessage)                    { h.Raw = payload }
+func (h *IssueHook) setRaw(payload json.RawMessage)                  { h.Raw = payload }
+func (h *IssueCommentHook) setRaw(payload json.RawMessage)           { h.Raw = payload }
+func (h *PullRequestHook) setRaw(payload json.RawMessage)            { h.Raw = payload }
+func (h *PullRequestCommentHook) setRaw(payload json.RawMessage)     { h.Raw = payload }
+func (h *ReviewHook) setRaw(payload json.RawMessage)                 { h.Raw = payload }
+func (h *ReviewCommentHook) setRaw(payload json.RawMessage)          { h.Raw = payload }
+func (h *LabelHook) setRaw(payload json.RawMessage)                  { h.Raw = payload }
+func (h *BranchProtectionRuleHook) setRaw(payload json.RawMessage)   { h.Raw = payload }
+func (h *SecurityAlertHook) setRaw(payload json.RawMessage)          { h.Raw = payload }
+func (h *MilestoneHook) setRaw(payload json.RawMessage)              { h.Raw = payload }
+func (h *StatusHook) setRaw(payload json.RawMessage)                 { h.Raw = payload }
+func (h *CheckRunHook) setRaw(payload json.RawMessage)               { h.Raw = payload }
+func (h *CheckSuiteHook) setRaw(payload json.RawMessage)             { h.Raw = payload }
+func (h *DeploymentStatusHook) setRaw(payload json.RawMessage)       { h.Raw = payload }
+func (h *ReleaseHook) setRaw(payload json.RawMessage)                { h.Raw = payload }
+func (h *RepositoryHook) setRaw(payload json.RawMessage)             { h.Raw = payload }
+func (h *ForkHook) setRaw(payload json.RawMessage)                   { h.Raw = payload }
+func (h *WatchHook) setRaw(payload json.RawMessage)                  { h.Raw = payload }
+func (h *StarHook) setRaw(payload json.RawMessage)                   { h.Raw = payload }
+func (h *SystemProjectHook) setRaw(payload json.RawMessage)          { h.Raw = payload }
+func (h *SystemUserHook) setRaw(payload json.RawMessage)             { h.Raw = payload }
+func (h *SystemGroupHook) setRaw(payload json.RawMessage)            { h.Raw = payload }
+func (h *SystemMemberHook) setRaw(payload json.RawMessage)           { h.Raw = payload }
+func (h *SystemRepositoryUpdateHook) setRaw(payload json.RawMessage) { h.Raw = payload }
+func (h *InstallationHook) setRaw(payload json.RawMessage)           { h.Raw = payload }
+func (h *InstallationRepositoryHook) setRaw(payload json.RawMessage) { h.Raw = payload }
+
 // GetInstallationRef returns the installation reference if the webhook is invoked on a
 // GitHub App
 func (h *PingHook) GetInstallationRef() *InstallationRef { return h.Installation }
@@ -572,6 +1138,18 @@ func (h *ReviewCommentHook) GetInstallationRef() *InstallationRef { return h.Ins
 // GitHub App
 func (h *LabelHook) GetInstallationRef() *InstallationRef { return h.Installation }
 
+// GetInstallationRef returns the installation reference if the webhook is invoked on a
+// GitHub App
+func (h *BranchProtectionRuleHook) GetInstallationRef() *InstallationRef { return h.Installation }
+
+// GetInstallationRef returns the installation reference if the webhook is invoked on a
+// GitHub App
+func (h *SecurityAlertHook) GetInstallationRef() *InstallationRef { return h.Installation }
+
+// GetInstallationRef returns the installation reference if the webhook is invoked on a
+// GitHub App
+func (h *MilestoneHook) GetInstallationRef() *InstallationRef { return h.Installation }
+
 // GetInstallationRef returns the installation reference if the webhook is invoked on a
 // GitHub App
 func (h *StatusHook) GetInstallationRef() *InstallationRef { return h.Installation }
@@ -606,7 +1184,27 @@ func (h *WatchHook) GetInstallationRef() *InstallationRef { return h.Installatio
 
 // GetInstallationRef returns the installation reference if the webhook is invoked on a
 // GitHub App
-func (h *StarHook) GetInstallationRef() *InstallationRef { return nil }
+func (h *StarHook) GetInstallationRef() *InstallationRef { return h.Installation }
+
+// GetInstallationRef returns the installation reference if the webhook is invoked on a
+// GitHub App
+func (h *SystemProjectHook) GetInstallationRef() *InstallationRef { return nil }
+
+// GetInstallationRef returns the installation reference if the webhook is invoked on a
+// GitHub App
+func (h *SystemUserHook) GetInstallationRef() *InstallationRef { return nil }
+
+// GetInstallationRef returns the installation reference if the webhook is invoked on a
+// GitHub App
+func (h *SystemGroupHook) GetInstallationRef() *InstallationRef { return nil }
+
+// GetInstallationRef returns the installation reference if the webhook is invoked on a
+// GitHub App
+func (h *SystemMemberHook) GetInstallationRef() *InstallationRef { return nil }
+
+// GetInstallationRef returns the installation reference if the webhook is invoked on a
+// GitHub App
+func (h *SystemRepositoryUpdateHook) GetInstallationRef() *InstallationRef { return nil }
 
 // GetInstallationRef returns the installation reference if the webhook is invoked on a
 // GitHub App
@@ -629,3 +1227,206 @@ func (h *InstallationRepositoryHook) GetInstallationRef() *InstallationRef {
 		ID: h.Installation.ID,
 	}
 }
+
+// Secrets adapts fn to a MultiSecretFunc returning its single
+// secret. An empty secret yields no candidate keys, in which
+// case no validation is performed.
+func Secrets(fn SecretFunc) MultiSecretFunc {
+	return func(webhook Webhook) ([]string, error) {
+		key, err := fn(webhook)
+		if err != nil || key == "" {
+			return nil, err
+		}
+		return []string{key}, nil
+	}
+}
+
+// ParseMulti returns the repository webhook payload parsed by the
+// service, validated against the candidate secrets. The services
+// not implementing MultiSecretParser parse the request once for
+// each candidate key, until one of them validates the webhook.
+func ParseMulti(s WebhookService, req *http.Request, fn MultiSecretFunc) (Webhook, error) {
+	if p, ok := s.(MultiSecretParser); ok {
+		return p.ParseMulti(req, fn)
+	}
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	// the candidate keys are requested once, on the first
+	// parse. The empty keys are skipped, but a webhook is not
+	// validated only if there is no candidate key at all.
+	var (
+		keys     []string
+		keysErr  error
+		resolved bool
+	)
+	for i := 0; ; i++ {
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		hook, err := s.Parse(req, func(webhook Webhook) (string, error) {
+			if !resolved {
+				resolved = true
+				candidates, err := fn(webhook)
+				for _, key := range candidates {
+					if key != "" {
+						keys = append(keys, key)
+					}
+				}
+				keysErr = err
+				if err == nil && len(candidates) != 0 && len(keys) == 0 {
+					keysErr = ErrSignatureInvalid
+				}
+			}
+			if keysErr != nil || i >= len(keys) {
+				return "", keysErr
+			}
+			return keys[i], nil
+		})
+		if err != ErrSignatureInvalid || i+1 >= len(keys) {
+			return hook, err
+		}
+	}
+}
+
+// ValidateAny reports whether validate accepts any of the
+// non-empty candidate keys.
+func ValidateAny(keys []string, validate func(key string) bool) bool {
+	for _, key := range keys {
+		if key != "" && validate(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// GUID returns the delivery identifier of the webhook, which
+// can be used to deduplicate retried deliveries, or an empty
+// string if the provider does not send one. The webhooks
+// defined outside of this package are read through their
+// GetGUID method or their exported GUID field.
+func GUID(hook Webhook) string {
+	if isNilHook(hook) {
+		return ""
+	}
+	if h, ok := hook.(interface{ GetGUID() string }); ok {
+		return h.GetGUID()
+	}
+	if f := guidField(hook); f.IsValid() {
+		return f.String()
+	}
+	return ""
+}
+
+// SetGUID sets the delivery identifier of a parsed webhook.
+// Drivers call it with the provider's delivery header so the
+// identifier is populated regardless of the event type. The
+// webhooks defined outside of this package are set through
+// their exported GUID field, and a nil webhook is ignored.
+func SetGUID(hook Webhook, guid string) {
+	if isNilHook(hook) {
+		return
+	}
+	if h, ok := hook.(guidSetter); ok {
+		h.setGUID(guid)
+	} else if f := guidField(hook); f.IsValid() && f.CanSet() {
+		f.SetString(guid)
+	}
+}
+
+// guidSetter is implemented by the webhooks with a delivery
+// identifier.
+type guidSetter interface {
+	setGUID(guid string)
+}
+
+// guidField returns the GUID string field of the webhook, or
+// the zero value if it has none.
+func guidField(hook Webhook) reflect.Value {
+	v := reflect.ValueOf(hook)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	f := v.Elem().FieldByName("GUID")
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return reflect.Value{}
+	}
+	return f
+}
+
+// isNilHook reports whether the webhook is nil or a nil
+// pointer.
+func isNilHook(hook Webhook) bool {
+	if hook == nil {
+		return true
+	}
+	v := reflect.ValueOf(hook)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// SetRaw sets the raw payload of a parsed webhook. Drivers
+// call it with the JSON payload when the RawPayload option is
+// set.
+func SetRaw(hook Webhook, payload []byte) {
+	if isNilHook(hook) {
+		return
+	}
+	if h, ok := hook.(rawSetter); ok {
+		h.setRaw(payload)
+	}
+}
+
+// rawSetter is implemented by the webhooks keeping their raw
+// payload.
+type rawSetter interface {
+	setRaw(payload json.RawMessage)
+}
+
+// ReadBody reads the body of a webhook request. It returns the
+// raw body, which signatures are calculated from, and the JSON
+// payload, which differs from the body for form deliveries.
+func (o WebhookServiceOptions) ReadBody(req *http.Request) (body, payload []byte, err error) {
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if len(o.ContentTypes) != 0 && !containsFold(o.ContentTypes, mediaType) {
+		return nil, nil, ErrContentTypeNotAllowed
+	}
+	max := o.MaxBodySize
+	if max <= 0 {
+		max = DefaultWebhookMaxBodySize
+	}
+	// the buffer is sized from the content length, so a large
+	// payload is read without growing and copying the buffer.
+	size := int64(0)
+	if req.ContentLength > 0 && req.ContentLength <= max {
+		size = req.ContentLength
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, size+bytes.MinRead))
+	if _, err := buf.ReadFrom(io.LimitReader(req.Body, max+1)); err != nil {
+		return nil, nil, err
+	}
+	body = buf.Bytes()
+	if int64(len(body)) > max {
+		return nil, nil, ErrPayloadTooLarge
+	}
+	if o.FormPayload && mediaType == "application/x-www-form-urlencoded" {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, nil, err
+		}
+		return body, []byte(values.Get("payload")), nil
+	}
+	return body, body, nil
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
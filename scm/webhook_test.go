@@ -0,0 +1,224 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSecrets(t *testing.T) {
+	keys, err := Secrets(func(Webhook) (string, error) { return "", nil })(nil)
+	if err != nil || len(keys) != 0 {
+		t.Errorf("Want no keys for an empty secret, got %v, %v", keys, err)
+	}
+
+	keys, err = Secrets(func(Webhook) (string, error) { return "topsecret", nil })(nil)
+	if err != nil || len(keys) != 1 || keys[0] != "topsecret" {
+		t.Errorf("Want the single secret, got %v, %v", keys, err)
+	}
+
+	want := errors.New("boom")
+	if _, err = Secrets(func(Webhook) (string, error) { return "", want })(nil); err != want {
+		t.Errorf("Want error %v, got %v", want, err)
+	}
+}
+
+func TestValidateAny(t *testing.T) {
+	match := func(key string) bool { return key == "topsecret" }
+	tests := []struct {
+		keys []string
+		want bool
+	}{
+		{nil, false},
+		{[]string{"void"}, false},
+		{[]string{"void", "topsecret"}, true},
+		{[]string{"", "topsecret"}, true},
+		{[]string{""}, false},
+	}
+	for _, test := range tests {
+		if got := ValidateAny(test.keys, match); got != test.want {
+			t.Errorf("Want ValidateAny(%v) %v, got %v", test.keys, test.want, got)
+		}
+	}
+	if ValidateAny([]string{""}, func(string) bool { return true }) {
+		t.Errorf("Want empty keys skipped")
+	}
+}
+
+// secretService is a WebhookService without ParseMulti, which
+// accepts the webhooks whose body is the secret.
+type secretService struct{}
+
+func (secretService) Parse(req *http.Request, fn SecretFunc) (Webhook, error) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	hook := &PushHook{Ref: string(body)}
+	key, err := fn(hook)
+	if err != nil || key == "" {
+		return hook, err
+	}
+	if key != string(body) {
+		return hook, ErrSignatureInvalid
+	}
+	return hook, nil
+}
+
+func TestParseMulti(t *testing.T) {
+	tests := []struct {
+		keys []string
+		err  error
+	}{
+		{nil, nil},
+		{[]string{"topsecret"}, nil},
+		{[]string{"void", "topsecret"}, nil},
+		{[]string{"", "topsecret"}, nil},
+		{[]string{"void"}, ErrSignatureInvalid},
+		{[]string{""}, ErrSignatureInvalid},
+	}
+	for _, test := range tests {
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("topsecret"))
+		calls := 0
+		hook, err := ParseMulti(secretService{}, r, func(Webhook) ([]string, error) {
+			calls++
+			return test.keys, nil
+		})
+		if err != test.err {
+			t.Errorf("Want ParseMulti(%v) error %v, got %v", test.keys, test.err, err)
+		}
+		if hook == nil || hook.(*PushHook).Ref != "topsecret" {
+			t.Errorf("Want the body parsed for every key, got %v", hook)
+		}
+		if calls != 1 {
+			t.Errorf("Want the keys requested once, got %d calls", calls)
+		}
+	}
+}
+
+func TestPushHookRefs(t *testing.T) {
+	tests := []struct {
+		hook     PushHook
+		tag      bool
+		deletion bool
+		branch   string
+		name     string
+	}{
+		{PushHook{Ref: "refs/heads/master"}, false, false, "master", ""},
+		{PushHook{Ref: "refs/heads/feature/x", Deleted: true}, false, true, "feature/x", ""},
+		{PushHook{Ref: "refs/tags/v1.0.0"}, true, false, "", "v1.0.0"},
+		{PushHook{Ref: "refs/tags/v1.0.0", Deleted: true}, true, false, "", "v1.0.0"},
+		{PushHook{Ref: "master"}, false, false, "master", ""},
+	}
+	for _, test := range tests {
+		h := test.hook
+		if got, want := h.IsTag(), test.tag; got != want {
+			t.Errorf("Want IsTag %v for %q, got %v", want, h.Ref, got)
+		}
+		if got, want := h.IsBranchDeletion(), test.deletion; got != want {
+			t.Errorf("Want IsBranchDeletion %v for %q, got %v", want, h.Ref, got)
+		}
+		if got, want := h.Branch(), test.branch; got != want {
+			t.Errorf("Want branch %q for %q, got %q", want, h.Ref, got)
+		}
+		if got, want := h.Tag(), test.name; got != want {
+			t.Errorf("Want tag %q for %q, got %q", want, h.Ref, got)
+		}
+	}
+}
+
+func TestSetGUID(t *testing.T) {
+	hooks := []Webhook{
+		&PushHook{},
+		&IssueHook{},
+		&SystemUserHook{},
+	}
+	for _, hook := range hooks {
+		SetGUID(hook, "f2e4a1b8")
+		if got, want := GUID(hook), "f2e4a1b8"; got != want {
+			t.Errorf("Want guid %q for %s, got %q", want, hook.Kind(), got)
+		}
+	}
+	// a webhook defined outside of this package is set through
+	// its GUID field.
+	external := &externalHook{}
+	SetGUID(external, "f2e4a1b8")
+	if got, want := GUID(external), "f2e4a1b8"; got != want {
+		t.Errorf("Want guid %q for an external webhook, got %q", want, got)
+	}
+	// a nil hook is ignored.
+	SetGUID((*PushHook)(nil), "f2e4a1b8")
+	SetGUID(nil, "f2e4a1b8")
+	if got := GUID((*PushHook)(nil)); got != "" {
+		t.Errorf("Want no guid for a nil webhook, got %q", got)
+	}
+}
+
+type externalHook struct {
+	GUID string
+}
+
+func (h *externalHook) Repository() Repository               { return Repository{} }
+func (h *externalHook) GetInstallationRef() *InstallationRef { return nil }
+func (h *externalHook) Kind() WebhookKind                    { return WebhookKindPush }
+
+func TestSetRaw(t *testing.T) {
+	hook := &PushHook{}
+	SetRaw(hook, []byte(`{"ref":"refs/heads/master"}`))
+	if got, want := string(hook.Raw), `{"ref":"refs/heads/master"}`; got != want {
+		t.Errorf("Want raw payload %s, got %s", want, got)
+	}
+	review := &ReviewHook{}
+	SetRaw(review, []byte(`{"action":"submitted"}`))
+	if got, want := string(review.Raw), `{"action":"submitted"}`; got != want {
+		t.Errorf("Want raw payload %s, got %s", want, got)
+	}
+}
+
+func TestWebhookServiceOptions_ReadBody(t *testing.T) {
+	newRequest := func(contentType, body string) *http.Request {
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		return r
+	}
+
+	body, payload, err := WebhookServiceOptions{}.ReadBody(newRequest("application/json", `{"ref":"master"}`))
+	if err != nil || string(body) != `{"ref":"master"}` || string(payload) != string(body) {
+		t.Errorf("Want the body as the payload, got %q, %q, %v", body, payload, err)
+	}
+
+	opts := WebhookServiceOptions{MaxBodySize: 4}
+	if _, _, err := opts.ReadBody(newRequest("application/json", "{}")); err != nil {
+		t.Errorf("Want a body within the limit to be read, got %v", err)
+	}
+	if _, _, err := opts.ReadBody(newRequest("application/json", `{"a":1}`)); err != ErrPayloadTooLarge {
+		t.Errorf("Want error %v, got %v", ErrPayloadTooLarge, err)
+	}
+
+	opts = WebhookServiceOptions{ContentTypes: []string{"application/json"}}
+	if _, _, err := opts.ReadBody(newRequest("application/json; charset=utf-8", "{}")); err != nil {
+		t.Errorf("Want an allowed content type to be read, got %v", err)
+	}
+	if _, _, err := opts.ReadBody(newRequest("text/plain", "{}")); err != ErrContentTypeNotAllowed {
+		t.Errorf("Want error %v, got %v", ErrContentTypeNotAllowed, err)
+	}
+
+	form := "payload=" + url.QueryEscape(`{"ref":"master"}`)
+	body, payload, err = WebhookServiceOptions{FormPayload: true}.ReadBody(newRequest("application/x-www-form-urlencoded", form))
+	if err != nil || string(body) != form || string(payload) != `{"ref":"master"}` {
+		t.Errorf("Want the form payload field, got %q, %q, %v", body, payload, err)
+	}
+
+	// form payloads are not decoded unless enabled.
+	_, payload, _ = WebhookServiceOptions{}.ReadBody(newRequest("application/x-www-form-urlencoded", form))
+	if string(payload) != form {
+		t.Errorf("Want the raw form as the payload, got %q", payload)
+	}
+}